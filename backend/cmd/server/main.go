@@ -48,11 +48,15 @@ import (
 	"time"
 
 	// Internal packages
-	appdep "templatev25/internal/app"  // Dependency injection container
-	"templatev25/internal/db"          // Database connection (GORM + PostgreSQL)
-	"templatev25/internal/http/router" // HTTP route definitions
-	"templatev25/internal/middleware"  // HTTP middlewares
-	"templatev25/internal/repository"  // Repository layer
+	appdep "templatev25/internal/app"         // Dependency injection container
+	localconfig "templatev25/internal/config" // Server lifecycle config, shared config validation
+	"templatev25/internal/db"                 // Database connection (GORM + PostgreSQL)
+	"templatev25/internal/http/router"        // HTTP route definitions
+	"templatev25/internal/i18n"               // Error message translations
+	"templatev25/internal/jobs"               // Background jobs (cleanup)
+	ijson "templatev25/internal/json"         // JSON encoder/decoder (-tags sonic)
+	"templatev25/internal/middleware"         // HTTP middlewares
+	"templatev25/internal/repository"         // Repository layer
 
 	// External packages
 	"git.gerege.mn/backend-packages/config"               // Configuration loading (Viper)
@@ -86,14 +90,35 @@ import (
 //  6. Fiber app setup
 //  7. Middlewares setup
 //  8. App logic (Service/Repository) setup
-//  9. Server start
-//  10. Graceful shutdown
+//  9. Background jobs (cleanup)
+//  10. Server start
+//  11. Graceful shutdown (DRAIN_TIMEOUT → SHUTDOWN_TIMEOUT)
 func main() {
 	// ============================================================
 	// STEP 1: Configuration ачаалах
 	// ============================================================
 	cfg := config.Load(".")
-	if err := cfg.Validate(); err != nil {
+	if err := localconfig.Validate(&cfg); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	srvCfg := localconfig.LoadServerConfig()
+	if err := srvCfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	sessCfg := localconfig.LoadSessionConfig()
+	if err := sessCfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	cleanupCfg := localconfig.LoadCleanupConfig()
+	if err := cleanupCfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	dbCfg := localconfig.LoadDBConfig()
+	if err := dbCfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	mailCfg := localconfig.LoadMailConfig()
+	if err := mailCfg.Validate(); err != nil {
 		log.Fatalf("invalid configuration: %v", err)
 	}
 
@@ -118,10 +143,31 @@ func main() {
 	// ============================================================
 	// STEP 4: Database холболт
 	// ============================================================
-	gormDB, err := db.NewPostgres(cfg)
+	// DB_SLOW_QUERY_THRESHOLD-оос удаан query → WARN + db_slow_queries_total
+	sqlLogger, err := db.NewSlowQueryLogger(logg, dbCfg.SlowQueryThreshold, provider)
+	if err != nil {
+		logg.Fatal("db logger init failed", zap.Error(err))
+	}
+	gormDB, err := db.NewPostgres(cfg, sqlLogger)
 	if err != nil {
 		logg.Fatal("db init failed", zap.Error(err))
 	}
+	// Connection pool gauge-ууд (/metrics)
+	if err := db.RegisterPoolMetrics(gormDB, provider); err != nil {
+		logg.Warn("db pool metrics disabled", zap.Error(err))
+	}
+	// Request тутмын query тоо/хугацааг access log-д (db_query_count, db_total_ms)
+	if cfg.Server.ENV == "development" {
+		if err := gormDB.Use(db.NewQueryMetricsPlugin()); err != nil {
+			logg.Warn("db query metrics disabled", zap.Error(err))
+		}
+	}
+	// Устгах боломжгүй system role-ууд (SUPER_ADMIN, ADMIN) байгаа эсэхийг баталгаажуулна
+	if n, err := db.SeedSystemRoles(context.Background(), gormDB); err != nil {
+		logg.Warn("system roles seed failed", zap.Error(err))
+	} else if n > 0 {
+		logg.Info("system roles seeded", zap.Int64("inserted", n))
+	}
 
 	// ============================================================
 	// STEP 5: Swagger documentation тохируулах
@@ -135,24 +181,52 @@ func main() {
 	// ============================================================
 	// STEP 6: Fiber application үүсгэх
 	// ============================================================
+	// SERVER_MAX_BODY_SIZE-ээс том body-г 413 JSON алдаагаар татгалзана
+	// JSON encoder/decoder нь build tag-аар сонгогдоно (default encoding/json, -tags sonic)
+	// 4xx/5xx response бүр http_errors_total{status, route}-д тоологдоно
+	// Accept-Language-аар алдааны message-ийг орчуулна (en, mn)
+	translator, err := i18n.New()
+	if err != nil {
+		logg.Fatal("i18n init failed", zap.Error(err))
+	}
+	errorMetrics, err := middleware.NewErrorMetrics(provider.Meter("templatev25/http"))
+	if err != nil {
+		logg.Fatal("error metrics init failed", zap.Error(err))
+	}
 	app := fiber.New(fiber.Config{
-		AppName:      cfg.Server.Name,
-		ErrorHandler: middleware.ErrorHandler(logg),
+		AppName:   cfg.Server.Name,
+		BodyLimit: int(srvCfg.MaxBodySize),
+		ErrorHandler: errorMetrics.WrapErrorHandler(
+			middleware.BodySizeErrorHandler(srvCfg.MaxBodySize, middleware.ErrorHandler(logg, translator)),
+		),
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  cfg.Server.IdleTimeout,
+		JSONEncoder:  ijson.Marshal,
+		JSONDecoder:  ijson.Unmarshal,
 	})
+	logg.Info("json_codec_selected", zap.String("codec", ijson.Name))
 
 	// Add Prometheus middleware
 	prometheusMiddleware := fiberprometheus.New(cfg.Server.Name)
 	prometheusMiddleware.RegisterAt(app, "/metrics")
 	app.Use(prometheusMiddleware.Middleware)
 
+	// Route template-ээр (/user/:id) label-тай latency histogram (http_request_duration_seconds)
+	app.Use(middleware.RouteLatency())
+
+	// Handler шууд бичсэн 4xx/5xx хариуг ч эцсийн status-аар тоолно
+	app.Use(errorMetrics.Handler())
+
 	// ============================================================
 	// STEP 7: Middlewares идэвхжүүлэх
 	// ============================================================
 	apiLogRepo := repository.NewAPILogRepositoryWithConfig(gormDB, &cfg)
-	ihttp.ApplyMiddlewares(app, &cfg, logg, apiLogRepo)
+	ihttp.ApplyMiddlewares(app, &cfg, ihttp.MiddlewareConfig{
+		Server: srvCfg,
+		CORS:   localconfig.LoadCORSConfig(),
+		Log:    localconfig.LoadLogConfig(),
+	}, logg, apiLogRepo)
 
 	// ============================================================
 	// STEP 8: Auth cache үүсгэх
@@ -162,13 +236,27 @@ func main() {
 	// ============================================================
 	// STEP 9: Dependencies inject хийх
 	// ============================================================
-	deps := appdep.NewDependencies(gormDB, &cfg, logg, authCache)
+	deps := appdep.NewDependencies(
+		appdep.WithDB(gormDB),
+		appdep.WithConfig(&cfg),
+		appdep.WithLogger(logg),
+		appdep.WithAuthCache(authCache),
+		appdep.WithSessionConfig(sessCfg),
+		appdep.WithMailConfig(mailCfg),
+		appdep.WithServerConfig(srvCfg),
+	)
 
 	// ============================================================
 	// STEP 10: Routes бүртгэх
 	// ============================================================
 	router.MapV1(app, deps)
 
+	// Хугацаа дууссан session, хуучин login history-г CLEANUP_INTERVAL тутам устгана
+	cleanupJob := jobs.NewCleanupJob(deps.Repo.Auth, cleanupCfg, logg)
+	if cleanupCfg.Enabled {
+		cleanupJob.Start()
+	}
+
 	// ============================================================
 	// STEP 11: Server эхлүүлэх (non-blocking)
 	// ============================================================
@@ -190,7 +278,15 @@ func main() {
 	// ============================================================
 	// STEP 13: Server зогсоох
 	// ============================================================
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// DRAIN_TIMEOUT хугацаанд шинэ хүсэлт хүлээн авсаар байна
+	// (load balancer энэ instance руу чиглүүлэхээ болих хүртэл)
+	if srvCfg.DrainTimeout > 0 {
+		logg.Info("draining before shutdown", zap.Duration("drain_timeout", srvCfg.DrainTimeout))
+		time.Sleep(srvCfg.DrainTimeout)
+	}
+
+	// SHUTDOWN_TIMEOUT хүртэл in-flight хүсэлтүүдийг дуусгахыг хүлээнэ
+	ctx, cancel := context.WithTimeout(context.Background(), srvCfg.ShutdownTimeout)
 	defer cancel()
 
 	if err := app.ShutdownWithContext(ctx); err != nil {
@@ -200,6 +296,12 @@ func main() {
 	// ============================================================
 	// STEP 14: Resources cleanup
 	// ============================================================
+	// Ажиллаж буй cleanup-ийг DB хаагдахаас өмнө зогсооно
+	cleanupJob.Stop()
+
+	// Buffer-т үлдсэн event-үүдийг DB хаагдахаас өмнө хүргэнэ
+	deps.Events.Close()
+
 	if sqlDB, err := gormDB.DB(); err == nil {
 		_ = sqlDB.Close()
 	}
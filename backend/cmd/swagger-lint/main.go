@@ -0,0 +1,307 @@
+// Package main provides implementation for swagger-lint
+//
+// File: main.go
+// Description: Route handler-уудын Swagger godoc annotation шалгагч
+/*
+Package main нь router-т бүртгэгдсэн handler бүр swag-ийн шаардлагатай
+annotation-тэй эсэхийг Go AST-аар шалгана.
+
+Шалгах дүрэм:
+  - @Summary, @Tags, @Success: бүх handler-т заавал
+  - @Param: route path-д параметр (":id" гэх мэт) байвал заавал
+
+Route-ийг дараах хэлбэрээр таньна:
+
+	h := handlers.NewNewsHandler(d)
+	router.Get("/:id", auth.RequirePermission(perm, "..."), h.Get)
+
+Сүүлийн аргумент (h.Get) нь handler бөгөөд h-ийн төрөл нь
+handlers package дахь NewNewsHandler constructor-ийн буцаах төрлөөс (*NewsHandler) тодорхойлогдоно.
+
+Ашиглалт:
+
+	go run ./cmd/swagger-lint -router internal/http/router -handlers internal/http/handlers
+
+router.go дахь //go:generate-ээр `go generate ./internal/http/router/` ажиллуулна.
+Annotation дутуу handler байвал file:line байршлыг хэвлээд 1 кодоор гарна.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// routeMethods нь Fiber router-ийн route бүртгэх методууд
+var routeMethods = map[string]bool{
+	"Get": true, "Post": true, "Put": true, "Patch": true,
+	"Delete": true, "Head": true, "Options": true, "All": true,
+}
+
+// route нь router-т бүртгэгдсэн нэг handler
+type route struct {
+	Method  string // HTTP method (GET, POST, ...)
+	Path    string // Group доторх path
+	Handler string // "NewsHandler.Get"
+}
+
+// finding нь annotation дутуу handler
+type finding struct {
+	Pos     token.Position
+	Route   route
+	Missing []string
+}
+
+func (f finding) String() string {
+	return fmt.Sprintf("%s: %s (%s %s) missing %s",
+		f.Pos, f.Route.Handler, f.Route.Method, f.Route.Path, strings.Join(f.Missing, ", "))
+}
+
+func main() {
+	routerDir := flag.String("router", "internal/http/router", "router package directory")
+	handlersDir := flag.String("handlers", "internal/http/handlers", "handlers package directory")
+	flag.Parse()
+
+	findings, err := lint(*routerDir, *handlersDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "swagger-lint:", err)
+		os.Exit(2)
+	}
+	for _, f := range findings {
+		fmt.Println(f)
+	}
+	if len(findings) > 0 {
+		fmt.Fprintf(os.Stderr, "swagger-lint: %d handler(s) missing swagger annotations\n", len(findings))
+		os.Exit(1)
+	}
+}
+
+// lint нь routerDir-ийн route-уудыг handlersDir-ийн method-уудтай тулгаж шалгана
+func lint(routerDir, handlersDir string) ([]finding, error) {
+	fset := token.NewFileSet()
+
+	routerFiles, err := parseDir(fset, routerDir, 0)
+	if err != nil {
+		return nil, err
+	}
+	handlerFiles, err := parseDir(fset, handlersDir, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := collectRoutes(routerFiles, collectConstructors(handlerFiles))
+	return check(fset, routes, collectMethods(handlerFiles)), nil
+}
+
+// parseDir нь _test.go-оос бусад .go файлуудыг parse хийнэ
+func parseDir(fset *token.FileSet, dir string, mode parser.Mode) ([]*ast.File, error) {
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, mode)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// collectConstructors нь "NewXxx" → буцаах төрөл ("Xxx") map буцаана.
+// Зөвхөн *T эсвэл T буцаадаг package-level функцуудыг авна.
+func collectConstructors(files []*ast.File) map[string]string {
+	ctors := map[string]string{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "New") {
+				continue
+			}
+			if fn.Type.Results == nil || len(fn.Type.Results.List) == 0 {
+				continue
+			}
+			if typ := receiverType(fn.Type.Results.List[0].Type); typ != "" {
+				ctors[fn.Name.Name] = typ
+			}
+		}
+	}
+	return ctors
+}
+
+// collectRoutes нь `x := handlers.NewXxx(...)` холболт болон
+// `r.Get("/path", ..., x.Method)` дуудлагуудаас route-уудыг цуглуулна.
+// Хувьсагчийн холболт нь source дарааллаар шинэчлэгдэнэ (group бүрт h дахин оноогддог).
+func collectRoutes(files []*ast.File, ctors map[string]string) []route {
+	var routes []route
+	for _, file := range files {
+		vars := map[string]string{} // хувьсагч → handler төрөл
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for i, rhs := range n.Rhs {
+					if i >= len(n.Lhs) {
+						break
+					}
+					ident, ok := n.Lhs[i].(*ast.Ident)
+					if !ok {
+						continue
+					}
+					if typ := constructorType(rhs, ctors); typ != "" {
+						vars[ident.Name] = typ
+					}
+				}
+			case *ast.CallExpr:
+				if r, ok := routeOf(n, vars); ok {
+					routes = append(routes, r)
+				}
+			}
+			return true
+		})
+	}
+	return routes
+}
+
+// constructorType нь `handlers.NewXxx(...)` дуудлагаас constructor-ийн буцаах төрлийг олно
+func constructorType(expr ast.Expr, ctors map[string]string) string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	var name string
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		name = fn.Sel.Name
+	case *ast.Ident:
+		name = fn.Name
+	default:
+		return ""
+	}
+	return ctors[name]
+}
+
+// routeOf нь call нь handler бүртгэж буй route эсэхийг шалгана
+func routeOf(call *ast.CallExpr, vars map[string]string) (route, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !routeMethods[sel.Sel.Name] || len(call.Args) < 2 {
+		return route{}, false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return route{}, false
+	}
+	path, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return route{}, false
+	}
+	h, ok := call.Args[len(call.Args)-1].(*ast.SelectorExpr)
+	if !ok {
+		return route{}, false
+	}
+	recv, ok := h.X.(*ast.Ident)
+	if !ok {
+		return route{}, false
+	}
+	typ, ok := vars[recv.Name]
+	if !ok {
+		return route{}, false
+	}
+	return route{
+		Method:  strings.ToUpper(sel.Sel.Name),
+		Path:    path,
+		Handler: typ + "." + h.Sel.Name,
+	}, true
+}
+
+// collectMethods нь "Type.Method" → method declaration map буцаана
+func collectMethods(files []*ast.File) map[string]*ast.FuncDecl {
+	methods := map[string]*ast.FuncDecl{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			if typ := receiverType(fn.Recv.List[0].Type); typ != "" {
+				methods[typ+"."+fn.Name.Name] = fn
+			}
+		}
+	}
+	return methods
+}
+
+// receiverType нь *T эсвэл T receiver-ийн нэрийг буцаана
+func receiverType(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// requiredTags нь route-д шаардлагатай annotation-ууд
+func requiredTags(r route) []string {
+	tags := []string{"@Summary", "@Tags"}
+	if strings.Contains(r.Path, ":") {
+		tags = append(tags, "@Param")
+	}
+	return append(tags, "@Success")
+}
+
+// check нь route бүрийн handler-ийн doc comment-ийг шалгана.
+// Олдоогүй method (embedded төрлөөс promote хийгдсэн гэх мэт)-ийг алгасна.
+// Нэг handler олон route-д бүртгэгдсэн бол нэг л удаа мэдээлнэ.
+func check(fset *token.FileSet, routes []route, methods map[string]*ast.FuncDecl) []finding {
+	var findings []finding
+	seen := map[string]bool{}
+	for _, r := range routes {
+		fn, ok := methods[r.Handler]
+		if !ok || seen[r.Handler] {
+			continue
+		}
+		seen[r.Handler] = true
+
+		var missing []string
+		for _, tag := range requiredTags(r) {
+			if !hasTag(fn.Doc, tag) {
+				missing = append(missing, tag)
+			}
+		}
+		if len(missing) > 0 {
+			findings = append(findings, finding{Pos: fset.Position(fn.Pos()), Route: r, Missing: missing})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i].Pos, findings[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Line < b.Line
+	})
+	return findings
+}
+
+// hasTag нь doc comment-д "// @Tag ..." мөр байгаа эсэхийг шалгана
+func hasTag(doc *ast.CommentGroup, tag string) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if text == tag || strings.HasPrefix(text, tag+" ") || strings.HasPrefix(text, tag+"\t") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+// Package main provides implementation for swagger-lint
+//
+// File: main_test.go
+// Description: Unit tests for route/annotation AST parsing
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHandlersSrc = `package handlers
+
+type NewsHandler struct{}
+type IconGroupHandler struct{}
+
+func NewNewsHandler() *NewsHandler { return &NewsHandler{} }
+
+// Constructor нэр нь төрлийн нэрээс өөр
+func NewGroupHandler() *IconGroupHandler { return &IconGroupHandler{} }
+
+// List godoc
+// @Summary      List news
+// @Tags         news
+// @Success      200 {object} map[string]interface{}
+func (h *NewsHandler) List() {}
+
+// Get godoc
+// @Summary      Get news
+// @Tags         news
+// @Param        id path int true "ID"
+// @Success      200 {object} map[string]interface{}
+func (h *NewsHandler) Get() {}
+
+// GET /news/:id/full
+func (h *NewsHandler) Full() {}
+
+// Delete godoc
+// @SummaryX     not a summary tag
+// @Tags         news
+// @Param        id path int true "ID"
+// @Success      200 {object} map[string]interface{}
+func (h *NewsHandler) Delete() {}
+
+func (h *IconGroupHandler) List() {}
+`
+
+const testRouterSrc = `package router
+
+func Map(v1 Router) {
+	v1.Group("/news").Route("", func(router Router) {
+		h := handlers.NewNewsHandler()
+		router.Get("/", h.List)
+		router.Get("/:id", auth.RequirePermission(perm, "news.read"), h.Get)
+		router.Get("/:id/full", h.Full)
+		router.Delete("/:id", h.Delete)
+		router.Get("/ws", websocket.New(h.List))
+		router.Use(h.List)
+	})
+	v1.Group("/group").Route("", func(router Router) {
+		h := handlers.NewGroupHandler()
+		router.Get("/", h.List)
+	})
+}
+`
+
+func parseSrc(t *testing.T, fset *token.FileSet, name, src string) *ast.File {
+	t.Helper()
+	f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+	require.NoError(t, err)
+	return f
+}
+
+func TestCollectRoutes(t *testing.T) {
+	fset := token.NewFileSet()
+	handlers := parseSrc(t, fset, "handlers.go", testHandlersSrc)
+	router := parseSrc(t, fset, "router.go", testRouterSrc)
+
+	ctors := collectConstructors([]*ast.File{handlers})
+	assert.Equal(t, map[string]string{
+		"NewNewsHandler":  "NewsHandler",
+		"NewGroupHandler": "IconGroupHandler",
+	}, ctors)
+
+	routes := collectRoutes([]*ast.File{router}, ctors)
+	assert.Equal(t, []route{
+		{Method: "GET", Path: "/", Handler: "NewsHandler.List"},
+		{Method: "GET", Path: "/:id", Handler: "NewsHandler.Get"},
+		{Method: "GET", Path: "/:id/full", Handler: "NewsHandler.Full"},
+		{Method: "DELETE", Path: "/:id", Handler: "NewsHandler.Delete"},
+		// h дахин оноогдоход шинэ төрөлд холбогдоно
+		{Method: "GET", Path: "/", Handler: "IconGroupHandler.List"},
+	}, routes)
+}
+
+func TestCheck(t *testing.T) {
+	fset := token.NewFileSet()
+	handlers := parseSrc(t, fset, "handlers.go", testHandlersSrc)
+	router := parseSrc(t, fset, "router.go", testRouterSrc)
+
+	routes := collectRoutes([]*ast.File{router}, collectConstructors([]*ast.File{handlers}))
+	findings := check(fset, routes, collectMethods([]*ast.File{handlers}))
+
+	require.Len(t, findings, 3)
+
+	assert.Equal(t, "NewsHandler.Full", findings[0].Route.Handler)
+	assert.Equal(t, []string{"@Summary", "@Tags", "@Param", "@Success"}, findings[0].Missing)
+	assert.Equal(t, "handlers.go", findings[0].Pos.Filename)
+	assert.Equal(t, 25, findings[0].Pos.Line)
+
+	// "@SummaryX" нь @Summary гэж тооцогдохгүй
+	assert.Equal(t, "NewsHandler.Delete", findings[1].Route.Handler)
+	assert.Equal(t, []string{"@Summary"}, findings[1].Missing)
+
+	// Path параметргүй route-д @Param шаардлагагүй
+	assert.Equal(t, "IconGroupHandler.List", findings[2].Route.Handler)
+	assert.Equal(t, []string{"@Summary", "@Tags", "@Success"}, findings[2].Missing)
+
+	assert.Equal(t, "handlers.go:25:1: NewsHandler.Full (GET /:id/full) missing @Summary, @Tags, @Param, @Success", findings[0].String())
+}
+
+func TestRequiredTags(t *testing.T) {
+	assert.Equal(t, []string{"@Summary", "@Tags", "@Success"}, requiredTags(route{Path: "/"}))
+	assert.Equal(t, []string{"@Summary", "@Tags", "@Param", "@Success"}, requiredTags(route{Path: "/:id/users"}))
+}
+
+func TestLint_Dirs(t *testing.T) {
+	dir := t.TempDir()
+	routerDir := filepath.Join(dir, "router")
+	handlersDir := filepath.Join(dir, "handlers")
+	require.NoError(t, os.Mkdir(routerDir, 0o755))
+	require.NoError(t, os.Mkdir(handlersDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(routerDir, "router.go"), []byte(testRouterSrc), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(handlersDir, "handlers.go"), []byte(testHandlersSrc), 0o644))
+	// _test.go файлууд алгасагдана
+	require.NoError(t, os.WriteFile(filepath.Join(handlersDir, "x_test.go"), []byte("package handlers\nfunc (h *NewsHandler) Full() {}\n"), 0o644))
+
+	findings, err := lint(routerDir, handlersDir)
+	require.NoError(t, err)
+	assert.Len(t, findings, 3)
+
+	_, err = lint(filepath.Join(dir, "missing"), handlersDir)
+	assert.Error(t, err)
+}
+
+// TestLint_Repository нь repo-ийн бүх route handler annotation-тэй эсэхийг шалгана
+func TestLint_Repository(t *testing.T) {
+	findings, err := lint("../../internal/http/router", "../../internal/http/handlers")
+	require.NoError(t, err)
+	for _, f := range findings {
+		t.Error(f)
+	}
+}
@@ -38,7 +38,12 @@ Dependency Graph:
 
 Ашиглалт:
 
-	deps := app.NewDependencies(db, cfg, logger, authCache)
+	deps := app.NewDependencies(
+		app.WithDB(db),
+		app.WithConfig(cfg),
+		app.WithLogger(logger),
+		app.WithAuthCache(authCache),
+	)
 	router.MapV1(app, deps)
 */
 package app
@@ -49,7 +54,10 @@ import (
 	"git.gerege.mn/backend-packages/config"     // Application configuration
 	"git.gerege.mn/backend-packages/sso-client" // SSO client
 	"templatev25/internal/auth"                 // Permission cache
+	"templatev25/internal/event"                // Event bus
+	"templatev25/internal/health"               // Health checkers
 	localconfig "templatev25/internal/config"   // Local auth config
+	"templatev25/internal/middleware"           // Idempotency store
 	"templatev25/internal/repository"           // Data access layer
 	"templatev25/internal/service"              // Business logic layer
 
@@ -86,6 +94,16 @@ type Dependencies struct {
 	// Environment variables, .env файлаас уншсан тохиргоо.
 	Cfg *config.Config
 
+	// SessionCfg нь SSO session auto-refresh тохиргоо (AUTH_REFRESH_THRESHOLD, COOKIE_*).
+	// Shared config-д байхгүй тул internal/config-оос ачаална.
+	SessionCfg *localconfig.SessionConfig
+
+	// MailCfg нь SMTP тохиргоо (SMTP_*). Host хоосон бол email зөвхөн log-д бичигдэнэ.
+	MailCfg *localconfig.MailConfig
+
+	// ServerCfg нь main.go-д баталгаажуулсан SERVER_* тохиргоо (INTERNAL_API_SECRET г.м.).
+	ServerCfg *localconfig.ServerConfig
+
 	// AuthCache нь session cache.
 	// SSO-оос ирсэн session-уудыг LRU cache-д хадгална.
 	// Дахин SSO руу request илгээхгүйгээр session validate хийнэ.
@@ -100,6 +118,25 @@ type Dependencies struct {
 	// auth.RequirePermission middleware-д дамжуулна.
 	PermCache *auth.PermissionCache
 
+	// RoleCache нь хэрэглэгчийн role кодын cache.
+	// auth.RequireRole middleware-д дамжуулна.
+	RoleCache *auth.RoleCache
+
+	// Idempotency нь X-Idempotency-Key-тэй POST request-ийн хариуг хадгална.
+	// middleware.Idempotency-д дамжуулна (Redis-д хадгалагдана).
+	Idempotency middleware.IdempotencyStore
+
+	// SignatureNonces нь ашиглагдсан HMAC гарын үсгийг skew цонхны хугацаанд
+	// хадгална (POST /user/sync replay хамгаалалт, Redis SET NX EX).
+	SignatureNonces middleware.SignatureNonceStore
+
+	// HealthCheckers нь GET /health-ийн дэд шалгалтууд (database, sso, redis).
+	HealthCheckers []health.HealthChecker
+
+	// Events нь service-үүдийн дагалдах үйлдлүүдийг (мэдэгдэл гэх мэт) салгах event bus.
+	// Shutdown үед Close() дуудаж үлдсэн event-үүдийг хүргэнэ.
+	Events *event.InMemoryBus
+
 	// Repo нь бүх repository-уудыг агуулна.
 	// Database CRUD operations.
 	Repo *RepoContainer
@@ -146,6 +183,10 @@ type RepoContainer struct {
 	// Tables: email_verification_tokens, password_reset_tokens
 	Registration repository.RegistrationRepository
 
+	// EmailVerification нь 6 оронтой email баталгаажуулах код.
+	// Table: email_verifications
+	EmailVerification repository.EmailVerificationRepository
+
 	// ============================================================
 	// SYSTEM & MODULE REPOSITORIES
 	// ============================================================
@@ -228,6 +269,14 @@ type RepoContainer struct {
 	// Table: news
 	News repository.NewsRepository
 
+	// NewsCategory нь мэдээний ангиллын CRUD operations.
+	// Table: news_categories
+	NewsCategory repository.NewsCategoryRepository
+
+	// File нь upload хийсэн файлын storage (local disk).
+	// - News attachment
+	File repository.FileRepository
+
 	// ChatItem нь chat item-ийн CRUD operations.
 	// Table: chat_items
 	ChatItem repository.ChatItemRepository
@@ -235,6 +284,10 @@ type RepoContainer struct {
 	// APILog нь API log-ийн CRUD operations.
 	// Table: logs
 	APILog repository.APILogRepository
+
+	// FeatureFlag нь системийн feature flag-ууд (middleware.FeatureGate).
+	// Table: feature_flags
+	FeatureFlag repository.FeatureFlagRepository
 }
 
 // ============================================================
@@ -282,6 +335,10 @@ type ServiceContainer struct {
 	// - Password reset
 	Registration *service.RegistrationService
 
+	// Verification нь нэвтэрсэн хэрэглэгчийн email кодоор баталгаажуулалт.
+	// - POST /verify/email, /verify/email/confirm (15 минутын хугацаатай код)
+	Verification *service.VerificationService
+
 	// ============================================================
 	// SYSTEM & MODULE SERVICES
 	// ============================================================
@@ -368,6 +425,9 @@ type ServiceContainer struct {
 	// News нь мэдээний business logic.
 	News *service.NewsService
 
+	// NewsCategory нь мэдээний ангиллын business logic.
+	NewsCategory *service.NewsCategoryService
+
 	// ChatItem нь chat item-ийн business logic.
 	ChatItem *service.ChatItemService
 
@@ -401,11 +461,18 @@ type ServiceContainer struct {
 // NewDependencies нь бүх dependency-уудыг үүсгэж, холбоно.
 // Энэ функц нь Composition Root болж ажиллана.
 //
-// Parameters:
-//   - db: GORM database connection
-//   - cfg: Application configuration
-//   - log: Zap structured logger
-//   - authCache: Session cache instance
+// Options (заавал):
+//   - WithDB: GORM database connection
+//   - WithConfig: Application configuration
+//   - WithLogger: Zap structured logger
+//   - WithAuthCache: Session cache instance
+//
+// Options (сонголттой):
+//   - WithSessionConfig: SSO session refresh тохиргоо (байхгүй бол env-ээс ачаална)
+//   - WithMailConfig: SMTP тохиргоо (байхгүй бол env-ээс ачаална)
+//   - WithServerConfig: SERVER_* тохиргоо (байхгүй бол env-ээс ачаална)
+//
+// Аль нэг нь дутуу бол startup үед дутуу option-уудын нэртэй panic хийнэ.
 //
 // Returns:
 //   - *Dependencies: Бүх dependency-уудыг агуулсан struct
@@ -415,7 +482,29 @@ type ServiceContainer struct {
 //  2. Services (business layer, repositories-ээс хамаарна)
 //  3. SSO client (auth layer)
 //  4. Final Dependencies struct
-func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache *ssoclient.Cache) *Dependencies {
+func NewDependencies(opts ...Option) *Dependencies {
+	core, err := applyOptions(opts)
+	if err != nil {
+		panic(err)
+	}
+	deps := newDependencies(core.DB, core.Cfg, core.Log, core.AuthCache)
+	deps.SessionCfg = core.SessionCfg
+	deps.MailCfg = core.MailCfg
+	deps.ServerCfg = core.ServerCfg
+
+	// SMTP тохируулсан бол баталгаажуулах код, нууц үг сэргээх, түгжигдсэн
+	// мэдэгдлийг жинхэнэ email-ээр илгээнэ (эс бөгөөс LogMailer)
+	if core.MailCfg.Enabled() {
+		mailer := service.NewSMTPMailer(core.MailCfg)
+		deps.Service.Auth.SetMailer(mailer)
+		deps.Service.Registration.SetMailer(mailer)
+		deps.Service.Verification.SetMailer(mailer)
+	}
+	return deps
+}
+
+// newDependencies нь баталгаажсан core dependency-уудаас бүх давхаргыг угсарна
+func newDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache *ssoclient.Cache) *Dependencies {
 
 	// ============================================================
 	// STEP 1: Create all repositories
@@ -426,9 +515,11 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 		// User & Auth
 		User:         repository.NewUserRepository(db),
 		UserRole:     repository.NewUserRoleRepository(db),
-		Auth:         repository.NewAuthRepository(db),
+		Auth:         repository.NewAuthRepository(db, log),
 		Registration: repository.NewRegistrationRepository(db),
 
+		EmailVerification: repository.NewEmailVerificationRepository(db),
+
 		// System & Module
 		System: repository.NewSystemRepository(db),
 		Module: repository.NewModuleRepository(db, cfg), // config: table prefix
@@ -440,7 +531,7 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 		Role:       repository.NewRoleRepository(db),
 
 		// Organization
-		Organization:     repository.NewOrganizationRepository(db),
+		Organization:     repository.NewOrganizationRepository(db, log),
 		OrganizationType: repository.NewOrganizationTypeRepository(db),
 		OrgUser:          repository.NewOrgUserRepository(db, cfg), // config: external URLs
 
@@ -453,10 +544,15 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 		PublicFile:   repository.NewPublicFileRepository(db),
 		Notification: repository.NewNotificationRepository(db),
 		News:         repository.NewNewsRepository(db),
-		ChatItem:     repository.NewChatItemRepository(db),
+		NewsCategory: repository.NewNewsCategoryRepository(db),
+		File:         repository.NewLocalFileRepository(service.PublicImageDir, service.PublicImageURL),
+		ChatItem:     repository.NewChatItemRepositoryWithFullText(db, localconfig.LoadChatConfig().FullTextSearch),
 
 		// Logging
 		APILog: repository.NewAPILogRepository(db),
+
+		// Feature flags
+		FeatureFlag: repository.NewFeatureFlagRepository(db),
 	}
 
 	// ============================================================
@@ -466,17 +562,20 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 	// Зарим service-ууд config, logger, бусад repository-уудыг авна.
 	
 	// Permission service эхлээд үүсгэх (Action service-д хэрэгтэй)
-	permissionSvc := service.NewPermissionService(repo.Permission, log)
+	permissionSvc := service.NewPermissionService(repo.Permission, repo.Module, log)
+
+	// System service (Menu service идэвхтэй system-ээр шүүхэд хэрэгтэй)
+	systemSvc := service.NewSystemService(repo.System, log)
 	
 	svc := &ServiceContainer{
 		// User & Auth
 		User:     service.NewUserService(repo.User, cfg, log), // External API calls
-		UserRole: service.NewUserRoleService(repo.UserRole),
+		UserRole: service.NewAuditedUserRoleService(service.NewUserRoleService(repo.UserRole), repo.UserRole, repo.Auth, log),
 
 		// System & Module
-		System: service.NewSystemService(repo.System, log),
+		System: systemSvc,
 		Module: service.NewModuleService(repo.Module),
-		Menu:   service.NewMenuService(repo.Menu),
+		Menu:   service.NewMenuServiceWithPermissions(repo.Menu, systemSvc, permissionSvc),
 
 		// Permission & Role
 		Permission: permissionSvc,
@@ -484,8 +583,8 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 		Role:       service.NewRoleService(repo.Role, log),
 
 		// Organization
-		Organization:     service.NewOrganizationService(repo.Organization, log),
-		OrganizationType: service.NewOrganizationTypeService(repo.OrganizationType),
+		Organization:     service.NewOrganizationService(repo.Organization, repo.Auth, log),
+		OrganizationType: service.NewOrganizationTypeService(repo.OrganizationType, repo.Auth, log),
 		OrgUser:          service.NewOrgUserService(repo.OrgUser, cfg, repo.User), // Cross-repo dependency
 
 		// Terminal & Platform
@@ -496,7 +595,8 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 		// Content
 		PublicFile:   service.NewPublicFileService(repo.PublicFile, cfg),
 		Notification: service.NewNotificationService(repo.Notification, cfg),
-		News:         service.NewNewsService(repo.News),
+		News:         service.NewNewsServiceWithFiles(repo.News, repo.File),
+		NewsCategory: service.NewNewsCategoryService(repo.NewsCategory),
 		ChatItem:     service.NewChatItemService(repo.ChatItem, log),
 
 		// Logging
@@ -506,6 +606,9 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 		Verify: service.NewVerifyService(cfg), // XYP, Passport APIs
 		Meet:   service.NewMeetService(cfg),   // Video conference API
 		Tpay:   service.NewTpayService(cfg),   // Payment API
+
+		// Email verification codes (/verify/email)
+		Verification: service.NewVerificationService(repo.EmailVerification, repo.User, log),
 	}
 
 	// ============================================================
@@ -523,10 +626,29 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 
 	// Create Redis session store
 	sessionStore := service.NewRedisSessionStore(redisClient, "session:", authCfg.LocalAuth.SessionTTL)
+
+	// Idempotency store (POST /user, POST /organization давхардлаас сэргийлнэ)
+	idempotencyStore := middleware.NewRedisIdempotencyStore(redisClient, "idempotency:")
+
+	// Ашиглагдсан HMAC гарын үсэг (POST /user/sync replay-ээс сэргийлнэ)
+	signatureNonces := middleware.NewRedisSignatureNonceStore(redisClient, "hmac_signature:")
 	svc.SessionStore = sessionStore
 
+	// User service org switching (PUT /me/org) нь session store-д org override хадгална
+	svc.User.SetOrgSwitcher(repo.OrgUser, sessionStore, authCfg.LocalAuth.SessionTTL)
+
+	// User устгахад notification, credential/session/MFA/login history-г нэг transaction-д устгана (GDPR),
+	// дараа нь Redis дэх идэвхтэй session-уудыг устгана
+	svc.User.SetErasure(repository.NewTxManager(db), repo.Notification, repo.Auth, sessionStore)
+	svc.Module.SetPermissionCleanup(repository.NewTxManager(db), repo.Permission, repo.Auth)
+
 	// Create Auth service (depends on repo.Auth, sessionStore, and authCfg)
 	svc.Auth = service.NewAuthService(repo.Auth, sessionStore, &authCfg.LocalAuth, log)
+	// Impersonate нь админ болон зорилтот хэрэглэгчийн role-ийг харьцуулна
+	svc.Auth.SetRoleChecker(repo.UserRole)
+	if authCfg.Google.ClientID != "" {
+		svc.Auth.SetGoogleOAuth(authCfg.Google, repo.User)
+	}
 
 	// Create Registration service (depends on repo.Auth, repo.User, repo.Registration, svc.Auth)
 	svc.Registration = service.NewRegistrationService(
@@ -541,17 +663,54 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 	// ============================================================
 	// STEP 3: Create permission cache
 	// ============================================================
-	// Permission cache нь 5 минутын TTL-тэй.
+	// Permission cache нь PERMISSION_CACHE_TTL-тэй (default 5 минут).
 	// Permission шалгахад DB руу дахин дахин очихгүй.
-	permCache := auth.NewPermissionCache(permissionSvc, 5*time.Minute)
+	permCacheCfg := localconfig.LoadPermissionCacheConfig()
+	permCache := auth.NewPermissionCache(permissionSvc, auth.PermissionCacheOptions{
+		DefaultTTL:      permCacheCfg.TTL,
+		MaxSize:         permCacheCfg.MaxSize,
+		UserTTLOverride: permCacheCfg.UserTTL,
+	})
+
+	// Role cache нь auth.RequireRole-д хэрэглэгчийн role кодуудыг 5 минут хадгална.
+	roleCache := auth.NewRoleCache(repo.UserRole, 5*time.Minute)
 
 	// ============================================================
 	// STEP 4: Wire up cache invalidators
 	// ============================================================
 	// Service-ууд permission өөрчлөгдөхөд cache цэвэрлэхэд ашиглана.
+	// Role болон user-role өөрчлөлт нь role cache-д ч нөлөөлнө.
 	svc.Permission.SetCacheInvalidator(permCache)
-	svc.Role.SetCacheInvalidator(permCache)
-	svc.UserRole.SetCacheInvalidator(permCache)
+	svc.Role.SetCacheInvalidator(auth.CacheInvalidators{permCache, roleCache})
+	svc.UserRole.SetCacheInvalidator(auth.CacheInvalidators{permCache, roleCache})
+	svc.OrganizationType.SetCacheInvalidator(permCache)
+	svc.Organization.SetCacheInvalidator(permCache)
+	svc.OrgUser.SetCacheInvalidator(permCache)
+	svc.Module.SetCacheInvalidator(permCache)
+
+	// ============================================================
+	// STEP 4.5: Health checkers (GET /health)
+	// ============================================================
+	// SSO URL тохируулаагүй бол SSO шалгалтыг алгасна.
+	healthCheckers := []health.HealthChecker{
+		health.NewPostgresChecker(db),
+		health.NewRedisChecker(redisClient),
+	}
+	if cfg.URLS.SSO != "" {
+		healthCheckers = append(healthCheckers, health.NewSSOChecker(cfg.URLS.SSO, nil))
+	}
+
+	// OrgUser.Add нь байгууллагын төрлийн role хязгаарыг (EnforceRoleLimit) шалгана
+	svc.OrgUser.SetRoleLimit(repo.Organization, repo.OrganizationType, repo.UserRole)
+
+	// ============================================================
+	// STEP 4.6: Event bus + subscribers
+	// ============================================================
+	// OrgUser.Add нь UserAddedToOrgEvent publish хийж,
+	// NotificationSubscriber мэндчилгээний мэдэгдэл илгээнэ.
+	eventBus := event.NewInMemoryBus(event.DefaultBufferSize, log)
+	svc.OrgUser.SetEventBus(eventBus)
+	service.NewNotificationSubscriber(svc.Notification, log).Register(eventBus)
 
 	// ============================================================
 	// STEP 5: Create final Dependencies struct
@@ -569,6 +728,21 @@ func NewDependencies(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache
 		// Permission cache (permission шалгахад ашиглана)
 		PermCache: permCache,
 
+		// Role cache (auth.RequireRole-д ашиглана)
+		RoleCache: roleCache,
+
+		// Idempotency store (давтан POST request-ийн хариу)
+		Idempotency: idempotencyStore,
+
+		// Ашиглагдсан HMAC гарын үсэг (replay хамгаалалт)
+		SignatureNonces: signatureNonces,
+
+		// Health checkers (GET /health)
+		HealthCheckers: healthCheckers,
+
+		// Event bus (service side effects)
+		Events: eventBus,
+
 		// Layer containers
 		Repo:    repo,
 		Service: svc,
@@ -0,0 +1,103 @@
+// Package app provides dependency injection
+//
+// File: options.go
+// Description: Functional options for NewDependencies
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	localconfig "templatev25/internal/config"
+
+	"git.gerege.mn/backend-packages/config"
+	"git.gerege.mn/backend-packages/sso-client"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Option нь NewDependencies-д core dependency оноох функц.
+// Шинэ dependency нэмэхэд дуудагч бүрийн signature өөрчлөгдөхгүй.
+type Option func(*Dependencies)
+
+// WithDB нь GORM database connection-ийг ононо (заавал)
+func WithDB(db *gorm.DB) Option {
+	return func(d *Dependencies) { d.DB = db }
+}
+
+// WithConfig нь application configuration-ийг ононо (заавал)
+func WithConfig(cfg *config.Config) Option {
+	return func(d *Dependencies) { d.Cfg = cfg }
+}
+
+// WithLogger нь structured logger-ийг ононо (заавал)
+func WithLogger(log *zap.Logger) Option {
+	return func(d *Dependencies) { d.Log = log }
+}
+
+// WithAuthCache нь SSO session cache-ийг ононо (заавал)
+func WithAuthCache(cache *ssoclient.Cache) Option {
+	return func(d *Dependencies) { d.AuthCache = cache }
+}
+
+// WithSessionConfig нь SSO session refresh тохиргоог ононо (сонголттой;
+// өгөөгүй бол localconfig.LoadSessionConfig()-оор env-ээс ачаална)
+func WithSessionConfig(sessCfg *localconfig.SessionConfig) Option {
+	return func(d *Dependencies) { d.SessionCfg = sessCfg }
+}
+
+// WithMailConfig нь SMTP тохиргоог ононо (сонголттой; өгөөгүй бол
+// localconfig.LoadMailConfig()-оор env-ээс ачаална, SMTP_HOST хоосон бол log mailer)
+func WithMailConfig(mailCfg *localconfig.MailConfig) Option {
+	return func(d *Dependencies) { d.MailCfg = mailCfg }
+}
+
+// WithServerConfig нь main.go-д баталгаажуулсан SERVER_* тохиргоог ононо
+// (сонголттой; өгөөгүй бол localconfig.LoadServerConfig()-оор env-ээс ачаална)
+func WithServerConfig(srvCfg *localconfig.ServerConfig) Option {
+	return func(d *Dependencies) { d.ServerCfg = srvCfg }
+}
+
+// applyOptions нь option-уудыг хэрэглээд заавал шаардлагатай dependency дутуу
+// бол бүх дутуу option-ийн нэрийг агуулсан алдаа буцаана
+func applyOptions(opts []Option) (*Dependencies, error) {
+	d := &Dependencies{}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	var missing []string
+	if d.DB == nil {
+		missing = append(missing, "WithDB")
+	}
+	if d.Cfg == nil {
+		missing = append(missing, "WithConfig")
+	}
+	if d.Log == nil {
+		missing = append(missing, "WithLogger")
+	}
+	if d.AuthCache == nil {
+		missing = append(missing, "WithAuthCache")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("app.NewDependencies: missing required option(s): %s", strings.Join(missing, ", "))
+	}
+	if d.SessionCfg == nil {
+		d.SessionCfg = localconfig.LoadSessionConfig()
+	}
+	if d.MailCfg == nil {
+		d.MailCfg = localconfig.LoadMailConfig()
+	}
+	if d.ServerCfg == nil {
+		d.ServerCfg = localconfig.LoadServerConfig()
+	}
+	return d, nil
+}
+
+// NewDependenciesPositional нь хуучин positional signature-ийн compatibility shim.
+//
+// Deprecated: use NewDependencies(WithDB(db), WithConfig(cfg), WithLogger(log), WithAuthCache(authCache)).
+func NewDependenciesPositional(db *gorm.DB, cfg *config.Config, log *zap.Logger, authCache *ssoclient.Cache) *Dependencies {
+	return NewDependencies(WithDB(db), WithConfig(cfg), WithLogger(log), WithAuthCache(authCache))
+}
@@ -69,8 +69,23 @@ import (
 //	requireAuth := auth.Require(cfg, log, cache)
 //	app.Get("/protected", requireAuth, handler.Protected)
 func Require(cfg *config.Config, log *zap.Logger, cache *ssoclient.Cache) fiber.Handler {
+	return RequireWithOrgOverride(cfg, log, cache, nil)
+}
+
+// OrgOverrideReader нь session-д сонгогдсон идэвхтэй байгууллагыг уншина.
+// PUT /me/org-оор хадгалагдсан override-ийг SSO claims дээр тавихад ашиглана.
+type OrgOverrideReader interface {
+	GetOrgOverride(ctx context.Context, sessionID string) (int, error)
+}
+
+// RequireWithOrgOverride нь Require-тэй адил боловч claims-ийн OrgID-г
+// session-ий org override-оор (байвал) сольж context-д хадгална.
+//
+// Parameters:
+//   - overrides: Org override reader (nil бол override хийхгүй)
+func RequireWithOrgOverride(cfg *config.Config, log *zap.Logger, cache *ssoclient.Cache, overrides OrgOverrideReader) fiber.Handler {
 	// Урьдчилсан шалгалт: Auth тохиргоо бүрэн байгаа эсэх
-	if cfg.Auth.ClientID == "" || cfg.Auth.ClientSecret == "" || cfg.URLS.SSO == "" {
+	if !authConfigured(cfg) {
 		// Тохиргоо дутуу бол бүх request-д 401 буцаах
 		return func(c *fiber.Ctx) error {
 			return fiber.NewError(fiber.StatusUnauthorized, "auth is not configured")
@@ -78,8 +93,20 @@ func Require(cfg *config.Config, log *zap.Logger, cache *ssoclient.Cache) fiber.
 	}
 
 	// SSO HTTP client үүсгэх
-	sso := ssoclient.NewSSOClient(cfg, log, cache)
+	return requireSession(cfg, log, ssoclient.NewSSOClient(cfg, log, cache), overrides, nil)
+}
 
+// authConfigured нь SSO-д шаардлагатай тохиргоо бүрэн эсэхийг шалгана
+func authConfigured(cfg *config.Config) bool {
+	return cfg.Auth.ClientID != "" && cfg.Auth.ClientSecret != "" && cfg.URLS.SSO != ""
+}
+
+// sessionRefreshFunc нь баталгаажсан session-ийг шинэчилнэ. Шинэчилсэн бол
+// шинэ SID, claims болон true буцаана (RequireWithRefresh).
+type sessionRefreshFunc func(c *fiber.Ctx, reqCtx context.Context, sid, reqID string, current ssoclient.Claims) (string, ssoclient.Claims, bool)
+
+// requireSession нь Require-ийн үндсэн middleware. refresh nil бол session-ийг шинэчлэхгүй.
+func requireSession(cfg *config.Config, log *zap.Logger, sso SessionRefresher, overrides OrgOverrideReader, refresh sessionRefreshFunc) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// ============================================================
 		// STEP 1: Session ID олох
@@ -112,6 +139,28 @@ func Require(cfg *config.Config, log *zap.Logger, cache *ssoclient.Cache) fiber.
 			return fiber.NewError(fiber.StatusUnauthorized, fiber.ErrUnauthorized.Message)
 		}
 
+		// Хэрэглэгч идэвхтэй байгууллагаа сольсон бол override-ийг хэрэглэнэ
+		orgOverride := 0
+		if overrides != nil {
+			orgID, err := overrides.GetOrgOverride(ctxTimeout, sid)
+			if err != nil {
+				log.Warn("org_override_read_failed", zap.String("request_id", reqID), zap.Error(err))
+			} else if orgID > 0 {
+				orgOverride = orgID
+				claims.OrgID = orgID
+			}
+		}
+
+		// Session хүчинтэй — дуусах дөхсөн бол шинэчилнэ (RequireWithRefresh)
+		if refresh != nil {
+			if newSID, refreshed, ok := refresh(c, ctxTimeout, sid, reqID, claims); ok {
+				if orgOverride > 0 {
+					refreshed.OrgID = orgOverride
+				}
+				sid, claims = newSID, refreshed
+			}
+		}
+
 		// ============================================================
 		// STEP 4: Context/Locals-д хадгалах
 		// ============================================================
@@ -0,0 +1,99 @@
+// Package auth provides authentication and authorization helpers
+//
+// File: password_policy.go
+// Description: Password complexity policy (length, upper/lowercase, digit, special character)
+/*
+Package auth нь SSO authentication болон session management-ийг хариуцна.
+
+Энэ файл нь local auth-ийн нууц үгийн нарийн төвөгтэй байдлын шаардлагыг
+(PasswordPolicy) тодорхойлно. Register, ChangePassword, SetPassword,
+ResetPassword бүгд ижил policy-оор шалгана.
+
+Ашиглалт:
+
+	policy := auth.PasswordPolicyFromConfig(&authCfg.LocalAuth)
+	if violations := policy.Validate(password); len(violations) > 0 {
+	    // ["uppercase", "digit"]
+	}
+*/
+package auth
+
+import (
+	"unicode"      // Тэмдэгтийн ангилал (Кирилл үсэг ч мөн тооцогдоно)
+	"unicode/utf8" // Уртыг тэмдэгтээр (byte биш) тоолох
+
+	localconfig "templatev25/internal/config" // LocalAuthConfig
+)
+
+// PolicyViolation нь зөрчигдсөн дүрмийн код (API хариунд шууд буцаана)
+type PolicyViolation string
+
+const (
+	// ViolationMinLength нь MinLength-ээс богино
+	ViolationMinLength PolicyViolation = "min_length"
+	// ViolationUppercase нь том үсэг байхгүй
+	ViolationUppercase PolicyViolation = "uppercase"
+	// ViolationLowercase нь жижиг үсэг байхгүй
+	ViolationLowercase PolicyViolation = "lowercase"
+	// ViolationDigit нь цифр байхгүй
+	ViolationDigit PolicyViolation = "digit"
+	// ViolationSpecial нь тусгай тэмдэгт (цэг тэмдэг, symbol) байхгүй
+	ViolationSpecial PolicyViolation = "special"
+)
+
+// PasswordPolicy нь нууц үгийн шаардлагууд. Zero value нь юу ч шаардахгүй.
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSpecial   bool
+}
+
+// PasswordPolicyFromConfig нь LOCAL_AUTH_PASSWORD_* тохиргооноос policy үүсгэнэ
+func PasswordPolicyFromConfig(cfg *localconfig.LocalAuthConfig) PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:        cfg.PasswordMinLength,
+		RequireUppercase: cfg.PasswordRequireUppercase,
+		RequireLowercase: cfg.PasswordRequireLowercase,
+		RequireDigit:     cfg.PasswordRequireDigit,
+		RequireSpecial:   cfg.PasswordRequireSpecial,
+	}
+}
+
+// Validate нь password-ийн зөрчсөн дүрмүүдийг тогтмол дарааллаар (min_length,
+// uppercase, lowercase, digit, special) буцаана; хоосон бол policy хангагдсан.
+// Урт нь rune-аар тоологдоно.
+func (p PasswordPolicy) Validate(password string) []PolicyViolation {
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
+	}
+
+	var violations []PolicyViolation
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, ViolationMinLength)
+	}
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, ViolationUppercase)
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, ViolationLowercase)
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, ViolationDigit)
+	}
+	if p.RequireSpecial && !hasSpecial {
+		violations = append(violations, ViolationSpecial)
+	}
+	return violations
+}
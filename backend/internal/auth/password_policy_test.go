@@ -0,0 +1,130 @@
+// Package auth provides authentication and authorization utilities
+//
+// File: password_policy_test.go
+// Description: Unit and fuzz tests for PasswordPolicy.Validate
+package auth
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	localconfig "templatev25/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// policyFromMask нь bit бүрээр дүрэм асаана: 0 min_length(8), 1 upper, 2 lower, 3 digit, 4 special
+func policyFromMask(mask int) PasswordPolicy {
+	p := PasswordPolicy{
+		RequireUppercase: mask&2 != 0,
+		RequireLowercase: mask&4 != 0,
+		RequireDigit:     mask&8 != 0,
+		RequireSpecial:   mask&16 != 0,
+	}
+	if mask&1 != 0 {
+		p.MinLength = 8
+	}
+	return p
+}
+
+func TestPasswordPolicy_Validate_AllRuleCombinations(t *testing.T) {
+	// missing нь тухайн password-д дутуу дүрмүүд (бүх дүрэм асаалттай үед)
+	samples := []struct {
+		password string
+		missing  []PolicyViolation
+	}{
+		{"Abcdef1!", nil},
+		{"Ab1!", []PolicyViolation{ViolationMinLength}},
+		{"abcdefg1!", []PolicyViolation{ViolationUppercase}},
+		{"ABCDEFG1!", []PolicyViolation{ViolationLowercase}},
+		{"Abcdefgh!", []PolicyViolation{ViolationDigit}},
+		{"Abcdefgh1", []PolicyViolation{ViolationSpecial}},
+		{"", []PolicyViolation{ViolationMinLength, ViolationUppercase, ViolationLowercase, ViolationDigit, ViolationSpecial}},
+		{"12345678", []PolicyViolation{ViolationUppercase, ViolationLowercase, ViolationSpecial}},
+		{"Нууц_үг2026", nil}, // Кирилл том/жижиг үсэг
+		{"Өө1!", []PolicyViolation{ViolationMinLength}},
+	}
+	bit := map[PolicyViolation]int{
+		ViolationMinLength: 1, ViolationUppercase: 2, ViolationLowercase: 4, ViolationDigit: 8, ViolationSpecial: 16,
+	}
+
+	for mask := 0; mask < 32; mask++ {
+		policy := policyFromMask(mask)
+		for _, s := range samples {
+			var want []PolicyViolation
+			for _, v := range s.missing {
+				if mask&bit[v] != 0 {
+					want = append(want, v)
+				}
+			}
+			assert.Equal(t, want, policy.Validate(s.password), "mask=%05b password=%q", mask, s.password)
+		}
+	}
+}
+
+func TestPasswordPolicy_Validate_LengthCountsCharacters(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 8}
+
+	// 8 Кирилл тэмдэгт = 16 byte; урт тэмдэгтээр тоологдоно
+	assert.Empty(t, policy.Validate("абвгдежз"))
+	assert.Equal(t, []PolicyViolation{ViolationMinLength}, policy.Validate("абвгдеж"))
+}
+
+func TestPasswordPolicyFromConfig(t *testing.T) {
+	policy := PasswordPolicyFromConfig(&localconfig.LocalAuthConfig{
+		PasswordMinLength:        12,
+		PasswordRequireUppercase: true,
+		PasswordRequireDigit:     true,
+	})
+
+	assert.Equal(t, PasswordPolicy{MinLength: 12, RequireUppercase: true, RequireDigit: true}, policy)
+}
+
+// FuzzPasswordPolicy_Validate нь Validate-ийг тусдаа (strings.IndexFunc) шалгалттай харьцуулна:
+//
+//	go test ./internal/auth -run '^$' -fuzz FuzzPasswordPolicy_Validate -fuzztime 30s
+func FuzzPasswordPolicy_Validate(f *testing.F) {
+	for _, seed := range []string{"", "Abcdef1!", "password", "ПАРОЛЬ123", "\x00\xff", "🔑Key1"} {
+		f.Add(seed, uint8(31))
+	}
+
+	f.Fuzz(func(t *testing.T, password string, mask uint8) {
+		policy := policyFromMask(int(mask % 32))
+		got := policy.Validate(password)
+
+		has := func(fn func(rune) bool) bool { return strings.IndexFunc(password, fn) >= 0 }
+		var want []PolicyViolation
+		if utf8.RuneCountInString(password) < policy.MinLength {
+			want = append(want, ViolationMinLength)
+		}
+		if policy.RequireUppercase && !has(unicode.IsUpper) {
+			want = append(want, ViolationUppercase)
+		}
+		if policy.RequireLowercase && !has(unicode.IsLower) {
+			want = append(want, ViolationLowercase)
+		}
+		if policy.RequireDigit && !has(unicode.IsDigit) {
+			want = append(want, ViolationDigit)
+		}
+		if policy.RequireSpecial && !has(func(r rune) bool {
+			return !unicode.IsUpper(r) && !unicode.IsLower(r) && !unicode.IsDigit(r) &&
+				(unicode.IsPunct(r) || unicode.IsSymbol(r))
+		}) {
+			want = append(want, ViolationSpecial)
+		}
+
+		if len(want) != len(got) {
+			t.Fatalf("Validate(%q) with %+v = %v, want %v", password, policy, got, want)
+		}
+		for i := range want {
+			if want[i] != got[i] {
+				t.Fatalf("Validate(%q) with %+v = %v, want %v", password, policy, got, want)
+			}
+		}
+		if len(PasswordPolicy{}.Validate(password)) != 0 {
+			t.Fatalf("zero policy rejected %q", password)
+		}
+	})
+}
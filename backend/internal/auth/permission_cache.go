@@ -14,7 +14,8 @@ Cache нь DB руу очих request-ийн тоог бууруулж, хурд
 
 Cache бүтэц:
   - In-memory cache (sync.Map ашиглана)
-  - TTL-тэй (default 5 минут)
+  - TTL-тэй (default 5 минут), хэрэглэгч бүрээр override хийж болно
+  - MaxSize хүрвэл хугацаа дууссан, дараа нь дурын нэг entry-г гаргана
   - User ID-гаар key хадгална
 
 Invalidation:
@@ -25,7 +26,12 @@ Invalidation:
 Ашиглалт:
 
 	// Cache үүсгэх
-	permCache := auth.NewPermissionCache(permService, 5*time.Minute)
+	permCache := auth.NewPermissionCache(permService, auth.PermissionCacheOptions{
+	    DefaultTTL: 5 * time.Minute,
+	    MaxSize:    10000,
+	    // Service account-ийн эрхийг үргэлж DB-ээс шалгана
+	    UserTTLOverride: map[int]time.Duration{42: 0},
+	})
 
 	// Middleware-д ашиглах
 	app.Post("/role",
@@ -42,6 +48,7 @@ import (
 	"context"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -79,30 +86,77 @@ type CacheInvalidator interface {
 // PERMISSION CACHE
 // ============================================================
 
+// DefaultPermissionCacheTTL нь DefaultTTL өгөөгүй үеийн TTL
+const DefaultPermissionCacheTTL = 5 * time.Minute
+
+// PermissionCacheOptions нь PermissionCache-ийн тохиргоо.
+type PermissionCacheOptions struct {
+	// DefaultTTL нь override-гүй хэрэглэгчийн cache хугацаа (<= 0 бол DefaultPermissionCacheTTL)
+	DefaultTTL time.Duration
+	// MaxSize нь cache-д хадгалах хэрэглэгчийн дээд тоо (<= 0 бол хязгааргүй)
+	MaxSize int
+	// UserTTLOverride нь userID -> TTL. 0 (эсвэл сөрөг) бол тухайн хэрэглэгчийг cache-лэхгүй,
+	// жишээ нь эрхийг нь үргэлж шинээр шалгах шаардлагатай service account.
+	UserTTLOverride map[int]time.Duration
+}
+
 // PermissionCache нь permission-уудыг cache-лэх layer.
 // PermissionChecker интерфейсийг implement хийнэ.
 type PermissionCache struct {
-	service PermissionChecker // Underlying service (DB руу хандах)
-	cache   sync.Map          // userID -> *cachedPermissions
-	ttl     time.Duration     // Cache TTL
-	mu      sync.RWMutex      // Role invalidation-д ашиглах
+	service PermissionChecker     // Underlying service (DB руу хандах)
+	cache   sync.Map              // userID -> *cachedPermissions
+	size    atomic.Int64          // cache доторх entry-ийн тоо (MaxSize шалгахад)
+	ttl     time.Duration         // Default cache TTL
+	maxSize int                   // 0 бол хязгааргүй
+	userTTL map[int]time.Duration // Per-user TTL override (mu-аар хамгаална)
+	mu      sync.RWMutex          // Role invalidation болон userTTL-д ашиглах
+	evictMu sync.Mutex            // Зэрэг eviction хийхээс сэргийлнэ
 }
 
 // NewPermissionCache нь шинэ permission cache үүсгэнэ.
 //
 // Parameters:
 //   - service: Underlying permission service
-//   - ttl: Cache-ийн хүчинтэй хугацаа (жишээ: 5*time.Minute)
+//   - opts: TTL, хэмжээ болон хэрэглэгч бүрийн TTL override
 //
 // Returns:
 //   - *PermissionCache: Cache instance
-func NewPermissionCache(service PermissionChecker, ttl time.Duration) *PermissionCache {
+func NewPermissionCache(service PermissionChecker, opts PermissionCacheOptions) *PermissionCache {
+	ttl := opts.DefaultTTL
+	if ttl <= 0 {
+		ttl = DefaultPermissionCacheTTL
+	}
+	userTTL := make(map[int]time.Duration, len(opts.UserTTLOverride))
+	for id, d := range opts.UserTTLOverride {
+		userTTL[id] = d
+	}
 	return &PermissionCache{
 		service: service,
 		ttl:     ttl,
+		maxSize: opts.MaxSize,
+		userTTL: userTTL,
 	}
 }
 
+// SetUserTTL нь хэрэглэгчийн TTL-ийг runtime-д тохируулна (0 бол cache-лэхгүй).
+// Шинэ TTL дараагийн уншилтаас үйлчлэхийн тулд хэрэглэгчийн одоогийн cache-ийг цэвэрлэнэ.
+func (pc *PermissionCache) SetUserTTL(userID int, ttl time.Duration) {
+	pc.mu.Lock()
+	pc.userTTL[userID] = ttl
+	pc.mu.Unlock()
+	pc.InvalidateUser(userID)
+}
+
+// ttlFor нь хэрэглэгчийн TTL-ийг буцаана (override байвал түүнийг)
+func (pc *PermissionCache) ttlFor(userID int) time.Duration {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	if d, ok := pc.userTTL[userID]; ok {
+		return d
+	}
+	return pc.ttl
+}
+
 // ============================================================
 // PERMISSION CHECKER IMPLEMENTATION
 // ============================================================
@@ -149,7 +203,7 @@ func (pc *PermissionCache) GetUserPermissions(ctx context.Context, userID int) (
 			return cp.codes, nil
 		}
 		// Хүчингүй болсон бол устгах
-		pc.cache.Delete(userID)
+		pc.delete(userID)
 	}
 
 	// ============================================================
@@ -163,14 +217,55 @@ func (pc *PermissionCache) GetUserPermissions(ctx context.Context, userID int) (
 	// ============================================================
 	// STEP 3: Cache-д хадгалах
 	// ============================================================
-	pc.cache.Store(userID, &cachedPermissions{
+	ttl := pc.ttlFor(userID)
+	if ttl <= 0 {
+		return perms, nil
+	}
+	pc.evictIfFull(userID)
+	if _, loaded := pc.cache.Swap(userID, &cachedPermissions{
 		codes:     perms,
-		expiresAt: time.Now().Add(pc.ttl),
-	})
+		expiresAt: time.Now().Add(ttl),
+	}); !loaded {
+		pc.size.Add(1)
+	}
 
 	return perms, nil
 }
 
+// delete нь entry-г устгаж size-ийг тохируулна
+func (pc *PermissionCache) delete(userID int) {
+	if _, loaded := pc.cache.LoadAndDelete(userID); loaded {
+		pc.size.Add(-1)
+	}
+}
+
+// evictIfFull нь шинэ хэрэглэгч нэмэхээс өмнө MaxSize хүрсэн бол эхлээд хугацаа
+// дууссан entry-үүдийг, тэгээд ч дүүрэн бол дурын нэг entry-г гаргана.
+func (pc *PermissionCache) evictIfFull(userID int) {
+	if pc.maxSize <= 0 || pc.size.Load() < int64(pc.maxSize) {
+		return
+	}
+	if _, ok := pc.cache.Load(userID); ok {
+		return // Байгаа entry-г солиход хэмжээ өсөхгүй
+	}
+
+	pc.evictMu.Lock()
+	defer pc.evictMu.Unlock()
+	pc.cache.Range(func(k, v interface{}) bool {
+		if v.(*cachedPermissions).isExpired() {
+			pc.delete(k.(int))
+		}
+		return true
+	})
+	pc.cache.Range(func(k, _ interface{}) bool {
+		if pc.size.Load() < int64(pc.maxSize) {
+			return false
+		}
+		pc.delete(k.(int))
+		return true
+	})
+}
+
 // ============================================================
 // CACHE INVALIDATION
 // ============================================================
@@ -181,7 +276,7 @@ func (pc *PermissionCache) GetUserPermissions(ctx context.Context, userID int) (
 // Parameters:
 //   - userID: Хэрэглэгчийн ID
 func (pc *PermissionCache) InvalidateUser(userID int) {
-	pc.cache.Delete(userID)
+	pc.delete(userID)
 }
 
 // InvalidateUsers нь олон хэрэглэгчийн cache-ийг цэвэрлэнэ.
@@ -190,7 +285,7 @@ func (pc *PermissionCache) InvalidateUser(userID int) {
 //   - userIDs: Хэрэглэгчдийн ID-ууд
 func (pc *PermissionCache) InvalidateUsers(userIDs []int) {
 	for _, id := range userIDs {
-		pc.cache.Delete(id)
+		pc.delete(id)
 	}
 }
 
@@ -199,7 +294,8 @@ func (pc *PermissionCache) InvalidateUsers(userIDs []int) {
 func (pc *PermissionCache) InvalidateAll() {
 	pc.mu.Lock()
 	defer pc.mu.Unlock()
-	pc.cache = sync.Map{}
+	pc.cache.Clear()
+	pc.size.Store(0)
 }
 
 // ============================================================
@@ -208,8 +304,10 @@ func (pc *PermissionCache) InvalidateAll() {
 
 // CacheStats нь cache-ийн статистик мэдээлэл.
 type CacheStats struct {
-	CachedUsers int           // Cache-д байгаа хэрэглэгчийн тоо
-	TTL         time.Duration // Cache TTL
+	CachedUsers   int           // Cache-д байгаа хэрэглэгчийн тоо
+	TTL           time.Duration // Default cache TTL
+	MaxSize       int           // 0 бол хязгааргүй
+	UserOverrides int           // TTL override-тэй хэрэглэгчийн тоо
 }
 
 // Stats нь cache-ийн статистикийг буцаана.
@@ -219,8 +317,13 @@ func (pc *PermissionCache) Stats() CacheStats {
 		count++
 		return true
 	})
+	pc.mu.RLock()
+	overrides := len(pc.userTTL)
+	pc.mu.RUnlock()
 	return CacheStats{
-		CachedUsers: count,
-		TTL:         pc.ttl,
+		CachedUsers:   count,
+		TTL:           pc.ttl,
+		MaxSize:       pc.maxSize,
+		UserOverrides: overrides,
 	}
 }
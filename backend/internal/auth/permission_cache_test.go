@@ -46,7 +46,7 @@ func TestNewPermissionCache(t *testing.T) {
 	mock := newMockChecker(nil)
 	ttl := 5 * time.Minute
 
-	cache := NewPermissionCache(mock, ttl)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{DefaultTTL: ttl})
 
 	assert.NotNil(t, cache)
 	assert.Equal(t, ttl, cache.ttl)
@@ -57,7 +57,7 @@ func TestPermissionCache_HasPermission(t *testing.T) {
 		1: {"admin.user.read", "admin.user.write"},
 		2: {"admin.role.read"},
 	})
-	cache := NewPermissionCache(mock, 5*time.Minute)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
 	ctx := context.Background()
 
 	tests := []struct {
@@ -111,7 +111,7 @@ func TestPermissionCache_GetUserPermissions(t *testing.T) {
 	mock := newMockChecker(map[int][]string{
 		1: {"perm1", "perm2", "perm3"},
 	})
-	cache := NewPermissionCache(mock, 5*time.Minute)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
 	ctx := context.Background()
 
 	// First call - should hit the mock
@@ -131,7 +131,7 @@ func TestPermissionCache_InvalidateUser(t *testing.T) {
 	mock := newMockChecker(map[int][]string{
 		1: {"perm1"},
 	})
-	cache := NewPermissionCache(mock, 5*time.Minute)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
 	ctx := context.Background()
 
 	// First call - populate cache
@@ -152,7 +152,7 @@ func TestPermissionCache_InvalidateUsers(t *testing.T) {
 		2: {"perm2"},
 		3: {"perm3"},
 	})
-	cache := NewPermissionCache(mock, 5*time.Minute)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
 	ctx := context.Background()
 
 	// Populate cache for all users
@@ -179,7 +179,7 @@ func TestPermissionCache_InvalidateAll(t *testing.T) {
 		1: {"perm1"},
 		2: {"perm2"},
 	})
-	cache := NewPermissionCache(mock, 5*time.Minute)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
 	ctx := context.Background()
 
 	// Populate cache
@@ -202,7 +202,7 @@ func TestPermissionCache_Stats(t *testing.T) {
 		2: {"perm2"},
 	})
 	ttl := 5 * time.Minute
-	cache := NewPermissionCache(mock, ttl)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{DefaultTTL: ttl})
 	ctx := context.Background()
 
 	// Initially empty
@@ -257,7 +257,7 @@ func TestPermissionCache_TTLExpiration(t *testing.T) {
 		1: {"perm1"},
 	})
 	// Very short TTL for testing
-	cache := NewPermissionCache(mock, 50*time.Millisecond)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{DefaultTTL: 50 * time.Millisecond})
 	ctx := context.Background()
 
 	// First call
@@ -271,3 +271,66 @@ func TestPermissionCache_TTLExpiration(t *testing.T) {
 	_, _ = cache.GetUserPermissions(ctx, 1)
 	assert.Equal(t, 2, mock.callCount)
 }
+
+func TestNewPermissionCache_DefaultTTL(t *testing.T) {
+	cache := NewPermissionCache(newMockChecker(nil), PermissionCacheOptions{})
+
+	assert.Equal(t, DefaultPermissionCacheTTL, cache.ttl)
+	assert.Equal(t, 0, cache.maxSize)
+}
+
+func TestPermissionCache_OverrideMapIsCopied(t *testing.T) {
+	overrides := map[int]time.Duration{1: time.Second}
+	cache := NewPermissionCache(newMockChecker(nil), PermissionCacheOptions{UserTTLOverride: overrides})
+
+	cache.SetUserTTL(2, 0)
+
+	assert.Len(t, overrides, 1, "caller's map must not be mutated")
+	assert.Equal(t, time.Second, cache.ttlFor(1))
+	assert.Equal(t, time.Duration(0), cache.ttlFor(2))
+	assert.Equal(t, DefaultPermissionCacheTTL, cache.ttlFor(3))
+}
+
+func TestPermissionCache_MaxSize(t *testing.T) {
+	mock := newMockChecker(nil)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{DefaultTTL: time.Minute, MaxSize: 3})
+	ctx := context.Background()
+
+	for id := 1; id <= 10; id++ {
+		_, _ = cache.GetUserPermissions(ctx, id)
+		assert.LessOrEqual(t, cache.Stats().CachedUsers, 3)
+	}
+	assert.Equal(t, 3, cache.Stats().CachedUsers)
+	assert.Equal(t, int64(3), cache.size.Load())
+
+	// Хамгийн сүүлд нэмэгдсэн нь cache-д байна
+	calls := mock.callCount
+	_, _ = cache.GetUserPermissions(ctx, 10)
+	assert.Equal(t, calls, mock.callCount)
+
+	cache.InvalidateAll()
+	assert.Equal(t, 0, cache.Stats().CachedUsers)
+	assert.Equal(t, int64(0), cache.size.Load())
+}
+
+func TestPermissionCache_MaxSizeEvictsExpiredFirst(t *testing.T) {
+	mock := newMockChecker(nil)
+	cache := NewPermissionCache(mock, PermissionCacheOptions{
+		DefaultTTL:      time.Minute,
+		MaxSize:         2,
+		UserTTLOverride: map[int]time.Duration{1: time.Millisecond},
+	})
+	ctx := context.Background()
+
+	_, _ = cache.GetUserPermissions(ctx, 1)
+	_, _ = cache.GetUserPermissions(ctx, 2)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = cache.GetUserPermissions(ctx, 3)
+
+	_, ok := cache.cache.Load(1)
+	assert.False(t, ok, "expired entry is evicted")
+	for _, id := range []int{2, 3} {
+		_, ok := cache.cache.Load(id)
+		assert.True(t, ok, id)
+	}
+}
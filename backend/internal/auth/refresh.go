@@ -0,0 +1,146 @@
+// Package auth provides implementation for auth
+//
+// File: refresh.go
+// Description: SSO session auto-refresh middleware
+/*
+Package auth нь SSO authentication болон session management-ийг хариуцна.
+
+Энэ файл нь дуусах дөхсөн SSO session-ийг автоматаар шинэчлэх
+RequireWithRefresh middleware-ийг агуулна. Зөвхөн protected route-уудад
+ажиллана — public route (login, logout, callback) хуучин cookie-тэй ч хүрнэ.
+
+Refresh flow (Require session-ийг баталгаажуулсны дараа):
+ 1. Require-ийн авсан claims-ийн Exp-ээс үлдсэн хугацааг тооцох
+ 2. TTL < SessionConfig.RefreshThreshold (AUTH_REFRESH_THRESHOLD) бол SSO RefreshToken дуудах
+ 3. Шинэ токеноор claims авч cache-д хадгалах
+ 4. Request-ийн cookie/Authorization-ийг шинэ токеноор солих
+ 5. Bearer client-д л X-Refreshed-Token header тавих (cookie session-ий
+    HttpOnly SID-ийг JavaScript-д ил гаргахгүй)
+
+Refresh амжилтгүй бол log бичээд хуучин (хүчинтэй хэвээр) session-оор үргэлжилнэ.
+*/
+package auth
+
+import (
+	"context" // Timeout context
+	"time"    // Threshold, timeout
+
+	localconfig "templatev25/internal/config" // Session refresh config
+
+	"git.gerege.mn/backend-packages/config"     // Configuration
+	"git.gerege.mn/backend-packages/sso-client" // SSO client
+
+	"github.com/gofiber/fiber/v2" // Web framework
+	"go.uber.org/zap"             // Structured logging
+)
+
+// HeaderRefreshedToken нь шинэчлэгдсэн bearer токеныг client-д буцаах header
+const HeaderRefreshedToken = "X-Refreshed-Token"
+
+// SessionRefresher нь SSO session-ий claims-ийг авч, токеныг шинэчилнэ.
+// *ssoclient.SSOClient энэ interface-ийг хангана; GetClaims нь session cache-ээр
+// дамждаг тул TTL шалгалт request бүрт SSO руу хандахгүй.
+type SessionRefresher interface {
+	GetClaims(ctx context.Context, sid, reqID string) (ssoclient.Claims, error)
+	RefreshToken(ctx context.Context, oldToken string) (string, error)
+}
+
+// sessionTTL нь claims-ийн Exp-ээс session дуусах хүртэлх хугацааг тооцно.
+// Exp байхгүй бол ok=false (refresh хийх шаардлагагүй гэж үзнэ).
+func sessionTTL(claims ssoclient.Claims, now time.Time) (time.Duration, bool) {
+	if claims.Exp <= 0 {
+		return 0, false
+	}
+	return time.Unix(claims.Exp, 0).Sub(now), true
+}
+
+// RequireWithRefresh нь RequireWithOrgOverride-тэй адил session-ийг шалгаж,
+// хүчинтэй session дуусах дөхсөн бол шинэчилнэ. Handler-ууд шинэ токен,
+// claims-ийг харна.
+//
+// Parameters:
+//   - cfg: Application configuration (SSO URL, client credentials, cookie name)
+//   - sessCfg: Refresh threshold болон refresh хийсэн cookie-ийн attribute-ууд
+//   - log: Zap logger
+//   - cache: Session cache (шинэ токены claims энд хадгалагдана)
+//   - overrides: Org override reader (nil бол override хийхгүй)
+//
+// Returns:
+//   - fiber.Handler: Middleware function
+//
+// Жишээ:
+//
+//	requireAuth := auth.RequireWithRefresh(cfg, localconfig.LoadSessionConfig(), log, cache, store)
+//	app.Get("/protected", requireAuth, handler.Protected)
+func RequireWithRefresh(cfg *config.Config, sessCfg *localconfig.SessionConfig, log *zap.Logger, cache *ssoclient.Cache, overrides OrgOverrideReader) fiber.Handler {
+	// Auth тохиргоо дутуу бол Require бүх request-д 401 буцаана
+	if !authConfigured(cfg) {
+		return RequireWithOrgOverride(cfg, log, cache, overrides)
+	}
+	return RequireWithRefreshWith(cfg, sessCfg, log, ssoclient.NewSSOClient(cfg, log, cache), overrides)
+}
+
+// RequireWithRefreshWith нь RequireWithRefresh-тэй адил боловч SSO client-ийг гаднаас авна (тестэд хэрэглэнэ).
+//
+// Refresh алгасах нөхцөл:
+//   - Claims-д Exp байхгүй
+//   - TTL >= threshold
+//
+// Refresh эсвэл шинэ claims авах амжилтгүй бол хуучин session-оор үргэлжилнэ.
+func RequireWithRefreshWith(cfg *config.Config, sessCfg *localconfig.SessionConfig, log *zap.Logger, sso SessionRefresher, overrides OrgOverrideReader) fiber.Handler {
+	threshold := sessCfg.RefreshThreshold
+	if threshold <= 0 {
+		threshold = localconfig.DefaultRefreshThreshold
+	}
+
+	refresh := func(c *fiber.Ctx, reqCtx context.Context, sid, reqID string, current ssoclient.Claims) (string, ssoclient.Claims, bool) {
+		ttl, ok := sessionTTL(current, time.Now())
+		if !ok || ttl >= threshold {
+			return "", ssoclient.Claims{}, false
+		}
+
+		// ============================================================
+		// STEP 1: Токен шинэчлэх
+		// ============================================================
+		newSID, err := sso.RefreshToken(reqCtx, sid)
+		if err != nil || newSID == "" {
+			log.Warn("sso_refresh_failed", zap.String("request_id", reqID), zap.Duration("ttl", ttl), zap.Error(err))
+			return "", ssoclient.Claims{}, false
+		}
+
+		// Шинэ токены claims-ийг авч cache-д хадгална
+		claims, err := sso.GetClaims(reqCtx, newSID, reqID)
+		if err != nil {
+			log.Warn("sso_refresh_claims_failed", zap.String("request_id", reqID), zap.Error(err))
+			return "", ssoclient.Claims{}, false
+		}
+
+		// ============================================================
+		// STEP 2: Request-ийг шинэ токеноор солих
+		// ============================================================
+		// Дараагийн handler-ууд ExtractSID-ээр шинэ токеныг авна
+		fromCookie := cfg.Cookie.Name != "" && extractFromCookie(c, cfg.Cookie.Name) != ""
+		if fromCookie {
+			c.Request().Header.SetCookie(cfg.Cookie.Name, newSID)
+			// Browser-ийн cookie-г ч мөн шинэчилнэ
+			c.Cookie(&fiber.Cookie{
+				Name:     cfg.Cookie.Name,
+				Value:    newSID,
+				Domain:   sessCfg.CookieDomain,
+				Secure:   sessCfg.CookieSecure,
+				MaxAge:   sessCfg.CookieMaxAge,
+				HTTPOnly: true,
+				SameSite: fiber.CookieSameSiteLaxMode,
+			})
+		} else {
+			c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+newSID)
+			// HttpOnly cookie-ийн SID-ийг header-ээр JavaScript-д ил гаргахгүй;
+			// bearer client л шинэ токеноо header-ээс авна
+			c.Set(HeaderRefreshedToken, newSID)
+		}
+		log.Info("sso_session_refreshed", zap.String("request_id", reqID), zap.Duration("ttl", ttl))
+		return newSID, claims, true
+	}
+
+	return requireSession(cfg, log, sso, overrides, refresh)
+}
@@ -0,0 +1,99 @@
+// Package auth provides implementation for auth
+//
+// File: role.go
+// Description: Role-based (coarse-grained) authorization middleware
+/*
+Package auth нь SSO authentication болон authorization-ийг хариуцна.
+
+Энэ файл нь role-based authorization middleware-ийг тодорхойлно.
+RequirePermission нь нарийн permission код шалгадаг бол RequireRole нь
+хэрэглэгчийн role кодоор (жишээ: "ADMIN") хурдан, бүдүүвч шалгалт хийнэ.
+
+Ашиглалт:
+
+	roleCache := auth.NewRoleCache(userRoleRepo, 5*time.Minute)
+	app.Delete("/user/:id",
+	    auth.Require(cfg, log, cache),
+	    auth.RequireRole(roleCache, "SUPER_ADMIN", "ADMIN"),
+	    handler.Delete,
+	)
+*/
+package auth
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"git.gerege.mn/backend-packages/sso-client"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ============================================================
+// ROLE CHECKER INTERFACE
+// ============================================================
+
+// RoleChecker нь хэрэглэгчийн role кодуудыг буцаах интерфейс.
+// repository.UserRoleRepository болон RoleCache энэ интерфейсийг хангана.
+type RoleChecker interface {
+	// GetRoleCodes нь хэрэглэгчийн идэвхтэй role-уудын кодыг буцаана
+	GetRoleCodes(ctx context.Context, userID int) ([]string, error)
+}
+
+// ============================================================
+// REQUIRE ROLE
+// ============================================================
+
+// RequireRole нь өгөгдсөн role-уудын аль нэгийг шаардана.
+// Claims байхгүй бол 401, role таарахгүй бол 403 Forbidden буцаана.
+//
+// Parameters:
+//   - checker: Role шалгах source (ихэвчлэн RoleCache)
+//   - roles: Зөвшөөрөгдсөн role кодууд (хоосон бол бүгдэд зөвшөөрнө)
+//
+// Returns:
+//   - fiber.Handler: Middleware function
+//
+// Ашиглалт:
+//
+//	app.Delete("/user/:id", auth.RequireRole(roleCache, "SUPER_ADMIN", "ADMIN"), handler.Delete)
+func RequireRole(checker RoleChecker, roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// ============================================================
+		// STEP 1: Claims авах
+		// ============================================================
+		cl, ok := ssoclient.GetClaims(c)
+		if !ok || cl == nil {
+			return fiber.NewError(fiber.StatusUnauthorized, "no claims")
+		}
+		if cl.UserID == 0 {
+			return fiber.NewError(fiber.StatusForbidden, "user not authenticated")
+		}
+
+		// Хоосон жагсаалт = бүгдэд зөвшөөрнө
+		if len(roles) == 0 {
+			return c.Next()
+		}
+
+		// ============================================================
+		// STEP 2: Role шалгах
+		// ============================================================
+		ctx, cancel := context.WithTimeout(c.UserContext(), 3*time.Second)
+		defer cancel()
+
+		userRoles, err := checker.GetRoleCodes(ctx, cl.UserID)
+		if err != nil {
+			// DB алдаа - internal error биш 403 буцаах (security)
+			return fiber.NewError(fiber.StatusForbidden, "role check failed")
+		}
+
+		for _, role := range roles {
+			if slices.Contains(userRoles, role) {
+				return c.Next()
+			}
+		}
+
+		return fiber.NewError(fiber.StatusForbidden, "insufficient role")
+	}
+}
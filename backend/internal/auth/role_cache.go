@@ -0,0 +1,120 @@
+// Package auth provides implementation for auth
+//
+// File: role_cache.go
+// Description: Role code caching layer for RequireRole
+/*
+Package auth нь role caching-ийг хариуцна.
+
+PermissionCache-тэй ижил бүтэцтэй: хэрэглэгчийн role кодуудыг TTL-тэй
+in-memory cache-д хадгалж, RequireRole шалгалт бүрт DB руу очихгүй.
+
+Ашиглалт:
+
+	roleCache := auth.NewRoleCache(userRoleRepo, 5*time.Minute)
+
+	// Role оноолт өөрчлөгдөхөд хоёр cache-ийг хамт цэвэрлэх
+	svc.UserRole.SetCacheInvalidator(auth.CacheInvalidators{permCache, roleCache})
+*/
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ============================================================
+// ROLE CACHE
+// ============================================================
+
+// RoleCache нь хэрэглэгчийн role кодуудыг cache-лэх layer.
+// RoleChecker болон CacheInvalidator интерфейсийг implement хийнэ.
+type RoleCache struct {
+	source RoleChecker   // Underlying source (DB руу хандах)
+	cache  sync.Map      // userID -> *cachedPermissions
+	ttl    time.Duration // Cache TTL
+}
+
+// NewRoleCache нь шинэ role cache үүсгэнэ.
+//
+// Parameters:
+//   - source: Underlying role source (жишээ: repository.UserRoleRepository)
+//   - ttl: Cache-ийн хүчинтэй хугацаа (жишээ: 5*time.Minute)
+func NewRoleCache(source RoleChecker, ttl time.Duration) *RoleCache {
+	return &RoleCache{
+		source: source,
+		ttl:    ttl,
+	}
+}
+
+// GetRoleCodes нь хэрэглэгчийн role кодуудыг буцаана.
+// Cache-д байвал DB руу явахгүй.
+func (rc *RoleCache) GetRoleCodes(ctx context.Context, userID int) ([]string, error) {
+	if cached, ok := rc.cache.Load(userID); ok {
+		cr := cached.(*cachedPermissions)
+		if !cr.isExpired() {
+			return cr.codes, nil
+		}
+		rc.cache.Delete(userID)
+	}
+
+	roles, err := rc.source.GetRoleCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.cache.Store(userID, &cachedPermissions{
+		codes:     roles,
+		expiresAt: time.Now().Add(rc.ttl),
+	})
+	return roles, nil
+}
+
+// InvalidateUser нь тодорхой хэрэглэгчийн cache-ийг цэвэрлэнэ.
+func (rc *RoleCache) InvalidateUser(userID int) {
+	rc.cache.Delete(userID)
+}
+
+// InvalidateUsers нь олон хэрэглэгчийн cache-ийг цэвэрлэнэ.
+func (rc *RoleCache) InvalidateUsers(userIDs []int) {
+	for _, id := range userIDs {
+		rc.cache.Delete(id)
+	}
+}
+
+// InvalidateAll нь бүх cache-ийг цэвэрлэнэ.
+// sync.Map-ийг орлуулахгүй, байранд нь цэвэрлэдэг тул зэрэг уншиж буй
+// GetRoleCodes-той race үүсгэхгүй.
+func (rc *RoleCache) InvalidateAll() {
+	rc.cache.Clear()
+}
+
+// ============================================================
+// MULTIPLE INVALIDATORS
+// ============================================================
+
+// CacheInvalidators нь олон cache-ийг нэг CacheInvalidator болгон нэгтгэнэ.
+// Service-д нэг invalidator л дамжуулдаг тул permission болон role cache-ийг
+// хамт цэвэрлэхэд ашиглана.
+type CacheInvalidators []CacheInvalidator
+
+// InvalidateUser нь бүх cache-д нэг хэрэглэгчийг цэвэрлэнэ
+func (cs CacheInvalidators) InvalidateUser(userID int) {
+	for _, c := range cs {
+		c.InvalidateUser(userID)
+	}
+}
+
+// InvalidateUsers нь бүх cache-д олон хэрэглэгчийг цэвэрлэнэ
+func (cs CacheInvalidators) InvalidateUsers(userIDs []int) {
+	for _, c := range cs {
+		c.InvalidateUsers(userIDs)
+	}
+}
+
+// InvalidateAll нь бүх cache-ийг цэвэрлэнэ
+func (cs CacheInvalidators) InvalidateAll() {
+	for _, c := range cs {
+		c.InvalidateAll()
+	}
+}
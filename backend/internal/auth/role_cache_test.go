@@ -0,0 +1,139 @@
+// Package auth provides authentication and authorization utilities
+//
+// File: role_cache_test.go
+// Description: Unit tests for role cache
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRoleSource is a mock implementation of RoleChecker
+type mockRoleSource struct {
+	roles     map[int][]string
+	err       error
+	callCount int
+}
+
+func (m *mockRoleSource) GetRoleCodes(ctx context.Context, userID int) ([]string, error) {
+	m.callCount++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.roles[userID], nil
+}
+
+func TestRoleCache_GetRoleCodes(t *testing.T) {
+	src := &mockRoleSource{roles: map[int][]string{1: {"ADMIN"}}}
+	cache := NewRoleCache(src, 5*time.Minute)
+	ctx := context.Background()
+
+	roles, err := cache.GetRoleCodes(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ADMIN"}, roles)
+
+	// Хоёр дахь удаа cache-ээс авна
+	roles, err = cache.GetRoleCodes(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ADMIN"}, roles)
+	assert.Equal(t, 1, src.callCount)
+}
+
+func TestRoleCache_Expiry(t *testing.T) {
+	src := &mockRoleSource{roles: map[int][]string{1: {"ADMIN"}}}
+	cache := NewRoleCache(src, 10*time.Millisecond)
+	ctx := context.Background()
+
+	_, _ = cache.GetRoleCodes(ctx, 1)
+	time.Sleep(20 * time.Millisecond)
+	_, _ = cache.GetRoleCodes(ctx, 1)
+	assert.Equal(t, 2, src.callCount)
+}
+
+func TestRoleCache_ErrorNotCached(t *testing.T) {
+	src := &mockRoleSource{err: errors.New("db error")}
+	cache := NewRoleCache(src, 5*time.Minute)
+	ctx := context.Background()
+
+	_, err := cache.GetRoleCodes(ctx, 1)
+	assert.Error(t, err)
+
+	src.err = nil
+	src.roles = map[int][]string{1: {"ADMIN"}}
+	roles, err := cache.GetRoleCodes(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ADMIN"}, roles)
+	assert.Equal(t, 2, src.callCount)
+}
+
+// staticRoleSource нь зэрэг дуудахад аюулгүй RoleChecker (тоолуургүй)
+type staticRoleSource []string
+
+func (s staticRoleSource) GetRoleCodes(ctx context.Context, userID int) ([]string, error) {
+	return s, nil
+}
+
+// go test -race дээр InvalidateAll болон GetRoleCodes зэрэг ажиллахад race гарахгүй
+func TestRoleCache_InvalidateAllConcurrent(t *testing.T) {
+	cache := NewRoleCache(staticRoleSource{"ADMIN"}, 5*time.Minute)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(userID int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				roles, err := cache.GetRoleCodes(ctx, userID)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"ADMIN"}, roles)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				cache.InvalidateAll()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCacheInvalidators(t *testing.T) {
+	permSrc := newMockChecker(map[int][]string{1: {"admin.user.read"}, 2: {"admin.role.read"}})
+	roleSrc := &mockRoleSource{roles: map[int][]string{1: {"ADMIN"}, 2: {"SUPPORT"}}}
+	permCache := NewPermissionCache(permSrc, PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
+	roleCache := NewRoleCache(roleSrc, 5*time.Minute)
+	inv := CacheInvalidators{permCache, roleCache}
+	ctx := context.Background()
+
+	warm := func() {
+		for _, id := range []int{1, 2} {
+			_, _ = permCache.GetUserPermissions(ctx, id)
+			_, _ = roleCache.GetRoleCodes(ctx, id)
+		}
+	}
+
+	warm()
+	inv.InvalidateUser(1)
+	warm()
+	assert.Equal(t, 3, permSrc.callCount)
+	assert.Equal(t, 3, roleSrc.callCount)
+
+	inv.InvalidateUsers([]int{1, 2})
+	warm()
+	assert.Equal(t, 5, permSrc.callCount)
+	assert.Equal(t, 5, roleSrc.callCount)
+
+	inv.InvalidateAll()
+	warm()
+	assert.Equal(t, 7, permSrc.callCount)
+	assert.Equal(t, 7, roleSrc.callCount)
+}
@@ -0,0 +1,116 @@
+// Package cache provides in-memory caching with TTL support
+//
+// File: lru.go
+// Description: Size-bounded least-recently-used cache with TTL
+//
+// Cache[T] evicts the entry that expires first, which with a fixed TTL is the
+// oldest insert. LRU[T] evicts the least recently *read or written* entry, so
+// frequently requested keys (e.g. popular search terms) stay cached.
+//
+// Usage:
+//
+//	results := cache.NewLRU[[]domain.ChatItem](200, 5*time.Minute)
+//	results.Set("q:hello", items)
+//	items, found := results.Get("q:hello")
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lruEntry нь list-ийн элемент дэх key/value
+type lruEntry[T any] struct {
+	key       string
+	value     T
+	expiresAt time.Time
+}
+
+// LRU is a thread-safe least-recently-used cache with a fixed capacity and TTL.
+// Expired entries are dropped lazily on Get (no background goroutine).
+type LRU[T any] struct {
+	mu      sync.Mutex
+	maxSize int
+	ttl     time.Duration
+	ll      *list.List // front: most recently used
+	items   map[string]*list.Element
+	now     func() time.Time
+}
+
+// NewLRU creates an LRU cache holding at most maxSize entries for ttl each
+func NewLRU[T any](maxSize int, ttl time.Duration) *LRU[T] {
+	if maxSize <= 0 {
+		maxSize = 1000
+	}
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &LRU[T]{
+		maxSize: maxSize,
+		ttl:     ttl,
+		ll:      list.New(),
+		items:   make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value and marks it as most recently used.
+// Expired entries are removed and reported as not found.
+func (c *LRU[T]) Get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero T
+	el, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	e := el.Value.(*lruEntry[T])
+	if c.now().After(e.expiresAt) {
+		c.removeElement(el)
+		return zero, false
+	}
+	c.ll.MoveToFront(el)
+	return e.value, true
+}
+
+// Set adds or replaces a value, evicting the least recently used entry when full
+func (c *LRU[T]) Set(key string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*lruEntry[T])
+		e.value, e.expiresAt = value, expiresAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&lruEntry[T]{key: key, value: value, expiresAt: expiresAt})
+	if c.ll.Len() > c.maxSize {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+// Clear removes all entries
+func (c *LRU[T]) Clear() {
+	c.mu.Lock()
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+	c.mu.Unlock()
+}
+
+// Len returns the number of entries (including expired ones not yet read)
+func (c *LRU[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// removeElement нь элементийг list болон map-аас хасна (mu түгжигдсэн байх ёстой)
+func (c *LRU[T]) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*lruEntry[T]).key)
+}
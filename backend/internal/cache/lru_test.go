@@ -0,0 +1,129 @@
+// Package cache provides in-memory caching with TTL support
+//
+// File: lru_test.go
+// Description: Unit tests for the LRU cache
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLRU_Defaults(t *testing.T) {
+	c := NewLRU[int](0, 0)
+
+	assert.Equal(t, 1000, c.maxSize)
+	assert.Equal(t, 5*time.Minute, c.ttl)
+}
+
+func TestLRU_SetAndGet(t *testing.T) {
+	c := NewLRU[string](2, time.Minute)
+
+	c.Set("a", "1")
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", v)
+
+	c.Set("a", "2")
+	v, _ = c.Get("a")
+	assert.Equal(t, "2", v)
+	assert.Equal(t, 1, c.Len())
+
+	_, ok = c.Get("missing")
+	assert.False(t, ok)
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[int](3, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	// a-г уншсанаар b хамгийн удаан ашиглагдаагүй болно
+	_, _ = c.Get("a")
+	c.Set("d", 4)
+
+	assert.Equal(t, 3, c.Len())
+	_, ok := c.Get("b")
+	assert.False(t, ok, "b should be evicted")
+	for _, k := range []string{"a", "c", "d"} {
+		_, ok := c.Get(k)
+		assert.True(t, ok, k)
+	}
+}
+
+func TestLRU_UpdateRefreshesRecency(t *testing.T) {
+	c := NewLRU[int](2, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("a", 10) // a шинэчлэгдэж front руу шилжинэ
+	c.Set("c", 3)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+}
+
+func TestLRU_CapacityUnderLoad(t *testing.T) {
+	c := NewLRU[int](200, time.Minute)
+	for i := 0; i < 1000; i++ {
+		c.Set(fmt.Sprintf("k%d", i), i)
+	}
+
+	assert.Equal(t, 200, c.Len())
+	_, ok := c.Get("k799")
+	assert.False(t, ok)
+	v, ok := c.Get("k800")
+	assert.True(t, ok)
+	assert.Equal(t, 800, v)
+}
+
+func TestLRU_Expiration(t *testing.T) {
+	c := NewLRU[int](2, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	now = now.Add(time.Minute + time.Second)
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len(), "expired entry is removed on Get")
+}
+
+func TestLRU_Clear(t *testing.T) {
+	c := NewLRU[int](2, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	c.Clear()
+
+	assert.Equal(t, 0, c.Len())
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestLRU_ConcurrentAccess(t *testing.T) {
+	c := NewLRU[int](50, time.Minute)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprintf("k%d", (g*500+i)%120)
+				c.Set(key, i)
+				c.Get(key)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, c.Len(), 50)
+}
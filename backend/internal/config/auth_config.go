@@ -5,8 +5,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -23,6 +26,25 @@ func (c *RedisConfig) Addr() string {
 	return c.Host + ":" + c.Port
 }
 
+// LockIndefinite marks a lockout that lasts until an admin unlocks the account
+const LockIndefinite time.Duration = -1
+
+// LockThreshold locks the account for Duration once failed attempts reach Attempts
+type LockThreshold struct {
+	Attempts int
+	Duration time.Duration // LockIndefinite: admin unlock only
+}
+
+// DefaultLockSchedule returns the default exponential lockout table
+func DefaultLockSchedule() []LockThreshold {
+	return []LockThreshold{
+		{Attempts: 3, Duration: time.Minute},
+		{Attempts: 5, Duration: 15 * time.Minute},
+		{Attempts: 7, Duration: 2 * time.Hour},
+		{Attempts: 10, Duration: LockIndefinite},
+	}
+}
+
 // LocalAuthConfig holds local authentication settings
 type LocalAuthConfig struct {
 	// Enabled indicates if local authentication is enabled
@@ -34,15 +56,37 @@ type LocalAuthConfig struct {
 	// MFATokenTTL is the MFA pending token lifetime
 	MFATokenTTL time.Duration
 
-	// LockoutThreshold is the number of failed attempts before lockout
+	// LockoutThreshold is the number of failed attempts before lockout (used when LockSchedule is empty)
 	LockoutThreshold int
 
-	// LockoutDuration is how long the account stays locked
+	// LockoutDuration is how long the account stays locked (used when LockSchedule is empty)
 	LockoutDuration time.Duration
 
-	// PasswordMinLength is the minimum password length
+	// LockSchedule maps failed attempt counts to lock durations (exponential backoff)
+	LockSchedule []LockThreshold
+
+	// PermanentLockAfter suspends the user (status "suspended") instead of a
+	// time-limited lock once failed attempts reach this count. 0 disables.
+	PermanentLockAfter int
+
+	// LockNotifyEmails are the admin addresses emailed when an account gets locked
+	LockNotifyEmails []string
+
+	// PasswordMinLength is the minimum password length (in characters)
 	PasswordMinLength int
 
+	// PasswordRequireUppercase requires at least one uppercase letter
+	PasswordRequireUppercase bool
+
+	// PasswordRequireLowercase requires at least one lowercase letter
+	PasswordRequireLowercase bool
+
+	// PasswordRequireDigit requires at least one digit
+	PasswordRequireDigit bool
+
+	// PasswordRequireSpecial requires at least one punctuation or symbol character
+	PasswordRequireSpecial bool
+
 	// PasswordHistoryCount is how many previous passwords to check
 	PasswordHistoryCount int
 
@@ -51,12 +95,31 @@ type LocalAuthConfig struct {
 
 	// EncryptionKey is the 32-byte key for encrypting TOTP secrets
 	EncryptionKey string
+
+	// PasswordResetURL is the frontend page that accepts ?token= (empty: token is emailed as text)
+	PasswordResetURL string
+
+	// RegistrationEnabled allows self-service signup via POST /auth/local/register
+	RegistrationEnabled bool
+
+	// EmailVerificationURL is the frontend page that accepts ?token= (empty: token is emailed as text)
+	EmailVerificationURL string
+}
+
+// GoogleOAuthConfig holds Google OAuth2 client settings (authorization code flow)
+type GoogleOAuthConfig struct {
+	// ClientID is the OAuth client ID; empty disables Google code exchange
+	ClientID string
+
+	// ClientSecret is the OAuth client secret
+	ClientSecret string
 }
 
 // AuthConfig combines all auth-related configurations
 type AuthConfig struct {
 	Redis     RedisConfig
 	LocalAuth LocalAuthConfig
+	Google    GoogleOAuthConfig
 }
 
 // LoadAuthConfig loads authentication configuration from environment variables
@@ -69,15 +132,29 @@ func LoadAuthConfig() *AuthConfig {
 			DB:       getEnvInt("REDIS_DB", 0),
 		},
 		LocalAuth: LocalAuthConfig{
-			Enabled:              getEnvBool("LOCAL_AUTH_ENABLED", true),
-			SessionTTL:           getEnvDuration("LOCAL_AUTH_SESSION_TTL", 24*time.Hour),
-			MFATokenTTL:          getEnvDuration("LOCAL_AUTH_MFA_TOKEN_TTL", 5*time.Minute),
-			LockoutThreshold:     getEnvInt("LOCAL_AUTH_LOCKOUT_THRESHOLD", 5),
-			LockoutDuration:      getEnvDuration("LOCAL_AUTH_LOCKOUT_DURATION", 15*time.Minute),
-			PasswordMinLength:    getEnvInt("LOCAL_AUTH_PASSWORD_MIN_LENGTH", 8),
-			PasswordHistoryCount: getEnvInt("LOCAL_AUTH_PASSWORD_HISTORY_COUNT", 5),
-			TOTPIssuer:           getEnv("LOCAL_AUTH_TOTP_ISSUER", "TemplateBackend"),
-			EncryptionKey:        getEnv("LOCAL_AUTH_ENCRYPTION_KEY", ""),
+			Enabled:                  getEnvBool("LOCAL_AUTH_ENABLED", true),
+			SessionTTL:               getEnvDuration("LOCAL_AUTH_SESSION_TTL", 24*time.Hour),
+			MFATokenTTL:              getEnvDuration("LOCAL_AUTH_MFA_TOKEN_TTL", 5*time.Minute),
+			LockoutThreshold:         getEnvInt("LOCAL_AUTH_LOCKOUT_THRESHOLD", 5),
+			LockoutDuration:          getEnvDuration("LOCAL_AUTH_LOCKOUT_DURATION", 15*time.Minute),
+			LockSchedule:             getEnvLockSchedule("LOCAL_AUTH_LOCK_SCHEDULE", DefaultLockSchedule()),
+			PermanentLockAfter:       getEnvInt("LOCAL_AUTH_PERMANENT_LOCK_AFTER", 10),
+			LockNotifyEmails:         getEnvList("LOCAL_AUTH_LOCK_NOTIFY_EMAILS"),
+			PasswordMinLength:        getEnvInt("LOCAL_AUTH_PASSWORD_MIN_LENGTH", 8),
+			PasswordRequireUppercase: getEnvBool("LOCAL_AUTH_PASSWORD_REQUIRE_UPPERCASE", true),
+			PasswordRequireLowercase: getEnvBool("LOCAL_AUTH_PASSWORD_REQUIRE_LOWERCASE", true),
+			PasswordRequireDigit:     getEnvBool("LOCAL_AUTH_PASSWORD_REQUIRE_DIGIT", true),
+			PasswordRequireSpecial:   getEnvBool("LOCAL_AUTH_PASSWORD_REQUIRE_SPECIAL", false),
+			PasswordHistoryCount:     getEnvInt("LOCAL_AUTH_PASSWORD_HISTORY_COUNT", 5),
+			TOTPIssuer:               getEnv("LOCAL_AUTH_TOTP_ISSUER", "TemplateBackend"),
+			EncryptionKey:            getEnv("LOCAL_AUTH_ENCRYPTION_KEY", ""),
+			PasswordResetURL:         getEnv("LOCAL_AUTH_PASSWORD_RESET_URL", ""),
+			RegistrationEnabled:      getEnvBool("LOCAL_AUTH_REGISTRATION_ENABLED", true),
+			EmailVerificationURL:     getEnv("LOCAL_AUTH_EMAIL_VERIFICATION_URL", ""),
+		},
+		Google: GoogleOAuthConfig{
+			ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
+			ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 		},
 	}
 }
@@ -119,3 +196,53 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// getEnvList returns the comma-separated environment variable as a list (empty entries dropped)
+func getEnvList(key string) []string {
+	var out []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// getEnvLockSchedule returns the environment variable as a lock schedule or a default
+func getEnvLockSchedule(key string, defaultValue []LockThreshold) []LockThreshold {
+	if value := os.Getenv(key); value != "" {
+		if schedule, err := ParseLockSchedule(value); err == nil {
+			return schedule
+		}
+	}
+	return defaultValue
+}
+
+// ParseLockSchedule parses "3:1m,5:15m,7:2h,10:indefinite" into a schedule sorted by attempts
+func ParseLockSchedule(s string) ([]LockThreshold, error) {
+	var schedule []LockThreshold
+	for _, entry := range strings.Split(s, ",") {
+		attemptsStr, durationStr, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid lock schedule entry %q", entry)
+		}
+
+		attempts, err := strconv.Atoi(strings.TrimSpace(attemptsStr))
+		if err != nil || attempts <= 0 {
+			return nil, fmt.Errorf("invalid attempts in lock schedule entry %q", entry)
+		}
+
+		duration := LockIndefinite
+		if durationStr = strings.TrimSpace(durationStr); durationStr != "indefinite" {
+			duration, err = time.ParseDuration(durationStr)
+			if err != nil || duration <= 0 {
+				return nil, fmt.Errorf("invalid duration in lock schedule entry %q", entry)
+			}
+		}
+
+		schedule = append(schedule, LockThreshold{Attempts: attempts, Duration: duration})
+	}
+
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].Attempts < schedule[j].Attempts })
+	return schedule, nil
+}
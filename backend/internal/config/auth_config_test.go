@@ -0,0 +1,63 @@
+// Package config provides local configuration for auth and related features
+//
+// File: auth_config_test.go
+// Description: Unit tests for the lockout schedule configuration
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLockSchedule(t *testing.T) {
+	got, err := ParseLockSchedule("7:2h, 3:1m,5:15m,10:indefinite")
+
+	require.NoError(t, err)
+	assert.Equal(t, []LockThreshold{
+		{Attempts: 3, Duration: time.Minute},
+		{Attempts: 5, Duration: 15 * time.Minute},
+		{Attempts: 7, Duration: 2 * time.Hour},
+		{Attempts: 10, Duration: LockIndefinite},
+	}, got)
+}
+
+func TestParseLockSchedule_Invalid(t *testing.T) {
+	for _, in := range []string{"3", "x:1m", "0:1m", "3:soon", "3:-1m", "3:0s"} {
+		_, err := ParseLockSchedule(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestLoadAuthConfig_LockSchedule(t *testing.T) {
+	t.Setenv("LOCAL_AUTH_LOCK_SCHEDULE", "")
+	assert.Equal(t, DefaultLockSchedule(), LoadAuthConfig().LocalAuth.LockSchedule)
+
+	t.Setenv("LOCAL_AUTH_LOCK_SCHEDULE", "2:30s")
+	assert.Equal(t, []LockThreshold{{Attempts: 2, Duration: 30 * time.Second}}, LoadAuthConfig().LocalAuth.LockSchedule)
+
+	// Буруу утга бол default руу буцна
+	t.Setenv("LOCAL_AUTH_LOCK_SCHEDULE", "bogus")
+	assert.Equal(t, DefaultLockSchedule(), LoadAuthConfig().LocalAuth.LockSchedule)
+}
+
+func TestLoadAuthConfig_PermanentLockAfter(t *testing.T) {
+	t.Setenv("LOCAL_AUTH_PERMANENT_LOCK_AFTER", "")
+	assert.Equal(t, 10, LoadAuthConfig().LocalAuth.PermanentLockAfter)
+
+	t.Setenv("LOCAL_AUTH_PERMANENT_LOCK_AFTER", "0")
+	assert.Equal(t, 0, LoadAuthConfig().LocalAuth.PermanentLockAfter)
+
+	t.Setenv("LOCAL_AUTH_PERMANENT_LOCK_AFTER", "20")
+	assert.Equal(t, 20, LoadAuthConfig().LocalAuth.PermanentLockAfter)
+}
+
+func TestLoadAuthConfig_LockNotifyEmails(t *testing.T) {
+	t.Setenv("LOCAL_AUTH_LOCK_NOTIFY_EMAILS", "")
+	assert.Empty(t, LoadAuthConfig().LocalAuth.LockNotifyEmails)
+
+	t.Setenv("LOCAL_AUTH_LOCK_NOTIFY_EMAILS", " admin@example.com, ,security@example.com ")
+	assert.Equal(t, []string{"admin@example.com", "security@example.com"}, LoadAuthConfig().LocalAuth.LockNotifyEmails)
+}
@@ -0,0 +1,19 @@
+// Package config provides local configuration for auth and related features
+//
+// File: chat_config.go
+// Description: Settings for chat item search
+package config
+
+// ChatConfig holds chat item (chat bot answers) settings
+type ChatConfig struct {
+	// FullTextSearch switches GET /chat?q= from ILIKE to PostgreSQL full-text search
+	// (to_tsvector('simple', answer), see migrations/024_chat_items_answer_fts.sql)
+	FullTextSearch bool
+}
+
+// LoadChatConfig loads chat configuration from environment variables
+func LoadChatConfig() *ChatConfig {
+	return &ChatConfig{
+		FullTextSearch: getEnvBool("CHAT_SEARCH_FULLTEXT", false),
+	}
+}
@@ -0,0 +1,42 @@
+// Package config provides local configuration for auth and related features
+//
+// File: cleanup_config.go
+// Description: Settings for the background cleanup job (sessions, login history)
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// CleanupConfig holds settings for the periodic session/login history cleanup job
+type CleanupConfig struct {
+	// Enabled indicates if the cleanup job runs
+	Enabled bool
+
+	// Interval is how often expired sessions and old login history are deleted
+	Interval time.Duration
+
+	// LoginHistoryRetentionDays is how many days of login history to keep
+	LoginHistoryRetentionDays int
+}
+
+// LoadCleanupConfig loads cleanup job configuration from environment variables
+func LoadCleanupConfig() *CleanupConfig {
+	return &CleanupConfig{
+		Enabled:                   getEnvBool("CLEANUP_ENABLED", true),
+		Interval:                  getEnvDuration("CLEANUP_INTERVAL", 24*time.Hour),
+		LoginHistoryRetentionDays: getEnvInt("CLEANUP_LOGIN_HISTORY_RETENTION_DAYS", 90),
+	}
+}
+
+// Validate checks that the interval and retention are usable
+func (c *CleanupConfig) Validate() error {
+	if c.Interval <= 0 {
+		return fmt.Errorf("CLEANUP_INTERVAL must be positive, got %s", c.Interval)
+	}
+	if c.LoginHistoryRetentionDays <= 0 {
+		return fmt.Errorf("CLEANUP_LOGIN_HISTORY_RETENTION_DAYS must be positive, got %d", c.LoginHistoryRetentionDays)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Package config provides local configuration for auth and related features
+//
+// File: cleanup_config_test.go
+// Description: Unit tests for cleanup job configuration
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCleanupConfig_Defaults(t *testing.T) {
+	t.Setenv("CLEANUP_ENABLED", "")
+	t.Setenv("CLEANUP_INTERVAL", "")
+	t.Setenv("CLEANUP_LOGIN_HISTORY_RETENTION_DAYS", "")
+
+	cfg := LoadCleanupConfig()
+
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, 24*time.Hour, cfg.Interval)
+	assert.Equal(t, 90, cfg.LoginHistoryRetentionDays)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadCleanupConfig_FromEnv(t *testing.T) {
+	t.Setenv("CLEANUP_ENABLED", "false")
+	t.Setenv("CLEANUP_INTERVAL", "6h")
+	t.Setenv("CLEANUP_LOGIN_HISTORY_RETENTION_DAYS", "30")
+
+	cfg := LoadCleanupConfig()
+
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, 6*time.Hour, cfg.Interval)
+	assert.Equal(t, 30, cfg.LoginHistoryRetentionDays)
+}
+
+func TestCleanupConfig_Validate(t *testing.T) {
+	assert.Error(t, (&CleanupConfig{Interval: 0, LoginHistoryRetentionDays: 90}).Validate())
+	assert.Error(t, (&CleanupConfig{Interval: time.Hour, LoginHistoryRetentionDays: 0}).Validate())
+	assert.NoError(t, (&CleanupConfig{Interval: time.Hour, LoginHistoryRetentionDays: 1}).Validate())
+}
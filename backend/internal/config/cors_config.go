@@ -0,0 +1,45 @@
+// Package config provides local configuration for auth and related features
+//
+// File: cors_config.go
+// Description: CORS policy settings not covered by the shared config package
+package config
+
+import "time"
+
+// Default CORS policy values (ApplyMiddlewares-д өмнө hardcode хийгдсэн утгууд)
+const (
+	DefaultCORSAllowMethods  = "GET,POST,PUT,PATCH,DELETE,OPTIONS"
+	DefaultCORSAllowHeaders  = "Content-Type,Authorization,X-CSRF-Token,X-Idempotency-Key"
+	DefaultCORSExposeHeaders = "X-Refreshed-Token,X-Idempotent-Replayed"
+)
+
+// CORSConfig holds the CORS settings beyond AllowOrigins/AllowCredentials,
+// which stay in the shared config package (cfg.CORS)
+type CORSConfig struct {
+	// AllowMethods is the comma separated Access-Control-Allow-Methods list
+	AllowMethods string
+
+	// AllowHeaders is the comma separated Access-Control-Allow-Headers list
+	AllowHeaders string
+
+	// ExposeHeaders is the comma separated Access-Control-Expose-Headers list
+	ExposeHeaders string
+
+	// MaxAge is how long browsers may cache a preflight response (0 = not sent)
+	MaxAge time.Duration
+
+	// AuthAllowOrigins is the stricter origin list for /auth/local/* routes.
+	// Empty means the global cfg.CORS.AllowOrigins is used.
+	AuthAllowOrigins string
+}
+
+// LoadCORSConfig loads CORS configuration from environment variables
+func LoadCORSConfig() *CORSConfig {
+	return &CORSConfig{
+		AllowMethods:     getEnv("CORS_ALLOW_METHODS", DefaultCORSAllowMethods),
+		AllowHeaders:     getEnv("CORS_ALLOW_HEADERS", DefaultCORSAllowHeaders),
+		ExposeHeaders:    getEnv("CORS_EXPOSE_HEADERS", DefaultCORSExposeHeaders),
+		MaxAge:           getEnvDuration("CORS_MAX_AGE", 0),
+		AuthAllowOrigins: getEnv("CORS_AUTH_ALLOW_ORIGINS", ""),
+	}
+}
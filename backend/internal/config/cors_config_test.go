@@ -0,0 +1,44 @@
+// Package config provides local configuration for auth and related features
+//
+// File: cors_config_test.go
+// Description: Unit tests for CORS configuration
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCORSConfig_Defaults(t *testing.T) {
+	t.Setenv("CORS_ALLOW_METHODS", "")
+	t.Setenv("CORS_ALLOW_HEADERS", "")
+	t.Setenv("CORS_EXPOSE_HEADERS", "")
+	t.Setenv("CORS_MAX_AGE", "")
+	t.Setenv("CORS_AUTH_ALLOW_ORIGINS", "")
+
+	cfg := LoadCORSConfig()
+
+	assert.Equal(t, DefaultCORSAllowMethods, cfg.AllowMethods)
+	assert.Equal(t, DefaultCORSAllowHeaders, cfg.AllowHeaders)
+	assert.Equal(t, DefaultCORSExposeHeaders, cfg.ExposeHeaders)
+	assert.Equal(t, time.Duration(0), cfg.MaxAge)
+	assert.Empty(t, cfg.AuthAllowOrigins)
+}
+
+func TestLoadCORSConfig_FromEnv(t *testing.T) {
+	t.Setenv("CORS_ALLOW_METHODS", "GET,POST")
+	t.Setenv("CORS_ALLOW_HEADERS", "Content-Type")
+	t.Setenv("CORS_EXPOSE_HEADERS", "X-Request-ID")
+	t.Setenv("CORS_MAX_AGE", "1h")
+	t.Setenv("CORS_AUTH_ALLOW_ORIGINS", "https://app.example.com")
+
+	cfg := LoadCORSConfig()
+
+	assert.Equal(t, "GET,POST", cfg.AllowMethods)
+	assert.Equal(t, "Content-Type", cfg.AllowHeaders)
+	assert.Equal(t, "X-Request-ID", cfg.ExposeHeaders)
+	assert.Equal(t, time.Hour, cfg.MaxAge)
+	assert.Equal(t, "https://app.example.com", cfg.AuthAllowOrigins)
+}
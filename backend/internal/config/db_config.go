@@ -0,0 +1,32 @@
+// Package config provides local configuration for auth and related features
+//
+// File: db_config.go
+// Description: Database settings not covered by backend-packages/config (slow query logging)
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DBConfig holds local database settings
+type DBConfig struct {
+	// SlowQueryThreshold is the duration above which a query is logged at WARN
+	// and counted in db_slow_queries_total
+	SlowQueryThreshold time.Duration
+}
+
+// LoadDBConfig loads database configuration from environment variables
+func LoadDBConfig() *DBConfig {
+	return &DBConfig{
+		SlowQueryThreshold: getEnvDuration("DB_SLOW_QUERY_THRESHOLD", 500*time.Millisecond),
+	}
+}
+
+// Validate checks that the slow query threshold is usable
+func (c *DBConfig) Validate() error {
+	if c.SlowQueryThreshold <= 0 {
+		return fmt.Errorf("DB_SLOW_QUERY_THRESHOLD must be positive, got %s", c.SlowQueryThreshold)
+	}
+	return nil
+}
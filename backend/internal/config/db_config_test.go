@@ -0,0 +1,33 @@
+// Package config provides local configuration for auth and related features
+//
+// File: db_config_test.go
+// Description: Unit tests for local database configuration
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDBConfig_Defaults(t *testing.T) {
+	t.Setenv("DB_SLOW_QUERY_THRESHOLD", "")
+
+	cfg := LoadDBConfig()
+
+	assert.Equal(t, 500*time.Millisecond, cfg.SlowQueryThreshold)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadDBConfig_FromEnv(t *testing.T) {
+	t.Setenv("DB_SLOW_QUERY_THRESHOLD", "2s")
+
+	assert.Equal(t, 2*time.Second, LoadDBConfig().SlowQueryThreshold)
+}
+
+func TestDBConfig_Validate(t *testing.T) {
+	assert.Error(t, (&DBConfig{SlowQueryThreshold: 0}).Validate())
+	assert.Error(t, (&DBConfig{SlowQueryThreshold: -time.Second}).Validate())
+	assert.NoError(t, (&DBConfig{SlowQueryThreshold: time.Millisecond}).Validate())
+}
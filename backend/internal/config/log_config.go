@@ -0,0 +1,22 @@
+// Package config provides local configuration for auth and related features
+//
+// File: log_config.go
+// Description: API log worker settings (dead-letter file for dropped entries)
+package config
+
+// DefaultLogDeadLetterPath is where dropped API log entries are written
+const DefaultLogDeadLetterPath = "dead_letter.log"
+
+// LogConfig holds API log settings not covered by the shared config package
+type LogConfig struct {
+	// DeadLetterPath is the file that receives API log entries dropped because the
+	// worker queue was full (newline-delimited JSON, appended)
+	DeadLetterPath string
+}
+
+// LoadLogConfig loads API log configuration from environment variables
+func LoadLogConfig() *LogConfig {
+	return &LogConfig{
+		DeadLetterPath: getEnv("LOG_DEAD_LETTER_PATH", DefaultLogDeadLetterPath),
+	}
+}
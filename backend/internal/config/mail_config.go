@@ -0,0 +1,65 @@
+// Package config provides local configuration for auth and related features
+//
+// File: mail_config.go
+// Description: SMTP transport settings for transactional email (verification, password reset, lockout)
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultSMTPPort is the mail submission port (STARTTLS)
+const DefaultSMTPPort = 587
+
+// MailConfig holds the outgoing mail transport settings.
+// An empty Host keeps the log-only mailer (emails are logged, not sent).
+type MailConfig struct {
+	// Host is the SMTP server host; empty disables sending
+	Host string
+
+	// Port is the SMTP server port
+	Port int
+
+	// Username is the SMTP AUTH user (empty: no authentication)
+	Username string
+
+	// Password is the SMTP AUTH password
+	Password string
+
+	// From is the envelope sender and From header address
+	From string
+}
+
+// LoadMailConfig loads mail transport configuration from environment variables
+func LoadMailConfig() *MailConfig {
+	return &MailConfig{
+		Host:     strings.TrimSpace(getEnv("SMTP_HOST", "")),
+		Port:     getEnvInt("SMTP_PORT", DefaultSMTPPort),
+		Username: getEnv("SMTP_USERNAME", ""),
+		Password: getEnv("SMTP_PASSWORD", ""),
+		From:     strings.TrimSpace(getEnv("SMTP_FROM", "")),
+	}
+}
+
+// Enabled reports whether an SMTP server is configured
+func (c *MailConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// Validate checks that a configured SMTP server has a usable port and sender
+func (c *MailConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("SMTP_PORT must be between 1 and 65535, got %d", c.Port)
+	}
+	if c.From == "" {
+		return fmt.Errorf("SMTP_FROM is required when SMTP_HOST is set")
+	}
+	if strings.ContainsAny(c.From, "\r\n") {
+		return fmt.Errorf("SMTP_FROM must not contain line breaks")
+	}
+	return nil
+}
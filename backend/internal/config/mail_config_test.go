@@ -0,0 +1,52 @@
+// Package config provides local configuration for auth and related features
+//
+// File: mail_config_test.go
+// Description: Unit tests for SMTP mail configuration
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMailConfig_Defaults(t *testing.T) {
+	t.Setenv("SMTP_HOST", "")
+	t.Setenv("SMTP_PORT", "")
+	t.Setenv("SMTP_USERNAME", "")
+	t.Setenv("SMTP_PASSWORD", "")
+	t.Setenv("SMTP_FROM", "")
+
+	cfg := LoadMailConfig()
+
+	assert.False(t, cfg.Enabled())
+	assert.Equal(t, DefaultSMTPPort, cfg.Port)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadMailConfig_FromEnv(t *testing.T) {
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_PORT", "2525")
+	t.Setenv("SMTP_USERNAME", "mailer")
+	t.Setenv("SMTP_PASSWORD", "secret")
+	t.Setenv("SMTP_FROM", "no-reply@example.com")
+
+	cfg := LoadMailConfig()
+
+	assert.True(t, cfg.Enabled())
+	assert.Equal(t, "smtp.example.com", cfg.Host)
+	assert.Equal(t, 2525, cfg.Port)
+	assert.Equal(t, "mailer", cfg.Username)
+	assert.Equal(t, "secret", cfg.Password)
+	assert.Equal(t, "no-reply@example.com", cfg.From)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestMailConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&MailConfig{}).Validate(), "disabled config is valid")
+	assert.Error(t, (&MailConfig{Host: "smtp", Port: 0, From: "a@b.c"}).Validate())
+	assert.Error(t, (&MailConfig{Host: "smtp", Port: 70000, From: "a@b.c"}).Validate())
+	assert.Error(t, (&MailConfig{Host: "smtp", Port: 587}).Validate())
+	assert.Error(t, (&MailConfig{Host: "smtp", Port: 587, From: "a@b.c\r\nBcc: x@y.z"}).Validate())
+	assert.NoError(t, (&MailConfig{Host: "smtp", Port: 587, From: "a@b.c"}).Validate())
+}
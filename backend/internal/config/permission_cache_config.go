@@ -0,0 +1,65 @@
+// Package config provides local configuration for auth and related features
+//
+// File: permission_cache_config.go
+// Description: Settings for the permission cache (TTL, size, per-user TTL)
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PermissionCacheConfig holds auth.PermissionCache settings
+type PermissionCacheConfig struct {
+	// TTL is how long a user's permission codes are cached
+	TTL time.Duration
+
+	// MaxSize is the maximum number of cached users (0 = unlimited)
+	MaxSize int
+
+	// UserTTL overrides TTL for specific users; 0 disables caching for that user
+	UserTTL map[int]time.Duration
+}
+
+// LoadPermissionCacheConfig loads permission cache configuration from environment variables
+func LoadPermissionCacheConfig() *PermissionCacheConfig {
+	return &PermissionCacheConfig{
+		TTL:     getEnvDuration("PERMISSION_CACHE_TTL", 5*time.Minute),
+		MaxSize: getEnvInt("PERMISSION_CACHE_MAX_SIZE", 10000),
+		UserTTL: getEnvUserTTL("PERMISSION_CACHE_USER_TTL"),
+	}
+}
+
+// getEnvUserTTL returns the environment variable as a per-user TTL map (nil if unset or invalid)
+func getEnvUserTTL(key string) map[int]time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if m, err := ParseUserTTL(value); err == nil {
+			return m
+		}
+	}
+	return nil
+}
+
+// ParseUserTTL parses "42:0s,43:30s" into userID → TTL
+func ParseUserTTL(s string) (map[int]time.Duration, error) {
+	m := make(map[int]time.Duration)
+	for _, entry := range strings.Split(s, ",") {
+		idStr, durationStr, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid user ttl entry %q", entry)
+		}
+		id, err := strconv.Atoi(strings.TrimSpace(idStr))
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid user id in user ttl entry %q", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(durationStr))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid duration in user ttl entry %q", entry)
+		}
+		m[id] = d
+	}
+	return m, nil
+}
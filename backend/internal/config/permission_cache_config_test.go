@@ -0,0 +1,44 @@
+// Package config provides local configuration for auth and related features
+//
+// File: permission_cache_config_test.go
+// Description: Unit tests for permission cache configuration
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPermissionCacheConfig_Defaults(t *testing.T) {
+	t.Setenv("PERMISSION_CACHE_TTL", "")
+	t.Setenv("PERMISSION_CACHE_MAX_SIZE", "")
+	t.Setenv("PERMISSION_CACHE_USER_TTL", "")
+
+	cfg := LoadPermissionCacheConfig()
+
+	assert.Equal(t, 5*time.Minute, cfg.TTL)
+	assert.Equal(t, 10000, cfg.MaxSize)
+	assert.Nil(t, cfg.UserTTL)
+}
+
+func TestLoadPermissionCacheConfig_FromEnv(t *testing.T) {
+	t.Setenv("PERMISSION_CACHE_TTL", "1m")
+	t.Setenv("PERMISSION_CACHE_MAX_SIZE", "500")
+	t.Setenv("PERMISSION_CACHE_USER_TTL", "42:0s, 7:10s")
+
+	cfg := LoadPermissionCacheConfig()
+
+	assert.Equal(t, time.Minute, cfg.TTL)
+	assert.Equal(t, 500, cfg.MaxSize)
+	assert.Equal(t, map[int]time.Duration{42: 0, 7: 10 * time.Second}, cfg.UserTTL)
+}
+
+func TestParseUserTTL_Invalid(t *testing.T) {
+	for _, s := range []string{"42", "x:1s", "0:1s", "42:soon", "42:-1s", ""} {
+		_, err := ParseUserTTL(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,131 @@
+// Package config provides local configuration for auth and related features
+//
+// File: server_config.go
+// Description: Server settings not in the shared config (shutdown, draining, body limit, request timeout, internal secret, tenant)
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxBodySize is Fiber's default request body limit (4MB)
+const DefaultMaxBodySize int64 = 4 * 1024 * 1024
+
+// DefaultRequestTimeout is the global per-request deadline
+const DefaultRequestTimeout = 30 * time.Second
+
+// MinInternalSecretLength is the minimum INTERNAL_API_SECRET length (HMAC-SHA256 key)
+const MinInternalSecretLength = 32
+
+// MaxTenantIDLength matches the tenant_id column size on logs and notifications
+const MaxTenantIDLength = 50
+
+// ServerConfig holds server lifecycle settings not covered by the shared config package
+type ServerConfig struct {
+	// ShutdownTimeout is the maximum time Fiber gets to finish in-flight requests on shutdown
+	ShutdownTimeout time.Duration
+
+	// DrainTimeout is how long to keep serving after SIGINT/SIGTERM before
+	// Fiber is told to shut down (lets load balancers stop routing new requests)
+	DrainTimeout time.Duration
+
+	// MaxBodySize is the request body limit in bytes passed to fiber.Config.BodyLimit.
+	// Larger bodies are rejected with 413 before reaching a handler.
+	MaxBodySize int64
+
+	// RequestTimeout is the deadline for each request's handler chain; slower requests
+	// get 503 TIMEOUT. 0 disables the global timeout (route-level timeouts still apply).
+	RequestTimeout time.Duration
+
+	// InternalSecret is the shared HMAC secret internal services use to sign requests
+	// (X-Signature/X-Timestamp). Empty disables signed access.
+	InternalSecret string
+
+	// CSP is the Content-Security-Policy set by middleware.SecurityHeaders.
+	// Empty uses the built-in strict policy; /docs/* always gets the Swagger UI policy.
+	CSP string
+
+	// TenantID identifies this deployment's tenant. middleware.Tenant stores it in the
+	// request context; API logs and notifications are stamped with it. Empty disables tagging.
+	TenantID string
+}
+
+// LoadServerConfig loads server lifecycle configuration from environment variables
+func LoadServerConfig() *ServerConfig {
+	return &ServerConfig{
+		ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 10*time.Second),
+		DrainTimeout:    getEnvDuration("DRAIN_TIMEOUT", 0),
+		MaxBodySize:     getEnvByteSize("SERVER_MAX_BODY_SIZE", DefaultMaxBodySize),
+		RequestTimeout:  getEnvDuration("SERVER_REQUEST_TIMEOUT", DefaultRequestTimeout),
+		InternalSecret:  getEnv("INTERNAL_API_SECRET", ""),
+		CSP:             getEnv("SERVER_CSP", ""),
+		TenantID:        strings.TrimSpace(getEnv("SERVER_TENANT_ID", "")),
+	}
+}
+
+// Validate checks that the timeouts, body limit and internal secret are usable
+func (c *ServerConfig) Validate() error {
+	if c.ShutdownTimeout < 0 {
+		return fmt.Errorf("SHUTDOWN_TIMEOUT must not be negative, got %s", c.ShutdownTimeout)
+	}
+	if c.DrainTimeout < 0 {
+		return fmt.Errorf("DRAIN_TIMEOUT must not be negative, got %s", c.DrainTimeout)
+	}
+	if c.RequestTimeout < 0 {
+		return fmt.Errorf("SERVER_REQUEST_TIMEOUT must not be negative, got %s", c.RequestTimeout)
+	}
+	if c.InternalSecret != "" && len(c.InternalSecret) < MinInternalSecretLength {
+		return fmt.Errorf("INTERNAL_API_SECRET must be at least %d characters", MinInternalSecretLength)
+	}
+	if c.MaxBodySize <= 0 {
+		return fmt.Errorf("SERVER_MAX_BODY_SIZE must be positive, got %d", c.MaxBodySize)
+	}
+	if len(c.TenantID) > MaxTenantIDLength {
+		return fmt.Errorf("SERVER_TENANT_ID must be at most %d characters", MaxTenantIDLength)
+	}
+	return nil
+}
+
+// byteUnits maps size suffixes to their multiplier (binary units, case-insensitive)
+var byteUnits = []struct {
+	suffix string
+	mult   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseByteSize parses sizes like "4MB", "512kb", "1GB" or a plain byte count "1048576"
+func ParseByteSize(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	mult := int64(1)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(v, u.suffix) {
+			v = strings.TrimSpace(strings.TrimSuffix(v, u.suffix))
+			mult = u.mult
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	return n * mult, nil
+}
+
+// getEnvByteSize returns the environment variable as a byte size or a default
+func getEnvByteSize(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if size, err := ParseByteSize(value); err == nil {
+			return size
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,119 @@
+// Package config provides local configuration for auth and related features
+//
+// File: server_config_test.go
+// Description: Unit tests for server lifecycle configuration
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadServerConfig_Defaults(t *testing.T) {
+	t.Setenv("SHUTDOWN_TIMEOUT", "")
+	t.Setenv("DRAIN_TIMEOUT", "")
+	t.Setenv("SERVER_MAX_BODY_SIZE", "")
+	t.Setenv("SERVER_REQUEST_TIMEOUT", "")
+	t.Setenv("SERVER_CSP", "")
+	t.Setenv("SERVER_TENANT_ID", "")
+
+	cfg := LoadServerConfig()
+
+	assert.Equal(t, 10*time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, time.Duration(0), cfg.DrainTimeout)
+	assert.Equal(t, int64(4<<20), cfg.MaxBodySize)
+	assert.Equal(t, 30*time.Second, cfg.RequestTimeout)
+	assert.Empty(t, cfg.CSP)
+	assert.Empty(t, cfg.TenantID)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadServerConfig_FromEnv(t *testing.T) {
+	t.Setenv("SHUTDOWN_TIMEOUT", "45s")
+	t.Setenv("DRAIN_TIMEOUT", "5s")
+	t.Setenv("SERVER_MAX_BODY_SIZE", "20MB")
+	t.Setenv("SERVER_REQUEST_TIMEOUT", "2s")
+	t.Setenv("SERVER_CSP", "default-src 'none'")
+	t.Setenv("SERVER_TENANT_ID", " gerege ")
+
+	cfg := LoadServerConfig()
+
+	assert.Equal(t, 45*time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, 5*time.Second, cfg.DrainTimeout)
+	assert.Equal(t, int64(20<<20), cfg.MaxBodySize)
+	assert.Equal(t, 2*time.Second, cfg.RequestTimeout)
+	assert.Equal(t, "default-src 'none'", cfg.CSP)
+	assert.Equal(t, "gerege", cfg.TenantID)
+}
+
+func TestLoadServerConfig_InvalidBodySizeFallsBack(t *testing.T) {
+	t.Setenv("SERVER_MAX_BODY_SIZE", "lots")
+
+	cfg := LoadServerConfig()
+
+	assert.Equal(t, DefaultMaxBodySize, cfg.MaxBodySize)
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "4MB", want: 4 << 20},
+		{in: "512kb", want: 512 << 10},
+		{in: "1GB", want: 1 << 30},
+		{in: " 10 MB ", want: 10 << 20},
+		{in: "100B", want: 100},
+		{in: "1048576", want: 1 << 20},
+		{in: "", wantErr: true},
+		{in: "MB", wantErr: true},
+		{in: "-1MB", wantErr: true},
+		{in: "1.5MB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseByteSize(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServerConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ServerConfig
+		wantErr bool
+	}{
+		{name: "valid", cfg: ServerConfig{ShutdownTimeout: 30 * time.Second, DrainTimeout: 5 * time.Second, MaxBodySize: DefaultMaxBodySize}},
+		{name: "zero timeouts", cfg: ServerConfig{MaxBodySize: DefaultMaxBodySize}},
+		{name: "negative shutdown timeout", cfg: ServerConfig{ShutdownTimeout: -time.Second, MaxBodySize: DefaultMaxBodySize}, wantErr: true},
+		{name: "negative drain timeout", cfg: ServerConfig{ShutdownTimeout: time.Second, DrainTimeout: -time.Second, MaxBodySize: DefaultMaxBodySize}, wantErr: true},
+		{name: "negative request timeout", cfg: ServerConfig{RequestTimeout: -time.Second, MaxBodySize: DefaultMaxBodySize}, wantErr: true},
+		{name: "short internal secret", cfg: ServerConfig{InternalSecret: "too-short", MaxBodySize: DefaultMaxBodySize}, wantErr: true},
+		{name: "internal secret", cfg: ServerConfig{InternalSecret: strings.Repeat("s", 32), MaxBodySize: DefaultMaxBodySize}},
+		{name: "tenant id", cfg: ServerConfig{TenantID: "gerege", MaxBodySize: DefaultMaxBodySize}},
+		{name: "tenant id too long", cfg: ServerConfig{TenantID: strings.Repeat("t", MaxTenantIDLength+1), MaxBodySize: DefaultMaxBodySize}, wantErr: true},
+		{name: "zero body size", cfg: ServerConfig{ShutdownTimeout: time.Second}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package config provides local configuration for auth and related features
+//
+// File: session_config.go
+// Description: SSO session auto-refresh and session cookie settings not in the shared config
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultRefreshThreshold is the remaining SSO session lifetime below which the token is refreshed
+const DefaultRefreshThreshold = 5 * time.Minute
+
+// SessionConfig holds SSO session refresh settings. The shared config only
+// carries the cookie name, so the refreshed cookie's attributes live here.
+type SessionConfig struct {
+	// RefreshThreshold triggers an SSO token refresh when the session has less time left
+	RefreshThreshold time.Duration
+
+	// CookieDomain is the Domain attribute of the refreshed session cookie (empty: host-only)
+	CookieDomain string
+
+	// CookieSecure sets the Secure attribute of the refreshed session cookie
+	CookieSecure bool
+
+	// CookieMaxAge is the Max-Age of the refreshed session cookie in seconds (0: session cookie)
+	CookieMaxAge int
+}
+
+// LoadSessionConfig loads session refresh configuration from environment variables
+func LoadSessionConfig() *SessionConfig {
+	return &SessionConfig{
+		RefreshThreshold: getEnvDuration("AUTH_REFRESH_THRESHOLD", DefaultRefreshThreshold),
+		CookieDomain:     getEnv("COOKIE_DOMAIN", ""),
+		CookieSecure:     getEnvBool("COOKIE_SECURE", true),
+		CookieMaxAge:     getEnvInt("COOKIE_MAX_AGE", 0),
+	}
+}
+
+// Validate checks that the refresh threshold and cookie max age are usable
+func (c *SessionConfig) Validate() error {
+	if c.RefreshThreshold <= 0 {
+		return fmt.Errorf("AUTH_REFRESH_THRESHOLD must be positive, got %s", c.RefreshThreshold)
+	}
+	if c.CookieMaxAge < 0 {
+		return fmt.Errorf("COOKIE_MAX_AGE must not be negative, got %d", c.CookieMaxAge)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Package config provides local configuration for auth and related features
+//
+// File: session_config_test.go
+// Description: Unit tests for SSO session refresh configuration
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSessionConfig_Defaults(t *testing.T) {
+	t.Setenv("AUTH_REFRESH_THRESHOLD", "")
+	t.Setenv("COOKIE_DOMAIN", "")
+	t.Setenv("COOKIE_SECURE", "")
+	t.Setenv("COOKIE_MAX_AGE", "")
+
+	cfg := LoadSessionConfig()
+
+	assert.Equal(t, DefaultRefreshThreshold, cfg.RefreshThreshold)
+	assert.Empty(t, cfg.CookieDomain)
+	assert.True(t, cfg.CookieSecure)
+	assert.Zero(t, cfg.CookieMaxAge)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadSessionConfig_FromEnv(t *testing.T) {
+	t.Setenv("AUTH_REFRESH_THRESHOLD", "90s")
+	t.Setenv("COOKIE_DOMAIN", ".gerege.mn")
+	t.Setenv("COOKIE_SECURE", "false")
+	t.Setenv("COOKIE_MAX_AGE", "3600")
+
+	cfg := LoadSessionConfig()
+
+	assert.Equal(t, 90*time.Second, cfg.RefreshThreshold)
+	assert.Equal(t, ".gerege.mn", cfg.CookieDomain)
+	assert.False(t, cfg.CookieSecure)
+	assert.Equal(t, 3600, cfg.CookieMaxAge)
+}
+
+func TestSessionConfig_Validate(t *testing.T) {
+	assert.Error(t, (&SessionConfig{RefreshThreshold: 0}).Validate())
+	assert.Error(t, (&SessionConfig{RefreshThreshold: time.Minute, CookieMaxAge: -1}).Validate())
+	assert.NoError(t, (&SessionConfig{RefreshThreshold: time.Minute, CookieMaxAge: 60}).Validate())
+}
@@ -0,0 +1,63 @@
+// Package config provides local configuration for auth and related features
+//
+// File: validator.go
+// Description: Startup validation of the shared configuration with human-readable errors
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	sharedconfig "git.gerege.mn/backend-packages/config"
+)
+
+// ValidationError lists every configuration rule that failed, so a misconfigured
+// deployment can be fixed in one pass instead of one restart per field
+type ValidationError struct {
+	Violations []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%d configuration error(s):\n  - %s", len(e.Violations), strings.Join(e.Violations, "\n  - "))
+}
+
+// Validate checks the shared configuration loaded by config.Load and returns a
+// *ValidationError listing all violations, or nil when the configuration is usable
+func Validate(cfg *sharedconfig.Config) error {
+	var v []string
+	fail := func(format string, args ...any) {
+		v = append(v, fmt.Sprintf(format, args...))
+	}
+
+	// Server
+	if addr := cfg.Server.Addr(); strings.TrimSpace(addr) == "" || strings.HasSuffix(addr, ":") {
+		fail("server address (SERVER_HOST/SERVER_PORT) must not be empty, got %q", addr)
+	}
+
+	// Auth
+	if cfg.Auth.CacheTTL <= 0 {
+		fail("AUTH_CACHE_TTL must be positive, got %s", cfg.Auth.CacheTTL)
+	}
+
+	// Docs: BasePath is only used when docs are served, but a set value must still be absolute
+	if (cfg.Docs.Enabled || cfg.Docs.BasePath != "") && !strings.HasPrefix(cfg.Docs.BasePath, "/") {
+		fail("docs base path must start with \"/\", got %q", cfg.Docs.BasePath)
+	}
+
+	// Database DSN (password may legitimately be empty with trust/peer auth)
+	for _, f := range []struct{ env, value string }{
+		{"DB_HOST", cfg.DB.Host},
+		{"DB_PORT", cfg.DB.Port},
+		{"DB_USER", cfg.DB.User},
+		{"DB_NAME", cfg.DB.Name},
+	} {
+		if strings.TrimSpace(f.value) == "" {
+			fail("%s must not be empty", f.env)
+		}
+	}
+
+	if len(v) > 0 {
+		return &ValidationError{Violations: v}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Package config provides local configuration for auth and related features
+//
+// File: validator_test.go
+// Description: Unit tests for shared configuration validation
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	sharedconfig "git.gerege.mn/backend-packages/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig нь бүх дүрмийг хангасан тохиргоо
+func validConfig() *sharedconfig.Config {
+	cfg := &sharedconfig.Config{}
+	cfg.Server.Host = "0.0.0.0"
+	cfg.Server.Port = "8000"
+	cfg.Auth.CacheTTL = 5 * time.Minute
+	cfg.Docs.Enabled = true
+	cfg.Docs.BasePath = "/api/v1"
+	cfg.DB.Host = "localhost"
+	cfg.DB.Port = "5432"
+	cfg.DB.User = "postgres"
+	cfg.DB.Name = "gerege_db"
+	return cfg
+}
+
+func TestValidate_Valid(t *testing.T) {
+	assert.NoError(t, Validate(validConfig()))
+}
+
+func TestValidate_Rules(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*sharedconfig.Config)
+		wantMsg string // "" бол алдаагүй
+	}{
+		{name: "server - empty port", mutate: func(c *sharedconfig.Config) { c.Server.Port = "" }, wantMsg: "server address"},
+		{name: "server - empty host and port", mutate: func(c *sharedconfig.Config) { c.Server.Host, c.Server.Port = "", "" }, wantMsg: "server address"},
+		{name: "auth - zero cache ttl", mutate: func(c *sharedconfig.Config) { c.Auth.CacheTTL = 0 }, wantMsg: "AUTH_CACHE_TTL must be positive, got 0s"},
+		{name: "auth - negative cache ttl", mutate: func(c *sharedconfig.Config) { c.Auth.CacheTTL = -time.Second }, wantMsg: "AUTH_CACHE_TTL"},
+		{name: "docs - relative base path", mutate: func(c *sharedconfig.Config) { c.Docs.BasePath = "api/v1" }, wantMsg: `docs base path must start with "/", got "api/v1"`},
+		{name: "docs - enabled with empty base path", mutate: func(c *sharedconfig.Config) { c.Docs.BasePath = "" }, wantMsg: "docs base path"},
+		{name: "docs - disabled with empty base path is ok", mutate: func(c *sharedconfig.Config) { c.Docs.Enabled, c.Docs.BasePath = false, "" }},
+		{name: "db - empty host", mutate: func(c *sharedconfig.Config) { c.DB.Host = "" }, wantMsg: "DB_HOST must not be empty"},
+		{name: "db - empty port", mutate: func(c *sharedconfig.Config) { c.DB.Port = "" }, wantMsg: "DB_PORT must not be empty"},
+		{name: "db - blank user", mutate: func(c *sharedconfig.Config) { c.DB.User = "  " }, wantMsg: "DB_USER must not be empty"},
+		{name: "db - empty name", mutate: func(c *sharedconfig.Config) { c.DB.Name = "" }, wantMsg: "DB_NAME must not be empty"},
+		{name: "db - empty password is ok", mutate: func(c *sharedconfig.Config) { c.DB.Password = "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(cfg)
+
+			err := Validate(cfg)
+
+			if tt.wantMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			require.True(t, errors.As(err, &verr))
+			require.Len(t, verr.Violations, 1)
+			assert.Contains(t, verr.Violations[0], tt.wantMsg)
+		})
+	}
+}
+
+func TestValidate_ListsAllViolations(t *testing.T) {
+	err := Validate(&sharedconfig.Config{})
+
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	// server address, cache ttl, DB_HOST, DB_PORT, DB_USER, DB_NAME (docs идэвхгүй)
+	assert.Len(t, verr.Violations, 6)
+	assert.Contains(t, err.Error(), "6 configuration error(s):\n  - server address")
+	assert.Contains(t, err.Error(), "\n  - DB_NAME must not be empty")
+}
@@ -0,0 +1,138 @@
+// Package db provides database connection management
+//
+// File: metrics_plugin.go
+// Description: Per-request query count/duration GORM plugin (development only)
+/*
+QueryMetricsPlugin нь Create, Query, Update, Delete callback-уудын өмнө/дараа
+цаг хэмжиж, statement context-ийн request ID-гаар түлхүүрлэн query-ийн тоо,
+нийт хугацааг хуримтлуулна. RequestLogger нь request дуусахад TakeQueryStats-аар
+уншиж, db_query_count, db_total_ms талбаруудыг access log-д нэмнэ.
+
+Зөвхөн ENV=development үед бүртгэгдэнэ (N+1 query илрүүлэхэд зориулсан).
+Request ID-гүй context-ээр (background job, API log worker) хийсэн query тоологдохгүй.
+
+Ашиглалт:
+
+	if cfg.Server.ENV == "development" {
+	    if err := gormDB.Use(db.NewQueryMetricsPlugin()); err != nil {
+	        logg.Warn("db query metrics disabled", zap.Error(err))
+	    }
+	}
+
+	// RequestLogger дотор
+	if s, ok := db.TakeQueryStats(reqID); ok {
+	    fields = append(fields, zap.Int64("db_query_count", s.Count))
+	}
+*/
+package db
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"templatev25/internal/requestctx"
+
+	"gorm.io/gorm"
+)
+
+// queryMetricsStartKey нь statement дээр query эхэлсэн цагийг хадгалах түлхүүр
+const queryMetricsStartKey = "metrics:start"
+
+// requestQueryStats нь request ID → *queryCounter.
+// TakeQueryStats нь уншихдаа устгана; timeout болсон handler-ийн хожуу query-ууд
+// л үлдэж болох ба энэ нь зөвхөн development орчинд хамаарна.
+var requestQueryStats sync.Map
+
+// QueryStats нь нэг request-ийн хугацаанд хийгдсэн query-ийн статистик
+type QueryStats struct {
+	Count int64         // Query-ийн тоо
+	Total time.Duration // Нийт хугацаа
+}
+
+// queryCounter нь нэг request-ийн зэрэг ажиллах query-уудад зориулсан atomic тоолуур
+type queryCounter struct {
+	count atomic.Int64
+	nanos atomic.Int64
+}
+
+// QueryMetricsPlugin нь gorm.Plugin-ийг хэрэгжүүлнэ
+type QueryMetricsPlugin struct{}
+
+// NewQueryMetricsPlugin нь шинэ QueryMetricsPlugin үүсгэнэ
+func NewQueryMetricsPlugin() *QueryMetricsPlugin {
+	return &QueryMetricsPlugin{}
+}
+
+// Name нь plugin-ийн нэр (gorm.DB.Use давхар бүртгэлийг шалгахад ашиглана)
+func (p *QueryMetricsPlugin) Name() string {
+	return "query_metrics"
+}
+
+// Initialize нь Create, Query, Update, Delete processor-уудад before/after callback бүртгэнэ
+func (p *QueryMetricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("metrics:before_create", beforeQuery); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register("metrics:after_create", afterQuery); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("metrics:before_query", beforeQuery); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register("metrics:after_query", afterQuery); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("metrics:before_update", beforeQuery); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("metrics:after_update", afterQuery); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("metrics:before_delete", beforeQuery); err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:delete").Register("metrics:after_delete", afterQuery)
+}
+
+// TakeQueryStats returns the stats collected for requestID and removes them.
+// ok is false when the plugin is not registered or the request made no queries.
+func TakeQueryStats(requestID string) (QueryStats, bool) {
+	if requestID == "" {
+		return QueryStats{}, false
+	}
+	v, ok := requestQueryStats.LoadAndDelete(requestID)
+	if !ok {
+		return QueryStats{}, false
+	}
+	c := v.(*queryCounter)
+	return QueryStats{Count: c.count.Load(), Total: time.Duration(c.nanos.Load())}, true
+}
+
+func beforeQuery(db *gorm.DB) {
+	db.InstanceSet(queryMetricsStartKey, time.Now())
+}
+
+func afterQuery(db *gorm.DB) {
+	v, ok := db.InstanceGet(queryMetricsStartKey)
+	if !ok {
+		return
+	}
+	start, ok := v.(time.Time)
+	if !ok || db.Statement.Context == nil {
+		return
+	}
+	reqID := requestctx.GetRequestID(db.Statement.Context)
+	if reqID == "" {
+		return
+	}
+
+	cv, ok := requestQueryStats.Load(reqID)
+	if !ok {
+		cv, _ = requestQueryStats.LoadOrStore(reqID, &queryCounter{})
+	}
+	c := cv.(*queryCounter)
+	c.count.Add(1)
+	c.nanos.Add(int64(time.Since(start)))
+}
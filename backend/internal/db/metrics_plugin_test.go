@@ -0,0 +1,77 @@
+// Package db provides database connection management
+//
+// File: metrics_plugin_test.go
+// Description: Unit tests and benchmark for the per-request query metrics plugin
+package db
+
+import (
+	"context"
+	"testing"
+
+	"templatev25/internal/requestctx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryMetricsPlugin_RegistersCallbacks(t *testing.T) {
+	gdb, _ := newRecordingDB(t)
+
+	require.NoError(t, gdb.Use(NewQueryMetricsPlugin()))
+
+	cb := gdb.Callback()
+	assert.NotNil(t, cb.Create().Get("metrics:after_create"))
+	assert.NotNil(t, cb.Query().Get("metrics:after_query"))
+	assert.NotNil(t, cb.Update().Get("metrics:after_update"))
+	assert.NotNil(t, cb.Delete().Get("metrics:after_delete"))
+	assert.NotNil(t, cb.Delete().Get("metrics:before_delete"))
+}
+
+func TestQueryMetricsPlugin_CountsPerRequest(t *testing.T) {
+	gdb, _ := newRecordingDB(t)
+	require.NoError(t, gdb.Use(NewQueryMetricsPlugin()))
+
+	ctx := requestctx.With(context.Background(), "req-metrics-1")
+	require.NoError(t, gdb.WithContext(ctx).Table("a").Where("id = ?", 1).Update("x", 1).Error)
+	require.NoError(t, gdb.WithContext(ctx).Exec("DELETE FROM a WHERE id = 1").Error) // raw → тоологдохгүй
+	require.NoError(t, gdb.WithContext(ctx).Table("a").Where("id = ?", 2).Delete(nil).Error)
+	// Request ID-гүй query тоологдохгүй
+	require.NoError(t, gdb.Table("a").Where("id = ?", 3).Update("x", 1).Error)
+
+	stats, ok := TakeQueryStats("req-metrics-1")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), stats.Count)
+	assert.Positive(t, stats.Total)
+
+	// Уншсаны дараа устгагдсан байна
+	_, ok = TakeQueryStats("req-metrics-1")
+	assert.False(t, ok)
+	_, ok = TakeQueryStats("")
+	assert.False(t, ok)
+}
+
+func BenchmarkQueryMetricsPlugin(b *testing.B) {
+	run := func(b *testing.B, withPlugin bool) {
+		gdb, _ := newRecordingDB(b)
+		if withPlugin {
+			if err := gdb.Use(NewQueryMetricsPlugin()); err != nil {
+				b.Fatal(err)
+			}
+		}
+		ctx := requestctx.With(context.Background(), "bench")
+		conn := gdb.WithContext(ctx)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := conn.Table("a").Where("id = ?", i).Update("x", 1).Error; err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StopTimer()
+		TakeQueryStats("bench")
+	}
+
+	b.Run("without_plugin", func(b *testing.B) { run(b, false) })
+	b.Run("with_plugin", func(b *testing.B) { run(b, true) })
+}
@@ -0,0 +1,113 @@
+// Package db provides database connection management
+//
+// File: metrics_test.go
+// Description: Unit tests for connection pool gauges
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// collectGauges нь reader-ээс gauge нэр → утгыг буцаана
+func collectGauges(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	out := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if g, ok := m.Data.(metricdata.Gauge[int64]); ok && len(g.DataPoints) > 0 {
+				out[m.Name] = g.DataPoints[0].Value
+			}
+		}
+	}
+	return out
+}
+
+func TestPoolGauges_Record(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	g, err := newPoolGauges(mp.Meter("test"))
+	require.NoError(t, err)
+
+	g.record(sql.DBStats{OpenConnections: 7, InUse: 3, Idle: 4, WaitCount: 12})
+
+	assert.Equal(t, map[string]int64{
+		"db.connections.open":       7,
+		"db.connections.in_use":     3,
+		"db.connections.idle":       4,
+		"db.connections.wait_count": 12,
+	}, collectGauges(t, reader))
+}
+
+func TestPoolGauges_RunUpdatesUntilDone(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	g, err := newPoolGauges(mp.Meter("test"))
+	require.NoError(t, err)
+
+	calls := make(chan struct{}, 10)
+	stats := func() sql.DBStats {
+		calls <- struct{}{}
+		return sql.DBStats{OpenConnections: len(calls)}
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		g.run(stats, time.Millisecond, done)
+		close(finished)
+	}()
+
+	// Эхний бичилт шууд, дараагийнх нь ticker-ээр
+	for i := 0; i < 2; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatal("pool stats were not sampled")
+		}
+	}
+	close(done)
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("run did not stop after done was closed")
+	}
+	assert.Contains(t, collectGauges(t, reader), "db.connections.open")
+}
+
+func TestRegisterPoolMetrics(t *testing.T) {
+	gormDB, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=x dbname=x sslmode=disable"), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               gormlogger.Discard,
+	})
+	require.NoError(t, err)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	assert.NotPanics(t, func() {
+		require.NoError(t, RegisterPoolMetrics(gormDB, mp))
+		require.NoError(t, RegisterPoolMetrics(gormDB, noop.NewMeterProvider()))
+	})
+
+	// Goroutine эхний snapshot-ийг шууд бичнэ
+	require.Eventually(t, func() bool {
+		return len(collectGauges(t, reader)) == 4
+	}, time.Second, 10*time.Millisecond)
+}
@@ -15,11 +15,11 @@ Features:
   - Connection pooling (MaxIdleConns, MaxOpenConns)
   - Connection lifetime management
   - Schema prefix (table naming)
-  - SQL logging
+  - SQL logging (SlowQueryLogger: удаан query → WARN, бусад → DEBUG)
 
 Ашиглалт:
 
-	gormDB, err := db.NewPostgres(cfg)
+	gormDB, err := db.NewPostgres(cfg, sqlLogger)
 	if err != nil {
 	    log.Fatal("db connection failed", zap.Error(err))
 	}
@@ -50,6 +50,7 @@ import (
 //
 // Parameters:
 //   - cfg: Application configuration (DB host, port, user, password, etc.)
+//   - sqlLogger: GORM logger (ихэвчлэн NewSlowQueryLogger); nil бол logger.Default
 //
 // Returns:
 //   - *gorm.DB: GORM database instance
@@ -60,7 +61,7 @@ import (
 //	host=localhost port=5432 user=postgres password=secret dbname=template sslmode=disable
 //
 // GORM Config:
-//   - Logger: SQL query-г лог хийнэ
+//   - Logger: SQL query-г лог хийнэ (sqlLogger)
 //   - NamingStrategy: Table prefix (schema.table_name)
 //
 // Connection Pool:
@@ -71,7 +72,8 @@ import (
 //
 // Жишээ:
 //
-//	gormDB, err := db.NewPostgres(cfg)
+//	sqlLogger, _ := db.NewSlowQueryLogger(logg, 500*time.Millisecond, otel.GetMeterProvider())
+//	gormDB, err := db.NewPostgres(cfg, sqlLogger)
 //	if err != nil {
 //	    log.Fatal("db init failed", zap.Error(err))
 //	}
@@ -79,7 +81,7 @@ import (
 //	// Application shutdown хийхэд
 //	sqlDB, _ := gormDB.DB()
 //	sqlDB.Close()
-func NewPostgres(cfg config.Config) (*gorm.DB, error) {
+func NewPostgres(cfg config.Config, sqlLogger logger.Interface) (*gorm.DB, error) {
 	// ============================================================
 	// STEP 1: DSN (Data Source Name) үүсгэх
 	// ============================================================
@@ -97,10 +99,12 @@ func NewPostgres(cfg config.Config) (*gorm.DB, error) {
 	// ============================================================
 	// STEP 2: GORM connection үүсгэх
 	// ============================================================
+	if sqlLogger == nil {
+		sqlLogger = logger.Default.LogMode(logger.Info)
+	}
 	g, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		// SQL logging (query-г console-д харуулна)
-		// Production-д logger.Silent ашиглаж болно
-		Logger: logger.Default.LogMode(logger.Info),
+		// SQL logging: DB_SLOW_QUERY_THRESHOLD-оос удаан query → WARN, бусад → DEBUG
+		Logger: sqlLogger,
 
 		// Table naming strategy
 		// Schema prefix: "template_backend.users" гэх мэт
@@ -0,0 +1,63 @@
+// Package db provides database connection management
+//
+// File: seed.go
+// Description: Startup seeding of built-in (system) roles
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"templatev25/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AdminSystemCode нь system role-ууд харьяалагдах системийн код (010_seed_core.sql)
+const AdminSystemCode = "ADMIN"
+
+// SystemRoles нь API-аар устгах боломжгүй (is_system_role = true) role-ууд.
+// 012_seed_roles.sql-тэй ижил код, нэртэй байх ёстой.
+var SystemRoles = []struct {
+	Code        string
+	Name        string
+	Description string
+}{
+	{Code: "SUPER_ADMIN", Name: "Супер Админ", Description: "Бүх эрхтэй систем админ"},
+	{Code: "ADMIN", Name: "Админ", Description: "Системийн админ"},
+}
+
+// SeedSystemRoles inserts the SystemRoles that do not exist yet and returns how
+// many rows were inserted. Existing roles are left untouched (ON CONFLICT DO NOTHING),
+// so it is safe to call on every startup.
+func SeedSystemRoles(ctx context.Context, db *gorm.DB) (int64, error) {
+	var sys domain.System
+	err := db.WithContext(ctx).Select("id").Where("code = ?", AdminSystemCode).Take(&sys).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("system %q not found, run migrations first", AdminSystemCode)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	active, system := true, true
+	var inserted int64
+	for _, r := range SystemRoles {
+		role := domain.Role{
+			SystemID:     sys.ID,
+			Code:         r.Code,
+			Name:         r.Name,
+			Description:  r.Description,
+			IsActive:     &active,
+			IsSystemRole: &system,
+		}
+		res := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&role)
+		if res.Error != nil {
+			return inserted, fmt.Errorf("seed role %s: %w", r.Code, res.Error)
+		}
+		inserted += res.RowsAffected
+	}
+	return inserted, nil
+}
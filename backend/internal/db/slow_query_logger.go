@@ -0,0 +1,169 @@
+// Package db provides database connection management
+//
+// File: slow_query_logger.go
+// Description: GORM logger (zap) with slow query detection and db_slow_queries_total counter
+/*
+SlowQueryLogger нь GORM-ийн logger.Interface-ийг zap дээр хэрэгжүүлнэ.
+
+  - threshold-оос удаан query → WARN (sql, elapsed, rows, caller) + db_slow_queries_total{table}
+  - алдаатай query (record not found-оос бусад) → ERROR
+  - бусад query → DEBUG (zap logger DEBUG идэвхгүй бол SQL огт форматлагдахгүй)
+
+Ашиглалт:
+
+	sqlLogger, err := db.NewSlowQueryLogger(logg, dbCfg.SlowQueryThreshold, provider)
+	if err != nil {
+	    logg.Fatal("db logger init failed", zap.Error(err))
+	}
+	gormDB, err := db.NewPostgres(cfg, sqlLogger)
+*/
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"templatev25/internal/requestctx"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// slowQueryLoggerFile нь caller хайхад алгасах энэ файлын зам
+var slowQueryLoggerFile = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return file
+}()
+
+// slowQueryTablePattern нь SQL-ээс эхний хүснэгтийн нэрийг (schema-гүй) гаргана
+var slowQueryTablePattern = regexp.MustCompile(`(?i)\b(?:from|into|update)\s+(?:"?\w+"?\.)?"?(\w+)"?`)
+
+// SlowQueryLogger нь gorm logger.Interface-ийг хэрэгжүүлнэ
+type SlowQueryLogger struct {
+	log       *zap.Logger
+	level     gormlogger.LogLevel
+	threshold time.Duration
+	slow      metric.Int64Counter
+}
+
+// NewSlowQueryLogger creates a GORM logger that warns about queries slower than
+// threshold and counts them in db.slow_queries (db_slow_queries_total in Prometheus).
+func NewSlowQueryLogger(log *zap.Logger, threshold time.Duration, mp metric.MeterProvider) (*SlowQueryLogger, error) {
+	slow, err := mp.Meter("templatev25/db").Int64Counter("db.slow_queries",
+		metric.WithDescription("Queries slower than DB_SLOW_QUERY_THRESHOLD"))
+	if err != nil {
+		return nil, err
+	}
+	return &SlowQueryLogger{
+		log:       log.WithOptions(zap.WithCaller(false)),
+		level:     gormlogger.Info,
+		threshold: threshold,
+		slow:      slow,
+	}, nil
+}
+
+// LogMode нь өөр түвшинтэй хуулбар буцаана (gorm.Session(&gorm.Session{Logger: ...}) дэмжинэ)
+func (l *SlowQueryLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	cp := *l
+	cp.level = level
+	return &cp
+}
+
+// Info нь GORM-ийн мэдээллийн мессежийг бичнэ
+func (l *SlowQueryLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log.Info(fmt.Sprintf(msg, args...), l.baseFields(ctx)...)
+	}
+}
+
+// Warn нь GORM-ийн анхааруулгыг бичнэ
+func (l *SlowQueryLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log.Warn(fmt.Sprintf(msg, args...), l.baseFields(ctx)...)
+	}
+}
+
+// Error нь GORM-ийн алдааг бичнэ
+func (l *SlowQueryLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log.Error(fmt.Sprintf(msg, args...), l.baseFields(ctx)...)
+	}
+}
+
+// Trace нь query бүрийн дараа дуудагдана
+func (l *SlowQueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		l.log.Error("db_query_error", l.queryFields(ctx, elapsed, fc, zap.Error(err))...)
+
+	case elapsed > l.threshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		l.slow.Add(ctx, 1, metric.WithAttributes(attribute.String("table", tableFromSQL(sql))))
+		l.log.Warn("db_slow_query", l.fields(ctx, elapsed, sql, rows, zap.Duration("threshold", l.threshold))...)
+
+	case l.level >= gormlogger.Info && l.log.Core().Enabled(zap.DebugLevel):
+		l.log.Debug("db_query", l.queryFields(ctx, elapsed, fc)...)
+	}
+}
+
+func (l *SlowQueryLogger) queryFields(ctx context.Context, elapsed time.Duration, fc func() (string, int64), extra ...zap.Field) []zap.Field {
+	sql, rows := fc()
+	return l.fields(ctx, elapsed, sql, rows, extra...)
+}
+
+func (l *SlowQueryLogger) fields(ctx context.Context, elapsed time.Duration, sql string, rows int64, extra ...zap.Field) []zap.Field {
+	fields := append(l.baseFields(ctx),
+		zap.String("sql", sql),
+		zap.Float64("elapsed_ms", float64(elapsed.Microseconds())/1000),
+		zap.Int64("rows", rows),
+	)
+	return append(fields, extra...)
+}
+
+// baseFields нь caller (GORM-ийн гаднах эхний file:line) болон request_id
+func (l *SlowQueryLogger) baseFields(ctx context.Context) []zap.Field {
+	fields := []zap.Field{zap.String("caller", queryCaller())}
+	if reqID := requestctx.GetRequestID(ctx); reqID != "" {
+		fields = append(fields, zap.String("request_id", reqID))
+	}
+	return fields
+}
+
+// queryCaller нь энэ файл болон gorm.io/* package-уудаас гаднах эхний file:line
+// (ихэвчлэн repository) -ийг буцаана. utils.FileWithLineNum нь энэ файлыг алгасдаггүй.
+func queryCaller() string {
+	pcs := [32]uintptr{}
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.File != slowQueryLoggerFile && !strings.Contains(frame.File, "gorm.io/") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// tableFromSQL нь FROM/INTO/UPDATE-ийн дараах эхний хүснэгтийг буцаана (олдохгүй бол "unknown")
+func tableFromSQL(sql string) string {
+	if m := slowQueryTablePattern.FindStringSubmatch(sql); m != nil {
+		return m[1]
+	}
+	return "unknown"
+}
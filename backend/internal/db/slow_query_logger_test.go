@@ -0,0 +1,173 @@
+// Package db provides database connection management
+//
+// File: slow_query_logger_test.go
+// Description: Unit tests for the zap-backed GORM slow query logger
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"templatev25/internal/requestctx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const testSlowSQL = `SELECT * FROM "template_backend"."users" WHERE id = 1`
+
+// newTestSlowQueryLogger нь observer core болон manual reader-тэй logger буцаана
+func newTestSlowQueryLogger(t *testing.T, level zapcore.Level) (*SlowQueryLogger, *observer.ObservedLogs, *sdkmetric.ManualReader) {
+	t.Helper()
+	core, logs := observer.New(level)
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	l, err := NewSlowQueryLogger(zap.New(core), 100*time.Millisecond, mp)
+	require.NoError(t, err)
+	return l, logs, reader
+}
+
+// slowQueryCounts нь db.slow_queries counter-ийн table → утга
+func slowQueryCounts(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	out := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if m.Name != "db.slow_queries" || !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				table, _ := dp.Attributes.Value(attribute.Key("table"))
+				out[table.AsString()] = dp.Value
+			}
+		}
+	}
+	return out
+}
+
+func sqlFunc(sql string, rows int64) func() (string, int64) {
+	return func() (string, int64) { return sql, rows }
+}
+
+func TestSlowQueryLogger_SlowQueryWarnsAndCounts(t *testing.T) {
+	l, logs, reader := newTestSlowQueryLogger(t, zap.DebugLevel)
+	ctx := requestctx.With(context.Background(), "req-slow")
+
+	l.Trace(ctx, time.Now().Add(-300*time.Millisecond), sqlFunc(testSlowSQL, 3), nil)
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, zap.WarnLevel, entry.Level)
+	assert.Equal(t, "db_slow_query", entry.Message)
+
+	fields := entry.ContextMap()
+	assert.Equal(t, testSlowSQL, fields["sql"])
+	assert.Equal(t, int64(3), fields["rows"])
+	assert.GreaterOrEqual(t, fields["elapsed_ms"], 300.0)
+	assert.Equal(t, "req-slow", fields["request_id"])
+	assert.Contains(t, fields["caller"], "slow_query_logger_test.go:")
+
+	assert.Equal(t, map[string]int64{"users": 1}, slowQueryCounts(t, reader))
+}
+
+func TestSlowQueryLogger_FastQueryDebugOnly(t *testing.T) {
+	l, logs, reader := newTestSlowQueryLogger(t, zap.DebugLevel)
+
+	l.Trace(context.Background(), time.Now(), sqlFunc(testSlowSQL, 1), nil)
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, zap.DebugLevel, logs.All()[0].Level)
+	assert.Equal(t, "db_query", logs.All()[0].Message)
+	assert.Empty(t, slowQueryCounts(t, reader))
+}
+
+func TestSlowQueryLogger_FastQuerySkipsSQLWhenDebugDisabled(t *testing.T) {
+	l, logs, _ := newTestSlowQueryLogger(t, zap.InfoLevel)
+
+	called := false
+	l.Trace(context.Background(), time.Now(), func() (string, int64) {
+		called = true
+		return testSlowSQL, 1
+	}, nil)
+
+	assert.Zero(t, logs.Len())
+	assert.False(t, called, "SQL should not be formatted when nothing is logged")
+}
+
+func TestSlowQueryLogger_ErrorLogged(t *testing.T) {
+	l, logs, _ := newTestSlowQueryLogger(t, zap.InfoLevel)
+
+	l.Trace(context.Background(), time.Now(), sqlFunc(testSlowSQL, 0), errors.New("connection reset"))
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, zap.ErrorLevel, logs.All()[0].Level)
+	assert.Equal(t, "connection reset", logs.All()[0].ContextMap()["error"])
+}
+
+func TestSlowQueryLogger_RecordNotFoundIsNotError(t *testing.T) {
+	l, logs, _ := newTestSlowQueryLogger(t, zap.InfoLevel)
+
+	l.Trace(context.Background(), time.Now(), sqlFunc(testSlowSQL, 0), gorm.ErrRecordNotFound)
+
+	assert.Zero(t, logs.Len())
+}
+
+func TestSlowQueryLogger_SilentMode(t *testing.T) {
+	l, logs, reader := newTestSlowQueryLogger(t, zap.DebugLevel)
+	silent := l.LogMode(gormlogger.Silent)
+
+	silent.Trace(context.Background(), time.Now().Add(-time.Second), sqlFunc(testSlowSQL, 1), errors.New("boom"))
+
+	assert.Zero(t, logs.Len())
+	assert.Empty(t, slowQueryCounts(t, reader))
+	// Эх logger-ийн түвшин өөрчлөгдөөгүй
+	assert.Equal(t, gormlogger.Info, l.level)
+}
+
+func TestSlowQueryLogger_WithGORM(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	l, err := NewSlowQueryLogger(zap.New(core), time.Nanosecond, sdkmetric.NewMeterProvider())
+	require.NoError(t, err)
+
+	base, _ := newRecordingDB(t)
+	gdb := base.Session(&gorm.Session{Logger: l})
+
+	require.NoError(t, gdb.Table("news").Where("id = ?", 1).Update("title", "x").Error)
+
+	slow := logs.FilterMessage("db_slow_query").All()
+	require.Len(t, slow, 1)
+	fields := slow[0].ContextMap()
+	assert.Contains(t, fields["sql"], `UPDATE "news" SET "title"='x' WHERE id = 1`)
+	assert.Contains(t, fields["caller"], "slow_query_logger_test.go:", "caller should point outside gorm and the logger")
+}
+
+func TestTableFromSQL(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{testSlowSQL, "users"},
+		{`INSERT INTO "template_backend"."news" ("title") VALUES ('x')`, "news"},
+		{`UPDATE "roles" SET "name"='a'`, "roles"},
+		{`delete from template_backend.sessions where id = 1`, "sessions"},
+		{`SELECT 1`, "unknown"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tableFromSQL(tt.sql), tt.sql)
+	}
+}
@@ -0,0 +1,54 @@
+// Package db provides database connection management
+//
+// File: tx.go
+// Description: Context-aware transaction helper
+/*
+WithTransaction нь transaction эхлүүлж, tx-г context-д хадгалаад fn-г дуудна.
+fn алдаа буцаавал (эсвэл panic хийвэл) rollback, үгүй бол commit хийнэ.
+
+Context-д аль хэдийн tx байгаа бол шинэ transaction нээхгүйгээр тэр tx-г
+ашиглана, тиймээс service-ээс эхлүүлсэн transaction дотор repository-ийн
+WithTransaction дуудлага нэг commit/rollback-д нэгдэнэ.
+
+Ашиглалт:
+
+	err := db.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+	    if err := tx.Create(&a).Error; err != nil {
+	        return err // rollback
+	    }
+	    return tx.Create(&b).Error
+	})
+
+	// Доод давхаргад
+	if tx, ok := db.FromContext(ctx); ok {
+	    conn = tx
+	}
+*/
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey нь context доторх *gorm.DB transaction-ий түлхүүр
+type txKey struct{}
+
+// WithTransaction runs fn inside a transaction and commits when it returns nil.
+// The ctx passed to fn carries the tx (see FromContext). When ctx already holds a
+// transaction, fn joins it instead of opening a nested one.
+func WithTransaction(ctx context.Context, db *gorm.DB, fn func(ctx context.Context, tx *gorm.DB) error) error {
+	if tx, ok := FromContext(ctx); ok {
+		return fn(ctx, tx)
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx), tx)
+	})
+}
+
+// FromContext returns the transaction stored by WithTransaction, if any
+func FromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
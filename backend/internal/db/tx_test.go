@@ -0,0 +1,143 @@
+// Package db provides database connection management
+//
+// File: tx_test.go
+// Description: Unit tests for the context-aware transaction helper
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// recorder нь BEGIN/COMMIT/ROLLBACK болон Exec хийсэн SQL-ийг дарааллаар нь бичнэ
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(e string) {
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+}
+
+func (r *recorder) all() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// recordingConnector нь database/sql-д өгөх хуурамч driver.Connector
+type recordingConnector struct{ rec *recorder }
+
+func (c recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return recordingConn(c), nil
+}
+func (c recordingConnector) Driver() driver.Driver { return nil }
+
+type recordingConn struct{ rec *recorder }
+
+func (c recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c recordingConn) Close() error { return nil }
+func (c recordingConn) Begin() (driver.Tx, error) {
+	c.rec.add("BEGIN")
+	return recordingTx(c), nil
+}
+func (c recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.rec.add(query)
+	return driver.RowsAffected(1), nil
+}
+
+type recordingTx struct{ rec *recorder }
+
+func (t recordingTx) Commit() error   { t.rec.add("COMMIT"); return nil }
+func (t recordingTx) Rollback() error { t.rec.add("ROLLBACK"); return nil }
+
+// newRecordingDB нь хуурамч connector дээр ажиллах *gorm.DB буцаана
+func newRecordingDB(t testing.TB) (*gorm.DB, *recorder) {
+	t.Helper()
+	rec := &recorder{}
+	sqlDB := sql.OpenDB(recordingConnector{rec: rec})
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 gormlogger.Discard,
+	})
+	require.NoError(t, err)
+	return gormDB, rec
+}
+
+func TestWithTransaction_CommitsOnSuccess(t *testing.T) {
+	gdb, rec := newRecordingDB(t)
+
+	err := WithTransaction(context.Background(), gdb, func(ctx context.Context, tx *gorm.DB) error {
+		got, ok := FromContext(ctx)
+		require.True(t, ok)
+		assert.Same(t, tx, got)
+		return tx.Exec("UPDATE a SET x = 1").Error
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BEGIN", "UPDATE a SET x = 1", "COMMIT"}, rec.all())
+}
+
+func TestWithTransaction_RollsBackOnError(t *testing.T) {
+	gdb, rec := newRecordingDB(t)
+	errBoom := errors.New("boom")
+
+	err := WithTransaction(context.Background(), gdb, func(ctx context.Context, tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE a SET x = 1").Error; err != nil {
+			return err
+		}
+		return errBoom
+	})
+
+	assert.ErrorIs(t, err, errBoom)
+	assert.Equal(t, []string{"BEGIN", "UPDATE a SET x = 1", "ROLLBACK"}, rec.all())
+}
+
+func TestWithTransaction_RollsBackOnPanic(t *testing.T) {
+	gdb, rec := newRecordingDB(t)
+
+	assert.Panics(t, func() {
+		_ = WithTransaction(context.Background(), gdb, func(context.Context, *gorm.DB) error {
+			panic("boom")
+		})
+	})
+	assert.Equal(t, []string{"BEGIN", "ROLLBACK"}, rec.all())
+}
+
+func TestWithTransaction_JoinsOuterTransaction(t *testing.T) {
+	gdb, rec := newRecordingDB(t)
+
+	err := WithTransaction(context.Background(), gdb, func(ctx context.Context, outer *gorm.DB) error {
+		return WithTransaction(ctx, gdb, func(_ context.Context, inner *gorm.DB) error {
+			assert.Same(t, outer, inner)
+			return inner.Exec("DELETE FROM b").Error
+		})
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BEGIN", "DELETE FROM b", "COMMIT"}, rec.all())
+}
+
+func TestFromContext_NoTransaction(t *testing.T) {
+	tx, ok := FromContext(context.Background())
+
+	assert.False(t, ok)
+	assert.Nil(t, tx)
+}
@@ -61,6 +61,24 @@ func (s UserStatus) IsValid() bool {
 	return false
 }
 
+// CredentialType нь user_credentials.credential_type-ийн утга
+// (chk_credential_type constraint-тэй ижил жагсаалт).
+type CredentialType string
+
+const (
+	// CredentialTypePassword - Local нууц үг (DB default)
+	CredentialTypePassword CredentialType = "password"
+
+	// CredentialTypeOAuth - Гадаад OAuth provider (Google)
+	CredentialTypeOAuth CredentialType = "oauth"
+
+	// CredentialTypeDAN - ДАН системээр баталгаажсан
+	CredentialTypeDAN CredentialType = "dan"
+
+	// CredentialTypeCertificate - Тоон гарын үсгийн сертификат
+	CredentialTypeCertificate CredentialType = "certificate"
+)
+
 // ============================================================
 // USER CREDENTIAL ENTITY
 // ============================================================
@@ -77,6 +95,9 @@ type UserCredential struct {
 	// UserID нь users table руу foreign key
 	UserID int `json:"user_id" gorm:"uniqueIndex;not null"`
 
+	// CredentialType нь нэвтрэх арга (CredentialType); хоосон бол DB default "password"
+	CredentialType string `json:"credential_type" gorm:"size:50;default:password"`
+
 	// PasswordHash нь Argon2id хэш
 	PasswordHash string `json:"-" gorm:"not null"`
 
@@ -92,6 +113,12 @@ type UserCredential struct {
 	// MustChangePassword нь нэвтрэх үед нууц үг солих шаардлагатай эсэх
 	MustChangePassword bool `json:"must_change_password" gorm:"default:false"`
 
+	// Provider нь холбогдсон гадаад identity provider ("google"; local бол хоосон)
+	Provider string `json:"provider,omitempty" gorm:"column:oauth_provider;size:50"`
+
+	// ProviderID нь provider дээрх хэрэглэгчийн ID (Google: ID token-ий sub)
+	ProviderID string `json:"-" gorm:"column:oauth_provider_id;size:255"`
+
 	// ExtraFields нь audit талбаруудыг агуулна
 	ExtraFields
 
@@ -322,11 +349,16 @@ const (
 	AuditActionSessionRevoke  SecurityAuditAction = "session_revoke"
 	AuditActionSessionExpire  SecurityAuditAction = "session_expire"
 	AuditActionLogoutAll      SecurityAuditAction = "logout_all"
+	AuditActionLogoutOthers   SecurityAuditAction = "logout_others"
 
 	// Account actions
 	AuditActionAccountLock    SecurityAuditAction = "account_lock"
 	AuditActionAccountUnlock  SecurityAuditAction = "account_unlock"
 	AuditActionStatusChange   SecurityAuditAction = "status_change"
+	AuditActionImpersonate    SecurityAuditAction = "impersonate"
+
+	// Admin actions
+	AuditActionModuleDelete SecurityAuditAction = "module_delete"
 
 	// Login actions
 	AuditActionLoginSuccess SecurityAuditAction = "login_success"
@@ -475,6 +507,49 @@ func (t *EmailVerificationToken) IsUsed() bool {
 	return t.UsedAt != nil
 }
 
+// ============================================================
+// EMAIL VERIFICATION CODE ENTITY
+// ============================================================
+
+// EmailVerification нь нэвтэрсэн хэрэглэгчийн email-ийг баталгаажуулах
+// 6 оронтой код хадгална (POST /verify/email, /verify/email/confirm).
+// Код нь hash хэлбэрээр хадгалагдана.
+// Table: email_verifications
+type EmailVerification struct {
+	// ID нь primary key
+	ID int `json:"id" gorm:"primaryKey"`
+
+	// UserID нь users table руу foreign key
+	UserID int `json:"user_id" gorm:"not null;index"`
+
+	// Code нь кодын SHA-256 hash (hex)
+	Code string `json:"-" gorm:"not null;type:varchar(64)"`
+
+	// ExpiresAt нь код дуусах хугацаа
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+
+	// UsedAt нь код ашиглагдсан хугацаа
+	UsedAt *time.Time `json:"used_at"`
+
+	// CreatedAt нь код үүсгэсэн хугацаа
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for GORM
+func (EmailVerification) TableName() string {
+	return "email_verifications"
+}
+
+// IsExpired нь now-д код хугацаа нь дууссан эсэхийг шалгана
+func (v *EmailVerification) IsExpired(now time.Time) bool {
+	return !now.Before(v.ExpiresAt)
+}
+
+// IsUsed checks if the code has been used
+func (v *EmailVerification) IsUsed() bool {
+	return v.UsedAt != nil
+}
+
 // ============================================================
 // PASSWORD RESET TOKEN ENTITY
 // ============================================================
@@ -488,8 +563,8 @@ type PasswordResetToken struct {
 	// UserID нь users table руу foreign key
 	UserID int `json:"user_id" gorm:"not null"`
 
-	// Token нь unique token string
-	Token string `json:"-" gorm:"uniqueIndex;not null"`
+	// TokenHash нь токены SHA-256 hash (hex). Түүхий токен зөвхөн email-ээр очно.
+	TokenHash string `json:"-" gorm:"column:token_hash;uniqueIndex;not null"`
 
 	// ExpiresAt нь токен дуусах хугацаа
 	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
@@ -12,5 +12,18 @@ type ChatItem struct {
 	ID     int    `json:"id"`
 	Key    string `json:"key"`
 	Answer string `json:"answer"`
+	Tags   []Tag  `json:"tags,omitempty" gorm:"many2many:chat_item_tags;"`
 	ExtraFields
 }
+
+// Tag нь chat item-ийг ангилах шошго
+type Tag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name" gorm:"size:100;uniqueIndex"`
+}
+
+// ChatItemTag нь chat_items ↔ tags many-to-many холбоос
+type ChatItemTag struct {
+	ChatItemID int `json:"chat_item_id" gorm:"primaryKey"`
+	TagID      int `json:"tag_id" gorm:"primaryKey"`
+}
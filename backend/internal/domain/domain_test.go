@@ -281,3 +281,31 @@ func TestSystem_Structure(t *testing.T) {
 	assert.Equal(t, "Admin System", system.Name)
 	assert.True(t, *system.IsActive)
 }
+
+func TestFeatureFlag_IsActiveAt(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		flag FeatureFlag
+		now  time.Time
+		want bool
+	}{
+		{"disabled", FeatureFlag{Enabled: false, ValidFrom: &from, ValidTo: &to}, from.Add(time.Hour), false},
+		{"no bounds", FeatureFlag{Enabled: true}, from, true},
+		{"before valid_from", FeatureFlag{Enabled: true, ValidFrom: &from, ValidTo: &to}, from.Add(-time.Second), false},
+		{"at valid_from", FeatureFlag{Enabled: true, ValidFrom: &from, ValidTo: &to}, from, true},
+		{"within range", FeatureFlag{Enabled: true, ValidFrom: &from, ValidTo: &to}, from.Add(24 * time.Hour), true},
+		{"at valid_to (exclusive)", FeatureFlag{Enabled: true, ValidFrom: &from, ValidTo: &to}, to, false},
+		{"after valid_to", FeatureFlag{Enabled: true, ValidFrom: &from, ValidTo: &to}, to.Add(time.Hour), false},
+		{"only valid_from", FeatureFlag{Enabled: true, ValidFrom: &from}, to.Add(365 * 24 * time.Hour), true},
+		{"only valid_to", FeatureFlag{Enabled: true, ValidTo: &to}, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.flag.IsActiveAt(tt.now))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Package domain provides implementation for domain
+//
+// File: errors.go
+// Description: Domain-level sentinel errors
+// Author: Bayarsaikhan Otgonbayar, CTO
+// Company: Gerege Core Team
+// Created: 2025-02-20
+// Last Updated: 2025-02-20
+/*
+Энэ файл нь repository болон service давхаргаас буцаах бүтэцлэгдсэн
+алдааны төрлүүдийг тодорхойлно. Handler-ууд error string харьцуулахын
+оронд errors.Is ашиглаж алдааны төрлийг шалгана.
+
+Ашиглалт:
+
+	if errors.Is(err, domain.ErrNotFound) {
+	    // 404
+	}
+
+	return domain.NewConflict("email already exists", err)
+*/
+package domain
+
+import (
+	"errors" // Sentinel errors
+
+	"gorm.io/gorm" // ErrRecordNotFound
+)
+
+// ============================================================
+// SENTINEL ERRORS
+// ============================================================
+
+var (
+	// ErrNotFound нь бичлэг олдоогүй үед (404)
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict нь давхардсан эсвэл зөрчилтэй өгөгдөл (409)
+	ErrConflict = errors.New("conflict")
+
+	// ErrForbidden нь эрх хүрэлцэхгүй үед (403)
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrInvalidInput нь буруу оролтын өгөгдөл (400)
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrExpired нь хугацаа нь дууссан код, токен (410)
+	ErrExpired = errors.New("expired")
+)
+
+// ============================================================
+// ERROR TYPE
+// ============================================================
+
+// Error нь sentinel төрөл, мессеж болон (заавал биш) шалтгаан алдааг агуулна.
+// errors.Is(err, domain.ErrNotFound) болон errors.Is(err, cause) хоёулаа ажиллана.
+type Error struct {
+	Kind    error  // ErrNotFound, ErrConflict, ErrForbidden, ErrInvalidInput, ErrExpired
+	Message string // Хэрэглэгчид харуулах мессеж
+	Cause   error  // Анхдагч алдаа (nil байж болно)
+}
+
+// Error нь error interface-ийг хэрэгжүүлнэ.
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Kind.Error()
+	}
+	if e.Cause != nil {
+		return msg + ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+// Unwrap нь Kind болон Cause-ийг буцаана (errors.Is/As-д ашиглагдана).
+func (e *Error) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Cause}
+}
+
+// ============================================================
+// CONSTRUCTORS
+// ============================================================
+
+// NewNotFound нь ErrNotFound төрлийн алдаа үүсгэнэ.
+func NewNotFound(msg string, cause error) error {
+	return &Error{Kind: ErrNotFound, Message: msg, Cause: cause}
+}
+
+// NewConflict нь ErrConflict төрлийн алдаа үүсгэнэ.
+func NewConflict(msg string, cause error) error {
+	return &Error{Kind: ErrConflict, Message: msg, Cause: cause}
+}
+
+// NewForbidden нь ErrForbidden төрлийн алдаа үүсгэнэ.
+func NewForbidden(msg string, cause error) error {
+	return &Error{Kind: ErrForbidden, Message: msg, Cause: cause}
+}
+
+// NewInvalidInput нь ErrInvalidInput төрлийн алдаа үүсгэнэ.
+func NewInvalidInput(msg string, cause error) error {
+	return &Error{Kind: ErrInvalidInput, Message: msg, Cause: cause}
+}
+
+// NewExpired нь ErrExpired төрлийн алдаа үүсгэнэ.
+func NewExpired(msg string, cause error) error {
+	return &Error{Kind: ErrExpired, Message: msg, Cause: cause}
+}
+
+// WrapNotFound нь gorm.ErrRecordNotFound-ийг ErrNotFound болгон ороож буцаана.
+// Бусад алдааг (nil-ийг оролцуулан) өөрчлөхгүй.
+//
+// Жишээ:
+//
+//	err := db.Take(&u, "id = ?", id).Error
+//	return u, domain.WrapNotFound(err, "user not found")
+func WrapNotFound(err error, msg string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NewNotFound(msg, err)
+	}
+	return err
+}
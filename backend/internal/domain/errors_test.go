@@ -0,0 +1,38 @@
+// Package domain provides business entities
+//
+// File: errors_test.go
+// Description: Unit tests for domain errors
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestError_IsKindAndCause(t *testing.T) {
+	cause := errors.New("db down")
+	err := NewConflict("duplicate", cause)
+
+	assert.True(t, errors.Is(err, ErrConflict))
+	assert.True(t, errors.Is(err, cause))
+	assert.False(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, "duplicate: db down", err.Error())
+}
+
+func TestError_DefaultMessage(t *testing.T) {
+	err := &Error{Kind: ErrForbidden}
+	assert.Equal(t, "forbidden", err.Error())
+}
+
+func TestWrapNotFound(t *testing.T) {
+	err := WrapNotFound(gorm.ErrRecordNotFound, "user not found")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	other := errors.New("other")
+	assert.Same(t, other, WrapNotFound(other, "x"))
+	assert.NoError(t, WrapNotFound(nil, "x"))
+}
@@ -0,0 +1,37 @@
+// Package domain provides business entities
+//
+// File: feature_flag.go
+// Description: System-level feature flags (toggle optional endpoints without a deploy)
+package domain
+
+import "time"
+
+// FeatureFlag нь deploy хийлгүйгээр асааж/унтраах боломжтой системийн feature.
+// Enabled бөгөөд [ValidFrom, ValidTo) хугацаанд байвал идэвхтэй (nil хил нь хязгааргүй).
+// Table: feature_flags
+type FeatureFlag struct {
+	ID          int        `json:"id" gorm:"primaryKey"`
+	Code        string     `json:"code" gorm:"type:varchar(100);not null;uniqueIndex"`
+	Enabled     bool       `json:"enabled" gorm:"not null;default:false"`
+	Description string     `json:"description" gorm:"type:varchar(255)"`
+	ValidFrom   *time.Time `json:"valid_from,omitempty"`
+	ValidTo     *time.Time `json:"valid_to,omitempty"`
+}
+
+// TableName нь GORM-д хүснэгтийн нэрийг заана
+func (FeatureFlag) TableName() string { return "feature_flags" }
+
+// IsActiveAt нь flag now агшинд идэвхтэй эсэхийг буцаана:
+// Enabled, ValidFrom <= now (эсвэл nil), now < ValidTo (эсвэл nil).
+func (f FeatureFlag) IsActiveAt(now time.Time) bool {
+	if !f.Enabled {
+		return false
+	}
+	if f.ValidFrom != nil && now.Before(*f.ValidFrom) {
+		return false
+	}
+	if f.ValidTo != nil && !now.Before(*f.ValidTo) {
+		return false
+	}
+	return true
+}
@@ -17,6 +17,7 @@ import (
 type APILog struct {
 	Id          int64          `json:"id" gorm:"primaryKey"`
 	OrgId       *int64         `json:"org_id" gorm:"index"`
+	TenantID    string         `json:"tenant_id" gorm:"size:50;index"`
 	UserId      *int64         `json:"user_id" gorm:"index"`
 	Username    string         `json:"username" gorm:"type:varchar(50)"`
 	Path        string         `json:"path" gorm:"type:varchar(255)"`
@@ -17,3 +17,12 @@ type Menu struct {
 	IsActive     *bool       `json:"is_active"`
 	ExtraFields
 }
+
+// MenuPermission нь menu-г харахад шаардагдах permission.
+// Menu-д холбогдсон бүх permission-ийг хэрэглэгч эзэмшсэн бол role-оос үл хамааран /me/menu-д харагдана.
+type MenuPermission struct {
+	MenuID       int64       `json:"menu_id" gorm:"primaryKey"`
+	PermissionID int         `json:"permission_id" gorm:"primaryKey"`
+	Permission   *Permission `json:"permission,omitempty" gorm:"foreignKey:PermissionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ExtraFields
+}
@@ -13,7 +13,7 @@ type Module struct {
 	Code        string  `json:"code" gorm:"type:varchar(255);unique"`
 	Name        string  `json:"name" gorm:"type:varchar(255)"`
 	Description string  `json:"description" gorm:"type:varchar(255)"`
-	IsActive    *bool   `json:"is_active"`
+	IsActive    *bool   `json:"is_active" gorm:"not null;default:true"` // false бол permission-ууд нь жагсаалтаас нуугдана
 	SystemID    int     `json:"system_id"`
 	System      *System `json:"system,omitempty" gorm:"foreignKey:SystemID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 	ExtraFields
@@ -8,10 +8,24 @@
 // Last Updated: 2025-02-20
 package domain
 
+import "time"
+
 type News struct {
-	Id       int    `json:"id" gorm:"primaryKey"`
-	Title    string `json:"title" gorm:"type:varchar(255)"`
-	Text     string `json:"text" gorm:"type:text"`
-	ImageUrl string `json:"image_url" gorm:"type:varchar(255)"`
+	Id          int        `json:"id" gorm:"primaryKey"`
+	Title       string     `json:"title" gorm:"type:varchar(255)"`
+	Text        string     `json:"text" gorm:"type:text"`
+	ImageUrl    string     `json:"image_url" gorm:"type:varchar(255)"`
+	IsPublished bool       `json:"is_published" gorm:"default:false"` // false бол draft
+	PublishedAt *time.Time `json:"published_at"`                      // Сүүлд нийтлэгдсэн огноо
+	CategoryID  *int       `json:"category_id" gorm:"index"`          // news_categories.id, nil бол ангилалгүй
+	ExtraFields
+}
+
+// NewsCategory нь мэдээний ангилал (news_categories).
+// Ангилал устгахад түүнд хамаарах мэдээний category_id NULL болно.
+type NewsCategory struct {
+	Id   int    `json:"id" gorm:"primaryKey"`
+	Name string `json:"name" gorm:"type:varchar(100);not null"`
+	Code string `json:"code" gorm:"type:varchar(50);uniqueIndex"`
 	ExtraFields
 }
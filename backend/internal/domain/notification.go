@@ -26,7 +26,7 @@ type Notification struct {
 	Content         string `json:"content" gorm:"type:text"`
 	IsRead          bool   `json:"is_read" gorm:"default:false"`
 	Type            string `json:"type" gorm:"size:20"`
-	Tenant          string `json:"tenant" gorm:"size:50"`
+	TenantID        string `json:"tenant_id" gorm:"size:50;index"`
 	GroupId         int    `json:"group_id" gorm:"index"`
 	CreatedUsername string `json:"created_username" gorm:"size:100"`
 	ExtraFields
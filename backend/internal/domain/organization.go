@@ -13,6 +13,11 @@ type OrganizationType struct {
 	Code        string `json:"code" gorm:"type:varchar(255)"`
 	Name        string `json:"name" gorm:"type:varchar(255)"`
 	Description string `json:"description" gorm:"type:varchar(255)"`
+	// EnforceRoleLimit нь true бол энэ төрлийн байгууллагад зөвхөн OrgTypeRole-д
+	// холбогдсон role-той хэрэглэгчийг нэмнэ (OrgUserService.Add)
+	EnforceRoleLimit bool `json:"enforce_role_limit" gorm:"not null;default:false"`
+	// DeleteReason нь устгах үед өгсөн шалтгаан (soft delete audit)
+	DeleteReason string `json:"delete_reason,omitempty" gorm:"type:varchar(500)"`
 	ExtraFields
 }
 
@@ -30,6 +35,15 @@ type OrgTypeRole struct {
 	ExtraFields
 }
 
+// OrgTypePermission нь байгууллагын төрөлд шууд олгосон permission.
+// Тухайн төрлийн байгууллагын гишүүд role-оос гадна эдгээр permission-тэй болно.
+type OrgTypePermission struct {
+	TypeID       int         `json:"type_id" gorm:"primaryKey"`
+	PermissionID int         `json:"permission_id" gorm:"primaryKey"`
+	Permission   *Permission `json:"permission,omitempty" gorm:"foreignKey:PermissionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ExtraFields
+}
+
 type Organization struct {
 	Id                int               `json:"id,omitempty" gorm:"primaryKey"`
 	RegNo             string            `json:"reg_no,omitempty" gorm:"type:varchar(7)"`
@@ -57,6 +71,8 @@ type Organization struct {
 	CountryNameEn     string            `json:"country_name_en,omitempty"`
 	ParentId          *int              `json:"parent_id"`
 	Children          *[]Organization   `json:"children,omitempty" gorm:"foreignKey:ParentId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	// Version нь optimistic locking-ийн хувилбар; Update бүрт 1-ээр нэмэгдэнэ
+	Version int `json:"version" gorm:"not null;default:1"`
 	ExtraFields
 }
 
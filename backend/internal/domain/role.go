@@ -17,6 +17,9 @@ type Role struct {
 	Description  string  `json:"description" gorm:"type:varchar(255)"`
 	IsActive     *bool   `json:"is_active"`
 	IsSystemRole *bool   `json:"is_system_role" gorm:"default:false"`
+	// Permissions нь role_permissions-ээр холбогдсон зөвшөөрлүүд (зөвхөн Preload хийхэд дүүрнэ).
+	// JSON-д dto.RoleDetailDto-оор гаргана, жагсаалтын хариуг томруулахгүйн тулд энд нууна.
+	Permissions []Permission `json:"-" gorm:"many2many:role_permissions;"`
 	ExtraFields
 }
 
@@ -13,6 +13,7 @@ type System struct {
 	Code        string `json:"code" gorm:"type:varchar(255);unique"`
 	Key         string `json:"key" gorm:"type:varchar(255)"`
 	Name        string `json:"name" gorm:"type:varchar(255)"`
+	Slug        string `json:"slug" gorm:"type:varchar(255);uniqueIndex:idx_systems_slug,where:slug <> '' AND deleted_date IS NULL"`
 	Description string `json:"description" gorm:"type:varchar(255)"`
 	IsActive    *bool  `json:"is_active"`
 	Icon        string `json:"icon" gorm:"type:varchar(255)"`
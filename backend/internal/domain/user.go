@@ -14,14 +14,17 @@ Entity-ууд нь:
   - JSON serialization-ийг дэмжинэ
   - Business validation агуулна
 
-Энэ файлд User болон UserRole entity-ууд тодорхойлогдсон.
+Энэ файлд User, UserRole болон UserRoleHistory entity-ууд тодорхойлогдсон.
 
 Database tables:
   - users: Хэрэглэгчийн мэдээлэл
   - user_roles: Хэрэглэгч-эрхийн холбоос (many-to-many)
+  - user_role_history: Эрх олгосон/хассан түүх
 */
 package domain
 
+import "time"
+
 // ============================================================
 // USER ENTITY
 // ============================================================
@@ -153,3 +156,45 @@ type UserRole struct {
 
 // TableName нь GORM-д хүснэгтийн нэрийг зааж өгнө.
 // func (UserRole) TableName() string { return "user_roles" }
+
+// ============================================================
+// USER ROLE HISTORY ENTITY
+// ============================================================
+
+// UserRoleHistory-ийн Action утгууд
+const (
+	UserRoleActionAssigned = "ROLE_ASSIGNED" // Эрх олгосон
+	UserRoleActionRevoked  = "ROLE_REVOKED"  // Эрх хассан
+)
+
+// UserRoleHistory нь хэрэглэгчийн эрх олгосон/хассан түүхийг хадгална.
+// Table: user_role_history
+//
+// user_roles-оос ялгаатай нь мөр устгагдахгүй, зөвхөн нэмэгдэнэ (append-only).
+type UserRoleHistory struct {
+	// ID нь primary key
+	ID int `json:"id" gorm:"primaryKey"`
+
+	// UserID нь эрх өөрчлөгдсөн хэрэглэгчийн ID
+	UserID int `json:"user_id" gorm:"not null;index:idx_user_role_history_user"`
+
+	// RoleID нь олгосон/хассан эрхийн ID
+	RoleID int `json:"role_id" gorm:"not null"`
+
+	// Action нь UserRoleActionAssigned эсвэл UserRoleActionRevoked
+	Action string `json:"action" gorm:"size:20;not null"`
+
+	// ChangedBy нь өөрчлөлт хийсэн хэрэглэгчийн ID (системээс бол nil)
+	ChangedBy *int `json:"changed_by"`
+
+	// ChangedAt нь өөрчлөлт хийгдсэн хугацаа
+	ChangedAt time.Time `json:"changed_at" gorm:"not null;index:idx_user_role_history_user"`
+
+	// Role нь холбогдсон эрхийн мэдээлэл
+	Role *Role `json:"role,omitempty" gorm:"foreignKey:RoleID;references:ID"`
+}
+
+// TableName returns the table name for GORM
+func (UserRoleHistory) TableName() string {
+	return "user_role_history"
+}
@@ -0,0 +1,183 @@
+// Package event provides implementation for event
+//
+// File: bus.go
+// Description: In-process event bus for service side effects
+/*
+Package event нь service-үүдийн хоорондох дагалдах үйлдлүүдийг (мэдэгдэл
+илгээх гэх мэт) салгах in-process event bus-ийг агуулна.
+
+Publish нь блоклохгүй: event buffered channel-д орж, тусдаа goroutine
+subscriber-уудад хүргэнэ. Buffer дүүрсэн үед event хаягдаж, Dropped()
+тоолуур нэмэгдэнэ — publisher-ийн request хэзээ ч хүлээхгүй.
+
+Ашиглалт:
+
+	bus := event.NewInMemoryBus(256, log)
+	defer bus.Close()
+
+	unsubscribe := bus.Subscribe(event.TypeUserAddedToOrg, func(e event.Event) {
+		ev := e.(event.UserAddedToOrgEvent)
+		...
+	})
+	bus.Publish(event.UserAddedToOrgEvent{OrgID: 1, UserID: 2})
+*/
+package event
+
+import (
+	"sync"        // Subscriber map lock
+	"sync/atomic" // Dropped counter
+
+	"go.uber.org/zap" // Structured logging
+)
+
+// Event нь bus-аар дамжих үйл явдал
+type Event interface {
+	// Type нь subscriber сонгох түлхүүр (жишээ: "org.user_added")
+	Type() string
+}
+
+// EventBus нь event publish/subscribe interface
+type EventBus interface {
+	// Publish нь event-ийг асинхроноор subscriber-уудад хүргэнэ
+	Publish(event Event)
+
+	// Subscribe нь eventType-ийн handler бүртгэж, бүртгэлийг цуцлах функц буцаана
+	Subscribe(eventType string, handler func(Event)) (unsubscribe func())
+}
+
+// DefaultBufferSize нь NewInMemoryBus-д 0 ба түүнээс бага утга өгөхөд хэрэглэнэ
+const DefaultBufferSize = 256
+
+// subscription нь нэг бүртгэлтэй handler
+type subscription struct {
+	id      uint64
+	handler func(Event)
+}
+
+// InMemoryBus нь buffered channel болон нэг dispatcher goroutine дээр
+// суурилсан EventBus. Handler-ууд бүртгэгдсэн дарааллаар, нэг нэгээр дуудагдана.
+type InMemoryBus struct {
+	events chan Event
+	done   chan struct{}
+	log    *zap.Logger
+
+	mu     sync.RWMutex
+	subs   map[string][]subscription
+	nextID uint64
+	closed bool
+
+	dropped atomic.Int64
+}
+
+// NewInMemoryBus нь InMemoryBus үүсгэж, dispatcher goroutine-г эхлүүлнэ.
+//
+// Parameters:
+//   - bufferSize: хүргэгдээгүй event-ийн дээд тоо (<= 0 бол DefaultBufferSize)
+//   - log: Zap logger (nil бол no-op)
+func NewInMemoryBus(bufferSize int, log *zap.Logger) *InMemoryBus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	if log == nil {
+		log = zap.NewNop()
+	}
+	b := &InMemoryBus{
+		events: make(chan Event, bufferSize),
+		done:   make(chan struct{}),
+		log:    log,
+		subs:   make(map[string][]subscription),
+	}
+	go b.dispatch()
+	return b
+}
+
+// Publish нь event-ийг buffer-т хийнэ. Buffer дүүрсэн эсвэл bus хаагдсан
+// бол event хаягдаж, Dropped() нэмэгдэнэ.
+func (b *InMemoryBus) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		b.dropped.Add(1)
+		return
+	}
+
+	select {
+	case b.events <- event:
+	default:
+		b.dropped.Add(1)
+		b.log.Warn("event bus buffer full, event dropped", zap.String("event_type", event.Type()))
+	}
+}
+
+// Subscribe нь eventType-ийн handler бүртгэнэ.
+// Буцаах функцийг дуудахад handler цаашид event хүлээн авахгүй (олон удаа дуудаж болно).
+func (b *InMemoryBus) Subscribe(eventType string, handler func(Event)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subs[eventType] = append(b.subs[eventType], subscription{id: id, handler: handler})
+
+	return func() { b.unsubscribe(eventType, id) }
+}
+
+func (b *InMemoryBus) unsubscribe(eventType string, id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs := b.subs[eventType]
+	for i, s := range subs {
+		if s.id == id {
+			// Шинэ slice үүсгэнэ: dispatcher хуучин snapshot-ийг уншиж байж болно
+			rest := make([]subscription, 0, len(subs)-1)
+			rest = append(rest, subs[:i]...)
+			b.subs[eventType] = append(rest, subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Dropped нь buffer дүүрсэн эсвэл bus хаагдсан тул хаягдсан event-ийн тоо
+func (b *InMemoryBus) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Close нь шинэ event хүлээн авахаа зогсоож, buffer-т байгаа event-үүдийг
+// хүргэж дуусахыг хүлээнэ. Олон удаа дуудаж болно.
+func (b *InMemoryBus) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.events)
+	}
+	b.mu.Unlock()
+
+	<-b.done
+}
+
+// dispatch нь channel-ээс event уншиж, subscriber-уудад хүргэнэ
+func (b *InMemoryBus) dispatch() {
+	defer close(b.done)
+
+	for ev := range b.events {
+		b.mu.RLock()
+		subs := b.subs[ev.Type()]
+		b.mu.RUnlock()
+
+		for _, s := range subs {
+			b.deliver(ev, s.handler)
+		}
+	}
+}
+
+// deliver нь handler-ийн panic-ийг барьж, dispatcher-ийг амьд үлдээнэ
+func (b *InMemoryBus) deliver(ev Event, handler func(Event)) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.log.Error("event handler panic", zap.String("event_type", ev.Type()), zap.Any("panic", r))
+		}
+	}()
+	handler(ev)
+}
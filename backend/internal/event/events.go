@@ -0,0 +1,23 @@
+// Package event provides implementation for event
+//
+// File: events.go
+// Description: Domain events published on the event bus
+package event
+
+import "time"
+
+// Event төрлүүд
+const (
+	// TypeUserAddedToOrg нь хэрэглэгч байгууллагад нэмэгдсэн үед
+	TypeUserAddedToOrg = "org.user_added"
+)
+
+// UserAddedToOrgEvent нь OrgUserService.Add амжилттай болсны дараа publish хийгдэнэ
+type UserAddedToOrgEvent struct {
+	OrgID      int
+	UserID     int
+	OccurredAt time.Time
+}
+
+// Type нь TypeUserAddedToOrg буцаана
+func (UserAddedToOrgEvent) Type() string { return TypeUserAddedToOrg }
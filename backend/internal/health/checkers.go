@@ -0,0 +1,147 @@
+// Package health provides implementation for health
+//
+// File: checkers.go
+// Description: PostgreSQL, SSO and Redis health checkers
+package health
+
+import (
+	"context"  // Timeout context
+	"net/http" // SSO HTTP check
+	"strings"  // URL trim
+	"time"     // HTTP client timeout
+
+	"github.com/redis/go-redis/v9" // Redis client
+	"gorm.io/gorm"                 // ORM
+)
+
+// ============================================================
+// POSTGRES
+// ============================================================
+
+// PostgresChecker нь database ping хийж, connection pool stats буцаана
+type PostgresChecker struct {
+	db *gorm.DB
+}
+
+// NewPostgresChecker нь PostgresChecker үүсгэнэ
+func NewPostgresChecker(db *gorm.DB) *PostgresChecker {
+	return &PostgresChecker{db: db}
+}
+
+// Check нь "database" нэртэй үр дүн буцаана.
+// Алдаа: "db_connection_error" (*sql.DB авч чадаагүй), "db_unreachable" (ping амжилтгүй)
+func (p *PostgresChecker) Check(ctx context.Context) HealthResult {
+	result := HealthResult{Name: "database", Status: StatusError}
+
+	// GORM-оос underlying *sql.DB авах
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		result.Error = "db_connection_error"
+		return result
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		result.Error = "db_unreachable"
+		return result
+	}
+
+	stats := sqlDB.Stats()
+	result.Status = StatusOK
+	result.Details = map[string]any{
+		"open_conns": stats.OpenConnections,
+		"in_use":     stats.InUse,
+		"idle":       stats.Idle,
+		"max_open":   stats.MaxOpenConnections,
+		"wait_count": stats.WaitCount,
+		"wait_time":  stats.WaitDuration.String(),
+	}
+	return result
+}
+
+// ============================================================
+// SSO
+// ============================================================
+
+// ssoCheckTimeout нь client заагаагүй үеийн HTTP timeout
+const ssoCheckTimeout = 2 * time.Second
+
+// SSOChecker нь SSO service-ийн <url>/health endpoint руу GET илгээнэ
+type SSOChecker struct {
+	url    string
+	client *http.Client
+}
+
+// NewSSOChecker нь SSOChecker үүсгэнэ.
+// client nil бол 2 секундын timeout-тэй client хэрэглэнэ.
+func NewSSOChecker(baseURL string, client *http.Client) *SSOChecker {
+	if client == nil {
+		client = &http.Client{Timeout: ssoCheckTimeout}
+	}
+	return &SSOChecker{url: strings.TrimRight(baseURL, "/") + "/health", client: client}
+}
+
+// Check нь "sso" нэртэй үр дүн буцаана.
+// Алдаа: "sso_unreachable" (холбогдож чадаагүй), "sso_unhealthy" (2xx биш хариу)
+func (s *SSOChecker) Check(ctx context.Context) HealthResult {
+	result := HealthResult{Name: "sso", Status: StatusError}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		result.Error = "sso_unreachable"
+		return result
+	}
+
+	start := time.Now()
+	res, err := s.client.Do(req)
+	if err != nil {
+		result.Error = "sso_unreachable"
+		return result
+	}
+	defer res.Body.Close()
+
+	result.Details = map[string]any{
+		"status_code": res.StatusCode,
+		"latency":     time.Since(start).String(),
+	}
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		result.Error = "sso_unhealthy"
+		return result
+	}
+
+	result.Status = StatusOK
+	return result
+}
+
+// ============================================================
+// REDIS
+// ============================================================
+
+// RedisChecker нь Redis PING хийж, connection pool stats буцаана
+type RedisChecker struct {
+	client *redis.Client
+}
+
+// NewRedisChecker нь RedisChecker үүсгэнэ
+func NewRedisChecker(client *redis.Client) *RedisChecker {
+	return &RedisChecker{client: client}
+}
+
+// Check нь "redis" нэртэй үр дүн буцаана.
+// Алдаа: "redis_unreachable" (PING амжилтгүй)
+func (r *RedisChecker) Check(ctx context.Context) HealthResult {
+	result := HealthResult{Name: "redis", Status: StatusError}
+
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		result.Error = "redis_unreachable"
+		return result
+	}
+
+	stats := r.client.PoolStats()
+	result.Status = StatusOK
+	result.Details = map[string]any{
+		"total_conns": stats.TotalConns,
+		"idle_conns":  stats.IdleConns,
+		"stale_conns": stats.StaleConns,
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+// Package health provides implementation for health
+//
+// File: health.go
+// Description: HealthChecker interface and result aggregation for GET /health
+/*
+Package health нь /health endpoint-ийн дэд шалгалтуудыг (PostgreSQL, SSO,
+Redis) агуулна.
+
+Checker бүр HealthChecker interface-ийг хангана. Run нь бүх checker-ийг
+зэрэг ажиллуулж, бүгд амжилттай бол "ok", аль нэг нь унасан бол
+"degraded" төлөв буцаана.
+
+Ашиглалт:
+
+	checkers := []health.HealthChecker{
+		health.NewPostgresChecker(db),
+		health.NewSSOChecker(cfg.URLS.SSO, nil),
+		health.NewRedisChecker(redisClient),
+	}
+	status, results := health.Run(ctx, checkers)
+*/
+package health
+
+import (
+	"context"       // Timeout context
+	"encoding/json" // Flattened result JSON
+	"sync"          // Parallel checks
+)
+
+// Төлөвүүд
+const (
+	// StatusOK нь checker амжилттай эсвэл бүх checker амжилттай
+	StatusOK = "ok"
+
+	// StatusError нь нэг checker амжилтгүй
+	StatusError = "error"
+
+	// StatusDegraded нь дор хаяж нэг checker амжилтгүй үеийн нийт төлөв
+	StatusDegraded = "degraded"
+)
+
+// HealthResult нь нэг checker-ийн үр дүн.
+// JSON-д Details нь status/error-той нэг түвшинд задарна:
+//
+//	{"status": "ok", "open_conns": 10, ...}
+type HealthResult struct {
+	// Name нь response дахь key (жишээ: "database", "sso", "redis")
+	Name string
+
+	// Status нь StatusOK эсвэл StatusError
+	Status string
+
+	// Error нь богино алдааны код (жишээ: "db_unreachable")
+	Error string
+
+	// Details нь нэмэлт мэдээлэл (connection pool stats гэх мэт)
+	Details map[string]any
+}
+
+// OK нь checker амжилттай эсэхийг буцаана
+func (r HealthResult) OK() bool {
+	return r.Status == StatusOK
+}
+
+// MarshalJSON нь status, error, details-ийг нэг object болгоно
+func (r HealthResult) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(r.Details)+2)
+	for k, v := range r.Details {
+		out[k] = v
+	}
+	out["status"] = r.Status
+	if r.Error != "" {
+		out["error"] = r.Error
+	}
+	return json.Marshal(out)
+}
+
+// HealthChecker нь нэг гадаад dependency-ийн төлөвийг шалгана.
+// Check нь ctx-ийн timeout-ийг хүндэтгэх ёстой.
+type HealthChecker interface {
+	Check(ctx context.Context) HealthResult
+}
+
+// Run нь бүх checker-ийг зэрэг ажиллуулна.
+//
+// Returns:
+//   - status: бүгд OK бол StatusOK, үгүй бол StatusDegraded
+//   - results: checkers-ийн дарааллаар үр дүн
+func Run(ctx context.Context, checkers []HealthChecker) (string, []HealthResult) {
+	results := make([]HealthResult, len(checkers))
+
+	var wg sync.WaitGroup
+	for i, checker := range checkers {
+		wg.Add(1)
+		go func(i int, checker HealthChecker) {
+			defer wg.Done()
+			results[i] = checker.Check(ctx)
+		}(i, checker)
+	}
+	wg.Wait()
+
+	status := StatusOK
+	for _, r := range results {
+		if !r.OK() {
+			status = StatusDegraded
+		}
+	}
+	return status, results
+}
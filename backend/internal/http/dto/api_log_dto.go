@@ -8,14 +8,46 @@
 // Last Updated: 2025-01-09
 package dto
 
-import "git.gerege.mn/backend-packages/common"
+import (
+	"time"
+
+	"git.gerege.mn/backend-packages/common"
+)
+
+// APILogDateLayout нь from/to шүүлтийн огнооны формат
+const APILogDateLayout = "2006-01-02"
 
 type APILogListQuery struct {
 	Method     string `query:"method"`
 	Path       string `query:"path"`
 	StatusCode *int   `query:"status_code"`
+	StatusGTE  *int   `query:"status_gte" validate:"omitempty,min=100,max=599"` // status_code >= status_gte (жишээ: 400 → бүх алдаа)
 	UserID     *int64 `query:"user_id"`
 	OrgID      *int64 `query:"org_id"`
 	IP         string `query:"ip"`
+	From       string `query:"from" validate:"omitempty,datetime=2006-01-02"` // created_date >= from (00:00)
+	To         string `query:"to"   validate:"omitempty,datetime=2006-01-02"` // created_date < to + 1 өдөр (тухайн өдрийг оролцуулна)
 	common.PaginationQuery
 }
+
+// Range нь From/To-г [from, to) хугацааны завсар болгон хөрвүүлнэ.
+// Хоосон талбарт nil буцаана. To нь тухайн өдрийг бүхэлд нь оролцуулахын тулд
+// дараагийн өдрийн 00:00 болно.
+func (q APILogListQuery) Range() (from, to *time.Time, err error) {
+	if q.From != "" {
+		t, err := time.ParseInLocation(APILogDateLayout, q.From, time.Local)
+		if err != nil {
+			return nil, nil, err
+		}
+		from = &t
+	}
+	if q.To != "" {
+		t, err := time.ParseInLocation(APILogDateLayout, q.To, time.Local)
+		if err != nil {
+			return nil, nil, err
+		}
+		t = t.AddDate(0, 0, 1)
+		to = &t
+	}
+	return from, to, nil
+}
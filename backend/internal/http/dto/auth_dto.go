@@ -93,6 +93,12 @@ type SetPasswordRequest struct {
 	Password string `json:"password" validate:"required,min=8"`
 }
 
+// ImpersonateRequest нь админ өөр хэрэглэгчийн нэрээр нэвтрэх хүсэлт
+type ImpersonateRequest struct {
+	TargetUserID int    `json:"target_user_id" validate:"required,gt=0"`
+	Reason       string `json:"reason" validate:"required,max=500"`
+}
+
 // ResetPasswordRequest нь нууц үг сэргээх хүсэлт
 type ResetPasswordRequest struct {
 	Email string `json:"email" validate:"required,email"`
@@ -174,6 +180,12 @@ type SecurityAuditResponse struct {
 	Total   int                  `json:"total"`
 }
 
+// UserAuditQuery нь GET /user/:id/audit-ийн query (limit нь 200 хүртэл хавчуулагдана)
+type UserAuditQuery struct {
+	Limit  int    `query:"limit"`
+	Action string `query:"action" validate:"omitempty,max=100"`
+}
+
 // ============================================================
 // REGISTRATION DTOs
 // ============================================================
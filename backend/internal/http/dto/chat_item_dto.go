@@ -20,7 +20,20 @@ type ChatItemUpdateDto ChatItemCreateDto
 type ChatItemQuery struct {
 	common.PaginationQuery
 	Search string `query:"search"`
+	// Q нь Answer дээрх хайлт (ILIKE эсвэл CHAT_SEARCH_FULLTEXT=true үед full-text)
+	Q string `query:"q" validate:"omitempty,max=200"`
 }
 type ChatItemKeyDto struct {
 	Key string `json:"key" validate:"required"`
 }
+
+// ChatItemTagsDto нь chat item-д оноох tag ID-ууд
+type ChatItemTagsDto struct {
+	TagIDs []int `json:"tag_ids" validate:"required,min=1,max=100,dive,gt=0"`
+}
+
+// ChatItemTagParam нь DELETE /chat/:id/tags/:tagID path параметрүүд
+type ChatItemTagParam struct {
+	ID    int `params:"id" validate:"required,gt=0"`
+	TagID int `params:"tagID" validate:"required,gt=0"`
+}
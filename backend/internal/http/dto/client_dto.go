@@ -0,0 +1,10 @@
+// Package dto provides implementation for dto
+//
+// File: client_dto.go
+// Description: Request DTOs for OAuth client scope endpoints
+package dto
+
+// ClientScopeDto нь POST/DELETE /client/scope-ийн body (SSO /authz/scope руу дамжина)
+type ClientScopeDto struct {
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+}
@@ -5,9 +5,14 @@
 package dto
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
+
+	"templatev25/internal/domain"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestRoleListQuery_Structure tests RoleListQuery DTO
@@ -19,6 +24,40 @@ func TestRoleListQuery_Structure(t *testing.T) {
 	assert.Equal(t, 1, query.SystemId)
 }
 
+// TestRoleDetailDto_EmbedsPermissions tests that permissions sit inside the role object
+func TestRoleDetailDto_EmbedsPermissions(t *testing.T) {
+	role := domain.Role{
+		ID:          5,
+		Code:        "ADMIN",
+		Name:        "Admin",
+		Permissions: []domain.Permission{{ID: 99}}, // json:"-" тул гарахгүй
+	}
+	d := RoleDetailDto{
+		Role:        role,
+		Permissions: []domain.Permission{{ID: 10, Code: "admin.role.read"}},
+	}
+
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, float64(5), out["id"])
+	assert.Equal(t, "ADMIN", out["code"])
+
+	perms, ok := out["permissions"].([]any)
+	require.True(t, ok, "permissions must be a top-level array")
+	require.Len(t, perms, 1)
+	assert.Equal(t, "admin.role.read", perms[0].(map[string]any)["code"])
+}
+
+// TestRoleDetailDto_EmptyPermissions tests that an empty list is kept as []
+func TestRoleDetailDto_EmptyPermissions(t *testing.T) {
+	b, err := json.Marshal(RoleDetailDto{Role: domain.Role{ID: 1}, Permissions: []domain.Permission{}})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"permissions":[]`)
+}
+
 // TestNewsListQuery_Structure tests NewsListQuery DTO
 func TestNewsListQuery_Structure(t *testing.T) {
 	query := NewsListQuery{}
@@ -50,6 +89,25 @@ func TestAPILogListQuery_Structure(t *testing.T) {
 	assert.NotNil(t, &query)
 }
 
+// TestAPILogListQuery_Range tests from/to conversion to a [from, to) interval
+func TestAPILogListQuery_Range(t *testing.T) {
+	from, to, err := APILogListQuery{}.Range()
+	require.NoError(t, err)
+	assert.Nil(t, from)
+	assert.Nil(t, to)
+
+	from, to, err = APILogListQuery{From: "2024-01-01", To: "2024-12-31"}.Range()
+	require.NoError(t, err)
+	require.NotNil(t, from)
+	require.NotNil(t, to)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), *from)
+	// To нь тухайн өдрийг оролцуулахын тулд дараагийн өдрийн 00:00
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local), *to)
+
+	_, _, err = APILogListQuery{From: "2024/01/01"}.Range()
+	assert.Error(t, err)
+}
+
 // TestPublicFileListQuery_Structure tests PublicFileListQuery DTO
 func TestPublicFileListQuery_Structure(t *testing.T) {
 	query := PublicFileListQuery{}
@@ -63,6 +121,33 @@ func TestOrgUserListQuery_Structure(t *testing.T) {
 }
 
 // TestDTOPackageCompiles verifies the DTO package compiles correctly
+// TestOrganizationDetail_JSON нь domain.Organization-ийн талбарууд хавтгайгаар,
+// тоонууд нь 0 байсан ч гарч байгааг шалгана
+func TestOrganizationDetail_JSON(t *testing.T) {
+	parentID := 3
+	detail := OrganizationDetail{
+		Organization:  domain.Organization{Id: 7, Name: "Org", TypeId: 2, ParentId: &parentID, Version: 4},
+		TypeName:      "Company",
+		ChildrenCount: 5,
+	}
+
+	raw, err := json.Marshal(detail)
+	require.NoError(t, err)
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &got))
+	assert.Equal(t, float64(7), got["id"])
+	assert.Equal(t, "Org", got["name"])
+	assert.Equal(t, float64(2), got["type_id"])
+	assert.Equal(t, float64(3), got["parent_id"])
+	assert.Equal(t, float64(4), got["version"])
+	assert.Equal(t, "Company", got["type_name"])
+	assert.Equal(t, float64(5), got["children_count"])
+	assert.Equal(t, float64(0), got["user_count"])
+	assert.NotContains(t, got, "organization", "embedded struct is flattened")
+	assert.NotContains(t, got, "children")
+}
+
 func TestDTOPackageCompiles(t *testing.T) {
 	// This test verifies that the dto package compiles
 	// DTOs are validated at the handler level using validators
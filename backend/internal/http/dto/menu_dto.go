@@ -32,3 +32,20 @@ type MenuCreateDto struct {
 }
 
 type MenuUpdateDto MenuCreateDto
+
+// MenuPermissionsDto — POST /menu/:id/permissions (бүрэн солино, хоосон массив бол бүгдийг хасна)
+type MenuPermissionsDto struct {
+	PermissionIDs []int `json:"permission_ids" validate:"required,max=500,dive,gt=0"`
+}
+
+// MenuReorderItem нь нэг menu-гийн шинэ дараалал
+type MenuReorderItem struct {
+	ID       int64 `json:"id"       validate:"required,gt=0"`
+	Sequence int   `json:"sequence" validate:"gte=0"`
+}
+
+// MenuReorderDto — PUT /menu/reorder (drag-and-drop). ID давхардаагүй, sequence-ууд
+// цоорхойгүй дараалсан байх ёстой (жишээ нь 1,2,3); нэг UPDATE-аар атомаар хадгална.
+type MenuReorderDto struct {
+	Orders []MenuReorderItem `json:"orders" validate:"required,min=1,max=500,dive"`
+}
@@ -11,7 +11,8 @@ package dto
 import "git.gerege.mn/backend-packages/common"
 
 type NewsListQuery struct {
-	CategoryID int `query:"category_id"`
+	CategoryID  *int  `query:"category_id" validate:"omitempty,gt=0"` // nil бол бүх ангилал
+	IsPublished *bool `query:"is_published"`                          // nil бол бүгд, true/false бол шүүнэ
 	common.PaginationQuery
 }
 
@@ -19,4 +20,12 @@ type NewsDto struct {
 	Title    string `json:"title"     validate:"required,min=3,max=255"`
 	Text     string `json:"text"      validate:"required,min=3"`
 	ImageUrl string `json:"image_url" validate:"omitempty,min=3,max=255"`
+	// CategoryID нь news_categories.id (заавал биш)
+	CategoryID *int `json:"category_id" validate:"omitempty,gt=0"`
+}
+
+// NewsCategoryDto нь POST/PUT /news/category-ийн body
+type NewsCategoryDto struct {
+	Name string `json:"name" validate:"required,max=100"`
+	Code string `json:"code" validate:"required,max=50"`
 }
@@ -12,6 +12,31 @@ type NotificationReadDto struct {
 	GroupId int `json:"group_id" validate:"required,gt=0"`
 }
 
+// NotificationReadBatchDto нь POST /notification/read-batch-ийн body.
+// All=true бол бүх мэдэгдэл, үгүй бол зөвхөн Ids.
+type NotificationReadBatchDto struct {
+	Ids []int `json:"ids" validate:"omitempty,max=1000,dive,gt=0"`
+	All bool  `json:"all"`
+}
+
+// NotificationReadBatchResponse нь уншсан болгосон мэдэгдлийн тоо
+type NotificationReadBatchResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// NotificationStatsResponse нь GET /notification/stats-ийн хариу.
+// ByType нь зөвхөн уншаагүй мэдэгдэлтэй type-уудыг агуулна.
+type NotificationStatsResponse struct {
+	TotalUnread int64            `json:"total_unread"`
+	ByType      map[string]int64 `json:"by_type"`
+	HasUnread   bool             `json:"has_unread"`
+}
+
+// NotificationUnreadCountResponse нь GET /me/notifications/unread-count-ийн хариу
+type NotificationUnreadCountResponse struct {
+	Count int64 `json:"count"`
+}
+
 type NotificationSendDto struct {
 	Tenant        string `json:"tenant" validate:"required"`
 	UserID        int    `json:"user_id"` // 0 бол broadcast_all
@@ -19,3 +44,12 @@ type NotificationSendDto struct {
 	Content       string `json:"content"`
 	IdempotentKey string `json:"idempotency_key"`
 }
+
+// NotificationGroupDto нь POST /notification/group-ийн body.
+// UserIDs-ийн хэрэглэгч бүрт нэг мэдэгдэл үүснэ (давхардсан ID нэг удаа).
+type NotificationGroupDto struct {
+	UserIDs []int  `json:"user_ids" validate:"required,min=1,max=1000,dive,gt=0"`
+	Title   string `json:"title" validate:"required,max=255"`
+	Content string `json:"content"`
+	Type    string `json:"type" validate:"omitempty,max=20"` // хоосон бол "info"
+}
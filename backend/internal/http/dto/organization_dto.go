@@ -9,6 +9,8 @@
 package dto
 
 import (
+	"time"
+
 	"templatev25/internal/domain"
 	"git.gerege.mn/backend-packages/common"
 )
@@ -40,16 +42,74 @@ type OrganizationDto struct {
 	ParentID          *int    `json:"parent_id"`
 }
 
-type OrganizationUpdateDto = OrganizationDto
+// OrganizationUpdateDto нь PUT /organization/:id-ийн body.
+// version нь client-ийн уншсан хувилбар; өөр хүсэлт түрүүлж шинэчилсэн бол 409 буцна.
+type OrganizationUpdateDto struct {
+	Id                int     `json:"id" validate:"omitempty,gt=0"`
+	RegNo             string  `json:"reg_no" validate:"required,max=7"`
+	Name              string  `json:"name" validate:"required,max=255"`
+	ShortName         string  `json:"short_name" validate:"omitempty,max=255"`
+	TypeId            int     `json:"type_id" validate:"required,gt=0"`
+	PhoneNo           string  `json:"phone_no" validate:"omitempty,max=8"`
+	Email             string  `json:"email" validate:"omitempty,max=50,email"`
+	Longitude         float64 `json:"longitude"`
+	Latitude          float64 `json:"latitude"`
+	IsActive          *bool   `json:"is_active"`
+	AimagId           int     `json:"aimag_id"`
+	SumId             int     `json:"sum_id"`
+	BagId             int     `json:"bag_id"`
+	AddressDetail     string  `json:"address_detail" validate:"omitempty,max=255"`
+	AimagName         string  `json:"aimag_name" validate:"omitempty,max=255"`
+	SumName           string  `json:"sum_name" validate:"omitempty,max=255"`
+	BagName           string  `json:"bag_name" validate:"omitempty,max=255"`
+	CountryCode       string  `json:"country_code"`
+	CountryName       string  `json:"country_name"`
+	Sequence          int     `json:"sequence"`
+	ParentAddressId   int     `json:"parent_address_id"`
+	ParentAddressName string  `json:"parent_address_name" validate:"omitempty,max=25"`
+	CountryNameEn     string  `json:"country_name_en"`
+	ParentID          *int    `json:"parent_id"`
+	Version           int     `json:"version" validate:"required,gt=0"`
+}
 
 type OrganizationTreeQuery struct {
 	OrgId int `query:"org_id" validate:"required"`
 }
 
+// OrgTypeStats нь байгууллагын төрөл тус бүрийн идэвхтэй (устгагдаагүй) байгууллагын тоо (GET /organization/stats)
+type OrgTypeStats struct {
+	TypeID        int       `json:"type_id"`
+	TypeName      string    `json:"type_name"`
+	Count         int64     `json:"count"`
+	LatestCreated time.Time `json:"latest_created"`
+}
+
+// OrganizationDetail нь GET /organization/:id-ийн хариу: байгууллага + төрлийн нэр,
+// устгагдаагүй шууд хүүхэд болон хэрэглэгчийн тоо
+type OrganizationDetail struct {
+	domain.Organization
+	TypeName      string `json:"type_name"`
+	ChildrenCount int    `json:"children_count"`
+	UserCount     int    `json:"user_count"`
+}
+
+// OrganizationParentDto нь PUT /organization/:id/parent-ийн body.
+// parent_id = 0 бол байгууллагыг root болгоно.
+type OrganizationParentDto struct {
+	ParentID *int `json:"parent_id" validate:"required,min=0"`
+}
+
 type OrganizationTypeDto struct {
 	Code        string `json:"code" validate:"required,max=255"`
 	Name        string `json:"name" validate:"required,max=255"`
 	Description string `json:"description" validate:"omitempty,max=255"`
+	// EnforceRoleLimit нь true бол байгууллагад зөвхөн төрөлд зөвшөөрөгдсөн role-той хэрэглэгч нэмнэ
+	EnforceRoleLimit bool `json:"enforce_role_limit"`
+}
+
+// OrgTypeDeleteDto — DELETE /orgtype/:id (body заавал биш)
+type OrgTypeDeleteDto struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
 }
 
 type OrgTypeRolesQuery struct {
@@ -61,6 +121,11 @@ type OrgTypeRolesAddDto struct {
 	RoleIDs []int `json:"role_ids" validate:"required,dive,gt=0"`
 }
 
+// OrgTypePermissionsDto — POST/PUT /orgtype/:id/permissions (бүрэн солино, хоосон массив бол бүгдийг хасна)
+type OrgTypePermissionsDto struct {
+	PermissionIDs []int `json:"permission_ids" validate:"required,max=500,dive,gt=0"`
+}
+
 type OrgUserListQuery struct {
 	OrgId  int    `query:"org_id"`
 	UserId int    `query:"user_id"`
@@ -74,6 +139,12 @@ type OrgUserCreateDto struct {
 }
 
 type OrgUserDeleteDto OrgUserCreateDto
+
+// OrgUserPathParam нь DELETE /organization/:id/user/:userID path параметрүүд
+type OrgUserPathParam struct {
+	ID     int `params:"id" validate:"required,gt=0"`
+	UserID int `params:"userID" validate:"required,gt=0"`
+}
 type ResOrguserUserItem struct {
 	OrgId       int                  `json:"org_id"`
 	UserId      int                  `json:"user_id"`
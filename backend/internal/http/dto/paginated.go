@@ -0,0 +1,61 @@
+// Package dto provides data transfer objects
+//
+// File: paginated.go
+// Description: Generic paginated list response with computed navigation fields
+package dto
+
+// PaginatedResponse нь жагсаалтын response-ийн data хэсэг.
+// resp.Paginated-ийн {items, total, page, size} дээр total_pages, has_next,
+// has_prev-г нэмж тооцсон тул client өөрөө тооцох шаардлагагүй.
+//
+// Ашиглалт:
+//
+//	items, total, page, size, err := h.Service.News.List(ctx, q)
+//	return resp.OK(c, dto.NewPaginatedResponse(items, total, page, size))
+//
+// Swagger: @Success 200 {object} dto.Response{data=dto.PaginatedResponse[domain.News]}
+type PaginatedResponse[T any] struct {
+	// Тухайн хуудасны мөрүүд (хоосон үед [] буюу null биш)
+	Items []T `json:"items"`
+	// Нийт мөрийн тоо
+	Total int64 `json:"total" example:"100"`
+	// Одоогийн хуудас (1-ээс эхэлнэ)
+	Page int `json:"page" example:"1"`
+	// Хуудасны хэмжээ
+	Size int `json:"size" example:"20"`
+	// Нийт хуудасны тоо (total = 0 бол 0)
+	TotalPages int `json:"total_pages" example:"5"`
+	// Дараагийн хуудас байгаа эсэх
+	HasNext bool `json:"has_next" example:"true"`
+	// Өмнөх хуудас байгаа эсэх
+	HasPrev bool `json:"has_prev" example:"false"`
+}
+
+// NewPaginatedResponse нь repository/service-ийн (items, total, page, size)-аас
+// total_pages, has_next, has_prev-г тооцож PaginatedResponse үүсгэнэ.
+// size <= 0 бол бүх мөр нэг хуудсанд багтсан гэж үзнэ.
+func NewPaginatedResponse[T any](items []T, total int64, page, size int) PaginatedResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	totalPages := 0
+	switch {
+	case total <= 0:
+		totalPages = 0
+	case size <= 0:
+		totalPages = 1
+	default:
+		totalPages = int((total + int64(size) - 1) / int64(size))
+	}
+
+	return PaginatedResponse[T]{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		Size:       size,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
@@ -0,0 +1,61 @@
+// Package dto provides data transfer objects
+//
+// File: paginated_test.go
+// Description: Unit tests for PaginatedResponse navigation fields
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPaginatedResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		total          int64
+		page, size     int
+		wantTotalPages int
+		wantNext       bool
+		wantPrev       bool
+	}{
+		{"first page", 45, 1, 20, 3, true, false},
+		{"middle page", 45, 2, 20, 3, true, true},
+		{"last page", 45, 3, 20, 3, false, true},
+		{"last page exact multiple", 40, 2, 20, 2, false, true},
+		{"single page", 5, 1, 20, 1, false, false},
+		{"empty", 0, 1, 20, 0, false, false},
+		{"page beyond last", 45, 5, 20, 3, false, true},
+		{"zero size", 7, 1, 0, 1, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPaginatedResponse([]int{1}, tt.total, tt.page, tt.size)
+
+			assert.Equal(t, tt.wantTotalPages, got.TotalPages)
+			assert.Equal(t, tt.wantNext, got.HasNext)
+			assert.Equal(t, tt.wantPrev, got.HasPrev)
+			assert.Equal(t, tt.total, got.Total)
+			assert.Equal(t, tt.page, got.Page)
+			assert.Equal(t, tt.size, got.Size)
+		})
+	}
+}
+
+func TestNewPaginatedResponse_JSON(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	b, err := json.Marshal(NewPaginatedResponse([]item{{ID: 1}}, 21, 1, 20))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[{"id":1}],"total":21,"page":1,"size":20,"total_pages":2,"has_next":true,"has_prev":false}`, string(b))
+
+	// nil items → [] (null биш)
+	b, err = json.Marshal(NewPaginatedResponse[item](nil, 0, 1, 20))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[],"total":0,"page":1,"size":20,"total_pages":0,"has_next":false,"has_prev":false}`, string(b))
+}
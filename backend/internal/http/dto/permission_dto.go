@@ -8,7 +8,11 @@
 // Last Updated: 2025-02-20
 package dto
 
-import "git.gerege.mn/backend-packages/common"
+import (
+	"templatev25/internal/domain"
+
+	"git.gerege.mn/backend-packages/common"
+)
 
 // Query: /permissions?search=...&module_id=...&page=1&size=20&sort=code:asc,name:desc
 type PermissionQuery struct {
@@ -17,12 +21,16 @@ type PermissionQuery struct {
 	ModuleID int    `query:"module_id"`
 	Search   string `query:"search"`
 	Sort     string `query:"sort"`
+	// IncludeInactiveModules true бол идэвхгүй module-ийн permission-уудыг ч буцаана
+	IncludeInactiveModules bool `query:"include_inactive_modules"`
 }
 
 type PermissionCreateDto struct {
 	SystemID  int     `json:"system_id"   validate:"required,gt=0"`
 	ModuleID  int     `json:"module_id"   validate:"required,gt=0"`
 	ActionIDs []int64 `json:"action_ids" validate:"required,min=1,dive,gt=0"`
+	// Code хоосон бол system.module.action кодоор автоматаар үүснэ (зөвхөн нэг action-д өгнө)
+	Code string `json:"code" validate:"omitempty,max=255"`
 }
 
 type PermissionUpdateDto struct {
@@ -34,3 +42,20 @@ type PermissionUpdateDto struct {
 	ActionID    *int64 `json:"action_id"`
 	IsActive    *bool  `json:"is_active"`
 }
+
+// SystemPermissionGroup нь GET /system/:id/permissions-ийн нэг module-ийн бүлэг
+type SystemPermissionGroup struct {
+	ModuleID    int                 `json:"module_id"`
+	ModuleName  string              `json:"module_name"`
+	Permissions []domain.Permission `json:"permissions"`
+}
+
+// PermissionCheckDto нь POST /permission/check-ийн body (нэг дуудлагад 50 хүртэл код)
+type PermissionCheckDto struct {
+	PermissionCodes []string `json:"permission_codes" validate:"required,min=1,max=50,dive,required,max=255"`
+}
+
+// PermissionCheckResponse нь код бүрийн хувьд хэрэглэгчид эрх байгаа эсэх
+type PermissionCheckResponse struct {
+	Results map[string]bool `json:"results"`
+}
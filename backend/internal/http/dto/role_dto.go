@@ -8,7 +8,11 @@
 // Last Updated: 2025-02-20
 package dto
 
-import "git.gerege.mn/backend-packages/common"
+import (
+	"templatev25/internal/domain"
+
+	"git.gerege.mn/backend-packages/common"
+)
 
 type RoleListQuery struct {
 	SystemId int   `query:"system_id" validate:"omitempty,gt=0"`
@@ -34,3 +38,23 @@ type RolePermissionsUpdateDto struct {
 	RoleID        int   `json:"role_id"        validate:"required,gt=0"`
 	PermissionIDs []int `json:"permission_ids" validate:"required,min=0,dive,gt=0"`
 }
+
+// RolePermissionsDiffDto нь POST /role/permissions/diff хүсэлт — санал болгож буй permission-уудыг
+// одоогийнхтой харьцуулна (DB-д бичихгүй)
+type RolePermissionsDiffDto struct {
+	RoleID      int   `json:"role_id"      validate:"required,gt=0"`
+	ProposedIDs []int `json:"proposed_ids" validate:"required,min=0,dive,gt=0"`
+}
+
+// RolePermissionsDiffResponse нь diff-ийн хариу — ID бүр өсөх эрэмбэтэй, хоосон үед []
+type RolePermissionsDiffResponse struct {
+	ToAdd     []int `json:"to_add"`
+	ToRemove  []int `json:"to_remove"`
+	Unchanged []int `json:"unchanged"`
+}
+
+// RoleDetailDto нь GET /role/:id хариу — role-ийн талбарууд дээр permissions массив нэмэгдэнэ
+type RoleDetailDto struct {
+	domain.Role
+	Permissions []domain.Permission `json:"permissions"`
+}
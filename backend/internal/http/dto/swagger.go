@@ -34,31 +34,3 @@ type ErrorResponse struct {
 	// Detailed error information
 	Details map[string]string `json:"details,omitempty"`
 }
-
-// PaginatedResponse is the paginated list response
-// @Description Paginated response structure
-type PaginatedResponse struct {
-	// Business logic code
-	Code string `json:"code" example:"OK"`
-	// Message
-	Message string `json:"message" example:"success"`
-	// Request ID
-	RequestID string `json:"request_id"`
-	// Response data
-	Data interface{} `json:"data"`
-	// Pagination metadata
-	Meta PaginationMeta `json:"meta"`
-}
-
-// PaginationMeta contains pagination information
-// @Description Pagination metadata
-type PaginationMeta struct {
-	// Current page number
-	Page int `json:"page" example:"1"`
-	// Page size
-	Size int `json:"size" example:"20"`
-	// Total number of items
-	Total int64 `json:"total" example:"100"`
-	// Total number of pages
-	TotalPages int `json:"total_pages" example:"5"`
-}
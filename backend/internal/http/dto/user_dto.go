@@ -8,6 +8,11 @@
 // Last Updated: 2025-02-20
 package dto
 
+import (
+	"git.gerege.mn/backend-packages/common"
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+)
+
 type UserCreateDto struct {
 	Id         int    `json:"id"         validate:"required,gt=0"` // хуучин логикоор Id-тайгаар орж ирдэг
 	CivilId    int    `json:"civil_id"`
@@ -21,6 +26,23 @@ type UserCreateDto struct {
 	Email      string `json:"email"       validate:"omitempty,max=80,email"`
 }
 
+// UserSearchQuery нь GET /user/search — q-г first_name, last_name, email, reg_no, phone_no-оос хайна
+type UserSearchQuery struct {
+	common.PaginationQuery
+	Q string `query:"q" validate:"required,min=2,max=100"`
+}
+
+// UserSyncDto нь POST /user/sync — SSO-оос олон хэрэглэгчийг нэг дор илгээнэ
+type UserSyncDto struct {
+	Users []UserCreateDto `json:"users" validate:"required,min=1,max=5000,dive"`
+}
+
+// UserSyncResponse нь upsert хийгдсэн мөрийн тоо болон устгагдсан тул алгассан id-ууд
+type UserSyncResponse struct {
+	Upserted int   `json:"upserted"`
+	Skipped  []int `json:"skipped"`
+}
+
 type UserUpdateDto struct {
 	Id         int    `json:"id"         validate:"required,gt=0"`
 	CivilId    int    `json:"civil_id"`
@@ -34,6 +56,23 @@ type UserUpdateDto struct {
 	Email      string `json:"email"       validate:"omitempty,max=80,email"`
 }
 
+// MeOrgSwitchDto — PUT /me/org (идэвхтэй байгууллага солих)
+type MeOrgSwitchDto struct {
+	OrgID int `json:"org_id" validate:"required,gt=0"`
+}
+
+// MeResponse — GET /me хариу: SSO claims болон admin impersonation session эсэх
+type MeResponse struct {
+	*ssoclient.Claims
+	IsImpersonated bool `json:"is_impersonated"`
+	ImpersonatedBy int  `json:"impersonated_by,omitempty"`
+}
+
+// MeOrgSwitchResponse — байгууллага сольсны дараах идэвхтэй байгууллага
+type MeOrgSwitchResponse struct {
+	OrgID int `json:"org_id"`
+}
+
 // Core-оос хайх хүсэлт (хуучин models.ReqFind-тэй адилхан талбар)
 type ReqFind struct {
 	SearchText string `json:"search_text" validate:"required"`
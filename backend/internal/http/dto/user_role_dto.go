@@ -34,3 +34,12 @@ type UserRoleRemoveDto struct {
 	UserID int `json:"user_id" validate:"required"`
 	RoleID int `json:"role_id" validate:"required"`
 }
+
+// UserRoleDetail нь GET /user/:id/roles-ийн нэг мөр (role + системийн нэр)
+type UserRoleDetail struct {
+	RoleID     int    `json:"role_id"`
+	RoleName   string `json:"role_name"`
+	RoleCode   string `json:"role_code"`
+	SystemID   int    `json:"system_id"`
+	SystemName string `json:"system_name"`
+}
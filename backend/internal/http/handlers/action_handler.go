@@ -14,6 +14,7 @@ import (
 	"context"
 	"strconv"
 	"templatev25/internal/app"
+	"templatev25/internal/http/validation"
 	"git.gerege.mn/backend-packages/resp"
 	"time"
 
@@ -42,7 +43,7 @@ func NewActionHandler(d *app.Dependencies) *ActionHandler {
 // @Success      200 {object} map[string]interface{}
 // @Router       /actions [get]
 func (h *ActionHandler) List(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.ActionQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.ActionQuery](c)
 	if !ok {
 		return nil
 	}
@@ -68,7 +69,7 @@ func (h *ActionHandler) List(c *fiber.Ctx) error {
 // @Success      201 {object} map[string]interface{}
 // @Router       /actions [post]
 func (h *ActionHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.ActionCreateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.ActionCreateDto](c)
 	if !ok {
 		return nil
 	}
@@ -100,7 +101,7 @@ func (h *ActionHandler) Update(c *fiber.Ctx) error {
 		return resp.BadRequest(c, "invalid action id", err.Error())
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.ActionUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.ActionUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -10,10 +10,13 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"templatev25/internal/app"
+	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"git.gerege.mn/backend-packages/resp"
 
@@ -40,6 +43,7 @@ func NewAPILogHandler(d *app.Dependencies) *APILogHandler {
 // @Param        method      query string false "Filter by HTTP method (GET, POST, etc.)"
 // @Param        path        query string false "Filter by path (ILIKE)"
 // @Param        status_code query int    false "Filter by status code"
+// @Param        status_gte  query int    false "Filter by status code >= value (e.g. 400)"
 // @Param        user_id     query int64  false "Filter by user ID"
 // @Param        org_id      query int64  false "Filter by organization ID"
 // @Param        ip          query string false "Filter by IP address (ILIKE)"
@@ -47,10 +51,16 @@ func NewAPILogHandler(d *app.Dependencies) *APILogHandler {
 // @Param        sort        query string false "Sort (e.g. created_date:desc,id:desc)"
 // @Param        created_from query string false "Filter from date (YYYY-MM-DD)"
 // @Param        created_to   query string false "Filter to date (YYYY-MM-DD)"
+// @Param        from        query string false "created_date from (YYYY-MM-DD, inclusive)"
+// @Param        to          query string false "created_date to (YYYY-MM-DD, inclusive)"
 // @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      403 {object} dto.ErrorResponse
+// @Failure      422 {object} dto.ErrorResponse
+// @Router       /api-log [get]
 // @Router       /api-logs [get]
 func (h *APILogHandler) List(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.APILogListQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.APILogListQuery](c)
 	if !ok {
 		return nil
 	}
@@ -60,6 +70,9 @@ func (h *APILogHandler) List(c *fiber.Ctx) error {
 
 	items, total, page, size, err := h.Service.APILog.List(ctx, q)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		h.Log.Error("api_log_list_failed", zap.Error(err))
 		return resp.InternalServerError(c, err.Error())
 	}
@@ -10,6 +10,7 @@ package handlers
 
 import (
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"templatev25/internal/app"
 	"git.gerege.mn/backend-packages/common"
@@ -38,7 +39,13 @@ func NewAppServiceGroupHandler(d *app.Dependencies) *AppServiceIconGroupHandler
 
 // ----- App Service Icon -----
 
-// GET /app-service-icon
+// List godoc
+// @Summary      List app service icons
+// @Tags         app-service-icon
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} map[string]interface{}
+// @Router       /app-service-icon [get]
 func (h *AppServiceIconHandler) List(c *fiber.Ctx) error {
 	items, err := h.Service.AppServiceIcon.List(c.UserContext())
 	if err != nil {
@@ -47,9 +54,17 @@ func (h *AppServiceIconHandler) List(c *fiber.Ctx) error {
 	return resp.OK(c, items)
 }
 
-// POST /app-service-icon
+// Create godoc
+// @Summary      Create app service icon
+// @Tags         app-service-icon
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.AppServiceIconDto true "app service icon data"
+// @Success      200 {object} map[string]interface{}
+// @Router       /app-service-icon [post]
 func (h *AppServiceIconHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.AppServiceIconDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.AppServiceIconDto](c)
 	if !ok {
 		return nil
 	}
@@ -60,13 +75,22 @@ func (h *AppServiceIconHandler) Create(c *fiber.Ctx) error {
 	return resp.OK(c)
 }
 
-// PUT /app-service-icon/{id}
+// Update godoc
+// @Summary      Update app service icon
+// @Tags         app-service-icon
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path int true "ID"
+// @Param        body body dto.AppServiceIconDto true "app service icon data"
+// @Success      200 {object} map[string]interface{}
+// @Router       /app-service-icon/{id} [put]
 func (h *AppServiceIconHandler) Update(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
-	req, ok := resp.BodyBindAndValidate[dto.AppServiceIconDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.AppServiceIconDto](c)
 	if !ok {
 		return nil
 	}
@@ -77,9 +101,16 @@ func (h *AppServiceIconHandler) Update(c *fiber.Ctx) error {
 	return resp.OK(c)
 }
 
-// DELETE /app-service-icon/{id}
+// Delete godoc
+// @Summary      Delete app service icon
+// @Tags         app-service-icon
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "ID"
+// @Success      200 {object} map[string]interface{}
+// @Router       /app-service-icon/{id} [delete]
 func (h *AppServiceIconHandler) Delete(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
@@ -89,7 +120,13 @@ func (h *AppServiceIconHandler) Delete(c *fiber.Ctx) error {
 	return resp.OK(c)
 }
 
-// GET /app-service-group
+// List godoc
+// @Summary      List app service groups
+// @Tags         app-service-group
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} map[string]interface{}
+// @Router       /app-service-group [get]
 func (h *AppServiceIconGroupHandler) List(c *fiber.Ctx) error {
 	items, err := h.Service.AppServiceGroup.List(c.UserContext())
 	if err != nil {
@@ -98,7 +135,13 @@ func (h *AppServiceIconGroupHandler) List(c *fiber.Ctx) error {
 	return resp.OK(c, items)
 }
 
-// GET /app-service-group/with-icons
+// ListGroupsWithIcons godoc
+// @Summary      List app service groups with their icons
+// @Tags         app-service-group
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} map[string]interface{}
+// @Router       /app-service-group/with-icons [get]
 func (h *AppServiceIconGroupHandler) ListGroupsWithIcons(c *fiber.Ctx) error {
 	items, err := h.Service.AppServiceGroup.ListGroupsWithIcons(c.UserContext())
 	if err != nil {
@@ -107,9 +150,17 @@ func (h *AppServiceIconGroupHandler) ListGroupsWithIcons(c *fiber.Ctx) error {
 	return resp.OK(c, items)
 }
 
-// POST /app-service-group
+// Create godoc
+// @Summary      Create app service group
+// @Tags         app-service-group
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.AppServiceIconGroupDto true "app service group data"
+// @Success      200 {object} map[string]interface{}
+// @Router       /app-service-group [post]
 func (h *AppServiceIconGroupHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.AppServiceIconGroupDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.AppServiceIconGroupDto](c)
 	if !ok {
 		return nil
 	}
@@ -120,13 +171,22 @@ func (h *AppServiceIconGroupHandler) Create(c *fiber.Ctx) error {
 	return resp.OK(c)
 }
 
-// PUT /app-service-group/{id}
+// Update godoc
+// @Summary      Update app service group
+// @Tags         app-service-group
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path int true "ID"
+// @Param        body body dto.AppServiceIconGroupDto true "app service group data"
+// @Success      200 {object} map[string]interface{}
+// @Router       /app-service-group/{id} [put]
 func (h *AppServiceIconGroupHandler) Update(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
-	req, ok := resp.BodyBindAndValidate[dto.AppServiceIconGroupDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.AppServiceIconGroupDto](c)
 	if !ok {
 		return nil
 	}
@@ -137,9 +197,16 @@ func (h *AppServiceIconGroupHandler) Update(c *fiber.Ctx) error {
 	return resp.OK(c)
 }
 
-// DELETE /app-service-group/{id}
+// Delete godoc
+// @Summary      Delete app service group
+// @Tags         app-service-group
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "ID"
+// @Success      200 {object} map[string]interface{}
+// @Router       /app-service-group/{id} [delete]
 func (h *AppServiceIconGroupHandler) Delete(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
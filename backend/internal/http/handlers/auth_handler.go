@@ -11,6 +11,7 @@ package handlers
 import (
 	"fmt"
 	"templatev25/internal/app"
+	"templatev25/internal/http/validation"
 
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/ctx"
@@ -141,7 +142,7 @@ func (h *AuthHandler) AuthVerify(c *fiber.Ctx) error {
 // @Failure      500 {object} map[string]interface{} "Server error"
 // @Router       /auth/org/change [post]
 func (h *AuthHandler) ChangeOrganization(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[common.ID](c)
+	req, ok := validation.BodyBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
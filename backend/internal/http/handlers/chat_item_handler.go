@@ -12,8 +12,11 @@ import (
 	"templatev25/internal/http/dto"
 
 	"context"
+	"errors"
 	"strings"
 	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/validation"
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/resp"
 	"time"
@@ -39,7 +42,7 @@ func NewChatItemHandler(d *app.Dependencies) *ChatItemHandler {
 // @Success      200 {object} map[string]interface{}
 // @Router       /chat/key [post]
 func (h *ChatItemHandler) GetByKey(c *fiber.Ctx) error {
-	dto, ok := resp.BodyBindAndValidate[dto.ChatItemKeyDto](c)
+	dto, ok := validation.BodyBindAndValidate[dto.ChatItemKeyDto](c)
 	if !ok {
 		return nil
 	}
@@ -60,12 +63,13 @@ func (h *ChatItemHandler) GetByKey(c *fiber.Ctx) error {
 // @Tags         chat
 // @Security     BearerAuth
 // @Produce      json
+// @Param        q query string false "Search text in answer (full-text when CHAT_SEARCH_FULLTEXT=true)"
 // @Param        page query int false "Page number"
 // @Param        size query int false "Page size"
 // @Success      200 {object} map[string]interface{}
 // @Router       /chat [get]
 func (h *ChatItemHandler) List(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.ChatItemQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.ChatItemQuery](c)
 	if !ok {
 		return nil
 	}
@@ -73,7 +77,13 @@ func (h *ChatItemHandler) List(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
-	items, total, page, size, err := h.Service.ChatItem.List(ctx, q)
+	list := h.Service.ChatItem.List
+	if q.Q != "" {
+		list = func(ctx context.Context, q dto.ChatItemQuery) ([]domain.ChatItem, int64, int, int, error) {
+			return h.Service.ChatItem.Search(ctx, q.Q, q.PaginationQuery)
+		}
+	}
+	items, total, page, size, err := list(ctx, q)
 	if err != nil {
 		return resp.InternalServerError(c, err.Error())
 	}
@@ -81,6 +91,35 @@ func (h *ChatItemHandler) List(c *fiber.Ctx) error {
 	return resp.Paginated(c, items, total, page, size)
 }
 
+// Get godoc
+// @Summary      Get chat item with tags
+// @Tags         chat
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Chat item ID"
+// @Success      200 {object} dto.Response
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /chat/{id} [get]
+func (h *ChatItemHandler) Get(c *fiber.Ctx) error {
+	param, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	item, err := h.Service.ChatItem.GetByID(ctx, param.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "chat item not found")
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, item)
+}
+
 // Create godoc
 // @Summary      Create chat item
 // @Tags         chat
@@ -91,7 +130,7 @@ func (h *ChatItemHandler) List(c *fiber.Ctx) error {
 // @Success      201 {object} map[string]interface{}
 // @Router       /chat [post]
 func (h *ChatItemHandler) Create(c *fiber.Ctx) error {
-	body, ok := resp.BodyBindAndValidate[dto.ChatItemCreateDto](c)
+	body, ok := validation.BodyBindAndValidate[dto.ChatItemCreateDto](c)
 	if !ok {
 		return nil
 	}
@@ -116,12 +155,12 @@ func (h *ChatItemHandler) Create(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /chat/{id} [put]
 func (h *ChatItemHandler) Update(c *fiber.Ctx) error {
-	param, ok := resp.ParamsBindAndValidate[common.ID](c)
+	param, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
 
-	body, ok := resp.BodyBindAndValidate[dto.ChatItemUpdateDto](c)
+	body, ok := validation.BodyBindAndValidate[dto.ChatItemUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -144,7 +183,7 @@ func (h *ChatItemHandler) Update(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /chat/{id} [delete]
 func (h *ChatItemHandler) Delete(c *fiber.Ctx) error {
-	param, ok := resp.ParamsBindAndValidate[common.ID](c)
+	param, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
@@ -157,3 +196,69 @@ func (h *ChatItemHandler) Delete(c *fiber.Ctx) error {
 	}
 	return resp.OK(c)
 }
+
+// AddTags godoc
+// @Summary      Add tags to chat item
+// @Tags         chat
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path int true "Chat item ID"
+// @Param        body body dto.ChatItemTagsDto true "Tag IDs"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /chat/{id}/tags [post]
+func (h *ChatItemHandler) AddTags(c *fiber.Ctx) error {
+	param, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+
+	body, ok := validation.BodyBindAndValidate[dto.ChatItemTagsDto](c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	tags, err := h.Service.ChatItem.AddTags(ctx, param.ID, body)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, tags)
+}
+
+// RemoveTag godoc
+// @Summary      Remove tag from chat item
+// @Tags         chat
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id    path int true "Chat item ID"
+// @Param        tagID path int true "Tag ID"
+// @Success      200 {object} dto.Response
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /chat/{id}/tags/{tagID} [delete]
+func (h *ChatItemHandler) RemoveTag(c *fiber.Ctx) error {
+	param, ok := validation.ParamsBindAndValidate[dto.ChatItemTagParam](c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	if err := h.Service.ChatItem.RemoveTag(ctx, param.ID, param.TagID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
@@ -13,6 +13,8 @@ import (
 	"net/http"
 	"templatev25/internal/app"
 	"templatev25/internal/auth"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 	"git.gerege.mn/backend-packages/httpx"
 	"git.gerege.mn/backend-packages/resp"
 	"time"
@@ -87,11 +89,11 @@ func (h *ClientHandler) ScopeList(c *fiber.Ctx) error {
 // @Security     BearerAuth
 // @Accept       json
 // @Produce      json
-// @Param        body body map[string]interface{} true "Scope data"
+// @Param        body body dto.ClientScopeDto true "Scope data"
 // @Success      200 {object} map[string]interface{}
 // @Router       /client/scope [post]
 func (h *ClientHandler) ScopeCreate(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[map[string]any](c)
+	req, ok := validation.BodyBindAndValidate[dto.ClientScopeDto](c)
 	if !ok {
 		return nil
 	}
@@ -104,11 +106,11 @@ func (h *ClientHandler) ScopeCreate(c *fiber.Ctx) error {
 // @Security     BearerAuth
 // @Accept       json
 // @Produce      json
-// @Param        body body map[string]interface{} true "Scope data"
+// @Param        body body dto.ClientScopeDto true "Scope data"
 // @Success      200 {object} map[string]interface{}
 // @Router       /client/scope [delete]
 func (h *ClientHandler) ScopeDelete(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[map[string]any](c)
+	req, ok := validation.BodyBindAndValidate[dto.ClientScopeDto](c)
 	if !ok {
 		return nil
 	}
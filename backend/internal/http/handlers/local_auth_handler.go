@@ -8,9 +8,12 @@ import (
 	"errors"
 
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/locals"
+	"templatev25/internal/http/validation"
 	"templatev25/internal/service"
 
 	"git.gerege.mn/backend-packages/resp"
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -36,10 +39,11 @@ func NewLocalAuthHandler(authService *service.AuthService) *LocalAuthHandler {
 // @Success      200 {object} dto.LoginResponse
 // @Failure      400 {object} dto.ErrorResponse
 // @Failure      401 {object} dto.ErrorResponse "Invalid credentials"
+// @Failure      403 {object} dto.ErrorResponse "Account suspended or not active"
 // @Failure      423 {object} dto.ErrorResponse "Account locked"
 // @Router       /auth/local/login [post]
 func (h *LocalAuthHandler) Login(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.LoginRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.LoginRequest](c)
 	if !ok {
 		return nil
 	}
@@ -64,6 +68,11 @@ func (h *LocalAuthHandler) Login(c *fiber.Ctx) error {
 				"success": false,
 				"message": "account is locked due to too many failed attempts",
 			})
+		case errors.Is(err, service.ErrAccountSuspended):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"message": "account is suspended, contact an administrator",
+			})
 		case errors.Is(err, service.ErrAccountNotActive):
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 				"success": false,
@@ -113,7 +122,7 @@ func (h *LocalAuthHandler) Login(c *fiber.Ctx) error {
 // @Failure      401 {object} dto.ErrorResponse "Invalid code"
 // @Router       /auth/local/verify-mfa [post]
 func (h *LocalAuthHandler) VerifyMFA(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.VerifyMFARequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.VerifyMFARequest](c)
 	if !ok {
 		return nil
 	}
@@ -175,7 +184,7 @@ func (h *LocalAuthHandler) VerifyMFA(c *fiber.Ctx) error {
 // @Failure      401 {object} dto.ErrorResponse "Invalid code"
 // @Router       /auth/local/verify-backup-code [post]
 func (h *LocalAuthHandler) VerifyBackupCode(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.VerifyBackupCodeRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.VerifyBackupCodeRequest](c)
 	if !ok {
 		return nil
 	}
@@ -310,10 +319,63 @@ func (h *LocalAuthHandler) RefreshSession(c *fiber.Ctx) error {
 	})
 }
 
+// Impersonate godoc
+// @Summary      Impersonate a user (admin only)
+// @Description  Зорилтот хэрэглэгчийн нэрээр 30 минутын local session үүсгэнэ (support). Audit trail-д админы ID, шалтгаан бичигдэнэ
+// @Tags         local-auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ImpersonateRequest true "Target user and reason"
+// @Success      200 {object} dto.LoginResponse
+// @Failure      400 {object} dto.ErrorResponse "Self impersonation"
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      403 {object} dto.ErrorResponse "Not admin, target has an equal or higher role, or target account not active"
+// @Failure      404 {object} dto.ErrorResponse "Target user not found"
+// @Router       /auth/local/impersonate [post]
+func (h *LocalAuthHandler) Impersonate(c *fiber.Ctx) error {
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok || claims.UserID == 0 {
+		return resp.Unauthorized(c)
+	}
+
+	req, ok := validation.BodyBindAndValidate[dto.ImpersonateRequest](c)
+	if !ok {
+		return nil
+	}
+
+	session, err := h.authService.Impersonate(
+		c.UserContext(),
+		claims.UserID,
+		req.TargetUserID,
+		req.Reason,
+		c.IP(),
+		c.Get("User-Agent"),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSelfImpersonation):
+			return resp.BadRequest(c, err.Error(), nil)
+		case errors.Is(err, service.ErrUserNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrAccountSuspended), errors.Is(err, service.ErrAccountNotActive),
+			errors.Is(err, service.ErrImpersonationDenied):
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		default:
+			return resp.InternalServerError(c, err.Error())
+		}
+	}
+
+	return resp.OK(c, dto.LoginResponse{
+		AccessToken: session.SessionID,
+		ExpiresAt:   session.ExpiresAt.Unix(),
+	})
+}
+
 // Helper functions
 func getSessionID(c *fiber.Ctx) string {
 	// Try to get from context (set by session auth middleware)
-	if sid, ok := c.Locals("session_id").(string); ok {
+	if sid, ok := locals.Get[string](c, "session_id"); ok {
 		return sid
 	}
 
@@ -327,8 +389,5 @@ func getSessionID(c *fiber.Ctx) string {
 }
 
 func getUserID(c *fiber.Ctx) int {
-	if userID, ok := c.Locals("user_id").(int); ok {
-		return userID
-	}
-	return 0
+	return locals.GetOrDefault(c, "user_id", 0)
 }
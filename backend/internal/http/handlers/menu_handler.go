@@ -10,11 +10,14 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"time"
 
 	"templatev25/internal/app"
+	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"git.gerege.mn/backend-packages/resp"
 	ssoclient "git.gerege.mn/backend-packages/sso-client"
@@ -121,7 +124,7 @@ func (h *MenuHandler) Get(c *fiber.Ctx) error {
 // @Success      201 {object} map[string]interface{}
 // @Router       /menu [post]
 func (h *MenuHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.MenuCreateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.MenuCreateDto](c)
 	if !ok {
 		return nil
 	}
@@ -154,7 +157,7 @@ func (h *MenuHandler) Update(c *fiber.Ctx) error {
 		return resp.BadRequest(c, "invalid menu id", err.Error())
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.MenuUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.MenuUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -194,3 +197,73 @@ func (h *MenuHandler) Delete(c *fiber.Ctx) error {
 	}
 	return resp.OK(c)
 }
+
+// SetPermissions godoc
+// @Summary      Replace permissions required by menu
+// @Description  permission_ids-ээр бүрэн солино. Бүх permission-ийг эзэмшсэн хэрэглэгчид menu /me/menu-д харагдана.
+// @Tags         menu
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path int64 true "Menu ID"
+// @Param        body body dto.MenuPermissionsDto true "payload"
+// @Success      200 {object} map[string]interface{}
+// @Failure      404 {object} map[string]interface{}
+// @Router       /menu/{id}/permissions [post]
+func (h *MenuHandler) SetPermissions(c *fiber.Ctx) error {
+	id64, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return resp.BadRequest(c, "invalid menu id", err.Error())
+	}
+
+	req, ok := validation.BodyBindAndValidate[dto.MenuPermissionsDto](c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	if err := h.Service.Menu.SetPermissions(ctx, id64, req.PermissionIDs); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		h.Log.Warn("menu_set_permissions_failed", zap.Error(err))
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
+
+// Reorder godoc
+// @Summary      Reorder menus
+// @Description  Drag-and-drop дарааллыг нэг bulk UPDATE-аар хадгална. ID давхардсан эсвэл sequence цоорхойтой бол 400, байхгүй menu бол 404.
+// @Tags         menu
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.MenuReorderDto true "payload"
+// @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} map[string]interface{}
+// @Failure      404 {object} map[string]interface{}
+// @Router       /menu/reorder [put]
+func (h *MenuHandler) Reorder(c *fiber.Ctx) error {
+	req, ok := validation.BodyBindAndValidate[dto.MenuReorderDto](c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	if err := h.Service.Menu.Reorder(ctx, req.Orders); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, domain.ErrNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		h.Log.Warn("menu_reorder_failed", zap.Error(err))
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
@@ -12,7 +12,10 @@ import (
 	"templatev25/internal/http/dto"
 
 	"context"
+	"errors"
 	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/validation"
 	"time"
 
 	"git.gerege.mn/backend-packages/common"
@@ -43,7 +46,7 @@ func NewModuleHandler(d *app.Dependencies) *ModuleHandler {
 // @Success      200 {object} map[string]interface{}
 // @Router       /module [get]
 func (h *ModuleHandler) List(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.ModuleListQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.ModuleListQuery](c)
 	if !ok {
 		return nil
 	}
@@ -69,7 +72,7 @@ func (h *ModuleHandler) List(c *fiber.Ctx) error {
 // @Success      201 {object} map[string]interface{}
 // @Router       /module [post]
 func (h *ModuleHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.ModuleCreateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.ModuleCreateDto](c)
 	if !ok {
 		return nil
 	}
@@ -95,11 +98,11 @@ func (h *ModuleHandler) Create(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /module/{id} [put]
 func (h *ModuleHandler) Update(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c)
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
-	req, ok := resp.BodyBindAndValidate[dto.ModuleUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.ModuleUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -123,7 +126,7 @@ func (h *ModuleHandler) Update(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /module/{id} [delete]
 func (h *ModuleHandler) Delete(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c)
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
@@ -137,3 +140,42 @@ func (h *ModuleHandler) Delete(c *fiber.Ctx) error {
 	}
 	return resp.OK(c)
 }
+
+// Permissions godoc
+// @Summary      List module permissions
+// @Description  Get paginated permissions of a single module
+// @Tags         module
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id     path  int    true  "Module ID"
+// @Param        page   query int    false "Page number"
+// @Param        size   query int    false "Page size"
+// @Param        search query string false "Search (code/name/description)"
+// @Param        sort   query string false "Sort (e.g. code:asc)"
+// @Param        include_inactive_modules query bool false "Include permissions when the module is inactive"
+// @Success      200 {object} map[string]interface{}
+// @Failure      404 {object} dto.ErrorResponse
+// @Router       /module/{id}/permissions [get]
+func (h *ModuleHandler) Permissions(c *fiber.Ctx) error {
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	q, ok := validation.QueryBindAndValidate[dto.PermissionQuery](c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	items, total, page, size, err := h.Service.Permission.ListByModule(ctx, params.ID, q)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "module not found")
+		}
+		h.Log.Error("module_permissions_failed", zap.Int("module_id", params.ID), zap.Error(err))
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.Paginated(c, items, total, page, size)
+}
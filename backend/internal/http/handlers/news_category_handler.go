@@ -0,0 +1,129 @@
+// Package handlers provides implementation for handlers
+//
+// File: news_category_handler.go
+// Description: News category handlers implementation
+package handlers
+
+import (
+	"errors"
+
+	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
+
+	"git.gerege.mn/backend-packages/common"
+	"git.gerege.mn/backend-packages/resp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NewsCategoryHandler struct{ *app.Dependencies }
+
+func NewNewsCategoryHandler(d *app.Dependencies) *NewsCategoryHandler {
+	return &NewsCategoryHandler{Dependencies: d}
+}
+
+// List godoc
+// @Summary      List news categories
+// @Tags         news
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        size query int false "Page size"
+// @Success      200 {object} dto.Response{data=dto.PaginatedResponse[domain.NewsCategory]}
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /news/category [get]
+func (h *NewsCategoryHandler) List(c *fiber.Ctx) error {
+	q, ok := validation.QueryBindAndValidate[common.PaginationQuery](c)
+	if !ok {
+		return nil
+	}
+	items, total, page, size, err := h.Service.NewsCategory.List(c.UserContext(), q)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, dto.NewPaginatedResponse(items, total, page, size))
+}
+
+// Create godoc
+// @Summary      Create news category
+// @Tags         news
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.NewsCategoryDto true "Category data"
+// @Success      201 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /news/category [post]
+func (h *NewsCategoryHandler) Create(c *fiber.Ctx) error {
+	req, ok := validation.BodyBindAndValidate[dto.NewsCategoryDto](c)
+	if !ok {
+		return nil
+	}
+	if err := h.Service.NewsCategory.Create(c.UserContext(), req); err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.Created(c)
+}
+
+// Update godoc
+// @Summary      Update news category
+// @Tags         news
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path int true "Category ID"
+// @Param        body body dto.NewsCategoryDto true "Category data"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /news/category/{id} [put]
+func (h *NewsCategoryHandler) Update(c *fiber.Ctx) error {
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	req, ok := validation.BodyBindAndValidate[dto.NewsCategoryDto](c)
+	if !ok {
+		return nil
+	}
+	if err := h.Service.NewsCategory.Update(c.UserContext(), idp.ID, req); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "news category not found")
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
+
+// Delete godoc
+// @Summary      Delete news category
+// @Description  Soft-deletes the category; news in it become uncategorized
+// @Tags         news
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Category ID"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /news/category/{id} [delete]
+func (h *NewsCategoryHandler) Delete(c *fiber.Ctx) error {
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	if err := h.Service.NewsCategory.Delete(c.UserContext(), idp.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "news category not found")
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
@@ -9,8 +9,12 @@
 package handlers
 
 import (
+	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
+	"templatev25/internal/service"
 
+	"errors"
 	"strconv"
 
 	"templatev25/internal/app"
@@ -33,12 +37,14 @@ func NewNewsHandler(d *app.Dependencies) *NewsHandler {
 // @Produce      json
 // @Param        page query int false "Page number"
 // @Param        size query int false "Page size"
-// @Success      200 {object} dto.PaginatedResponse
+// @Param        category_id query int false "Filter by category ID"
+// @Param        is_published query bool false "Filter by publish state"
+// @Success      200 {object} dto.Response{data=dto.PaginatedResponse[domain.News]}
 // @Failure      400 {object} dto.ErrorResponse
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /news [get]
 func (h *NewsHandler) List(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.NewsListQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.NewsListQuery](c)
 	if !ok {
 		return nil
 	}
@@ -46,7 +52,7 @@ func (h *NewsHandler) List(c *fiber.Ctx) error {
 	if err != nil {
 		return resp.InternalServerError(c, err.Error())
 	}
-	return resp.Paginated(c, items, total, page, size)
+	return resp.OK(c, dto.NewPaginatedResponse(items, total, page, size))
 }
 
 // Get godoc
@@ -85,7 +91,7 @@ func (h *NewsHandler) Get(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /news [post]
 func (h *NewsHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.NewsDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.NewsDto](c)
 	if !ok {
 		return nil
 	}
@@ -112,11 +118,11 @@ func (h *NewsHandler) Create(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /news/{id} [put]
 func (h *NewsHandler) Update(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
-	req, ok := resp.BodyBindAndValidate[dto.NewsDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.NewsDto](c)
 	if !ok {
 		return nil
 	}
@@ -128,6 +134,100 @@ func (h *NewsHandler) Update(c *fiber.Ctx) error {
 	return resp.OK(c)
 }
 
+// UploadAttachment godoc
+// @Summary      Upload news image
+// @Description  Stores a JPEG/PNG/WebP image (max 5 MB) and sets it as the news image_url
+// @Tags         news
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id   path     int  true "News ID"
+// @Param        file formData file true "Image file"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      413 {object} dto.ErrorResponse
+// @Failure      415 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /news/{id}/attachment [post]
+func (h *NewsHandler) UploadAttachment(c *fiber.Ctx) error {
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "file is required")
+	}
+
+	url, err := h.Service.News.UploadAttachment(c.UserContext(), idp.ID, header)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			return fiber.NewError(fiber.StatusNotFound, "news not found")
+		case errors.Is(err, service.ErrAttachmentTooLarge):
+			return fiber.NewError(fiber.StatusRequestEntityTooLarge, err.Error())
+		case errors.Is(err, service.ErrAttachmentUnsupported):
+			return fiber.NewError(fiber.StatusUnsupportedMediaType, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, fiber.Map{"image_url": url})
+}
+
+// Publish godoc
+// @Summary      Publish news
+// @Tags         news
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "News ID"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /news/{id}/publish [patch]
+func (h *NewsHandler) Publish(c *fiber.Ctx) error {
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	if err := h.Service.News.Publish(c.UserContext(), idp.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "news not found")
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
+
+// Unpublish godoc
+// @Summary      Unpublish news (back to draft)
+// @Tags         news
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "News ID"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /news/{id}/unpublish [patch]
+func (h *NewsHandler) Unpublish(c *fiber.Ctx) error {
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	if err := h.Service.News.Unpublish(c.UserContext(), idp.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "news not found")
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
+
 // Delete godoc
 // @Summary      Delete news
 // @Tags         news
@@ -141,7 +241,7 @@ func (h *NewsHandler) Update(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /news/{id} [delete]
 func (h *NewsHandler) Delete(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
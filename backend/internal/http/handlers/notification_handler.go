@@ -9,16 +9,27 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"time"
+
+	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"templatev25/internal/app"
 	"git.gerege.mn/backend-packages/sso-client"
 	"git.gerege.mn/backend-packages/common"
+	"git.gerege.mn/backend-packages/ctx"
 	"git.gerege.mn/backend-packages/resp"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
 )
 
+// wsUserIDKey нь WebSocket upgrade-ийн өмнө баталгаажсан user ID-г Locals-д хадгалах түлхүүр
+const wsUserIDKey = "ws_user_id"
+
 type NotificationHandler struct {
 	*app.Dependencies
 }
@@ -38,7 +49,7 @@ func NewNotificationHandler(d *app.Dependencies) *NotificationHandler {
 // @Success      200 {object} map[string]interface{}
 // @Router       /notification [get]
 func (h *NotificationHandler) List(c *fiber.Ctx) error {
-	p, ok := resp.ParamsBindAndValidate[common.PaginationQuery](c)
+	p, ok := validation.ParamsBindAndValidate[common.PaginationQuery](c)
 	if !ok {
 		return nil
 	}
@@ -65,7 +76,7 @@ func (h *NotificationHandler) List(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /notification/groups [get]
 func (h *NotificationHandler) Groups(c *fiber.Ctx) error {
-	p, ok := resp.ParamsBindAndValidate[common.PaginationQuery](c)
+	p, ok := validation.ParamsBindAndValidate[common.PaginationQuery](c)
 	if !ok {
 		return nil
 	}
@@ -86,7 +97,7 @@ func (h *NotificationHandler) Groups(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /notification/read [post]
 func (h *NotificationHandler) Read(c *fiber.Ctx) error {
-	req, ok := resp.ParamsBindAndValidate[dto.NotificationReadDto](c)
+	req, ok := validation.ParamsBindAndValidate[dto.NotificationReadDto](c)
 	if !ok {
 		return nil
 	}
@@ -120,6 +131,84 @@ func (h *NotificationHandler) ReadAll(c *fiber.Ctx) error {
 	return resp.OK(c)
 }
 
+// Stats godoc
+// @Summary      Unread notification counts
+// @Description  Уншаагүй мэдэгдлийн тоо: нийт болон type-аар
+// @Tags         notification
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} dto.Response{data=dto.NotificationStatsResponse}
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /notification/stats [get]
+func (h *NotificationHandler) Stats(c *fiber.Ctx) error {
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
+	stats, err := h.Service.Notification.Stats(c.UserContext(), claims.UserID)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, stats)
+}
+
+// UnreadCount godoc
+// @Summary      Unread notification count
+// @Description  Уншаагүй мэдэгдлийн нийт тоо (frontend badge). 5 секунд cache-тэй.
+// @Tags         notification
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} dto.Response{data=dto.NotificationUnreadCountResponse}
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /me/notifications/unread-count [get]
+func (h *NotificationHandler) UnreadCount(c *fiber.Ctx) error {
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
+	count, err := h.Service.Notification.UnreadCount(c.UserContext(), claims.UserID)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, dto.NotificationUnreadCountResponse{Count: count})
+}
+
+// ReadBatch godoc
+// @Summary      Mark multiple notifications as read
+// @Description  {"ids":[1,2,3]} эсвэл {"all":true}. Өөрчлөгдсөн мөрийн тоог буцаана.
+// @Tags         notification
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.NotificationReadBatchDto true "Notification IDs or all"
+// @Success      200 {object} dto.NotificationReadBatchResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /notification/read-batch [post]
+func (h *NotificationHandler) ReadBatch(c *fiber.Ctx) error {
+	req, ok := validation.BodyBindAndValidate[dto.NotificationReadBatchDto](c)
+	if !ok {
+		return nil
+	}
+
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
+
+	updated, err := h.Service.Notification.MarkReadBatch(c.UserContext(), claims.UserID, req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, dto.NotificationReadBatchResponse{Updated: updated})
+}
+
 // Send godoc
 // @Summary      Send notification
 // @Tags         notification
@@ -128,9 +217,10 @@ func (h *NotificationHandler) ReadAll(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        body body dto.NotificationSendDto true "Notification data"
 // @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} dto.ErrorResponse
 // @Router       /notification [post]
 func (h *NotificationHandler) Send(c *fiber.Ctx) error {
-	req, ok := resp.ParamsBindAndValidate[dto.NotificationSendDto](c)
+	req, ok := validation.ParamsBindAndValidate[dto.NotificationSendDto](c)
 	if !ok {
 		return nil
 	}
@@ -145,7 +235,93 @@ func (h *NotificationHandler) Send(c *fiber.Ctx) error {
 		req,
 		claims.Username,
 	); err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
+
+// SendGroup godoc
+// @Summary      Send notification to a group of users
+// @Description  user_ids-ийн хэрэглэгч бүрт мэдэгдэл үүсгэнэ (1-1000 хэрэглэгч, эс бөгөөс 422)
+// @Tags         notification
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.NotificationGroupDto true "Recipients and notification"
+// @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      422 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /notification/group [post]
+func (h *NotificationHandler) SendGroup(c *fiber.Ctx) error {
+	req, ok := validation.BodyBindAndValidate[dto.NotificationGroupDto](c)
+	if !ok {
+		return nil
+	}
+
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
+
+	if err := h.Service.Notification.CreateGroup(c.UserContext(), req, claims.Username); err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return resp.InternalServerError(c, err.Error())
 	}
 	return resp.OK(c)
 }
+
+// WSAuth нь WebSocket upgrade хийхээс өмнө ?token= параметрийг SSO cache-ээр шалгана.
+// Browser WebSocket нь header дамжуулж чаддаггүй тул token-г query-оор авна.
+func (h *NotificationHandler) WSAuth(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+	token := c.Query("token")
+	if token == "" {
+		return fiber.NewError(fiber.StatusUnauthorized, fiber.ErrUnauthorized.Message)
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(c.UserContext(), 3*time.Second)
+	defer cancel()
+
+	claims, err := h.SSO.GetClaims(ctxTimeout, token, ctx.RequestID(c))
+	if err != nil || claims.UserID == 0 {
+		return fiber.NewError(fiber.StatusUnauthorized, fiber.ErrUnauthorized.Message)
+	}
+	c.Locals(wsUserIDKey, claims.UserID)
+	return c.Next()
+}
+
+// WS godoc
+// @Summary      Real-time notifications (WebSocket)
+// @Description  WebSocket холболт. Шинэ мэдэгдэл бүр JSON (domain.Notification) хэлбэрээр ирнэ.
+// @Tags         notification
+// @Param        token query string true "Session token"
+// @Success      101
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      426 {object} dto.ErrorResponse
+// @Router       /notification/ws [get]
+func (h *NotificationHandler) WS(conn *websocket.Conn) {
+	userID, _ := conn.Locals(wsUserIDKey).(int)
+	hub := h.Service.Notification.Hub()
+
+	hub.Register(userID, conn)
+	defer func() {
+		hub.Unregister(userID, conn)
+		conn.Close()
+	}()
+
+	// Client холболтоо таслах хүртэл уншина (ping/close frame-ууд)
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
@@ -9,10 +9,13 @@
 package handlers
 
 import (
+	"errors"
 	"strings"
 
 	"templatev25/internal/app"
+	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/resp"
@@ -40,7 +43,7 @@ func NewOrganizationHandler(d *app.Dependencies) *OrganizationHandler {
 // @Failure      500 {object} map[string]interface{} "Server error"
 // @Router       /organization/find [get]
 func (h *OrganizationHandler) FindFromCore(c *fiber.Ctx) error {
-	req, ok := resp.QueryBindAndValidate[ssoclient.ReqFind](c)
+	req, ok := validation.QueryBindAndValidate[ssoclient.ReqFind](c)
 	if !ok {
 		return nil
 	}
@@ -62,10 +65,10 @@ func (h *OrganizationHandler) FindFromCore(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        page query int false "Page number"
 // @Param        size query int false "Page size"
-// @Success      200 {object} map[string]interface{}
+// @Success      200 {object} dto.Response{data=dto.PaginatedResponse[domain.Organization]}
 // @Router       /organization [get]
 func (h *OrganizationHandler) List(c *fiber.Ctx) error {
-	p, ok := resp.ParamsBindAndValidate[common.PaginationQuery](c)
+	p, ok := validation.ParamsBindAndValidate[common.PaginationQuery](c)
 	if !ok {
 		return nil
 	}
@@ -73,7 +76,7 @@ func (h *OrganizationHandler) List(c *fiber.Ctx) error {
 	if err != nil {
 		return resp.InternalServerError(c, err.Error())
 	}
-	return resp.Paginated(c, items, total, page, size)
+	return resp.OK(c, dto.NewPaginatedResponse(items, total, page, size))
 }
 
 // Create godoc
@@ -87,7 +90,7 @@ func (h *OrganizationHandler) List(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /organization [post]
 func (h *OrganizationHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.OrganizationDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.OrganizationDto](c)
 	if !ok {
 		return nil
 	}
@@ -109,19 +112,26 @@ func (h *OrganizationHandler) Create(c *fiber.Ctx) error {
 // @Param        id   path int true "Organization ID"
 // @Param        body body dto.OrganizationUpdateDto true "Organization data"
 // @Success      200 {object} map[string]interface{}
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      409 {object} dto.ErrorResponse "Organization was modified by another request"
 // @Router       /organization/{id} [put]
 func (h *OrganizationHandler) Update(c *fiber.Ctx) error {
-	idParam, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idParam, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
-	req, ok := resp.BodyBindAndValidate[dto.OrganizationUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.OrganizationUpdateDto](c)
 	if !ok {
 		return nil
 	}
 	out, err := h.Service.Organization.Update(c.UserContext(), idParam.ID, req)
 	if err != nil {
-
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrConflict) {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
 		return resp.InternalServerError(c, err.Error())
 	}
 	return resp.OK(c, out)
@@ -137,7 +147,7 @@ func (h *OrganizationHandler) Update(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /organization/{id} [delete]
 func (h *OrganizationHandler) Delete(c *fiber.Ctx) error {
-	idParam, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idParam, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
@@ -158,19 +168,227 @@ func (h *OrganizationHandler) Delete(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /organization/tree [get]
 func (h *OrganizationHandler) Tree(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.OrganizationTreeQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.OrganizationTreeQuery](c)
 	if !ok {
 		return nil
 	}
 
 	items, err := h.Service.Organization.Tree(c.UserContext(), q.OrgId)
 	if err != nil {
-
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
 		return resp.InternalServerError(c, err.Error())
 	}
 	return resp.OK(c, items)
 }
 
+// Get godoc
+// @Summary      Get organization detail
+// @Description  Байгууллага, түүний төрлийн нэр, устгагдаагүй шууд дэд байгууллага болон хэрэглэгчийн тоо
+// @Tags         organization
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Organization ID"
+// @Success      200 {object} dto.Response{data=dto.OrganizationDetail}
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /organization/{id} [get]
+func (h *OrganizationHandler) Get(c *fiber.Ctx) error {
+	idParam, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+
+	detail, err := h.Service.Organization.GetByID(c.UserContext(), idParam.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, detail)
+}
+
+// TreeByID godoc
+// @Summary      Get organization subtree
+// @Description  Байгууллага болон түүний бүх түвшний дэд байгууллагууд (children дотор шаталсан)
+// @Tags         organization
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Organization ID"
+// @Success      200 {object} dto.Response{data=domain.Organization}
+// @Failure      404 {object} map[string]interface{}
+// @Router       /organization/{id}/tree [get]
+func (h *OrganizationHandler) TreeByID(c *fiber.Ctx) error {
+	idParam, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+
+	items, err := h.Service.Organization.Tree(c.UserContext(), idParam.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, items[0])
+}
+
+// Stats godoc
+// @Summary      Organization counts per type
+// @Description  Устгагдаагүй байгууллагын тоо, хамгийн сүүлд үүссэн огноо төрөл тус бүрээр (dashboard)
+// @Tags         organization
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} dto.OrgTypeStats
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /organization/stats [get]
+func (h *OrganizationHandler) Stats(c *fiber.Ctx) error {
+	stats, err := h.Service.Organization.Stats(c.UserContext())
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, stats)
+}
+
+// Search godoc
+// @Summary      Full-text search organizations
+// @Description  Нэр, код, регистрийн дугаараар prefix хайлт (PostgreSQL tsvector)
+// @Tags         organization
+// @Security     BearerAuth
+// @Produce      json
+// @Param        q    query string true  "Search text"
+// @Param        page query int    false "Page number"
+// @Param        size query int    false "Page size"
+// @Success      200 {object} dto.Response{data=dto.PaginatedResponse[domain.Organization]}
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /organization/search [get]
+func (h *OrganizationHandler) Search(c *fiber.Ctx) error {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "q is required")
+	}
+	p, ok := validation.QueryBindAndValidate[common.PaginationQuery](c)
+	if !ok {
+		return nil
+	}
+	items, total, page, size, err := h.Service.Organization.Search(c.UserContext(), q, p)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, dto.NewPaginatedResponse(items, total, page, size))
+}
+
+// Restore godoc
+// @Summary      Restore deleted organization
+// @Description  Undo a soft delete by clearing deleted_date. Organization memberships removed on delete are not restored.
+// @Tags         organization
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Organization ID"
+// @Success      200 {object} map[string]interface{}
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      403 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse "Organization does not exist"
+// @Failure      409 {object} dto.ErrorResponse "Organization is not deleted"
+// @Router       /organization/{id}/restore [put]
+func (h *OrganizationHandler) Restore(c *fiber.Ctx) error {
+	idParam, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	out, err := h.Service.Organization.Restore(c.UserContext(), idParam.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrConflict) {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, out)
+}
+
+// MoveToParent godoc
+// @Summary      Move organization under a new parent
+// @Description  Reparent an organization together with its subtree. parent_id = 0 makes it a root organization.
+// @Tags         organization
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path int                       true "Organization ID"
+// @Param        body body dto.OrganizationParentDto true "New parent"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /organization/{id}/parent [put]
+func (h *OrganizationHandler) MoveToParent(c *fiber.Ctx) error {
+	idParam, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	req, ok := validation.BodyBindAndValidate[dto.OrganizationParentDto](c)
+	if !ok {
+		return nil
+	}
+	if err := h.Service.Organization.MoveToParent(c.UserContext(), idParam.ID, *req.ParentID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
+
+// Users godoc
+// @Summary      Get users of organization
+// @Description  Get paginated users of an organization (org ID from path)
+// @Tags         organization
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path  int    true  "Organization ID"
+// @Param        name query string false "Filter by name"
+// @Param        page query int    false "Page number"
+// @Param        size query int    false "Page size"
+// @Success      200 {object} map[string]interface{}
+// @Failure      404 {object} dto.ErrorResponse
+// @Router       /organization/{id}/users [get]
+func (h *OrganizationHandler) Users(c *fiber.Ctx) error {
+	idParam, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	p, ok := validation.QueryBindAndValidate[common.PaginationQuery](c)
+	if !ok {
+		return nil
+	}
+
+	exists, err := h.Service.Organization.Exists(c.UserContext(), idParam.ID)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	if !exists {
+		return fiber.NewError(fiber.StatusNotFound, "organization not found")
+	}
+
+	name := strings.TrimSpace(c.Query("name"))
+	items, total, page, size, err := h.Service.OrgUser.UsersByOrg(c.UserContext(), idParam.ID, name, p)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.Paginated(c, items, total, page, size)
+}
+
 type OrganizationTypeHandler struct {
 	*app.Dependencies
 }
@@ -190,7 +408,7 @@ func NewOrganizationTypeHandler(d *app.Dependencies) *OrganizationTypeHandler {
 // @Success      200 {object} map[string]interface{}
 // @Router       /orgtype [get]
 func (h *OrganizationTypeHandler) List(c *fiber.Ctx) error {
-	p, ok := resp.QueryBindAndValidate[common.PaginationQuery](c)
+	p, ok := validation.QueryBindAndValidate[common.PaginationQuery](c)
 	if !ok {
 		return nil
 	}
@@ -211,7 +429,7 @@ func (h *OrganizationTypeHandler) List(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /orgtype [post]
 func (h *OrganizationTypeHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.OrganizationTypeDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.OrganizationTypeDto](c)
 	if !ok {
 		return nil
 	}
@@ -234,11 +452,11 @@ func (h *OrganizationTypeHandler) Create(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /orgtype/{id} [put]
 func (h *OrganizationTypeHandler) Update(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
-	req, ok := resp.BodyBindAndValidate[dto.OrganizationTypeDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.OrganizationTypeDto](c)
 	if !ok {
 		return nil
 	}
@@ -254,17 +472,29 @@ func (h *OrganizationTypeHandler) Update(c *fiber.Ctx) error {
 // @Summary      Delete organization type
 // @Tags         orgtype
 // @Security     BearerAuth
+// @Accept       json
 // @Produce      json
-// @Param        id path int true "ID"
+// @Param        id   path int true "ID"
+// @Param        body body dto.OrgTypeDeleteDto false "Delete reason"
 // @Success      200 {object} map[string]interface{}
+// @Failure      404 {object} dto.ErrorResponse
 // @Router       /orgtype/{id} [delete]
 func (h *OrganizationTypeHandler) Delete(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
-	if err := h.Service.OrganizationType.Delete(c.UserContext(), idp.ID); err != nil {
-
+	// Body заавал биш (reason)
+	var req dto.OrgTypeDeleteDto
+	if len(c.Body()) > 0 {
+		if req, ok = validation.BodyBindAndValidate[dto.OrgTypeDeleteDto](c); !ok {
+			return nil
+		}
+	}
+	if err := h.Service.OrganizationType.Delete(c.UserContext(), idp.ID, req.Reason); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
 		return resp.InternalServerError(c, err.Error())
 	}
 	return resp.OK(c)
@@ -279,7 +509,7 @@ func (h *OrganizationTypeHandler) Delete(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /orgtype/system [get]
 func (h *OrganizationTypeHandler) Systems(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.OrgTypeSystemsQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.OrgTypeSystemsQuery](c)
 	if !ok {
 		return nil
 	}
@@ -299,14 +529,17 @@ func (h *OrganizationTypeHandler) Systems(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        body body dto.OrgTypeAddSystemsDto true "payload"
 // @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} dto.ErrorResponse
 // @Router       /orgtype/system [post]
 func (h *OrganizationTypeHandler) AddSystems(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.OrgTypeAddSystemsDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.OrgTypeAddSystemsDto](c)
 	if !ok {
 		return nil
 	}
 	if err := h.Service.OrganizationType.AddSystems(c.UserContext(), req.TypeID, req.SystemIDs); err != nil {
-
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return resp.InternalServerError(c, err.Error())
 	}
 	return resp.OK(c)
@@ -321,7 +554,7 @@ func (h *OrganizationTypeHandler) AddSystems(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /orgtype/role [get]
 func (h *OrganizationTypeHandler) Roles(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.OrgTypeRolesQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.OrgTypeRolesQuery](c)
 	if !ok {
 		return nil
 	}
@@ -342,7 +575,7 @@ func (h *OrganizationTypeHandler) Roles(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /orgtype/role [post]
 func (h *OrganizationTypeHandler) AddRoles(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.OrgTypeRolesAddDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.OrgTypeRolesAddDto](c)
 	if !ok {
 		return nil
 	}
@@ -352,6 +585,62 @@ func (h *OrganizationTypeHandler) AddRoles(c *fiber.Ctx) error {
 	return resp.OK(c)
 }
 
+// Permissions godoc
+// @Summary      Get permissions linked to organization type
+// @Description  Байгууллагын төрөлд шууд олгосон permission-ууд (role-оор дамжаагүй)
+// @Tags         orgtype
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Organization Type ID"
+// @Success      200 {object} map[string]interface{}
+// @Failure      404 {object} map[string]interface{}
+// @Router       /orgtype/{id}/permissions [get]
+func (h *OrganizationTypeHandler) Permissions(c *fiber.Ctx) error {
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	items, err := h.Service.OrganizationType.Permissions(c.UserContext(), idp.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, items)
+}
+
+// SetPermissions godoc
+// @Summary      Replace permissions linked to organization type
+// @Description  permission_ids-ээр бүрэн солино. Тухайн төрлийн байгууллагын гишүүд эдгээр permission-тэй болно.
+// @Tags         orgtype
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Organization Type ID"
+// @Param        body body dto.OrgTypePermissionsDto true "payload"
+// @Success      200 {object} map[string]interface{}
+// @Failure      404 {object} map[string]interface{}
+// @Router       /orgtype/{id}/permissions [put]
+// @Router       /orgtype/{id}/permissions [post]
+func (h *OrganizationTypeHandler) SetPermissions(c *fiber.Ctx) error {
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	req, ok := validation.BodyBindAndValidate[dto.OrgTypePermissionsDto](c)
+	if !ok {
+		return nil
+	}
+	if err := h.Service.OrganizationType.SetPermissions(c.UserContext(), idp.ID, req.PermissionIDs); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
+
 type OrgUserHandler struct{ *app.Dependencies }
 
 func NewOrgUserHandler(d *app.Dependencies) *OrgUserHandler {
@@ -370,7 +659,7 @@ func NewOrgUserHandler(d *app.Dependencies) *OrgUserHandler {
 // @Success      200 {object} map[string]interface{}
 // @Router       /orguser [get]
 func (h *OrgUserHandler) List(c *fiber.Ctx) error {
-	q, ok := resp.ParamsBindAndValidate[dto.OrgUserListQuery](c)
+	q, ok := validation.ParamsBindAndValidate[dto.OrgUserListQuery](c)
 	if !ok {
 		return nil
 	}
@@ -396,14 +685,18 @@ func (h *OrgUserHandler) List(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        body body dto.OrgUserCreateDto true "payload"
 // @Success      200 {object} map[string]interface{}
+// @Failure      403 {object} dto.ErrorResponse "User has a role not allowed for the organization type"
 // @Router       /orguser [post]
 func (h *OrgUserHandler) Add(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.OrgUserCreateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.OrgUserCreateDto](c)
 	if !ok {
 		return nil
 	}
 	authHeader := c.Get(fiber.HeaderAuthorization)
 	if err := h.Service.OrgUser.Add(c.UserContext(), req, authHeader); err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		}
 		msg := err.Error()
 		if strings.Contains(msg, "duplicate") {
 			return resp.InternalServerError(c, "Хэрэглэгч аль хэдийн бүртгэгдсэн байна")
@@ -423,10 +716,34 @@ func (h *OrgUserHandler) Add(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /orguser [delete]
 func (h *OrgUserHandler) Remove(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.OrgUserDeleteDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.OrgUserDeleteDto](c)
+	if !ok {
+		return nil
+	}
+	if err := h.Service.OrgUser.Remove(c.UserContext(), req); err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c)
+}
+
+// RemoveByPath godoc
+// @Summary      Remove user from organization (path params)
+// @Description  DELETE /orguser-тэй ижил, org болон user ID-г path-аас авна
+// @Tags         orguser
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id     path int true "Organization ID"
+// @Param        userID path int true "User ID"
+// @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      422 {object} dto.ErrorResponse
+// @Router       /organization/{id}/user/{userID} [delete]
+func (h *OrgUserHandler) RemoveByPath(c *fiber.Ctx) error {
+	param, ok := validation.ParamsBindAndValidate[dto.OrgUserPathParam](c)
 	if !ok {
 		return nil
 	}
+	req := dto.OrgUserDeleteDto{OrgId: param.ID, UserId: param.UserID}
 	if err := h.Service.OrgUser.Remove(c.UserContext(), req); err != nil {
 		return resp.InternalServerError(c, err.Error())
 	}
@@ -452,7 +769,7 @@ func (h *OrgUserHandler) Users(c *fiber.Ctx) error {
 			orgId = claims.OrgID
 		}
 	}
-	p, ok := resp.ParamsBindAndValidate[common.PaginationQuery](c)
+	p, ok := validation.ParamsBindAndValidate[common.PaginationQuery](c)
 	if !ok {
 		return nil
 	}
@@ -484,7 +801,7 @@ func (h *OrgUserHandler) Orgs(c *fiber.Ctx) error {
 			userId = claims.UserID
 		}
 	}
-	p, ok := resp.ParamsBindAndValidate[common.PaginationQuery](c)
+	p, ok := validation.ParamsBindAndValidate[common.PaginationQuery](c)
 	if !ok {
 		return nil
 	}
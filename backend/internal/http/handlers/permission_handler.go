@@ -9,12 +9,16 @@
 package handlers
 
 import (
+	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"context"
+	"errors"
 	"templatev25/internal/app"
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/resp"
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -35,6 +39,7 @@ func NewPermissionHandler(d *app.Dependencies) *PermissionHandler {
 // @Security     BearerAuth
 // @Param        search    query   string false "Search (code/name/description)"
 // @Param        module_id query   int    false "Filter by module_id"
+// @Param        include_inactive_modules query bool false "Include permissions of inactive modules"
 // @Param        page      query   int    false "Page number"
 // @Param        size      query   int    false "Page size"
 // @Param        sort      query   string false "Sort (e.g. code:asc,name:desc)"
@@ -42,7 +47,7 @@ func NewPermissionHandler(d *app.Dependencies) *PermissionHandler {
 // @Success      200 {object} map[string]interface{}
 // @Router       /permissions [get]
 func (h *PermissionHandler) List(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.PermissionQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.PermissionQuery](c)
 	if !ok {
 		return nil
 	}
@@ -68,7 +73,7 @@ func (h *PermissionHandler) List(c *fiber.Ctx) error {
 // @Success      201 {object} map[string]interface{}
 // @Router       /permissions [post]
 func (h *PermissionHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.PermissionCreateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.PermissionCreateDto](c)
 	if !ok {
 		return nil
 	}
@@ -77,6 +82,9 @@ func (h *PermissionHandler) Create(c *fiber.Ctx) error {
 	defer cancel()
 
 	if err := h.Service.Permission.Create(ctx, req); err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		h.Log.Error("permission_create_failed", zap.Error(err))
 		return resp.InternalServerError(c, err.Error())
 	}
@@ -94,12 +102,12 @@ func (h *PermissionHandler) Create(c *fiber.Ctx) error {
 // @Success      200    {object} map[string]interface{}
 // @Router       /permissions/{id} [put]
 func (h *PermissionHandler) Update(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c)
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.PermissionUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.PermissionUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -123,7 +131,7 @@ func (h *PermissionHandler) Update(c *fiber.Ctx) error {
 // @Success      200  {object} map[string]interface{}
 // @Router       /permissions/{id} [delete]
 func (h *PermissionHandler) Delete(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c)
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
@@ -137,3 +145,42 @@ func (h *PermissionHandler) Delete(c *fiber.Ctx) error {
 	}
 	return resp.OK(c)
 }
+
+// Check godoc
+// @Summary      Check which of the given permissions the current user has
+// @Description  Нэг дуудлагаар 50 хүртэл permission код шалгана (PermissionCache-ээр)
+// @Tags         permissions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.PermissionCheckDto true "Permission codes"
+// @Success      200 {object} dto.Response{data=dto.PermissionCheckResponse}
+// @Failure      422 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /permission/check [post]
+func (h *PermissionHandler) Check(c *fiber.Ctx) error {
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
+	req, ok := validation.BodyBindAndValidate[dto.PermissionCheckDto](c)
+	if !ok {
+		return nil
+	}
+
+	codes, err := h.PermCache.GetUserPermissions(c.UserContext(), claims.CitizenID)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+
+	granted := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		granted[code] = true
+	}
+	results := make(map[string]bool, len(req.PermissionCodes))
+	for _, code := range req.PermissionCodes {
+		results[code] = granted[code]
+	}
+	return resp.OK(c, dto.PermissionCheckResponse{Results: results})
+}
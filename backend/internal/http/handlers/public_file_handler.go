@@ -12,6 +12,7 @@ import (
 	"templatev25/internal/http/dto"
 
 	"templatev25/internal/app"
+	"templatev25/internal/http/validation"
 
 	"templatev25/internal/service"
 	"git.gerege.mn/backend-packages/resp"
@@ -28,6 +29,12 @@ func NewFileHandler(d *app.Dependencies) *FileHandler {
 }
 
 // GET /file/:uuid  -> файл serve хийх
+// @Summary      Serve public file
+// @Tags         file
+// @Produce      octet-stream
+// @Param        uuid path string true "File name (uuid)"
+// @Success      200 {file} file
+// @Router       /file/{uuid} [get]
 func (h *FileHandler) GetFile(c *fiber.Ctx) error {
 	uuid := c.Params("uuid")
 	if uuid == "" {
@@ -38,6 +45,16 @@ func (h *FileHandler) GetFile(c *fiber.Ctx) error {
 }
 
 // POST /file/upload (multipart/form-data)
+// @Summary      Upload public file
+// @Tags         file
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file        formData file   true  "File"
+// @Param        description formData string false "Description"
+// @Param        name        formData string false "Existing file name to replace"
+// @Success      200 {object} map[string]interface{}
+// @Router       /file/upload [post]
 func (h *FileHandler) Upload(c *fiber.Ctx) error {
 	form, err := c.MultipartForm()
 	if err != nil {
@@ -71,8 +88,16 @@ func (h *FileHandler) Upload(c *fiber.Ctx) error {
 }
 
 // GET /file/list
+// @Summary      List public files
+// @Tags         file
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        size query int false "Page size"
+// @Success      200 {object} map[string]interface{}
+// @Router       /file/list [get]
 func (h *FileHandler) GetPublicFileList(c *fiber.Ctx) error {
-	q, ok := resp.ParamsBindAndValidate[dto.PublicFileListQuery](c)
+	q, ok := validation.ParamsBindAndValidate[dto.PublicFileListQuery](c)
 	if !ok {
 		return nil
 	}
@@ -84,8 +109,16 @@ func (h *FileHandler) GetPublicFileList(c *fiber.Ctx) error {
 }
 
 // DELETE /file  (body: { "id": number })
+// @Summary      Delete public file
+// @Tags         file
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.PublicFileDeleteDto true "File to delete"
+// @Success      200 {object} map[string]interface{}
+// @Router       /file [delete]
 func (h *FileHandler) DeletePublicFile(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.PublicFileDeleteDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.PublicFileDeleteDto](c)
 	if !ok {
 		return nil
 	}
@@ -8,6 +8,7 @@ import (
 	"errors"
 
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 	"templatev25/internal/service"
 
 	"git.gerege.mn/backend-packages/resp"
@@ -35,10 +36,11 @@ func NewRegistrationHandler(registrationService *service.RegistrationService) *R
 // @Param        body body dto.RegisterRequest true "Registration data"
 // @Success      201 {object} dto.RegisterResponse
 // @Failure      400 {object} dto.ErrorResponse
+// @Failure      403 {object} dto.ErrorResponse "Registration disabled"
 // @Failure      409 {object} dto.ErrorResponse "Email already exists"
 // @Router       /auth/local/register [post]
 func (h *RegistrationHandler) Register(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.RegisterRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.RegisterRequest](c)
 	if !ok {
 		return nil
 	}
@@ -56,6 +58,8 @@ func (h *RegistrationHandler) Register(c *fiber.Ctx) error {
 	result, err := h.registrationService.Register(c.UserContext(), regReq)
 	if err != nil {
 		switch {
+		case errors.Is(err, service.ErrRegistrationDisabled):
+			return fiber.NewError(fiber.StatusForbidden, "registration is disabled")
 		case errors.Is(err, service.ErrEmailAlreadyExists):
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 				"success": false,
@@ -64,7 +68,7 @@ func (h *RegistrationHandler) Register(c *fiber.Ctx) error {
 		case errors.Is(err, service.ErrPasswordMismatch):
 			return resp.BadRequest(c, "passwords do not match", nil)
 		case errors.Is(err, service.ErrPasswordTooWeak):
-			return resp.BadRequest(c, "password does not meet requirements", nil)
+			return passwordTooWeak(c, err)
 		default:
 			return resp.InternalServerError(c, err.Error())
 		}
@@ -89,7 +93,7 @@ func (h *RegistrationHandler) Register(c *fiber.Ctx) error {
 // @Failure      400 {object} dto.ErrorResponse "Invalid or expired token"
 // @Router       /auth/local/verify-email [post]
 func (h *RegistrationHandler) VerifyEmail(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.VerifyEmailRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.VerifyEmailRequest](c)
 	if !ok {
 		return nil
 	}
@@ -121,7 +125,7 @@ func (h *RegistrationHandler) VerifyEmail(c *fiber.Ctx) error {
 // @Failure      400 {object} dto.ErrorResponse
 // @Router       /auth/local/resend-verification [post]
 func (h *RegistrationHandler) ResendVerification(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.ResendVerificationRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.ResendVerificationRequest](c)
 	if !ok {
 		return nil
 	}
@@ -154,7 +158,7 @@ func (h *RegistrationHandler) ResendVerification(c *fiber.Ctx) error {
 // @Failure      400 {object} dto.ErrorResponse
 // @Router       /auth/local/forgot-password [post]
 func (h *RegistrationHandler) ForgotPassword(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.ForgotPasswordRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.ForgotPasswordRequest](c)
 	if !ok {
 		return nil
 	}
@@ -182,7 +186,7 @@ func (h *RegistrationHandler) ForgotPassword(c *fiber.Ctx) error {
 // @Failure      400 {object} dto.ErrorResponse "Invalid token or password"
 // @Router       /auth/local/reset-password [post]
 func (h *RegistrationHandler) ResetPassword(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.ResetPasswordConfirmRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.ResetPasswordConfirmRequest](c)
 	if !ok {
 		return nil
 	}
@@ -195,7 +199,11 @@ func (h *RegistrationHandler) ResetPassword(c *fiber.Ctx) error {
 		case errors.Is(err, service.ErrPasswordMismatch):
 			return resp.BadRequest(c, "passwords do not match", nil)
 		case errors.Is(err, service.ErrPasswordTooWeak):
-			return resp.BadRequest(c, "password does not meet requirements", nil)
+			return passwordTooWeak(c, err)
+		case errors.Is(err, service.ErrPasswordReused):
+			return resp.BadRequest(c, "password was recently used", nil)
+		case errors.Is(err, service.ErrCredentialsNotFound):
+			return resp.BadRequest(c, "invalid or expired reset token", nil)
 		default:
 			return resp.InternalServerError(c, err.Error())
 		}
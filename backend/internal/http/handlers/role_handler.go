@@ -12,7 +12,11 @@ import (
 	"templatev25/internal/http/dto"
 
 	"context"
+	"errors"
 	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/validation"
+	"templatev25/internal/service"
 	"time"
 
 	"git.gerege.mn/backend-packages/common"
@@ -51,7 +55,7 @@ func NewRoleHandler(d *app.Dependencies) *RoleHandler {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /role [get]
 func (h *RoleHandler) List(c *fiber.Ctx) error {
-	p, ok := resp.QueryBindAndValidate[dto.RoleListQuery](c)
+	p, ok := validation.QueryBindAndValidate[dto.RoleListQuery](c)
 	if !ok {
 		return nil
 	}
@@ -63,6 +67,34 @@ func (h *RoleHandler) List(c *fiber.Ctx) error {
 	return resp.Paginated(c, items, total, page, size)
 }
 
+// Get godoc
+// @Summary      Get role detail with permissions
+// @Tags         role
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Role ID"
+// @Success      200 {object} dto.RoleDetailDto
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /role/{id} [get]
+func (h *RoleHandler) Get(c *fiber.Ctx) error {
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	role, perms, err := h.Service.Role.GetByID(c.UserContext(), params.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "role not found")
+		}
+		h.Log.Error("role_get_failed", zap.Int("role_id", params.ID), zap.Error(err))
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, dto.RoleDetailDto{Role: role, Permissions: perms})
+}
+
 // Create godoc
 // @Summary      Create role
 // @Tags         role
@@ -76,7 +108,7 @@ func (h *RoleHandler) List(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /role [post]
 func (h *RoleHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.RoleCreateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.RoleCreateDto](c)
 	if !ok {
 		return nil
 	}
@@ -104,11 +136,11 @@ func (h *RoleHandler) Create(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /role/{id} [put]
 func (h *RoleHandler) Update(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c)
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
-	req, ok := resp.BodyBindAndValidate[dto.RoleUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.RoleUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -129,17 +161,21 @@ func (h *RoleHandler) Update(c *fiber.Ctx) error {
 // @Success      200 {object} dto.Response
 // @Failure      400 {object} dto.ErrorResponse
 // @Failure      401 {object} dto.ErrorResponse
+// @Failure      403 {object} dto.ErrorResponse
 // @Failure      404 {object} dto.ErrorResponse
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /role/{id} [delete]
 func (h *RoleHandler) Delete(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c)
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
 	// ctx доторх User/Org-г repo тал уншина
 	err := h.Service.Role.Delete(c.UserContext(), params.ID)
 	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		}
 		h.Log.Error("access_group_delete_failed", zap.Error(err))
 		return resp.InternalServerError(c, err.Error())
 	}
@@ -160,7 +196,7 @@ func (h *RoleHandler) Delete(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /role/permissions [get]
 func (h *RoleHandler) GetRolePermissions(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.RolePermissionsQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.RolePermissionsQuery](c)
 	if !ok {
 		return nil
 	}
@@ -174,6 +210,38 @@ func (h *RoleHandler) GetRolePermissions(c *fiber.Ctx) error {
 	return resp.OK(c, items)
 }
 
+// DiffRolePermissions godoc
+// @Summary      Preview permission changes of a role
+// @Description  Compares the role's current permissions with the proposed list without writing to the database
+// @Tags         role
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.RolePermissionsDiffDto true "Proposed permission IDs"
+// @Success      200 {object} dto.Response{data=dto.RolePermissionsDiffResponse}
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /role/permissions/diff [post]
+func (h *RoleHandler) DiffRolePermissions(c *fiber.Ctx) error {
+	req, ok := validation.BodyBindAndValidate[dto.RolePermissionsDiffDto](c)
+	if !ok {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	perms, err := h.Service.Role.GetPermissions(ctx, dto.RolePermissionsQuery{RoleID: req.RoleID})
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	current := make([]int, 0, len(perms))
+	for _, p := range perms {
+		current = append(current, p.ID)
+	}
+	return resp.OK(c, service.DiffPermissionIDs(current, req.ProposedIDs))
+}
+
 // --- ШИНЭ: POST /role/permissions (replace semantics)
 
 // SetRolePermissions godoc
@@ -190,7 +258,7 @@ func (h *RoleHandler) GetRolePermissions(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /role/permissions [post]
 func (h *RoleHandler) SetRolePermissions(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.RolePermissionsUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.RolePermissionsUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -14,6 +14,7 @@ import (
 	"context"
 	"strconv"
 	"templatev25/internal/app"
+	"templatev25/internal/http/validation"
 
 	"git.gerege.mn/backend-packages/ctx"
 	"git.gerege.mn/backend-packages/resp"
@@ -29,6 +30,13 @@ func NewRoomHandler(d *app.Dependencies) *RoomHandler {
 	return &RoomHandler{Dependencies: d}
 }
 
+// List godoc
+// @Summary      List video conference rooms
+// @Tags         room
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} map[string]interface{}
+// @Router       /room [get]
 func (h *RoomHandler) List(c *fiber.Ctx) error {
 	response, err := h.Service.Meet.List(c.UserContext())
 	if err != nil {
@@ -37,8 +45,17 @@ func (h *RoomHandler) List(c *fiber.Ctx) error {
 	return resp.OK(c, response.Data)
 }
 
+// Create godoc
+// @Summary      Create video conference room
+// @Tags         room
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.CreateRoomRequest true "Room data"
+// @Success      201 {object} map[string]interface{}
+// @Router       /room [post]
 func (h *RoomHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.CreateRoomRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.CreateRoomRequest](c)
 	if !ok {
 		return nil
 	}
@@ -51,8 +68,17 @@ func (h *RoomHandler) Create(c *fiber.Ctx) error {
 	return resp.Created(c, room.Data)
 }
 
+// Join godoc
+// @Summary      Join video conference room
+// @Tags         room
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.JoinRoomRequest true "Join request"
+// @Success      201 {object} map[string]interface{}
+// @Router       /room/join [post]
 func (h *RoomHandler) Join(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.JoinRoomRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.JoinRoomRequest](c)
 	if !ok {
 		return nil
 	}
@@ -65,6 +91,15 @@ func (h *RoomHandler) Join(c *fiber.Ctx) error {
 	return resp.Created(c, room.Data)
 }
 
+// Delete godoc
+// @Summary      Delete video conference room
+// @Tags         room
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Room ID"
+// @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} dto.ErrorResponse
+// @Router       /room/{id} [delete]
 func (h *RoomHandler) Delete(c *fiber.Ctx) error {
 	roomID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || roomID <= 0 {
@@ -78,13 +113,24 @@ func (h *RoomHandler) Delete(c *fiber.Ctx) error {
 	return resp.OK(c, fiber.Map{"message": "room deleted successfully"})
 }
 
+// AddUsers godoc
+// @Summary      Add users to room
+// @Tags         room
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path int true "Room ID"
+// @Param        body body dto.AddUsersRequest true "User IDs"
+// @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} dto.ErrorResponse
+// @Router       /room/{id}/users [post]
 func (h *RoomHandler) AddUsers(c *fiber.Ctx) error {
 	roomID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || roomID <= 0 {
 		return resp.BadRequest(c, "invalid room id", nil)
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.AddUsersRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.AddUsersRequest](c)
 	if !ok {
 		return nil
 	}
@@ -96,6 +142,16 @@ func (h *RoomHandler) AddUsers(c *fiber.Ctx) error {
 	return resp.OK(c, fiber.Map{"message": "users added successfully"})
 }
 
+// RemoveUser godoc
+// @Summary      Remove user from room
+// @Tags         room
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path int true "Room ID"
+// @Param        user_id path int true "User ID"
+// @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} dto.ErrorResponse
+// @Router       /room/{id}/users/{user_id} [delete]
 func (h *RoomHandler) RemoveUser(c *fiber.Ctx) error {
 	roomID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || roomID <= 0 {
@@ -114,6 +170,14 @@ func (h *RoomHandler) RemoveUser(c *fiber.Ctx) error {
 	return resp.OK(c, fiber.Map{"message": "user removed successfully"})
 }
 
+// GenerateToken godoc
+// @Summary      Generate room access token
+// @Description  Query параметрүүдийг meet service руу дамжуулна
+// @Tags         room
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} map[string]interface{}
+// @Router       /room/token [get]
 func (h *RoomHandler) GenerateToken(c *fiber.Ctx) error {
 	uctx := c.UserContext()
 
@@ -12,7 +12,11 @@ import (
 	"templatev25/internal/http/dto"
 
 	"context"
+	"errors"
+	"strconv"
 	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/validation"
 	"time"
 
 	"git.gerege.mn/backend-packages/common"
@@ -39,10 +43,11 @@ func NewSystemHandler(d *app.Dependencies) *SystemHandler {
 // @Param        code query string false "Filter by code (ILIKE)"
 // @Param        name query string false "Filter by name (ILIKE)"
 // @Param        is_active query bool false "Filter by active"
+// @Param        sort query string false "Sort (default sequence:asc; e.g. name:asc)"
 // @Produce      json
 // @Success      200 {object} map[string]interface{}
 func (h *SystemHandler) List(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.SystemListQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.SystemListQuery](c)
 	if !ok {
 		return nil
 	}
@@ -59,22 +64,36 @@ func (h *SystemHandler) List(c *fiber.Ctx) error {
 }
 
 // GET /system/:id
-// @Summary      Get system by id
+// @Summary      Get system by id or slug
+// @Description  id нь тоо бол ID-аар, үгүй бол slug-аар хайна (жишээ: /system/gerege-app)
 // @Tags         systems
 // @Security     BearerAuth
 // @Produce      json
+// @Param        id path string true "System ID or slug"
 // @Success      200 {object} map[string]interface{}
+// @Failure      404 {object} map[string]interface{}
 func (h *SystemHandler) Get(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c) // dto.IDInt{ Id int `params:"id" validate:"required"` }
-	if !ok {
-		return nil
-	}
+	param := c.Params("id")
 
 	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
-	item, err := h.Service.System.ByID(ctx, params.ID)
+	var (
+		item domain.System
+		err  error
+	)
+	if id, convErr := strconv.Atoi(param); convErr == nil {
+		if id <= 0 {
+			return resp.BadRequest(c, "invalid system id", nil)
+		}
+		item, err = h.Service.System.ByID(ctx, id)
+	} else {
+		item, err = h.Service.System.BySlug(ctx, param)
+	}
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
 		h.Log.Warn("system_get_failed", zap.Error(err))
 		return resp.InternalServerError(c, err.Error())
 	}
@@ -91,7 +110,7 @@ func (h *SystemHandler) Get(c *fiber.Ctx) error {
 // @Success      201 {object} map[string]interface{}
 // @Failure      400 {object} map[string]interface{}
 func (h *SystemHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.SystemCreateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.SystemCreateDto](c)
 	if !ok {
 		return nil
 	}
@@ -116,11 +135,11 @@ func (h *SystemHandler) Create(c *fiber.Ctx) error {
 // @Param        body body dto.SystemUpdateDto    true "payload"
 // @Success      200 {object} map[string]interface{}
 func (h *SystemHandler) Update(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c)
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
-	req, ok := resp.BodyBindAndValidate[dto.SystemUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.SystemUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -140,9 +159,10 @@ func (h *SystemHandler) Update(c *fiber.Ctx) error {
 // @Tags         systems
 // @Security     BearerAuth
 // @Produce      json
+// @Param        id path int true "System ID"
 // @Success      200 {object} map[string]interface{}
 func (h *SystemHandler) Delete(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c)
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
@@ -156,3 +176,27 @@ func (h *SystemHandler) Delete(c *fiber.Ctx) error {
 	}
 	return resp.OK(c)
 }
+
+// GET /system/:id/permissions
+// @Summary      List system permissions grouped by module
+// @Tags         systems
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path int true "System ID"
+// @Success      200 {array} dto.SystemPermissionGroup
+func (h *SystemHandler) Permissions(c *fiber.Ctx) error {
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	groups, err := h.Service.Permission.ListBySystemGrouped(ctx, params.ID)
+	if err != nil {
+		h.Log.Error("system_permissions_failed", zap.Int("system_id", params.ID), zap.Error(err))
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, groups)
+}
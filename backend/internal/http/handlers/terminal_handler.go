@@ -10,6 +10,7 @@ package handlers
 
 import (
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"templatev25/internal/app"
 	"git.gerege.mn/backend-packages/common"
@@ -27,6 +28,7 @@ func NewTerminalHandler(d *app.Dependencies) *TerminalHandler {
 }
 
 // GET /terminal
+// @Summary List terminals
 // @Tags terminal
 // @Produce json
 // @Param page query int false "page>=1"
@@ -38,7 +40,7 @@ func NewTerminalHandler(d *app.Dependencies) *TerminalHandler {
 // @Success 200 {object} map[string]interface{}
 // @Router /terminal [get]
 func (h *TerminalHandler) List(c *fiber.Ctx) error {
-	p, ok := resp.QueryBindAndValidate[common.PaginationQuery](c)
+	p, ok := validation.QueryBindAndValidate[common.PaginationQuery](c)
 	if !ok {
 		return nil
 	}
@@ -50,6 +52,7 @@ func (h *TerminalHandler) List(c *fiber.Ctx) error {
 }
 
 // POST /terminal
+// @Summary Create terminal
 // @Tags terminal
 // @Accept json
 // @Produce json
@@ -57,7 +60,7 @@ func (h *TerminalHandler) List(c *fiber.Ctx) error {
 // @Success 200 {object} map[string]interface{}
 // @Router /terminal [post]
 func (h *TerminalHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.TerminalCreateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.TerminalCreateDto](c)
 	if !ok {
 		return nil
 	}
@@ -70,6 +73,7 @@ func (h *TerminalHandler) Create(c *fiber.Ctx) error {
 }
 
 // PUT /terminal/{id}
+// @Summary Update terminal
 // @Tags terminal
 // @Accept json
 // @Produce json
@@ -78,12 +82,12 @@ func (h *TerminalHandler) Create(c *fiber.Ctx) error {
 // @Success 200 {object} map[string]interface{}
 // @Router /terminal/{id} [put]
 func (h *TerminalHandler) Update(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.TerminalUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.TerminalUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -96,13 +100,14 @@ func (h *TerminalHandler) Update(c *fiber.Ctx) error {
 }
 
 // DELETE /terminal/{id}
+// @Summary Delete terminal
 // @Tags terminal
 // @Produce json
 // @Param id path int true "ID"
 // @Success 200 {object} map[string]interface{}
 // @Router /terminal/{id} [delete]
 func (h *TerminalHandler) Delete(c *fiber.Ctx) error {
-	idp, ok := resp.ParamsBindAndValidate[common.ID](c)
+	idp, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
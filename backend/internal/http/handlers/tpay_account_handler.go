@@ -10,6 +10,7 @@ package handlers
 
 import (
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"context"
 	"strconv"
@@ -62,7 +63,7 @@ func (h *tpayAccountHandler) GetMyAccounts(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /me/accounts/default [put]
 func (h *tpayAccountHandler) SetDefaultAccount(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.SetDefaultAccountRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.SetDefaultAccountRequest](c)
 	if !ok {
 		return nil
 	}
@@ -116,7 +117,7 @@ func (h *tpayAccountHandler) GenerateQR(c *fiber.Ctx) error {
 		return resp.BadRequest(c, "invalid account_id", nil)
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.AccountQRGenerateRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.AccountQRGenerateRequest](c)
 	if !ok {
 		return nil
 	}
@@ -10,6 +10,7 @@ package handlers
 
 import (
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"git.gerege.mn/backend-packages/config"
 
@@ -57,7 +58,7 @@ func (h *tpayCardHandler) CardList(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /me/card/create [post]
 func (h *tpayCardHandler) AddCard(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.CreateCardDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.CreateCardDto](c)
 	if !ok {
 		return nil
 	}
@@ -80,7 +81,7 @@ func (h *tpayCardHandler) AddCard(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /me/card/confirm [post]
 func (h *tpayCardHandler) Confirm(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.ConfirmCardReq](c)
+	req, ok := validation.BodyBindAndValidate[dto.ConfirmCardReq](c)
 	if !ok {
 		return nil
 	}
@@ -120,7 +121,7 @@ func (h *tpayCardHandler) SendOtp(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /me/card/verify [post]
 func (h *tpayCardHandler) VerifyCard(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.ReqVerifyCard](c)
+	req, ok := validation.BodyBindAndValidate[dto.ReqVerifyCard](c)
 	if !ok {
 		return nil
 	}
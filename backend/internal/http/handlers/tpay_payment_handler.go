@@ -10,6 +10,7 @@ package handlers
 
 import (
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 
 	"git.gerege.mn/backend-packages/config"
 
@@ -42,7 +43,7 @@ func newTpayPaymentHandler(cfg *config.Config, svc *service.PaymentService) *tpa
 // @Success      200 {object} map[string]interface{}
 // @Router       /me/tpay/transaction/qr-pay [post]
 func (h *tpayPaymentHandler) QrPay(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.QRPayRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.QRPayRequest](c)
 	if !ok {
 		return nil
 	}
@@ -65,7 +66,7 @@ func (h *tpayPaymentHandler) QrPay(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /me/tpay/transaction/p2p [post]
 func (h *tpayPaymentHandler) P2PTransfer(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.P2PTransferRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.P2PTransferRequest](c)
 	if !ok {
 		return nil
 	}
@@ -0,0 +1,122 @@
+// Package handlers provides implementation for handlers
+//
+// File: user_audit_handler.go
+// Description: Admin view of another user's security audit trail (GET /user/:id/audit)
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"templatev25/internal/auth"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
+
+	"git.gerege.mn/backend-packages/common"
+	"git.gerege.mn/backend-packages/resp"
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+	"github.com/gofiber/fiber/v2"
+)
+
+// UserAuditPermission нь өөр хэрэглэгчийн audit trail харах эрх
+const UserAuditPermission = "admin.user.audit.read"
+
+// User audit limit (?limit=)
+const (
+	userAuditDefaultLimit = 50
+	userAuditMaxLimit     = 200
+)
+
+// AuditTrailReader нь хэрэглэгчийн security audit trail (repository.AuthRepository хэрэгжүүлнэ)
+type AuditTrailReader interface {
+	GetAuditTrail(ctx context.Context, userID int, limit int) ([]domain.SecurityAuditTrail, error)
+	GetAuditTrailByAction(ctx context.Context, userID int, action string, limit int) ([]domain.SecurityAuditTrail, error)
+}
+
+// UserAuditHandler нь admin-д зориулсан хэрэглэгчийн audit trail endpoint.
+// /me/security-audit-аас ялгаатай нь зорилтот хэрэглэгчийг path-аас авч,
+// хүсэлт гаргагчийн admin.user.audit.read эрхийг handler дотор шалгана.
+type UserAuditHandler struct {
+	audit AuditTrailReader
+	perms auth.PermissionChecker
+}
+
+// NewUserAuditHandler creates a new user audit handler
+func NewUserAuditHandler(audit AuditTrailReader, perms auth.PermissionChecker) *UserAuditHandler {
+	return &UserAuditHandler{audit: audit, perms: perms}
+}
+
+// GetAudit godoc
+// @Summary      Get a user's security audit trail (admin)
+// @Description  Requires admin.user.audit.read. Newest entries first; optional action filter.
+// @Tags         user
+// @Security     BearerAuth
+// @Param        id path int true "User ID"
+// @Param        limit query int false "Limit (1-200, default 50)"
+// @Param        action query string false "Action type filter"
+// @Produce      json
+// @Success      200 {object} dto.Response{data=dto.SecurityAuditResponse}
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      403 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /user/{id}/audit [get]
+func (h *UserAuditHandler) GetAudit(c *fiber.Ctx) error {
+	adminID := ssoclient.GetUserID(c)
+	if adminID == 0 {
+		return resp.Unauthorized(c)
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	// auth.RequirePermission-тэй ижил: шалгалтын алдаа ч 403 (internal алдаа задлахгүй)
+	allowed, err := h.perms.HasPermission(ctx, adminID, UserAuditPermission)
+	if err != nil {
+		return fiber.NewError(fiber.StatusForbidden, "permission check failed")
+	}
+	if !allowed {
+		return fiber.NewError(fiber.StatusForbidden, "insufficient permissions: "+UserAuditPermission)
+	}
+
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	q, ok := validation.QueryBindAndValidate[dto.UserAuditQuery](c)
+	if !ok {
+		return nil
+	}
+	limit := clampUserAuditLimit(q.Limit)
+
+	var audit []domain.SecurityAuditTrail
+	if q.Action != "" {
+		audit, err = h.audit.GetAuditTrailByAction(ctx, params.ID, q.Action, limit)
+	} else {
+		audit, err = h.audit.GetAuditTrail(ctx, params.ID, limit)
+	}
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+
+	entries := toSecurityAuditEntries(audit)
+	return resp.OK(c, dto.SecurityAuditResponse{
+		Entries: entries,
+		Total:   len(entries),
+	})
+}
+
+// clampUserAuditLimit нь limit-ийг [1, userAuditMaxLimit] хооронд оруулна (0 бол default)
+func clampUserAuditLimit(limit int) int {
+	switch {
+	case limit == 0:
+		return userAuditDefaultLimit
+	case limit < 1:
+		return 1
+	case limit > userAuditMaxLimit:
+		return userAuditMaxLimit
+	default:
+		return limit
+	}
+}
@@ -10,9 +10,18 @@ package handlers
 
 import (
 	"templatev25/internal/http/dto"
+	"templatev25/internal/service"
+	"templatev25/internal/service/export"
 
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	ihttp "templatev25/internal/http"
+	"templatev25/internal/http/validation"
+	"time"
 
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/resp"
@@ -31,11 +40,13 @@ func NewUserHandler(d *app.Dependencies) *UserHandler {
 
 // Me godoc
 // @Summary      Get current user (claims)
+// @Description  SSO claims; admin impersonation session бол is_impersonated=true
 // @Tags         me
 // @Security     BearerAuth
 // @Produce      json
-// @Success      200 {object} dto.Response
+// @Success      200 {object} dto.MeResponse
 // @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
 // @Router       /me [get]
 func (h *UserHandler) Me(c *fiber.Ctx) error {
 	claims, ok := ssoclient.GetClaims(c)
@@ -43,7 +54,21 @@ func (h *UserHandler) Me(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnauthorized, "no claims")
 	}
 
-	return resp.OK(c, claims)
+	out := dto.MeResponse{Claims: claims}
+	// Impersonation-ий тэмдэглэгээ session store-д хадгалагдана (POST /auth/local/impersonate)
+	if h.Service.SessionStore != nil {
+		if sid := ssoclient.GetSID(c); sid != "" {
+			session, err := h.Service.SessionStore.Get(c.UserContext(), sid)
+			if err != nil {
+				return resp.InternalServerError(c, err.Error())
+			}
+			if session != nil && session.IsImpersonated() {
+				out.IsImpersonated = true
+				out.ImpersonatedBy = session.ImpersonatedBy
+			}
+		}
+	}
+	return resp.OK(c, out)
 }
 
 // FindFromCore godoc
@@ -59,7 +84,7 @@ func (h *UserHandler) Me(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /user/find-from-core [post]
 func (h *UserHandler) FindFromCore(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[ssoclient.ReqFind](c)
+	req, ok := validation.BodyBindAndValidate[ssoclient.ReqFind](c)
 	if !ok {
 		return nil
 	}
@@ -99,21 +124,97 @@ func (h *UserHandler) FindFromCore(c *fiber.Ctx) error {
 // @Param        sort query string false "JSON sort"
 // @Param        createdFrom query string false "Created from (YYYY-MM-DD)"
 // @Param        createdTo query string false "Created to (YYYY-MM-DD)"
-// @Success      200 {object} dto.Response
+// @Param        stream query bool false "Stream all matching users as a bare JSON array (page/size ignored)"
+// @Success      200 {object} dto.Response{data=dto.PaginatedResponse[domain.User]}
 // @Failure      400 {object} dto.ErrorResponse
 // @Failure      401 {object} dto.ErrorResponse
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /user [get]
 func (h *UserHandler) List(c *fiber.Ctx) error {
-	p, ok := resp.QueryBindAndValidate[common.PaginationQuery](c)
+	p, ok := validation.QueryBindAndValidate[common.PaginationQuery](c)
 	if !ok {
 		return nil
 	}
+	// ?stream=true → бүх хэрэглэгчийг санах ойд цуглуулалгүй JSON array-аар stream хийнэ
+	if c.QueryBool("stream") {
+		items, errc := h.Service.User.ListStream(c.UserContext(), p)
+		return ihttp.StreamJSONWithErrors(c, items, errc)
+	}
 	items, total, page, size, err := h.Service.User.List(c.UserContext(), p)
 	if err != nil {
 		return resp.InternalServerError(c, err.Error())
 	}
-	return resp.Paginated(c, items, total, page, size) // <- Paginated-г хэрэглэж байна
+	return resp.OK(c, dto.NewPaginatedResponse(items, total, page, size))
+}
+
+// Search godoc
+// @Summary      Search users by name, email, reg_no or phone
+// @Description  q нь first_name, last_name, email, reg_no, phone_no баганаас аль нэгэнд нь агуулагдвал (ILIKE, OR) олдоно.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        q query string true "Search text (2-100 chars)"
+// @Param        page query int false "Page number (>=1)"
+// @Param        size query int false "Page size"
+// @Param        sort query string false "JSON sort"
+// @Success      200 {object} dto.Response{data=dto.PaginatedResponse[domain.User]}
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      422 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /user/search [get]
+func (h *UserHandler) Search(c *fiber.Ctx) error {
+	q, ok := validation.QueryBindAndValidate[dto.UserSearchQuery](c)
+	if !ok {
+		return nil
+	}
+	items, total, page, size, err := h.Service.User.Search(c.UserContext(), q.Q, q.PaginationQuery)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, dto.NewPaginatedResponse(items, total, page, size))
+}
+
+// Export godoc
+// @Summary      Export users as CSV, NDJSON or XLSX
+// @Description  Format нь ?format= (csv, json, xlsx) эсвэл Accept header-ээр сонгогдоно; query давуу эрхтэй.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format query string false "Export format (csv, json, xlsx)"
+// @Param        search query string false "JSON search (first_name,last_name,reg_no,phone_no,...)"
+// @Param        createdFrom query string false "Created from (YYYY-MM-DD)"
+// @Param        createdTo query string false "Created to (YYYY-MM-DD)"
+// @Success      200 {file} file
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      406 {object} dto.ErrorResponse
+// @Router       /user/export [get]
+func (h *UserHandler) Export(c *fiber.Ctx) error {
+	c.Vary(fiber.HeaderAccept)
+	format, err := export.Resolve(c.Query("format"), c.Get(fiber.HeaderAccept))
+	if err != nil {
+		if errors.Is(err, export.ErrNotAcceptable) {
+			return fiber.NewError(fiber.StatusNotAcceptable, err.Error())
+		}
+		return fiber.NewError(fiber.StatusBadRequest, "unsupported export format: "+c.Query("format"))
+	}
+	p, ok := validation.QueryBindAndValidate[common.PaginationQuery](c)
+	if !ok {
+		return nil
+	}
+
+	// Файл санах ойд бүтнээрээ үүсэхгүй — мөр бүр шууд response руу бичигдэнэ.
+	// Stream эхэлсний дараах алдаа нь холболт тасрахаар илэрнэ.
+	filename := fmt.Sprintf("users_%s.%s", time.Now().Format("2006-01-02"), format.Extension())
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx := c.UserContext()
+	return ihttp.Stream(c, func(w io.Writer) error {
+		return h.Service.User.Export(ctx, p, format, w)
+	})
 }
 
 // Create godoc
@@ -129,7 +230,7 @@ func (h *UserHandler) List(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /user [post]
 func (h *UserHandler) Create(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.UserCreateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.UserCreateDto](c)
 	if !ok {
 		return nil
 	}
@@ -140,6 +241,37 @@ func (h *UserHandler) Create(c *fiber.Ctx) error {
 	return resp.OK(c, out)
 }
 
+// Sync godoc
+// @Summary      Bulk upsert users pushed by SSO
+// @Tags         user
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.UserSyncDto true "Users"
+// @Success      200 {object} dto.UserSyncResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      422 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /user/sync [post]
+func (h *UserHandler) Sync(c *fiber.Ctx) error {
+	req, ok := validation.BodyBindAndValidate[dto.UserSyncDto](c)
+	if !ok {
+		return nil
+	}
+	n, skipped, err := h.Service.User.SyncFromSSO(c.UserContext(), req.Users)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	if skipped == nil {
+		skipped = []int{}
+	}
+	return resp.OK(c, dto.UserSyncResponse{Upserted: n, Skipped: skipped})
+}
+
 // Update godoc
 // @Summary      Update user
 // @Tags         user
@@ -156,7 +288,7 @@ func (h *UserHandler) Create(c *fiber.Ctx) error {
 // @Router       /user/{id} [put]
 func (h *UserHandler) Update(c *fiber.Ctx) error {
 
-	req, ok := resp.BodyBindAndValidate[dto.UserUpdateDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.UserUpdateDto](c)
 	if !ok {
 		return nil
 	}
@@ -180,7 +312,7 @@ func (h *UserHandler) Update(c *fiber.Ctx) error {
 // @Failure      500 {object} dto.ErrorResponse
 // @Router       /user/{id} [delete]
 func (h *UserHandler) Delete(c *fiber.Ctx) error {
-	params, ok := resp.ParamsBindAndValidate[common.ID](c)
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
 	if !ok {
 		return nil
 	}
@@ -260,6 +392,60 @@ func (h *UserHandler) ProfileSSO(c *fiber.Ctx) error {
 	return resp.OK(c, res)
 }
 
+// Menu godoc
+// @Summary      Get current user's menu tree
+// @Description  Хэрэглэгчийн role-уудын permission-д хамаарах menu-г мод бүтэцтэйгээр буцаана. Идэвхгүй системийн menu орохгүй.
+// @Tags         me
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} dto.Response
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /me/menu [get]
+func (h *UserHandler) Menu(c *fiber.Ctx) error {
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
+	items, err := h.Service.Menu.ListByUserRoles(c.UserContext(), claims.CitizenID)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, items)
+}
+
+// Permissions godoc
+// @Summary      Get current user's permission codes
+// @Description  Хэрэглэгчийн role-уудаар олгогдсон permission кодуудыг буцаана (PermissionCache-ээр)
+// @Tags         me
+// @Security     BearerAuth
+// @Produce      json
+// @Param        system query string false "System code prefix (жишээ: admin)"
+// @Success      200 {object} dto.Response
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /me/permissions [get]
+func (h *UserHandler) Permissions(c *fiber.Ctx) error {
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
+	codes, err := h.PermCache.GetUserPermissions(c.UserContext(), claims.CitizenID)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+
+	// ?system=admin → зөвхөн "admin."-аар эхэлсэн кодууд
+	prefix := strings.TrimSpace(c.Query("system"))
+	out := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if prefix == "" || strings.HasPrefix(code, prefix+".") {
+			out = append(out, code)
+		}
+	}
+	return resp.OK(c, out)
+}
+
 // Organizations godoc
 // @Summary      Get user's organizations list
 // @Tags         me
@@ -285,3 +471,42 @@ func (h *UserHandler) Organizations(c *fiber.Ctx) error {
 		"items":  items,
 	})
 }
+
+// SwitchOrganization godoc
+// @Summary      Switch active organization
+// @Description  Одоогийн session-ий идэвхтэй байгууллагыг солино (хэрэглэгч гишүүн байх ёстой)
+// @Tags         me
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.MeOrgSwitchDto true "Organization"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      403 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /me/org [put]
+func (h *UserHandler) SwitchOrganization(c *fiber.Ctx) error {
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
+	req, ok := validation.BodyBindAndValidate[dto.MeOrgSwitchDto](c)
+	if !ok {
+		return nil
+	}
+	if err := h.Service.User.SwitchOrganization(c.UserContext(), claims.CitizenID, req.OrgID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrgNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrOrgNotMember):
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		case errors.Is(err, service.ErrInvalidSession):
+			return resp.Unauthorized(c)
+		}
+		return resp.InternalServerError(c, err.Error())
+	}
+	// Session ID (HttpOnly cookie) хариунд буцаагдахгүй — session өөрөө хэвээр
+	return resp.OK(c, dto.MeOrgSwitchResponse{OrgID: req.OrgID})
+}
@@ -5,14 +5,19 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"strconv"
+	"time"
 
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/locals"
+	"templatev25/internal/http/validation"
 	"templatev25/internal/service"
 
 	"git.gerege.mn/backend-packages/resp"
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -104,7 +109,7 @@ func (h *UserManagementHandler) ConfirmTOTP(c *fiber.Ctx) error {
 		return resp.Unauthorized(c)
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.ConfirmTOTPRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.ConfirmTOTPRequest](c)
 	if !ok {
 		return nil
 	}
@@ -146,7 +151,7 @@ func (h *UserManagementHandler) DisableTOTP(c *fiber.Ctx) error {
 		return resp.Unauthorized(c)
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.DisableTOTPRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.DisableTOTPRequest](c)
 	if !ok {
 		return nil
 	}
@@ -272,6 +277,36 @@ func (h *UserManagementHandler) RevokeSession(c *fiber.Ctx) error {
 	return resp.OK(c, fiber.Map{"message": "session revoked"})
 }
 
+// RevokeAllSessions godoc
+// @Summary      Revoke all other sessions
+// @Description  Signs out every session of the current user except the one making the request
+// @Tags         local-auth-user
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} dto.Response
+// @Failure      401 {object} dto.ErrorResponse
+// @Router       /auth/local/me/sessions/all [delete]
+func (h *UserManagementHandler) RevokeAllSessions(c *fiber.Ctx) error {
+	userID := getUserID(c)
+	currentSessionID := getSessionID(c)
+	if userID == 0 || currentSessionID == "" {
+		return resp.Unauthorized(c)
+	}
+
+	err := h.authService.RevokeAllSessions(
+		c.UserContext(),
+		userID,
+		currentSessionID,
+		c.IP(),
+		c.Get("User-Agent"),
+	)
+	if err != nil {
+		return resp.InternalServerError(c, err.Error())
+	}
+
+	return resp.OK(c, fiber.Map{"message": "other sessions revoked"})
+}
+
 // ============================================================
 // PASSWORD ENDPOINTS
 // ============================================================
@@ -293,12 +328,60 @@ func (h *UserManagementHandler) ChangePassword(c *fiber.Ctx) error {
 		return resp.Unauthorized(c)
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.ChangePasswordRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.ChangePasswordRequest](c)
 	if !ok {
 		return nil
 	}
 
-	err := h.authService.ChangePassword(
+	return changePassword(c, h.authService, userID, req)
+}
+
+// PasswordChanger нь нууц үг солих үйлдэл (service.AuthService хэрэгжүүлнэ)
+type PasswordChanger interface {
+	ChangePassword(ctx context.Context, userID int, currentPass, newPass, ip, userAgent string) error
+}
+
+// MePasswordHandler нь /me group-ийн нууц үг солих endpoint.
+// /auth/local/me/password-аас ялгаатай нь userID-г SSO claims-аас авна.
+type MePasswordHandler struct {
+	passwords PasswordChanger
+}
+
+// NewMePasswordHandler creates a new /me password handler
+func NewMePasswordHandler(passwords PasswordChanger) *MePasswordHandler {
+	return &MePasswordHandler{passwords: passwords}
+}
+
+// ChangePassword godoc
+// @Summary      Change own password
+// @Description  POST /auth/local/me/password-тэй ижил, хэрэглэгчийг SSO claims-аас тодорхойлно
+// @Tags         me
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.ChangePasswordRequest true "Password change"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      422 {object} dto.ErrorResponse
+// @Router       /me/password [patch]
+func (h *MePasswordHandler) ChangePassword(c *fiber.Ctx) error {
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok || claims.UserID == 0 {
+		return resp.Unauthorized(c)
+	}
+
+	req, ok := validation.BodyBindAndValidate[dto.ChangePasswordRequest](c)
+	if !ok {
+		return nil
+	}
+
+	return changePassword(c, h.passwords, claims.UserID, req)
+}
+
+// changePassword нь нууц үг солиод service алдааг HTTP хариу болгоно
+func changePassword(c *fiber.Ctx, passwords PasswordChanger, userID int, req dto.ChangePasswordRequest) error {
+	err := passwords.ChangePassword(
 		c.UserContext(),
 		userID,
 		req.CurrentPassword,
@@ -311,7 +394,7 @@ func (h *UserManagementHandler) ChangePassword(c *fiber.Ctx) error {
 		case errors.Is(err, service.ErrInvalidCredentials):
 			return resp.BadRequest(c, "current password is incorrect", nil)
 		case errors.Is(err, service.ErrPasswordTooWeak):
-			return resp.BadRequest(c, "password does not meet requirements", nil)
+			return passwordTooWeak(c, err)
 		case errors.Is(err, service.ErrPasswordReused):
 			return resp.BadRequest(c, "password was recently used", nil)
 		case errors.Is(err, service.ErrCredentialsNotFound):
@@ -328,11 +411,22 @@ func (h *UserManagementHandler) ChangePassword(c *fiber.Ctx) error {
 // HISTORY ENDPOINTS
 // ============================================================
 
+// Login history limit (?limit=)
+const (
+	loginHistoryDefaultLimit = 50
+	loginHistoryMaxLimit     = 100
+)
+
+// LoginHistoryReader нь хэрэглэгчийн login түүх (service.AuthService хэрэгжүүлнэ)
+type LoginHistoryReader interface {
+	GetLoginHistory(ctx context.Context, userID int, limit int) ([]domain.LoginHistory, error)
+}
+
 // GetLoginHistory godoc
 // @Summary      Get login history
 // @Tags         local-auth-user
 // @Security     BearerAuth
-// @Param        limit query int false "Limit (default 50)"
+// @Param        limit query int false "Limit (1-100, default 50)"
 // @Produce      json
 // @Success      200 {object} dto.LoginHistoryResponse
 // @Failure      401 {object} dto.ErrorResponse
@@ -343,12 +437,44 @@ func (h *UserManagementHandler) GetLoginHistory(c *fiber.Ctx) error {
 		return resp.Unauthorized(c)
 	}
 
-	limit := c.QueryInt("limit", 50)
-	if limit > 100 {
-		limit = 100
+	return loginHistory(c, h.authService, userID)
+}
+
+// MeLoginHistoryHandler нь /me group-ийн login түүхийн endpoint.
+// /auth/local/me/login-history-аас ялгаатай нь userID-г SSO claims-аас авна.
+type MeLoginHistoryHandler struct {
+	history LoginHistoryReader
+}
+
+// NewMeLoginHistoryHandler creates a new /me login history handler
+func NewMeLoginHistoryHandler(history LoginHistoryReader) *MeLoginHistoryHandler {
+	return &MeLoginHistoryHandler{history: history}
+}
+
+// GetLoginHistory godoc
+// @Summary      Get own login history
+// @Description  GET /auth/local/me/login-history-тэй ижил, хэрэглэгчийг SSO claims-аас тодорхойлно
+// @Tags         me
+// @Security     BearerAuth
+// @Param        limit query int false "Limit (1-100, default 50)"
+// @Produce      json
+// @Success      200 {object} dto.LoginHistoryResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Router       /me/login-history [get]
+func (h *MeLoginHistoryHandler) GetLoginHistory(c *fiber.Ctx) error {
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok || claims.UserID == 0 {
+		return resp.Unauthorized(c)
 	}
 
-	history, err := h.authService.GetLoginHistory(c.UserContext(), userID, limit)
+	return loginHistory(c, h.history, claims.UserID)
+}
+
+// loginHistory нь ?limit-ийг хавчуулж хэрэглэгчийн login түүхийг буцаана
+func loginHistory(c *fiber.Ctx, reader LoginHistoryReader, userID int) error {
+	limit := clampLoginHistoryLimit(c.QueryInt("limit", loginHistoryDefaultLimit))
+
+	history, err := reader.GetLoginHistory(c.UserContext(), userID, limit)
 	if err != nil {
 		return resp.InternalServerError(c, err.Error())
 	}
@@ -373,6 +499,18 @@ func (h *UserManagementHandler) GetLoginHistory(c *fiber.Ctx) error {
 	})
 }
 
+// clampLoginHistoryLimit нь limit-ийг [1, loginHistoryMaxLimit] хооронд оруулна
+func clampLoginHistoryLimit(limit int) int {
+	switch {
+	case limit < 1:
+		return 1
+	case limit > loginHistoryMaxLimit:
+		return loginHistoryMaxLimit
+	default:
+		return limit
+	}
+}
+
 // GetSecurityAudit godoc
 // @Summary      Get security audit trail
 // @Tags         local-auth-user
@@ -398,9 +536,18 @@ func (h *UserManagementHandler) GetSecurityAudit(c *fiber.Ctx) error {
 		return resp.InternalServerError(c, err.Error())
 	}
 
-	var entries []dto.SecurityAuditEntry
+	entries := toSecurityAuditEntries(audit)
+	return resp.OK(c, dto.SecurityAuditResponse{
+		Entries: entries,
+		Total:   len(entries),
+	})
+}
+
+// toSecurityAuditEntries нь audit trail мөрүүдийг хариуны оруулга болгоно (created_at-ийн хамт)
+func toSecurityAuditEntries(audit []domain.SecurityAuditTrail) []dto.SecurityAuditEntry {
+	entries := make([]dto.SecurityAuditEntry, 0, len(audit))
 	for _, a := range audit {
-		entries = append(entries, dto.SecurityAuditEntry{
+		e := dto.SecurityAuditEntry{
 			ID:         a.ID,
 			Action:     a.Action,
 			TargetType: a.TargetType,
@@ -409,13 +556,13 @@ func (h *UserManagementHandler) GetSecurityAudit(c *fiber.Ctx) error {
 			NewValue:   a.NewValue,
 			IPAddress:  a.IPAddress,
 			UserAgent:  a.UserAgent,
-		})
+		}
+		if a.CreatedDate != nil {
+			e.CreatedAt = time.Time(*a.CreatedDate)
+		}
+		entries = append(entries, e)
 	}
-
-	return resp.OK(c, dto.SecurityAuditResponse{
-		Entries: entries,
-		Total:   len(entries),
-	})
+	return entries
 }
 
 // ============================================================
@@ -446,7 +593,7 @@ func (h *UserManagementHandler) UpdateUserStatus(c *fiber.Ctx) error {
 		return resp.BadRequest(c, "invalid user id", nil)
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.UpdateUserStatusRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.UpdateUserStatusRequest](c)
 	if !ok {
 		return nil
 	}
@@ -532,7 +679,7 @@ func (h *UserManagementHandler) SetUserPassword(c *fiber.Ctx) error {
 		return resp.BadRequest(c, "invalid user id", nil)
 	}
 
-	req, ok := resp.BodyBindAndValidate[dto.SetPasswordRequest](c)
+	req, ok := validation.BodyBindAndValidate[dto.SetPasswordRequest](c)
 	if !ok {
 		return nil
 	}
@@ -540,7 +687,7 @@ func (h *UserManagementHandler) SetUserPassword(c *fiber.Ctx) error {
 	err = h.authService.SetPassword(c.UserContext(), targetID, req.Password)
 	if err != nil {
 		if errors.Is(err, service.ErrPasswordTooWeak) {
-			return resp.BadRequest(c, "password does not meet requirements", nil)
+			return passwordTooWeak(c, err)
 		}
 		return resp.InternalServerError(c, err.Error())
 	}
@@ -548,10 +695,17 @@ func (h *UserManagementHandler) SetUserPassword(c *fiber.Ctx) error {
 	return resp.OK(c, fiber.Map{"message": "password set"})
 }
 
+// passwordTooWeak нь 400 хариуны data-д зөрчигдсөн password policy дүрмүүдийг
+// ({"violations": ["uppercase", "digit"]}) буцаана
+func passwordTooWeak(c *fiber.Ctx, err error) error {
+	var weak *service.PasswordTooWeakError
+	if errors.As(err, &weak) {
+		return resp.BadRequest(c, "password does not meet requirements", fiber.Map{"violations": weak.Violations})
+	}
+	return resp.BadRequest(c, "password does not meet requirements", nil)
+}
+
 // Helper function
 func getEmail(c *fiber.Ctx) string {
-	if email, ok := c.Locals("email").(string); ok {
-		return email
-	}
-	return ""
+	return locals.GetOrDefault(c, "email", "")
 }
@@ -13,9 +13,11 @@ import (
 
 	"context"
 	"templatev25/internal/app"
+	"templatev25/internal/http/validation"
 
 	"time"
 
+	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/resp"
 
 	"github.com/gofiber/fiber/v2"
@@ -42,7 +44,7 @@ func NewUserRoleHandler(d *app.Dependencies) *UserRoleHandler {
 // @Success      200 {object} map[string]interface{}
 // @Router       /role-matrix/users [get]
 func (h *UserRoleHandler) UsersByRole(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.UserRoleUsersQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.UserRoleUsersQuery](c)
 	if !ok {
 		return nil
 	}
@@ -70,7 +72,7 @@ func (h *UserRoleHandler) UsersByRole(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /role-matrix/roles [get]
 func (h *UserRoleHandler) RolesByUser(c *fiber.Ctx) error {
-	q, ok := resp.QueryBindAndValidate[dto.UserRoleRolesQuery](c)
+	q, ok := validation.QueryBindAndValidate[dto.UserRoleRolesQuery](c)
 	if !ok {
 		return nil
 	}
@@ -98,7 +100,7 @@ func (h *UserRoleHandler) RolesByUser(c *fiber.Ctx) error {
 // @Router       /role-matrix [post]
 func (h *UserRoleHandler) Create(c *fiber.Ctx) error {
 	// эхэлж "assign by role" bind оролдоно
-	if req, ok := resp.BodyBindAndValidate[dto.UserRoleAssignByRole](c); ok {
+	if req, ok := validation.BodyBindAndValidate[dto.UserRoleAssignByRole](c); ok {
 		ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 		defer cancel()
 		if err := h.Service.UserRole.AssignByRole(ctx, req); err != nil {
@@ -109,7 +111,7 @@ func (h *UserRoleHandler) Create(c *fiber.Ctx) error {
 	}
 
 	// эсрэг тохиолдолд "assign by user" гэж үзнэ
-	req2, ok := resp.BodyBindAndValidate[dto.UserRoleAssignByUser](c)
+	req2, ok := validation.BodyBindAndValidate[dto.UserRoleAssignByUser](c)
 	if !ok {
 		return nil
 	}
@@ -134,7 +136,7 @@ func (h *UserRoleHandler) Create(c *fiber.Ctx) error {
 // @Success      200 {object} map[string]interface{}
 // @Router       /role-matrix [delete]
 func (h *UserRoleHandler) Delete(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[dto.UserRoleRemoveDto](c)
+	req, ok := validation.BodyBindAndValidate[dto.UserRoleRemoveDto](c)
 	if !ok {
 		return nil
 	}
@@ -147,3 +149,65 @@ func (h *UserRoleHandler) Delete(c *fiber.Ctx) error {
 	}
 	return resp.OK(c)
 }
+
+// UserRoles godoc
+// @Summary      List roles of a user
+// @Description  Get all roles assigned to a user with the system each role belongs to
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "User ID"
+// @Success      200 {array}  dto.UserRoleDetail
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      403 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /user/{id}/roles [get]
+func (h *UserRoleHandler) UserRoles(c *fiber.Ctx) error {
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	items, err := h.Service.UserRole.GetByUserID(ctx, params.ID)
+	if err != nil {
+		h.Log.Error("userrole_user_roles_failed", zap.Int("user_id", params.ID), zap.Error(err))
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.OK(c, items)
+}
+
+// RoleHistory godoc
+// @Summary      User role history
+// @Description  Get paginated role assignment/revocation history of a user (newest first)
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path  int true  "User ID"
+// @Param        page query int false "Page number"
+// @Param        size query int false "Page size"
+// @Success      200 {object} map[string]interface{}
+// @Router       /user/{id}/role-history [get]
+func (h *UserRoleHandler) RoleHistory(c *fiber.Ctx) error {
+	params, ok := validation.ParamsBindAndValidate[common.ID](c)
+	if !ok {
+		return nil
+	}
+	q, ok := validation.QueryBindAndValidate[common.PaginationQuery](c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	items, total, page, size, err := h.Service.UserRole.History(ctx, params.ID, q)
+	if err != nil {
+		h.Log.Error("userrole_history_failed", zap.Int("user_id", params.ID), zap.Error(err))
+		return resp.InternalServerError(c, err.Error())
+	}
+	return resp.Paginated(c, items, total, page, size)
+}
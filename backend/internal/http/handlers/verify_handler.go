@@ -11,6 +11,8 @@ package handlers
 import (
 	"fmt"
 	"templatev25/internal/app"
+	"templatev25/internal/http/locals"
+	"templatev25/internal/http/validation"
 	"git.gerege.mn/backend-packages/ctx"
 	"git.gerege.mn/backend-packages/sso-client"
 	"git.gerege.mn/backend-packages/resp"
@@ -27,8 +29,19 @@ func NewVerifyHandler(d *app.Dependencies) *VerifyHandler {
 	return &VerifyHandler{Dependencies: d}
 }
 
+// Dan godoc
+// @Summary      DAN verification URL
+// @Description  Иргэний ДАН баталгаажуулалт руу чиглүүлэх URL буцаана
+// @Tags         verify
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} map[string]interface{}
+// @Router       /verify/dan [get]
 func (h *VerifyHandler) Dan(c *fiber.Ctx) error {
-	sid := c.Locals(ssoclient.LocalsSID).(string)
+	sid, ok := locals.Get[string](c, ssoclient.LocalsSID)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
 
 	claims, err := h.SSO.GetClaims(c.Context(), sid, ctx.RequestID(c))
 	if err != nil {
@@ -56,41 +69,74 @@ func (h *VerifyHandler) Dan(c *fiber.Ctx) error {
 	return resp.OK(c, fiber.Map{"url": authURL})
 }
 
+// Email godoc
+// @Summary      Send email verification code
+// @Description  Нэвтэрсэн хэрэглэгчийн email рүү 6 оронтой код илгээнэ (15 минут хүчинтэй)
+// @Tags         verify
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse "User has no email"
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /verify/email [post]
 func (h *VerifyHandler) Email(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[struct {
-		Email string `json:"email" validate:"required"`
-	}](c)
+	claims, ok := ssoclient.GetClaims(c)
 	if !ok {
-		return nil
+		return resp.Unauthorized(c)
 	}
 
-	err := h.Service.Verify.EmailVerify(c.UserContext(), req.Email)
-	if err != nil {
-		return resp.InternalServerError(c, err.Error())
+	// Domain алдааг (400/404) ErrorHandler HTTP status руу хөрвүүлнэ
+	if err := h.Service.Verification.SendEmailCode(c.UserContext(), claims.UserID); err != nil {
+		return err
 	}
 
 	return resp.OK(c)
 }
 
+// EmailConfirm godoc
+// @Summary      Confirm email verification code
+// @Tags         verify
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body object true "{\"code\": \"123456\"}"
+// @Success      200 {object} dto.Response
+// @Failure      400 {object} dto.ErrorResponse "Invalid code"
+// @Failure      409 {object} dto.ErrorResponse "Code already used"
+// @Failure      410 {object} dto.ErrorResponse "Code expired (15 minutes)"
+// @Router       /verify/email/confirm [post]
 func (h *VerifyHandler) EmailConfirm(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[struct {
-		Email string `json:"email" validate:"required"`
-		Code  string `json:"code"  validate:"required,len=6"`
+	req, ok := validation.BodyBindAndValidate[struct {
+		Code string `json:"code" validate:"required,len=6,numeric"`
 	}](c)
 	if !ok {
 		return nil
 	}
 
-	err := h.Service.Verify.EmailVerifyConfirm(c.UserContext(), req.Email, req.Code)
-	if err != nil {
-		return resp.InternalServerError(c, err.Error())
+	claims, ok := ssoclient.GetClaims(c)
+	if !ok {
+		return resp.Unauthorized(c)
+	}
+
+	if err := h.Service.Verification.ConfirmEmail(c.UserContext(), claims.UserID, req.Code); err != nil {
+		return err
 	}
 
 	return resp.OK(c)
 }
 
+// Phone godoc
+// @Summary      Send phone verification code
+// @Tags         verify
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body object true "{\"phone_no\": \"99112233\"}"
+// @Success      200 {object} map[string]interface{}
+// @Router       /verify/phone [post]
 func (h *VerifyHandler) Phone(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[struct {
+	req, ok := validation.BodyBindAndValidate[struct {
 		PhoneNo string `json:"phone_no" validate:"required"`
 	}](c)
 	if !ok {
@@ -105,8 +151,17 @@ func (h *VerifyHandler) Phone(c *fiber.Ctx) error {
 	return resp.OK(c)
 }
 
+// PhoneConfirm godoc
+// @Summary      Confirm phone verification code
+// @Tags         verify
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body object true "{\"phone_no\": \"99112233\", \"code\": \"123456\"}"
+// @Success      200 {object} map[string]interface{}
+// @Router       /verify/phone/confirm [post]
 func (h *VerifyHandler) PhoneConfirm(c *fiber.Ctx) error {
-	req, ok := resp.BodyBindAndValidate[struct {
+	req, ok := validation.BodyBindAndValidate[struct {
 		Phone string `json:"phone_no" validate:"required"`
 		Code  string `json:"code"  validate:"required,len=6"`
 	}](c)
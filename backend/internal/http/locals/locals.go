@@ -0,0 +1,42 @@
+// Package locals provides typed access to fiber.Ctx locals
+//
+// File: locals.go
+// Description: Generic Set/Get helpers that replace unchecked c.Locals(key).(T) assertions
+/*
+c.Locals(key).(T) хэлбэрийн шалгалтгүй type assertion нь утга байхгүй эсвэл өөр
+төрөлтэй үед panic хийдэг. Эдгээр helper-ууд panic хийхгүй, (zero, false) буцаана.
+
+Ашиглалт:
+
+	locals.Set(c, "session_id", session.SessionID)
+
+	sid, ok := locals.Get[string](c, ssoclient.LocalsSID)
+	if !ok {
+	    return resp.Unauthorized(c)
+	}
+
+	userID := locals.GetOrDefault(c, "user_id", 0)
+*/
+package locals
+
+import "github.com/gofiber/fiber/v2"
+
+// Set stores val under key for the lifetime of the request
+func Set[T any](c *fiber.Ctx, key string, val T) {
+	c.Locals(key, val)
+}
+
+// Get returns the value stored under key. ok is false when the key is missing,
+// the value is nil or it is not a T.
+func Get[T any](c *fiber.Ctx, key string) (T, bool) {
+	v, ok := c.Locals(key).(T)
+	return v, ok
+}
+
+// GetOrDefault returns the value stored under key, or def when Get would report false
+func GetOrDefault[T any](c *fiber.Ctx, key string, def T) T {
+	if v, ok := Get[T](c, key); ok {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,84 @@
+// Package locals provides typed access to fiber.Ctx locals
+//
+// File: locals_test.go
+// Description: Unit tests for the typed locals helpers
+package locals
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+type testClaims struct{ UserID int }
+
+// withCtx нь fn-ийг шинэ request context-тэй дуудна
+func withCtx(t *testing.T, fn func(c *fiber.Ctx)) {
+	t.Helper()
+	app := fiber.New()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+	fn(c)
+}
+
+func TestGet_CorrectType(t *testing.T) {
+	withCtx(t, func(c *fiber.Ctx) {
+		Set(c, "claims", &testClaims{UserID: 7})
+		Set(c, "session_id", "sid-1")
+
+		claims, ok := Get[*testClaims](c, "claims")
+		assert.True(t, ok)
+		assert.Equal(t, 7, claims.UserID)
+
+		sid, ok := Get[string](c, "session_id")
+		assert.True(t, ok)
+		assert.Equal(t, "sid-1", sid)
+	})
+}
+
+func TestGet_TypeMismatch(t *testing.T) {
+	withCtx(t, func(c *fiber.Ctx) {
+		Set(c, "user_id", "not-an-int")
+
+		assert.NotPanics(t, func() {
+			v, ok := Get[int](c, "user_id")
+			assert.False(t, ok)
+			assert.Zero(t, v)
+
+			claims, ok := Get[*testClaims](c, "user_id")
+			assert.False(t, ok)
+			assert.Nil(t, claims)
+		})
+	})
+}
+
+func TestGet_MissingAndNil(t *testing.T) {
+	withCtx(t, func(c *fiber.Ctx) {
+		_, ok := Get[string](c, "missing")
+		assert.False(t, ok)
+
+		c.Locals("claims", nil)
+		claims, ok := Get[*testClaims](c, "claims")
+		assert.False(t, ok)
+		assert.Nil(t, claims)
+
+		// Typed nil pointer нь тухайн төрөлтэй тул ok=true
+		Set[*testClaims](c, "claims", nil)
+		claims, ok = Get[*testClaims](c, "claims")
+		assert.True(t, ok)
+		assert.Nil(t, claims)
+	})
+}
+
+func TestGetOrDefault(t *testing.T) {
+	withCtx(t, func(c *fiber.Ctx) {
+		Set(c, "user_id", 42)
+		Set(c, "email", 123)
+
+		assert.Equal(t, 42, GetOrDefault(c, "user_id", 0))
+		assert.Equal(t, "", GetOrDefault(c, "email", ""), "wrong type falls back to default")
+		assert.Equal(t, "fallback", GetOrDefault(c, "missing", "fallback"))
+	})
+}
@@ -27,11 +27,13 @@ func MapAPILogRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Han
 	// ------------------------------------------------------------
 	// API LOG ROUTES
 	// ------------------------------------------------------------
-	// API log-ийн list (paginated).
-	v1.Group("/api-logs", requireAuth, middleware.Timeout(10*time.Second)).Route("", func(router fiber.Router) {
-		h := handlers.NewAPILogHandler(d)
-
-		// List API logs (paginated) with permission check
-		router.Get("/", auth.RequirePermission(perm, "admin.api-log.read"), h.List)
-	})
+	// API log-ийн list (paginated, admin only).
+	// /api-log нь үндсэн зам, /api-logs нь хуучин client-уудад зориулсан alias.
+	h := handlers.NewAPILogHandler(d)
+	for _, path := range []string{"/api-log", "/api-logs"} {
+		v1.Group(path, requireAuth, middleware.Timeout(10*time.Second)).Route("", func(router fiber.Router) {
+			// List API logs (?user_id=&method=&status_gte=&path=&from=&to=&page=&size=)
+			router.Get("/", auth.RequireRole(d.RoleCache, adminRoles...), auth.RequirePermission(perm, "admin.api-log.read"), h.List)
+		})
+	}
 }
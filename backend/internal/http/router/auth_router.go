@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"templatev25/internal/app"
+	"templatev25/internal/auth"
 	"templatev25/internal/http/handlers"
 	"templatev25/internal/middleware"
 
@@ -122,6 +123,10 @@ func MapAuthRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Handl
 		// POST /auth/local/refresh → Extend session expiry
 		router.Post("/refresh", sessionAuth, localAuthHandler.RefreshSession)
 
+		// Admin impersonation (SSO admin → target хэрэглэгчийн local session)
+		// POST /auth/local/impersonate → 30 минутын session, RefreshSession сунгахгүй
+		router.Post("/impersonate", requireAuth, auth.RequireRole(d.RoleCache, adminRoles...), strictLimiter, localAuthHandler.Impersonate)
+
 		// ------------------------------------------------------------
 		// REGISTRATION ROUTES (Public)
 		// ------------------------------------------------------------
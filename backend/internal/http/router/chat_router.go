@@ -62,9 +62,14 @@ func MapChatRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Handl
 
 		r.Get("/", auth.RequirePermission(perm, "admin.chat.read"), h.List)
 		r.Post("/", auth.RequirePermission(perm, "admin.chat.create"), h.Create)
+		r.Post("/key", h.GetByKey) // Public endpoint for chat bot
+		r.Get("/:id", auth.RequirePermission(perm, "admin.chat.read"), h.Get)
 		r.Put("/:id", auth.RequirePermission(perm, "admin.chat.update"), h.Update)
 		r.Delete("/:id", auth.RequirePermission(perm, "admin.chat.delete"), h.Delete)
-		r.Post("/key", h.GetByKey) // Public endpoint for chat bot
+
+		// Tags
+		r.Post("/:id/tags", auth.RequirePermission(perm, "admin.chat.update"), h.AddTags)
+		r.Delete("/:id/tags/:tagID", auth.RequirePermission(perm, "admin.chat.update"), h.RemoveTag)
 	})
 }
 
@@ -0,0 +1,71 @@
+// Package router provides HTTP route definitions
+//
+// File: health_test.go
+// Description: Unit tests for the /health handler aggregation and cache
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"templatev25/internal/health"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingChecker нь тогтмол үр дүн буцааж, дуудалтын тоог бүртгэнэ
+type countingChecker struct {
+	result health.HealthResult
+	calls  atomic.Int32
+}
+
+func (c *countingChecker) Check(ctx context.Context) health.HealthResult {
+	c.calls.Add(1)
+	return c.result
+}
+
+func getHealth(t *testing.T, app *fiber.App) map[string]any {
+	t.Helper()
+	res, err := app.Test(httptest.NewRequest("GET", "/health", nil))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	data, ok := body["data"].(map[string]any)
+	require.True(t, ok, "response should have data object: %v", body)
+	return data
+}
+
+func TestHealthHandler(t *testing.T) {
+	db := &countingChecker{result: health.HealthResult{Name: "database", Status: health.StatusOK, Details: map[string]any{"open_conns": 1}}}
+	sso := &countingChecker{result: health.HealthResult{Name: "sso", Status: health.StatusError, Error: "sso_unreachable"}}
+
+	// Өмнөх тестийн cache-ийг хүчингүй болгоно
+	healthCacheTime.Store(0)
+	t.Cleanup(func() { healthCacheTime.Store(0) })
+
+	app := fiber.New()
+	app.Get("/health", healthHandler([]health.HealthChecker{db, sso}))
+
+	data := getHealth(t, app)
+	assert.Equal(t, "degraded", data["status"])
+	assert.Equal(t, map[string]any{"status": "ok", "open_conns": float64(1)}, data["database"])
+	assert.Equal(t, map[string]any{"status": "error", "error": "sso_unreachable"}, data["sso"])
+
+	// 5 секундын дотор дахин дуудахад checker ажиллахгүй
+	getHealth(t, app)
+	assert.Equal(t, int32(1), db.calls.Load())
+	assert.Equal(t, int32(1), sso.calls.Load())
+
+	// Cache хугацаа дууссаны дараа дахин шалгана
+	healthCacheTime.Store(0)
+	getHealth(t, app)
+	assert.Equal(t, int32(2), db.calls.Load())
+}
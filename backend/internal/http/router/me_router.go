@@ -26,8 +26,15 @@ import (
 //   - GET  /me/profile   → Full profile
 //   - GET  /me/profile/sso → SSO profile
 //   - GET  /me/organizations → User organizations
+//   - GET  /me/menu          → Menu tree (filtered by user's roles)
+//   - GET  /me/permissions   → Permission codes (?system=admin prefix filter)
+//   - PUT  /me/org           → Switch active organization
+//   - PATCH /me/password     → Change password (userID from SSO claims)
+//   - GET  /me/login-history → Login history (userID from SSO claims)
+//   - GET  /me/notifications/unread-count → Unread notification count (badge)
 //
 //   Security (Local Auth) - Path: /auth/local/me/*
+//   (impersonation session-д DELETE sessions, password, TOTP, backup-codes 403)
 //   - GET    /auth/local/me/sessions         → List active sessions
 //   - DELETE /auth/local/me/sessions/:id     → Revoke specific session
 //   - POST   /auth/local/me/password         → Change password
@@ -59,6 +66,27 @@ func MapMeRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Handler
 		router.Get("/profile", middleware.Timeout(5*time.Second), userHandler.Profile)
 		router.Get("/profile/sso", middleware.Timeout(5*time.Second), userHandler.ProfileSSO)
 		router.Get("/organizations", middleware.Timeout(5*time.Second), userHandler.Organizations)
+		router.Put("/org", middleware.Timeout(5*time.Second), userHandler.SwitchOrganization)
+
+		// Menu tree (role permission-оор шүүгдсэн)
+		router.Get("/menu", middleware.Timeout(5*time.Second), userHandler.Menu)
+
+		// Permission кодууд (PermissionCache-ээр)
+		router.Get("/permissions", middleware.Timeout(5*time.Second), userHandler.Permissions)
+
+		// Password (rate limited) - POST /auth/local/me/password-тэй ижил AuthService.ChangePassword
+		mePasswordHandler := handlers.NewMePasswordHandler(d.Service.Auth)
+		router.Patch("/password", middleware.StrictRateLimiter(), middleware.Timeout(5*time.Second), mePasswordHandler.ChangePassword)
+
+		// Login history - /auth/local/me/login-history-ийн alias.
+		// Хоёр route нь ижил loginHistory хэрэгжүүлэлттэй (limit 1-100), зөвхөн
+		// userID-ийн эх үүсвэр ялгаатай: энд SSO claims, local route дээр session.
+		// Local auth-гүй (SSO-оор нэвтэрдэг) client-ууд /me group-ээ л ашиглана.
+		meLoginHistoryHandler := handlers.NewMeLoginHistoryHandler(d.Service.Auth)
+		router.Get("/login-history", middleware.Timeout(5*time.Second), meLoginHistoryHandler.GetLoginHistory)
+
+		// Notification badge - хуудас ачаалах бүрт дуудагддаг тул repository талд 5 секунд cache-тэй
+		router.Get("/notifications/unread-count", middleware.Timeout(5*time.Second), handlers.NewNotificationHandler(d).UnreadCount)
 
 		// Account management
 		accr := router.Group("/accounts")
@@ -94,16 +122,20 @@ func MapMeRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Handler
 	v1.Group("/auth/local/me", sessionAuth).Route("", func(router fiber.Router) {
 		userMgmtHandler := handlers.NewUserManagementHandler(d.Service.Auth)
 		strictLimiter := middleware.StrictRateLimiter()
+		// Impersonation session-ээр MFA, нууц үг, session цуцлах үйлдэл хийхгүй (403)
+		noImpersonation := middleware.DenyImpersonated()
 
 		// Session management
 		// GET  /me/sessions     → List all active sessions
+		// DELETE /me/sessions/all → Revoke all sessions except the current one
 		// DELETE /me/sessions/:id → Revoke specific session
 		router.Get("/sessions", middleware.Timeout(5*time.Second), userMgmtHandler.ListSessions)
-		router.Delete("/sessions/:id", middleware.Timeout(5*time.Second), userMgmtHandler.RevokeSession)
+		router.Delete("/sessions/all", noImpersonation, middleware.Timeout(5*time.Second), userMgmtHandler.RevokeAllSessions)
+		router.Delete("/sessions/:id", noImpersonation, middleware.Timeout(5*time.Second), userMgmtHandler.RevokeSession)
 
 		// Password management (rate limited)
 		// POST /me/password → Change password
-		router.Post("/password", strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.ChangePassword)
+		router.Post("/password", noImpersonation, strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.ChangePassword)
 
 		// MFA management
 		mfar := router.Group("/mfa")
@@ -114,16 +146,16 @@ func MapMeRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Handler
 		// POST /me/mfa/totp/setup   → Initiate TOTP setup
 		// POST /me/mfa/totp/confirm → Confirm TOTP with code
 		// DELETE /me/mfa/totp       → Disable TOTP
-		mfar.Post("/totp/setup", strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.SetupTOTP)
-		mfar.Post("/totp/confirm", strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.ConfirmTOTP)
-		mfar.Delete("/totp", strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.DisableTOTP)
+		mfar.Post("/totp/setup", noImpersonation, strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.SetupTOTP)
+		mfar.Post("/totp/confirm", noImpersonation, strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.ConfirmTOTP)
+		mfar.Delete("/totp", noImpersonation, strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.DisableTOTP)
 
 		// Backup codes (rate limited)
 		// POST /me/mfa/backup-codes → Generate new backup codes
-		mfar.Post("/backup-codes", strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.GenerateBackupCodes)
+		mfar.Post("/backup-codes", noImpersonation, strictLimiter, middleware.Timeout(5*time.Second), userMgmtHandler.GenerateBackupCodes)
 
 		// Login history & audit
-		// GET /me/login-history  → Login attempts history
+		// GET /me/login-history  → Login attempts history (/me/login-history alias-тай)
 		// GET /me/security-audit → Security audit trail
 		router.Get("/login-history", middleware.Timeout(5*time.Second), userMgmtHandler.GetLoginHistory)
 		router.Get("/security-audit", middleware.Timeout(5*time.Second), userMgmtHandler.GetSecurityAudit)
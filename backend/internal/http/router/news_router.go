@@ -30,15 +30,31 @@ func MapNewsRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Handl
 	// Мэдээний CRUD (List, Get нь public).
 	v1.Group("/news", middleware.Timeout(5*time.Second)).Route("", func(router fiber.Router) {
 		h := handlers.NewNewsHandler(d)
+		ch := handlers.NewNewsCategoryHandler(d)
+
+		// Ангилал ("/:id"-ээс өмнө бүртгэнэ, эс бөгөөс "category" нь id болж таарна)
+		router.Get("/category", ch.List)
+		router.Post("/category", requireAuth, auth.RequirePermission(perm, "admin.news.create"), ch.Create)
+		router.Put("/category/:id", requireAuth, auth.RequirePermission(perm, "admin.news.update"), ch.Update)
+		router.Delete("/category/:id", requireAuth, auth.RequirePermission(perm, "admin.news.delete"), ch.Delete)
 
 		// Public read (no permission required)
 		router.Get("/", h.List)
 		router.Get("/:id", h.Get)
 
 		// Protected write with permission checks
-		router.Post("/", requireAuth, auth.RequirePermission(perm, "admin.news.create"), h.Create)
-		router.Put("/:id", requireAuth, auth.RequirePermission(perm, "admin.news.update"), h.Update)
+		// title, text-ээс HTML tag хасна (XSS)
+		sanitize := middleware.Sanitize("title", "text")
+		router.Post("/", requireAuth, auth.RequirePermission(perm, "admin.news.create"), sanitize, h.Create)
+		router.Put("/:id", requireAuth, auth.RequirePermission(perm, "admin.news.update"), sanitize, h.Update)
 		router.Delete("/:id", requireAuth, auth.RequirePermission(perm, "admin.news.delete"), h.Delete)
+
+		// Зураг upload (multipart/form-data, "file" талбар, ≤ 5 MB)
+		router.Post("/:id/attachment", requireAuth, auth.RequirePermission(perm, "admin.news.update"), h.UploadAttachment)
+
+		// Publish state (draft ↔ published)
+		router.Patch("/:id/publish", requireAuth, auth.RequirePermission(perm, "admin.news.update"), h.Publish)
+		router.Patch("/:id/unpublish", requireAuth, auth.RequirePermission(perm, "admin.news.update"), h.Unpublish)
 	})
 }
 
@@ -17,6 +17,7 @@ import (
 	"templatev25/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
 )
 
 // MapNotificationRoutes нь notification route-уудыг бүртгэнэ.
@@ -24,6 +25,15 @@ func MapNotificationRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fib
 	// Permission checker (cache-тэй)
 	perm := d.PermCache
 
+	// ------------------------------------------------------------
+	// NOTIFICATION WEBSOCKET
+	// ------------------------------------------------------------
+	// GET /notification/ws?token=xxx → Real-time мэдэгдэл.
+	// requireAuth/Timeout group-ээс өмнө бүртгэнэ (token query-оор шалгагдана,
+	// холболт урт хугацаанд нээлттэй байна).
+	wsHandler := handlers.NewNotificationHandler(d)
+	v1.Get("/notification/ws", wsHandler.WSAuth, websocket.New(wsHandler.WS))
+
 	// ------------------------------------------------------------
 	// NOTIFICATION ROUTES
 	// ------------------------------------------------------------
@@ -37,12 +47,19 @@ func MapNotificationRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fib
 		// Get notification groups (user's own groups - no admin permission required)
 		router.Get("/groups", h.Groups)
 
+		// Unread counts by type (user's own notifications - no admin permission required)
+		router.Get("/stats", h.Stats)
+
 		// Send notification (requires admin permission)
 		router.Post("/", auth.RequirePermission(perm, "admin.notification.create"), h.Send)
 
+		// Send notification to selected users (requires admin permission)
+		router.Post("/group", auth.RequirePermission(perm, "admin.notification.create"), h.SendGroup)
+
 		// Mark as read (user's own notifications - no admin permission required)
 		router.Post("/read", h.Read)
 		router.Post("/read-all", h.ReadAll)
+		router.Post("/read-batch", h.ReadBatch)
 	})
 }
 
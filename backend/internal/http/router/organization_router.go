@@ -36,12 +36,37 @@ func MapOrganizationRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fib
 
 		// CRUD operations with permission checks
 		router.Get("/", auth.RequirePermission(perm, "admin.organization.read"), h.List)
-		router.Post("/", auth.RequirePermission(perm, "admin.organization.create"), h.Create)
+		router.Post("/", auth.RequirePermission(perm, "admin.organization.create"), middleware.Idempotency(d.Idempotency, idempotencyTTL), h.Create)
 		router.Put("/:id", auth.RequirePermission(perm, "admin.organization.update"), h.Update)
 		router.Delete("/:id", auth.RequirePermission(perm, "admin.organization.delete"), h.Delete)
 
+		// Move organization (with subtree) under a new parent (PUT /organization/:id/parent {"parent_id": 5})
+		router.Put("/:id/parent", auth.RequirePermission(perm, "admin.organization.update"), h.MoveToParent)
+
+		// Restore soft-deleted organization (admin only)
+		router.Put("/:id/restore", auth.RequireRole(d.RoleCache, adminRoles...), auth.RequirePermission(perm, "admin.organization.update"), h.Restore)
+
 		// Get organization tree (hierarchical structure)
 		router.Get("/tree", auth.RequirePermission(perm, "admin.organization.read"), h.Tree)
+
+		// Nested subtree of one organization (GET /organization/:id/tree)
+		router.Get("/:id/tree", auth.RequirePermission(perm, "admin.organization.read"), h.TreeByID)
+
+		// Counts per organization type (dashboard)
+		router.Get("/stats", auth.RequirePermission(perm, "admin.organization.read"), h.Stats)
+
+		// Full-text search (GET /organization/search?q=...)
+		router.Get("/search", auth.RequirePermission(perm, "admin.organization.read"), h.Search)
+
+		// Users of organization (alias of /orguser/users?org_id=)
+		router.Get("/:id/users", auth.RequirePermission(perm, "admin.orguser.read"), h.Users)
+
+		// Remove user from organization (RESTful alias of DELETE /orguser)
+		router.Delete("/:id/user/:userID", auth.RequirePermission(perm, "admin.orguser.delete"), handlers.NewOrgUserHandler(d).RemoveByPath)
+
+		// Organization detail with type name, children and user counts.
+		// /find, /tree, /stats, /search-ийн дараа бүртгэнэ (эс бөгөөс :id тэднийг барина)
+		router.Get("/:id", auth.RequirePermission(perm, "admin.organization.read"), h.Get)
 	})
 
 	// ------------------------------------------------------------
@@ -91,6 +116,13 @@ func MapOrganizationRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fib
 		// POST /orgtype/role {type_id, role_ids} → Add roles
 		router.Get("/role", auth.RequirePermission(perm, "admin.orgtype.read"), h.Roles)
 		router.Post("/role", auth.RequirePermission(perm, "admin.orgtype.update"), h.AddRoles)
+
+		// Permission assignment (role-оор дамжилгүй шууд)
+		// GET      /orgtype/:id/permissions → Permissions for org type
+		// PUT/POST /orgtype/:id/permissions {permission_ids} → Replace permissions
+		router.Get("/:id/permissions", auth.RequirePermission(perm, "admin.orgtype.read"), h.Permissions)
+		router.Put("/:id/permissions", auth.RequirePermission(perm, "admin.orgtype.update"), h.SetPermissions)
+		router.Post("/:id/permissions", auth.RequirePermission(perm, "admin.orgtype.update"), h.SetPermissions)
 	})
 
 	// ------------------------------------------------------------
@@ -35,11 +35,14 @@ Endpoint groups:
 
 Ашиглалт:
 
-	deps := app.NewDependencies(...)
+	deps := app.NewDependencies(app.WithDB(db), app.WithConfig(cfg), ...)
 	router.MapV1(fiberApp, deps)
 */
 package router
 
+// Handler бүр @Summary, @Tags, @Param, @Success annotation-тэй эсэхийг шалгана (make swagger-lint).
+//go:generate go run ../../../cmd/swagger-lint -router . -handlers ../handlers
+
 import (
 	"context"
 	"sync"
@@ -48,13 +51,13 @@ import (
 
 	"templatev25/internal/app"        // Dependency container
 	"templatev25/internal/auth"       // Auth middleware
+	"templatev25/internal/health"     // Health checkers
 	"templatev25/internal/middleware" // Middleware
 
 	"git.gerege.mn/backend-packages/resp" // Response helpers
 
 	"github.com/gofiber/fiber/v2"        // Web framework
 	swagger "github.com/gofiber/swagger" // Swagger UI middleware
-	"gorm.io/gorm"                       // ORM (for health check)
 )
 
 // ============================================================
@@ -69,6 +72,17 @@ var (
 
 const healthCacheTTL = 5 // Cache TTL in seconds
 
+// idempotencyTTL нь X-Idempotency-Key-ийн хариуг хадгалах хугацаа (POST /user, POST /organization)
+const idempotencyTTL = 24 * time.Hour
+
+// adminRoles нь admin-only route-уудад (auth.RequireRole) зөвшөөрөгдөх role кодууд
+var adminRoles = []string{"SUPER_ADMIN", "ADMIN"}
+
+// Feature flag кодууд (feature_flags.code) — middleware.FeatureGate-ээр хаагдах optional endpoint-ууд
+const (
+	featureUserSearch = "user_search" // GET /user/search
+)
+
 // ============================================================
 // MAIN ROUTE MAPPING FUNCTION
 // ============================================================
@@ -85,7 +99,7 @@ const healthCacheTTL = 5 // Cache TTL in seconds
 //	┌──────────────────────────────────────────────────────────┐
 //	│                     PUBLIC ROUTES                         │
 //	├──────────────────────────────────────────────────────────┤
-//	│  GET  /health     → Health check (DB ping)               │
+//	│  GET  /health     → Health check (DB, SSO, Redis)        │
 //	│  GET  /docs/*     → Swagger UI                           │
 //	└──────────────────────────────────────────────────────────┘
 //	┌──────────────────────────────────────────────────────────┐
@@ -119,9 +133,9 @@ func MapV1(app *fiber.App, d *app.Dependencies) {
 	pub := app.Group("/")
 
 	// Health check endpoint
-	// Database connection-ийг шалгана (2 секундын timeout-тэй)
-	// Response: {"code": "OK", "data": {"status": "ok"}}
-	pub.Get("/health", healthHandler(d.DB))
+	// Database, SSO, Redis-ийг шалгана (2 секундын timeout-тэй)
+	// Response: {"code": "OK", "data": {"status": "ok", "database": {...}, ...}}
+	pub.Get("/health", healthHandler(d.HealthCheckers))
 
 	// Swagger UI (зөвхөн Docs.Enabled=true үед)
 	// URL: /docs/index.html
@@ -138,7 +152,12 @@ func MapV1(app *fiber.App, d *app.Dependencies) {
 	// Protected route-уудад хэрэглэгчийн session-ийг шалгана.
 	// Cookie-д "sid" байвал түүнийг validate хийнэ.
 	// Session invalid бол 401 Unauthorized буцаана.
-	requireAuth := auth.Require(d.Cfg, d.Log, d.AuthCache)
+	// PUT /me/org-оор сонгосон байгууллага байвал claims-ийн org_id-г солино.
+	// Session дуусахад AUTH_REFRESH_THRESHOLD-оос бага хугацаа үлдсэн бол токеныг
+	// автоматаар шинэчилнэ (cookie session: Set-Cookie, bearer: X-Refreshed-Token).
+	// Зөвхөн protected route-уудад ажиллах тул public route-ууд (login, logout)
+	// хуучин cookie-тэй ч хүрнэ.
+	requireAuth := auth.RequireWithRefresh(d.Cfg, d.SessionCfg, d.Log, d.AuthCache, d.Service.SessionStore)
 
 	// ============================================================
 	// V1 API ROUTES
@@ -223,21 +242,20 @@ func MapV1(app *fiber.App, d *app.Dependencies) {
 // serverStartTime нь server эхэлсэн хугацаа (uptime тооцоолоход хэрэглэнэ)
 var serverStartTime = time.Now()
 
-// healthHandler нь database connection-ийг шалгаж, server-ийн төлөвийг буцаана.
+// healthHandler нь checker бүрийг (database, sso, redis) ажиллуулж, server-ийн төлөвийг буцаана.
 //
 // Returns:
 //   - 200 OK: {"code": "OK", "data": {...}}
-//   - 500 Error: {"code": "INTERNAL_ERROR", "message": "db_down"}
 //
 // Response data includes:
-//   - status: "ok" or "degraded"
+//   - status: "ok" (бүх checker амжилттай) or "degraded"
 //   - uptime: Server uptime in seconds
-//   - database: Database connection status
 //   - timestamp: Current server time (RFC3339)
+//   - <checker name>: Checker бүрийн status, error, details (жишээ: database, sso, redis)
 //
-// Database ping timeout: 2 секунд
+// Checker-ууд зэрэг ажиллана, нийт timeout: 2 секунд
 // Cached for 5 seconds to reduce database load under high traffic
-func healthHandler(db *gorm.DB) fiber.Handler {
+func healthHandler(checkers []health.HealthChecker) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		now := time.Now().Unix()
 
@@ -263,52 +281,21 @@ func healthHandler(db *gorm.DB) fiber.Handler {
 		ctx, cancel := context.WithTimeout(c.UserContext(), 2*time.Second)
 		defer cancel()
 
+		status, results := health.Run(ctx, checkers)
+
 		// Health check result
 		result := fiber.Map{
-			"status":    "ok",
+			"status":    status,
 			"uptime":    int64(time.Since(serverStartTime).Seconds()),
 			"timestamp": time.Now().Format(time.RFC3339),
+			// API log queue-ийн дүүргэлт, хаягдсан entry-ийн тоо (status-т нөлөөлөхгүй)
+			"api_log": middleware.LogWorkerHealth(),
 		}
-
-		// GORM-оос underlying *sql.DB авах
-		sqlDB, err := db.DB()
-		if err != nil {
-			result["status"] = "degraded"
-			result["database"] = fiber.Map{
-				"status": "error",
-				"error":  "db_connection_error",
-			}
-			// Cache error result too (avoid DB hammering)
-			healthCache.Store(result)
-			healthCacheTime.Store(now)
-			return resp.OK(c, result)
-		}
-
-		// Database ping хийх
-		if err := sqlDB.PingContext(ctx); err != nil {
-			result["status"] = "degraded"
-			result["database"] = fiber.Map{
-				"status": "error",
-				"error":  "db_unreachable",
-			}
-			healthCache.Store(result)
-			healthCacheTime.Store(now)
-			return resp.OK(c, result)
-		}
-
-		// Database stats авах
-		stats := sqlDB.Stats()
-		result["database"] = fiber.Map{
-			"status":      "ok",
-			"open_conns":  stats.OpenConnections,
-			"in_use":      stats.InUse,
-			"idle":        stats.Idle,
-			"max_open":    stats.MaxOpenConnections,
-			"wait_count":  stats.WaitCount,
-			"wait_time":   stats.WaitDuration.String(),
+		for _, r := range results {
+			result[r.Name] = r
 		}
 
-		// Cache the result
+		// Cache the result (error result too - avoid hammering dependencies)
 		healthCache.Store(result)
 		healthCacheTime.Store(now)
 
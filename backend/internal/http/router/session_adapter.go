@@ -41,6 +41,7 @@ func (a *SessionStoreAdapter) Get(ctx context.Context, sessionID string) (*middl
 		CreatedAt:      session.CreatedAt,
 		ExpiresAt:      session.ExpiresAt,
 		LastActivityAt: session.LastActivityAt,
+		ImpersonatedBy: session.ImpersonatedBy,
 	}, nil
 }
 
@@ -55,6 +56,7 @@ func (a *SessionStoreAdapter) Update(ctx context.Context, session *middleware.Se
 		CreatedAt:      session.CreatedAt,
 		ExpiresAt:      session.ExpiresAt,
 		LastActivityAt: session.LastActivityAt,
+		ImpersonatedBy: session.ImpersonatedBy,
 	}
 	return a.store.Update(ctx, serviceSession)
 }
@@ -34,6 +34,7 @@ func MapSystemRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Han
 		// CRUD operations with permission checks
 		router.Get("/", auth.RequirePermission(perm, "admin.system.read"), h.List)
 		router.Get("/:id", auth.RequirePermission(perm, "admin.system.read"), h.Get)
+		router.Get("/:id/permissions", auth.RequirePermission(perm, "admin.permission.read"), h.Permissions)
 		router.Post("/", auth.RequirePermission(perm, "admin.system.create"), h.Create)
 		router.Put("/:id", auth.RequirePermission(perm, "admin.system.update"), h.Update)
 		router.Delete("/:id", auth.RequirePermission(perm, "admin.system.delete"), h.Delete)
@@ -48,6 +49,7 @@ func MapSystemRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Han
 
 		// CRUD operations with permission checks
 		r.Get("/", auth.RequirePermission(perm, "admin.module.read"), h.List)
+		r.Get("/:id/permissions", auth.RequirePermission(perm, "admin.permission.read"), h.Permissions)
 		r.Post("/", auth.RequirePermission(perm, "admin.module.create"), h.Create)
 		r.Put("/:id", auth.RequirePermission(perm, "admin.module.update"), h.Update)
 		r.Delete("/:id", auth.RequirePermission(perm, "admin.module.delete"), h.Delete)
@@ -61,6 +63,9 @@ func MapSystemRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Han
 		h := handlers.NewPermissionHandler(d)
 
 		// CRUD operations with permission checks
+		// Current user-ийн эрхийг бөөнөөр шалгах (admin эрх шаардахгүй)
+		router.Post("/check", h.Check)
+
 		router.Get("/", auth.RequirePermission(perm, "admin.permission.read"), h.List)
 		router.Post("/", auth.RequirePermission(perm, "admin.permission.create"), h.Create)
 		router.Put("/:id", auth.RequirePermission(perm, "admin.permission.update"), h.Update)
@@ -97,8 +102,13 @@ func MapSystemRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Han
 		// Permission management with permission checks
 		// GET  /role/permissions?role_id=1 → Role's permissions
 		// POST /role/permissions {role_id, permission_ids} → Set permissions
+		// POST /role/permissions/diff {role_id, proposed_ids} → Өөрчлөлтийн preview (DB-д бичихгүй)
 		router.Get("/permissions", auth.RequirePermission(perm, "admin.role.read"), role.GetRolePermissions)
 		router.Post("/permissions", auth.RequirePermission(perm, "admin.role.update"), role.SetRolePermissions)
+		router.Post("/permissions/diff", auth.RequirePermission(perm, "admin.role.read"), role.DiffRolePermissions)
+
+		// GET /role/:id → Role + permissions (/permissions-ийн дараа бүртгэнэ)
+		router.Get("/:id", auth.RequirePermission(perm, "admin.role.read"), role.Get)
 	})
 
 	// ------------------------------------------------------------
@@ -128,8 +138,10 @@ func MapSystemRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Han
 		router.Get("/my", h.ListByRole) // Get menus by current user's roles (no permission required)
 		router.Get("/:id", auth.RequirePermission(perm, "admin.menu.read"), h.Get)
 		router.Post("/", auth.RequirePermission(perm, "admin.menu.create"), h.Create)
+		router.Put("/reorder", auth.RequirePermission(perm, "admin.menu.update"), h.Reorder) // /:id-ээс өмнө бүртгэнэ
 		router.Put("/:id", auth.RequirePermission(perm, "admin.menu.update"), h.Update)
 		router.Delete("/:id", auth.RequirePermission(perm, "admin.menu.delete"), h.Delete)
+		router.Post("/:id/permissions", auth.RequirePermission(perm, "admin.menu.update"), h.SetPermissions)
 	})
 
 	// ------------------------------------------------------------
@@ -21,6 +21,23 @@ import (
 
 // MapUserRoutes нь user CRUD route-уудыг бүртгэнэ.
 func MapUserRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Handler) {
+	// ------------------------------------------------------------
+	// SIGNED INTERNAL SYNC (INTERNAL_API_SECRET тохируулсан үед)
+	// ------------------------------------------------------------
+	// X-Signature header-тэй POST /user/sync-ийг SSO session-гүйгээр HMAC-аар
+	// баталгаажуулна (гарын үсэг бүр нэг л удаа хүлээн авагдана). Header байхгүй бол
+	// SSO session + admin role/permission шаардана. Route /user group-ээс өмнө
+	// бүртгэгдэх тул group-ийн requireAuth signed дуудлагад ажиллахгүй.
+	if secret := d.ServerCfg.InternalSecret; secret != "" {
+		syncHandler := handlers.NewUserHandler(d)
+		v1.Post("/user/sync",
+			middleware.SignedOr(middleware.HMACSignature(secret, d.SignatureNonces), requireAuth),
+			middleware.UnlessSigned(auth.RequireRole(d.RoleCache, adminRoles...)),
+			middleware.UnlessSigned(auth.RequirePermission(d.PermCache, "admin.user.create")),
+			syncHandler.Sync,
+		)
+	}
+
 	// ------------------------------------------------------------
 	// USER ROUTES
 	// ------------------------------------------------------------
@@ -32,15 +49,41 @@ func MapUserRoutes(v1 fiber.Router, d *app.Dependencies, requireAuth fiber.Handl
 		// POST /user/find-from-core → Search user in Core database
 		router.Post("/find-from-core", auth.RequirePermission(d.PermCache, "admin.user.read"), handler.FindFromCore)
 
+		// Search
+		// GET /user/search?q=bold → first_name, last_name, email, reg_no, phone_no-оос хайна
+		// (user_search feature flag идэвхгүй бол 404)
+		router.Get("/search", middleware.FeatureGate(featureUserSearch, d.Repo.FeatureFlag), auth.RequirePermission(d.PermCache, "admin.user.read"), handler.Search)
+
+		// Export
+		// GET /user/export?format=csv → Download users as CSV
+		router.Get("/export", auth.RequirePermission(d.PermCache, "admin.user.read"), handler.Export)
+
+		// SSO sync (зөвхөн SUPER_ADMIN, ADMIN role; дотоод сервис HMAC гарын үсгээр дээрхийг ашиглана)
+		// POST /user/sync {users: [...]} → Bulk upsert by id
+		router.Post("/sync", auth.RequireRole(d.RoleCache, adminRoles...), auth.RequirePermission(d.PermCache, "admin.user.create"), handler.Sync)
+
 		// User CRUD
 		// GET    /user       → List users (paginated)
 		// POST   /user       → Create user
 		// PUT    /user/:id   → Update user
-		// DELETE /user/:id   → Delete user
+		// DELETE /user/:id   → Delete user (зөвхөн SUPER_ADMIN, ADMIN role)
 		router.Get("/", auth.RequirePermission(d.PermCache, "admin.user.read"), handler.List)
-		router.Post("/", auth.RequirePermission(d.PermCache, "admin.user.create"), handler.Create)
+		router.Post("/", auth.RequirePermission(d.PermCache, "admin.user.create"), middleware.Idempotency(d.Idempotency, idempotencyTTL), handler.Create)
 		router.Put("/:id", auth.RequirePermission(d.PermCache, "admin.user.update"), handler.Update)
-		router.Delete("/:id", auth.RequirePermission(d.PermCache, "admin.user.delete"), handler.Delete)
+		router.Delete("/:id", auth.RequireRole(d.RoleCache, adminRoles...), auth.RequirePermission(d.PermCache, "admin.user.delete"), handler.Delete)
+
+		// Role history
+		// GET /user/:id/roles        → Хэрэглэгчийн role-ууд (системийн нэрийн хамт)
+		// GET /user/:id/role-history → Эрх олгосон/хассан түүх (шинэ нь эхэндээ)
+		userRole := handlers.NewUserRoleHandler(d)
+		router.Get("/:id/roles", auth.RequirePermission(d.PermCache, "admin.user.roles.read"), userRole.UserRoles)
+		router.Get("/:id/role-history", auth.RequirePermission(d.PermCache, "admin.user.read"), userRole.RoleHistory)
+
+		// Security audit trail
+		// GET /user/:id/audit?limit=50&action=login → Хэрэглэгчийн audit trail
+		// (admin.user.audit.read эрхийг handler өөрөө шалгана)
+		userAudit := handlers.NewUserAuditHandler(d.Repo.Auth, d.PermCache)
+		router.Get("/:id/audit", userAudit.GetAudit)
 	})
 }
 
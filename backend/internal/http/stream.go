@@ -0,0 +1,95 @@
+// Package http provides implementation for http
+//
+// File: stream.go
+// Description: Channel-аас JSON array stream хийх response helper
+package http
+
+import (
+	"bufio"
+	"io"
+
+	ijson "templatev25/internal/json"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// streamBufferSize нь pipe руу бичихийн өмнөх буфер (нэг chunk-ийн хэмжээ)
+const streamBufferSize = 32 * 1024
+
+// StreamJSON нь items channel-ийн элемент бүрийг JSON array болгон response руу
+// шууд бичнэ: "[" → item, item, ... → "]". Бүх slice санах ойд зэрэг ачаалагдахгүй.
+//
+// Body нь io.Pipe-аар chunked transfer encoding-оор илгээгдэнэ. Status (200) эхний
+// chunk-аас өмнө бичигддэг тул stream эхэлсний дараах алдааг status-аар мэдэгдэх боломжгүй.
+//
+// Handler дууссаны дараа (request context цуцлагдсаны дараа) body уншигддаг тул
+// items-ийг үүсгэгч нь request context-оос хамааралгүй context ашиглах ёстой.
+func StreamJSON[T any](c *fiber.Ctx, items <-chan T) error {
+	return StreamJSONWithErrors(c, items, nil)
+}
+
+// StreamJSONWithErrors нь StreamJSON-той ижил. items хаагдсаны дараа errc-ээс алдаа
+// ирвэл хаах "]"-ийг бичилгүй холболтыг тасална — client дутуу JSON авч алдааг мэднэ.
+// errc нь nil байж болно.
+func StreamJSONWithErrors[T any](c *fiber.Ctx, items <-chan T, errc <-chan error) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return Stream(c, func(w io.Writer) error {
+		return writeJSONArray(w, items, errc)
+	})
+}
+
+// Stream нь write-ийн w руу бичсэн бүхнийг io.Pipe-аар response руу шууд илгээнэ.
+// write тусдаа goroutine-д ажиллах ба алдаа буцаавал холболт тасарна (status-ыг
+// өөрчлөх боломжгүй). Client салбал w руу бичих нь алдаа буцааж write зогсоно.
+//
+// StreamJSON-той адил write нь request context-оос хамааралгүй context ашиглах ёстой.
+func Stream(c *fiber.Ctx, write func(w io.Writer) error) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(write(pw))
+	}()
+	return c.SendStream(pr)
+}
+
+// writeJSONArray нь items-ийг w руу JSON array болгон бичнэ.
+// Бичих алдаа гарвал (client салсан гэх мэт) үлдсэн items-ийг уншиж дуусгана,
+// ингэснээр үүсгэгч goroutine send дээр гацахгүй.
+func writeJSONArray[T any](w io.Writer, items <-chan T, errc <-chan error) (err error) {
+	defer func() {
+		if err != nil {
+			for range items {
+			}
+		}
+	}()
+
+	bw := bufio.NewWriterSize(w, streamBufferSize)
+	if err := bw.WriteByte('['); err != nil {
+		return err
+	}
+	first := true
+	for item := range items {
+		b, err := ijson.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if !first {
+			if err := bw.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		first = false
+		if _, err := bw.Write(b); err != nil {
+			return err
+		}
+	}
+
+	if errc != nil {
+		if err := <-errc; err != nil {
+			return err
+		}
+	}
+	if err := bw.WriteByte(']'); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
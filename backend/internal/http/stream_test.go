@@ -0,0 +1,161 @@
+// Package http provides HTTP server setup
+//
+// File: stream_test.go
+// Description: Unit tests and buffered vs streamed benchmarks for StreamJSON
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/domain"
+	ijson "templatev25/internal/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamBenchRows нь benchmark-ийн мөрийн тоо
+const streamBenchRows = 50000
+
+// testUsers нь n хэрэглэгчийн slice үүсгэнэ
+func testUsers(n int) []domain.User {
+	users := make([]domain.User, n)
+	for i := range users {
+		users[i] = domain.User{
+			Id:        i + 1,
+			CivilId:   100000 + i,
+			RegNo:     fmt.Sprintf("АА%08d", i),
+			LastName:  "Бат",
+			FirstName: fmt.Sprintf("Болд %d", i),
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			Status:    "active",
+		}
+	}
+	return users
+}
+
+// produce нь users-ийг channel руу илгээгээд хаана
+func produce(users []domain.User) <-chan domain.User {
+	ch := make(chan domain.User, 64)
+	go func() {
+		defer close(ch)
+		for _, u := range users {
+			ch <- u
+		}
+	}()
+	return ch
+}
+
+// =============================================================================
+// StreamJSON
+// =============================================================================
+
+func TestStreamJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []domain.User
+	}{
+		{name: "empty", users: []domain.User{}},
+		{name: "single", users: testUsers(1)},
+		{name: "many - larger than buffer", users: testUsers(2000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{DisableStartupMessage: true})
+			app.Get("/", func(c *fiber.Ctx) error {
+				return StreamJSON(c, produce(tt.users))
+			})
+
+			res, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			want, err := json.Marshal(tt.users)
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, res.StatusCode)
+			assert.Equal(t, fiber.MIMEApplicationJSONCharsetUTF8, res.Header.Get(fiber.HeaderContentType))
+			assert.Equal(t, string(want), string(body))
+		})
+	}
+}
+
+func TestWriteJSONArray_ProducerError(t *testing.T) {
+	errc := make(chan error, 1)
+	errc <- errors.New("db gone")
+
+	var buf bytes.Buffer
+	err := writeJSONArray(&buf, produce(testUsers(2)), errc)
+
+	require.EqualError(t, err, "db gone")
+	// Хаах "]" бичигдэхгүй тул client дутуу JSON авна
+	assert.False(t, json.Valid(buf.Bytes()))
+}
+
+// failWriter нь эхний бичилтээс алдаа буцаана (client салсан)
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, io.ErrClosedPipe }
+
+func TestWriteJSONArray_WriteErrorDrainsItems(t *testing.T) {
+	ch := make(chan domain.User) // buffer-гүй: drain хийгдэхгүй бол producer гацна
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(ch)
+		for _, u := range testUsers(streamBufferSize) {
+			ch <- u
+		}
+	}()
+
+	err := writeJSONArray(failWriter{}, ch, nil)
+
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+	<-done
+}
+
+// =============================================================================
+// Benchmarks (go test -bench=JSONList -benchmem ./internal/http/)
+// =============================================================================
+
+// BenchmarkJSONList_Buffered нь одоогийн арга: бүх мөрийг slice-д ачаалаад нэг удаа marshal
+func BenchmarkJSONList_Buffered(b *testing.B) {
+	rows := testUsers(streamBenchRows)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		users := make([]domain.User, 0, 64)
+		for u := range produce(rows) {
+			users = append(users, u)
+		}
+		out, err := ijson.Marshal(users)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := io.Discard.Write(out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkJSONList_Streamed нь StreamJSON-ий арга: мөр бүрийг шууд бичнэ
+func BenchmarkJSONList_Streamed(b *testing.B) {
+	rows := testUsers(streamBenchRows)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := writeJSONArray(io.Discard, produce(rows), nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Package validation provides implementation for validation
+//
+// File: validator.go
+// Description: Request DTO validation with field-level error detail
+/*
+Package validation нь request DTO-уудын `validate:"..."` tag-ийг
+go-playground/validator/v10-аар шалгаж, алдааг талбар бүрээр задлан буцаана.
+
+Алдааны response:
+
+	{
+	  "code": "VALIDATION_ERROR",
+	  "message": "validation failed",
+	  "errors": [
+	    {"field": "email", "tag": "email", "message": "invalid email format"}
+	  ]
+	}
+
+Field нэр нь json (эсвэл query/params/form) tag-аас авагдана.
+
+Ашиглалт:
+
+	req, ok := validation.BodyBindAndValidate[dto.UserCreateDto](c)
+	if !ok {
+		return nil // response аль хэдийн бичигдсэн
+	}
+*/
+package validation
+
+import (
+	"errors"  // errors.As
+	"fmt"     // Messages
+	"reflect" // Field kind, tag names
+	"strings" // Tag parsing
+
+	"git.gerege.mn/backend-packages/ctx"  // Request ID
+	"git.gerege.mn/backend-packages/resp" // Parse error response
+
+	"github.com/go-playground/validator/v10" // Struct validation
+	"github.com/gofiber/fiber/v2"            // Web framework
+)
+
+// CodeValidationError нь validation алдааны response code
+const CodeValidationError = "VALIDATION_ERROR"
+
+// FieldError нь нэг талбарын validation алдаа
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
+// ValidationError нь нэг буюу хэд хэдэн талбарын алдааг агуулна
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error нь "validation failed: email: invalid email format; ..." хэлбэрийн мессеж буцаана
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		parts[i] = fe.Field + ": " + fe.Message
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// Response нь validation алдааны JSON response
+type Response struct {
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Message   string       `json:"message"`
+	Errors    []FieldError `json:"errors"`
+}
+
+// validate нь tag нэрийг JSON нэрээр буцаадаг shared validator (goroutine-safe)
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(fieldName)
+	return v
+}
+
+// fieldName нь json → query → params → form tag-ийн эхнийхийг field нэр болгоно
+func fieldName(f reflect.StructField) string {
+	for _, key := range []string{"json", "query", "params", "form"} {
+		name, _, _ := strings.Cut(f.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// ============================================================
+// VALIDATION
+// ============================================================
+
+// Struct нь v-ийн validate tag-уудыг шалгана.
+// Алдаа байвал *ValidationError буцаана.
+func Struct(v any) error {
+	if err := validate.Struct(v); err != nil {
+		if fields := Format(err); fields != nil {
+			return &ValidationError{Errors: fields}
+		}
+		return err
+	}
+	return nil
+}
+
+// Format нь validation алдааг талбар бүрийн FieldError болгоно.
+// *ValidationError эсвэл validator.ValidationErrors биш бол nil буцаана.
+func Format(err error) []FieldError {
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		return ve.Errors
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+
+	out := make([]FieldError, len(verrs))
+	for i, fe := range verrs {
+		out[i] = FieldError{
+			Field:   fieldPath(fe),
+			Tag:     fe.Tag(),
+			Message: message(fe),
+		}
+	}
+	return out
+}
+
+// fieldPath нь root struct-ийн нэргүй field зам буцаана (жишээ: "items[0].id")
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if _, rest, ok := strings.Cut(ns, "."); ok {
+		return rest
+	}
+	return fe.Field()
+}
+
+// message нь tag бүрт ойлгомжтой мессеж буцаана
+func message(fe validator.FieldError) string {
+	param := fe.Param()
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "invalid email format"
+	case "min":
+		return "must be at least " + param + sizeUnit(fe.Kind())
+	case "max":
+		return "must be at most " + param + sizeUnit(fe.Kind())
+	case "len":
+		return "must be exactly " + param + sizeUnit(fe.Kind())
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be greater than or equal to " + param
+	case "lt":
+		return "must be less than " + param
+	case "lte":
+		return "must be less than or equal to " + param
+	case "eq":
+		return "must be " + param
+	case "eqfield":
+		return "must match " + param
+	case "numeric":
+		return "must be numeric"
+	case "datetime":
+		return "must be a date in " + param + " format"
+	default:
+		return fmt.Sprintf("failed on %q validation", fe.Tag())
+	}
+}
+
+// sizeUnit нь min/max/len-ийн нэгжийг field-ийн төрлөөр сонгоно
+func sizeUnit(k reflect.Kind) string {
+	switch k {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
+// ============================================================
+// FIBER HELPERS
+// ============================================================
+
+// BodyBindAndValidate нь request body-г T руу parse хийж, validate tag-уудыг шалгана.
+// Parse алдаа бол 400 BAD_REQUEST, validation алдаа бол 422 VALIDATION_ERROR (талбар бүрээр) бичнэ.
+//
+// Returns:
+//   - T: Parse хийсэн утга
+//   - bool: false бол response бичигдсэн, handler шууд nil буцаах ёстой
+func BodyBindAndValidate[T any](c *fiber.Ctx) (T, bool) {
+	var t T
+	if err := c.BodyParser(&t); err != nil {
+		_ = resp.BadRequest(c, "invalid request body", err.Error())
+		return t, false
+	}
+	return t, check(c, &t)
+}
+
+// QueryBindAndValidate нь query string-ийг T руу parse хийж шалгана (BodyBindAndValidate-тэй адил)
+func QueryBindAndValidate[T any](c *fiber.Ctx) (T, bool) {
+	var t T
+	if err := c.QueryParser(&t); err != nil {
+		_ = resp.BadRequest(c, "invalid query parameters", err.Error())
+		return t, false
+	}
+	return t, check(c, &t)
+}
+
+// ParamsBindAndValidate нь path параметрийг T руу parse хийж шалгана (BodyBindAndValidate-тэй адил)
+func ParamsBindAndValidate[T any](c *fiber.Ctx) (T, bool) {
+	var t T
+	if err := c.ParamsParser(&t); err != nil {
+		_ = resp.BadRequest(c, "invalid path parameters", err.Error())
+		return t, false
+	}
+	return t, check(c, &t)
+}
+
+// check нь v-г шалгаж, алдаатай бол validation response бичнэ
+func check(c *fiber.Ctx, v any) bool {
+	err := Struct(v)
+	if err == nil {
+		return true
+	}
+	_ = WriteError(c, err)
+	return false
+}
+
+// WriteError нь err-ийг 422 VALIDATION_ERROR response болгон бичнэ.
+// Талбарын алдаа биш бол errors хоосон жагсаалт байна.
+func WriteError(c *fiber.Ctx, err error) error {
+	fields := Format(err)
+	if fields == nil {
+		fields = []FieldError{}
+	}
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(Response{
+		Code:      CodeValidationError,
+		RequestID: ctx.RequestID(c),
+		Message:   "validation failed",
+		Errors:    fields,
+	})
+}
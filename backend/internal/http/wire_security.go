@@ -11,6 +11,7 @@ package http
 import (
 	"time"
 
+	localconfig "templatev25/internal/config"
 	"templatev25/internal/middleware"
 	"templatev25/internal/repository"
 
@@ -18,17 +19,22 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"go.uber.org/zap"
 
 	fiberprometheus "github.com/ansrivas/fiberprometheus/v2"
 	fbhelmet "github.com/gofiber/fiber/v2/middleware/helmet"
-	fbrecover "github.com/gofiber/fiber/v2/middleware/recover"
-	fbrequestid "github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
+// MiddlewareConfig нь main.go-д нэг удаа ачаалж баталгаажуулсан local тохиргоонууд.
+// ApplyMiddlewares env-ийг дахин уншихгүй.
+type MiddlewareConfig struct {
+	Server *localconfig.ServerConfig // Tenant, HMAC secret, CSP, request timeout
+	CORS   *localconfig.CORSConfig   // Methods/headers/max-age, /auth/local origins
+	Log    *localconfig.LogConfig    // API log dead-letter file
+}
+
 // ApplyMiddlewares wires common middlewares.
-func ApplyMiddlewares(app *fiber.App, cfg *config.Config, logg *zap.Logger, apiLogRepo ...interface{}) {
+func ApplyMiddlewares(app *fiber.App, cfg *config.Config, mwCfg MiddlewareConfig, logg *zap.Logger, apiLogRepo ...interface{}) {
 	var repo interface{}
 	if len(apiLogRepo) > 0 {
 		repo = apiLogRepo[0]
@@ -37,8 +43,11 @@ func ApplyMiddlewares(app *fiber.App, cfg *config.Config, logg *zap.Logger, apiL
 	isProduction := cfg.Server.ENV == "production" || cfg.Server.ENV == "prod"
 
 	// ---- Core Recovery & Request ID ----
-	app.Use(fbrecover.New())
-	app.Use(fbrequestid.New())
+	// Panic-ийн утга, stack trace нь zap-аар log-д бичигдэж client ерөнхий 500 авна
+	app.Use(middleware.Recovery(logg))
+	app.Use(middleware.RequestID())
+	// Tenant ID (SERVER_TENANT_ID) → request context; API log, notification-д бичигдэнэ
+	app.Use(middleware.Tenant(mwCfg.Server))
 	app.Use(fbhelmet.New())
 
 	// ---- Distributed Tracing (OpenTelemetry) ----
@@ -58,23 +67,27 @@ func ApplyMiddlewares(app *fiber.App, cfg *config.Config, logg *zap.Logger, apiL
 	}
 
 	// CORS (cookie-compatible)
-	app.Use(cors.New(cors.Config{
-		AllowOrigins:     cfg.CORS.AllowOrigins,
-		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
-		AllowHeaders:     "Content-Type,Authorization,X-CSRF-Token",
-		AllowCredentials: cfg.CORS.AllowCredentials,
-	}))
+	// Local auth route-ууд илүү хатуу origin жагсаалттай (CORS_AUTH_ALLOW_ORIGINS).
+	// Override нь global policy-оос өмнө бүртгэгдэх ёстой.
+	authOrigins := mwCfg.CORS.AuthAllowOrigins
+	if authOrigins == "" {
+		authOrigins = cfg.CORS.AllowOrigins
+	}
+	app.Use("/auth/local", middleware.WithCORS(authOrigins, mwCfg.CORS))
+	app.Use(middleware.CORS(cfg, mwCfg.CORS))
 
 	// ---- CSRF Protection ----
 	// Protects against Cross-Site Request Forgery attacks
 	csrfConfig := middleware.DefaultCSRFConfig(isProduction)
+	// Зөвхөн HMAC гарын үсэг баталгаажсан POST /user/sync CSRF-ээс чөлөөлөгдөнө
+	csrfConfig.InternalSecret = mwCfg.Server.InternalSecret
 	app.Use(middleware.CSRF(csrfConfig))
 
-	// Security headers
-	app.Use(middleware.SecurityHeaders())
+	// Security headers (CSP: SERVER_CSP, хоосон бол default strict policy)
+	app.Use(middleware.SecurityHeaders(mwCfg.Server.CSP))
 
-	// Body size limit ~2MB (adjust via env if you want)
-	app.Use(middleware.BodySizeLimit(2 * 1024 * 1024))
+	// Body size limit: fiber.Config.BodyLimit (SERVER_MAX_BODY_SIZE, main.go) бүх
+	// route-д хэрэгжиж 413 JSON буцаана — энд давхар хязгаар тавихгүй.
 
 	// Rate limiter: 100 req/min per user/IP
 	app.Use(middleware.RateLimiter(100, time.Minute))
@@ -103,9 +116,17 @@ func ApplyMiddlewares(app *fiber.App, cfg *config.Config, logg *zap.Logger, apiL
 
 	// Access logger
 	if repo != nil {
+		// Queue дүүрэхэд хаягдсан API log-ийг dead-letter файл руу бичнэ
+		if err := middleware.OpenLogDeadLetter(mwCfg.Log.DeadLetterPath); err != nil {
+			logg.Warn("api log dead-letter file unavailable", zap.Error(err))
+		}
 		app.Use(middleware.RequestLogger(logg, repo.(repository.APILogRepository)))
 	} else {
 		app.Use(middleware.RequestLogger(logg))
 	}
 
+	// Request timeout (SERVER_REQUEST_TIMEOUT, 0 бол идэвхгүй).
+	// Logger-ийн дараа бүртгэгдсэн тул 503 TIMEOUT хариу access log-д орно.
+	app.Use(middleware.Timeout(mwCfg.Server.RequestTimeout))
+
 }
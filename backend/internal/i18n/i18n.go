@@ -0,0 +1,125 @@
+// Package i18n provides translated user-facing messages
+//
+// File: i18n.go
+// Description: Translator for API error messages (English, Mongolian) selected by Accept-Language
+/*
+Мессежүүд locales/<lang>.json файлд key → text хэлбэрээр хадгалагдаж,
+binary-д embed.FS-ээр суугдана. Key нь API error code (NOT_FOUND, CONFLICT гэх мэт).
+
+Fallback дараалал:
+ 1. Хүссэн хэл (Accept-Language-ийн primary tag, жишээ нь "mn-MN" → "mn")
+ 2. DefaultLanguage ("en")
+ 3. Key өөрөө (T) эсвэл ok=false (Lookup)
+
+Ашиглалт:
+
+	tr, err := i18n.New()
+	if err != nil {
+	    logg.Fatal("i18n init failed", zap.Error(err))
+	}
+	lang := i18n.PrimaryLanguage(c.Get(fiber.HeaderAcceptLanguage))
+	msg := tr.T(lang, "NOT_FOUND")
+*/
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage нь тодорхойгүй эсвэл дэмжигдээгүй хэлний fallback
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// Translator holds the message catalog per language
+type Translator struct {
+	messages map[string]map[string]string
+}
+
+// New loads every embedded locales/<lang>.json file
+func New() (*Translator, error) {
+	files, err := fs.Glob(locales, "locales/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Translator{messages: make(map[string]map[string]string, len(files))}
+	for _, f := range files {
+		data, err := locales.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f, err)
+		}
+		var msgs map[string]string
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", f, err)
+		}
+		t.messages[strings.TrimSuffix(path.Base(f), ".json")] = msgs
+	}
+
+	if _, ok := t.messages[DefaultLanguage]; !ok {
+		return nil, fmt.Errorf("default language %q has no message file", DefaultLanguage)
+	}
+	return t, nil
+}
+
+// Lookup returns the message for key in lang, falling back to DefaultLanguage.
+// ok is false when neither language has the key.
+func (t *Translator) Lookup(lang, key string) (string, bool) {
+	if msg, ok := t.messages[lang][key]; ok {
+		return msg, true
+	}
+	msg, ok := t.messages[DefaultLanguage][key]
+	return msg, ok
+}
+
+// T returns the translated message for key formatted with args.
+// Key олдохгүй бол key-г өөрийг нь буцаана.
+func (t *Translator) T(lang, key string, args ...interface{}) string {
+	msg, ok := t.Lookup(lang, key)
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// PrimaryLanguage extracts the primary subtag of the most preferred language in an
+// Accept-Language header ("mn-MN,en;q=0.8" → "mn"). Returns "" when header is empty or "*".
+func PrimaryLanguage(header string) string {
+	best, bestQ := "", -1.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue // q=0 → "хүлээн авахгүй"
+		}
+		// Ижил q-тай бол эхнийх нь давуу
+		if q > bestQ {
+			best, bestQ = tag, q
+		}
+	}
+
+	primary, _, _ := strings.Cut(best, "-")
+	return strings.ToLower(primary)
+}
@@ -0,0 +1,87 @@
+// Package i18n provides translated user-facing messages
+//
+// File: i18n_test.go
+// Description: Unit tests for language fallback, missing keys and Accept-Language parsing
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTranslator(t *testing.T) *Translator {
+	t.Helper()
+	tr, err := New()
+	require.NoError(t, err)
+	return tr
+}
+
+func TestNew_LoadsEmbeddedLocales(t *testing.T) {
+	tr := newTestTranslator(t)
+
+	assert.Contains(t, tr.messages, "en")
+	assert.Contains(t, tr.messages, "mn")
+	// Хэл бүр ижил key-үүдтэй байх ёстой
+	for key := range tr.messages["en"] {
+		assert.Contains(t, tr.messages["mn"], key, "mn.json missing key %s", key)
+	}
+	assert.Len(t, tr.messages["mn"], len(tr.messages["en"]))
+}
+
+func TestTranslator_T(t *testing.T) {
+	tr := newTestTranslator(t)
+
+	assert.Equal(t, "The requested resource was not found.", tr.T("en", "NOT_FOUND"))
+	assert.Equal(t, "Хайсан өгөгдөл олдсонгүй.", tr.T("mn", "NOT_FOUND"))
+}
+
+func TestTranslator_UnknownLanguageFallsBackToEnglish(t *testing.T) {
+	tr := newTestTranslator(t)
+
+	assert.Equal(t, tr.T("en", "CONFLICT"), tr.T("fr", "CONFLICT"))
+	assert.Equal(t, tr.T("en", "CONFLICT"), tr.T("", "CONFLICT"))
+}
+
+func TestTranslator_KeyNotFound(t *testing.T) {
+	tr := newTestTranslator(t)
+
+	assert.Equal(t, "NO_SUCH_KEY", tr.T("mn", "NO_SUCH_KEY"))
+
+	msg, ok := tr.Lookup("mn", "NO_SUCH_KEY")
+	assert.False(t, ok)
+	assert.Empty(t, msg)
+}
+
+func TestTranslator_MissingKeyInLanguageFallsBackToEnglish(t *testing.T) {
+	tr := &Translator{messages: map[string]map[string]string{
+		"en": {"HELLO": "Hello %s"},
+		"mn": {},
+	}}
+
+	assert.Equal(t, "Hello Bat", tr.T("mn", "HELLO", "Bat"))
+}
+
+func TestPrimaryLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"*", ""},
+		{"mn", "mn"},
+		{"mn-MN", "mn"},
+		{"EN-us", "en"},
+		{"mn-MN,mn;q=0.9,en;q=0.8", "mn"},
+		{"en;q=0.5, mn;q=0.9", "mn"},
+		{"fr-CH, fr;q=0.9, en;q=0.8", "fr"},
+		{"mn;q=0, en", "en"},
+		{"mn;q=abc, en;q=0.1", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, PrimaryLanguage(tt.header))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Package jobs provides implementation for jobs
+//
+// File: cleanup.go
+// Description: Хугацаа дууссан session болон хуучин login history устгах background job
+/*
+Package jobs нь HTTP хүсэлтээс үл хамааран давтамжтай ажиллах background job-уудыг агуулна.
+
+CleanupJob нь sessions болон login_history хүснэгтүүдэд хуримтлагдсан
+хэрэгцээгүй мөрүүдийг тогтмол устгана:
+  - sessions: expires_at өнгөрсөн, эсвэл 30 хоногоос өмнө цуцлагдсан
+  - login_history: CLEANUP_LOGIN_HISTORY_RETENTION_DAYS хоногоос хуучин
+
+Жишээ:
+
+	job := jobs.NewCleanupJob(deps.Repo.Auth, cleanupCfg, logg)
+	job.Start()
+	...
+	<-quit
+	job.Stop()
+*/
+package jobs
+
+import (
+	"context" // Cancellation
+	"errors"  // errors.Join
+	"sync"    // Stop once
+	"time"    // Ticker
+
+	"templatev25/internal/config"     // CleanupConfig
+	"templatev25/internal/repository" // AuthRepository
+
+	"go.uber.org/zap" // Structured logging
+)
+
+// CleanupJob нь Interval тутам хугацаа дууссан session болон хуучин login history-г устгана
+type CleanupJob struct {
+	repo          repository.AuthRepository
+	interval      time.Duration
+	retentionDays int
+	log           *zap.Logger
+
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewCleanupJob нь шинэ CleanupJob үүсгэнэ (nil log бол log бичихгүй)
+func NewCleanupJob(repo repository.AuthRepository, cfg *config.CleanupConfig, log *zap.Logger) *CleanupJob {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return &CleanupJob{
+		repo:          repo,
+		interval:      cfg.Interval,
+		retentionDays: cfg.LoginHistoryRetentionDays,
+		log:           log.Named("cleanup"),
+	}
+}
+
+// Start нь job-ийг background goroutine-д эхлүүлнэ.
+// Эхлэхдээ шууд нэг удаа, дараа нь interval тутам ажиллана.
+func (j *CleanupJob) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	j.done = make(chan struct{})
+
+	go j.run(ctx)
+	j.log.Info("cleanup job started",
+		zap.Duration("interval", j.interval),
+		zap.Int("login_history_retention_days", j.retentionDays))
+}
+
+// Stop нь ажиллаж буй устгалтыг цуцлаад goroutine дуусахыг хүлээнэ.
+// Start дуудагдаагүй эсвэл олон удаа дуудсан ч аюулгүй.
+func (j *CleanupJob) Stop() {
+	j.stopOnce.Do(func() {
+		if j.cancel == nil {
+			return
+		}
+		j.cancel()
+		<-j.done
+		j.log.Info("cleanup job stopped")
+	})
+}
+
+// run нь ctx цуцлагдах хүртэл RunOnce-г давтана
+func (j *CleanupJob) run(ctx context.Context) {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		_ = j.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce нь нэг удаагийн устгалт хийж, устгасан мөрийн тоог log-д бичнэ.
+// Нэг алхам амжилтгүй болсон ч нөгөөг нь гүйцэтгэнэ.
+func (j *CleanupJob) RunOnce(ctx context.Context) error {
+	var errs []error
+
+	sessions, err := j.repo.DeleteExpiredSessions(ctx)
+	if err != nil {
+		j.log.Error("delete expired sessions failed", zap.Error(err))
+		errs = append(errs, err)
+	} else {
+		j.log.Info("expired sessions deleted", zap.Int64("deleted", sessions))
+	}
+
+	history, err := j.repo.DeleteOldLoginHistory(ctx, j.retentionDays)
+	if err != nil {
+		j.log.Error("delete old login history failed", zap.Error(err))
+		errs = append(errs, err)
+	} else {
+		j.log.Info("old login history deleted",
+			zap.Int64("deleted", history),
+			zap.Int("retention_days", j.retentionDays))
+	}
+
+	return errors.Join(errs...)
+}
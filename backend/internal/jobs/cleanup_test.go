@@ -0,0 +1,112 @@
+// Package jobs provides implementation for jobs
+//
+// File: cleanup_test.go
+// Description: Unit tests for CleanupJob
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"templatev25/internal/config"
+	"templatev25/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// mockAuthRepository нь зөвхөн cleanup-д хэрэгтэй методуудыг mock хийнэ
+type mockAuthRepository struct {
+	repository.AuthRepository
+	mock.Mock
+}
+
+func (m *mockAuthRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockAuthRepository) DeleteOldLoginHistory(ctx context.Context, retentionDays int) (int64, error) {
+	args := m.Called(ctx, retentionDays)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func newTestJob(repo *mockAuthRepository, interval time.Duration) (*CleanupJob, *observer.ObservedLogs) {
+	core, logs := observer.New(zap.InfoLevel)
+	cfg := &config.CleanupConfig{Interval: interval, LoginHistoryRetentionDays: 90}
+	return NewCleanupJob(repo, cfg, zap.New(core)), logs
+}
+
+func TestCleanupJob_RunOnce(t *testing.T) {
+	repo := &mockAuthRepository{}
+	repo.On("DeleteExpiredSessions", mock.Anything).Return(int64(3), nil)
+	repo.On("DeleteOldLoginHistory", mock.Anything, 90).Return(int64(12), nil)
+
+	job, logs := newTestJob(repo, time.Hour)
+	require.NoError(t, job.RunOnce(context.Background()))
+
+	repo.AssertExpectations(t)
+	sessions := logs.FilterMessage("expired sessions deleted").All()
+	require.Len(t, sessions, 1)
+	assert.Equal(t, int64(3), sessions[0].ContextMap()["deleted"])
+	history := logs.FilterMessage("old login history deleted").All()
+	require.Len(t, history, 1)
+	assert.Equal(t, int64(12), history[0].ContextMap()["deleted"])
+}
+
+func TestCleanupJob_RunOnce_ContinuesAfterError(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	repo := &mockAuthRepository{}
+	repo.On("DeleteExpiredSessions", mock.Anything).Return(int64(0), dbErr)
+	repo.On("DeleteOldLoginHistory", mock.Anything, 90).Return(int64(5), nil)
+
+	job, logs := newTestJob(repo, time.Hour)
+	err := job.RunOnce(context.Background())
+
+	assert.ErrorIs(t, err, dbErr)
+	repo.AssertCalled(t, "DeleteOldLoginHistory", mock.Anything, 90)
+	assert.Equal(t, 1, logs.FilterMessage("delete expired sessions failed").Len())
+}
+
+func TestCleanupJob_StartRunsOnTickerUntilStopped(t *testing.T) {
+	runs := make(chan struct{}, 10)
+	repo := &mockAuthRepository{}
+	repo.On("DeleteExpiredSessions", mock.Anything).Return(int64(0), nil)
+	repo.On("DeleteOldLoginHistory", mock.Anything, 90).
+		Run(func(mock.Arguments) { runs <- struct{}{} }).
+		Return(int64(0), nil)
+
+	job, _ := newTestJob(repo, 5*time.Millisecond)
+	job.Start()
+
+	// Эхний ажиллалт шууд, дараагийнх нь ticker-ээр
+	for i := 0; i < 2; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatal("cleanup did not run")
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		job.Stop()
+		job.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not return")
+	}
+}
+
+func TestCleanupJob_StopWithoutStart(t *testing.T) {
+	job, _ := newTestJob(&mockAuthRepository{}, time.Hour)
+	assert.NotPanics(t, job.Stop)
+}
@@ -0,0 +1,38 @@
+//go:build !sonic
+
+// Package json provides implementation for json
+//
+// File: json.go
+// Description: Build tag-аар сонгогддог JSON encoder/decoder (default: encoding/json)
+/*
+Package json нь Fiber-ийн JSONEncoder/JSONDecoder-т ашиглах JSON
+implementation-ийг build tag-аар сонгоно.
+
+  - Default (tag-гүй): стандарт encoding/json
+  - -tags sonic: github.com/bytedance/sonic (JIT/SIMD, encoding/json-той нийцтэй)
+
+Ашиглалт:
+
+	app := fiber.New(fiber.Config{
+	    JSONEncoder: json.Marshal,
+	    JSONDecoder: json.Unmarshal,
+	})
+
+	go build -tags sonic ./cmd/server   // эсвэл: make run-sonic
+*/
+package json
+
+import "encoding/json" // Стандарт JSON
+
+// Name нь сонгогдсон implementation-ийн нэр (startup log-д ашиглана)
+const Name = "encoding/json"
+
+// Marshal нь v-г JSON болгоно (encoding/json.Marshal)
+func Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal нь JSON data-г v руу decode хийнэ (encoding/json.Unmarshal)
+func Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,26 @@
+//go:build sonic
+
+// Package json provides implementation for json
+//
+// File: json_sonic.go
+// Description: sonic-д суурилсан JSON encoder/decoder (-tags sonic)
+package json
+
+import "github.com/bytedance/sonic" // Хурдан JSON
+
+// api нь encoding/json-той ижил үр дүн гаргах тохиргоо (map key эрэмбэлэх, HTML escape, UTF-8 шалгах).
+// Хариуны формат tag-аас хамаарч өөрчлөгдөхгүй байх ёстой.
+var api = sonic.ConfigStd
+
+// Name нь сонгогдсон implementation-ийн нэр (startup log-д ашиглана)
+const Name = "sonic"
+
+// Marshal нь v-г JSON болгоно (sonic.ConfigStd.Marshal)
+func Marshal(v any) ([]byte, error) {
+	return api.Marshal(v)
+}
+
+// Unmarshal нь JSON data-г v руу decode хийнэ (sonic.ConfigStd.Unmarshal)
+func Unmarshal(data []byte, v any) error {
+	return api.Unmarshal(data, v)
+}
@@ -0,0 +1,126 @@
+// Package json provides implementation for json
+//
+// File: json_test.go
+// Description: Parity tests and encoding/json vs sonic benchmarks
+package json
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"testing"
+
+	"templatev25/internal/domain"
+
+	"github.com/bytedance/sonic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// benchUsers нь n хэрэглэгчийн slice үүсгэнэ (benchmark/parity test-д)
+func benchUsers(n int) []domain.User {
+	users := make([]domain.User, n)
+	for i := range users {
+		users[i] = domain.User{
+			Id:         i + 1,
+			CivilId:    100000 + i,
+			RegNo:      fmt.Sprintf("АА%08d", i),
+			FamilyName: "Боржигин",
+			LastName:   "Бат",
+			FirstName:  fmt.Sprintf("Болд %d", i),
+			Gender:     i%2 + 1,
+			BirthDate:  "1990-01-15",
+			PhoneNo:    "99119911",
+			Email:      fmt.Sprintf("user%d@example.com", i),
+			Status:     "active",
+			LoginCount: i,
+		}
+	}
+	return users
+}
+
+// TestMarshal_MatchesStdlib нь сонгогдсон codec (tag-аас хамааран) encoding/json-той
+// ижил хариу гаргаж байгааг шалгана
+func TestMarshal_MatchesStdlib(t *testing.T) {
+	t.Logf("codec: %s", Name)
+
+	values := []any{
+		benchUsers(3),
+		map[string]any{"b": 1, "a": "<script>&</script>", "c": []int{1, 2}},
+		struct {
+			Code string `json:"code"`
+			Data any    `json:"data,omitempty"`
+		}{Code: "OK"},
+	}
+	for _, v := range values {
+		want, err := stdjson.Marshal(v)
+		require.NoError(t, err)
+		got, err := Marshal(v)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got))
+	}
+}
+
+func TestUnmarshal_RoundTrip(t *testing.T) {
+	users := benchUsers(10)
+	data, err := Marshal(users)
+	require.NoError(t, err)
+
+	var out []domain.User
+	require.NoError(t, Unmarshal(data, &out))
+	assert.Equal(t, users, out)
+
+	assert.Error(t, Unmarshal([]byte(`{"id":`), &domain.User{}))
+}
+
+// =============================================================================
+// BENCHMARKS (go test -bench . -benchmem ./internal/json/)
+// =============================================================================
+
+func BenchmarkMarshalUsers(b *testing.B) {
+	users := benchUsers(1000)
+	impls := []struct {
+		name    string
+		marshal func(any) ([]byte, error)
+	}{
+		{"encoding-json", stdjson.Marshal},
+		{"sonic", sonic.ConfigStd.Marshal},
+	}
+	for _, impl := range impls {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				data, err := impl.marshal(users)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.SetBytes(int64(len(data)))
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshalUsers(b *testing.B) {
+	data, err := stdjson.Marshal(benchUsers(1000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	impls := []struct {
+		name      string
+		unmarshal func([]byte, any) error
+	}{
+		{"encoding-json", stdjson.Unmarshal},
+		{"sonic", sonic.ConfigStd.Unmarshal},
+	}
+	for _, impl := range impls {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				var out []domain.User
+				if err := impl.unmarshal(data, &out); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
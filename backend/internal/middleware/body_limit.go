@@ -0,0 +1,79 @@
+// Package middleware provides implementation for middleware
+//
+// File: body_limit.go
+// Description: JSON 413 response when the request body exceeds fiber.Config.BodyLimit
+package middleware
+
+import (
+	"errors" // Error type checking
+	"fmt"    // Size formatting
+
+	"git.gerege.mn/backend-packages/ctx"  // Request ID helper
+	"git.gerege.mn/backend-packages/resp" // Response struct
+
+	"github.com/gofiber/fiber/v2" // Web framework
+	"github.com/valyala/fasthttp" // ErrBodyTooLarge
+)
+
+// BodySizeErrorHandler нь body хэмжээ хэтэрсэн алдааг тодорхой JSON
+// 413 response болгож, бусад алдааг next руу дамжуулна.
+//
+// Body limit-ийг fasthttp request уншихдаа шалгадаг тул handler, middleware
+// ажиллахаас өмнө алдаа гарна. Энэ wrapper байхгүй бол клиент зөвхөн
+// "Request Entity Too Large" гэсэн ерөнхий мессеж авна.
+//
+// Parameters:
+//   - limit: fiber.Config.BodyLimit-д өгсөн хязгаар (byte), мессежид харуулна
+//   - next: Үндсэн error handler (ErrorHandler)
+//
+// Ашиглалт:
+//
+//	app := fiber.New(fiber.Config{
+//	    BodyLimit:    int(srvCfg.MaxBodySize),
+//	    ErrorHandler: middleware.BodySizeErrorHandler(srvCfg.MaxBodySize, middleware.ErrorHandler(log)),
+//	})
+//
+// Response:
+//
+//	{
+//	    "code": "PAYLOAD_TOO_LARGE",
+//	    "request_id": "",
+//	    "message": "request body exceeds the 4MB limit"
+//	}
+func BodySizeErrorHandler(limit int64, next fiber.ErrorHandler) fiber.ErrorHandler {
+	msg := fmt.Sprintf("request body exceeds the %s limit", formatByteSize(limit))
+
+	return func(c *fiber.Ctx, err error) error {
+		if !isBodyTooLarge(err) {
+			return next(c, err)
+		}
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(resp.APIResponse{
+			Code:      httpStatusToCode(fiber.StatusRequestEntityTooLarge),
+			RequestID: ctx.RequestID(c),
+			Message:   msg,
+		})
+	}
+}
+
+// isBodyTooLarge нь fasthttp-ийн body limit алдаа эсвэл 413 fiber.Error эсэхийг шалгана
+func isBodyTooLarge(err error) bool {
+	if errors.Is(err, fasthttp.ErrBodyTooLarge) {
+		return true
+	}
+	var e *fiber.Error
+	return errors.As(err, &e) && e.Code == fiber.StatusRequestEntityTooLarge
+}
+
+// formatByteSize нь byte тоог "4MB", "512KB" гэх мэт уншигдахуйц болгоно
+func formatByteSize(n int64) string {
+	switch {
+	case n >= 1<<30 && n%(1<<30) == 0:
+		return fmt.Sprintf("%dGB", n>>30)
+	case n >= 1<<20 && n%(1<<20) == 0:
+		return fmt.Sprintf("%dMB", n>>20)
+	case n >= 1<<10 && n%(1<<10) == 0:
+		return fmt.Sprintf("%dKB", n>>10)
+	default:
+		return fmt.Sprintf("%dB", n)
+	}
+}
@@ -0,0 +1,93 @@
+// Package middleware provides HTTP middlewares
+//
+// File: body_limit_test.go
+// Description: Unit tests for BodySizeErrorHandler
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newBodyLimitApp(limit int64) *fiber.App {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+		BodyLimit:             int(limit),
+		ErrorHandler:          BodySizeErrorHandler(limit, ErrorHandler(zap.NewNop())),
+	})
+	app.Post("/news", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	app.Get("/boom", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusBadRequest, "bad")
+	})
+	return app
+}
+
+func TestBodySizeErrorHandler_LargePayload(t *testing.T) {
+	const limit = 1 << 10 // 1KB
+	app := newBodyLimitApp(limit)
+
+	// app.Test нь body limit алдааг response-ийн оронд буцаадаг тул жинхэнэ listener ашиглана
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	// base64 зураг гэх мэт том JSON body
+	payload := `{"image":"` + string(bytes.Repeat([]byte("A"), 4*limit)) + `"}`
+	resp, err := http.Post("http://"+ln.Addr().String()+"/news", "application/json", bytes.NewBufferString(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "application/json")
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "PAYLOAD_TOO_LARGE", body["code"])
+	assert.Equal(t, "request body exceeds the 1KB limit", body["message"])
+}
+
+func TestBodySizeErrorHandler_WithinLimit(t *testing.T) {
+	app := newBodyLimitApp(1 << 10)
+
+	req := httptest.NewRequest("POST", "/news", bytes.NewBufferString(`{"title":"hello"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestBodySizeErrorHandler_PassesOtherErrors(t *testing.T) {
+	app := newBodyLimitApp(1 << 10)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/boom", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "BAD_REQUEST", body["code"])
+	assert.Equal(t, "bad", body["message"])
+}
+
+func TestFormatByteSize(t *testing.T) {
+	assert.Equal(t, "4MB", formatByteSize(4<<20))
+	assert.Equal(t, "512KB", formatByteSize(512<<10))
+	assert.Equal(t, "2GB", formatByteSize(2<<30))
+	assert.Equal(t, "1500B", formatByteSize(1500))
+}
@@ -0,0 +1,64 @@
+// Package middleware provides implementation for middleware
+//
+// File: cors.go
+// Description: Configurable CORS policy with per-route override
+package middleware
+
+import (
+	localconfig "templatev25/internal/config" // CORS methods/headers/max-age
+	"templatev25/internal/http/locals"        // Typed c.Locals access
+
+	"git.gerege.mn/backend-packages/config" // Shared config (cfg.CORS)
+
+	"github.com/gofiber/fiber/v2"                 // Web framework
+	"github.com/gofiber/fiber/v2/middleware/cors" // CORS implementation
+)
+
+// localsCORSOverride нь route-д тусгай CORS policy хэрэглэгдсэнийг тэмдэглэнэ.
+// Global CORS энэ утгыг харвал алгасна.
+const localsCORSOverride = "cors_override"
+
+// CORS нь application даяарх CORS policy-г буцаана.
+//
+// AllowOrigins, AllowCredentials нь shared config-оос (cfg.CORS),
+// AllowMethods, AllowHeaders, ExposeHeaders, MaxAge нь cc-ээс
+// (CORS_ALLOW_METHODS, CORS_ALLOW_HEADERS, CORS_EXPOSE_HEADERS, CORS_MAX_AGE).
+//
+// WithCORS хэрэглэгдсэн route-уудыг алгасна.
+func CORS(cfg *config.Config, cc *localconfig.CORSConfig) fiber.Handler {
+	return cors.New(corsConfig(cc, cfg.CORS.AllowOrigins, cfg.CORS.AllowCredentials, func(c *fiber.Ctx) bool {
+		return locals.GetOrDefault(c, localsCORSOverride, false)
+	}))
+}
+
+// WithCORS нь тухайн route group-д зориулсан CORS policy-г буцаана.
+// Methods, headers, max-age нь global policy-тэй ижил, origin нь өөр бөгөөд
+// credential (cookie) зөвшөөрөхгүй — Bearer token-оор ажилладаг route-уудад.
+//
+// Preflight (OPTIONS)-ийг global CORS хариулахаас өмнө барих ёстой тул
+// global CORS-оос ӨМНӨ prefix-ээр бүртгэнэ.
+//
+// Ашиглалт:
+//
+//	app.Use("/auth/local", middleware.WithCORS("https://app.example.com", cc))
+//	app.Use(middleware.CORS(cfg, cc))
+func WithCORS(origins string, cc *localconfig.CORSConfig) fiber.Handler {
+	h := cors.New(corsConfig(cc, origins, false, nil))
+	return func(c *fiber.Ctx) error {
+		c.Locals(localsCORSOverride, true)
+		return h(c)
+	}
+}
+
+// corsConfig нь env тохиргоо болон origin-оос cors.Config үүсгэнэ
+func corsConfig(cc *localconfig.CORSConfig, origins string, credentials bool, next func(*fiber.Ctx) bool) cors.Config {
+	return cors.Config{
+		Next:             next,
+		AllowOrigins:     origins,
+		AllowMethods:     cc.AllowMethods,
+		AllowHeaders:     cc.AllowHeaders,
+		ExposeHeaders:    cc.ExposeHeaders,
+		AllowCredentials: credentials,
+		MaxAge:           int(cc.MaxAge.Seconds()),
+	}
+}
@@ -0,0 +1,90 @@
+// Package middleware provides HTTP middlewares
+//
+// File: cors_test.go
+// Description: Unit tests for the CORS policy and per-route override
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	localconfig "templatev25/internal/config"
+
+	"git.gerege.mn/backend-packages/config"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCORSApp(t *testing.T) *fiber.App {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.CORS.AllowOrigins = "https://app.example.com,https://partner.example.com"
+	cfg.CORS.AllowCredentials = true
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	cc := localconfig.LoadCORSConfig()
+	app.Use("/auth/local", WithCORS("https://app.example.com", cc))
+	app.Use(CORS(cfg, cc))
+	app.Get("/news", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Post("/auth/local/login", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	return app
+}
+
+func doCORS(t *testing.T, app *fiber.App, method, path, origin string) (int, string, string) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(fiber.HeaderOrigin, origin)
+	if method == fiber.MethodOptions {
+		req.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodPost)
+	}
+	res, err := app.Test(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	return res.StatusCode, res.Header.Get(fiber.HeaderAccessControlAllowOrigin), res.Header.Get(fiber.HeaderAccessControlAllowCredentials)
+}
+
+func TestCORS_GlobalPolicy(t *testing.T) {
+	app := newCORSApp(t)
+
+	_, origin, creds := doCORS(t, app, fiber.MethodGet, "/news", "https://partner.example.com")
+	assert.Equal(t, "https://partner.example.com", origin)
+	assert.Equal(t, "true", creds)
+
+	_, origin, _ = doCORS(t, app, fiber.MethodGet, "/news", "https://evil.example.com")
+	assert.Empty(t, origin)
+}
+
+func TestCORS_AuthLocalOverride(t *testing.T) {
+	app := newCORSApp(t)
+
+	_, origin, creds := doCORS(t, app, fiber.MethodPost, "/auth/local/login", "https://app.example.com")
+	assert.Equal(t, "https://app.example.com", origin)
+	assert.Empty(t, creds, "override must not allow credentials")
+
+	// Global policy-д зөвшөөрөгдсөн ч local auth-д зөвшөөрөгдөөгүй origin
+	_, origin, _ = doCORS(t, app, fiber.MethodPost, "/auth/local/login", "https://partner.example.com")
+	assert.Empty(t, origin)
+
+	status, origin, _ := doCORS(t, app, fiber.MethodOptions, "/auth/local/login", "https://partner.example.com")
+	assert.Equal(t, fiber.StatusNoContent, status)
+	assert.Empty(t, origin)
+}
+
+func TestCORS_EnvSettings(t *testing.T) {
+	t.Setenv("CORS_ALLOW_METHODS", "GET,POST")
+	t.Setenv("CORS_MAX_AGE", "10m")
+	app := newCORSApp(t)
+
+	req := httptest.NewRequest(fiber.MethodOptions, "/news", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://app.example.com")
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodGet)
+	res, err := app.Test(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, "https://app.example.com", res.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "GET,POST", res.Header.Get(fiber.HeaderAccessControlAllowMethods))
+	assert.Equal(t, "600", res.Header.Get(fiber.HeaderAccessControlMaxAge))
+}
@@ -23,6 +23,12 @@ type CSRFConfig struct {
 	Expiration time.Duration
 	// SkipPaths are paths that skip CSRF validation (e.g., webhooks)
 	SkipPaths []string
+	// SignedPaths are POST paths that internal services call with an HMAC signature
+	// (X-Signature/X-Timestamp). CSRF is skipped only when InternalSecret is set and
+	// the signature verifies.
+	SignedPaths []string
+	// InternalSecret is INTERNAL_API_SECRET; empty disables the SignedPaths exemption
+	InternalSecret string
 }
 
 // DefaultCSRFConfig returns production-ready CSRF settings
@@ -39,6 +45,9 @@ func DefaultCSRFConfig(isProduction bool) CSRFConfig {
 			"/auth/callback", // OAuth callbacks
 			"/auth/local/",   // Local auth API (stateless)
 		},
+		SignedPaths: []string{
+			"/user/sync", // Internal SSO sync (HMAC signed)
+		},
 	}
 }
 
@@ -69,6 +78,10 @@ func CSRF(cfg CSRFConfig) fiber.Handler {
 	for _, p := range cfg.SkipPaths {
 		skipMap[p] = true
 	}
+	signedMap := make(map[string]bool, len(cfg.SignedPaths))
+	for _, p := range cfg.SignedPaths {
+		signedMap[p] = true
+	}
 
 	return csrf.New(csrf.Config{
 		KeyLookup:      "header:X-CSRF-Token",
@@ -85,6 +98,12 @@ func CSRF(cfg CSRFConfig) fiber.Handler {
 			if method == fiber.MethodGet || method == fiber.MethodHead || method == fiber.MethodOptions {
 				return true
 			}
+			// Signed internal calls are authenticated by HMAC, not cookies. The
+			// signature is verified here so the header alone never disables CSRF.
+			if cfg.InternalSecret != "" && method == fiber.MethodPost && signedMap[c.Path()] &&
+				c.Get(HeaderSignature) != "" && VerifyHMACSignature(c, cfg.InternalSecret) == nil {
+				return true
+			}
 			// Skip configured paths
 			path := c.Path()
 			if skipMap[path] {
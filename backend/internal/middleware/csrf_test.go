@@ -0,0 +1,54 @@
+// Package middleware provides HTTP middlewares
+//
+// File: csrf_test.go
+// Description: Unit tests for the CSRF signed-path exemption
+package middleware
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSRF_SignedPathExemption(t *testing.T) {
+	const secret = "0123456789abcdef0123456789abcdef"
+	body := `{"id":1}`
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		path      string
+		signature string
+		want      int
+	}{
+		{name: "valid signature on /user/sync", secret: secret, path: "/user/sync", signature: SignRequest(secret, "POST", "/user/sync", ts, []byte(body)), want: fiber.StatusNoContent},
+		{name: "invalid signature", secret: secret, path: "/user/sync", signature: "deadbeef", want: fiber.StatusForbidden},
+		{name: "secret not configured", secret: "", path: "/user/sync", signature: SignRequest(secret, "POST", "/user/sync", ts, []byte(body)), want: fiber.StatusForbidden},
+		{name: "valid signature on another path", secret: secret, path: "/role", signature: SignRequest(secret, "POST", "/role", ts, []byte(body)), want: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultCSRFConfig(false)
+			cfg.InternalSecret = tt.secret
+
+			app := fiber.New()
+			app.Use(CSRF(cfg))
+			app.Post("/*", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+
+			req := httptest.NewRequest("POST", tt.path, strings.NewReader(body))
+			req.Header.Set(HeaderSignature, tt.signature)
+			req.Header.Set(HeaderTimestamp, ts)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
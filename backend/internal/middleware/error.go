@@ -15,9 +15,11 @@ Package middleware нь HTTP middleware-уудыг агуулна.
 Error handling flow:
  1. Handler error буцаана (return err)
  2. ErrorHandler барьж авна
- 3. Error code, message тодорхойлно
+ 3. Error code, message тодорхойлно (fiber.Error эсвэл domain error)
  4. Log бичнэ
- 5. JSON response буцаана
+ 5. Translator өгөгдсөн ба Accept-Language header ирсэн бол message-ийг
+    error code-оор (NOT_FOUND гэх мэт) орчуулна. Орчуулга байхгүй бол raw message
+ 6. JSON response буцаана
 
 Response format:
 
@@ -32,6 +34,9 @@ package middleware
 import (
 	"errors" // Error type checking
 
+	"templatev25/internal/domain" // Domain errors
+	"templatev25/internal/i18n"   // Error message translations
+
 	"git.gerege.mn/backend-packages/ctx"  // Request ID helper
 	"git.gerege.mn/backend-packages/resp" // Response struct
 
@@ -48,6 +53,7 @@ import (
 //
 // Parameters:
 //   - log: Zap logger
+//   - tr: (optional) Translator; өгөөгүй бол message орчуулагдахгүй
 //
 // Returns:
 //   - fiber.ErrorHandler: Error handler function
@@ -55,7 +61,7 @@ import (
 // Ашиглалт:
 //
 //	app := fiber.New(fiber.Config{
-//	    ErrorHandler: middleware.ErrorHandler(log),
+//	    ErrorHandler: middleware.ErrorHandler(log, translator),
 //	})
 //
 // Log format:
@@ -71,7 +77,12 @@ import (
 //	    "req_id": "uuid",
 //	    "user_id": 123
 //	}
-func ErrorHandler(log *zap.Logger) fiber.ErrorHandler {
+func ErrorHandler(log *zap.Logger, tr ...*i18n.Translator) fiber.ErrorHandler {
+	var translator *i18n.Translator
+	if len(tr) > 0 {
+		translator = tr[0]
+	}
+
 	return func(c *fiber.Ctx, err error) error {
 		// Default values (500 Internal Server Error)
 		code := fiber.StatusInternalServerError
@@ -86,6 +97,14 @@ func ErrorHandler(log *zap.Logger) fiber.ErrorHandler {
 		if errors.As(err, &e) {
 			code = e.Code
 			msg = e.Message
+		} else if status, ok := domainErrorStatus(err); ok {
+			// Domain error (domain.ErrNotFound гэх мэт) → HTTP status
+			code = status
+			msg = err.Error()
+			var de *domain.Error
+			if errors.As(err, &de) && de.Message != "" {
+				msg = de.Message
+			}
 		}
 
 		// ============================================================
@@ -115,16 +134,63 @@ func ErrorHandler(log *zap.Logger) fiber.ErrorHandler {
 		)
 
 		// ============================================================
-		// STEP 4: JSON response буцаах
+		// STEP 4: Message орчуулах
+		// ============================================================
+		// Header-гүй client-ууд өмнөх шигээ raw message авна.
+		// Дэмжигдээгүй хэл → English (i18n.DefaultLanguage)
+		apiCode := httpStatusToCode(code)
+		if translator != nil {
+			if lang := i18n.PrimaryLanguage(c.Get(fiber.HeaderAcceptLanguage)); lang != "" {
+				if translated, ok := translator.Lookup(lang, apiCode); ok {
+					msg = translated
+				}
+			}
+		}
+
+		// ============================================================
+		// STEP 5: JSON response буцаах
 		// ============================================================
 		return c.Status(code).JSON(resp.APIResponse{
-			Code:      httpStatusToCode(code),
+			Code:      apiCode,
 			RequestID: reqID,
 			Message:   msg,
 		})
 	}
 }
 
+// ============================================================
+// DOMAIN ERROR TO STATUS
+// ============================================================
+
+// domainErrorStatus нь domain алдааг HTTP status code руу хөрвүүлнэ.
+//
+// Mapping:
+//   - domain.ErrInvalidInput → 400
+//   - domain.ErrForbidden    → 403
+//   - domain.ErrNotFound     → 404
+//   - domain.ErrConflict     → 409
+//   - domain.ErrExpired      → 410
+//
+// Returns:
+//   - int: HTTP status code
+//   - bool: Domain алдаа мөн эсэх
+func domainErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		return fiber.StatusBadRequest, true
+	case errors.Is(err, domain.ErrForbidden):
+		return fiber.StatusForbidden, true
+	case errors.Is(err, domain.ErrNotFound):
+		return fiber.StatusNotFound, true
+	case errors.Is(err, domain.ErrConflict):
+		return fiber.StatusConflict, true
+	case errors.Is(err, domain.ErrExpired):
+		return fiber.StatusGone, true
+	default:
+		return 0, false
+	}
+}
+
 // ============================================================
 // HTTP STATUS TO CODE
 // ============================================================
@@ -145,6 +211,7 @@ func ErrorHandler(log *zap.Logger) fiber.ErrorHandler {
 //   - 405 → METHOD_NOT_ALLOWED
 //   - 408 → REQUEST_TIMEOUT
 //   - 409 → CONFLICT
+//   - 410 → GONE
 //   - 413 → PAYLOAD_TOO_LARGE
 //   - 422 → VALIDATION_ERROR
 //   - 429 → TOO_MANY_REQUESTS
@@ -169,6 +236,8 @@ func httpStatusToCode(status int) string {
 		return "REQUEST_TIMEOUT"
 	case 409:
 		return "CONFLICT"
+	case 410:
+		return "GONE"
 	case 413:
 		return "PAYLOAD_TOO_LARGE"
 	case 422:
@@ -0,0 +1,129 @@
+// Package middleware provides implementation for middleware
+//
+// File: error_handler.go
+// Description: Error handler wrapper that counts 4xx/5xx responses (http_errors_total)
+/*
+ErrorMetrics нь status 400-аас их response бүрийг http.errors counter-т
+тоолно (Prometheus дээр http_errors_total).
+
+  - Handler: middleware — c.Next()-ийн дараа эцсийн status-аар тоолно. Handler
+    алдаа буцаагаагүй ч c.Status(400).JSON(...) гэж шууд бичсэн хариу тоологдоно.
+  - WrapErrorHandler: fiber.ErrorHandler-ийг ороож, middleware chain-д
+    ороогүй (body limit гэх мэт routing-оос өмнөх) алдааг тоолно. Middleware
+    тоолсон request-ийг дахин тоолохгүй.
+
+Labels:
+  - status: "4xx" эсвэл "5xx" (status class)
+  - route:  route template (/user/:id). Route таараагүй (404) болон routing-оос
+    өмнө гарсан (body limit гэх мэт) алдаа "unmatched" болно — бүтэн path
+    ашиглавал cardinality хязгааргүй өснө.
+
+Ашиглалт:
+
+	errorMetrics, err := middleware.NewErrorMetrics(provider.Meter("templatev25/http"))
+	if err != nil {
+	    logg.Fatal("error metrics init failed", zap.Error(err))
+	}
+	app := fiber.New(fiber.Config{
+	    ErrorHandler: errorMetrics.WrapErrorHandler(
+	        middleware.BodySizeErrorHandler(srvCfg.MaxBodySize, middleware.ErrorHandler(logg)),
+	    ),
+	})
+	app.Use(errorMetrics.Handler())
+*/
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// unmatchedRoute нь route template тодорхойгүй үеийн route label
+const unmatchedRoute = "unmatched"
+
+// localsErrorCounted нь request-ийг ErrorMetrics.Handler тоолохыг тэмдэглэнэ
+const localsErrorCounted = "error_metrics_counted"
+
+// ErrorMetrics counts every response with status >= 400 as
+// http.errors{status, route} (http_errors_total in Prometheus).
+type ErrorMetrics struct {
+	counter metric.Int64Counter
+}
+
+// NewErrorMetrics registers the http.errors counter on meter
+func NewErrorMetrics(meter metric.Meter) (*ErrorMetrics, error) {
+	counter, err := meter.Int64Counter("http.errors",
+		metric.WithDescription("HTTP error responses by status class and route template"))
+	if err != nil {
+		return nil, err
+	}
+	return &ErrorMetrics{counter: counter}, nil
+}
+
+// Handler нь эцсийн response status-аар тоолох middleware.
+// Handler алдаа буцаасан бол ErrorHandler хараахан ажиллаагүй тул
+// status-ийг responseStatus-аар тооцно.
+func (m *ErrorMetrics) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(localsErrorCounted, true)
+		err := c.Next()
+		m.record(c, responseStatus(c, err), err)
+		return err
+	}
+}
+
+// WrapErrorHandler wraps next so that errors which never reached Handler
+// (rejected before routing, e.g. body too large) are still counted.
+func (m *ErrorMetrics) WrapErrorHandler(next fiber.ErrorHandler) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		handlerErr := next(c, err)
+		if counted, _ := c.Locals(localsErrorCounted).(bool); !counted {
+			m.record(c, c.Response().StatusCode(), err)
+		}
+		return handlerErr
+	}
+}
+
+// record нь status >= 400 бол counter-ийг нэмэгдүүлнэ
+func (m *ErrorMetrics) record(c *fiber.Ctx, status int, err error) {
+	if status < fiber.StatusBadRequest {
+		return
+	}
+	m.counter.Add(c.UserContext(), 1, metric.WithAttributes(
+		attribute.String("status", statusClass(status)),
+		attribute.String("route", routeTemplate(c, err)),
+	))
+}
+
+// statusClass нь 204 → "2xx", 404 → "4xx", 503 → "5xx"
+func statusClass(status int) string {
+	switch {
+	case status >= fiber.StatusInternalServerError:
+		return "5xx"
+	case status >= 100:
+		return strconv.Itoa(status/100) + "xx"
+	default:
+		return "unknown"
+	}
+}
+
+// routeTemplate нь таарсан route-ийн template-ийг буцаана.
+// Handler-гүй fallback route (routing-оос өмнөх алдаа) болон Fiber-ийн
+// "Cannot GET /path" 404 (зөвхөн middleware таарсан) → "unmatched".
+func routeTemplate(c *fiber.Ctx, err error) string {
+	route := c.Route()
+	if len(route.Handlers) == 0 || route.Path == "" {
+		return unmatchedRoute
+	}
+	var fe *fiber.Error
+	if errors.As(err, &fe) && fe.Code == fiber.StatusNotFound &&
+		strings.HasPrefix(fe.Message, "Cannot "+c.Method()+" ") {
+		return unmatchedRoute
+	}
+	return route.Path
+}
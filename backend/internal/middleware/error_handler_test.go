@@ -0,0 +1,156 @@
+// Package middleware provides HTTP middlewares
+//
+// File: error_handler_test.go
+// Description: Unit tests for the http_errors_total middleware and error handler wrapper
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+)
+
+// newErrorMetricsApp нь ErrorMetrics-тэй Fiber app болон manual reader буцаана
+func newErrorMetricsApp(t *testing.T) (*fiber.App, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	m, err := NewErrorMetrics(mp.Meter("test"))
+	require.NoError(t, err)
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: m.WrapErrorHandler(BodySizeErrorHandler(16, ErrorHandler(zap.NewNop()))),
+		BodyLimit:    16,
+	})
+	app.Use(m.Handler())
+	app.Get("/user/:id", func(c *fiber.Ctx) error {
+		switch c.Params("id") {
+		case "missing":
+			return fiber.NewError(fiber.StatusNotFound, "user not found")
+		case "gone":
+			return domain.ErrNotFound
+		case "invalid":
+			// ErrorHandler-ийг дамжихгүй, шууд бичсэн хариу
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid"})
+		case "boom":
+			return errors.New("boom")
+		}
+		return c.SendString("ok")
+	})
+	return app, reader
+}
+
+// errorCounts нь http.errors counter-ийн "status route" → утга
+func errorCounts(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	out := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if m.Name != "http.errors" || !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				status, _ := dp.Attributes.Value(attribute.Key("status"))
+				route, _ := dp.Attributes.Value(attribute.Key("route"))
+				out[status.AsString()+" "+route.AsString()] = dp.Value
+			}
+		}
+	}
+	return out
+}
+
+func TestErrorMetrics_CountsByStatusClassAndRouteTemplate(t *testing.T) {
+	app, reader := newErrorMetricsApp(t)
+
+	for _, path := range []string{"/user/missing", "/user/missing", "/user/boom", "/user/1"} {
+		res, err := app.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+	}
+
+	assert.Equal(t, map[string]int64{
+		"4xx /user/:id": 2,
+		"5xx /user/:id": 1,
+	}, errorCounts(t, reader))
+}
+
+func TestErrorMetrics_CountsResponsesWrittenByHandler(t *testing.T) {
+	app, reader := newErrorMetricsApp(t)
+
+	for path, want := range map[string]int{
+		"/user/invalid": fiber.StatusBadRequest,
+		"/user/gone":    fiber.StatusNotFound,
+	} {
+		res, err := app.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+		assert.Equal(t, want, res.StatusCode)
+		_ = res.Body.Close()
+	}
+
+	assert.Equal(t, map[string]int64{"4xx /user/:id": 2}, errorCounts(t, reader))
+}
+
+func TestErrorMetrics_UnmatchedRoute(t *testing.T) {
+	app, reader := newErrorMetricsApp(t)
+
+	for _, path := range []string{"/nope/1", "/nope/2"} {
+		res, err := app.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+		_ = res.Body.Close()
+	}
+
+	assert.Equal(t, map[string]int64{"4xx unmatched": 2}, errorCounts(t, reader))
+}
+
+func TestErrorMetrics_BodyTooLarge(t *testing.T) {
+	app, reader := newErrorMetricsApp(t)
+
+	// fasthttp body limit алдаа нь routing-оос өмнө ErrorHandler-т ирдэг (route тодорхойгүй).
+	// app.Test энэ алдааг response болгодоггүй тул handler-ийг шууд дуудна.
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+	require.NoError(t, app.Config().ErrorHandler(c, fasthttp.ErrBodyTooLarge))
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, c.Response().StatusCode())
+
+	assert.Equal(t, map[string]int64{"4xx unmatched": 1}, errorCounts(t, reader))
+}
+
+func TestErrorMetrics_SuccessNotCounted(t *testing.T) {
+	app, reader := newErrorMetricsApp(t)
+
+	res, err := app.Test(httptest.NewRequest("GET", "/user/1", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	_ = res.Body.Close()
+
+	assert.Empty(t, errorCounts(t, reader))
+}
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", statusClass(200))
+	assert.Equal(t, "2xx", statusClass(204))
+	assert.Equal(t, "3xx", statusClass(304))
+	assert.Equal(t, "4xx", statusClass(400))
+	assert.Equal(t, "4xx", statusClass(499))
+	assert.Equal(t, "5xx", statusClass(500))
+	assert.Equal(t, "5xx", statusClass(503))
+	assert.Equal(t, "unknown", statusClass(0))
+}
@@ -0,0 +1,106 @@
+// Package middleware provides HTTP middlewares
+//
+// File: error_test.go
+// Description: Unit tests for ErrorHandler domain error mapping and Accept-Language translation
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/i18n"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestErrorHandler_DomainErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantMsg    string
+	}{
+		{"not found sentinel", domain.ErrNotFound, 404, "NOT_FOUND", "not found"},
+		{"not found with message", domain.NewNotFound("user not found", nil), 404, "NOT_FOUND", "user not found"},
+		{"wrapped gorm not found", domain.WrapNotFound(gorm.ErrRecordNotFound, "role not found"), 404, "NOT_FOUND", "role not found"},
+		{"conflict", domain.NewConflict("email already exists", nil), 409, "CONFLICT", "email already exists"},
+		{"forbidden", domain.NewForbidden("not a member", nil), 403, "FORBIDDEN", "not a member"},
+		{"expired", domain.NewExpired("verification code expired", nil), 410, "GONE", "verification code expired"},
+		{"invalid input", domain.NewInvalidInput("bad id", errors.New("parse error")), 400, "BAD_REQUEST", "bad id"},
+		{"fmt wrapped domain error", fmt.Errorf("service: %w", domain.ErrConflict), 409, "CONFLICT", "service: conflict"},
+		{"fiber error takes precedence", fiber.NewError(fiber.StatusTeapot, "teapot"), 418, "CLIENT_ERROR", "teapot"},
+		{"unknown error", errors.New("boom"), 500, "INTERNAL_ERROR", "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+			app.Get("/test", func(c *fiber.Ctx) error {
+				return tt.err
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/test", nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.wantCode, body["code"])
+			assert.Equal(t, tt.wantMsg, body["message"])
+		})
+	}
+}
+
+func TestDomainErrorStatus_NonDomainError(t *testing.T) {
+	_, ok := domainErrorStatus(errors.New("plain"))
+	assert.False(t, ok)
+}
+
+func TestErrorHandler_AcceptLanguage(t *testing.T) {
+	tr, err := i18n.New()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		handler fiber.ErrorHandler
+		lang    string
+		err     error
+		wantMsg string
+	}{
+		{"mongolian", ErrorHandler(zap.NewNop(), tr), "mn-MN,en;q=0.8", domain.NewNotFound("user not found", nil), "Хайсан өгөгдөл олдсонгүй."},
+		{"english", ErrorHandler(zap.NewNop(), tr), "en-US", domain.NewConflict("email already exists", nil), "The resource already exists or is in conflict."},
+		{"unknown language falls back to english", ErrorHandler(zap.NewNop(), tr), "fr", domain.ErrNotFound, "The requested resource was not found."},
+		{"no header keeps raw message", ErrorHandler(zap.NewNop(), tr), "", domain.NewNotFound("user not found", nil), "user not found"},
+		{"no translator keeps raw message", ErrorHandler(zap.NewNop()), "mn", domain.NewNotFound("user not found", nil), "user not found"},
+		{"unmapped status uses CLIENT_ERROR", ErrorHandler(zap.NewNop(), tr), "mn", fiber.NewError(fiber.StatusTeapot, "teapot"), "Хүсэлтийг боловсруулах боломжгүй."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: tt.handler})
+			app.Get("/test", func(c *fiber.Ctx) error {
+				return tt.err
+			})
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.lang != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tt.lang)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.wantMsg, body["message"])
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package middleware provides HTTP middlewares
+//
+// File: feature_gate.go
+// Description: Feature flag gate that hides optional endpoints while their flag is inactive
+/*
+Package middleware нь HTTP middleware-уудыг агуулна.
+
+Энэ файл нь feature_flags хүснэгтийн flag идэвхгүй үед endpoint-ийг
+огт байхгүй мэт (404) харагдуулах middleware-ийг тодорхойлно.
+Flag-ийг DB-ээс асааж/унтраахад deploy шаардлагагүй.
+
+Ашиглалт:
+
+	router.Get("/search", middleware.FeatureGate("user_search", d.Repo.FeatureFlag), handler.Search)
+*/
+package middleware
+
+import (
+	"errors" // Not found шалгах
+	"html"   // Fiber-ийн 404 message-тэй ижил escape
+	"time"   // Хугацааны хязгаар
+
+	"templatev25/internal/domain"     // FeatureFlag, ErrNotFound
+	"templatev25/internal/repository" // FeatureFlagRepository
+
+	"github.com/gofiber/fiber/v2" // Web framework
+)
+
+// FeatureGate нь flagCode-той flag идэвхгүй (disabled, хугацаанаас гадуур эсвэл
+// бүртгэлгүй) үед route бүртгэгдээгүй үеийн Fiber-ийн 404 хариуг буцаана.
+// Flag request бүрд DB-ээс уншигдана; уншихад гарсан бусад алдаа ErrorHandler руу (500) дамжина.
+//
+// Parameters:
+//   - flagCode: feature_flags.code
+//   - repo: Flag унших repository
+//
+// Returns:
+//   - fiber.Handler: Middleware function
+func FeatureGate(flagCode string, repo repository.FeatureFlagRepository) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		flag, err := repo.GetByCode(c.UserContext(), flagCode)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if err != nil || !flag.IsActiveAt(time.Now()) {
+			return fiber.NewError(fiber.StatusNotFound, "Cannot "+c.Method()+" "+html.EscapeString(c.Path()))
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,135 @@
+// Package middleware provides HTTP middlewares
+//
+// File: feature_gate_test.go
+// Description: Unit tests for the feature flag gate (time-bounded flag evaluation)
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"templatev25/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeFeatureFlagRepo нь code → flag map-аас уншина; err өгөгдсөн бол түүнийг буцаана
+type fakeFeatureFlagRepo struct {
+	flags map[string]domain.FeatureFlag
+	err   error
+}
+
+func (r *fakeFeatureFlagRepo) List(context.Context) ([]domain.FeatureFlag, error) {
+	items := make([]domain.FeatureFlag, 0, len(r.flags))
+	for _, f := range r.flags {
+		items = append(items, f)
+	}
+	return items, r.err
+}
+
+func (r *fakeFeatureFlagRepo) GetByCode(_ context.Context, code string) (domain.FeatureFlag, error) {
+	if r.err != nil {
+		return domain.FeatureFlag{}, r.err
+	}
+	f, ok := r.flags[code]
+	if !ok {
+		return domain.FeatureFlag{}, domain.NewNotFound("feature flag not found", nil)
+	}
+	return f, nil
+}
+
+func (r *fakeFeatureFlagRepo) Enable(context.Context, string) error  { return nil }
+func (r *fakeFeatureFlagRepo) Disable(context.Context, string) error { return nil }
+
+func timePtr(t time.Time) *time.Time { return &t }
+
+func TestFeatureGate(t *testing.T) {
+	now := time.Now()
+	hour := time.Hour
+
+	tests := []struct {
+		name       string
+		repo       *fakeFeatureFlagRepo
+		wantStatus int
+	}{
+		{
+			name:       "enabled without bounds",
+			repo:       &fakeFeatureFlagRepo{flags: map[string]domain.FeatureFlag{"beta": {Code: "beta", Enabled: true}}},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name: "within range",
+			repo: &fakeFeatureFlagRepo{flags: map[string]domain.FeatureFlag{"beta": {
+				Code: "beta", Enabled: true, ValidFrom: timePtr(now.Add(-hour)), ValidTo: timePtr(now.Add(hour)),
+			}}},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name: "before valid_from",
+			repo: &fakeFeatureFlagRepo{flags: map[string]domain.FeatureFlag{"beta": {
+				Code: "beta", Enabled: true, ValidFrom: timePtr(now.Add(hour)),
+			}}},
+			wantStatus: fiber.StatusNotFound,
+		},
+		{
+			name: "after valid_to",
+			repo: &fakeFeatureFlagRepo{flags: map[string]domain.FeatureFlag{"beta": {
+				Code: "beta", Enabled: true, ValidTo: timePtr(now.Add(-hour)),
+			}}},
+			wantStatus: fiber.StatusNotFound,
+		},
+		{
+			name:       "disabled",
+			repo:       &fakeFeatureFlagRepo{flags: map[string]domain.FeatureFlag{"beta": {Code: "beta"}}},
+			wantStatus: fiber.StatusNotFound,
+		},
+		{
+			name:       "unknown flag",
+			repo:       &fakeFeatureFlagRepo{flags: map[string]domain.FeatureFlag{}},
+			wantStatus: fiber.StatusNotFound,
+		},
+		{
+			name:       "repository error",
+			repo:       &fakeFeatureFlagRepo{err: errors.New("db down")},
+			wantStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+			app.Get("/beta", FeatureGate("beta", tt.repo), func(c *fiber.Ctx) error {
+				return c.SendString("ok")
+			})
+
+			res, err := app.Test(httptest.NewRequest("GET", "/beta", nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, res.StatusCode)
+		})
+	}
+
+	t.Run("inactive flag looks like an unknown route", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+		app.Get("/beta", FeatureGate("beta", &fakeFeatureFlagRepo{}), func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+
+		gated, err := app.Test(httptest.NewRequest("GET", "/beta", nil))
+		require.NoError(t, err)
+		unknown, err := app.Test(httptest.NewRequest("GET", "/missing", nil))
+		require.NoError(t, err)
+
+		gatedBody, _ := io.ReadAll(gated.Body)
+		unknownBody, _ := io.ReadAll(unknown.Body)
+		assert.Equal(t, unknown.StatusCode, gated.StatusCode)
+		assert.Contains(t, string(gatedBody), "Cannot GET /beta")
+		assert.Contains(t, string(unknownBody), "Cannot GET /missing")
+	})
+}
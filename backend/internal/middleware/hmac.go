@@ -0,0 +1,208 @@
+// Package middleware provides implementation for middleware
+//
+// File: hmac.go
+// Description: HMAC-SHA256 request signature verification for internal service-to-service calls
+package middleware
+
+import (
+	"context"       // Nonce store timeout
+	"crypto/hmac"   // Constant-time compare
+	"crypto/sha256" // HMAC-SHA256
+	"encoding/hex"  // Signature encoding
+	"strconv"       // Timestamp parsing
+	"strings"       // Case-insensitive hex
+	"time"          // Replay window
+
+	"github.com/gofiber/fiber/v2"  // Web framework
+	"github.com/redis/go-redis/v9" // Redis client
+)
+
+const (
+	// HeaderSignature нь hex кодлогдсон HMAC-SHA256 гарын үсэг
+	HeaderSignature = "X-Signature"
+
+	// HeaderTimestamp нь гарын үсэг зурсан Unix цаг (секунд)
+	HeaderTimestamp = "X-Timestamp"
+
+	// HMACMaxClockSkew нь X-Timestamp болон серверийн цагийн зөвшөөрөгдөх зөрүү (replay хамгаалалт)
+	HMACMaxClockSkew = 5 * time.Minute
+
+	// LocalsHMACVerified нь гарын үсэг баталгаажсан request-ийн Locals key
+	LocalsHMACVerified = "hmac_verified"
+)
+
+// hmacReplayTTL нь ашиглагдсан гарын үсгийг санах хугацаа. X-Timestamp нь серверийн
+// цагаас ±HMACMaxClockSkew зөрж болох тул нэг гарын үсэг нийт 2×skew хугацаанд хүчинтэй.
+const hmacReplayTTL = 2 * HMACMaxClockSkew
+
+// SignatureNonceStore нь ашиглагдсан гарын үсгийг бүртгэнэ (Redis SET NX EX).
+// IdempotencyStore-оос ялгаатай нь store-ийн алдааг нууж болохгүй (fail-closed):
+// алдаа гарвал гарын үсэг давтагдаагүй гэдгийг батлах боломжгүй.
+type SignatureNonceStore interface {
+	// Claim нь key байхгүй үед л ttl хугацаатай хадгалж true буцаана.
+	// Store-д хандаж чадаагүй бол алдаа буцаана.
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// RedisSignatureNonceStore нь олон instance хооронд хуваалцах nonce store
+type RedisSignatureNonceStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisSignatureNonceStore нь Redis nonce store үүсгэнэ (prefix хоосон бол "hmac_signature:")
+func NewRedisSignatureNonceStore(client *redis.Client, prefix string) *RedisSignatureNonceStore {
+	if prefix == "" {
+		prefix = "hmac_signature:"
+	}
+	return &RedisSignatureNonceStore{client: client, prefix: prefix}
+}
+
+// Claim нь Redis SET NX-ээр key-г атомаар захиална. Redis алдааг буцаана.
+func (s *RedisSignatureNonceStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, redisIdempotencyTimeout)
+	defer cancel()
+	return s.client.SetNX(ctx, s.prefix+key, nil, ttl).Result()
+}
+
+// InMemorySignatureNonceStore нь нэг instance-д зориулсан nonce store (тест, local)
+type InMemorySignatureNonceStore struct {
+	entries *InMemoryIdempotencyStore
+}
+
+// NewInMemorySignatureNonceStore нь санах ойн nonce store үүсгэнэ
+func NewInMemorySignatureNonceStore() *InMemorySignatureNonceStore {
+	return &InMemorySignatureNonceStore{entries: NewInMemoryIdempotencyStore()}
+}
+
+// Claim нь хугацаа нь дуусаагүй бичлэг байхгүй үед л хадгална
+func (s *InMemorySignatureNonceStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.entries.SetNX(key, nil, ttl), nil
+}
+
+// SignRequest нь HMAC-SHA256(secret, method+path+timestamp+body)-ийг hex болгож буцаана.
+// Дуудагч сервис X-Signature header-т энэ утгыг, X-Timestamp-д timestamp-ийг илгээнэ.
+//
+// Жишээ:
+//
+//	ts := strconv.FormatInt(time.Now().Unix(), 10)
+//	req.Header.Set(middleware.HeaderTimestamp, ts)
+//	req.Header.Set(middleware.HeaderSignature, middleware.SignRequest(secret, "POST", "/user/sync", ts, body))
+func SignRequest(secret, method, path, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(method))
+	mac.Write([]byte(path))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyHMACSignature нь request-ийн X-Signature, X-Timestamp header-үүдийг шалгана.
+// Хүчинтэй бол nil, эс бөгөөс 401 *fiber.Error буцаана.
+//
+// Шалгалт:
+//  1. secret хоосон бол бүх request татгалзагдана (тохиргоо дутуу)
+//  2. X-Timestamp серверийн цагаас HMACMaxClockSkew-ээс их зөрвөл татгалзана
+//  3. method + path (query-гүй) + timestamp + body-гоор тооцсон HMAC-тай constant-time харьцуулна
+func VerifyHMACSignature(c *fiber.Ctx, secret string) error {
+	if secret == "" {
+		return fiber.NewError(fiber.StatusUnauthorized, "request signing is not configured")
+	}
+
+	signature, timestamp := c.Get(HeaderSignature), c.Get(HeaderTimestamp)
+	if signature == "" || timestamp == "" {
+		return fiber.NewError(fiber.StatusUnauthorized, "missing request signature")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnauthorized, "invalid request timestamp")
+	}
+	skew := time.Since(time.Unix(unix, 0))
+	if skew < -HMACMaxClockSkew || skew > HMACMaxClockSkew {
+		return fiber.NewError(fiber.StatusUnauthorized, "request timestamp is outside the allowed window")
+	}
+
+	expected := SignRequest(secret, c.Method(), c.Path(), timestamp, c.Body())
+	if !hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected)) {
+		return fiber.NewError(fiber.StatusUnauthorized, "invalid request signature")
+	}
+	return nil
+}
+
+// claimHMACSignature нь баталгаажсан request-ийн гарын үсгийг нэг удаа ашиглагдсан
+// гэж бүртгэнэ. Skew цонхны дотор ижил гарын үсэгтэй request давтагдвал 401,
+// store-д хандаж чадаагүй бол 503.
+// VerifyHMACSignature-ээс тусдаа: CSRF skip зэрэг шалгалт request-ийг хүлээн
+// авахгүйгээр гарын үсгийг шалгаж болно.
+func claimHMACSignature(c *fiber.Ctx, nonces SignatureNonceStore) error {
+	claimed, err := nonces.Claim(c.UserContext(), strings.ToLower(c.Get(HeaderSignature)), hmacReplayTTL)
+	if err != nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "request signature store is unavailable")
+	}
+	if !claimed {
+		return fiber.NewError(fiber.StatusUnauthorized, "request signature was already used")
+	}
+	return nil
+}
+
+// HMACSignature нь дотоод микросервисүүдийн дуудлагыг SSO session-гүйгээр
+// хуваалцсан secret-ээр баталгаажуулах middleware буцаана.
+//
+// Parameters:
+//   - secret: Хуваалцсан түлхүүр (INTERNAL_API_SECRET)
+//   - nonces: Ашиглагдсан гарын үсгийн store (заавал; nil бол panic — replay
+//     шалгалтгүйгээр эхлүүлэхгүй)
+//
+// Returns:
+//   - fiber.Handler: Гарын үсэг буруу, хугацаа хэтэрсэн, давтагдсан бол 401,
+//     nonce store ажиллахгүй бол 503
+//
+// Ашиглалт:
+//
+//	internal := app.Group("/internal", middleware.HMACSignature(srvCfg.InternalSecret, d.SignatureNonces))
+func HMACSignature(secret string, nonces SignatureNonceStore) fiber.Handler {
+	if nonces == nil {
+		panic("middleware: HMACSignature requires a SignatureNonceStore")
+	}
+	return func(c *fiber.Ctx) error {
+		if err := VerifyHMACSignature(c, secret); err != nil {
+			return err
+		}
+		if err := claimHMACSignature(c, nonces); err != nil {
+			return err
+		}
+		c.Locals(LocalsHMACVerified, true)
+		return c.Next()
+	}
+}
+
+// SignedOr нь X-Signature header-тэй request-ийг signed (HMACSignature)-аар,
+// бусдыг fallback (жишээ: SSO session)-аар баталгаажуулна. Нэг route дээр дотоод
+// сервис болон хэрэглэгчийн дуудлагыг хоёуланг нь хүлээн авахад хэрэглэнэ.
+//
+// Ашиглалт:
+//
+//	v1.Post("/user/sync",
+//		middleware.SignedOr(middleware.HMACSignature(secret, nonces), requireAuth),
+//		middleware.UnlessSigned(auth.RequireRole(roleCache, "ADMIN")),
+//		handler.Sync)
+func SignedOr(signed, fallback fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get(HeaderSignature) != "" {
+			return signed(c)
+		}
+		return fallback(c)
+	}
+}
+
+// UnlessSigned нь HMACSignature баталгаажуулсан request-д h-г алгасна.
+// Session-д суурилсан (role, permission) шалгалтыг signed дуудлагад хэрэглэхгүй.
+func UnlessSigned(h fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if verified, _ := c.Locals(LocalsHMACVerified).(bool); verified {
+			return c.Next()
+		}
+		return h(c)
+	}
+}
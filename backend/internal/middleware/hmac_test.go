@@ -0,0 +1,247 @@
+// Package middleware provides HTTP middlewares
+//
+// File: hmac_test.go
+// Description: Unit tests for HMAC request signature verification
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHMACSecret = "0123456789abcdef0123456789abcdef"
+
+// newSignedRequest нь secret-ээр гарын үсэг зурсан POST /user/sync request үүсгэнэ
+func newSignedRequest(secret, body string, at time.Time) *http.Request {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	req := httptest.NewRequest("POST", "/user/sync", strings.NewReader(body))
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, SignRequest(secret, "POST", "/user/sync", ts, []byte(body)))
+	return req
+}
+
+func TestHMACSignature(t *testing.T) {
+	const body = `{"users":[{"id":1}]}`
+
+	tests := []struct {
+		name       string
+		secret     string
+		req        func() *http.Request
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "valid signature",
+			secret:     testHMACSecret,
+			req:        func() *http.Request { return newSignedRequest(testHMACSecret, body, time.Now()) },
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "valid signature in upper case hex",
+			secret: testHMACSecret,
+			req: func() *http.Request {
+				r := newSignedRequest(testHMACSecret, body, time.Now())
+				r.Header.Set(HeaderSignature, strings.ToUpper(r.Header.Get(HeaderSignature)))
+				return r
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "signed with another secret",
+			secret:     testHMACSecret,
+			req:        func() *http.Request { return newSignedRequest("another-secret-another-secret-xx", body, time.Now()) },
+			wantStatus: fiber.StatusUnauthorized,
+			wantMsg:    "invalid request signature",
+		},
+		{
+			name:   "body tampered after signing",
+			secret: testHMACSecret,
+			req: func() *http.Request {
+				signed := newSignedRequest(testHMACSecret, body, time.Now())
+				r := httptest.NewRequest("POST", "/user/sync", strings.NewReader(`{"users":[{"id":2}]}`))
+				r.Header = signed.Header
+				return r
+			},
+			wantStatus: fiber.StatusUnauthorized,
+			wantMsg:    "invalid request signature",
+		},
+		{
+			name:       "replayed after 6 minutes",
+			secret:     testHMACSecret,
+			req:        func() *http.Request { return newSignedRequest(testHMACSecret, body, time.Now().Add(-6*time.Minute)) },
+			wantStatus: fiber.StatusUnauthorized,
+			wantMsg:    "outside the allowed window",
+		},
+		{
+			name:       "timestamp in the future",
+			secret:     testHMACSecret,
+			req:        func() *http.Request { return newSignedRequest(testHMACSecret, body, time.Now().Add(6*time.Minute)) },
+			wantStatus: fiber.StatusUnauthorized,
+			wantMsg:    "outside the allowed window",
+		},
+		{
+			name:       "within clock skew",
+			secret:     testHMACSecret,
+			req:        func() *http.Request { return newSignedRequest(testHMACSecret, body, time.Now().Add(-4*time.Minute)) },
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "non numeric timestamp",
+			secret: testHMACSecret,
+			req: func() *http.Request {
+				r := newSignedRequest(testHMACSecret, body, time.Now())
+				r.Header.Set(HeaderTimestamp, time.Now().Format(time.RFC3339))
+				return r
+			},
+			wantStatus: fiber.StatusUnauthorized,
+			wantMsg:    "invalid request timestamp",
+		},
+		{
+			name:       "missing headers",
+			secret:     testHMACSecret,
+			req:        func() *http.Request { return httptest.NewRequest("POST", "/user/sync", strings.NewReader(body)) },
+			wantStatus: fiber.StatusUnauthorized,
+			wantMsg:    "missing request signature",
+		},
+		{
+			name:       "empty secret rejects everything",
+			secret:     "",
+			req:        func() *http.Request { return newSignedRequest("", body, time.Now()) },
+			wantStatus: fiber.StatusUnauthorized,
+			wantMsg:    "not configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verified bool
+			app := fiber.New()
+			app.Post("/user/sync", HMACSignature(tt.secret, NewInMemorySignatureNonceStore()), func(c *fiber.Ctx) error {
+				verified, _ = c.Locals(LocalsHMACVerified).(bool)
+				return c.SendString("synced")
+			})
+
+			resp, err := app.Test(tt.req())
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantStatus == fiber.StatusOK, verified)
+			if tt.wantMsg != "" {
+				msg, _ := io.ReadAll(resp.Body)
+				assert.Contains(t, string(msg), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestHMACSignature_RejectsReplay(t *testing.T) {
+	const body = `{"users":[{"id":1}]}`
+	app := fiber.New()
+	app.Post("/user/sync", HMACSignature(testHMACSecret, NewInMemorySignatureNonceStore()), func(c *fiber.Ctx) error {
+		return c.SendString("synced")
+	})
+
+	now := time.Now()
+	resp, err := app.Test(newSignedRequest(testHMACSecret, body, now))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// Ижил гарын үсэгтэй request skew цонхны дотор дахин ирвэл татгалзана
+	resp, err = app.Test(newSignedRequest(testHMACSecret, body, now))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	msg, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(msg), "already used")
+
+	// Шинэ timestamp-тэй (өөр гарын үсэг) request хүлээн авагдана
+	resp, err = app.Test(newSignedRequest(testHMACSecret, body, now.Add(time.Second)))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestSignRequest_CoversEveryPart(t *testing.T) {
+	base := SignRequest(testHMACSecret, "POST", "/user/sync", "1700000000", []byte("{}"))
+
+	assert.Len(t, base, 64)
+	assert.Equal(t, base, SignRequest(testHMACSecret, "POST", "/user/sync", "1700000000", []byte("{}")))
+	assert.NotEqual(t, base, SignRequest(testHMACSecret, "PUT", "/user/sync", "1700000000", []byte("{}")))
+	assert.NotEqual(t, base, SignRequest(testHMACSecret, "POST", "/user", "1700000000", []byte("{}")))
+	assert.NotEqual(t, base, SignRequest(testHMACSecret, "POST", "/user/sync", "1700000001", []byte("{}")))
+	assert.NotEqual(t, base, SignRequest(testHMACSecret, "POST", "/user/sync", "1700000000", []byte("[]")))
+}
+
+// failingNonceStore нь Redis тасарсан үеийг дуурайна
+type failingNonceStore struct{}
+
+func (failingNonceStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return false, errors.New("redis: connection refused")
+}
+
+func TestHMACSignature_FailsClosedWhenNonceStoreErrors(t *testing.T) {
+	const body = `{"users":[{"id":1}]}`
+	app := fiber.New()
+	app.Post("/user/sync", HMACSignature(testHMACSecret, failingNonceStore{}), func(c *fiber.Ctx) error {
+		return c.SendString("synced")
+	})
+
+	resp, err := app.Test(newSignedRequest(testHMACSecret, body, time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestHMACSignature_RequiresNonceStore(t *testing.T) {
+	assert.Panics(t, func() { HMACSignature(testHMACSecret, nil) })
+}
+
+func TestSignedOr(t *testing.T) {
+	const body = `{"users":[{"id":1}]}`
+	sessionOnly := func(c *fiber.Ctx) error {
+		if c.Get("Cookie") == "" {
+			return fiber.ErrUnauthorized
+		}
+		return c.Next()
+	}
+	adminOnly := func(c *fiber.Ctx) error {
+		return fiber.ErrForbidden
+	}
+
+	app := fiber.New()
+	app.Post("/user/sync",
+		SignedOr(HMACSignature(testHMACSecret, NewInMemorySignatureNonceStore()), sessionOnly),
+		UnlessSigned(adminOnly),
+		func(c *fiber.Ctx) error { return c.SendString("synced") },
+	)
+
+	// Signed дуудлага session-ий шалгалтыг алгасна
+	resp, err := app.Test(newSignedRequest(testHMACSecret, body, time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// Буруу гарын үсэг fallback руу шилжихгүй
+	resp, err = app.Test(newSignedRequest("wrong-secret", body, time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	// Гарын үсэггүй бол session болон admin шалгалт ажиллана
+	req := httptest.NewRequest("POST", "/user/sync", strings.NewReader(body))
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	req = httptest.NewRequest("POST", "/user/sync", strings.NewReader(body))
+	req.Header.Set("Cookie", "sid=abc")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
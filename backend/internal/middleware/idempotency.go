@@ -0,0 +1,317 @@
+// Package middleware provides HTTP middlewares
+//
+// File: idempotency.go
+// Description: Idempotency-key middleware for safely retrying POST requests
+/*
+Package middleware нь HTTP middleware-уудыг агуулна.
+
+Энэ файл нь X-Idempotency-Key header-тэй request-ийн хариуг хадгалж,
+ижил key-тэй давтан request ирвэл handler-ийг дахин ажиллуулахгүйгээр
+хадгалсан хариуг буцаана. Сүлжээ тасалдсаны улмаас давхар бичлэг
+үүсэхээс сэргийлнэ.
+
+Key-г handler ажиллахаас өмнө атомаар (SetNX) захиална: ижил key-тэй
+зэрэг ирсэн хоёр дахь request нь 409 авна. Key нь method, path, body-ийн
+hash-тай холбогдох тул өөр request-д дахин ашигласан key 422 авна.
+
+Ашиглалт:
+
+	store := middleware.NewInMemoryIdempotencyStore()
+	router.Post("/", middleware.Idempotency(store, 24*time.Hour), handler.Create)
+*/
+package middleware
+
+import (
+	"context"       // Redis timeout
+	"crypto/sha256" // Request fingerprint
+	"encoding/hex"  // Fingerprint encoding
+	"encoding/json" // Cached response serialization
+	"sync"          // In-memory store lock
+	"time"          // TTL
+
+	"git.gerege.mn/backend-packages/sso-client" // Session ID авах
+
+	"github.com/gofiber/fiber/v2"  // Web framework
+	"github.com/redis/go-redis/v9" // Redis client
+)
+
+const (
+	// HeaderIdempotencyKey нь client-ийн илгээх idempotency key header
+	HeaderIdempotencyKey = "X-Idempotency-Key"
+
+	// HeaderIdempotentReplayed нь хадгалсан хариуг буцаасан үед тавигдана
+	HeaderIdempotentReplayed = "X-Idempotent-Replayed"
+)
+
+// idempotencyLockTTL нь ажиллаж байгаа request-ийн захиалгын хугацаа. Process
+// унасан ч key энэ хугацааны дараа чөлөөлөгдөнө (SERVER_REQUEST_TIMEOUT-оос урт).
+const idempotencyLockTTL = time.Minute
+
+// IdempotencyStore нь idempotency key-ээр хадгалсан хариуг хадгална.
+type IdempotencyStore interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, body []byte, ttl time.Duration)
+	// SetNX нь key байхгүй үед л атомаар хадгалж true буцаана
+	SetNX(key string, body []byte, ttl time.Duration) bool
+	Delete(key string)
+}
+
+// cachedResponse нь store-д хадгалагдах хариу.
+// Pending нь handler ажиллаж байгаа үеийн захиалга (хариу хараахан байхгүй).
+type cachedResponse struct {
+	Pending     bool   `json:"pending,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// ============================================================
+// IDEMPOTENCY MIDDLEWARE
+// ============================================================
+
+// Idempotency нь X-Idempotency-Key header-ээр хариуг давхардуулахгүй болгох middleware буцаана.
+//
+// Flow:
+//   - Header байхгүй: шууд handler руу (bypass)
+//   - Key store-д хариутай: request ижил бол хадгалсан хариуг буцаана
+//     (X-Idempotent-Replayed: true), өөр method/path/body бол 422
+//   - Key захиалагдсан (өөр request ажиллаж байна): 409
+//   - Key байхгүй: SetNX-ээр захиалж handler ажиллуулна, 2xx хариуг ttl
+//     хугацаагаар хадгална; бусад үед захиалгыг чөлөөлнө
+//
+// Key нь session (эсвэл IP)-ээр хүрээлэгдсэн тул өөр хэрэглэгч ижил key
+// илгээсэн ч бусдын хариуг авахгүй. Алдаатай (non-2xx) хариу хадгалагдахгүй
+// тул client дахин оролдох боломжтой.
+//
+// Parameters:
+//   - store: Хариу хадгалах store (in-memory эсвэл Redis)
+//   - ttl: Хариу хадгалах хугацаа (жишээ: 24*time.Hour)
+//
+// Returns:
+//   - fiber.Handler: Middleware function
+func Idempotency(store IdempotencyStore, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" || store == nil {
+			return c.Next()
+		}
+		storeKey := idempotencyScope(c) + ":" + key
+		fingerprint := requestFingerprint(c)
+
+		// Cache hit: хадгалсан хариуг буцаана
+		if raw, ok := store.Get(storeKey); ok {
+			return replayIdempotent(c, raw, fingerprint)
+		}
+
+		// Key-г атомаар захиална; зэрэг ирсэн request-үүдээс зөвхөн нэг нь handler руу орно
+		pending, err := json.Marshal(cachedResponse{Pending: true, Fingerprint: fingerprint})
+		if err != nil {
+			return err
+		}
+		if !store.SetNX(storeKey, pending, idempotencyLockTTL) {
+			if raw, ok := store.Get(storeKey); ok {
+				return replayIdempotent(c, raw, fingerprint)
+			}
+			return fiber.NewError(fiber.StatusConflict, "a request with this idempotency key is in progress")
+		}
+
+		// Handler амжилтгүй (алдаа, non-2xx, panic) бол захиалгыг чөлөөлнө
+		stored := false
+		defer func() {
+			if !stored {
+				store.Delete(storeKey)
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status < fiber.StatusOK || status >= fiber.StatusMultipleChoices {
+			return nil
+		}
+
+		raw, err := json.Marshal(cachedResponse{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		})
+		if err == nil {
+			store.Set(storeKey, raw, ttl)
+			stored = true
+		}
+		return nil
+	}
+}
+
+// replayIdempotent нь store-д байгаа бичлэгийг хариу болгоно: ажиллаж байгаа
+// бол 409, өөр request-ийн key бол 422, эс бөгөөс хадгалсан хариу
+func replayIdempotent(c *fiber.Ctx, raw []byte, fingerprint string) error {
+	var cached cachedResponse
+	if err := json.Unmarshal(raw, &cached); err != nil {
+		return fiber.NewError(fiber.StatusConflict, "a request with this idempotency key is in progress")
+	}
+	if cached.Fingerprint != fingerprint {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "idempotency key was already used for a different request")
+	}
+	if cached.Pending {
+		return fiber.NewError(fiber.StatusConflict, "a request with this idempotency key is in progress")
+	}
+	c.Set(HeaderIdempotentReplayed, "true")
+	if cached.ContentType != "" {
+		c.Set(fiber.HeaderContentType, cached.ContentType)
+	}
+	return c.Status(cached.Status).Send(cached.Body)
+}
+
+// requestFingerprint нь method, path, body-ийн SHA-256 hash (key-г request-тэй холбоно)
+func requestFingerprint(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{0})
+	h.Write([]byte(c.Path()))
+	h.Write([]byte{0})
+	h.Write(c.Body())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyScope нь key-г хэрэглэгчээр хүрээлэх утга буцаана (session эсвэл IP)
+func idempotencyScope(c *fiber.Ctx) string {
+	if sid := ssoclient.GetSID(c); sid != "" {
+		return "sid:" + sid
+	}
+	return "ip:" + c.IP()
+}
+
+// ============================================================
+// IN-MEMORY STORE
+// ============================================================
+
+// InMemoryIdempotencyStore нь process дотор хадгалах store (нэг instance, тест).
+type InMemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	body      []byte
+	expiresAt time.Time
+}
+
+// NewInMemoryIdempotencyStore нь хоосон in-memory store үүсгэнэ
+func NewInMemoryIdempotencyStore() *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{entries: map[string]idempotencyEntry{}}
+}
+
+// Get нь хугацаа нь дуусаагүй хариуг буцаана
+func (s *InMemoryIdempotencyStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(s.entries, key)
+		return nil, false
+	}
+	return e.body, true
+}
+
+// Set нь хариуг ttl хугацаагаар хадгална.
+// Хугацаа нь дууссан бичлэгүүдийг мөн цэвэрлэнэ.
+func (s *InMemoryIdempotencyStore) Set(key string, body []byte, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setLocked(key, body, ttl)
+}
+
+// SetNX нь хугацаа нь дуусаагүй бичлэг байхгүй үед л хадгална
+func (s *InMemoryIdempotencyStore) SetNX(key string, body []byte, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok && !time.Now().After(e.expiresAt) {
+		return false
+	}
+	s.setLocked(key, body, ttl)
+	return true
+}
+
+// Delete нь key-г устгана
+func (s *InMemoryIdempotencyStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+// setLocked нь s.mu түгжээтэй үед дуудагдана
+func (s *InMemoryIdempotencyStore) setLocked(key string, body []byte, ttl time.Duration) {
+	now := time.Now()
+	for k, e := range s.entries {
+		if now.After(e.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = idempotencyEntry{body: body, expiresAt: now.Add(ttl)}
+}
+
+// ============================================================
+// REDIS STORE
+// ============================================================
+
+// redisIdempotencyTimeout нь Redis үйлдэл бүрийн хугацааны хязгаар
+const redisIdempotencyTimeout = 2 * time.Second
+
+// RedisIdempotencyStore нь олон instance хооронд хуваалцах Redis store
+type RedisIdempotencyStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisIdempotencyStore нь Redis store үүсгэнэ (prefix хоосон бол "idempotency:")
+func NewRedisIdempotencyStore(client *redis.Client, prefix string) *RedisIdempotencyStore {
+	if prefix == "" {
+		prefix = "idempotency:"
+	}
+	return &RedisIdempotencyStore{client: client, prefix: prefix}
+}
+
+// Get нь хадгалсан хариуг буцаана. Redis алдаа гарвал miss гэж үзнэ.
+func (s *RedisIdempotencyStore) Get(key string) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisIdempotencyTimeout)
+	defer cancel()
+	body, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// Set нь хариуг ttl хугацаагаар хадгална. Redis алдааг үл тооно.
+func (s *RedisIdempotencyStore) Set(key string, body []byte, ttl time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisIdempotencyTimeout)
+	defer cancel()
+	_ = s.client.Set(ctx, s.prefix+key, body, ttl).Err()
+}
+
+// SetNX нь Redis SET NX-ээр key-г атомаар захиална. Redis алдаа гарвал
+// Get-тэй адил fail-open (true) буцаана.
+func (s *RedisIdempotencyStore) SetNX(key string, body []byte, ttl time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), redisIdempotencyTimeout)
+	defer cancel()
+	ok, err := s.client.SetNX(ctx, s.prefix+key, body, ttl).Result()
+	if err != nil {
+		return true
+	}
+	return ok
+}
+
+// Delete нь key-г устгана. Redis алдааг үл тооно.
+func (s *RedisIdempotencyStore) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisIdempotencyTimeout)
+	defer cancel()
+	_ = s.client.Del(ctx, s.prefix+key).Err()
+}
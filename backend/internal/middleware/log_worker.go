@@ -0,0 +1,204 @@
+// Package middleware provides implementation for middleware
+//
+// File: log_worker.go
+// Description: API log worker pool with drop counter and dead-letter file
+/*
+RequestLogger нь API log-ийг database руу logWorkerPool-оор дамжуулан
+асинхрон бичнэ. Queue дүүрсэн үед request-ийг блоклохгүйн тулд entry-г
+хаяна, гэхдээ:
+
+  - api_log.dropped counter-ийг нэмнэ (Prometheus дээр api_log_dropped_total)
+  - entry-г dead-letter файл руу newline-delimited JSON хэлбэрээр бичнэ
+    (LOG_DEAD_LETTER_PATH, анхдагч нь dead_letter.log)
+
+LogWorkerHealth нь queue-ийн дүүргэлт болон нийт хаягдсан тоог /health-д өгнө.
+
+Ашиглалт:
+
+	if err := middleware.OpenLogDeadLetter(localconfig.LoadLogConfig().DeadLetterPath); err != nil {
+	    logg.Warn("api log dead-letter file unavailable", zap.Error(err))
+	}
+	app.Use(middleware.RequestLogger(logg, apiLogRepo))
+*/
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/zap"
+)
+
+const (
+	logWorkerCount  = 5    // Number of worker goroutines
+	logQueueSize    = 1000 // Buffer size for log queue
+	logWriteTimeout = 5 * time.Second
+)
+
+var (
+	apiLogPool   *logWorkerPool
+	logQueueOnce sync.Once
+
+	// logDeadLetter нь OpenLogDeadLetter-ээр нээгдсэн файл (nil бол dead-letter бичихгүй)
+	logDeadLetter io.Writer
+)
+
+type logEntry struct {
+	repo   repository.APILogRepository
+	apiLog domain.APILog
+}
+
+// WorkerStats нь API log worker pool-ийн төлөв (GET /health)
+type WorkerStats struct {
+	QueueLen     int   `json:"queue_len"`
+	QueueCap     int   `json:"queue_cap"`
+	DroppedTotal int64 `json:"dropped_total"`
+}
+
+// deadLetterEntry нь APILog-ийн json:"-" талбаруудыг оруулж бүтэн entry-г хадгална
+// (дараа нь database руу дахин оруулах боломжтой)
+type deadLetterEntry struct {
+	domain.APILog
+	Params   json.RawMessage `json:"params,omitempty"`
+	Queries  json.RawMessage `json:"queries,omitempty"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// logWorkerPool нь API log-ийг database руу бичих worker-ууд болон queue
+type logWorkerPool struct {
+	queue   chan logEntry
+	log     *zap.Logger
+	dropped atomic.Int64
+	counter metric.Int64Counter
+
+	mu         sync.Mutex // deadLetter бичилтийг цувруулна
+	deadLetter io.Writer
+}
+
+// newLogWorkerPool нь worker-гүй pool үүсгэнэ (start-аар эхлүүлнэ).
+// deadLetter nil бол хаягдсан entry зөвхөн counter-т тоологдоно.
+func newLogWorkerPool(log *zap.Logger, size int, meter metric.Meter, deadLetter io.Writer) *logWorkerPool {
+	counter, err := meter.Int64Counter("api_log.dropped",
+		metric.WithDescription("API log entries dropped because the worker queue was full"))
+	if err != nil {
+		log.Warn("api log dropped counter unavailable", zap.Error(err))
+		counter, _ = noop.NewMeterProvider().Meter("").Int64Counter("api_log.dropped")
+	}
+	return &logWorkerPool{
+		queue:      make(chan logEntry, size),
+		log:        log,
+		counter:    counter,
+		deadLetter: deadLetter,
+	}
+}
+
+// start нь n worker goroutine эхлүүлнэ
+func (p *logWorkerPool) start(n int) {
+	for i := 0; i < n; i++ {
+		go p.work()
+	}
+}
+
+// work processes log entries from the queue
+func (p *logWorkerPool) work() {
+	for entry := range p.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), logWriteTimeout)
+		if err := entry.repo.Create(ctx, entry.apiLog); err != nil {
+			p.log.Error("failed to save api log to database", zap.Error(err))
+		}
+		cancel()
+	}
+}
+
+// enqueue нь entry-г блоклохгүйгээр queue-д нэмнэ. Queue дүүрсэн бол entry-г
+// хаяж, counter-ийг нэмээд dead-letter файл руу бичээд false буцаана.
+func (p *logWorkerPool) enqueue(entry logEntry) bool {
+	select {
+	case p.queue <- entry:
+		return true
+	default:
+	}
+
+	p.dropped.Add(1)
+	p.counter.Add(context.Background(), 1)
+	p.log.Warn("api log queue full, dropping log entry",
+		zap.String("path", entry.apiLog.Path),
+		zap.String("method", entry.apiLog.Method))
+	p.writeDeadLetter(entry.apiLog)
+	return false
+}
+
+// writeDeadLetter нь entry-г нэг мөр JSON болгож dead-letter руу бичнэ
+func (p *logWorkerPool) writeDeadLetter(apiLog domain.APILog) {
+	if p.deadLetter == nil {
+		return
+	}
+	line, err := json.Marshal(deadLetterEntry{
+		APILog:   apiLog,
+		Params:   json.RawMessage(apiLog.Params),
+		Queries:  json.RawMessage(apiLog.Queries),
+		Body:     json.RawMessage(apiLog.Body),
+		Response: json.RawMessage(apiLog.Response),
+	})
+	if err != nil {
+		p.log.Error("failed to encode dead-letter api log", zap.Error(err))
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.deadLetter.Write(append(line, '\n')); err != nil {
+		p.log.Error("failed to write dead-letter api log", zap.Error(err))
+	}
+}
+
+// stats нь queue-ийн одоогийн төлөвийг буцаана
+func (p *logWorkerPool) stats() WorkerStats {
+	return WorkerStats{
+		QueueLen:     len(p.queue),
+		QueueCap:     cap(p.queue),
+		DroppedTotal: p.dropped.Load(),
+	}
+}
+
+// initLogWorkers starts the worker pool for async log writing.
+// Called once when first log repo is provided.
+func initLogWorkers(log *zap.Logger) {
+	logQueueOnce.Do(func() {
+		apiLogPool = newLogWorkerPool(log, logQueueSize, otel.GetMeterProvider().Meter("templatev25/http"), logDeadLetter)
+		apiLogPool.start(logWorkerCount)
+	})
+}
+
+// OpenLogDeadLetter opens (or creates) path in append mode for API log entries
+// dropped on a full queue. Must be called before the first RequestLogger with a
+// repository; the file stays open for the lifetime of the process.
+func OpenLogDeadLetter(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	logDeadLetter = f
+	return nil
+}
+
+// LogWorkerHealth returns the API log queue length, capacity and the number of
+// entries dropped since start. Zero value when database logging is disabled.
+func LogWorkerHealth() WorkerStats {
+	if apiLogPool == nil {
+		return WorkerStats{}
+	}
+	return apiLogPool.stats()
+}
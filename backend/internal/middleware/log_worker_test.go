@@ -0,0 +1,138 @@
+// Package middleware provides HTTP middlewares
+//
+// File: log_worker_test.go
+// Description: Unit tests for API log queue saturation (drop counter, dead-letter, stats)
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"gorm.io/datatypes"
+)
+
+// newTestLogPool нь worker-гүй (queue хоослогдохгүй) pool болон manual reader буцаана
+func newTestLogPool(t *testing.T, size int, deadLetter *bytes.Buffer) (*logWorkerPool, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	if deadLetter == nil {
+		return newLogWorkerPool(zap.NewNop(), size, mp.Meter("test"), nil), reader
+	}
+	return newLogWorkerPool(zap.NewNop(), size, mp.Meter("test"), deadLetter), reader
+}
+
+// droppedCount нь api_log.dropped counter-ийн нийт утга
+func droppedCount(t *testing.T, reader *sdkmetric.ManualReader) int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if m.Name != "api_log.dropped" || !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				total += dp.Value
+			}
+		}
+	}
+	return total
+}
+
+func testLogEntry(i int) logEntry {
+	return logEntry{apiLog: domain.APILog{
+		Path:       fmt.Sprintf("/user/%d", i),
+		Method:     "POST",
+		StatusCode: 201,
+		Body:       datatypes.JSON(`{"name":"bold"}`),
+	}}
+}
+
+func TestLogWorkerPool_SaturatedQueueDrops(t *testing.T) {
+	var deadLetter bytes.Buffer
+	pool, reader := newTestLogPool(t, 2, &deadLetter)
+
+	var queued int
+	for i := 0; i < 5; i++ {
+		if pool.enqueue(testLogEntry(i)) {
+			queued++
+		}
+	}
+
+	assert.Equal(t, 2, queued)
+	assert.Equal(t, WorkerStats{QueueLen: 2, QueueCap: 2, DroppedTotal: 3}, pool.stats())
+	assert.Equal(t, int64(3), droppedCount(t, reader))
+
+	// Хаягдсан entry бүр нэг мөр JSON, body зэрэг json:"-" талбарууд хадгалагдана
+	var paths []string
+	scanner := bufio.NewScanner(&deadLetter)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		paths = append(paths, line["path"].(string))
+		assert.Equal(t, map[string]any{"name": "bold"}, line["body"])
+	}
+	assert.Equal(t, []string{"/user/2", "/user/3", "/user/4"}, paths)
+}
+
+func TestLogWorkerPool_NoDeadLetterStillCounts(t *testing.T) {
+	pool, reader := newTestLogPool(t, 1, nil)
+
+	assert.True(t, pool.enqueue(testLogEntry(1)))
+	assert.False(t, pool.enqueue(testLogEntry(2)))
+
+	assert.Equal(t, int64(1), pool.stats().DroppedTotal)
+	assert.Equal(t, int64(1), droppedCount(t, reader))
+}
+
+func TestLogWorkerPool_WorkersDrainQueue(t *testing.T) {
+	pool, reader := newTestLogPool(t, 10, nil)
+
+	repo := mocks.NewAPILogRepository(t)
+	saved := make(chan domain.APILog, 3)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("domain.APILog")).
+		Run(func(args mock.Arguments) { saved <- args.Get(1).(domain.APILog) }).
+		Return(nil).Times(3)
+
+	for i := 0; i < 3; i++ {
+		entry := testLogEntry(i)
+		entry.repo = repo
+		require.True(t, pool.enqueue(entry))
+	}
+	pool.start(2)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-saved:
+		case <-time.After(2 * time.Second):
+			t.Fatal("api log was not written by workers")
+		}
+	}
+	assert.Equal(t, WorkerStats{QueueLen: 0, QueueCap: 10, DroppedTotal: 0}, pool.stats())
+	assert.Zero(t, droppedCount(t, reader))
+}
+
+func TestLogWorkerHealth_DisabledWithoutRepository(t *testing.T) {
+	if apiLogPool != nil {
+		t.Skip("worker pool already initialized")
+	}
+	assert.Equal(t, WorkerStats{}, LogWorkerHealth())
+}
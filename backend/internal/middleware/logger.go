@@ -39,14 +39,15 @@ Log format (JSON):
 package middleware
 
 import (
-	"context"
 	"encoding/json"
 	"strings" // String manipulation
-	"sync"
 	"time" // Duration
 
+	"templatev25/internal/db"
 	"templatev25/internal/domain"
+	"templatev25/internal/http/locals"
 	"templatev25/internal/repository"
+	"templatev25/internal/requestctx"
 
 	"git.gerege.mn/backend-packages/ctx" // Context helpers
 
@@ -55,54 +56,6 @@ import (
 	"gorm.io/datatypes"
 )
 
-// ============================================================
-// LOG WORKER POOL (Goroutine leak prevention)
-// ============================================================
-
-const (
-	logWorkerCount   = 5     // Number of worker goroutines
-	logQueueSize     = 1000  // Buffer size for log queue
-	logWriteTimeout  = 5 * time.Second
-)
-
-var (
-	logQueue     chan logEntry
-	logQueueOnce sync.Once
-	logLogger    *zap.Logger
-)
-
-type logEntry struct {
-	repo   repository.APILogRepository
-	apiLog domain.APILog
-}
-
-// initLogWorkers starts the worker pool for async log writing.
-// Called once when first log repo is provided.
-func initLogWorkers(log *zap.Logger) {
-	logQueueOnce.Do(func() {
-		logQueue = make(chan logEntry, logQueueSize)
-		logLogger = log
-
-		// Start worker goroutines
-		for i := 0; i < logWorkerCount; i++ {
-			go logWorker()
-		}
-	})
-}
-
-// logWorker processes log entries from the queue
-func logWorker() {
-	for entry := range logQueue {
-		ctx, cancel := context.WithTimeout(context.Background(), logWriteTimeout)
-		if err := entry.repo.Create(ctx, entry.apiLog); err != nil {
-			if logLogger != nil {
-				logLogger.Error("failed to save api log to database", zap.Error(err))
-			}
-		}
-		cancel()
-	}
-}
-
 // ============================================================
 // REQUEST LOGGER
 // ============================================================
@@ -121,6 +74,7 @@ func logWorker() {
 //   - user_agent: Browser user agent
 //   - request_id: Unique request identifier
 //   - user_id: Authenticated user ID (if available)
+//   - db_query_count, db_total_ms: DB query stats (development only, see db.QueryMetricsPlugin)
 //
 // Additional fields on 4xx/5xx:
 //   - authorization: Masked Authorization header
@@ -203,8 +157,11 @@ func RequestLogger(log *zap.Logger, apiLogRepo ...repository.APILogRepository) f
 		// ============================================================
 		// CONTEXT VALUES
 		// ============================================================
-		// Request ID (header эсвэл locals-оос)
-		reqID := headerOrLocal(c, "X-Request-ID", "requestid")
+		// Request ID (RequestID middleware-ийн context, эсвэл header/locals-оос)
+		reqID := requestctx.GetRequestID(c.UserContext())
+		if reqID == "" {
+			reqID = headerOrLocal(c, HeaderRequestID, "requestid")
+		}
 
 		// User ID (authenticated бол)
 		userID, _ := ctx.GetValue[int](c.UserContext(), ctx.KeyUserID)
@@ -239,6 +196,13 @@ func RequestLogger(log *zap.Logger, apiLogRepo ...repository.APILogRepository) f
 		if userID != 0 {
 			fields = append(fields, zap.Int("user_id", userID))
 		}
+		// DB query статистик (зөвхөн development-д QueryMetricsPlugin бүртгэгдсэн үед)
+		if qs, ok := db.TakeQueryStats(reqID); ok {
+			fields = append(fields,
+				zap.Int64("db_query_count", qs.Count),
+				zap.Float64("db_total_ms", float64(qs.Total.Microseconds())/1000),
+			)
+		}
 
 		// 4xx/5xx үед masked headers нэмэх
 		if status >= 400 {
@@ -286,7 +250,7 @@ func RequestLogger(log *zap.Logger, apiLogRepo ...repository.APILogRepository) f
 				var responseBodyBytes []byte
 
 				// Эхлээд locals-оос авах (хэрэв handler-ууд хадгалсан бол)
-				if responseBodyVal, ok := c.Locals("response_body").([]byte); ok && len(responseBodyVal) > 0 {
+				if responseBodyVal, ok := locals.Get[[]byte](c, "response_body"); ok && len(responseBodyVal) > 0 {
 					responseBodyBytes = responseBodyVal
 				} else {
 					// Fallback: Response().Body() ашиглах
@@ -323,11 +287,7 @@ func RequestLogger(log *zap.Logger, apiLogRepo ...repository.APILogRepository) f
 			}
 
 			// Get username from context (if available)
-			username := ""
-			// Try to get username from context or locals
-			if usernameVal, ok := c.Locals("username").(string); ok {
-				username = usernameVal
-			}
+			username := locals.GetOrDefault(c, "username", "")
 
 			// Get org_id from context (if available)
 			var orgID *int64
@@ -338,7 +298,8 @@ func RequestLogger(log *zap.Logger, apiLogRepo ...repository.APILogRepository) f
 
 			// Create APILog entry
 			apiLog := domain.APILog{
-				OrgId: orgID,
+				OrgId:    orgID,
+				TenantID: requestctx.GetTenantID(c.UserContext()),
 				UserId: func() *int64 {
 					if userID != 0 {
 						userID64 := int64(userID)
@@ -362,17 +323,9 @@ func RequestLogger(log *zap.Logger, apiLogRepo ...repository.APILogRepository) f
 				CreatedDate: time.Now(),
 			}
 
-			// Save to database asynchronously via worker pool (don't block response)
-			// Non-blocking send - if queue is full, log warning and drop
-			select {
-			case logQueue <- logEntry{repo: repo, apiLog: apiLog}:
-				// Successfully queued
-			default:
-				// Queue full, log warning
-				log.Warn("api log queue full, dropping log entry",
-					zap.String("path", path),
-					zap.String("method", method))
-			}
+			// Save to database asynchronously via worker pool (don't block response).
+			// Queue дүүрсэн бол entry хаягдаж dead-letter файл руу бичигдэнэ.
+			apiLogPool.enqueue(logEntry{repo: repo, apiLog: apiLog})
 		}
 
 		return err
@@ -398,7 +351,7 @@ func headerOrLocal(c *fiber.Ctx, header, local string) string {
 		return v
 	}
 	// Locals-оос хайх
-	if v, ok := c.Locals(local).(string); ok && v != "" {
+	if v, ok := locals.Get[string](c, local); ok && v != "" {
 		return v
 	}
 	return ""
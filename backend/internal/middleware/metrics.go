@@ -7,14 +7,23 @@
 //   - Request count by method, path, and status
 //   - Request duration histogram
 //   - Active request gauge
+//
+// RouteLatency нь global MeterProvider дээр route template-ээр (/user/:id)
+// label-тай latency histogram бичнэ (fiberprometheus-ийн path label-аас бага cardinality).
 package middleware
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"templatev25/internal/telemetry"
 
 	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 // MetricsConfig holds metrics middleware configuration
@@ -80,3 +89,66 @@ func HTTPMetricsWithConfig(m *telemetry.Metrics, cfg MetricsConfig) fiber.Handle
 		return err
 	}
 }
+
+// routeLatencyBuckets нь http.request.duration histogram-ийн bucket хилүүд (секунд)
+var routeLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// RouteLatency records request latency as the OTEL histogram "http.request.duration"
+// (Prometheus: http_request_duration_seconds) labeled by method, route_template
+// (c.Route().Path, e.g. /user/:id; "unmatched" when no route matched) and
+// status_class (2xx, 4xx, 5xx, ...).
+func RouteLatency() fiber.Handler {
+	return routeLatency(otel.GetMeterProvider().Meter("templatev25/http"), DefaultMetricsConfig())
+}
+
+func routeLatency(meter metric.Meter, cfg MetricsConfig) fiber.Handler {
+	hist, err := meter.Float64Histogram(
+		"http.request.duration",
+		metric.WithDescription("HTTP request duration by route template"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(routeLatencyBuckets...),
+	)
+	if err != nil {
+		hist, _ = noop.NewMeterProvider().Meter("").Float64Histogram("http.request.duration")
+	}
+
+	skipMap := make(map[string]bool, len(cfg.SkipPaths))
+	for _, p := range cfg.SkipPaths {
+		skipMap[p] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		if skipMap[c.Path()] {
+			return c.Next()
+		}
+
+		start := time.Now()
+		err := c.Next()
+		duration := time.Since(start).Seconds()
+
+		// c.Method() нь fasthttp buffer-ийг заадаг тул attribute-д хуулбарыг хадгална
+		hist.Record(c.UserContext(), duration, metric.WithAttributes(
+			attribute.String("method", strings.Clone(c.Method())),
+			attribute.String("route_template", routeTemplate(c, err)),
+			attribute.String("status_class", statusClass(responseStatus(c, err))),
+		))
+
+		return err
+	}
+}
+
+// responseStatus нь handler-ийн буцаасан алдааг (ErrorHandler хараахан ажиллаагүй) тооцсон status.
+// ErrorHandler-тэй ижил дарааллаар: fiber.Error, domain алдаа, эс бөгөөс 500.
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		return fe.Code
+	}
+	if status, ok := domainErrorStatus(err); ok {
+		return status
+	}
+	return fiber.StatusInternalServerError
+}
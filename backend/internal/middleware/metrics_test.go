@@ -0,0 +1,133 @@
+// Package middleware provides HTTP middlewares
+//
+// File: metrics_test.go
+// Description: Unit tests for the route template latency histogram (http_request_duration_seconds)
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// newRouteLatencyApp нь RouteLatency-тэй Fiber app болон manual reader буцаана
+func newRouteLatencyApp(t *testing.T) (*fiber.App, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(routeLatency(mp.Meter("test"), DefaultMetricsConfig()))
+	app.Get("/health", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/user/:id", func(c *fiber.Ctx) error {
+		switch c.Params("id") {
+		case "0":
+			return fiber.NewError(fiber.StatusNotFound, "user not found")
+		case "gone":
+			return domain.ErrNotFound
+		}
+		return c.SendString("ok")
+	})
+	app.Post("/user", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusInternalServerError).SendString("boom")
+	})
+	return app, reader
+}
+
+// latencyPoints нь http.request.duration histogram-ийн data point-уудыг буцаана
+func latencyPoints(t *testing.T, reader *sdkmetric.ManualReader) []metricdata.HistogramDataPoint[float64] {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "http.request.duration" {
+				continue
+			}
+			assert.Equal(t, "s", m.Unit)
+			hist, ok := m.Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			return hist.DataPoints
+		}
+	}
+	return nil
+}
+
+// pointCounts нь "method route_template status_class" → count
+func pointCounts(points []metricdata.HistogramDataPoint[float64]) map[string]uint64 {
+	out := map[string]uint64{}
+	for _, dp := range points {
+		method, _ := dp.Attributes.Value(attribute.Key("method"))
+		route, _ := dp.Attributes.Value(attribute.Key("route_template"))
+		class, _ := dp.Attributes.Value(attribute.Key("status_class"))
+		out[method.AsString()+" "+route.AsString()+" "+class.AsString()] += dp.Count
+	}
+	return out
+}
+
+func doRequest(t *testing.T, app *fiber.App, method, path string) {
+	t.Helper()
+	res, err := app.Test(httptest.NewRequest(method, path, nil))
+	require.NoError(t, err)
+	_ = res.Body.Close()
+}
+
+func TestRouteLatency_LabelsByRouteTemplate(t *testing.T) {
+	app, reader := newRouteLatencyApp(t)
+
+	doRequest(t, app, "GET", "/user/1")
+	doRequest(t, app, "GET", "/user/2")
+	doRequest(t, app, "GET", "/user/0")
+	doRequest(t, app, "POST", "/user")
+	doRequest(t, app, "GET", "/nope/123")
+
+	assert.Equal(t, map[string]uint64{
+		"GET /user/:id 2xx": 2,
+		"GET /user/:id 4xx": 1,
+		"POST /user 5xx":    1,
+		"GET unmatched 4xx": 1,
+	}, pointCounts(latencyPoints(t, reader)))
+}
+
+func TestRouteLatency_DomainErrorStatus(t *testing.T) {
+	app, reader := newRouteLatencyApp(t)
+
+	// domain.ErrNotFound нь ErrorHandler-т 404 болдог тул 5xx гэж тоологдохгүй
+	doRequest(t, app, "GET", "/user/gone")
+
+	assert.Equal(t, map[string]uint64{
+		"GET /user/:id 4xx": 1,
+	}, pointCounts(latencyPoints(t, reader)))
+}
+
+func TestRouteLatency_BucketBoundaries(t *testing.T) {
+	app, reader := newRouteLatencyApp(t)
+
+	doRequest(t, app, "GET", "/user/1")
+
+	points := latencyPoints(t, reader)
+	require.Len(t, points, 1)
+	assert.Equal(t, routeLatencyBuckets, points[0].Bounds)
+	assert.Equal(t, []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}, points[0].Bounds)
+	// Bound бүрд нэг bucket + +Inf bucket
+	assert.Len(t, points[0].BucketCounts, len(routeLatencyBuckets)+1)
+	// Fake handler 5ms-ээс хурдан → эхний bucket
+	assert.Equal(t, uint64(1), points[0].BucketCounts[0])
+}
+
+func TestRouteLatency_SkipPaths(t *testing.T) {
+	app, reader := newRouteLatencyApp(t)
+
+	doRequest(t, app, "GET", "/health")
+
+	assert.Empty(t, latencyPoints(t, reader))
+}
@@ -6,11 +6,14 @@ package middleware
 
 import (
 	"context"
+	"io"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	fbrecover "github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -75,7 +78,7 @@ func TestSecurityHeaders(t *testing.T) {
 	// Check security headers
 	assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
 	assert.Equal(t, "DENY", resp.Header.Get("X-Frame-Options"))
-	assert.Equal(t, "no-referrer", resp.Header.Get("Referrer-Policy"))
+	assert.Equal(t, "strict-origin-when-cross-origin", resp.Header.Get("Referrer-Policy"))
 	assert.NotEmpty(t, resp.Header.Get("Content-Security-Policy"))
 	assert.NotEmpty(t, resp.Header.Get("Permissions-Policy"))
 }
@@ -138,23 +141,29 @@ func TestBodySizeLimit_Over(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
-func TestPaginationLimit_Valid(t *testing.T) {
+// newPaginationApp нь handler-т хүрсэн size/pageSize-ийг буцаадаг app үүсгэнэ
+func newPaginationApp(maxSize int) *fiber.App {
 	app := fiber.New()
-	app.Use(PaginationLimit(100))
+	app.Use(PaginationLimit(maxSize))
 	app.Get("/test", func(c *fiber.Ctx) error {
-		return c.SendString("ok")
+		return c.SendString(c.Query("size") + "|" + c.Query("pageSize"))
 	})
+	return app
+}
+
+func TestPaginationLimit_Valid(t *testing.T) {
+	app := newPaginationApp(100)
 
 	tests := []struct {
-		name       string
-		query      string
-		wantStatus int
+		name     string
+		query    string
+		wantBody string
 	}{
-		{"no params", "", 200},
-		{"valid size", "?size=50", 200},
-		{"valid pageSize", "?pageSize=50", 200},
-		{"valid page", "?page=1", 200},
-		{"at limit", "?size=100", 200},
+		{"no params", "", "|"},
+		{"valid size", "?size=50", "50|"},
+		{"valid pageSize", "?pageSize=50", "|50"},
+		{"valid page", "?page=1", "|"},
+		{"at limit", "?size=100", "100|"},
 	}
 
 	for _, tt := range tests {
@@ -163,26 +172,26 @@ func TestPaginationLimit_Valid(t *testing.T) {
 			resp, err := app.Test(req)
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, 200, resp.StatusCode)
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, tt.wantBody, string(body))
 		})
 	}
 }
 
-func TestPaginationLimit_Invalid(t *testing.T) {
-	app := fiber.New()
-	app.Use(PaginationLimit(100))
-	app.Get("/test", func(c *fiber.Ctx) error {
-		return c.SendString("ok")
-	})
+func TestPaginationLimit_Clamp(t *testing.T) {
+	app := newPaginationApp(100)
 
 	tests := []struct {
-		name       string
-		query      string
-		wantStatus int
+		name     string
+		query    string
+		wantBody string
 	}{
-		{"size over limit", "?size=200", 400},
-		{"negative size", "?size=-5", 400},
-		{"negative page", "?page=-1", 400},
+		{"size over limit clamped to max", "?size=500", "100|"},
+		{"zero size defaults to 10", "?size=0", "10|"},
+		{"negative size defaults to 10", "?size=-5", "10|"},
+		{"non-numeric size defaults to 10", "?size=abc", "10|"},
+		{"pageSize over limit clamped to max", "?pageSize=200", "|100"},
 	}
 
 	for _, tt := range tests {
@@ -191,26 +200,65 @@ func TestPaginationLimit_Invalid(t *testing.T) {
 			resp, err := app.Test(req)
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, 200, resp.StatusCode)
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, tt.wantBody, string(body))
 		})
 	}
 }
 
-func TestPaginationLimit_CustomMax(t *testing.T) {
+func TestPaginationLimit_BindsClampedSize(t *testing.T) {
 	app := fiber.New()
-	app.Use(PaginationLimit(10)) // Custom max of 10
+	app.Use(PaginationLimit(100))
 	app.Get("/test", func(c *fiber.Ctx) error {
-		return c.SendString("ok")
+		var q struct {
+			Page int `query:"page"`
+			Size int `query:"size"`
+		}
+		if err := c.QueryParser(&q); err != nil {
+			return err
+		}
+		return c.SendString(strconv.Itoa(q.Size))
 	})
 
-	// Size 15 should be rejected with max 10
-	req := httptest.NewRequest("GET", "/test?size=15", nil)
+	req := httptest.NewRequest("GET", "/test?page=2&size=500", nil)
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "100", string(body))
+}
+
+func TestPaginationLimit_Invalid(t *testing.T) {
+	app := newPaginationApp(100)
+
+	req := httptest.NewRequest("GET", "/test?page=-1", nil)
 	resp, err := app.Test(req)
 
 	require.NoError(t, err)
 	assert.Equal(t, 400, resp.StatusCode)
 }
 
+func TestPaginationLimit_CustomMax(t *testing.T) {
+	app := newPaginationApp(10) // Custom max of 10
+
+	// Size 15 is clamped to max 10
+	req := httptest.NewRequest("GET", "/test?size=15", nil)
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "10|", string(body))
+
+	// size=0 үед default (10) нь max-аас хэтрэхгүй
+	app = newPaginationApp(5)
+	resp, err = app.Test(httptest.NewRequest("GET", "/test?size=0", nil))
+	require.NoError(t, err)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(t, "5|", string(body))
+}
+
 func TestTimeout(t *testing.T) {
 	app := fiber.New()
 	app.Use(Timeout(5 * time.Second))
@@ -250,6 +298,124 @@ func TestTimeout_ContextPropagated(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+func TestTimeout_SlowHandlerReturns503(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler fiber.Handler
+	}{
+		{
+			name: "handler ignores context and fails",
+			handler: func(c *fiber.Ctx) error {
+				time.Sleep(200 * time.Millisecond)
+				return c.Status(fiber.StatusInternalServerError).SendString("db error")
+			},
+		},
+		{
+			name: "handler returns context error",
+			handler: func(c *fiber.Ctx) error {
+				select {
+				case <-c.UserContext().Done():
+					return c.UserContext().Err()
+				case <-time.After(time.Second):
+					return c.SendString("too late")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(Timeout(50 * time.Millisecond))
+			app.Get("/slow", tt.handler)
+
+			start := time.Now()
+			resp, err := app.Test(httptest.NewRequest("GET", "/slow", nil))
+			require.NoError(t, err)
+
+			assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+			body, _ := io.ReadAll(resp.Body)
+			assert.JSONEq(t, `{"code":"TIMEOUT","message":"request timed out"}`, string(body))
+			assert.Less(t, time.Since(start), 500*time.Millisecond)
+		})
+	}
+}
+
+func TestTimeout_SlowCommittedWriteKept(t *testing.T) {
+	// Deadline хэтэрсэн ч амжилттай бичигдсэн хариуг 503-аар солихгүй
+	// (client амжилттай POST-оо дахин илгээхээс сэргийлнэ)
+	app := fiber.New()
+	app.Use(Timeout(50 * time.Millisecond))
+	app.Post("/create", func(c *fiber.Ctx) error {
+		time.Sleep(200 * time.Millisecond)
+		return c.Status(fiber.StatusCreated).SendString("created")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/create", nil))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "created", string(body))
+}
+
+func TestTimeout_FastHandlerKeepsResponse(t *testing.T) {
+	app := fiber.New()
+	app.Use(Timeout(time.Second))
+	app.Get("/ok", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/missing", func(c *fiber.Ctx) error { return fiber.ErrNotFound })
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/ok", nil))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "ok", string(body))
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/missing", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestTimeout_PanicReachesRecover(t *testing.T) {
+	app := fiber.New()
+	app.Use(fbrecover.New())
+	app.Use(Timeout(time.Second))
+	app.Get("/panic", func(c *fiber.Ctx) error { panic("boom") })
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/panic", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestTimeout_RouteLevelShorterWins(t *testing.T) {
+	// Global болон route-level Timeout давхцвал богино deadline үйлчилнэ
+	app := fiber.New()
+	app.Use(Timeout(time.Second))
+	app.Get("/slow", Timeout(50*time.Millisecond), func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.UserContext().Err()
+	})
+
+	start := time.Now()
+	resp, err := app.Test(httptest.NewRequest("GET", "/slow", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestTimeout_ZeroDisables(t *testing.T) {
+	app := fiber.New()
+	app.Use(Timeout(0))
+	app.Get("/test", func(c *fiber.Ctx) error {
+		_, ok := c.UserContext().Deadline()
+		assert.False(t, ok)
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/test", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
 func TestDefaultMaxPageSize(t *testing.T) {
 	assert.Equal(t, 100, DefaultMaxPageSize)
 }
@@ -0,0 +1,73 @@
+// Package middleware provides HTTP middlewares
+//
+// File: recovery.go
+// Description: Panic recovery that logs the panic value and stack trace via zap
+/*
+Package middleware нь HTTP middleware-уудыг агуулна.
+
+Энэ файл нь handler-т гарсан panic-ийг барьж, panic-ийн утга болон stack
+trace-ийг zap-аар log бичих middleware-ийг тодорхойлно. Fiber-ийн recover
+middleware нь stack-ийг stdout руу хэвлэдэг тул structured log-д орохгүй.
+
+Client руу stack trace огт илгээгдэхгүй: хариуг ErrorHandler нь бусад 500
+алдаатай ижил ерөнхий JSON-оор ("internal server error") бичнэ.
+
+Ашиглалт:
+
+	app.Use(middleware.Recovery(log)) // хамгийн эхэнд бүртгэнэ
+*/
+package middleware
+
+import (
+	"fmt"           // Panic утгыг string болгох
+	"runtime/debug" // Stack trace
+
+	"templatev25/internal/requestctx" // Request ID (RequestID middleware-ээс)
+
+	"github.com/gofiber/fiber/v2" // Web framework
+	"go.uber.org/zap"             // Structured logging
+)
+
+// errPanicRecovered нь panic-ийн оронд ErrorHandler руу дамжих ерөнхий алдаа
+// (panic-ийн утга client руу гарахгүй)
+var errPanicRecovered = fiber.NewError(fiber.StatusInternalServerError, "internal server error")
+
+// Recovery нь panic-ийг барьж log бичээд 500 алдаа буцаах middleware үүсгэнэ.
+//
+// Log format:
+//
+//	{
+//	    "level": "error",
+//	    "msg": "panic_recovered",
+//	    "panic": "runtime error: index out of range [1] with length 0",
+//	    "stack": "goroutine 1 [running]:\n...",
+//	    "method": "GET",
+//	    "path": "/user",
+//	    "req_id": "uuid"
+//	}
+//
+// Parameters:
+//   - log: Zap logger (nil бол zap.NewNop)
+//
+// Returns:
+//   - fiber.Handler: Middleware function
+func Recovery(log *zap.Logger) fiber.Handler {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error("panic_recovered",
+					zap.String("panic", fmt.Sprintf("%v", r)),
+					zap.String("stack", string(debug.Stack())),
+					zap.String("method", c.Method()),
+					zap.String("path", c.OriginalURL()),
+					zap.String("req_id", requestctx.GetRequestID(c.UserContext())),
+				)
+				err = errPanicRecovered
+			}
+		}()
+		return c.Next()
+	}
+}
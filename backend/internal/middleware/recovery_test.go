@@ -0,0 +1,97 @@
+// Package middleware provides HTTP middlewares
+//
+// File: recovery_test.go
+// Description: Unit tests for zap-based panic recovery
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecovery(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	log := zap.New(core)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+	app.Use(Recovery(log))
+	app.Use(RequestID())
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("secret db password leaked")
+	})
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	t.Run("panic returns sanitized 500", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/panic", nil)
+		req.Header.Set(HeaderRequestID, "req-123")
+
+		res, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &body))
+		assert.Equal(t, "INTERNAL_ERROR", body["code"])
+		assert.Equal(t, "internal server error", body["message"])
+		assert.NotContains(t, string(raw), "secret")
+		assert.NotContains(t, string(raw), "goroutine")
+
+		entries := logs.FilterMessage("panic_recovered").All()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "secret db password leaked", fields["panic"])
+		assert.Contains(t, fields["stack"], "goroutine")
+		assert.Equal(t, "/panic", fields["path"])
+		assert.Equal(t, "req-123", fields["req_id"])
+	})
+
+	t.Run("no panic passes through", func(t *testing.T) {
+		logs.TakeAll()
+
+		res, err := app.Test(httptest.NewRequest("GET", "/ok", nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Zero(t, logs.Len())
+	})
+}
+
+// faultingHandler нь stack trace-д нэрээрээ харагдах ёстой panic гаргагч handler
+func faultingHandler(c *fiber.Ctx) error {
+	var items []int
+	return c.SendString(string(rune(items[1])))
+}
+
+func TestRecovery_ThroughTimeoutLogsOriginalStack(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(zap.NewNop())})
+	app.Use(Recovery(zap.New(core)))
+	app.Use(Timeout(time.Second))
+	app.Get("/panic", faultingHandler)
+
+	res, err := app.Test(httptest.NewRequest("GET", "/panic", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+
+	entries := logs.FilterMessage("panic_recovered").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	// Timeout-оор дамжсан ч анхны panic-ийн утга
+	assert.Contains(t, fields["panic"], "index out of range")
+	// Stack нь алдаа гарсан handler-ийг заана
+	assert.Contains(t, fields["stack"], "faultingHandler")
+}
@@ -22,6 +22,9 @@ package middleware
 import (
 	"context"
 
+	"templatev25/internal/http/locals"
+	"templatev25/internal/requestctx"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -67,13 +70,11 @@ const (
 func RequestContext(log *zap.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Request ID авах (fbrequestid middleware-ээс)
-		reqID := c.Locals("requestid")
-		if reqID == nil {
-			reqID = c.Get("X-Request-Id", "")
+		reqIDStr, ok := locals.Get[string](c, "requestid")
+		if !ok {
+			reqIDStr = c.Get("X-Request-Id", "")
 		}
-
-		reqIDStr, ok := reqID.(string)
-		if !ok || reqIDStr == "" {
+		if reqIDStr == "" {
 			reqIDStr = "unknown"
 		}
 
@@ -83,6 +84,7 @@ func RequestContext(log *zap.Logger) fiber.Handler {
 		// Context-д хадгалах
 		ctx := c.UserContext()
 		ctx = context.WithValue(ctx, KeyRequestID, reqIDStr)
+		ctx = requestctx.With(ctx, reqIDStr) // repository давхаргад зориулсан
 		ctx = context.WithValue(ctx, KeyLogger, reqLog)
 
 		// Fiber context-д буцаах
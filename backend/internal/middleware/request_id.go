@@ -0,0 +1,67 @@
+// Package middleware provides implementation for middleware
+//
+// File: request_id.go
+// Description: Request ID (correlation ID) generation and propagation
+package middleware
+
+import (
+	"templatev25/internal/requestctx" // Request ID context helper
+
+	"github.com/gofiber/fiber/v2" // Web framework
+	"github.com/google/uuid"      // UUID v4
+)
+
+const (
+	// HeaderRequestID нь request ID дамжуулах HTTP header
+	HeaderRequestID = "X-Request-ID"
+
+	// LocalsRequestID нь c.Locals дахь request ID-ийн түлхүүр
+	LocalsRequestID = "request_id"
+
+	// maxRequestIDLength нь клиентээс ирсэн ID-ийн дээд урт (log-ийг хамгаална)
+	maxRequestIDLength = 128
+)
+
+// RequestID нь request бүрт correlation ID онооно.
+//
+// Клиент X-Request-ID header илгээсэн бөгөөд хүчинтэй бол түүнийг ашиглана,
+// үгүй бол UUID v4 үүсгэнэ. ID нь:
+//   - c.Locals("request_id") болон c.Locals("requestid") (fiber requestid-тэй нийцтэй)
+//   - c.UserContext() (requestctx.GetRequestID-ээр уншина)
+//   - X-Request-ID response header
+//
+// гэсэн газруудад хадгалагдана. Ингэснээр handler → service → repository
+// давхаргын log-ууд нэг ID-аар холбогдоно.
+//
+// Ашиглалт:
+//
+//	app.Use(middleware.RequestID())
+//	app.Use(middleware.RequestContext(log))
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(HeaderRequestID)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		c.Locals(LocalsRequestID, id)
+		c.Locals("requestid", id)
+		c.Set(HeaderRequestID, id)
+		c.SetUserContext(requestctx.With(c.UserContext(), id))
+
+		return c.Next()
+	}
+}
+
+// validRequestID нь клиентээс ирсэн ID-г log-д аюулгүй эсэхийг шалгана
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if ch := id[i]; ch < 0x21 || ch > 0x7e {
+			return false
+		}
+	}
+	return true
+}
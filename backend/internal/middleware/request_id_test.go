@@ -0,0 +1,116 @@
+// Package middleware provides HTTP middlewares
+//
+// File: request_id_test.go
+// Description: Unit tests for RequestID and request_id propagation to repositories
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"templatev25/internal/repository"
+	"templatev25/internal/requestctx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestRequestID_GeneratesUUID(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequestID())
+
+	var fromLocals, fromCtx string
+	app.Get("/", func(c *fiber.Ctx) error {
+		fromLocals, _ = c.Locals(LocalsRequestID).(string)
+		fromCtx = requestctx.GetRequestID(c.UserContext())
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+
+	id := resp.Header.Get(HeaderRequestID)
+	parsed, err := uuid.Parse(id)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), parsed.Version())
+	assert.Equal(t, id, fromLocals)
+	assert.Equal(t, id, fromCtx)
+}
+
+func TestRequestID_IncomingHeader(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequestID())
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "valid id is reused", header: "gateway-abc-123", keep: true},
+		{name: "id with spaces is replaced", header: "bad id", keep: false},
+		{name: "too long id is replaced", header: strings.Repeat("a", 200), keep: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set(HeaderRequestID, tt.header)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			got := resp.Header.Get(HeaderRequestID)
+			if tt.keep {
+				assert.Equal(t, tt.header, got)
+			} else {
+				assert.NotEqual(t, tt.header, got)
+				_, err := uuid.Parse(got)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// Request-ийн ID нь handler → repository хүртэл context-оор дамжиж repository log-д орно
+func TestRequestID_PropagatesToRepositoryLog(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(core)
+
+	// Хүрэх боломжгүй DB — query амжилтгүй ч repository log өмнө нь бичигдэнэ
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=x dbname=x sslmode=disable connect_timeout=1"), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               gormlogger.Discard,
+	})
+	require.NoError(t, err)
+	repo := repository.NewOrganizationRepository(db, log)
+
+	app := fiber.New()
+	app.Use(RequestID())
+	app.Use(RequestContext(log))
+	app.Get("/org", func(c *fiber.Ctx) error {
+		_, _ = repo.ByID(c.UserContext(), 1)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/org", nil), 5000)
+	require.NoError(t, err)
+	id := resp.Header.Get(HeaderRequestID)
+	require.NotEmpty(t, id)
+
+	entries := logs.FilterMessage("repo_call").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, id, fields["request_id"])
+	assert.Equal(t, "organizations", fields["table"])
+	assert.Equal(t, "ByID", fields["method"])
+}
@@ -0,0 +1,111 @@
+// Package middleware provides implementation for middleware
+//
+// File: sanitize.go
+// Description: JSON body-ийн string талбаруудаас HTML tag хасах (XSS хамгаалалт)
+package middleware
+
+import (
+	"encoding/json" // Body decode/encode
+	"html"          // Entity unescape
+	"strings"       // Content-Type check, field match
+
+	"github.com/gofiber/fiber/v2"        // Web framework
+	"github.com/gofiber/fiber/v2/utils"  // ToLower
+	"github.com/microcosm-cc/bluemonday" // HTML sanitizer
+)
+
+// strictPolicy нь бүх HTML tag-ийг хасдаг policy (goroutine-safe тул нэг л удаа үүсгэнэ)
+var strictPolicy = bluemonday.StrictPolicy()
+
+// Sanitize нь JSON body-ийн заасан string талбаруудыг bluemonday StrictPolicy-гоор
+// цэвэрлээд body-г дахин бичнэ. Handler нь цэвэрлэгдсэн утгыг BodyParser-аар авна.
+//
+// Дүрэм:
+//   - Хоосон body, JSON биш эсвэл object биш body-г өөрчлөхгүй (handler 400 буцаана)
+//   - Body байгаа ч Content-Type нь JSON биш бол 415 (form/xml-ээр тойрохоос сэргийлнэ)
+//   - Талбарын нэрийг том жижиг үсэг ялгахгүй харьцуулна ("Title" ч цэвэрлэгдэнэ) —
+//     encoding/json DTO талбарыг ийм байдлаар тааруулдаг
+//   - Заагдсан талбар string биш эсвэл байхгүй бол алгасна
+//   - Бусад талбарууд яг хэвээрээ үлдэнэ
+//
+// Зөвхөн tag хасна, HTML escape хийхгүй ("a & b" хэвээр) — escape нь гаралтын
+// (render) үүрэг тул хадгалсан текст давхар escape болохгүй.
+//
+// Ашиглалт:
+//
+//	router.Post("/", requireAuth, middleware.Sanitize("title", "text"), h.Create)
+func Sanitize(fields ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		body := c.Body()
+		if len(body) == 0 || len(fields) == 0 {
+			return c.Next()
+		}
+		if !strings.HasPrefix(utils.ToLower(c.Get(fiber.HeaderContentType)), fiber.MIMEApplicationJSON) {
+			return fiber.NewError(fiber.StatusUnsupportedMediaType, "request body must be application/json")
+		}
+
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(body, &obj); err != nil || obj == nil {
+			return c.Next()
+		}
+
+		changed := false
+		for key, raw := range obj {
+			if !matchesField(key, fields) {
+				continue
+			}
+			var v string
+			if err := json.Unmarshal(raw, &v); err != nil {
+				continue
+			}
+			clean := stripTags(v)
+			if clean == v {
+				continue
+			}
+			encoded, err := json.Marshal(clean)
+			if err != nil {
+				return err
+			}
+			obj[key] = encoded
+			changed = true
+		}
+
+		if changed {
+			sanitized, err := json.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			c.Request().SetBody(sanitized)
+		}
+		return c.Next()
+	}
+}
+
+// maxStripPasses bounds stripTags for pathological input; markup nested in entities
+// ("&amp;lt;b&amp;gt;" → "&lt;b&gt;" → "<b>" → "") needs one pass per level
+const maxStripPasses = 32
+
+// stripTags removes all HTML tags and returns the text unescaped. Entity-encoded
+// tags become real tags once unescaped, so the pass repeats until Sanitize makes
+// no further change. If the text does not settle within maxStripPasses, the
+// escaped Sanitize output is returned so unescaped markup never leaves here.
+func stripTags(v string) string {
+	for range maxStripPasses {
+		clean := html.UnescapeString(strictPolicy.Sanitize(v))
+		if clean == v {
+			return v
+		}
+		v = clean
+	}
+	return strictPolicy.Sanitize(v)
+}
+
+// matchesField reports whether key equals one of fields, ignoring case
+func matchesField(key string, fields []string) bool {
+	for _, f := range fields {
+		if strings.EqualFold(key, f) {
+			return true
+		}
+	}
+	return false
+}
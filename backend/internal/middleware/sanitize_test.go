@@ -0,0 +1,138 @@
+// Package middleware provides HTTP middlewares
+//
+// File: sanitize_test.go
+// Description: Unit tests for Sanitize (HTML stripping of JSON body fields)
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newsBody нь news handler-ийн BodyParser-аар авах бүтэцтэй ижил
+type newsBody struct {
+	Title    string `json:"title"`
+	Text     string `json:"text"`
+	ImageUrl string `json:"image_url"`
+}
+
+// newSanitizeApp нь Sanitize-ийн дараах body-г буцаадаг app үүсгэнэ
+func newSanitizeApp() *fiber.App {
+	app := fiber.New()
+	app.Post("/news", Sanitize("title", "text"), func(c *fiber.Ctx) error {
+		return c.Send(c.Body())
+	})
+	return app
+}
+
+func postSanitize(t *testing.T, app *fiber.App, contentType, body string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/news", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	res, err := app.Test(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	out, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res.StatusCode, string(out)
+}
+
+func TestSanitize_StripsHTML(t *testing.T) {
+	status, body := postSanitize(t, newSanitizeApp(), fiber.MIMEApplicationJSON,
+		`{"title":"<script>alert(1)</script>Hello","text":"<b>bold</b> news","image_url":"https://cdn/x.png?a=<b>"}`)
+	require.Equal(t, fiber.StatusOK, status)
+
+	var got newsBody
+	require.NoError(t, json.Unmarshal([]byte(body), &got))
+	assert.Equal(t, "Hello", got.Title)
+	assert.Equal(t, "bold news", got.Text)
+	// Заагаагүй талбар өөрчлөгдөхгүй
+	assert.Equal(t, "https://cdn/x.png?a=<b>", got.ImageUrl)
+}
+
+func TestSanitize_CaseInsensitiveKeys(t *testing.T) {
+	status, body := postSanitize(t, newSanitizeApp(), fiber.MIMEApplicationJSON,
+		`{"Title":"<script>alert(1)</script>Hello","TEXT":"<img src=x onerror=alert(1)>news"}`)
+	require.Equal(t, fiber.StatusOK, status)
+
+	// BodyParser нь "Title"/"TEXT"-ийг DTO талбарт тааруулдаг тул тэдгээр ч цэвэрлэгдэнэ
+	var got newsBody
+	require.NoError(t, json.Unmarshal([]byte(body), &got))
+	assert.Equal(t, "Hello", got.Title)
+	assert.Equal(t, "news", got.Text)
+}
+
+func TestSanitize_DoesNotEscapeText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "ampersand kept", in: "<b>Tom & Jerry</b>", want: "Tom & Jerry"},
+		{name: "entity-encoded tag stripped", in: "&lt;script&gt;alert(1)&lt;/script&gt;ok", want: "ok"},
+		{name: "quotes kept", in: `<i>"quoted"</i> it's`, want: `"quoted" it's`},
+		{name: "nested entity-encoded tag stripped", in: "&amp;amp;amp;lt;img src=x onerror=alert(1)&amp;amp;amp;gt;ok", want: "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, err := json.Marshal(map[string]string{"title": tt.in})
+			require.NoError(t, err)
+
+			status, body := postSanitize(t, newSanitizeApp(), fiber.MIMEApplicationJSON, string(in))
+			require.Equal(t, fiber.StatusOK, status)
+
+			var got newsBody
+			require.NoError(t, json.Unmarshal([]byte(body), &got))
+			assert.Equal(t, tt.want, got.Title)
+		})
+	}
+}
+
+func TestSanitize_LeavesBodyUntouched(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "plain text", body: `{"title":"Hello",  "text":"World"}`},
+		{name: "non-string field", body: `{"title":123,"text":["<b>x</b>"]}`},
+		{name: "invalid json", body: `{"title":"<b>`},
+		{name: "json array", body: `["<b>x</b>"]`},
+		{name: "empty body", body: ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := postSanitize(t, newSanitizeApp(), fiber.MIMEApplicationJSON, tt.body)
+			assert.Equal(t, fiber.StatusOK, status)
+			assert.Equal(t, tt.body, body)
+		})
+	}
+}
+
+func TestSanitize_RejectsNonJSONBody(t *testing.T) {
+	status, _ := postSanitize(t, newSanitizeApp(), fiber.MIMEApplicationForm, "title=%3Cscript%3E")
+	assert.Equal(t, fiber.StatusUnsupportedMediaType, status)
+
+	status, _ = postSanitize(t, newSanitizeApp(), "application/json; charset=utf-8", `{"title":"<i>x</i>"}`)
+	assert.Equal(t, fiber.StatusOK, status)
+}
+
+func TestStripTags_NeverReturnsMarkup(t *testing.T) {
+	// Entity-ээр хэчнээн давхар encode хийсэн tag ч бодит tag болж гарах ёсгүй
+	in := "&lt;img src=x onerror=alert(1)&gt;"
+	for range maxStripPasses + 2 {
+		in = strings.ReplaceAll(in, "&", "&amp;")
+		assert.NotContains(t, stripTags(in), "<")
+	}
+}
@@ -17,7 +17,8 @@ Package middleware нь HTTP middleware-уудыг агуулна.
 Security headers:
   - X-Content-Type-Options: MIME sniffing хамгаалалт
   - X-Frame-Options: Clickjacking хамгаалалт
-  - Content-Security-Policy: XSS, injection хамгаалалт
+  - X-XSS-Protection: Хуучин browser-ийн XSS filter
+  - Content-Security-Policy: XSS, injection хамгаалалт (SERVER_CSP-ээр солино)
   - Referrer-Policy: Referrer мэдээлэл хязгаарлах
   - Permissions-Policy: Browser features хязгаарлах
 */
@@ -25,10 +26,14 @@ package middleware
 
 import (
 	"context" // Context with timeout
-	"fmt"     // Format strings
+	"errors"  // DeadlineExceeded check
+	"strconv" // Page size rewrite
 	"strings" // String operations
 	"time"    // Duration
 
+	"git.gerege.mn/backend-packages/ctx"  // Request ID helper
+	"git.gerege.mn/backend-packages/resp" // Response struct
+
 	"github.com/gofiber/fiber/v2" // Web framework
 )
 
@@ -113,24 +118,38 @@ func HTTPSRedirect(enabled bool) fiber.Handler {
 //	  - Энэ хуудсыг iframe-д оруулахыг хориглоно
 //	  - Clickjacking халдлагаас хамгаална
 //
-//	Referrer-Policy: no-referrer
-//	  - Бусад сайт руу Referrer header илгээхгүй
-//	  - Хэрэглэгчийн privacy хамгаална
+//	X-XSS-Protection: 1; mode=block
+//	  - XSS filter-тэй хуучин browser-т хуудсыг блоклуулна
+//
+//	Referrer-Policy: strict-origin-when-cross-origin
+//	  - Өөр origin руу зөвхөн origin-ийг (path, query-гүй) илгээнэ
+//	  - HTTPS → HTTP үед Referrer илгээхгүй
 //
 //	Content-Security-Policy (CSP):
 //	  - XSS, data injection халдлагаас хамгаална
-//	  - Swagger docs-д тусгай CSP (inline script, Google Fonts зөвшөөрнө)
+//	  - csp өгөөгүй (эсвэл хоосон) бол cspDefault
+//	  - /docs/* дээр тохируулсан CSP хэрэглэхгүй, Swagger UI-д зориулсан
+//	    тусгай CSP тавина (inline script, Google Fonts зөвшөөрнө)
 //
 //	Permissions-Policy:
 //	  - Browser features (geolocation, microphone, camera) хориглоно
 //
+// Parameters:
+//   - csp: Optional Content-Security-Policy (cfg SERVER_CSP)
+//
 // Returns:
 //   - fiber.Handler: Middleware function
 //
 // Ашиглалт:
 //
 //	app.Use(middleware.SecurityHeaders())
-func SecurityHeaders() fiber.Handler {
+//	app.Use(middleware.SecurityHeaders(localconfig.LoadServerConfig().CSP))
+func SecurityHeaders(csp ...string) fiber.Handler {
+	policy := cspDefault
+	if len(csp) > 0 && strings.TrimSpace(csp[0]) != "" {
+		policy = strings.TrimSpace(csp[0])
+	}
+
 	return func(c *fiber.Ctx) error {
 		// ============================================================
 		// BASIC SECURITY HEADERS
@@ -142,8 +161,11 @@ func SecurityHeaders() fiber.Handler {
 		// Clickjacking хамгаалалт
 		c.Set("X-Frame-Options", "DENY")
 
+		// Хуучин browser-ийн XSS filter
+		c.Set("X-XSS-Protection", "1; mode=block")
+
 		// Referrer хязгаарлалт
-		c.Set("Referrer-Policy", "no-referrer")
+		c.Set("Referrer-Policy", "strict-origin-when-cross-origin")
 
 		// ============================================================
 		// CSP - Use pre-computed headers for performance
@@ -154,9 +176,9 @@ func SecurityHeaders() fiber.Handler {
 			return c.Next()
 		}
 
-		// Default strict CSP
+		// Тохируулсан эсвэл default strict CSP
 		if c.GetRespHeader("Content-Security-Policy") == "" {
-			c.Set("Content-Security-Policy", cspDefault)
+			c.Set("Content-Security-Policy", policy)
 		}
 
 		// Permissions policy (pre-computed)
@@ -209,6 +231,9 @@ const DefaultMaxPageSize = 100
 // DefaultMinPageSize нь нэг хуудсанд хамгийн бага бичлэгийн тоо
 const DefaultMinPageSize = 1
 
+// DefaultPageSize нь size=0 (эсвэл сөрөг) ирэхэд хэрэглэх хэмжээ
+const DefaultPageSize = 10
+
 // PaginationLimit нь pagination параметрүүдийг хязгаарлах middleware буцаана.
 // Хэт их мэдээлэл татаж авахаас сэргийлнэ.
 //
@@ -219,11 +244,13 @@ const DefaultMinPageSize = 1
 //   - fiber.Handler: Middleware function
 //
 // Query parameters:
-//   - size/pageSize: Нэг хуудсанд хэдэн бичлэг
+//   - size/pageSize: Нэг хуудсанд хэдэн бичлэг. [1, maxSize] хооронд
+//     хавчуулж query string-д буцааж бичнэ (size=500 → 100, size=0 → 10),
+//     тиймээс handler-ийн common.PaginationQuery хязгаарлагдсан утгыг авна.
 //   - page: Хуудасны дугаар (1-ээс эхэлнэ)
 //
 // Response:
-//   - 400 Bad Request (хязгаар хэтэрсэн бол)
+//   - 400 Bad Request (page сөрөг бол)
 //
 // Ашиглалт:
 //
@@ -235,26 +262,13 @@ func PaginationLimit(maxSize ...int) fiber.Handler {
 	}
 
 	return func(c *fiber.Ctx) error {
-		// Size параметр шалгах (size эсвэл pageSize)
-		size := c.QueryInt("size", 0)
-		if size == 0 {
-			size = c.QueryInt("pageSize", 0)
-		}
-
-		// Size хязгаар шалгах
-		if size > max {
-			return fiber.NewError(
-				fiber.StatusBadRequest,
-				fmt.Sprintf("page size too large, maximum is %d", max),
-			)
-		}
-
-		// Size сөрөг тоо байх ёсгүй
-		if size < 0 {
-			return fiber.NewError(
-				fiber.StatusBadRequest,
-				"page size must be positive",
-			)
+		// Size параметр хавчуулах (size эсвэл pageSize). Ирээгүй бол хөндөхгүй.
+		args := c.Request().URI().QueryArgs()
+		for _, key := range []string{"size", "pageSize"} {
+			if !args.Has(key) {
+				continue
+			}
+			args.Set(key, strconv.Itoa(clampPageSize(c.QueryInt(key, DefaultPageSize), max)))
 		}
 
 		// Page параметр шалгах
@@ -270,40 +284,93 @@ func PaginationLimit(maxSize ...int) fiber.Handler {
 	}
 }
 
+// clampPageSize нь size-ийг [DefaultMinPageSize, max] хооронд оруулна.
+// 0 эсвэл сөрөг бол DefaultPageSize.
+func clampPageSize(size, max int) int {
+	switch {
+	case size < DefaultMinPageSize:
+		return min(DefaultPageSize, max)
+	case size > max:
+		return max
+	default:
+		return size
+	}
+}
+
 // ============================================================
 // TIMEOUT
 // ============================================================
 
+// TimeoutCode нь хугацаа хэтэрсэн request-ийн response code
+const TimeoutCode = "TIMEOUT"
+
 // Timeout нь request-д хатуу хугацааны хязгаар тавих middleware буцаана.
-// Удаан ажиллаж байгаа request-үүдийг таслана.
+// Удаан ажиллаж байгаа request-үүдийг 503 хариугаар таслана.
 //
 // Parameters:
-//   - d: Timeout хугацаа (жишээ: 5*time.Second)
+//   - d: Timeout хугацаа (жишээ: 5*time.Second). d <= 0 бол middleware юу ч хийхгүй.
 //
 // Returns:
 //   - fiber.Handler: Middleware function
 //
 // Хэрхэн ажиллах:
-//  1. Context-д timeout нэмнэ
+//  1. c.UserContext()-оос deadline-тэй context үүсгэж c.SetUserContext-оор хадгална
 //  2. Service layer c.UserContext() авахад timeout-тэй context ирнэ
-//  3. Timeout дуусвал context.DeadlineExceeded алдаа буцаана
+//  3. c.Next()-ийг шууд (нэг goroutine-д) ажиллуулна
+//  4. Handler буцсаны дараа deadline хэтэрсэн бөгөөд handler амжилттай (2xx/3xx)
+//     хариу бичээгүй бол хариуг 503 TIMEOUT-оор солино
+//
+// Handler-ийг таслахгүй: context cancel болмогц DB, HTTP client зэрэг
+// context-ийг хүндэтгэдэг дуудлага шууд буцна. Context-ийг үл тоосон handler
+// дуустлаа ажиллана (fiber.Ctx-ийг өөр goroutine-оос хуваалцахгүй).
+//
+// Handler commit хийсэн (2xx/3xx) хариуг 503-аар дарж бичихгүй: эс бөгөөс
+// client амжилттай болсон non-idempotent POST-оо дахин илгээнэ.
+//
+// Global болон route-level Timeout давхарлаж болно — богино нь үйлчилнэ.
 //
 // Ашиглалт:
 //
-//	// Route-д 5 секундын timeout
-//	app.Get("/slow", middleware.Timeout(5*time.Second), handler.Slow)
+//	// Бүх route-д (wire_security.go, SERVER_REQUEST_TIMEOUT)
+//	app.Use(middleware.Timeout(srvCfg.RequestTimeout))
+//
+//	// Route group-д
+//	api := app.Group("/api", middleware.Timeout(5*time.Second))
 //
-//	// Route group-д timeout
-//	api := app.Group("/api", middleware.Timeout(10*time.Second))
+// Response (503):
+//
+//	{
+//	    "code": "TIMEOUT",
+//	    "request_id": "...",
+//	    "message": "request timed out"
+//	}
 func Timeout(d time.Duration) fiber.Handler {
+	if d <= 0 {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+
 	return func(c *fiber.Ctx) error {
 		// Context-д timeout нэмэх
-		ctx, cancel := context.WithTimeout(c.UserContext(), d)
+		tctx, cancel := context.WithTimeout(c.UserContext(), d)
 		defer cancel() // Resource cleanup
 
 		// Шинэ context-ийг Fiber-д буцаах
-		c.SetUserContext(ctx)
-
-		return c.Next()
+		c.SetUserContext(tctx)
+
+		err := c.Next()
+		if errors.Is(tctx.Err(), context.DeadlineExceeded) && !committed(c, err) {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(resp.APIResponse{
+				Code:      TimeoutCode,
+				RequestID: ctx.RequestID(c),
+				Message:   "request timed out",
+			})
+		}
+		return err
 	}
 }
+
+// committed нь handler алдаагүй буцаж 2xx/3xx хариу бичсэн эсэхийг шалгана
+func committed(c *fiber.Ctx, err error) bool {
+	status := c.Response().StatusCode()
+	return err == nil && status >= fiber.StatusOK && status < fiber.StatusBadRequest
+}
@@ -23,6 +23,7 @@ type SessionData struct {
 	CreatedAt      time.Time
 	ExpiresAt      time.Time
 	LastActivityAt time.Time
+	ImpersonatedBy int
 }
 
 // SessionStore interface defines methods needed by the session auth middleware
@@ -128,6 +129,22 @@ func RequireLocalAuth(sessionStore SessionStore) fiber.Handler {
 	return SessionAuth(sessionStore)
 }
 
+// DenyImpersonated rejects the request with 403 when the session was created by
+// admin impersonation. Register it after SessionAuth on security-sensitive routes
+// (MFA, password, session revocation) so a support session cannot change the
+// target's credentials or sign them out.
+func DenyImpersonated() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if session, ok := c.Locals("session").(*SessionData); ok && session.ImpersonatedBy != 0 {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"message": "not allowed in an impersonation session",
+			})
+		}
+		return c.Next()
+	}
+}
+
 // Helper to extract bearer token from Authorization header
 func extractBearerToken(c *fiber.Ctx) string {
 	auth := c.Get("Authorization")
@@ -0,0 +1,45 @@
+// Package middleware provides HTTP middlewares
+//
+// File: session_auth_test.go
+// Description: Unit tests for DenyImpersonated
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenyImpersonated(t *testing.T) {
+	tests := []struct {
+		name    string
+		session *SessionData
+		want    int
+	}{
+		{name: "impersonation session is rejected", session: &SessionData{UserID: 42, ImpersonatedBy: 1}, want: fiber.StatusForbidden},
+		{name: "own session passes", session: &SessionData{UserID: 42}, want: fiber.StatusNoContent},
+		{name: "no session passes", session: nil, want: fiber.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				if tt.session != nil {
+					c.Locals("session", tt.session)
+				}
+				return c.Next()
+			})
+			app.Post("/mfa/totp/setup", DenyImpersonated(), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("POST", "/mfa/totp/setup", nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Package middleware provides implementation for middleware
+//
+// File: tenant.go
+// Description: Stores the deployment tenant ID in the request context
+package middleware
+
+import (
+	localconfig "templatev25/internal/config" // SERVER_TENANT_ID
+	"templatev25/internal/requestctx"         // Tenant ID context helper
+
+	"github.com/gofiber/fiber/v2" // Web framework
+)
+
+// Tenant нь SERVER_TENANT_ID-г request бүрийн c.UserContext()-д хадгална.
+//
+// RequestLogger нь APILog.TenantID-г, NotificationService нь Notification.TenantID-г
+// requestctx.GetTenantID-ээр эндээс уншина. sc.TenantID хоосон бол юу ч хийхгүй.
+//
+// Ашиглалт:
+//
+//	app.Use(middleware.RequestID())
+//	app.Use(middleware.Tenant(mwCfg.Server))
+func Tenant(sc *localconfig.ServerConfig) fiber.Handler {
+	tenantID := sc.TenantID
+	return func(c *fiber.Ctx) error {
+		if tenantID != "" {
+			c.SetUserContext(requestctx.WithTenantID(c.UserContext(), tenantID))
+		}
+		return c.Next()
+	}
+}
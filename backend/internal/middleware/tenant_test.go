@@ -0,0 +1,49 @@
+// Package middleware provides HTTP middlewares
+//
+// File: tenant_test.go
+// Description: Unit tests for Tenant middleware
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	localconfig "templatev25/internal/config"
+	"templatev25/internal/requestctx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenant(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID string
+	}{
+		{name: "configured tenant is stored", tenantID: "gerege"},
+		{name: "empty tenant leaves context untouched", tenantID: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(RequestID())
+			app.Use(Tenant(&localconfig.ServerConfig{TenantID: tt.tenantID}))
+
+			var gotTenant, gotRequestID string
+			app.Get("/", func(c *fiber.Ctx) error {
+				gotTenant = requestctx.GetTenantID(c.UserContext())
+				gotRequestID = requestctx.GetRequestID(c.UserContext())
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			require.NoError(t, err)
+
+			assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+			assert.Equal(t, tt.tenantID, gotTenant)
+			assert.Equal(t, resp.Header.Get(HeaderRequestID), gotRequestID, "request id survives")
+		})
+	}
+}
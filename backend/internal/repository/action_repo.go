@@ -72,7 +72,7 @@ func (r *actionRepository) List(ctx context.Context, q dto.ActionQuery) ([]domai
 func (r *actionRepository) ByID(ctx context.Context, id int64) (domain.Action, error) {
 	var m domain.Action
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
-		return domain.Action{}, err
+		return domain.Action{}, domain.WrapNotFound(err, "action not found")
 	}
 	return m, nil
 }
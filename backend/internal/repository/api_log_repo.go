@@ -72,6 +72,9 @@ func (r *apiLogRepository) List(ctx context.Context, q dto.APILogListQuery) ([]d
 	if q.StatusCode != nil {
 		tx = tx.Where("status_code = ?", *q.StatusCode)
 	}
+	if q.StatusGTE != nil {
+		tx = tx.Where("status_code >= ?", *q.StatusGTE)
+	}
 	if q.UserID != nil {
 		tx = tx.Where("user_id = ?", *q.UserID)
 	}
@@ -82,6 +85,17 @@ func (r *apiLogRepository) List(ctx context.Context, q dto.APILogListQuery) ([]d
 		tx = tx.Where("ip ILIKE ?", "%"+q.IP+"%")
 	}
 
+	from, to, err := q.Range()
+	if err != nil {
+		return nil, 0, 0, 0, domain.NewInvalidInput("invalid from/to date", err)
+	}
+	if from != nil {
+		tx = tx.Where("created_date >= ?", *from)
+	}
+	if to != nil {
+		tx = tx.Where("created_date < ?", *to)
+	}
+
 	var total int64
 	if err := tx.Count(&total).Error; err != nil {
 		return nil, 0, 0, 0, err
@@ -8,8 +8,11 @@ import (
 	"context"
 	"time"
 
+	dbtx "templatev25/internal/db"
 	"templatev25/internal/domain"
+	"templatev25/internal/softdelete"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -18,11 +21,13 @@ type AuthRepository interface {
 	// Credentials
 	GetCredentialByUserID(ctx context.Context, userID int) (*domain.UserCredential, error)
 	GetCredentialByEmail(ctx context.Context, email string) (*domain.UserCredential, error)
+	GetCredentialByProvider(ctx context.Context, provider, providerID string) (*domain.UserCredential, error)
 	CreateCredential(ctx context.Context, cred *domain.UserCredential) error
 	UpdateCredential(ctx context.Context, cred *domain.UserCredential) error
 	IncrementFailedAttempts(ctx context.Context, userID int) error
 	ResetFailedAttempts(ctx context.Context, userID int) error
-	LockAccount(ctx context.Context, userID int, until time.Time) error
+	// LockAccount нь түгжээгүй (эсвэл хугацаа нь дууссан) бол түгжиж true буцаана
+	LockAccount(ctx context.Context, userID int, until time.Time) (bool, error)
 	UnlockAccount(ctx context.Context, userID int) error
 
 	// MFA TOTP
@@ -48,11 +53,14 @@ type AuthRepository interface {
 	UpdateSessionActivity(ctx context.Context, id string) error
 	RevokeSession(ctx context.Context, id string, reason string) error
 	RevokeAllUserSessions(ctx context.Context, userID int, reason string) error
+	RevokeOtherUserSessions(ctx context.Context, userID int, exceptSessionID string, reason string) error
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
 
 	// Login History
 	CreateLoginHistory(ctx context.Context, history *domain.LoginHistory) error
 	GetLoginHistory(ctx context.Context, userID int, limit int) ([]domain.LoginHistory, error)
 	GetRecentLoginHistory(ctx context.Context, userID int, since time.Time) ([]domain.LoginHistory, error)
+	DeleteOldLoginHistory(ctx context.Context, retentionDays int) (int64, error)
 
 	// Security Audit Trail
 	CreateAuditTrail(ctx context.Context, audit *domain.SecurityAuditTrail) error
@@ -67,15 +75,20 @@ type AuthRepository interface {
 	UpdateUserStatus(ctx context.Context, userID int, status string, reason string, changedBy int) error
 	UpdateUserLoginStats(ctx context.Context, userID int) error
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetUserByID(ctx context.Context, userID int) (*domain.User, error)
+
+	// GDPR erasure
+	DeleteByUserID(ctx context.Context, userID int) error
 }
 
 type authRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	log *zap.Logger
 }
 
-// NewAuthRepository creates a new auth repository instance
-func NewAuthRepository(db *gorm.DB) AuthRepository {
-	return &authRepository{db: db}
+// NewAuthRepository creates a new auth repository instance (nil log disables debug logging)
+func NewAuthRepository(db *gorm.DB, log *zap.Logger) AuthRepository {
+	return &authRepository{db: db, log: orNop(log)}
 }
 
 // ============================================================
@@ -83,35 +96,65 @@ func NewAuthRepository(db *gorm.DB) AuthRepository {
 // ============================================================
 
 func (r *authRepository) GetCredentialByUserID(ctx context.Context, userID int) (*domain.UserCredential, error) {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "GetCredentialByUserID")
+	defer span.End()
+
 	var cred domain.UserCredential
 	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&cred).Error
 	if err != nil {
-		return nil, err
+		return nil, domain.WrapNotFound(err, "credentials not found")
 	}
 	return &cred, nil
 }
 
 func (r *authRepository) GetCredentialByEmail(ctx context.Context, email string) (*domain.UserCredential, error) {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "GetCredentialByEmail")
+	defer span.End()
+
 	var cred domain.UserCredential
 	err := r.db.WithContext(ctx).
 		Joins("JOIN users ON users.id = user_credentials.user_id").
-		Where("users.email = ? AND users.deleted_date IS NULL", email).
+		Scopes(softdelete.ActiveOn("users")).
+		Where("users.email = ?", email).
 		First(&cred).Error
 	if err != nil {
-		return nil, err
+		return nil, domain.WrapNotFound(err, "credentials not found")
+	}
+	return &cred, nil
+}
+
+func (r *authRepository) GetCredentialByProvider(ctx context.Context, provider, providerID string) (*domain.UserCredential, error) {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "GetCredentialByProvider")
+	defer span.End()
+
+	var cred domain.UserCredential
+	err := r.db.WithContext(ctx).
+		Where("oauth_provider = ? AND oauth_provider_id = ?", provider, providerID).
+		First(&cred).Error
+	if err != nil {
+		return nil, domain.WrapNotFound(err, "credentials not found")
 	}
 	return &cred, nil
 }
 
 func (r *authRepository) CreateCredential(ctx context.Context, cred *domain.UserCredential) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "CreateCredential")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Create(cred).Error
 }
 
 func (r *authRepository) UpdateCredential(ctx context.Context, cred *domain.UserCredential) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "UpdateCredential")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Save(cred).Error
 }
 
 func (r *authRepository) IncrementFailedAttempts(ctx context.Context, userID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "IncrementFailedAttempts")
+	defer span.End()
+
 	return r.db.WithContext(ctx).
 		Model(&domain.UserCredential{}).
 		Where("user_id = ?", userID).
@@ -119,6 +162,9 @@ func (r *authRepository) IncrementFailedAttempts(ctx context.Context, userID int
 }
 
 func (r *authRepository) ResetFailedAttempts(ctx context.Context, userID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "ResetFailedAttempts")
+	defer span.End()
+
 	return r.db.WithContext(ctx).
 		Model(&domain.UserCredential{}).
 		Where("user_id = ?", userID).
@@ -128,14 +174,22 @@ func (r *authRepository) ResetFailedAttempts(ctx context.Context, userID int) er
 		}).Error
 }
 
-func (r *authRepository) LockAccount(ctx context.Context, userID int, until time.Time) error {
-	return r.db.WithContext(ctx).
+func (r *authRepository) LockAccount(ctx context.Context, userID int, until time.Time) (bool, error) {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "LockAccount")
+	defer span.End()
+
+	// Зэрэг ирсэн буруу оролдлогууд нэг түгжээг давхар хэрэглэхгүй
+	res := r.db.WithContext(ctx).
 		Model(&domain.UserCredential{}).
-		Where("user_id = ?", userID).
-		Update("locked_until", until).Error
+		Where("user_id = ? AND (locked_until IS NULL OR locked_until <= ?)", userID, time.Now()).
+		Update("locked_until", until)
+	return res.RowsAffected > 0, res.Error
 }
 
 func (r *authRepository) UnlockAccount(ctx context.Context, userID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "UnlockAccount")
+	defer span.End()
+
 	return r.db.WithContext(ctx).
 		Model(&domain.UserCredential{}).
 		Where("user_id = ?", userID).
@@ -150,29 +204,44 @@ func (r *authRepository) UnlockAccount(ctx context.Context, userID int) error {
 // ============================================================
 
 func (r *authRepository) GetMFAByUserID(ctx context.Context, userID int) (*domain.UserMFATotp, error) {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_totp", "GetMFAByUserID")
+	defer span.End()
+
 	var mfa domain.UserMFATotp
 	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&mfa).Error
 	if err != nil {
-		return nil, err
+		return nil, domain.WrapNotFound(err, "mfa not found")
 	}
 	return &mfa, nil
 }
 
 func (r *authRepository) CreateMFA(ctx context.Context, mfa *domain.UserMFATotp) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_totp", "CreateMFA")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Create(mfa).Error
 }
 
 func (r *authRepository) UpdateMFA(ctx context.Context, mfa *domain.UserMFATotp) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_totp", "UpdateMFA")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Save(mfa).Error
 }
 
 func (r *authRepository) DeleteMFA(ctx context.Context, userID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_totp", "DeleteMFA")
+	defer span.End()
+
 	return r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
 		Delete(&domain.UserMFATotp{}).Error
 }
 
 func (r *authRepository) EnableMFA(ctx context.Context, userID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_totp", "EnableMFA")
+	defer span.End()
+
 	now := time.Now()
 	return r.db.WithContext(ctx).
 		Model(&domain.UserMFATotp{}).
@@ -184,6 +253,9 @@ func (r *authRepository) EnableMFA(ctx context.Context, userID int) error {
 }
 
 func (r *authRepository) DisableMFA(ctx context.Context, userID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_totp", "DisableMFA")
+	defer span.End()
+
 	return r.db.WithContext(ctx).
 		Model(&domain.UserMFATotp{}).
 		Where("user_id = ?", userID).
@@ -198,6 +270,9 @@ func (r *authRepository) DisableMFA(ctx context.Context, userID int) error {
 // ============================================================
 
 func (r *authRepository) GetBackupCodes(ctx context.Context, userID int) ([]domain.UserMFABackupCode, error) {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_backup_codes", "GetBackupCodes")
+	defer span.End()
+
 	var codes []domain.UserMFABackupCode
 	err := r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
@@ -207,6 +282,9 @@ func (r *authRepository) GetBackupCodes(ctx context.Context, userID int) ([]doma
 }
 
 func (r *authRepository) GetUnusedBackupCodes(ctx context.Context, userID int) ([]domain.UserMFABackupCode, error) {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_backup_codes", "GetUnusedBackupCodes")
+	defer span.End()
+
 	var codes []domain.UserMFABackupCode
 	err := r.db.WithContext(ctx).
 		Where("user_id = ? AND used_at IS NULL", userID).
@@ -215,16 +293,25 @@ func (r *authRepository) GetUnusedBackupCodes(ctx context.Context, userID int) (
 }
 
 func (r *authRepository) CreateBackupCodes(ctx context.Context, codes []domain.UserMFABackupCode) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_backup_codes", "CreateBackupCodes")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Create(&codes).Error
 }
 
 func (r *authRepository) DeleteBackupCodes(ctx context.Context, userID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_backup_codes", "DeleteBackupCodes")
+	defer span.End()
+
 	return r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
 		Delete(&domain.UserMFABackupCode{}).Error
 }
 
 func (r *authRepository) UseBackupCode(ctx context.Context, codeID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_mfa_backup_codes", "UseBackupCode")
+	defer span.End()
+
 	now := time.Now()
 	return r.db.WithContext(ctx).
 		Model(&domain.UserMFABackupCode{}).
@@ -237,19 +324,28 @@ func (r *authRepository) UseBackupCode(ctx context.Context, codeID int) error {
 // ============================================================
 
 func (r *authRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	ctx, span := startSpanLog(ctx, r.log, "sessions", "CreateSession")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Create(session).Error
 }
 
 func (r *authRepository) GetSession(ctx context.Context, id string) (*domain.Session, error) {
+	ctx, span := startSpanLog(ctx, r.log, "sessions", "GetSession")
+	defer span.End()
+
 	var session domain.Session
 	err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error
 	if err != nil {
-		return nil, err
+		return nil, domain.WrapNotFound(err, "session not found")
 	}
 	return &session, nil
 }
 
 func (r *authRepository) GetUserSessions(ctx context.Context, userID int) ([]domain.Session, error) {
+	ctx, span := startSpanLog(ctx, r.log, "sessions", "GetUserSessions")
+	defer span.End()
+
 	var sessions []domain.Session
 	err := r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
@@ -259,6 +355,9 @@ func (r *authRepository) GetUserSessions(ctx context.Context, userID int) ([]dom
 }
 
 func (r *authRepository) GetActiveUserSessions(ctx context.Context, userID int) ([]domain.Session, error) {
+	ctx, span := startSpanLog(ctx, r.log, "sessions", "GetActiveUserSessions")
+	defer span.End()
+
 	var sessions []domain.Session
 	err := r.db.WithContext(ctx).
 		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
@@ -268,6 +367,9 @@ func (r *authRepository) GetActiveUserSessions(ctx context.Context, userID int)
 }
 
 func (r *authRepository) UpdateSessionActivity(ctx context.Context, id string) error {
+	ctx, span := startSpanLog(ctx, r.log, "sessions", "UpdateSessionActivity")
+	defer span.End()
+
 	return r.db.WithContext(ctx).
 		Model(&domain.Session{}).
 		Where("id = ?", id).
@@ -275,6 +377,9 @@ func (r *authRepository) UpdateSessionActivity(ctx context.Context, id string) e
 }
 
 func (r *authRepository) RevokeSession(ctx context.Context, id string, reason string) error {
+	ctx, span := startSpanLog(ctx, r.log, "sessions", "RevokeSession")
+	defer span.End()
+
 	now := time.Now()
 	return r.db.WithContext(ctx).
 		Model(&domain.Session{}).
@@ -286,6 +391,9 @@ func (r *authRepository) RevokeSession(ctx context.Context, id string, reason st
 }
 
 func (r *authRepository) RevokeAllUserSessions(ctx context.Context, userID int, reason string) error {
+	ctx, span := startSpanLog(ctx, r.log, "sessions", "RevokeAllUserSessions")
+	defer span.End()
+
 	now := time.Now()
 	return r.db.WithContext(ctx).
 		Model(&domain.Session{}).
@@ -296,15 +404,52 @@ func (r *authRepository) RevokeAllUserSessions(ctx context.Context, userID int,
 		}).Error
 }
 
+// RevokeOtherUserSessions нь exceptSessionID-ээс бусад идэвхтэй session-уудыг цуцална ("sign out everywhere")
+func (r *authRepository) RevokeOtherUserSessions(ctx context.Context, userID int, exceptSessionID string, reason string) error {
+	ctx, span := startSpanLog(ctx, r.log, "sessions", "RevokeOtherUserSessions")
+	defer span.End()
+
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptSessionID).
+		Updates(map[string]interface{}{
+			"revoked_at":     now,
+			"revoked_reason": reason,
+		}).Error
+}
+
+// RevokedSessionRetention нь цуцлагдсан session-ийг устгахаас өмнө хадгалах хугацаа
+const RevokedSessionRetention = 30 * 24 * time.Hour
+
+// DeleteExpiredSessions нь хугацаа дууссан болон RevokedSessionRetention-оос
+// өмнө цуцлагдсан session-уудыг бүр мөсөн (soft delete биш) устгаж, устгасан мөрийн тоог буцаана.
+func (r *authRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	ctx, span := startSpanLog(ctx, r.log, "sessions", "DeleteExpiredSessions")
+	defer span.End()
+
+	now := time.Now()
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("expires_at < ? OR (revoked_at IS NOT NULL AND revoked_at < ?)", now, now.Add(-RevokedSessionRetention)).
+		Delete(&domain.Session{})
+	return res.RowsAffected, res.Error
+}
+
 // ============================================================
 // LOGIN HISTORY
 // ============================================================
 
 func (r *authRepository) CreateLoginHistory(ctx context.Context, history *domain.LoginHistory) error {
+	ctx, span := startSpanLog(ctx, r.log, "login_history", "CreateLoginHistory")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Create(history).Error
 }
 
 func (r *authRepository) GetLoginHistory(ctx context.Context, userID int, limit int) ([]domain.LoginHistory, error) {
+	ctx, span := startSpanLog(ctx, r.log, "login_history", "GetLoginHistory")
+	defer span.End()
+
 	var history []domain.LoginHistory
 	err := r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
@@ -315,6 +460,9 @@ func (r *authRepository) GetLoginHistory(ctx context.Context, userID int, limit
 }
 
 func (r *authRepository) GetRecentLoginHistory(ctx context.Context, userID int, since time.Time) ([]domain.LoginHistory, error) {
+	ctx, span := startSpanLog(ctx, r.log, "login_history", "GetRecentLoginHistory")
+	defer span.End()
+
 	var history []domain.LoginHistory
 	err := r.db.WithContext(ctx).
 		Where("user_id = ? AND created_date >= ?", userID, since).
@@ -323,15 +471,68 @@ func (r *authRepository) GetRecentLoginHistory(ctx context.Context, userID int,
 	return history, err
 }
 
+// DeleteOldLoginHistory нь retentionDays хоногоос хуучин нэвтрэлтийн түүхийг бүр мөсөн устгана
+func (r *authRepository) DeleteOldLoginHistory(ctx context.Context, retentionDays int) (int64, error) {
+	ctx, span := startSpanLog(ctx, r.log, "login_history", "DeleteOldLoginHistory")
+	defer span.End()
+
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("created_date < ?", cutoff).
+		Delete(&domain.LoginHistory{})
+	return res.RowsAffected, res.Error
+}
+
+// ============================================================
+// GDPR ERASURE
+// ============================================================
+
+// userAuthData нь DeleteByUserID-ийн бүрмөсөн устгах user_id-тай хүснэгтүүд.
+// security_audit_trail нь аюулгүй байдлын бүртгэл тул хадгалагдана.
+var userAuthData = []interface{}{
+	&domain.UserCredential{},
+	&domain.PasswordHistory{},
+	&domain.UserMFATotp{},
+	&domain.UserMFABackupCode{},
+	&domain.Session{},
+	&domain.RefreshToken{},
+	&domain.LoginHistory{},
+	&domain.EmailVerificationToken{},
+	&domain.EmailVerification{},
+	&domain.PasswordResetToken{},
+}
+
+// DeleteByUserID нь хэрэглэгчийн credential, session, MFA, login history зэргийг
+// нэг transaction-д бүрмөсөн устгана (context-д tx байвал түүнд нэгдэнэ).
+func (r *authRepository) DeleteByUserID(ctx context.Context, userID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "user_credentials", "DeleteByUserID")
+	defer span.End()
+
+	return dbtx.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		for _, model := range userAuthData {
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // ============================================================
 // SECURITY AUDIT TRAIL
 // ============================================================
 
 func (r *authRepository) CreateAuditTrail(ctx context.Context, audit *domain.SecurityAuditTrail) error {
+	ctx, span := startSpanLog(ctx, r.log, "security_audit_trail", "CreateAuditTrail")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Create(audit).Error
 }
 
 func (r *authRepository) GetAuditTrail(ctx context.Context, userID int, limit int) ([]domain.SecurityAuditTrail, error) {
+	ctx, span := startSpanLog(ctx, r.log, "security_audit_trail", "GetAuditTrail")
+	defer span.End()
+
 	var audit []domain.SecurityAuditTrail
 	err := r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
@@ -342,6 +543,9 @@ func (r *authRepository) GetAuditTrail(ctx context.Context, userID int, limit in
 }
 
 func (r *authRepository) GetAuditTrailByAction(ctx context.Context, userID int, action string, limit int) ([]domain.SecurityAuditTrail, error) {
+	ctx, span := startSpanLog(ctx, r.log, "security_audit_trail", "GetAuditTrailByAction")
+	defer span.End()
+
 	var audit []domain.SecurityAuditTrail
 	err := r.db.WithContext(ctx).
 		Where("user_id = ? AND action = ?", userID, action).
@@ -356,6 +560,9 @@ func (r *authRepository) GetAuditTrailByAction(ctx context.Context, userID int,
 // ============================================================
 
 func (r *authRepository) GetPasswordHistory(ctx context.Context, userID int, limit int) ([]domain.PasswordHistory, error) {
+	ctx, span := startSpanLog(ctx, r.log, "password_history", "GetPasswordHistory")
+	defer span.End()
+
 	var history []domain.PasswordHistory
 	err := r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
@@ -366,6 +573,9 @@ func (r *authRepository) GetPasswordHistory(ctx context.Context, userID int, lim
 }
 
 func (r *authRepository) CreatePasswordHistory(ctx context.Context, history *domain.PasswordHistory) error {
+	ctx, span := startSpanLog(ctx, r.log, "password_history", "CreatePasswordHistory")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Create(history).Error
 }
 
@@ -374,6 +584,9 @@ func (r *authRepository) CreatePasswordHistory(ctx context.Context, history *dom
 // ============================================================
 
 func (r *authRepository) UpdateUserStatus(ctx context.Context, userID int, status string, reason string, changedBy int) error {
+	ctx, span := startSpanLog(ctx, r.log, "users", "UpdateUserStatus")
+	defer span.End()
+
 	now := time.Now()
 	return r.db.WithContext(ctx).
 		Model(&domain.User{}).
@@ -387,6 +600,9 @@ func (r *authRepository) UpdateUserStatus(ctx context.Context, userID int, statu
 }
 
 func (r *authRepository) UpdateUserLoginStats(ctx context.Context, userID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "users", "UpdateUserLoginStats")
+	defer span.End()
+
 	now := time.Now()
 	return r.db.WithContext(ctx).
 		Model(&domain.User{}).
@@ -398,12 +614,31 @@ func (r *authRepository) UpdateUserLoginStats(ctx context.Context, userID int) e
 }
 
 func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, span := startSpanLog(ctx, r.log, "users", "GetUserByEmail")
+	defer span.End()
+
 	var user domain.User
 	err := r.db.WithContext(ctx).
-		Where("email = ? AND deleted_date IS NULL", email).
+		Scopes(softdelete.Active()).
+		Where("email = ?", email).
+		First(&user).Error
+	if err != nil {
+		return nil, domain.WrapNotFound(err, "user not found")
+	}
+	return &user, nil
+}
+
+func (r *authRepository) GetUserByID(ctx context.Context, userID int) (*domain.User, error) {
+	ctx, span := startSpanLog(ctx, r.log, "users", "GetUserByID")
+	defer span.End()
+
+	var user domain.User
+	err := r.db.WithContext(ctx).
+		Scopes(softdelete.Active()).
+		Where("id = ?", userID).
 		First(&user).Error
 	if err != nil {
-		return nil, err
+		return nil, domain.WrapNotFound(err, "user not found")
 	}
 	return &user, nil
 }
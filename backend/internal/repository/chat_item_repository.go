@@ -10,35 +10,49 @@ package repository
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 
+	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/ctx"
 	"git.gerege.mn/backend-packages/scopes"
 	"git.gerege.mn/backend-packages/utils"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ChatItemRepository interface {
 	List(ctx context.Context, q dto.ChatItemQuery) ([]domain.ChatItem, int64, int, int, error)
+	Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.ChatItem, int64, int, int, error)
 	ByID(ctx context.Context, id int) (domain.ChatItem, error)
 	Create(ctx context.Context, m domain.ChatItem) error
 	Update(ctx context.Context, id int, m domain.ChatItem) error
 	Delete(ctx context.Context, id int) error
 	FindByKey(ctx context.Context, key string) (domain.ChatItem, error)
+	AddTags(ctx context.Context, itemID int, tagIDs []int) error
+	RemoveTag(ctx context.Context, itemID, tagID int) error
+	GetTags(ctx context.Context, itemID int) ([]domain.Tag, error)
 }
 
 type chatItemRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	fullText bool // true: Search нь to_tsvector/to_tsquery, false: ILIKE
 }
 
 func NewChatItemRepository(db *gorm.DB) ChatItemRepository {
 	return &chatItemRepository{db: db}
 }
 
+// NewChatItemRepositoryWithFullText нь Search-ийг PostgreSQL full-text хайлтаар (fullText=true)
+// эсвэл ILIKE-аар (fullText=false) хийх repository үүсгэнэ (CHAT_SEARCH_FULLTEXT)
+func NewChatItemRepositoryWithFullText(db *gorm.DB, fullText bool) ChatItemRepository {
+	return &chatItemRepository{db: db, fullText: fullText}
+}
+
 func (r *chatItemRepository) FindByKey(ctx context.Context, key string) (domain.ChatItem, error) {
 	var item domain.ChatItem
 	err := r.db.WithContext(ctx).
@@ -77,10 +91,51 @@ func (r *chatItemRepository) List(ctx context.Context, q dto.ChatItemQuery) ([]d
 	return items, total, page, size, nil
 }
 
+// Search нь Answer талбараар хайна. Хайх үг хоосон бол бүх item-ийг (id DESC) хуудаслаж буцаана.
+//   - fullText: үг бүрийг prefix (үг:*) байдлаар AND-аар нэгтгэж ts_rank-аар эрэмбэлнэ
+//     (idx_chat_items_answer_fts GIN index)
+//   - эс бөгөөс: answer ILIKE '%query%' (%, _ тэмдэгтүүд escape хийгдэнэ)
+func (r *chatItemRepository) Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.ChatItem, int64, int, int, error) {
+	page, size, offset := utils.OffsetLimit(p)
+	query = strings.TrimSpace(query)
+
+	tx := r.db.WithContext(ctx).Model(&domain.ChatItem{})
+	order := clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Column{Name: "id"}, Desc: true}}}
+
+	if tsq := prefixTSQuery(query); r.fullText && tsq != "" {
+		tx = tx.Where("to_tsvector('simple', answer) @@ to_tsquery('simple', ?)", tsq)
+		order = clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(to_tsvector('simple', answer), to_tsquery('simple', ?)) DESC, id DESC",
+			Vars:               []interface{}{tsq},
+			WithoutParentheses: true,
+		}}
+	} else if !r.fullText && query != "" {
+		tx = tx.Where(`answer ILIKE ? ESCAPE '\'`, "%"+escapeLike(query)+"%")
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	var items []domain.ChatItem
+	if err := tx.Clauses(order).Offset(offset).Limit(size).Find(&items).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+	return items, total, page, size, nil
+}
+
+// escapeLike нь LIKE/ILIKE pattern-ийн тусгай тэмдэгтүүдийг (\, %, _) escape хийнэ
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 func (r *chatItemRepository) ByID(ctx context.Context, id int) (domain.ChatItem, error) {
 	var m domain.ChatItem
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
-		return domain.ChatItem{}, err
+	if err := r.db.WithContext(ctx).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("tags.name") }).
+		Where("id = ?", id).First(&m).Error; err != nil {
+		return domain.ChatItem{}, domain.WrapNotFound(err, "chat item not found")
 	}
 	return m, nil
 }
@@ -126,3 +181,55 @@ func (r *chatItemRepository) Delete(uctx context.Context, id int) error {
 
 	return r.db.WithContext(uctx).Where("id = ?", id).Updates(&m).Error
 }
+
+// ---------- Tags ----------
+
+// AddTags нь chat item-д tag-ууд ононо. Аль хэдийн оноогдсон tag-ийг алгасна.
+// Байхгүй tag ID байвал юу ч нэмэхгүйгээр ErrNotFound буцаана.
+func (r *chatItemRepository) AddTags(ctx context.Context, itemID int, tagIDs []int) error {
+	ids := slices.Compact(slices.Sorted(slices.Values(tagIDs)))
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var found int64
+		if err := tx.Model(&domain.Tag{}).Where("id IN ?", ids).Count(&found).Error; err != nil {
+			return err
+		}
+		if found != int64(len(ids)) {
+			return domain.NewNotFound("tag not found", nil)
+		}
+
+		links := make([]domain.ChatItemTag, len(ids))
+		for i, id := range ids {
+			links[i] = domain.ChatItemTag{ChatItemID: itemID, TagID: id}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+	})
+}
+
+// RemoveTag нь chat item-ээс tag салгана. Холбоос байхгүй бол ErrNotFound.
+func (r *chatItemRepository) RemoveTag(ctx context.Context, itemID, tagID int) error {
+	res := r.db.WithContext(ctx).
+		Where("chat_item_id = ? AND tag_id = ?", itemID, tagID).
+		Delete(&domain.ChatItemTag{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFound("chat item tag not found", nil)
+	}
+	return nil
+}
+
+// GetTags нь chat item-д оноогдсон tag-уудыг нэрээр эрэмбэлж буцаана
+func (r *chatItemRepository) GetTags(ctx context.Context, itemID int) ([]domain.Tag, error) {
+	tags := []domain.Tag{}
+	err := r.db.WithContext(ctx).
+		Joins("JOIN chat_item_tags cit ON cit.tag_id = tags.id").
+		Where("cit.chat_item_id = ?", itemID).
+		Order("tags.name").
+		Find(&tags).Error
+	return tags, err
+}
@@ -0,0 +1,72 @@
+// Package repository provides implementation for repository
+//
+// File: email_verification_repo.go
+// Description: Repository for 6-digit email verification codes (/verify/email)
+package repository
+
+import (
+	"context"
+	"time"
+
+	dbtx "templatev25/internal/db"
+	"templatev25/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// EmailVerificationRepository нь email_verifications хүснэгтийн үйлдлүүд
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, v *domain.EmailVerification) error
+	// LatestByCode нь хэрэглэгчийн тухайн hash-тай хамгийн сүүлийн кодыг буцаана
+	LatestByCode(ctx context.Context, userID int, codeHash string) (*domain.EmailVerification, error)
+	// MarkUsed нь кодыг ашигласан болгож хэрэглэгчийн email-ийг баталгаажсан болгоно (нэг transaction)
+	MarkUsed(ctx context.Context, id, userID int) error
+}
+
+type emailVerificationRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailVerificationRepository creates a new email verification repository instance
+func NewEmailVerificationRepository(db *gorm.DB) EmailVerificationRepository {
+	return &emailVerificationRepository{db: db}
+}
+
+func (r *emailVerificationRepository) Create(ctx context.Context, v *domain.EmailVerification) error {
+	return r.db.WithContext(ctx).Create(v).Error
+}
+
+func (r *emailVerificationRepository) LatestByCode(ctx context.Context, userID int, codeHash string) (*domain.EmailVerification, error) {
+	var v domain.EmailVerification
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND code = ?", userID, codeHash).
+		Order("id DESC").
+		First(&v).Error
+	if err != nil {
+		return nil, domain.WrapNotFound(err, "verification code not found")
+	}
+	return &v, nil
+}
+
+// MarkUsed нь used_at IS NULL нөхцөлтэй update хийнэ; зэрэг ирсэн хоёр
+// баталгаажуулалтын зөвхөн нэг нь амжилттай болж, нөгөө нь Conflict авна.
+func (r *emailVerificationRepository) MarkUsed(ctx context.Context, id, userID int) error {
+	now := time.Now()
+	return dbtx.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		res := tx.Model(&domain.EmailVerification{}).
+			Where("id = ? AND used_at IS NULL", id).
+			Update("used_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewConflict("verification code already used", nil)
+		}
+		return tx.Model(&domain.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"email_verified":    true,
+				"email_verified_at": now,
+			}).Error
+	})
+}
@@ -0,0 +1,70 @@
+// Package repository provides implementation for repository
+//
+// File: feature_flag_repo.go
+// Description: Repository for system-level feature flags (middleware.FeatureGate)
+package repository
+
+import (
+	"context"
+
+	"templatev25/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// FeatureFlagRepository нь feature_flags хүснэгтийн үйлдлүүд
+type FeatureFlagRepository interface {
+	List(ctx context.Context) ([]domain.FeatureFlag, error)
+	// GetByCode нь code-оор flag-ийг буцаана (олдохгүй бол domain.ErrNotFound)
+	GetByCode(ctx context.Context, code string) (domain.FeatureFlag, error)
+	// Enable/Disable нь enabled талбарыг солино (хугацааны хязгаар хэвээр)
+	Enable(ctx context.Context, code string) error
+	Disable(ctx context.Context, code string) error
+}
+
+type featureFlagRepository struct {
+	db *gorm.DB
+}
+
+// NewFeatureFlagRepository creates a new feature flag repository instance
+func NewFeatureFlagRepository(db *gorm.DB) FeatureFlagRepository {
+	return &featureFlagRepository{db: db}
+}
+
+func (r *featureFlagRepository) List(ctx context.Context) ([]domain.FeatureFlag, error) {
+	var items []domain.FeatureFlag
+	if err := r.db.WithContext(ctx).Order("code").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *featureFlagRepository) GetByCode(ctx context.Context, code string) (domain.FeatureFlag, error) {
+	var f domain.FeatureFlag
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&f).Error; err != nil {
+		return domain.FeatureFlag{}, domain.WrapNotFound(err, "feature flag not found")
+	}
+	return f, nil
+}
+
+func (r *featureFlagRepository) Enable(ctx context.Context, code string) error {
+	return r.setEnabled(ctx, code, true)
+}
+
+func (r *featureFlagRepository) Disable(ctx context.Context, code string) error {
+	return r.setEnabled(ctx, code, false)
+}
+
+// setEnabled нь code-той flag байхгүй бол domain.ErrNotFound буцаана
+func (r *featureFlagRepository) setEnabled(ctx context.Context, code string, enabled bool) error {
+	res := r.db.WithContext(ctx).Model(&domain.FeatureFlag{}).
+		Where("code = ?", code).
+		Update("enabled", enabled)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFound("feature flag not found", nil)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Package repository provides implementation for repository
+//
+// File: file_repo.go
+// Description: File storage repository (local disk)
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// FileRepository нь upload хийсэн файлыг хадгалж, нийтэд нээлттэй URL-ийг буцаана.
+// Одоогоор local disk; S3 зэрэг object storage нь энэ interface-ийг хэрэгжүүлээд
+// dependency.go-д солигдоно.
+type FileRepository interface {
+	Upload(ctx context.Context, file multipart.File, header *multipart.FileHeader, bucket string) (string, error)
+}
+
+// bucketPattern нь bucket нэрийг path traversal-аас хамгаална
+var bucketPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+type localFileRepository struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalFileRepository нь dir-т файл бичиж, baseURL + нэр хэлбэрийн URL буцаах repository үүсгэнэ.
+// Bucket нь файлын нэрийн угтвар болно (дэд хавтас биш), ингэснээр GET /file/:uuid шууд serve хийнэ.
+func NewLocalFileRepository(dir, baseURL string) FileRepository {
+	return &localFileRepository{dir: dir, baseURL: baseURL}
+}
+
+func (r *localFileRepository) Upload(ctx context.Context, file multipart.File, header *multipart.FileHeader, bucket string) (string, error) {
+	if !bucketPattern.MatchString(bucket) {
+		return "", fmt.Errorf("invalid bucket %q", bucket)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(r.dir, 0o750); err != nil {
+		return "", err
+	}
+
+	name := bucket + "-" + uuid.New().String() + strings.ToLower(filepath.Ext(header.Filename))
+	path := filepath.Clean(filepath.Join(r.dir, name))
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(out, file); err != nil {
+		_ = out.Close()
+		_ = os.Remove(path)
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(path)
+		return "", err
+	}
+	return r.baseURL + name, nil
+}
@@ -0,0 +1,70 @@
+// Package repository provides data access layer
+//
+// File: file_repo_test.go
+// Description: Unit tests for the local disk file repository
+package repository
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File["file"][0]
+}
+
+func TestLocalFileRepository_Upload(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "public")
+	repo := NewLocalFileRepository(dir, "https://cdn.example.com/file/")
+	header := newTestFileHeader(t, "Photo.PNG", []byte("png-bytes"))
+	file, err := header.Open()
+	require.NoError(t, err)
+	defer file.Close()
+
+	url, err := repo.Upload(context.Background(), file, header, "news")
+
+	require.NoError(t, err)
+	name := strings.TrimPrefix(url, "https://cdn.example.com/file/")
+	assert.True(t, strings.HasPrefix(name, "news-"), name)
+	assert.True(t, strings.HasSuffix(name, ".png"), name)
+	stored, err := os.ReadFile(filepath.Join(dir, name))
+	require.NoError(t, err)
+	assert.Equal(t, "png-bytes", string(stored))
+}
+
+func TestLocalFileRepository_RejectsInvalidBucket(t *testing.T) {
+	dir := t.TempDir()
+	repo := NewLocalFileRepository(dir, "/file/")
+	header := newTestFileHeader(t, "a.png", []byte("x"))
+
+	for _, bucket := range []string{"", "../etc", "news/x", "News"} {
+		file, err := header.Open()
+		require.NoError(t, err)
+		_, err = repo.Upload(context.Background(), file, header, bucket)
+		_ = file.Close()
+		assert.Error(t, err, bucket)
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
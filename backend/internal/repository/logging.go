@@ -0,0 +1,35 @@
+// Package repository provides implementation for repository
+//
+// File: logging.go
+// Description: DEBUG-level repository call logging with request_id correlation
+package repository
+
+import (
+	"context"
+
+	"templatev25/internal/requestctx"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// orNop нь nil logger-ийг zap.NewNop() болгоно (тест, хуучин дуудлагад)
+func orNop(log *zap.Logger) *zap.Logger {
+	if log == nil {
+		return zap.NewNop()
+	}
+	return log
+}
+
+// startSpanLog нь startSpan дээр нэмээд дуудлагыг request_id-тай DEBUG log болгон бичнэ.
+// Ингэснээр нэг request-ийн handler, service, repository log-ууд request_id-аар холбогдоно.
+func startSpanLog(ctx context.Context, log *zap.Logger, table, method string) (context.Context, trace.Span) {
+	if ce := log.Check(zap.DebugLevel, "repo_call"); ce != nil {
+		ce.Write(
+			zap.String("table", table),
+			zap.String("method", method),
+			zap.String("request_id", requestctx.GetRequestID(ctx)),
+		)
+	}
+	return startSpan(ctx, table, method)
+}
@@ -10,6 +10,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"templatev25/internal/domain"
@@ -28,12 +29,16 @@ type MenuRepository interface {
 	List(ctx context.Context, q dto.MenuListQuery) ([]domain.Menu, int64, int, int, error)
 	ListAll(ctx context.Context) ([]domain.Menu, error)
 	ListByUserRoles(ctx context.Context, userID int) ([]domain.Menu, error)
+	ListByUserRolesInSystems(ctx context.Context, userID int, systemIDs []int) ([]domain.Menu, error)
 	GetMenusByPermissionIDs(ctx context.Context, permissionIDs []int) ([]domain.Menu, error)
 	GetMenusByIDs(ctx context.Context, ids []int64) ([]domain.Menu, error)
 	ByID(ctx context.Context, id int64) (domain.Menu, error)
 	Create(ctx context.Context, m domain.Menu) error
 	Update(ctx context.Context, id int64, m domain.Menu) error
 	Delete(ctx context.Context, id int64) error
+	SetPermissions(ctx context.Context, menuID int64, permissionIDs []int) error
+	ListMenuPermissions(ctx context.Context) ([]domain.MenuPermission, error)
+	Reorder(ctx context.Context, orders []dto.MenuReorderItem) error
 }
 
 type menuRepository struct {
@@ -135,6 +140,32 @@ func (r *menuRepository) ListByUserRoles(ctx context.Context, userID int) ([]dom
 	return menus, nil
 }
 
+// ListByUserRolesInSystems нь ListByUserRoles-тэй ижил боловч зөвхөн systemIDs-д
+// хамаарах permission-тэй menu-г буцаана
+func (r *menuRepository) ListByUserRolesInSystems(ctx context.Context, userID int, systemIDs []int) ([]domain.Menu, error) {
+	if len(systemIDs) == 0 {
+		return []domain.Menu{}, nil
+	}
+
+	var menus []domain.Menu
+	err := r.db.WithContext(ctx).
+		Model(&domain.Menu{}).
+		Distinct().
+		Joins("JOIN permissions p ON p.id = menus.permission_id").
+		Joins("JOIN role_permissions rp ON rp.permission_id = menus.permission_id").
+		Joins("JOIN user_roles ur ON ur.role_id = rp.role_id").
+		Where("ur.user_id = ? AND menus.is_active = true AND menus.deleted_date IS NULL", userID).
+		Where("p.system_id IN ?", systemIDs).
+		Order("menus.sequence ASC, menus.id ASC").
+		Find(&menus).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return menus, nil
+}
+
 func (r *menuRepository) GetMenusByPermissionIDs(ctx context.Context, permissionIDs []int) ([]domain.Menu, error) {
 	if len(permissionIDs) == 0 {
 		return []domain.Menu{}, nil
@@ -170,7 +201,7 @@ func (r *menuRepository) GetMenusByIDs(ctx context.Context, ids []int64) ([]doma
 func (r *menuRepository) ByID(ctx context.Context, id int64) (domain.Menu, error) {
 	var m domain.Menu
 	if err := r.db.WithContext(ctx).Where("id = ?", id).Preload("Parent").Preload("System").First(&m).Error; err != nil {
-		return domain.Menu{}, err
+		return domain.Menu{}, domain.WrapNotFound(err, "menu not found")
 	}
 	return m, nil
 }
@@ -209,3 +240,90 @@ func (r *menuRepository) Delete(uctx context.Context, id int64) error {
 	m.DeletedDate = gorm.DeletedAt{Valid: true, Time: time.Now()}
 	return r.db.WithContext(uctx).Model(&domain.Menu{}).Where("id = ?", id).Updates(&m).Error
 }
+
+// SetPermissions нь menu-д шаардагдах permission-уудыг permissionIDs-ээр бүрэн солино
+// (хоосон бол бүгдийг хасна).
+func (r *menuRepository) SetPermissions(ctx context.Context, menuID int64, permissionIDs []int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Composite PK тул soft delete биш, бүр устгана — эс бөгөөс дахин нэмэхэд давхцана.
+		if err := tx.Unscoped().
+			Where("menu_id = ?", menuID).
+			Delete(&domain.MenuPermission{}).Error; err != nil {
+			return err
+		}
+
+		if len(permissionIDs) == 0 {
+			return nil
+		}
+
+		links := make([]domain.MenuPermission, 0, len(permissionIDs))
+		for _, pid := range permissionIDs {
+			links = append(links, domain.MenuPermission{
+				MenuID:       menuID,
+				PermissionID: pid,
+			})
+		}
+		return tx.Create(&links).Error
+	})
+}
+
+// Reorder нь menu-уудын sequence-ийг нэг UPDATE ... CASE statement-аар солино.
+// Устгагдсан эсвэл байхгүй ID орсон бол transaction rollback хийж ErrNotFound буцаана.
+func (r *menuRepository) Reorder(ctx context.Context, orders []dto.MenuReorderItem) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	query, args := buildMenuReorderSQL(orders)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Exec(query, args...)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != int64(len(orders)) {
+			return domain.NewNotFound("menu not found", nil)
+		}
+		return nil
+	})
+}
+
+// buildMenuReorderSQL нь Reorder-ийн bulk UPDATE-ийг үүсгэнэ:
+//
+//	UPDATE menus SET sequence = CASE id WHEN ? THEN CAST(? AS BIGINT) ... END
+//	WHERE id IN (?, ...) AND deleted_date IS NULL
+//
+// args нь эхлээд (id, sequence) хосууд, дараа нь IN-ийн id-ууд.
+func buildMenuReorderSQL(orders []dto.MenuReorderItem) (string, []any) {
+	args := make([]any, 0, len(orders)*3)
+
+	var b strings.Builder
+	b.WriteString("UPDATE menus SET sequence = CASE id")
+	for _, o := range orders {
+		b.WriteString(" WHEN ? THEN CAST(? AS BIGINT)")
+		args = append(args, o.ID, o.Sequence)
+	}
+	b.WriteString(" END WHERE id IN (")
+	for i, o := range orders {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("?")
+		args = append(args, o.ID)
+	}
+	b.WriteString(") AND deleted_date IS NULL")
+	return b.String(), args
+}
+
+// ListMenuPermissions нь идэвхтэй menu-уудын шаардагдах permission-уудыг
+// Permission (code, system_id)-тэй нь буцаана
+func (r *menuRepository) ListMenuPermissions(ctx context.Context) ([]domain.MenuPermission, error) {
+	var links []domain.MenuPermission
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN menus m ON m.id = menu_permissions.menu_id").
+		Where("m.is_active = true AND m.deleted_date IS NULL").
+		Preload("Permission").
+		Order("menu_permissions.menu_id, menu_permissions.permission_id").
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
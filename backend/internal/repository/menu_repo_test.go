@@ -0,0 +1,70 @@
+// Package repository provides data access layer
+//
+// File: menu_repo_test.go
+// Description: Unit tests for the menu reorder bulk UPDATE construction
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"templatev25/internal/http/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestBuildMenuReorderSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		orders   []dto.MenuReorderItem
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "single item",
+			orders:   []dto.MenuReorderItem{{ID: 7, Sequence: 1}},
+			wantSQL:  "UPDATE menus SET sequence = CASE id WHEN ? THEN CAST(? AS BIGINT) END WHERE id IN (?) AND deleted_date IS NULL",
+			wantArgs: []any{int64(7), 1, int64(7)},
+		},
+		{
+			name:   "three items keep input order",
+			orders: []dto.MenuReorderItem{{ID: 3, Sequence: 0}, {ID: 1, Sequence: 1}, {ID: 2, Sequence: 2}},
+			wantSQL: "UPDATE menus SET sequence = CASE id" +
+				" WHEN ? THEN CAST(? AS BIGINT) WHEN ? THEN CAST(? AS BIGINT) WHEN ? THEN CAST(? AS BIGINT)" +
+				" END WHERE id IN (?, ?, ?) AND deleted_date IS NULL",
+			wantArgs: []any{int64(3), 0, int64(1), 1, int64(2), 2, int64(3), int64(1), int64(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := buildMenuReorderSQL(tt.orders)
+
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestBuildMenuReorderSQL_Rendered(t *testing.T) {
+	gdb, _ := newDryRunDB(t)
+
+	sql, args := buildMenuReorderSQL([]dto.MenuReorderItem{{ID: 4, Sequence: 2}, {ID: 9, Sequence: 3}})
+	stmt := gdb.Session(&gorm.Session{SkipDefaultTransaction: true}).Exec(sql, args...).Statement
+
+	assert.Equal(t,
+		"UPDATE menus SET sequence = CASE id WHEN $1 THEN CAST($2 AS BIGINT) WHEN $3 THEN CAST($4 AS BIGINT) END WHERE id IN ($5, $6) AND deleted_date IS NULL",
+		stmt.SQL.String())
+	assert.Equal(t,
+		"UPDATE menus SET sequence = CASE id WHEN 4 THEN CAST(2 AS BIGINT) WHEN 9 THEN CAST(3 AS BIGINT) END WHERE id IN (4, 9) AND deleted_date IS NULL",
+		gdb.Dialector.Explain(stmt.SQL.String(), stmt.Vars...))
+}
+
+func TestMenuRepository_Reorder_Empty(t *testing.T) {
+	gdb, _ := newDryRunDB(t)
+
+	// Хоосон бол DB руу хандахгүй
+	require.NoError(t, NewMenuRepository(gdb, nil).Reorder(context.Background(), nil))
+}
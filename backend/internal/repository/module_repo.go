@@ -12,6 +12,7 @@ import (
 	"context"
 	"time"
 
+	dbtx "templatev25/internal/db"
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 
@@ -27,6 +28,7 @@ import (
 type ModuleRepository interface {
 	List(ctx context.Context, q dto.ModuleListQuery) ([]domain.Module, int64, int, int, error)
 	ByID(ctx context.Context, id int) (domain.Module, error)
+	ByIDs(ctx context.Context, ids []int) ([]domain.Module, error)
 	Create(ctx context.Context, m domain.Module) error
 	Update(ctx context.Context, id int, m domain.Module) error
 	Delete(ctx context.Context, id int) error
@@ -89,11 +91,23 @@ func (r *moduleRepository) List(ctx context.Context, q dto.ModuleListQuery) ([]d
 func (r *moduleRepository) ByID(ctx context.Context, id int) (domain.Module, error) {
 	var m domain.Module
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
-		return domain.Module{}, err
+		return domain.Module{}, domain.WrapNotFound(err, "module not found")
 	}
 	return m, nil
 }
 
+// ByIDs нь олон module-ийг нэг query-ээр авна (олдоогүй ID-г алгасна)
+func (r *moduleRepository) ByIDs(ctx context.Context, ids []int) ([]domain.Module, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var items []domain.Module
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 func (r *moduleRepository) Create(uctx context.Context, m domain.Module) error {
 	if uid, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
 		m.CreatedUserId = uid
@@ -126,5 +140,8 @@ func (r *moduleRepository) Delete(uctx context.Context, id int) error {
 		m.DeletedOrgId = oid
 	}
 	m.DeletedDate = gorm.DeletedAt{Valid: true, Time: time.Now()}
-	return r.db.WithContext(uctx).Model(&domain.Module{}).Where("id = ?", id).Updates(&m).Error
+	// ModuleService.Delete-ийн permission cascade transaction-д нэгдэнэ
+	return dbtx.WithTransaction(uctx, r.db, func(_ context.Context, tx *gorm.DB) error {
+		return tx.Model(&domain.Module{}).Where("id = ?", id).Updates(&m).Error
+	})
 }
@@ -0,0 +1,99 @@
+// Package repository provides implementation for repository
+//
+// File: news_category_repo.go
+// Description: News category repository implementation
+package repository
+
+import (
+	"context"
+	"time"
+
+	"templatev25/internal/domain"
+
+	"git.gerege.mn/backend-packages/common"
+	"git.gerege.mn/backend-packages/ctx"
+	"git.gerege.mn/backend-packages/utils"
+
+	"gorm.io/gorm"
+)
+
+type NewsCategoryRepository interface {
+	List(ctx context.Context, p common.PaginationQuery) ([]domain.NewsCategory, int64, int, int, error)
+	Create(ctx context.Context, m domain.NewsCategory) error
+	Update(ctx context.Context, id int, m domain.NewsCategory) error
+	Delete(ctx context.Context, id int) error
+}
+
+type newsCategoryRepository struct{ db *gorm.DB }
+
+func NewNewsCategoryRepository(db *gorm.DB) NewsCategoryRepository {
+	return &newsCategoryRepository{db: db}
+}
+
+func (r *newsCategoryRepository) List(ctx context.Context, p common.PaginationQuery) ([]domain.NewsCategory, int64, int, int, error) {
+	page, size, offset := utils.OffsetLimit(p)
+
+	tx := r.db.WithContext(ctx).Model(&domain.NewsCategory{})
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	var items []domain.NewsCategory
+	if err := tx.Order("name ASC, id ASC").Offset(offset).Limit(size).Find(&items).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+	return items, total, page, size, nil
+}
+
+func (r *newsCategoryRepository) Create(uctx context.Context, m domain.NewsCategory) error {
+	if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
+		m.CreatedUserId = userId
+	}
+	if orgId, ok := ctx.GetValue[int](uctx, ctx.KeyOrgID); ok {
+		m.CreatedOrgId = orgId
+	}
+	return r.db.WithContext(uctx).Create(&m).Error
+}
+
+func (r *newsCategoryRepository) Update(uctx context.Context, id int, m domain.NewsCategory) error {
+	if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
+		m.UpdatedUserId = userId
+	}
+	if orgId, ok := ctx.GetValue[int](uctx, ctx.KeyOrgID); ok {
+		m.UpdatedOrgId = orgId
+	}
+	res := r.db.WithContext(uctx).Model(&domain.NewsCategory{}).Where("id = ?", id).Updates(&m)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFound("news category not found", nil)
+	}
+	return nil
+}
+
+// Delete нь ангиллыг soft delete хийж, түүнд хамаарах мэдээний category_id-г
+// NULL болгоно (soft delete-д FK ON DELETE SET NULL ажилладаггүй).
+func (r *newsCategoryRepository) Delete(uctx context.Context, id int) error {
+	m := domain.NewsCategory{}
+	if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
+		m.DeletedUserId = userId
+	}
+	if orgId, ok := ctx.GetValue[int](uctx, ctx.KeyOrgID); ok {
+		m.DeletedOrgId = orgId
+	}
+	m.DeletedDate = gorm.DeletedAt{Valid: true, Time: time.Now()}
+
+	return r.db.WithContext(uctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.NewsCategory{}).Where("id = ?", id).Updates(&m)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFound("news category not found", nil)
+		}
+		return tx.Model(&domain.News{}).Where("category_id = ?", id).Update("category_id", nil).Error
+	})
+}
@@ -28,6 +28,7 @@ type NewsRepository interface {
 	Create(ctx context.Context, m domain.News) error
 	Update(ctx context.Context, id int, m domain.News) error
 	Delete(uctx context.Context, id int) error
+	SetPublished(ctx context.Context, id int, published bool) error
 }
 
 type newsRepository struct{ db *gorm.DB }
@@ -50,8 +51,11 @@ func (r *newsRepository) List(ctx context.Context, q dto.NewsListQuery) ([]domai
 			scopes.DateScope(q.CreatedFrom, q.CreatedTo),
 		)
 
-	if q.CategoryID != 0 {
-		tx = tx.Where("category_id = ?", q.CategoryID)
+	if q.CategoryID != nil {
+		tx = tx.Where("category_id = ?", *q.CategoryID)
+	}
+	if q.IsPublished != nil {
+		tx = tx.Where("is_published = ?", *q.IsPublished)
 	}
 
 	var total int64
@@ -70,7 +74,7 @@ func (r *newsRepository) List(ctx context.Context, q dto.NewsListQuery) ([]domai
 func (r *newsRepository) GetByID(ctx context.Context, id int) (domain.News, error) {
 	var m domain.News
 	err := r.db.WithContext(ctx).First(&m, "id = ?", id).Error
-	return m, err
+	return m, domain.WrapNotFound(err, "news not found")
 }
 
 func (r *newsRepository) Create(uctx context.Context, m domain.News) error {
@@ -99,6 +103,32 @@ func (r *newsRepository) Update(uctx context.Context, id int, m domain.News) err
 	return nil
 }
 
+// SetPublished нь мэдээг нийтлэх (published_at = одоо) эсвэл draft болгоно (published_at = NULL).
+func (r *newsRepository) SetPublished(uctx context.Context, id int, published bool) error {
+	updates := map[string]interface{}{
+		"is_published": published,
+		"published_at": nil,
+	}
+	if published {
+		updates["published_at"] = time.Now()
+	}
+	if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
+		updates["updated_user_id"] = userId
+	}
+	if orgId, ok := ctx.GetValue[int](uctx, ctx.KeyOrgID); ok {
+		updates["updated_org_id"] = orgId
+	}
+
+	res := r.db.WithContext(uctx).Model(&domain.News{}).Where("id = ?", id).Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFound("news not found", nil)
+	}
+	return nil
+}
+
 func (r *newsRepository) Delete(uctx context.Context, id int) error {
 	m := domain.News{}
 	if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
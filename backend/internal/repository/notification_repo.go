@@ -11,6 +11,7 @@ package repository
 import (
 	"context"
 
+	dbtx "templatev25/internal/db"
 	"templatev25/internal/domain"
 	"git.gerege.mn/backend-packages/common"
 
@@ -22,43 +23,68 @@ import (
 
 type NotificationRepository interface {
 	ListByUser(ctx context.Context, userID int, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error)
+	ListByTenant(ctx context.Context, tenantID string, userID int, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error)
 	MarkGroupRead(ctx context.Context, userID, groupID int) error
-	MarkAllRead(ctx context.Context, userID int) error
+	MarkAllRead(ctx context.Context, userID int) (int64, error)
+	MarkReadByIDs(ctx context.Context, userID int, ids []int) (int64, error)
+	CountByType(ctx context.Context, userID int) (map[string]int64, error)
+	UnreadCount(ctx context.Context, userID int) (int64, error)
 
 	ListGroups(ctx context.Context, p common.PaginationQuery) ([]domain.NotificationGroup, int64, int, int, error)
 	CreateGroup(ctx context.Context, g domain.NotificationGroup) (domain.NotificationGroup, error)
 
 	CreateNotification(ctx context.Context, n domain.Notification) (domain.Notification, error)
 	CreateNotificationsBulk(ctx context.Context, ns []domain.Notification) error
+	CreateGroupWithNotifications(ctx context.Context, g domain.NotificationGroup, ns []domain.Notification) (domain.NotificationGroup, error)
 
 	AllUserIDs(ctx context.Context) ([]int, error)
+	// MissingUserIDs нь ids-ээс users хүснэгтэд байхгүй (эсвэл устгагдсан) id-уудыг буцаана
+	MissingUserIDs(ctx context.Context, ids []int) ([]int, error)
+
+	// GDPR erasure (hard delete, context-ийн transaction-д нэгдэнэ)
+	DeleteByUserID(ctx context.Context, userID int) error
+	DeleteGroupsByUserID(ctx context.Context, userID int) error
 }
 
-type notificationRepository struct{ db *gorm.DB }
+// notificationBatchSize нь CreateInBatches-ийн нэг INSERT-ийн мөрийн тоо
+const notificationBatchSize = 100
+
+type notificationRepository struct {
+	db     *gorm.DB
+	unread *NotificationUnreadCache
+}
 
 func NewNotificationRepository(db *gorm.DB) NotificationRepository {
-	return &notificationRepository{db: db}
+	return &notificationRepository{db: db, unread: NewNotificationUnreadCache(NotificationUnreadCacheTTL)}
 }
 
 func (r *notificationRepository) ListByUser(ctx context.Context, userID int, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error) {
+	return r.list(r.db.WithContext(ctx).Model(&domain.Notification{}).Where("user_id = ?", userID), p)
+}
+
+// ListByTenant нь ListByUser-тэй ижил боловч зөвхөн tenantID-д хамаарах мэдэгдлийг буцаана
+func (r *notificationRepository) ListByTenant(ctx context.Context, tenantID string, userID int, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error) {
+	return r.list(r.db.WithContext(ctx).Model(&domain.Notification{}).Where("tenant_id = ? AND user_id = ?", tenantID, userID), p)
+}
+
+// list нь шүүлттэй notifications query дээр search/date/sort/pagination хэрэглэнэ
+func (r *notificationRepository) list(base *gorm.DB, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error) {
 	page, size, offset := utils.OffsetLimit(p)
 	colMap := scopes.ColumnMap{
-		"id":       "notifications.id",
-		"user_id":  "notifications.user_id",
-		"is_read":  "notifications.is_read",
-		"type":     "notifications.type",
-		"tenant":   "notifications.tenant",
-		"title":    "notifications.title",
-		"content":  "notifications.content",
-		"group_id": "notifications.group_id",
+		"id":        "notifications.id",
+		"user_id":   "notifications.user_id",
+		"is_read":   "notifications.is_read",
+		"type":      "notifications.type",
+		"tenant_id": "notifications.tenant_id",
+		"tenant":    "notifications.tenant_id", // хуучин search key (tenant → tenant_id)
+		"title":     "notifications.title",
+		"content":   "notifications.content",
+		"group_id":  "notifications.group_id",
 	}
-	tx := r.db.WithContext(ctx).
-		Model(&domain.Notification{}).
-		Where("user_id = ?", userID).
-		Scopes(
-			scopes.SearchScope(colMap, utils.ParseSearch(p.Search)),
-			scopes.DateScope(p.CreatedFrom, p.CreatedTo),
-		)
+	tx := base.Scopes(
+		scopes.SearchScope(colMap, utils.ParseSearch(p.Search)),
+		scopes.DateScope(p.CreatedFrom, p.CreatedTo),
+	)
 
 	var total int64
 	if err := tx.Count(&total).Error; err != nil {
@@ -74,17 +100,77 @@ func (r *notificationRepository) ListByUser(ctx context.Context, userID int, p c
 }
 
 func (r *notificationRepository) MarkGroupRead(ctx context.Context, userID, groupID int) error {
+	defer r.unread.Invalidate(userID)
 	return r.db.WithContext(ctx).
 		Model(&domain.Notification{}).
 		Where("group_id = ? AND user_id = ?", groupID, userID).
 		Update("is_read", true).Error
 }
 
-func (r *notificationRepository) MarkAllRead(ctx context.Context, userID int) error {
-	return r.db.WithContext(ctx).
+// MarkAllRead нь хэрэглэгчийн уншаагүй бүх мэдэгдлийг нэг UPDATE-аар уншсан болгоно.
+// Өөрчлөгдсөн мөрийн тоог буцаана.
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID int) (int64, error) {
+	defer r.unread.Invalidate(userID)
+	res := r.db.WithContext(ctx).
 		Model(&domain.Notification{}).
-		Where("user_id = ?", userID).
-		Update("is_read", true).Error
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Update("is_read", true)
+	return res.RowsAffected, res.Error
+}
+
+// MarkReadByIDs нь зөвхөн тухайн хэрэглэгчид хамаарах ids мэдэгдлүүдийг уншсан болгоно.
+// Бусдын мэдэгдлийн ID ирвэл user_id нөхцөлөөр алгасагдана. Өөрчлөгдсөн мөрийн тоог буцаана.
+func (r *notificationRepository) MarkReadByIDs(ctx context.Context, userID int, ids []int) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	defer r.unread.Invalidate(userID)
+	res := r.db.WithContext(ctx).
+		Model(&domain.Notification{}).
+		Where("user_id = ? AND is_read = ? AND id IN ?", userID, false, ids).
+		Update("is_read", true)
+	return res.RowsAffected, res.Error
+}
+
+// CountByType нь хэрэглэгчийн уншаагүй мэдэгдлийг type-аар бүлэглэж тоолно (GROUP BY type).
+// Уншаагүй мэдэгдэлгүй type map-д орохгүй.
+func (r *notificationRepository) CountByType(ctx context.Context, userID int) (map[string]int64, error) {
+	var rows []struct {
+		Type  string
+		Count int64
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Notification{}).
+		Select("type, COUNT(*) AS count").
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Group("type").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		out[row.Type] = row.Count
+	}
+	return out, nil
+}
+
+// UnreadCount нь хэрэглэгчийн уншаагүй, устгагдаагүй мэдэгдлийн тоог нэг COUNT-оор буцаана.
+// Үр дүн NotificationUnreadCacheTTL хугацаанд cache-д хадгалагдана.
+func (r *notificationRepository) UnreadCount(ctx context.Context, userID int) (int64, error) {
+	if n, ok := r.unread.get(userID); ok {
+		return n, nil
+	}
+
+	var n int64
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Count(&n).Error; err != nil {
+		return 0, err
+	}
+	r.unread.set(userID, n)
+	return n, nil
 }
 
 func (r *notificationRepository) ListGroups(ctx context.Context, p common.PaginationQuery) ([]domain.NotificationGroup, int64, int, int, error) {
@@ -127,6 +213,7 @@ func (r *notificationRepository) CreateNotification(ctx context.Context, n domai
 	if err := r.db.WithContext(ctx).Create(&n).Error; err != nil {
 		return domain.Notification{}, err
 	}
+	r.unread.Invalidate(n.UserId)
 	return n, nil
 }
 
@@ -141,6 +228,24 @@ func (r *notificationRepository) CreateNotificationsBulk(ctx context.Context, ns
 	})
 }
 
+// CreateGroupWithNotifications нь group-ийг үүсгээд ns-ийн GroupId-г тохируулж
+// нэг transaction-д CreateInBatches-аар (100 мөрөөр) хадгална.
+func (r *notificationRepository) CreateGroupWithNotifications(ctx context.Context, g domain.NotificationGroup, ns []domain.Notification) (domain.NotificationGroup, error) {
+	err := dbtx.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		if err := tx.WithContext(ctx).Create(&g).Error; err != nil {
+			return err
+		}
+		for i := range ns {
+			ns[i].GroupId = g.Id
+		}
+		return tx.WithContext(ctx).CreateInBatches(&ns, notificationBatchSize).Error
+	})
+	if err != nil {
+		return domain.NotificationGroup{}, err
+	}
+	return g, nil
+}
+
 func (r *notificationRepository) AllUserIDs(ctx context.Context) ([]int, error) {
 	var ids []int
 	if err := r.db.WithContext(ctx).Model(&domain.User{}).Pluck("id", &ids).Error; err != nil {
@@ -148,3 +253,39 @@ func (r *notificationRepository) AllUserIDs(ctx context.Context) ([]int, error)
 	}
 	return ids, nil
 }
+
+func (r *notificationRepository) MissingUserIDs(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var found []int
+	if err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+		return nil, err
+	}
+	exists := make(map[int]bool, len(found))
+	for _, id := range found {
+		exists[id] = true
+	}
+	var missing []int
+	for _, id := range ids {
+		if !exists[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+// DeleteByUserID нь хэрэглэгчийн бүх notification-ийг бүрмөсөн устгана (GDPR erasure)
+func (r *notificationRepository) DeleteByUserID(ctx context.Context, userID int) error {
+	return dbtx.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		return tx.Unscoped().Where("user_id = ?", userID).Delete(&domain.Notification{}).Error
+	})
+}
+
+// DeleteGroupsByUserID нь хэрэглэгч рүү илгээсэн notification group-уудыг бүрмөсөн устгана.
+// Broadcast group-ууд user_id = 0 тул хөндөгдөхгүй.
+func (r *notificationRepository) DeleteGroupsByUserID(ctx context.Context, userID int) error {
+	return dbtx.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		return tx.Unscoped().Where("user_id = ?", userID).Delete(&domain.NotificationGroup{}).Error
+	})
+}
@@ -0,0 +1,51 @@
+// Package repository provides implementation for repository
+//
+// File: notification_repo_test.go
+// Description: DryRun SQL tests for notification repository list queries
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"git.gerege.mn/backend-packages/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationRepository_ListByTenant_SQL(t *testing.T) {
+	gdb, lastSQL := newDryRunDB(t)
+
+	_, _, _, _, err := NewNotificationRepository(gdb).ListByTenant(context.Background(), "gerege", 7, common.PaginationQuery{Page: 1, Size: 10})
+	require.NoError(t, err)
+
+	sql := lastSQL()
+	assert.Contains(t, sql, `FROM "notifications"`)
+	assert.Contains(t, sql, "tenant_id = 'gerege' AND user_id = 7")
+	assert.Contains(t, sql, `"notifications"."deleted_date" IS NULL`)
+}
+
+func TestNotificationRepository_ListByUser_SQL(t *testing.T) {
+	gdb, lastSQL := newDryRunDB(t)
+
+	_, _, _, _, err := NewNotificationRepository(gdb).ListByUser(context.Background(), 7, common.PaginationQuery{Page: 1, Size: 10})
+	require.NoError(t, err)
+
+	sql := lastSQL()
+	assert.Contains(t, sql, "user_id = 7")
+	assert.NotContains(t, sql, "tenant_id")
+}
+
+func TestNotificationRepository_List_LegacyTenantSearchKey(t *testing.T) {
+	for _, search := range []string{"tenant_id:gerege", "tenant:gerege"} {
+		t.Run(search, func(t *testing.T) {
+			gdb, lastSQL := newDryRunDB(t)
+
+			_, _, _, _, err := NewNotificationRepository(gdb).ListByUser(context.Background(), 7, common.PaginationQuery{Page: 1, Size: 10, Search: search})
+			require.NoError(t, err)
+
+			// Хуучин "tenant" key нь tenant_id багана руу map хийгдэнэ
+			assert.Contains(t, lastSQL(), "notifications.tenant_id")
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package repository provides implementation for repository
+//
+// File: notification_unread_cache.go
+// Description: Short-lived per-user cache for NotificationRepository.UnreadCount
+/*
+NotificationUnreadCache нь хэрэглэгч бүрийн уншаагүй мэдэгдлийн тоог 5 секунд
+хадгална. Frontend-ийн badge хуудас ачаалах бүрт GET /me/notifications/unread-count
+дууддаг тул DB руу давтан COUNT хийхгүй.
+
+  - UnreadCount: cache-д байвал буцаана, үгүй бол DB-ээс тоолоод хадгална
+  - MarkGroupRead / MarkAllRead / MarkReadByIDs / CreateNotification: тухайн
+    хэрэглэгчийн entry-г устгана (badge шууд шинэчлэгдэнэ)
+
+Bulk илгээлт (CreateNotificationsBulk) entry устгахгүй — TTL дуустал (хамгийн
+ихдээ 5 секунд) хуучин тоо харагдаж болно. Cache нь process тус бүрд тусдаа.
+*/
+package repository
+
+import (
+	"sync"
+	"time"
+)
+
+// NotificationUnreadCacheTTL нь UnreadCount-ийн cache-ийн хүчинтэй хугацаа
+const NotificationUnreadCacheTTL = 5 * time.Second
+
+// unreadCountEntry нь нэг хэрэглэгчийн хадгалсан тоо
+type unreadCountEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// NotificationUnreadCache нь userID → уншаагүй мэдэгдлийн тоог TTL-тэй хадгална
+type NotificationUnreadCache struct {
+	entries sync.Map // int -> *unreadCountEntry
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewNotificationUnreadCache creates an empty cache with the given TTL
+func NewNotificationUnreadCache(ttl time.Duration) *NotificationUnreadCache {
+	return &NotificationUnreadCache{ttl: ttl, now: time.Now}
+}
+
+// get нь хугацаа нь дуусаагүй тоог буцаана
+func (c *NotificationUnreadCache) get(userID int) (int64, bool) {
+	v, ok := c.entries.Load(userID)
+	if !ok {
+		return 0, false
+	}
+	e := v.(*unreadCountEntry)
+	if !c.now().Before(e.expiresAt) {
+		c.entries.Delete(userID)
+		return 0, false
+	}
+	return e.count, true
+}
+
+// set нь тоог TTL-тэй хадгална
+func (c *NotificationUnreadCache) set(userID int, count int64) {
+	c.entries.Store(userID, &unreadCountEntry{count: count, expiresAt: c.now().Add(c.ttl)})
+}
+
+// Invalidate нь хэрэглэгчийн хадгалсан тоог устгана
+func (c *NotificationUnreadCache) Invalidate(userID int) {
+	c.entries.Delete(userID)
+}
@@ -0,0 +1,128 @@
+// Package repository provides data access layer
+//
+// File: notification_unread_cache_test.go
+// Description: Unit tests for the UnreadCount cache (hit, miss, zero count, TTL, invalidation)
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"templatev25/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newCachedNotificationRepo нь DryRun DB-тэй repository, сүүлийн SQL болон
+// SELECT query-ийн тоолуур буцаана
+func newCachedNotificationRepo(t *testing.T) (*notificationRepository, func() string, func() int) {
+	t.Helper()
+	gdb, lastSQL := newDryRunDB(t)
+
+	var queries int
+	require.NoError(t, gdb.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		queries++
+	}))
+	gdb = gdb.Session(&gorm.Session{SkipDefaultTransaction: true})
+
+	repo := NewNotificationRepository(gdb).(*notificationRepository)
+	return repo, lastSQL, func() int { return queries }
+}
+
+func TestNotificationRepository_UnreadCount_CacheHit(t *testing.T) {
+	repo, _, queries := newCachedNotificationRepo(t)
+	repo.unread.set(7, 12)
+
+	n, err := repo.UnreadCount(context.Background(), 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(12), n)
+	assert.Zero(t, queries(), "cache hit must not query the database")
+}
+
+func TestNotificationRepository_UnreadCount_CacheMiss(t *testing.T) {
+	repo, lastSQL, queries := newCachedNotificationRepo(t)
+
+	_, err := repo.UnreadCount(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queries())
+
+	sql := lastSQL()
+	assert.Contains(t, sql, "SELECT count(*)")
+	assert.Contains(t, sql, "user_id = 7 AND is_read = false")
+	assert.Contains(t, sql, `"notifications"."deleted_date" IS NULL`)
+
+	// Өөр хэрэглэгч бол тусдаа entry
+	_, err = repo.UnreadCount(context.Background(), 8)
+	require.NoError(t, err)
+	assert.Equal(t, 2, queries())
+}
+
+func TestNotificationRepository_UnreadCount_ZeroIsCached(t *testing.T) {
+	repo, _, queries := newCachedNotificationRepo(t)
+
+	// DryRun-д COUNT үргэлж 0 буцаана
+	n, err := repo.UnreadCount(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	n, err = repo.UnreadCount(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Equal(t, 1, queries(), "zero count must be cached like any other value")
+}
+
+func TestNotificationRepository_UnreadCount_CacheExpires(t *testing.T) {
+	repo, _, queries := newCachedNotificationRepo(t)
+	now := time.Now()
+	repo.unread.now = func() time.Time { return now }
+	repo.unread.set(7, 12)
+
+	now = now.Add(NotificationUnreadCacheTTL)
+	n, err := repo.UnreadCount(context.Background(), 7)
+	require.NoError(t, err)
+
+	assert.Zero(t, n)
+	assert.Equal(t, 1, queries(), "expired entry must be reloaded")
+}
+
+func TestNotificationRepository_MutationsInvalidateUnreadCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*notificationRepository) error
+	}{
+		{name: "mark group read", mutate: func(r *notificationRepository) error {
+			return r.MarkGroupRead(context.Background(), 7, 3)
+		}},
+		{name: "mark all read", mutate: func(r *notificationRepository) error {
+			_, err := r.MarkAllRead(context.Background(), 7)
+			return err
+		}},
+		{name: "mark read by ids", mutate: func(r *notificationRepository) error {
+			_, err := r.MarkReadByIDs(context.Background(), 7, []int{1, 2})
+			return err
+		}},
+		{name: "create notification", mutate: func(r *notificationRepository) error {
+			_, err := r.CreateNotification(context.Background(), domain.Notification{UserId: 7, Title: "hi"})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, _, _ := newCachedNotificationRepo(t)
+			repo.unread.set(7, 12)
+			repo.unread.set(8, 4)
+
+			require.NoError(t, tt.mutate(repo))
+
+			_, ok := repo.unread.get(7)
+			assert.False(t, ok, "mutation must invalidate the user's count")
+			_, ok = repo.unread.get(8)
+			assert.True(t, ok, "other users' counts must be kept")
+		})
+	}
+}
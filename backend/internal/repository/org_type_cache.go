@@ -0,0 +1,142 @@
+// Package repository provides implementation for repository
+//
+// File: org_type_cache.go
+// Description: Cache-aside TTL cache for OrganizationType.List
+/*
+OrganizationTypeCache нь organizationTypeRepository.List-ийн шүүлтгүй
+(dropdown) хуудсуудыг 60 секунд хадгална. Dropdown-ууд хуудас ачаалах бүрт
+List дууддаг тул DB руу давтан очихгүй.
+
+  - List: Search/Sort/огнооны шүүлтгүй query-г (page, size)-аар cache-лэнэ,
+    шүүлттэй query үргэлж DB руу очно (key-ийн тоо хязгааргүй өсөхгүй)
+  - Create / Update / Delete: commit хийгдсэний дараа Invalidate (бүх хуудас)
+  - ForceRefresh: cache-ийг алгасаж DB-ээс шууд уншина
+
+Invalidate бүр generation-ийг нэмэгдүүлнэ. Өөрчлөлтөөс өмнө DB-ээс уншиж
+эхэлсэн List нь хуучин generation-тэй тул үр дүнгээ cache-д бичихгүй — хуучин
+өгөгдөл TTL дуустал үлдэхгүй.
+
+Cache нь process тус бүрд тусдаа — олон instance-тай үед өөр instance дээр
+хийсэн өөрчлөлт TTL дуустал (хамгийн ихдээ 60 секунд) харагдахгүй байж болно.
+*/
+package repository
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"templatev25/internal/domain"
+
+	"git.gerege.mn/backend-packages/common"
+)
+
+// OrganizationTypeCacheTTL нь List-ийн cache-ийн хүчинтэй хугацаа
+const OrganizationTypeCacheTTL = 60 * time.Second
+
+// orgTypeCacheMaxEntries нь cache-д хадгалах хуудасны дээд тоо
+const orgTypeCacheMaxEntries = 64
+
+// orgTypeCacheKey нь шүүлтгүй query-ийн хуудас
+type orgTypeCacheKey struct {
+	page int
+	size int
+}
+
+// orgTypeListResult нь List-ийн нэг хуудасны үр дүн
+type orgTypeListResult struct {
+	items     []domain.OrganizationType
+	total     int64
+	page      int
+	size      int
+	expiresAt time.Time
+}
+
+// OrganizationTypeCache нь шүүлтгүй List хуудсуудыг TTL-тэй хадгална
+type OrganizationTypeCache struct {
+	mu         sync.Mutex
+	entries    map[orgTypeCacheKey]*orgTypeListResult
+	generation uint64 // Invalidate бүрт нэмэгдэнэ
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// NewOrganizationTypeCache creates an empty cache with the given TTL
+func NewOrganizationTypeCache(ttl time.Duration) *OrganizationTypeCache {
+	return &OrganizationTypeCache{
+		entries: make(map[orgTypeCacheKey]*orgTypeListResult),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// cacheKey нь зөвхөн шүүлтгүй (page, size-аас өөр талбаргүй) query-д key буцаана
+func cacheKey(p common.PaginationQuery) (orgTypeCacheKey, bool) {
+	if p != (common.PaginationQuery{Page: p.Page, Size: p.Size}) {
+		return orgTypeCacheKey{}, false
+	}
+	return orgTypeCacheKey{page: p.Page, size: p.Size}, true
+}
+
+// get нь хугацаа нь дуусаагүй үр дүнгийн хуулбарыг буцаана.
+// Cache-гүй үед одоогийн generation-ийг буцаах тул DB-ээс уншсан үр дүнг
+// set-д дамжуулна.
+func (c *OrganizationTypeCache) get(p common.PaginationQuery) (*orgTypeListResult, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, ok := cacheKey(p)
+	if !ok {
+		return nil, c.generation, false
+	}
+	res, ok := c.entries[key]
+	if !ok {
+		return nil, c.generation, false
+	}
+	if !c.now().Before(res.expiresAt) {
+		delete(c.entries, key)
+		return nil, c.generation, false
+	}
+	cp := *res
+	cp.items = slices.Clone(res.items)
+	return &cp, c.generation, true
+}
+
+// set нь үр дүнг TTL-тэй хадгална. Уншиж эхэлснээс хойш Invalidate дуудагдсан
+// (gen өөрчлөгдсөн) бол хадгалахгүй. Хадгалахаас өмнө хугацаа нь дууссан
+// entry-үүдийг цэвэрлэнэ.
+func (c *OrganizationTypeCache) set(p common.PaginationQuery, gen uint64, res *orgTypeListResult) {
+	key, ok := cacheKey(p)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.generation {
+		return
+	}
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= orgTypeCacheMaxEntries {
+		return
+	}
+
+	cp := *res
+	cp.items = slices.Clone(res.items)
+	cp.expiresAt = now.Add(c.ttl)
+	c.entries[key] = &cp
+}
+
+// Invalidate нь бүх хадгалсан хуудсыг устгаж generation-ийг нэмэгдүүлнэ
+func (c *OrganizationTypeCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.generation++
+}
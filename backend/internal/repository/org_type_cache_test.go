@@ -0,0 +1,198 @@
+// Package repository provides data access layer
+//
+// File: org_type_cache_test.go
+// Description: Unit tests for the OrganizationType.List cache (hit, miss, TTL, invalidation)
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"templatev25/internal/domain"
+
+	"git.gerege.mn/backend-packages/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newCachedOrgTypeRepo нь DryRun DB-тэй repository болон SELECT query-ийн тоолуур буцаана
+func newCachedOrgTypeRepo(t *testing.T) (*organizationTypeRepository, func() int) {
+	t.Helper()
+	gdb, _ := newDryRunDB(t)
+
+	var queries int
+	require.NoError(t, gdb.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		queries++
+	}))
+
+	// DryRun-д Create/Update-ийн default transaction холболт нээх гэж оролдоно
+	gdb = gdb.Session(&gorm.Session{SkipDefaultTransaction: true})
+
+	repo := NewOrganizationTypeRepository(gdb).(*organizationTypeRepository)
+	return repo, func() int { return queries }
+}
+
+// seedOrgTypeCache нь p хуудсанд cache entry оруулна
+func seedOrgTypeCache(repo *organizationTypeRepository, p common.PaginationQuery) {
+	_, gen, _ := repo.cache.get(p)
+	repo.cache.set(p, gen, &orgTypeListResult{
+		items: []domain.OrganizationType{{Id: 1, Code: "SCHOOL", Name: "School"}},
+		total: 1,
+		page:  1,
+		size:  10,
+	})
+}
+
+func TestOrganizationTypeRepository_List_CacheHit(t *testing.T) {
+	repo, queries := newCachedOrgTypeRepo(t)
+	p := common.PaginationQuery{Page: 1, Size: 10}
+	seedOrgTypeCache(repo, p)
+
+	items, total, page, size, err := repo.List(context.Background(), p)
+	require.NoError(t, err)
+
+	assert.Zero(t, queries(), "cache hit must not query the database")
+	require.Len(t, items, 1)
+	assert.Equal(t, "SCHOOL", items[0].Code)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 1, page)
+	assert.Equal(t, 10, size)
+}
+
+func TestOrganizationTypeRepository_List_CacheMiss(t *testing.T) {
+	repo, queries := newCachedOrgTypeRepo(t)
+	p := common.PaginationQuery{Page: 1, Size: 10}
+
+	_, _, _, _, err := repo.List(context.Background(), p)
+	require.NoError(t, err)
+	afterMiss := queries()
+	assert.Positive(t, afterMiss, "cache miss must query the database (count + find)")
+
+	// Ижил query дахин ирэхэд cache-ээс
+	_, _, _, _, err = repo.List(context.Background(), p)
+	require.NoError(t, err)
+	assert.Equal(t, afterMiss, queries())
+
+	// Өөр хуудас бол тусдаа entry
+	_, _, _, _, err = repo.List(context.Background(), common.PaginationQuery{Page: 2, Size: 10})
+	require.NoError(t, err)
+	assert.Greater(t, queries(), afterMiss)
+}
+
+func TestOrganizationTypeRepository_List_FilteredNotCached(t *testing.T) {
+	repo, queries := newCachedOrgTypeRepo(t)
+	p := common.PaginationQuery{Page: 1, Size: 10, Search: "name:school"}
+
+	_, _, _, _, err := repo.List(context.Background(), p)
+	require.NoError(t, err)
+	afterFirst := queries()
+
+	// Хайлттай query бүр DB руу очно, cache-д entry нэмэгдэхгүй
+	_, _, _, _, err = repo.List(context.Background(), p)
+	require.NoError(t, err)
+	assert.Greater(t, queries(), afterFirst)
+	assert.Empty(t, repo.cache.entries)
+}
+
+func TestOrganizationTypeCache_GetReturnsCopy(t *testing.T) {
+	repo, _ := newCachedOrgTypeRepo(t)
+	p := common.PaginationQuery{Page: 1, Size: 10}
+	seedOrgTypeCache(repo, p)
+
+	items, _, _, _, err := repo.List(context.Background(), p)
+	require.NoError(t, err)
+	items[0].Name = "Mutated"
+
+	items, _, _, _, err = repo.List(context.Background(), p)
+	require.NoError(t, err)
+	assert.Equal(t, "School", items[0].Name)
+}
+
+func TestOrganizationTypeCache_StaleSetAfterInvalidateDropped(t *testing.T) {
+	cache := NewOrganizationTypeCache(OrganizationTypeCacheTTL)
+	p := common.PaginationQuery{Page: 1, Size: 10}
+
+	// DB-ээс уншиж эхэлсний дараа өөрчлөлт commit хийгдэж Invalidate дуудагдсан
+	_, gen, ok := cache.get(p)
+	require.False(t, ok)
+	cache.Invalidate()
+	cache.set(p, gen, &orgTypeListResult{items: []domain.OrganizationType{{Id: 1}}})
+
+	_, _, ok = cache.get(p)
+	assert.False(t, ok, "result read before invalidation must not be cached")
+}
+
+func TestOrganizationTypeCache_SetSweepsExpired(t *testing.T) {
+	cache := NewOrganizationTypeCache(OrganizationTypeCacheTTL)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for page := 1; page <= 3; page++ {
+		cache.set(common.PaginationQuery{Page: page, Size: 10}, 0, &orgTypeListResult{})
+	}
+	require.Len(t, cache.entries, 3)
+
+	now = now.Add(OrganizationTypeCacheTTL)
+	cache.set(common.PaginationQuery{Page: 4, Size: 10}, 0, &orgTypeListResult{})
+	assert.Len(t, cache.entries, 1)
+}
+
+func TestOrganizationTypeRepository_List_CacheExpires(t *testing.T) {
+	repo, queries := newCachedOrgTypeRepo(t)
+	now := time.Now()
+	repo.cache.now = func() time.Time { return now }
+
+	p := common.PaginationQuery{Page: 1, Size: 10}
+	seedOrgTypeCache(repo, p)
+
+	now = now.Add(OrganizationTypeCacheTTL)
+	_, _, _, _, err := repo.List(context.Background(), p)
+	require.NoError(t, err)
+	assert.Positive(t, queries(), "expired entry must be reloaded")
+}
+
+func TestOrganizationTypeRepository_MutationsInvalidateCache(t *testing.T) {
+	p := common.PaginationQuery{Page: 1, Size: 10}
+
+	tests := []struct {
+		name   string
+		mutate func(*organizationTypeRepository) error
+	}{
+		{name: "create", mutate: func(r *organizationTypeRepository) error {
+			return r.Create(context.Background(), domain.OrganizationType{Code: "NEW", Name: "New"})
+		}},
+		{name: "update", mutate: func(r *organizationTypeRepository) error {
+			return r.Update(context.Background(), 1, domain.OrganizationType{Code: "SCHOOL", Name: "Renamed"})
+		}},
+		{name: "delete", mutate: func(r *organizationTypeRepository) error {
+			return r.Delete(context.Background(), 1, "duplicate")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, _ := newCachedOrgTypeRepo(t)
+			seedOrgTypeCache(repo, p)
+
+			require.NoError(t, tt.mutate(repo))
+
+			_, _, ok := repo.cache.get(p)
+			assert.False(t, ok, "mutation must invalidate cached pages")
+		})
+	}
+}
+
+func TestOrganizationTypeRepository_ForceRefresh_BypassesCache(t *testing.T) {
+	repo, queries := newCachedOrgTypeRepo(t)
+	p := common.PaginationQuery{Page: 1, Size: 10}
+	seedOrgTypeCache(repo, p)
+
+	_, err := repo.ForceRefresh(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, queries())
+	_, _, ok := repo.cache.get(p)
+	assert.False(t, ok)
+}
@@ -14,9 +14,12 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
+	dbtx "templatev25/internal/db"
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/softdelete"
 
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/config"
@@ -25,6 +28,7 @@ import (
 	"git.gerege.mn/backend-packages/scopes"
 	"git.gerege.mn/backend-packages/utils"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -35,16 +39,32 @@ type OrganizationRepository interface {
 	Update(ctx context.Context, id int, m domain.Organization) (domain.Organization, error)
 	Delete(ctx context.Context, id int) error
 	ByID(ctx context.Context, id int) (domain.Organization, error)
-	Tree(ctx context.Context, rootID int) ([]domain.Organization, error)
+	// ByIDWithDetail нь байгууллагыг төрлийн нэр, хүүхэд/хэрэглэгчийн тоотой нь нэг query-гээр буцаана
+	ByIDWithDetail(ctx context.Context, id int) (dto.OrganizationDetail, error)
+	// TreeCTE нь rootID болон түүний бүх үр удмыг (recursive CTE) хавтгай жагсаалтаар буцаана
+	TreeCTE(ctx context.Context, rootID int) ([]domain.Organization, error)
+	Exists(ctx context.Context, id int) (bool, error)
+	Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.Organization, int64, int, int, error)
+	MoveToParent(ctx context.Context, orgID, newParentID int) error
+	DeletedByID(ctx context.Context, id int) (domain.Organization, error)
+	Restore(ctx context.Context, id int) error
+	Stats(ctx context.Context) ([]dto.OrgTypeStats, error)
 }
 
-type organizationRepository struct{ db *gorm.DB }
+type organizationRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
 
-func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
-	return &organizationRepository{db: db}
+// NewOrganizationRepository нь nil log өгвөл debug log бичихгүй
+func NewOrganizationRepository(db *gorm.DB, log *zap.Logger) OrganizationRepository {
+	return &organizationRepository{db: db, log: orNop(log)}
 }
 
 func (r *organizationRepository) List(ctx context.Context, p common.PaginationQuery) ([]domain.Organization, int64, int, int, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "List")
+	defer span.End()
+
 	page, size, offset := utils.OffsetLimit(p)
 	colMap := scopes.ColumnMap{
 		"id":         "organizations.id",
@@ -71,7 +91,74 @@ func (r *organizationRepository) List(ctx context.Context, p common.PaginationQu
 	return items, total, page, size, nil
 }
 
+// Stats нь устгагдаагүй байгууллагуудыг type_id-аар бүлэглэж тоо, хамгийн сүүлд үүссэн огноо,
+// төрлийн нэрийг (organization_types-аас) type_id-аар эрэмбэлж буцаана.
+func (r *organizationRepository) Stats(ctx context.Context) ([]dto.OrgTypeStats, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "Stats")
+	defer span.End()
+
+	var stats []dto.OrgTypeStats
+	err := r.db.WithContext(ctx).Model(&domain.Organization{}).
+		Select("organizations.type_id, COALESCE(organization_types.name, '') AS type_name, " +
+			"COUNT(*) AS count, MAX(organizations.created_date) AS latest_created").
+		Joins("LEFT JOIN organization_types ON organization_types.id = organizations.type_id").
+		Group("organizations.type_id, organization_types.name").
+		Order("organizations.type_id").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// Search нь search_vector (tsvector, GIN index) дээр full-text хайлт хийнэ.
+// Үг бүрийг prefix (үг:*) байдлаар AND-аар нэгтгэнэ, ts_rank-аар эрэмбэлнэ.
+// Хайх үг үлдээгүй бол хоосон үр дүн буцаана.
+func (r *organizationRepository) Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.Organization, int64, int, int, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "Search")
+	defer span.End()
+
+	page, size, offset := utils.OffsetLimit(p)
+
+	tsq := prefixTSQuery(query)
+	if tsq == "" {
+		return []domain.Organization{}, 0, page, size, nil
+	}
+
+	tx := r.db.WithContext(ctx).Model(&domain.Organization{}).
+		Where("search_vector @@ to_tsquery('simple', ?)", tsq)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	var items []domain.Organization
+	if err := tx.Preload("Type").
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(search_vector, to_tsquery('simple', ?)) DESC, name ASC",
+			Vars:               []interface{}{tsq},
+			WithoutParentheses: true,
+		}}).
+		Offset(offset).Limit(size).Find(&items).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+	return items, total, page, size, nil
+}
+
+// prefixTSQuery нь хэрэглэгчийн оролтыг to_tsquery-д аюулгүй хэлбэрт оруулна.
+// Жишээ: "гэрэгэ  core!" → "гэрэгэ:* & core:*"
+func prefixTSQuery(q string) string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
 func (r *organizationRepository) Create(ctx context.Context, m domain.Organization) (domain.Organization, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "Create")
+	defer span.End()
+
 	if err := r.db.WithContext(ctx).Clauses(clause.Returning{}, clause.OnConflict{
 		Columns:   []clause.Column{{Name: "id"}},
 		UpdateAll: true,
@@ -82,18 +169,39 @@ func (r *organizationRepository) Create(ctx context.Context, m domain.Organizati
 }
 
 func (r *organizationRepository) Update(ctx context.Context, id int, m domain.Organization) (domain.Organization, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "Update")
+	defer span.End()
+
+	// m.Version нь client-ийн уншсан хувилбар. Зөвхөн тэр хувилбар хэвээр байвал
+	// шинэчилж, хувилбарыг нэмэгдүүлнэ.
+	expected := m.Version
 	m.Id = id
-	if err := r.db.WithContext(ctx).Clauses(clause.Returning{}).
+	m.Version = expected + 1
+	res := r.db.WithContext(ctx).Clauses(clause.Returning{}).
 		Model(&domain.Organization{}).
-		Where("id = ?", id).
-		Updates(&m).Error; err != nil {
-		return domain.Organization{}, err
+		Where("id = ? AND version = ?", id, expected).
+		Updates(&m)
+	if res.Error != nil {
+		return domain.Organization{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		var exists int64
+		if err := r.db.WithContext(ctx).Model(&domain.Organization{}).Where("id = ?", id).Count(&exists).Error; err != nil {
+			return domain.Organization{}, err
+		}
+		if exists == 0 {
+			return domain.Organization{}, domain.NewNotFound("organization not found", nil)
+		}
+		return domain.Organization{}, domain.NewConflict("organization was modified by another request", nil)
 	}
 	return m, nil
 }
 
 func (r *organizationRepository) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "Delete")
+	defer span.End()
+
+	return dbtx.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
 		if err := tx.Delete(&domain.OrganizationUser{}, "org_id = ?", id).Error; err != nil {
 			return err
 		}
@@ -104,28 +212,180 @@ func (r *organizationRepository) Delete(ctx context.Context, id int) error {
 	})
 }
 
+// DeletedByID нь soft delete хийгдсэн байгууллагыг буцаана.
+// Устгагдаагүй эсвэл бүр мөсөн (hard) устгагдсан бол ErrNotFound.
+func (r *organizationRepository) DeletedByID(ctx context.Context, id int) (domain.Organization, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "DeletedByID")
+	defer span.End()
+
+	var o domain.Organization
+	err := r.db.WithContext(ctx).Scopes(softdelete.DeletedOnly()).Take(&o, "id = ?", id).Error
+	return o, domain.WrapNotFound(err, "deleted organization not found")
+}
+
+// Restore нь soft delete хийгдсэн байгууллагын deleted_date-ийг NULL болгож сэргээнэ.
+// Delete-ээр устгагдсан organization_users холбоосууд сэргээгдэхгүй.
+// Сэргээх мөр олдохгүй бол ErrNotFound.
+func (r *organizationRepository) Restore(ctx context.Context, id int) error {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "Restore")
+	defer span.End()
+
+	res := r.db.WithContext(ctx).Unscoped().Model(&domain.Organization{}).
+		Where("id = ? AND deleted_date IS NOT NULL", id).
+		Updates(map[string]any{
+			"deleted_date": nil,
+			"version":      gorm.Expr("version + 1"),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFound("deleted organization not found", nil)
+	}
+	return nil
+}
+
 func (r *organizationRepository) ByID(ctx context.Context, id int) (domain.Organization, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "ByID")
+	defer span.End()
+
 	var o domain.Organization
 	err := r.db.WithContext(ctx).Preload("Type").Take(&o, "id = ?", id).Error
-	return o, err
+	return o, domain.WrapNotFound(err, "organization not found")
+}
+
+// orgDetailSelect нь ByIDWithDetail-ийн баганууд: устгагдсан хүүхэд, гишүүнчлэл,
+// хэрэглэгч тоологдохгүй (GET /organization/:id/users-тэй ижил)
+const orgDetailSelect = `organizations.*,
+	COALESCE(organization_types.name, '') AS type_name,
+	(SELECT COUNT(*) FROM organizations AS c
+		WHERE c.parent_id = organizations.id AND c.deleted_date IS NULL) AS children_count,
+	(SELECT COUNT(*) FROM organization_users AS ou
+		JOIN users AS u ON u.id = ou.user_id AND u.deleted_date IS NULL
+		WHERE ou.org_id = organizations.id AND ou.deleted_date IS NULL) AS user_count`
+
+func (r *organizationRepository) ByIDWithDetail(ctx context.Context, id int) (dto.OrganizationDetail, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "ByIDWithDetail")
+	defer span.End()
+
+	var d dto.OrganizationDetail
+	err := r.db.WithContext(ctx).Model(&domain.Organization{}).
+		Select(orgDetailSelect).
+		Joins("LEFT JOIN organization_types ON organization_types.id = organizations.type_id").
+		Where("organizations.id = ?", id).
+		Take(&d).Error
+	return d, domain.WrapNotFound(err, "organization not found")
+}
+
+func (r *organizationRepository) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "Exists")
+	defer span.End()
+
+	var cnt int64
+	if err := r.db.WithContext(ctx).Model(&domain.Organization{}).Where("id = ?", id).Count(&cnt).Error; err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
 }
 
-func (r *organizationRepository) Tree(ctx context.Context, rootID int) ([]domain.Organization, error) {
+// ancestorsCTE нь parentID-аас эхлэн root хүртэлх бүх өвөг байгууллагын id-г гаргана.
+// UNION (ALL биш) нь өгөгдөлд аль хэдийн цикл байсан ч рекурс зогсохыг баталгаажуулна.
+const ancestorsCTE = `
+WITH RECURSIVE ancestors AS (
+	SELECT id, parent_id FROM organizations WHERE id = ?
+	UNION
+	SELECT o.id, o.parent_id FROM organizations o JOIN ancestors a ON o.id = a.parent_id
+)
+SELECT COUNT(*) FROM ancestors WHERE id = ?`
+
+// orgMoveLockKey нь MoveToParent-ийн transaction-level advisory lock-ийн түлхүүр
+const orgMoveLockKey int64 = 0x6f72675f6d6f7665 // "org_move"
+
+// MoveToParent нь orgID байгууллагыг (дэд модтой нь) newParentID-ийн доор шилжүүлнэ.
+// newParentID == 0 бол root болгоно (parent_id = NULL).
+//
+// Шинэ parent нь orgID өөрөө эсвэл түүний үр удам бол цикл үүсэх тул
+// ErrInvalidInput буцаана. Байгууллага/parent олдохгүй бол ErrNotFound.
+//
+// Шилжүүлэлтүүд advisory lock-оор дараалуулагдана: зэрэг ирсэн A→B, B→A хоёр
+// шилжүүлэлт цикл шалгалтыг хоёулаа давж цикл үүсгэхгүй.
+func (r *organizationRepository) MoveToParent(ctx context.Context, orgID, newParentID int) error {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "MoveToParent")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Transaction дуустал (commit/rollback) бусад MoveToParent хүлээнэ
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", orgMoveLockKey).Error; err != nil {
+			return err
+		}
+
+		var org domain.Organization
+		if err := tx.Select("id").Take(&org, "id = ?", orgID).Error; err != nil {
+			return domain.WrapNotFound(err, "organization not found")
+		}
+
+		var parentID *int
+		if newParentID != 0 {
+			var parent domain.Organization
+			if err := tx.Select("id").Take(&parent, "id = ?", newParentID).Error; err != nil {
+				return domain.WrapNotFound(err, "parent organization not found")
+			}
+
+			// newParentID-ийн өвгүүдийн дунд orgID байвал orgID-ийн үр удам руу шилжүүлж байна
+			var cycles int64
+			if err := tx.Raw(ancestorsCTE, newParentID, orgID).Scan(&cycles).Error; err != nil {
+				return err
+			}
+			if cycles > 0 {
+				return domain.NewInvalidInput("organization cannot be moved under itself or its descendant", nil)
+			}
+			parentID = &newParentID
+		}
+
+		return tx.Model(&domain.Organization{}).Where("id = ?", orgID).Updates(map[string]any{
+			"parent_id": parentID,
+			"version":   gorm.Expr("version + 1"),
+		}).Error
+	})
+}
+
+// orgTreeMaxDepth нь parent_id-д (алдаатай өгөгдлөөс) цикл үүссэн ч CTE төгсгөлгүй
+// давтагдахаас сэргийлэх гүний хязгаар. MoveToParent нь цикл үүсгэхийг хориглодог.
+const orgTreeMaxDepth = 64
+
+// treeCTE нь root-оос эхлэн устгагдаагүй бүх үр удмыг гүнээр нь буцаана
+const treeCTE = `
+WITH RECURSIVE org_tree AS (
+	SELECT o.*, 0 AS depth FROM organizations o WHERE o.id = ? AND o.deleted_date IS NULL
+	UNION ALL
+	SELECT o.*, ot.depth + 1 FROM organizations o
+	JOIN org_tree ot ON o.parent_id = ot.id
+	WHERE o.deleted_date IS NULL AND ot.depth < ?
+)
+SELECT * FROM org_tree ORDER BY depth, sequence, id`
+
+// TreeCTE нь root болон бүх түвшний үр удмыг нэг query-гээр авна (root олдохгүй бол хоосон).
+// Мод бүтэц (Children) нь service давхаргад угсрагдана.
+func (r *organizationRepository) TreeCTE(ctx context.Context, rootID int) ([]domain.Organization, error) {
+	ctx, span := startSpanLog(ctx, r.log, "organizations", "TreeCTE")
+	defer span.End()
+
 	var items []domain.Organization
-	// Хэрэв танайд ParentPreloader/ChildrenPreloader байгаа бол түүнийг хэрэглээрэй.
-	if err := r.db.WithContext(ctx).
-		Preload("Children").
-		Find(&items, "id = ?", rootID).Error; err != nil {
+	if err := r.db.WithContext(ctx).Raw(treeCTE, rootID, orgTreeMaxDepth).Scan(&items).Error; err != nil {
 		return nil, err
 	}
 	return items, nil
 }
 
 type OrganizationTypeRepository interface {
+	// List нь шүүлтгүй хуудсуудыг OrganizationTypeCache-ээр дамжуулна (TTL 60s)
 	List(ctx context.Context, p common.PaginationQuery) ([]domain.OrganizationType, int64, int, int, error)
+	// ForceRefresh нь cache-ийг цэвэрлээд бүх төрлийг DB-ээс шууд уншина
+	ForceRefresh(ctx context.Context) ([]domain.OrganizationType, error)
 	Create(ctx context.Context, m domain.OrganizationType) error
 	Update(ctx context.Context, id int, m domain.OrganizationType) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id int, reason string) error
+	ByID(ctx context.Context, id int) (domain.OrganizationType, error)
 
 	// System linkage
 	AddSystems(ctx context.Context, orgTypeID int, systemIDs []int) error
@@ -134,15 +394,40 @@ type OrganizationTypeRepository interface {
 	// Role linkage
 	AddRoles(ctx context.Context, orgTypeID int, roleIDs []int) error
 	Roles(ctx context.Context, orgTypeID int) ([]domain.Role, error)
+
+	// Permission linkage
+	AddPermissions(ctx context.Context, orgTypeID int, permissionIDs []int) error
+	Permissions(ctx context.Context, orgTypeID int) ([]domain.Permission, error)
 }
 
-type organizationTypeRepository struct{ db *gorm.DB }
+type organizationTypeRepository struct {
+	db    *gorm.DB
+	cache *OrganizationTypeCache
+}
 
 func NewOrganizationTypeRepository(db *gorm.DB) OrganizationTypeRepository {
-	return &organizationTypeRepository{db: db}
+	return &organizationTypeRepository{db: db, cache: NewOrganizationTypeCache(OrganizationTypeCacheTTL)}
 }
 
 func (r *organizationTypeRepository) List(ctx context.Context, p common.PaginationQuery) ([]domain.OrganizationType, int64, int, int, error) {
+	ctx, span := startSpan(ctx, "organization_types", "List")
+	defer span.End()
+
+	res, gen, ok := r.cache.get(p)
+	if ok {
+		return res.items, res.total, res.page, res.size, nil
+	}
+
+	items, total, page, size, err := r.list(ctx, p)
+	if err != nil {
+		return nil, 0, 0, 0, err
+	}
+	r.cache.set(p, gen, &orgTypeListResult{items: items, total: total, page: page, size: size})
+	return items, total, page, size, nil
+}
+
+// list нь cache-гүйгээр DB-ээс нэг хуудас уншина
+func (r *organizationTypeRepository) list(ctx context.Context, p common.PaginationQuery) ([]domain.OrganizationType, int64, int, int, error) {
 	page, size, offset := utils.OffsetLimit(p)
 	colMap := scopes.ColumnMap{
 		"id":   "organization_types.id",
@@ -165,7 +450,23 @@ func (r *organizationTypeRepository) List(ctx context.Context, p common.Paginati
 	return items, total, page, size, nil
 }
 
+func (r *organizationTypeRepository) ForceRefresh(ctx context.Context) ([]domain.OrganizationType, error) {
+	ctx, span := startSpan(ctx, "organization_types", "ForceRefresh")
+	defer span.End()
+
+	r.cache.Invalidate()
+
+	var items []domain.OrganizationType
+	if err := r.db.WithContext(ctx).Order("id DESC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 func (r *organizationTypeRepository) Create(uctx context.Context, m domain.OrganizationType) error {
+	uctx, span := startSpan(uctx, "organization_types", "Create")
+	defer span.End()
+
 	if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
 		m.CreatedUserId = userId
 	}
@@ -173,24 +474,41 @@ func (r *organizationTypeRepository) Create(uctx context.Context, m domain.Organ
 		m.CreatedOrgId = orgId
 	}
 
-	return r.db.WithContext(uctx).Create(&m).Error
+	if err := r.db.WithContext(uctx).Create(&m).Error; err != nil {
+		return err
+	}
+	r.cache.Invalidate()
+	return nil
 }
 
 func (r *organizationTypeRepository) Update(uctx context.Context, id int, m domain.OrganizationType) error {
+	uctx, span := startSpan(uctx, "organization_types", "Update")
+	defer span.End()
+
 	if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
 		m.UpdatedUserId = userId
 	}
 	if orgId, ok := ctx.GetValue[int](uctx, ctx.KeyOrgID); ok {
 		m.UpdatedOrgId = orgId
 	}
-	return r.db.WithContext(uctx).
+	// enforce_role_limit-ийг false болгож болохоор (struct Updates нь zero утгыг алгасдаг)
+	// засварлах боломжтой баганууд бүгд бичигдэнэ
+	if err := r.db.WithContext(uctx).
 		Model(&domain.OrganizationType{}).
 		Where("id = ?", id).
-		Updates(&m).Error
+		Select("code", "name", "description", "enforce_role_limit", "updated_user_id", "updated_org_id").
+		Updates(&m).Error; err != nil {
+		return err
+	}
+	r.cache.Invalidate()
+	return nil
 }
 
-func (r *organizationTypeRepository) Delete(uctx context.Context, id int) error {
-	m := domain.OrganizationType{}
+func (r *organizationTypeRepository) Delete(uctx context.Context, id int, reason string) error {
+	uctx, span := startSpan(uctx, "organization_types", "Delete")
+	defer span.End()
+
+	m := domain.OrganizationType{DeleteReason: reason}
 	if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
 		m.DeletedUserId = userId
 	}
@@ -198,7 +516,20 @@ func (r *organizationTypeRepository) Delete(uctx context.Context, id int) error
 		m.DeletedOrgId = orgId
 	}
 	m.DeletedDate = gorm.DeletedAt{Valid: true, Time: time.Now()}
-	return r.db.WithContext(uctx).Where("id = ?", id).Updates(&m).Error
+	if err := r.db.WithContext(uctx).Where("id = ?", id).Updates(&m).Error; err != nil {
+		return err
+	}
+	r.cache.Invalidate()
+	return nil
+}
+
+func (r *organizationTypeRepository) ByID(ctx context.Context, id int) (domain.OrganizationType, error) {
+	ctx, span := startSpan(ctx, "organization_types", "ByID")
+	defer span.End()
+
+	var m domain.OrganizationType
+	err := r.db.WithContext(ctx).Take(&m, "id = ?", id).Error
+	return m, domain.WrapNotFound(err, "organization type not found")
 }
 
 type OrgUserRepository interface {
@@ -228,6 +559,9 @@ func NewOrgUserRepository(db *gorm.DB, cfg *config.Config) OrgUserRepository {
 }
 
 func (r *orgUserRepository) List(ctx context.Context, q dto.OrgUserListQuery) ([]domain.OrganizationUser, int64, int, int, error) {
+	ctx, span := startSpan(ctx, "organization_users", "List")
+	defer span.End()
+
 	page, size, offset := utils.OffsetLimit(q.PaginationQuery)
 
 	var (
@@ -272,14 +606,23 @@ func (r *orgUserRepository) List(ctx context.Context, q dto.OrgUserListQuery) ([
 }
 
 func (r *orgUserRepository) Add(ctx context.Context, ou domain.OrganizationUser) error {
+	ctx, span := startSpan(ctx, "organization_users", "Add")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Create(&ou).Error
 }
 
 func (r *orgUserRepository) Remove(ctx context.Context, orgId, userId int) error {
+	ctx, span := startSpan(ctx, "organization_users", "Remove")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Delete(&domain.OrganizationUser{}, "org_id = ? AND user_id = ?", orgId, userId).Error
 }
 
 func (r *orgUserRepository) OrgExists(ctx context.Context, orgId int) (bool, error) {
+	ctx, span := startSpan(ctx, "organizations", "OrgExists")
+	defer span.End()
+
 	var cnt int64
 	if err := r.db.WithContext(ctx).Model(&domain.Organization{}).Where("id = ?", orgId).Count(&cnt).Error; err != nil {
 		return false, err
@@ -288,6 +631,9 @@ func (r *orgUserRepository) OrgExists(ctx context.Context, orgId int) (bool, err
 }
 
 func (r *orgUserRepository) UserExists(ctx context.Context, userId int) (bool, error) {
+	ctx, span := startSpan(ctx, "users", "UserExists")
+	defer span.End()
+
 	var cnt int64
 	if err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", userId).Count(&cnt).Error; err != nil {
 		return false, err
@@ -296,41 +642,41 @@ func (r *orgUserRepository) UserExists(ctx context.Context, userId int) (bool, e
 }
 
 func (r *orgUserRepository) FindByOrgAndUser(ctx context.Context, orgId, userId int) (domain.OrganizationUser, error) {
+	ctx, span := startSpan(ctx, "organization_users", "FindByOrgAndUser")
+	defer span.End()
+
 	var m domain.OrganizationUser
 	err := r.db.WithContext(ctx).Where("org_id = ? AND user_id = ?", orgId, userId).First(&m).Error
-	return m, err
+	return m, domain.WrapNotFound(err, "organization user not found")
 }
 
-// ---------- Raw JOIN queries (pagination гарыг нь удирдана) ----------
+// ---------- JOIN queries (pagination гарыг нь удирдана) ----------
 
 func (r *orgUserRepository) ListUsersByOrg(ctx context.Context, orgId int, name string, page, size int) ([]dto.ResOrguserUserItem, int64, error) {
+	ctx, span := startSpan(ctx, "organization_users", "ListUsersByOrg")
+	defer span.End()
+
 	var (
 		total int64
 		rows  []dto.ResOrguserUserItem
 	)
 
-	nameCond := ""
-	argsCnt := []any{orgId}
+	tx := r.db.WithContext(ctx).
+		Table("organization_users AS tou").
+		Joins("LEFT JOIN users tu ON tou.user_id = tu.id").
+		Scopes(softdelete.ActiveOn("tou"), softdelete.ActiveOn("tu")).
+		Where("tou.org_id = ?", orgId)
 	if strings.TrimSpace(name) != "" {
-		nameCond = "AND (tu.first_name ILIKE @name OR tu.last_name ILIKE @name OR tu.reg_no ILIKE @name OR tu.phone_no ILIKE @name)"
-		argsCnt = append(argsCnt, sql.Named("name", "%"+name+"%"))
-	}
-
-	cntSQL := fmt.Sprintf(`
-		SELECT COUNT(*) FROM organization_users tou
-		LEFT JOIN users tu ON tou.user_id = tu.id
-		WHERE tou.deleted_date IS NULL
-		  AND tu.deleted_date IS NULL
-		  AND tou.org_id = ?
-		  %s
-	`, nameCond)
-	if err := r.db.WithContext(ctx).Raw(cntSQL, argsCnt...).Scan(&total).Error; err != nil {
+		tx = tx.Where("(tu.first_name ILIKE @name OR tu.last_name ILIKE @name OR tu.reg_no ILIKE @name OR tu.phone_no ILIKE @name)",
+			sql.Named("name", "%"+name+"%"))
+	}
+	tx = tx.Session(&gorm.Session{})
+
+	if err := tx.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	offset := (page - 1) * size
-	querySQL := fmt.Sprintf(`
-		SELECT
+	if err := tx.Select(`
 			tou.org_id,
 			tu.id AS user_id,
 			tu.last_name,
@@ -340,74 +686,47 @@ func (r *orgUserRepository) ListUsersByOrg(ctx context.Context, orgId int, name
 			tu.gender,
 			tu.phone_no,
 			tu.email,
-			tou.created_date
-		FROM organization_users tou
-		LEFT JOIN users tu ON tou.user_id = tu.id
-		WHERE tou.deleted_date IS NULL
-		  AND tu.deleted_date IS NULL
-		  AND tou.org_id = ?
-		  %s
-		LIMIT %d OFFSET %d
-	`, nameCond, size, offset)
-
-	args := []any{orgId}
-	if strings.TrimSpace(name) != "" {
-		args = append(args, sql.Named("name", "%"+name+"%"))
-	}
-	if err := r.db.WithContext(ctx).Raw(querySQL, args...).Scan(&rows).Error; err != nil {
+			tou.created_date`).
+		Limit(size).Offset((page - 1) * size).
+		Scan(&rows).Error; err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
 }
 
 func (r *orgUserRepository) ListOrgsByUser(ctx context.Context, userId int, name string, page, size int) ([]dto.ResOrguserOrgItem, int64, error) {
+	ctx, span := startSpan(ctx, "organization_users", "ListOrgsByUser")
+	defer span.End()
+
 	var (
 		total int64
 		rows  []dto.ResOrguserOrgItem
 	)
 
-	nameCond := ""
-	argsCnt := []any{userId}
+	tx := r.db.WithContext(ctx).
+		Table("organization_users AS tou").
+		Joins("LEFT JOIN organizations tu ON tou.org_id = tu.id").
+		Scopes(softdelete.ActiveOn("tou"), softdelete.ActiveOn("tu")).
+		Where("tou.user_id = ?", userId)
 	if strings.TrimSpace(name) != "" {
-		nameCond = "AND (tu.name ILIKE @name OR tu.short_name ILIKE @name OR tu.reg_no ILIKE @name)"
-		argsCnt = append(argsCnt, sql.Named("name", "%"+name+"%"))
-	}
-
-	cntSQL := fmt.Sprintf(`
-		SELECT COUNT(*) FROM organization_users tou
-		LEFT JOIN organizations tu ON tou.org_id = tu.id
-		WHERE tou.deleted_date IS NULL
-		  AND tu.deleted_date IS NULL
-		  AND tou.user_id = ?
-		  %s
-	`, nameCond)
-	if err := r.db.WithContext(ctx).Raw(cntSQL, argsCnt...).Scan(&total).Error; err != nil {
+		tx = tx.Where("(tu.name ILIKE @name OR tu.short_name ILIKE @name OR tu.reg_no ILIKE @name)",
+			sql.Named("name", "%"+name+"%"))
+	}
+	tx = tx.Session(&gorm.Session{})
+
+	if err := tx.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	offset := (page - 1) * size
-	querySQL := fmt.Sprintf(`
-		SELECT
+	if err := tx.Select(`
 			tou.org_id,
 			tu.id,
 			tu.name,
 			tu.short_name,
 			tu.reg_no,
-			tou.created_date
-		FROM organization_users tou
-		LEFT JOIN organizations tu ON tou.org_id = tu.id
-		WHERE tou.deleted_date IS NULL
-		  AND tu.deleted_date IS NULL
-		  AND tou.user_id = ?
-		  %s
-		LIMIT %d OFFSET %d
-	`, nameCond, size, offset)
-
-	args := []any{userId}
-	if strings.TrimSpace(name) != "" {
-		args = append(args, sql.Named("name", "%"+name+"%"))
-	}
-	if err := r.db.WithContext(ctx).Raw(querySQL, args...).Scan(&rows).Error; err != nil {
+			tou.created_date`).
+		Limit(size).Offset((page - 1) * size).
+		Scan(&rows).Error; err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -416,6 +735,9 @@ func (r *orgUserRepository) ListOrgsByUser(ctx context.Context, userId int, name
 // --- System linkage ---
 
 func (r *organizationTypeRepository) Systems(ctx context.Context, orgTypeID int) ([]domain.System, error) {
+	ctx, span := startSpan(ctx, "org_type_systems", "Systems")
+	defer span.End()
+
 	var links []domain.OrgTypeSystem
 	if err := r.db.WithContext(ctx).
 		Preload("System").
@@ -434,9 +756,27 @@ func (r *organizationTypeRepository) Systems(ctx context.Context, orgTypeID int)
 }
 
 func (r *organizationTypeRepository) AddSystems(ctx context.Context, orgTypeID int, systemIDs []int) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	ctx, span := startSpan(ctx, "org_type_systems", "AddSystems")
+	defer span.End()
+
+	return dbtx.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		if len(systemIDs) > 0 {
+			// Бүх module нь идэвхгүй system-ийг холбохгүй
+			var inactive []int
+			if err := tx.Model(&domain.Module{}).
+				Where("system_id IN ?", systemIDs).
+				Group("system_id").
+				Having("NOT bool_or(COALESCE(is_active, TRUE))").
+				Pluck("system_id", &inactive).Error; err != nil {
+				return err
+			}
+			if len(inactive) > 0 {
+				return domain.NewInvalidInput(fmt.Sprintf("systems have no active modules: %v", inactive), nil)
+			}
+		}
+
 		// одоогийн map-уудыг цэвэрлээд шинээр үүсгэнэ (replace semantics)
-		if err := tx.WithContext(ctx).
+		if err := tx.
 			Where("type_id = ?", orgTypeID).
 			Delete(&domain.OrgTypeSystem{}).Error; err != nil {
 			return err
@@ -454,13 +794,16 @@ func (r *organizationTypeRepository) AddSystems(ctx context.Context, orgTypeID i
 				SystemID: sid,
 			})
 		}
-		return tx.WithContext(ctx).Create(&links).Error
+		return tx.Create(&links).Error
 	})
 }
 
 // --- Role linkage ---
 
 func (r *organizationTypeRepository) Roles(ctx context.Context, orgTypeID int) ([]domain.Role, error) {
+	ctx, span := startSpan(ctx, "org_type_roles", "Roles")
+	defer span.End()
+
 	var links []domain.OrgTypeRole
 	if err := r.db.WithContext(ctx).
 		Preload("Role").
@@ -479,6 +822,9 @@ func (r *organizationTypeRepository) Roles(ctx context.Context, orgTypeID int) (
 }
 
 func (r *organizationTypeRepository) AddRoles(ctx context.Context, orgTypeID int, roleIDs []int) error {
+	ctx, span := startSpan(ctx, "org_type_roles", "AddRoles")
+	defer span.End()
+
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// одоогийн map-уудыг цэвэрлээд шинээр үүсгэнэ (replace semantics)
 		if err := tx.WithContext(ctx).
@@ -502,3 +848,55 @@ func (r *organizationTypeRepository) AddRoles(ctx context.Context, orgTypeID int
 		return tx.WithContext(ctx).Create(&links).Error
 	})
 }
+
+// --- Permission linkage ---
+
+func (r *organizationTypeRepository) Permissions(ctx context.Context, orgTypeID int) ([]domain.Permission, error) {
+	ctx, span := startSpan(ctx, "org_type_permissions", "Permissions")
+	defer span.End()
+
+	var links []domain.OrgTypePermission
+	if err := r.db.WithContext(ctx).
+		Preload("Permission").
+		Where("type_id = ?", orgTypeID).
+		Order("permission_id").
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Permission, 0, len(links))
+	for _, l := range links {
+		if l.Permission != nil {
+			out = append(out, *l.Permission)
+		}
+	}
+	return out, nil
+}
+
+func (r *organizationTypeRepository) AddPermissions(ctx context.Context, orgTypeID int, permissionIDs []int) error {
+	ctx, span := startSpan(ctx, "org_type_permissions", "AddPermissions")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// одоогийн map-уудыг цэвэрлээд шинээр үүсгэнэ (replace semantics).
+		// Composite PK тул soft delete биш, бүр устгана — эс бөгөөс дахин нэмэхэд давхцана.
+		if err := tx.WithContext(ctx).Unscoped().
+			Where("type_id = ?", orgTypeID).
+			Delete(&domain.OrgTypePermission{}).Error; err != nil {
+			return err
+		}
+
+		if len(permissionIDs) == 0 {
+			return nil
+		}
+
+		links := make([]domain.OrgTypePermission, 0, len(permissionIDs))
+		for _, pid := range permissionIDs {
+			links = append(links, domain.OrgTypePermission{
+				TypeID:       orgTypeID,
+				PermissionID: pid,
+			})
+		}
+		return tx.WithContext(ctx).Create(&links).Error
+	})
+}
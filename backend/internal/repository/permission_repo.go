@@ -11,6 +11,7 @@ package repository
 import (
 	"context"
 	"strings"
+	dbtx "templatev25/internal/db"
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 
@@ -27,16 +28,28 @@ type PermissionRepository interface {
 	List(ctx context.Context, q dto.PermissionQuery) ([]domain.Permission, int64, int, int, error)
 	ByID(ctx context.Context, id int) (domain.Permission, error)
 	ByCode(ctx context.Context, code string) (domain.Permission, error)
+	ListBySystem(ctx context.Context, systemID int) ([]domain.Permission, error)
+	ListByModule(ctx context.Context, moduleID int, q dto.PermissionQuery) ([]domain.Permission, int64, int, int, error)
 	Create(ctx context.Context, m domain.Permission) error
-	CreateBatch(ctx context.Context, systemID int, moduleID int, actionIDs []int64) error
+	CreateBatch(ctx context.Context, systemID int, moduleID int, actionIDs []int64, codeFn PermissionCodeFunc) error
 	Update(ctx context.Context, id int, m domain.Permission) error
 	Delete(ctx context.Context, id int) error
+	// DeleteByModule нь модулийн бүх permission-ийг soft delete хийж, устгасан тоог буцаана
+	DeleteByModule(ctx context.Context, moduleID int) (int64, error)
 
 	// Permission шалгах методууд
 	UserHasPermission(ctx context.Context, userID int, permissionCode string) (bool, error)
 	GetUserPermissionCodes(ctx context.Context, userID int) ([]string, error)
+
+	// Байгууллагын төрлөөр олгогдсон permission (org_type_permissions)
+	UserHasOrgTypePermission(ctx context.Context, userID int, permissionCode string) (bool, error)
+	GetUserOrgTypePermissionCodes(ctx context.Context, userID int) ([]string, error)
 }
 
+// PermissionCodeFunc нь system, module, action-ийн кодоос permission code үүсгэнэ.
+// Алдаа буцаавал CreateBatch transaction rollback хийгдэнэ.
+type PermissionCodeFunc func(systemCode, moduleCode, actionCode string) (string, error)
+
 type permissionRepository struct {
 	db *gorm.DB
 }
@@ -71,6 +84,10 @@ func (r *permissionRepository) List(ctx context.Context, q dto.PermissionQuery)
 		tx = tx.Where("module_id = ?", q.ModuleID)
 	}
 
+	if !q.IncludeInactiveModules {
+		tx = tx.Scopes(activeModulePermissions)
+	}
+
 	var total int64
 	if err := tx.Count(&total).Error; err != nil {
 		return nil, 0, 0, 0, err
@@ -85,10 +102,16 @@ func (r *permissionRepository) List(ctx context.Context, q dto.PermissionQuery)
 	return items, total, page, size, nil
 }
 
+// activeModulePermissions нь идэвхгүй (is_active = false) module-ийн permission-уудыг хасна.
+// Module-гүй permission үлдэнэ.
+func activeModulePermissions(db *gorm.DB) *gorm.DB {
+	return db.Where("NOT EXISTS (SELECT 1 FROM modules m WHERE m.id = permissions.module_id AND m.is_active = ?)", false)
+}
+
 func (r *permissionRepository) ByID(ctx context.Context, id int) (domain.Permission, error) {
 	var m domain.Permission
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
-		return domain.Permission{}, err
+		return domain.Permission{}, domain.WrapNotFound(err, "permission not found")
 	}
 	return m, nil
 }
@@ -96,11 +119,30 @@ func (r *permissionRepository) ByID(ctx context.Context, id int) (domain.Permiss
 func (r *permissionRepository) ByCode(ctx context.Context, code string) (domain.Permission, error) {
 	var m domain.Permission
 	if err := r.db.WithContext(ctx).Where("code = ? AND deleted_date IS NULL", code).First(&m).Error; err != nil {
-		return domain.Permission{}, err
+		return domain.Permission{}, domain.WrapNotFound(err, "permission not found")
 	}
 	return m, nil
 }
 
+// ListBySystem нь system-ийн бүх permission-ийг module_id, code дарааллаар буцаана
+func (r *permissionRepository) ListBySystem(ctx context.Context, systemID int) ([]domain.Permission, error) {
+	var items []domain.Permission
+	if err := r.db.WithContext(ctx).
+		Where("system_id = ?", systemID).
+		Order("module_id ASC, code ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ListByModule нь нэг module-ийн permission-уудыг List-ийн search/sort/pagination-тай буцаана.
+// q.ModuleID-г moduleID-аар дарж бичнэ.
+func (r *permissionRepository) ListByModule(ctx context.Context, moduleID int, q dto.PermissionQuery) ([]domain.Permission, int64, int, int, error) {
+	q.ModuleID = moduleID
+	return r.List(ctx, q)
+}
+
 func (r *permissionRepository) Create(uctx context.Context, m domain.Permission) error {
 	if uid, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
 		m.CreatedUserId = uid
@@ -111,7 +153,9 @@ func (r *permissionRepository) Create(uctx context.Context, m domain.Permission)
 	return r.db.WithContext(uctx).Create(&m).Error
 }
 
-func (r *permissionRepository) CreateBatch(uctx context.Context, systemID int, moduleID int, actionIDs []int64) error {
+// CreateBatch нь action бүрт нэг permission үүсгэнэ.
+// codeFn nil бол code нь systemcode.modulecode.actioncode (lower case) байна.
+func (r *permissionRepository) CreateBatch(uctx context.Context, systemID int, moduleID int, actionIDs []int64, codeFn PermissionCodeFunc) error {
 	// ctx-оос CreatedUser/Org онооно
 	var createdUserId, createdOrgId int
 	if uid, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
@@ -150,6 +194,13 @@ func (r *permissionRepository) CreateBatch(uctx context.Context, systemID int, m
 		for _, action := range actions {
 			// Permission code-г systemcode.modulecode.actioncode гэж үүсгэх (lower case)
 			permissionCode := strings.ToLower(system.Code) + "." + strings.ToLower(module.Code) + "." + strings.ToLower(action.Code)
+			if codeFn != nil {
+				code, err := codeFn(system.Code, module.Code, action.Code)
+				if err != nil {
+					return err
+				}
+				permissionCode = code
+			}
 
 			permission := domain.Permission{
 				Code:        permissionCode,
@@ -197,6 +248,27 @@ func (r *permissionRepository) Delete(uctx context.Context, id int) error {
 	return r.db.WithContext(uctx).Where("id = ?", id).Updates(&m).Error
 }
 
+// DeleteByModule нь модулийн идэвхтэй permission-уудыг soft delete хийнэ.
+// ctx-д transaction (dbtx.WithTransaction) байвал түүнд нэгдэнэ.
+func (r *permissionRepository) DeleteByModule(uctx context.Context, moduleID int) (int64, error) {
+	m := domain.Permission{}
+	if uid, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
+		m.DeletedUserId = uid
+	}
+	if oid, ok := ctx.GetValue[int](uctx, ctx.KeyOrgID); ok {
+		m.DeletedOrgId = oid
+	}
+	m.DeletedDate = gorm.DeletedAt{Valid: true, Time: time.Now()}
+
+	var deleted int64
+	err := dbtx.WithTransaction(uctx, r.db, func(_ context.Context, tx *gorm.DB) error {
+		res := tx.Model(&domain.Permission{}).Where("module_id = ?", moduleID).Updates(&m)
+		deleted = res.RowsAffected
+		return res.Error
+	})
+	return deleted, err
+}
+
 // UserHasPermission нь хэрэглэгч тодорхой permission-тэй эсэхийг шалгана.
 // user_roles -> roles -> role_permissions -> permissions гэсэн холбоосоор шалгана.
 //
@@ -256,3 +328,43 @@ func (r *permissionRepository) GetUserPermissionCodes(ctx context.Context, userI
 	}
 	return codes, nil
 }
+
+// orgTypePermissionJoins нь хэрэглэгч → гишүүн байгууллага → төрөл → permission холбоос.
+// Устгагдсан байгууллага/гишүүнчлэл болон идэвхгүй permission-ийг тооцохгүй.
+const orgTypePermissionJoins = `
+	FROM permissions p
+	JOIN org_type_permissions otp ON otp.permission_id = p.id
+	JOIN organizations o ON o.type_id = otp.type_id
+	JOIN organization_users ou ON ou.org_id = o.id
+	WHERE ou.user_id = ?
+	AND p.is_active = true
+	AND p.deleted_date IS NULL
+	AND otp.deleted_date IS NULL
+	AND o.deleted_date IS NULL
+	AND ou.deleted_date IS NULL`
+
+// UserHasOrgTypePermission нь хэрэглэгчийн гишүүн байгаа аль нэг байгууллагын төрөлд
+// тухайн permission шууд олгогдсон эсэхийг шалгана.
+// organization_users -> organizations -> org_type_permissions -> permissions холбоосоор шалгана.
+func (r *permissionRepository) UserHasOrgTypePermission(ctx context.Context, userID int, permissionCode string) (bool, error) {
+	var exists bool
+	err := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS(SELECT 1 `+orgTypePermissionJoins+` AND p.code = ?)`,
+		userID, permissionCode,
+	).Scan(&exists).Error
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// GetUserOrgTypePermissionCodes нь хэрэглэгчийн байгууллагуудын төрлөөр олгогдсон
+// бүх permission код-уудыг буцаана.
+func (r *permissionRepository) GetUserOrgTypePermissionCodes(ctx context.Context, userID int) ([]string, error) {
+	var codes []string
+	err := r.db.WithContext(ctx).Raw(`SELECT DISTINCT p.code `+orgTypePermissionJoins, userID).Scan(&codes).Error
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
@@ -0,0 +1,29 @@
+// Package repository provides data access layer
+//
+// File: permission_repo_test.go
+// Description: DryRun SQL tests for the inactive module permission filter
+package repository
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveModulePermissions_SQL(t *testing.T) {
+	gdb, lastSQL := newDryRunDB(t)
+
+	var items []domain.Permission
+	require.NoError(t, gdb.Model(&domain.Permission{}).
+		Where("system_id = ?", 3).
+		Scopes(activeModulePermissions).
+		Find(&items).Error)
+
+	sql := lastSQL()
+	assert.Contains(t, sql, `FROM "permissions"`)
+	assert.Contains(t, sql, "system_id = 3")
+	assert.Contains(t, sql, "NOT EXISTS (SELECT 1 FROM modules m WHERE m.id = permissions.module_id AND m.is_active = false)")
+}
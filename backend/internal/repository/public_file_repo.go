@@ -80,7 +80,7 @@ func (r *publicFileRepository) Create(ctx context.Context, m domain.PublicFile)
 func (r *publicFileRepository) DeleteByID(ctx context.Context, id int) (domain.PublicFile, error) {
 	var pf domain.PublicFile
 	if err := r.db.WithContext(ctx).Take(&pf, "id = ?", id).Error; err != nil {
-		return domain.PublicFile{}, err
+		return domain.PublicFile{}, domain.WrapNotFound(err, "public file not found")
 	}
 	if err := r.db.WithContext(ctx).Delete(&pf).Error; err != nil {
 		return domain.PublicFile{}, err
@@ -91,11 +91,11 @@ func (r *publicFileRepository) DeleteByID(ctx context.Context, id int) (domain.P
 func (r *publicFileRepository) GetByName(ctx context.Context, name string) (domain.PublicFile, error) {
 	var pf domain.PublicFile
 	err := r.db.WithContext(ctx).Take(&pf, "name = ?", name).Error
-	return pf, err
+	return pf, domain.WrapNotFound(err, "public file not found")
 }
 
 func (r *publicFileRepository) GetByID(ctx context.Context, id int) (domain.PublicFile, error) {
 	var pf domain.PublicFile
 	err := r.db.WithContext(ctx).Take(&pf, "id = ?", id).Error
-	return pf, err
+	return pf, domain.WrapNotFound(err, "public file not found")
 }
@@ -6,13 +6,19 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	dbtx "templatev25/internal/db"
 	"templatev25/internal/domain"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// pgUniqueViolation нь Postgres-ийн unique constraint зөрчлийн SQLSTATE код
+const pgUniqueViolation = "23505"
+
 // RegistrationRepository defines additional repository methods for registration
 type RegistrationRepository interface {
 	// Email verification
@@ -23,12 +29,13 @@ type RegistrationRepository interface {
 
 	// Password reset
 	CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error
-	GetPasswordResetToken(ctx context.Context, token string) (*domain.PasswordResetToken, error)
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error)
 	MarkPasswordResetTokenUsed(ctx context.Context, tokenID int) error
 	DeleteUserPasswordResetTokens(ctx context.Context, userID int) error
 
 	// User management
 	CreateUser(ctx context.Context, user *domain.User) error
+	CreateUserWithCredential(ctx context.Context, user *domain.User, cred *domain.UserCredential) error
 	UpdateUserEmailVerified(ctx context.Context, userID int) error
 	GetUserByID(ctx context.Context, userID int) (*domain.User, error)
 	EmailExists(ctx context.Context, email string) (bool, error)
@@ -55,7 +62,7 @@ func (r *registrationRepository) GetEmailVerificationToken(ctx context.Context,
 	var token domain.EmailVerificationToken
 	err := r.db.WithContext(ctx).Where("token = ?", tokenStr).First(&token).Error
 	if err != nil {
-		return nil, err
+		return nil, domain.WrapNotFound(err, "verification token not found")
 	}
 	return &token, nil
 }
@@ -82,11 +89,12 @@ func (r *registrationRepository) CreatePasswordResetToken(ctx context.Context, t
 	return r.db.WithContext(ctx).Create(token).Error
 }
 
-func (r *registrationRepository) GetPasswordResetToken(ctx context.Context, tokenStr string) (*domain.PasswordResetToken, error) {
+// GetPasswordResetToken нь token_hash-аар (SHA-256 hex) токен хайна
+func (r *registrationRepository) GetPasswordResetToken(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
 	var token domain.PasswordResetToken
-	err := r.db.WithContext(ctx).Where("token = ?", tokenStr).First(&token).Error
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
 	if err != nil {
-		return nil, err
+		return nil, domain.WrapNotFound(err, "reset token not found")
 	}
 	return &token, nil
 }
@@ -113,6 +121,23 @@ func (r *registrationRepository) CreateUser(ctx context.Context, user *domain.Us
 	return r.db.WithContext(ctx).Create(user).Error
 }
 
+// CreateUserWithCredential creates the user and its password credential in one
+// transaction, so a failed credential insert leaves no password-less user behind.
+// A concurrent registration with the same email returns domain.ErrConflict.
+func (r *registrationRepository) CreateUserWithCredential(ctx context.Context, user *domain.User, cred *domain.UserCredential) error {
+	return dbtx.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+				return domain.NewConflict("email already registered", err)
+			}
+			return err
+		}
+		cred.UserID = user.Id
+		return tx.Create(cred).Error
+	})
+}
+
 func (r *registrationRepository) UpdateUserEmailVerified(ctx context.Context, userID int) error {
 	now := time.Now()
 	return r.db.WithContext(ctx).
@@ -135,11 +160,15 @@ func (r *registrationRepository) GetUserByID(ctx context.Context, userID int) (*
 	return &user, nil
 }
 
+// EmailExists reports whether any user row, including soft-deleted ones, holds
+// the email. users.email is unique across deleted rows too, so a deleted
+// user's email cannot be registered again.
 func (r *registrationRepository) EmailExists(ctx context.Context, email string) (bool, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
+		Unscoped().
 		Model(&domain.User{}).
-		Where("email = ? AND deleted_date IS NULL", email).
+		Where("LOWER(email) = LOWER(?)", email).
 		Count(&count).Error
 	if err != nil {
 		return false, err
@@ -116,3 +116,38 @@ func TestContextUsage(t *testing.T) {
 
 	assert.NotNil(t, ctx)
 }
+
+// TestPrefixTSQuery tests sanitizing user input for to_tsquery
+func TestPrefixTSQuery(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"gerege", "gerege:*"},
+		{"  Gerege   Core ", "gerege:* & core:*"},
+		{"Улаанбаатар банк", "улаанбаатар:* & банк:*"},
+		{"a&b|c!(d):*", "a:* & b:* & c:* & d:*"},
+		{"&|!", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, prefixTSQuery(tt.in), tt.in)
+	}
+}
+
+// TestEscapeLike tests escaping LIKE pattern metacharacters
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"hello", "hello"},
+		{"100%", `100\%`},
+		{"a_b", `a\_b`},
+		{`c:\dir`, `c:\\dir`},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, escapeLike(tt.in), tt.in)
+	}
+}
@@ -26,6 +26,8 @@ type RoleRepository interface {
 	// model_repo шиг PaginationQuery дамжуулдаг
 	List(ctx context.Context, p dto.RoleListQuery) ([]domain.Role, int64, int, int, error)
 	ByID(ctx context.Context, id int) (domain.Role, error)
+	// ByIDWithPermissions нь role-ийг permission-уудын хамт нэг дуудлагаар авна
+	ByIDWithPermissions(ctx context.Context, id int) (domain.Role, error)
 	// model_repo-ийн signature-тэй тааруулсан
 	Create(ctx context.Context, m domain.Role) error
 	Update(ctx context.Context, id int, m domain.Role) error
@@ -47,7 +49,21 @@ func NewRoleRepository(db *gorm.DB) RoleRepository {
 func (r *roleRepository) ByID(ctx context.Context, id int) (domain.Role, error) {
 	var m domain.Role
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
-		return domain.Role{}, err
+		return domain.Role{}, domain.WrapNotFound(err, "role not found")
+	}
+	return m, nil
+}
+
+func (r *roleRepository) ByIDWithPermissions(ctx context.Context, id int) (domain.Role, error) {
+	var m domain.Role
+	if err := r.db.WithContext(ctx).
+		Preload("System").
+		Preload("Permissions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("permissions.code")
+		}).
+		Where("id = ?", id).
+		First(&m).Error; err != nil {
+		return domain.Role{}, domain.WrapNotFound(err, "role not found")
 	}
 	return m, nil
 }
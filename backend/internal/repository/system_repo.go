@@ -24,7 +24,9 @@ import (
 
 type SystemRepository interface {
 	List(ctx context.Context, q dto.SystemListQuery) ([]domain.System, int64, int, int, error)
+	ListActive(ctx context.Context) ([]domain.System, error)
 	ByID(ctx context.Context, id int) (domain.System, error)
+	GetBySlug(ctx context.Context, slug string) (domain.System, error)
 	Create(ctx context.Context, m domain.System) error
 	Update(ctx context.Context, id int, m domain.System) error
 	Delete(ctx context.Context, id int) error // soft delete
@@ -88,10 +90,31 @@ func (r *systemRepository) List(ctx context.Context, q dto.SystemListQuery) ([]d
 	return items, total, page, size, nil
 }
 
+// ListActive нь идэвхтэй бүх system-ийг sequence дарааллаар буцаана (pagination-гүй)
+func (r *systemRepository) ListActive(ctx context.Context) ([]domain.System, error) {
+	var items []domain.System
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Order("sequence ASC, id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 func (r *systemRepository) ByID(ctx context.Context, id int) (domain.System, error) {
 	var m domain.System
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
-		return domain.System{}, err
+		return domain.System{}, domain.WrapNotFound(err, "system not found")
+	}
+	return m, nil
+}
+
+// GetBySlug нь устгагдаагүй system-ийг slug-аар буцаана
+func (r *systemRepository) GetBySlug(ctx context.Context, slug string) (domain.System, error) {
+	var m domain.System
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&m).Error; err != nil {
+		return domain.System{}, domain.WrapNotFound(err, "system not found")
 	}
 	return m, nil
 }
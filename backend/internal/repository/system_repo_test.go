@@ -0,0 +1,71 @@
+// Package repository provides data access layer
+//
+// File: system_repo_test.go
+// Description: DryRun SQL tests for system and menu active-system queries
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// newDryRunDB нь DB-д холбогдохгүй, зөвхөн SQL үүсгэдэг *gorm.DB болон
+// хамгийн сүүлийн query-г (vars орлуулсан) буцаах функц үүсгэнэ
+func newDryRunDB(t *testing.T) (*gorm.DB, func() string) {
+	t.Helper()
+	gdb, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=dryrun sslmode=disable"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               gormlogger.Discard,
+	})
+	require.NoError(t, err)
+
+	var last string
+	require.NoError(t, gdb.Callback().Query().After("gorm:query").Register("test:capture_sql", func(db *gorm.DB) {
+		last = db.Dialector.Explain(db.Statement.SQL.String(), db.Statement.Vars...)
+	}))
+	return gdb, func() string { return last }
+}
+
+func TestSystemRepository_ListActive_SQL(t *testing.T) {
+	gdb, lastSQL := newDryRunDB(t)
+
+	items, err := NewSystemRepository(gdb).ListActive(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	sql := lastSQL()
+	assert.Contains(t, sql, `FROM "systems"`)
+	assert.Contains(t, sql, "is_active = true")
+	assert.Contains(t, sql, `"systems"."deleted_date" IS NULL`)
+	assert.Contains(t, sql, "ORDER BY sequence ASC, id ASC")
+}
+
+func TestMenuRepository_ListByUserRolesInSystems_SQL(t *testing.T) {
+	gdb, lastSQL := newDryRunDB(t)
+	repo := &menuRepository{db: gdb}
+
+	_, err := repo.ListByUserRolesInSystems(context.Background(), 9, []int{3, 4})
+	require.NoError(t, err)
+
+	sql := lastSQL()
+	assert.Contains(t, sql, "JOIN permissions p ON p.id = menus.permission_id")
+	assert.Contains(t, sql, "ur.user_id = 9")
+	assert.Contains(t, sql, "p.system_id IN (3,4)")
+}
+
+func TestMenuRepository_ListByUserRolesInSystems_NoSystems(t *testing.T) {
+	gdb, lastSQL := newDryRunDB(t)
+	repo := &menuRepository{db: gdb}
+
+	menus, err := repo.ListByUserRolesInSystems(context.Background(), 9, nil)
+	require.NoError(t, err)
+	assert.Empty(t, menus)
+	assert.Empty(t, lastSQL(), "query should be skipped without active systems")
+}
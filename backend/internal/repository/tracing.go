@@ -0,0 +1,32 @@
+// Package repository provides implementation for repository
+//
+// File: tracing.go
+// Description: OpenTelemetry span helper for repository methods
+package repository
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName нь repository span-уудын instrumentation нэр
+const tracerName = "templatev25"
+
+// startSpan нь "repo.<method>" нэртэй span нээж, db.* attribute-уудыг онооно.
+// Дуудагч тал буцаасан ctx-ийг цааш ашиглаж, span.End()-ийг defer хийнэ.
+//
+// Tracer-ийг дуудалт бүрт global provider-оос авдаг тул InitTracer (эсвэл
+// тестийн provider) хожуу тохируулагдсан ч span зөв provider руу очно.
+func startSpan(ctx context.Context, table, method string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "repo."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", method),
+			attribute.String("db.table", table),
+		),
+	)
+}
@@ -11,6 +11,8 @@ package repository
 import (
 	"context"
 
+	dbtx "templatev25/internal/db"
+
 	"gorm.io/gorm"
 )
 
@@ -23,3 +25,23 @@ func WithTx(ctx context.Context, db *gorm.DB, fn TxFunc) error {
 		return fn(tx.WithContext(ctx))
 	})
 }
+
+// TxManager нь service давхаргад *gorm.DB-гүйгээр transaction эхлүүлэх боломж олгоно.
+// fn-д дамжих ctx нь tx агуулах тул dbtx.WithTransaction ашигладаг repository
+// method-ууд нэг commit/rollback-д нэгдэнэ.
+type TxManager interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txManager struct{ db *gorm.DB }
+
+// NewTxManager creates a TxManager backed by db
+func NewTxManager(db *gorm.DB) TxManager {
+	return &txManager{db: db}
+}
+
+func (m *txManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return dbtx.WithTransaction(ctx, m.db, func(ctx context.Context, _ *gorm.DB) error {
+		return fn(ctx)
+	})
+}
@@ -10,8 +10,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 	"time"
 
+	dbtx "templatev25/internal/db"
 	"templatev25/internal/domain"
 
 	"git.gerege.mn/backend-packages/common"
@@ -20,14 +23,33 @@ import (
 	"git.gerege.mn/backend-packages/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// userUpsertBatchSize нь BulkUpsert-ийн нэг INSERT-д орох мөрийн тоо
+const userUpsertBatchSize = 500
+
+// userSSOColumns нь SSO-оос ирдэг, upsert-ээр шинэчлэгдэх баганууд.
+// status, login_count зэрэг локал талбарууд дарагдахгүй.
+var userSSOColumns = []string{
+	"civil_id", "reg_no", "family_name", "last_name", "first_name",
+	"gender", "birth_date", "phone_no", "email", "updated_date",
+}
+
 type UserRepository interface {
 	List(ctx context.Context, p common.PaginationQuery) ([]domain.User, int64, int, int, error)
+	// Search нь q-г нэр, и-мэйл, регистр, утасны дугаараас (OR, ILIKE) хайна
+	Search(ctx context.Context, q string, p common.PaginationQuery) ([]domain.User, int64, int, int, error)
 	Create(ctx context.Context, m domain.User) (domain.User, error)
 	Update(ctx context.Context, m domain.User) (domain.User, error)
 	Delete(ctx context.Context, id int) (domain.User, error)
 	GetByID(ctx context.Context, id int) (domain.User, error)
+	FindInBatches(ctx context.Context, p common.PaginationQuery, batchSize int, fn func(batch []domain.User) error) error
+	// ListStream нь List-ийн шүүлтүүр/эрэмбээр бүх мөрийг (pagination-гүй) channel-аар буцаана
+	ListStream(ctx context.Context, p common.PaginationQuery) (<-chan domain.User, <-chan error)
+	// BulkUpsert нь id-аар INSERT ... ON CONFLICT DO UPDATE хийнэ (SSO sync).
+	// Устгагдсан (deleted_date тохируулсан) хэрэглэгчийг сэргээхгүй, id-г нь skipped-д буцаана.
+	BulkUpsert(ctx context.Context, users []domain.User) (skipped []int, err error)
 
 	// Organizations helper (profile/organizations endpoint-д хэрэглэнэ)
 	UserOrgIDs(ctx context.Context, userID int) ([]int, error)
@@ -43,22 +65,24 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
+// userColumnMap нь search/sort-д зөвшөөрөгдсөн баганууд
+var userColumnMap = scopes.ColumnMap{
+	"id":          "users.id",
+	"reg_no":      "users.reg_no",
+	"first_name":  "users.first_name",
+	"last_name":   "users.last_name",
+	"phone_no":    "users.phone_no",
+	"email":       "users.email",
+	"birth_date":  "users.birth_date",
+	"civil_id":    "users.civil_id",
+	"family_name": "users.family_name",
+	"gender":      "users.gender",
+}
+
 // List — model_repo хэв маяг: scopes + pagination + олон талбарт name хайлт
 func (r *userRepository) List(ctx context.Context, p common.PaginationQuery) ([]domain.User, int64, int, int, error) {
 	page, size, offset := utils.OffsetLimit(p)
-
-	colMap := scopes.ColumnMap{
-		"id":          "users.id",
-		"reg_no":      "users.reg_no",
-		"first_name":  "users.first_name",
-		"last_name":   "users.last_name",
-		"phone_no":    "users.phone_no",
-		"email":       "users.email",
-		"birth_date":  "users.birth_date",
-		"civil_id":    "users.civil_id",
-		"family_name": "users.family_name",
-		"gender":      "users.gender",
-	}
+	colMap := userColumnMap
 
 	tx := r.db.WithContext(ctx).Model(&domain.User{}).Scopes(
 		scopes.SearchScope(colMap, utils.ParseSearch(p.Search)),
@@ -81,6 +105,110 @@ func (r *userRepository) List(ctx context.Context, p common.PaginationQuery) ([]
 	return items, total, page, size, nil
 }
 
+// userSearchColumns нь GET /user/search-ийн чөлөөт текст хайлт хийх баганууд
+var userSearchColumns = []string{
+	"users.first_name", "users.last_name", "users.email", "users.reg_no", "users.phone_no",
+}
+
+// UserSearchScope нь q-г userSearchColumns-ийн аль нэгэнд нь ILIKE-аар (OR) хайх scope.
+// %, _ тэмдэгтүүд escape хийгдэнэ; q хоосон бол шүүлт нэмэхгүй.
+func UserSearchScope(q string) func(*gorm.DB) *gorm.DB {
+	q = strings.TrimSpace(q)
+	return func(db *gorm.DB) *gorm.DB {
+		if q == "" {
+			return db
+		}
+		conds := make([]string, len(userSearchColumns))
+		for i, col := range userSearchColumns {
+			conds[i] = col + ` ILIKE @q ESCAPE '\'`
+		}
+		return db.Where("("+strings.Join(conds, " OR ")+")", sql.Named("q", "%"+escapeLike(q)+"%"))
+	}
+}
+
+// Search нь UserSearchScope-оор шүүж List-тэй ижил эрэмбэ (sort, default id DESC), хуудаслалтаар буцаана
+func (r *userRepository) Search(ctx context.Context, q string, p common.PaginationQuery) ([]domain.User, int64, int, int, error) {
+	page, size, offset := utils.OffsetLimit(p)
+
+	tx := r.db.WithContext(ctx).Model(&domain.User{}).Scopes(UserSearchScope(q))
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	var items []domain.User
+	if err := tx.Scopes(
+		scopes.SortScope(userColumnMap, utils.ParseSort(p.Sort), "id DESC"),
+	).Offset(offset).Limit(size).Find(&items).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	return items, total, page, size, nil
+}
+
+// FindInBatches нь List-тэй ижил шүүлтүүрээр (search, createdFrom/To) бүх хэрэглэгчийг
+// batchSize хэмжээгээр уншиж fn руу дамжуулна. Эрэмбэ нь primary key (GORM FindInBatches).
+func (r *userRepository) FindInBatches(ctx context.Context, p common.PaginationQuery, batchSize int, fn func(batch []domain.User) error) error {
+	var batch []domain.User
+	return r.db.WithContext(ctx).Model(&domain.User{}).Scopes(
+		scopes.SearchScope(userColumnMap, utils.ParseSearch(p.Search)),
+		scopes.DateScope(p.CreatedFrom, p.CreatedTo),
+	).FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
+
+// userStreamBuffer нь ListStream-ийн channel buffer (DB уншилт ба JSON бичилтийг зэрэгцүүлнэ)
+const userStreamBuffer = 64
+
+// ListStream нь List-тэй ижил шүүлтүүр (search, createdFrom/To) болон эрэмбээр бүх хэрэглэгчийг
+// GORM Rows() cursor-оор нэг нэгээр уншиж channel руу илгээнэ. page/size-ийг үл харгалзана.
+//
+// Items channel хаагдсаны дараа errc-ээс алдаа (байвал) уншина; errc нь дараа нь хаагдана.
+// ctx цуцлагдвал уншилтыг зогсоож ctx.Err() буцаана. Consumer нь items-ийг заавал уншиж дуусгах
+// эсвэл ctx-ийг цуцлах ёстой, эс бөгөөс goroutine send дээр хүлээнэ.
+func (r *userRepository) ListStream(ctx context.Context, p common.PaginationQuery) (<-chan domain.User, <-chan error) {
+	out := make(chan domain.User, userStreamBuffer)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(out)
+
+		tx := r.db.WithContext(ctx).Model(&domain.User{}).Scopes(
+			scopes.SearchScope(userColumnMap, utils.ParseSearch(p.Search)),
+			scopes.DateScope(p.CreatedFrom, p.CreatedTo),
+			scopes.SortScope(userColumnMap, utils.ParseSort(p.Sort), "id DESC"),
+		)
+		rows, err := tx.Rows()
+		if err != nil {
+			errc <- err
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var u domain.User
+			if err := tx.ScanRows(rows, &u); err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case out <- u:
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			errc <- err
+		}
+	}()
+
+	return out, errc
+}
+
 func (r *userRepository) Create(ctx context.Context, m domain.User) (domain.User, error) {
 	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
 		return domain.User{}, err
@@ -88,6 +216,60 @@ func (r *userRepository) Create(ctx context.Context, m domain.User) (domain.User
 	return m, nil
 }
 
+func (r *userRepository) BulkUpsert(ctx context.Context, users []domain.User) ([]int, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int, len(users))
+	for i, u := range users {
+		ids[i] = u.Id
+	}
+
+	var skipped []int
+	err := dbtx.WithTransaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		// GDPR erasure-ээр устгагдсан мөрийг SSO sync дахин бөглөх ёсгүй
+		if err := tx.Unscoped().
+			Model(&domain.User{}).
+			Where("id IN ? AND deleted_date IS NOT NULL", ids).
+			Pluck("id", &skipped).Error; err != nil {
+			return err
+		}
+
+		live := users
+		if len(skipped) > 0 {
+			deleted := make(map[int]struct{}, len(skipped))
+			for _, id := range skipped {
+				deleted[id] = struct{}{}
+			}
+			live = make([]domain.User, 0, len(users)-len(skipped))
+			for _, u := range users {
+				if _, ok := deleted[u.Id]; !ok {
+					live = append(live, u)
+				}
+			}
+		}
+		if len(live) == 0 {
+			return nil
+		}
+
+		// WHERE нь шалгалтын дараа зэрэг устгагдсан мөрийг ч шинэчлэхгүй
+		return tx.
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns(userSSOColumns),
+				Where: clause.Where{Exprs: []clause.Expression{
+					clause.Expr{SQL: "users.deleted_date IS NULL"},
+				}},
+			}).
+			CreateInBatches(&live, userUpsertBatchSize).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return skipped, nil
+}
+
 func (r *userRepository) Update(ctx context.Context, m domain.User) (domain.User, error) {
 
 	if err := r.db.WithContext(ctx).
@@ -100,26 +282,30 @@ func (r *userRepository) Update(ctx context.Context, m domain.User) (domain.User
 }
 
 func (r *userRepository) Delete(uctx context.Context, id int) (domain.User, error) {
-	// Soft delete - RoleRepository-тай адил pattern
+	// Soft delete - RoleRepository-тай адил pattern.
+	// Context-д transaction байвал (UserService.Delete-ийн GDPR erasure) түүнд нэгдэнэ.
 	var ex domain.User
-	if err := r.db.WithContext(uctx).Take(&ex, "id = ?", id).Error; err != nil {
-		return domain.User{}, err
-	}
+	err := dbtx.WithTransaction(uctx, r.db, func(uctx context.Context, tx *gorm.DB) error {
+		if err := tx.Take(&ex, "id = ?", id).Error; err != nil {
+			return domain.WrapNotFound(err, "user not found")
+		}
 
-	// DeletedUser/Org context-оос авах
-	m := domain.User{}
-	if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
-		m.DeletedUserId = userId
-	}
-	if orgId, ok := ctx.GetValue[int](uctx, ctx.KeyOrgID); ok {
-		m.DeletedOrgId = orgId
-	}
-	m.DeletedDate = gorm.DeletedAt{Valid: true, Time: time.Now()}
+		// DeletedUser/Org context-оос авах
+		m := domain.User{}
+		if userId, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
+			m.DeletedUserId = userId
+		}
+		if orgId, ok := ctx.GetValue[int](uctx, ctx.KeyOrgID); ok {
+			m.DeletedOrgId = orgId
+		}
+		m.DeletedDate = gorm.DeletedAt{Valid: true, Time: time.Now()}
 
-	if err := r.db.WithContext(uctx).
-		Model(&domain.User{}).
-		Where("id = ?", id).
-		Updates(&m).Error; err != nil {
+		return tx.
+			Model(&domain.User{}).
+			Where("id = ?", id).
+			Updates(&m).Error
+	})
+	if err != nil {
 		return domain.User{}, err
 	}
 
@@ -129,7 +315,7 @@ func (r *userRepository) Delete(uctx context.Context, id int) (domain.User, erro
 func (r *userRepository) GetByID(ctx context.Context, id int) (domain.User, error) {
 	var u domain.User
 	err := r.db.WithContext(ctx).Take(&u, "id = ?", id).Error
-	return u, err
+	return u, domain.WrapNotFound(err, "user not found")
 }
 
 // ---------- Organizations helpers ----------
@@ -159,7 +345,7 @@ func (r *userRepository) GetOrganization(ctx context.Context, id int, fields []s
 		tx = tx.Select(fields)
 	}
 	if err := tx.Take(&o, "id = ?", id).Error; err != nil {
-		return nil, err
+		return nil, domain.WrapNotFound(err, "organization not found")
 	}
 	return &o, nil
 }
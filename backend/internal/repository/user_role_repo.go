@@ -14,6 +14,7 @@ import (
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 
+	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/utils"
 
 	"gorm.io/gorm"
@@ -23,9 +24,13 @@ import (
 type UserRoleRepository interface {
 	UsersByRole(ctx context.Context, q dto.UserRoleUsersQuery) ([]domain.UserRole, int64, int, int, error)
 	RolesByUser(ctx context.Context, q dto.UserRoleRolesQuery) ([]domain.UserRole, int64, int, int, error)
+	GetByUserID(ctx context.Context, userID int) ([]domain.UserRole, error)
 	AddUsersToRole(ctx context.Context, roleID int, userIDs []int) error
 	AddRolesToUser(ctx context.Context, userID int, roleIDs []int) error
 	Remove(ctx context.Context, userID, roleID int) error
+	GetRoleCodes(ctx context.Context, userID int) ([]string, error)
+	CreateHistory(ctx context.Context, entries []domain.UserRoleHistory) error
+	HistoryByUser(ctx context.Context, userID int, p common.PaginationQuery) ([]domain.UserRoleHistory, int64, int, int, error)
 }
 
 type userRoleRepository struct{ db *gorm.DB }
@@ -71,6 +76,19 @@ func (r *userRoleRepository) RolesByUser(ctx context.Context, q dto.UserRoleRole
 	return items, total, page, size, nil
 }
 
+// GetByUserID нь хэрэглэгчийн бүх role-ийг (идэвхгүйг оруулаад) системийн хамт буцаана.
+// GET /user/:id/roles
+func (r *userRoleRepository) GetByUserID(ctx context.Context, userID int) ([]domain.UserRole, error) {
+	var items []domain.UserRole
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Preload("Role").
+		Preload("Role.System").
+		Order("role_id ASC").
+		Find(&items).Error
+	return items, err
+}
+
 // POST assign by role
 // Batch insert with ON CONFLICT - N queries -> 1 query
 func (r *userRoleRepository) AddUsersToRole(ctx context.Context, roleID int, userIDs []int) error {
@@ -114,3 +132,49 @@ func (r *userRoleRepository) AddRolesToUser(ctx context.Context, userID int, rol
 func (r *userRoleRepository) Remove(ctx context.Context, userID, roleID int) error {
 	return r.db.WithContext(ctx).Where("role_id = ? AND user_id = ?", roleID, userID).Delete(&domain.UserRole{}).Error
 }
+
+// GetRoleCodes нь хэрэглэгчийн идэвхтэй role-уудын код-уудыг буцаана.
+// auth.RequireRole middleware-д (RoleCache-аар дамжин) ашиглагдана.
+func (r *userRoleRepository) GetRoleCodes(ctx context.Context, userID int) ([]string, error) {
+	var codes []string
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT r.code FROM roles r
+		JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = ?
+		AND r.is_active = true
+		AND r.deleted_date IS NULL
+		AND ur.deleted_date IS NULL
+	`, userID).Scan(&codes).Error
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// CreateHistory нь эрх олгосон/хассан түүхийг нэг batch insert-ээр бичнэ
+func (r *userRoleRepository) CreateHistory(ctx context.Context, entries []domain.UserRoleHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&entries).Error
+}
+
+// GET /user/:id/role-history
+// Шинэ нь эхэндээ, Role-ийн мэдээлэлтэй
+func (r *userRoleRepository) HistoryByUser(ctx context.Context, userID int, p common.PaginationQuery) ([]domain.UserRoleHistory, int64, int, int, error) {
+	page, size, offset := utils.OffsetLimit(p)
+
+	tx := r.db.WithContext(ctx).Model(&domain.UserRoleHistory{}).Where("user_id = ?", userID)
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	var items []domain.UserRoleHistory
+	if err := tx.Preload("Role").
+		Order("changed_at DESC, id DESC").
+		Offset(offset).Limit(size).Find(&items).Error; err != nil {
+		return nil, 0, 0, 0, err
+	}
+	return items, total, page, size, nil
+}
@@ -0,0 +1,61 @@
+// Package requestctx provides implementation for requestctx
+//
+// File: requestctx.go
+// Description: Request ID and tenant ID helpers shared by middleware, services and repositories
+/*
+Package requestctx нь request ID болон tenant ID-г context.Context-д хадгалах,
+унших helper-үүдийг агуулна.
+
+middleware package нь repository-г import хийдэг тул repository давхарга
+middleware.GetRequestID-г ашиглаж чадахгүй. Энэ package ямар ч дотоод
+package-аас хамааралгүй тул бүх давхаргаас import хийж болно.
+
+Request ID-ийн түлхүүр нь backend-packages/ctx.KeyRequestID тул auth
+middleware-ийн тавьсан утгатай ижил. Tenant ID-г middleware.Tenant тавина.
+
+Ашиглалт:
+
+	// middleware.RequestID дотор
+	c.SetUserContext(requestctx.With(c.UserContext(), id))
+
+	// repository/service дотор
+	log.Debug("repo_call", zap.String("request_id", requestctx.GetRequestID(ctx)))
+*/
+package requestctx
+
+import (
+	"context"
+
+	"git.gerege.mn/backend-packages/ctx"
+)
+
+// KeyTenantID нь tenant ID-ийн context түлхүүр (backend-packages/ctx-д байхгүй)
+const KeyTenantID ctx.Key = "tenant_id"
+
+// With нь request ID-г context-д хадгална
+func With(c context.Context, requestID string) context.Context {
+	return ctx.WithValue(c, ctx.KeyRequestID, requestID)
+}
+
+// GetRequestID нь context-оос request ID авна (байхгүй бол "")
+func GetRequestID(c context.Context) string {
+	if c == nil {
+		return ""
+	}
+	id, _ := ctx.GetValue[string](c, ctx.KeyRequestID)
+	return id
+}
+
+// WithTenantID нь tenant ID-г context-д хадгална
+func WithTenantID(c context.Context, tenantID string) context.Context {
+	return ctx.WithValue(c, KeyTenantID, tenantID)
+}
+
+// GetTenantID нь context-оос tenant ID авна (байхгүй бол "")
+func GetTenantID(c context.Context) string {
+	if c == nil {
+		return ""
+	}
+	id, _ := ctx.GetValue[string](c, KeyTenantID)
+	return id
+}
@@ -0,0 +1,124 @@
+// Package service provides implementation for service
+//
+// File: audited_user_role_service.go
+// Description: Audit wrapper for UserRoleService
+//
+// This wrapper records every role assignment/revocation in user_role_history
+// and security_audit_trail after the underlying change succeeds.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/repository"
+
+	"git.gerege.mn/backend-packages/ctx"
+	"go.uber.org/zap"
+)
+
+// userRoleAuditTargetType нь security_audit_trail.target_type утга
+const userRoleAuditTargetType = "user_role"
+
+// AuditedUserRoleService wraps UserRoleService with role change history
+type AuditedUserRoleService struct {
+	UserRoleService
+	repo  repository.UserRoleRepository
+	audit repository.AuthRepository
+	log   *zap.Logger
+}
+
+// NewAuditedUserRoleService creates a new audited user role service.
+// Өөрчлөлт хийсэн хэрэглэгчийг context-ийн ctx.KeyUserID-аас авна.
+func NewAuditedUserRoleService(svc UserRoleService, repo repository.UserRoleRepository, audit repository.AuthRepository, log *zap.Logger) *AuditedUserRoleService {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return &AuditedUserRoleService{UserRoleService: svc, repo: repo, audit: audit, log: log}
+}
+
+// AssignByRole records ROLE_ASSIGNED for every user after assigning
+func (s *AuditedUserRoleService) AssignByRole(c context.Context, req dto.UserRoleAssignByRole) error {
+	if err := s.UserRoleService.AssignByRole(c, req); err != nil {
+		return err
+	}
+	pairs := make([][2]int, 0, len(req.UserIDs))
+	for _, uid := range req.UserIDs {
+		pairs = append(pairs, [2]int{uid, req.RoleID})
+	}
+	s.record(c, domain.UserRoleActionAssigned, pairs)
+	return nil
+}
+
+// AssignByUser records ROLE_ASSIGNED for every role after assigning
+func (s *AuditedUserRoleService) AssignByUser(c context.Context, req dto.UserRoleAssignByUser) error {
+	if err := s.UserRoleService.AssignByUser(c, req); err != nil {
+		return err
+	}
+	pairs := make([][2]int, 0, len(req.RoleIDs))
+	for _, rid := range req.RoleIDs {
+		pairs = append(pairs, [2]int{req.UserID, rid})
+	}
+	s.record(c, domain.UserRoleActionAssigned, pairs)
+	return nil
+}
+
+// Remove records ROLE_REVOKED after removing
+func (s *AuditedUserRoleService) Remove(c context.Context, req dto.UserRoleRemoveDto) error {
+	if err := s.UserRoleService.Remove(c, req); err != nil {
+		return err
+	}
+	s.record(c, domain.UserRoleActionRevoked, [][2]int{{req.UserID, req.RoleID}})
+	return nil
+}
+
+// record нь (userID, roleID) хос бүрт history болон audit trail бичнэ.
+// Эрхийн өөрчлөлт аль хэдийн хийгдсэн тул алдааг зөвхөн log-д бичнэ.
+func (s *AuditedUserRoleService) record(c context.Context, action string, pairs [][2]int) {
+	var changedBy *int
+	if uid, ok := ctx.GetValue[int](c, ctx.KeyUserID); ok {
+		changedBy = &uid
+	}
+	now := time.Now()
+
+	entries := make([]domain.UserRoleHistory, 0, len(pairs))
+	for _, p := range pairs {
+		entries = append(entries, domain.UserRoleHistory{
+			UserID:    p[0],
+			RoleID:    p[1],
+			Action:    action,
+			ChangedBy: changedBy,
+			ChangedAt: now,
+		})
+	}
+	if err := s.repo.CreateHistory(c, entries); err != nil {
+		s.log.Error("user_role_history_failed", zap.String("action", action), zap.Error(err))
+	}
+
+	for _, e := range entries {
+		audit := &domain.SecurityAuditTrail{
+			UserID:     changedBy,
+			Action:     action,
+			TargetType: userRoleAuditTargetType,
+			TargetID:   strconv.Itoa(e.UserID),
+		}
+		// jsonb багана тул хоосон талд "null"
+		audit.OldValue, audit.NewValue = "null", userRoleAuditValue(e)
+		if action == domain.UserRoleActionRevoked {
+			audit.OldValue, audit.NewValue = audit.NewValue, audit.OldValue
+		}
+		if err := s.audit.CreateAuditTrail(c, audit); err != nil {
+			s.log.Error("user_role_audit_failed", zap.String("action", action), zap.Error(err))
+		}
+	}
+}
+
+// userRoleAuditValue нь audit trail-ийн old/new value JSON
+func userRoleAuditValue(e domain.UserRoleHistory) string {
+	b, _ := json.Marshal(map[string]int{"user_id": e.UserID, "role_id": e.RoleID})
+	return string(b)
+}
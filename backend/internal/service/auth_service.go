@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	"templatev25/internal/auth"
 	"templatev25/internal/config"
 	"templatev25/internal/domain"
 	"templatev25/internal/repository"
@@ -29,7 +30,7 @@ import (
 	"github.com/pquerna/otp/totp"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/argon2"
-	"gorm.io/gorm"
+	"golang.org/x/oauth2"
 )
 
 // Error definitions
@@ -37,6 +38,7 @@ var (
 	ErrInvalidCredentials  = errors.New("invalid email or password")
 	ErrAccountLocked       = errors.New("account is locked")
 	ErrAccountNotActive    = errors.New("account is not active")
+	ErrAccountSuspended    = errors.New("account is suspended")
 	ErrMFARequired         = errors.New("MFA verification required")
 	ErrInvalidMFACode      = errors.New("invalid MFA code")
 	ErrMFANotEnabled       = errors.New("MFA is not enabled")
@@ -46,8 +48,36 @@ var (
 	ErrPasswordReused      = errors.New("password was recently used")
 	ErrUserNotFound        = errors.New("user not found")
 	ErrCredentialsNotFound = errors.New("credentials not found")
+	ErrSelfImpersonation   = errors.New("cannot impersonate yourself")
+	ErrImpersonationDenied = errors.New("cannot impersonate a user with an equal or higher role")
 )
 
+// PasswordTooWeakError wraps ErrPasswordTooWeak with the password policy rules that failed
+type PasswordTooWeakError struct {
+	Violations []auth.PolicyViolation
+}
+
+func (e *PasswordTooWeakError) Error() string {
+	rules := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		rules[i] = string(v)
+	}
+	return ErrPasswordTooWeak.Error() + ": " + strings.Join(rules, ", ")
+}
+
+// Unwrap lets errors.Is(err, ErrPasswordTooWeak) match
+func (e *PasswordTooWeakError) Unwrap() error {
+	return ErrPasswordTooWeak
+}
+
+// validatePassword checks password against the configured PasswordPolicy
+func validatePassword(cfg *config.LocalAuthConfig, password string) error {
+	if violations := auth.PasswordPolicyFromConfig(cfg).Validate(password); len(violations) > 0 {
+		return &PasswordTooWeakError{Violations: violations}
+	}
+	return nil
+}
+
 // Argon2id parameters (OWASP recommended)
 const (
 	argon2Time    = 1
@@ -57,12 +87,36 @@ const (
 	argon2SaltLen = 16
 )
 
+// accountLockedNotifyTimeout bounds the background account-locked email
+const accountLockedNotifyTimeout = 10 * time.Second
+
+// ImpersonationSessionTTL is the fixed lifetime of an impersonation session (RefreshSession does not extend it)
+const ImpersonationSessionTTL = 30 * time.Minute
+
+// impersonationRoleRanks ranks the system roles for Impersonate; other roles rank 0
+var impersonationRoleRanks = map[string]int{
+	"ADMIN":       1,
+	"SUPER_ADMIN": 2,
+}
+
+// indefiniteLockUntil is stored as locked_until for config.LockIndefinite (admin unlock only)
+var indefiniteLockUntil = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
 // AuthService handles authentication, MFA, and session management
 type AuthService struct {
 	repo         repository.AuthRepository
 	sessionStore SessionStore
 	cfg          *config.LocalAuthConfig
+	mailer       Mailer
 	logger       *zap.Logger
+
+	// Google OAuth (SetGoogleOAuth; nil бол ExchangeGoogleCode идэвхгүй)
+	google *oauth2.Config
+	users  repository.UserRepository
+
+	// roles нь Impersonate-д админ болон зорилтот хэрэглэгчийн role-ийг харьцуулна
+	// (SetRoleChecker; nil бол impersonation хориглоно)
+	roles auth.RoleChecker
 }
 
 // NewAuthService creates a new authentication service
@@ -76,10 +130,21 @@ func NewAuthService(
 		repo:         repo,
 		sessionStore: sessionStore,
 		cfg:          cfg,
+		mailer:       NewLogMailer(logger),
 		logger:       logger,
 	}
 }
 
+// SetRoleChecker enables Impersonate by providing the role codes used to rank admin and target
+func (s *AuthService) SetRoleChecker(roles auth.RoleChecker) {
+	s.roles = roles
+}
+
+// SetMailer replaces the default log-only mailer
+func (s *AuthService) SetMailer(m Mailer) {
+	s.mailer = m
+}
+
 // ============================================================
 // LOGIN
 // ============================================================
@@ -105,29 +170,39 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*LoginRespon
 	// Get user by email
 	user, err := s.repo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, domain.ErrNotFound) {
 			s.logFailedLogin(ctx, nil, req.Email, req.IPAddress, req.UserAgent, "user not found")
 			return nil, ErrInvalidCredentials
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	// Check user status
-	if user.Status != string(domain.UserStatusActive) {
-		s.logFailedLogin(ctx, &user.Id, req.Email, req.IPAddress, req.UserAgent, "account not active")
-		return nil, ErrAccountNotActive
-	}
-
 	// Get credentials
 	cred, err := s.repo.GetCredentialByUserID(ctx, user.Id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, domain.ErrNotFound) {
 			s.logFailedLogin(ctx, &user.Id, req.Email, req.IPAddress, req.UserAgent, "no credentials")
 			return nil, ErrCredentialsNotFound
 		}
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
 
+	// Check user status (suspended нь PermanentLockAfter-ээр эсвэл админ түдгэлзүүлсэн).
+	// Төлөвийг нууц үг зөв үед л илчилнэ — буруу нууц үгтэй хүсэлт идэвхтэй бус
+	// бүртгэлийг ялгаж чадахгүй (account enumeration). Түгжээний тоолуур нэмэгдэхгүй.
+	if user.Status != string(domain.UserStatusActive) {
+		if !s.verifyPassword(req.Password, cred.PasswordHash) {
+			s.logFailedLogin(ctx, &user.Id, req.Email, req.IPAddress, req.UserAgent, "invalid password (account "+user.Status+")")
+			return nil, ErrInvalidCredentials
+		}
+		if user.Status == string(domain.UserStatusSuspended) {
+			s.logFailedLogin(ctx, &user.Id, req.Email, req.IPAddress, req.UserAgent, "account suspended")
+			return nil, ErrAccountSuspended
+		}
+		s.logFailedLogin(ctx, &user.Id, req.Email, req.IPAddress, req.UserAgent, "account not active")
+		return nil, ErrAccountNotActive
+	}
+
 	// Check if account is locked
 	if cred.IsLocked() {
 		s.logFailedLogin(ctx, &user.Id, req.Email, req.IPAddress, req.UserAgent, "account locked")
@@ -139,13 +214,28 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*LoginRespon
 		// Increment failed attempts
 		s.repo.IncrementFailedAttempts(ctx, user.Id)
 
-		// Check if should lock
-		newCred, _ := s.repo.GetCredentialByUserID(ctx, user.Id)
-		if newCred != nil && newCred.FailedLoginAttempts >= s.cfg.LockoutThreshold {
-			lockUntil := time.Now().Add(s.cfg.LockoutDuration)
-			s.repo.LockAccount(ctx, user.Id, lockUntil)
-			s.logAudit(ctx, &user.Id, string(domain.AuditActionAccountLock), "user", strconv.Itoa(user.Id),
-				nil, map[string]interface{}{"locked_until": lockUntil}, req.IPAddress, req.UserAgent)
+		// Check if should lock (duration grows with the number of failed attempts)
+		attempts := 0
+		if newCred, _ := s.repo.GetCredentialByUserID(ctx, user.Id); newCred != nil {
+			attempts = newCred.FailedLoginAttempts
+		}
+		if s.cfg.PermanentLockAfter > 0 && attempts >= s.cfg.PermanentLockAfter {
+			// Хугацаатай түгжээний оронд хэрэглэгчийг түдгэлзүүлнэ (админ л сэргээнэ)
+			s.suspendForFailedLogins(ctx, user.Id, attempts, req.IPAddress, req.UserAgent)
+		} else if d := LockDuration(attempts, s.lockSchedule()); d != 0 {
+			lockUntil := indefiniteLockUntil
+			if d > 0 {
+				lockUntil = time.Now().Add(d)
+			}
+			// Шинээр түгжсэн үед л audit бичиж, мэдэгдэл илгээнэ
+			locked, err := s.repo.LockAccount(ctx, user.Id, lockUntil)
+			if err != nil {
+				s.logger.Error("failed to lock account", zap.Int("user_id", user.Id), zap.Error(err))
+			} else if locked {
+				s.logAudit(ctx, &user.Id, string(domain.AuditActionAccountLock), "user", strconv.Itoa(user.Id),
+					nil, map[string]interface{}{"locked_until": lockUntil}, req.IPAddress, req.UserAgent)
+				s.notifyAccountLockedAsync(ctx, user.Id, lockUntil)
+			}
 		}
 
 		s.logFailedLogin(ctx, &user.Id, req.Email, req.IPAddress, req.UserAgent, "invalid password")
@@ -159,16 +249,9 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*LoginRespon
 	mfa, err := s.repo.GetMFAByUserID(ctx, user.Id)
 	if err == nil && mfa != nil && mfa.IsEnabled {
 		// MFA required - return pending token
-		mfaToken := uuid.New().String()
-		pendingData := &MFAPendingData{
-			UserID:    user.Id,
-			Email:     user.Email,
-			IPAddress: req.IPAddress,
-			UserAgent: req.UserAgent,
-			ExpiresAt: time.Now().Add(s.cfg.MFATokenTTL),
-		}
-		if err := s.sessionStore.StoreMFAToken(ctx, mfaToken, pendingData, s.cfg.MFATokenTTL); err != nil {
-			return nil, fmt.Errorf("failed to store MFA token: %w", err)
+		mfaToken, err := s.issueMFAToken(ctx, user, req.IPAddress, req.UserAgent)
+		if err != nil {
+			return nil, err
 		}
 
 		return &LoginResponse{
@@ -196,6 +279,146 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*LoginRespon
 	}, nil
 }
 
+// issueMFAToken stores a pending MFA login for user and returns its token
+func (s *AuthService) issueMFAToken(ctx context.Context, user *domain.User, ip, userAgent string) (string, error) {
+	mfaToken := uuid.New().String()
+	pendingData := &MFAPendingData{
+		UserID:    user.Id,
+		Email:     user.Email,
+		IPAddress: ip,
+		UserAgent: userAgent,
+		ExpiresAt: time.Now().Add(s.cfg.MFATokenTTL),
+	}
+	if err := s.sessionStore.StoreMFAToken(ctx, mfaToken, pendingData, s.cfg.MFATokenTTL); err != nil {
+		return "", fmt.Errorf("failed to store MFA token: %w", err)
+	}
+	return mfaToken, nil
+}
+
+// LockDuration returns how long to lock an account after the given number of failed attempts.
+// The entry with the highest Attempts not above attempts wins; 0 means no lock and
+// config.LockIndefinite means the account stays locked until an admin unlocks it.
+func LockDuration(attempts int, schedule []config.LockThreshold) time.Duration {
+	var (
+		d    time.Duration
+		best int
+	)
+	for _, t := range schedule {
+		if t.Attempts <= attempts && t.Attempts > best {
+			best, d = t.Attempts, t.Duration
+		}
+	}
+	return d
+}
+
+// suspendReasonFailedLogins нь PermanentLockAfter-ээр түдгэлзүүлсэн үеийн status_reason
+const suspendReasonFailedLogins = "too many failed logins"
+
+// suspendForFailedLogins sets the user status to suspended, revokes all sessions
+// and records the status change (changedBy 0 = system)
+func (s *AuthService) suspendForFailedLogins(ctx context.Context, userID, attempts int, ip, userAgent string) {
+	if err := s.repo.UpdateUserStatus(ctx, userID, string(domain.UserStatusSuspended), suspendReasonFailedLogins, 0); err != nil {
+		s.logger.Error("failed to suspend user after failed logins", zap.Int("user_id", userID), zap.Error(err))
+		return
+	}
+	s.sessionStore.DeleteAllUserSessions(ctx, userID)
+	s.repo.RevokeAllUserSessions(ctx, userID, "status change: "+string(domain.UserStatusSuspended))
+
+	s.logAudit(ctx, &userID, string(domain.AuditActionStatusChange), "user", strconv.Itoa(userID),
+		map[string]interface{}{"status": string(domain.UserStatusActive)},
+		map[string]interface{}{"status": string(domain.UserStatusSuspended), "reason": suspendReasonFailedLogins, "failed_attempts": attempts},
+		ip, userAgent)
+	s.logger.Warn("user suspended after failed logins", zap.Int("user_id", userID), zap.Int("failed_attempts", attempts))
+}
+
+// IsAccountSuspended reports whether the user's status is suspended
+func (s *AuthService) IsAccountSuspended(ctx context.Context, userID int) (bool, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.Status == string(domain.UserStatusSuspended), nil
+}
+
+// lockSchedule returns the configured schedule, falling back to the single
+// LockoutThreshold/LockoutDuration pair when no schedule is set
+func (s *AuthService) lockSchedule() []config.LockThreshold {
+	if len(s.cfg.LockSchedule) > 0 {
+		return s.cfg.LockSchedule
+	}
+	if s.cfg.LockoutThreshold <= 0 {
+		return nil
+	}
+	return []config.LockThreshold{{Attempts: s.cfg.LockoutThreshold, Duration: s.cfg.LockoutDuration}}
+}
+
+// NotifyAccountLocked emails the user and the configured admin recipients
+// (LockNotifyEmails) that the account has been locked
+func (s *AuthService) NotifyAccountLocked(ctx context.Context, userID int, lockedUntil time.Time) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var errs []error
+	if user.Email == "" {
+		errs = append(errs, fmt.Errorf("user %d has no email", userID))
+	} else if err := s.mailer.Send(ctx, user.Email, "Account locked", s.accountLockedBody(lockedUntil)); err != nil {
+		errs = append(errs, fmt.Errorf("failed to send account locked email: %w", err))
+	}
+
+	adminBody := s.accountLockedAdminBody(user, lockedUntil)
+	for _, to := range s.cfg.LockNotifyEmails {
+		if err := s.mailer.Send(ctx, to, "User account locked", adminBody); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send account locked email to %s: %w", to, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notifyAccountLockedAsync sends the lock email without delaying the login response.
+// The request context is detached so the email survives the request being finished.
+func (s *AuthService) notifyAccountLockedAsync(ctx context.Context, userID int, lockedUntil time.Time) {
+	bg := context.WithoutCancel(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(bg, accountLockedNotifyTimeout)
+		defer cancel()
+
+		if err := s.NotifyAccountLocked(ctx, userID, lockedUntil); err != nil {
+			s.logger.Error("account locked notification failed",
+				zap.Int("user_id", userID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+func (s *AuthService) accountLockedBody(lockedUntil time.Time) string {
+	if !lockedUntil.Before(indefiniteLockUntil) {
+		return "Your account has been locked after too many failed login attempts.\n\n" +
+			"Contact an administrator to unlock it."
+	}
+	return fmt.Sprintf(
+		"Your account has been locked after too many failed login attempts.\n\n"+
+			"It will be unlocked automatically at %s.\n\n"+
+			"If this was not you, reset your password once the account is unlocked.",
+		lockedUntil.UTC().Format(time.RFC1123),
+	)
+}
+
+// accountLockedAdminBody нь админ хүлээн авагчид илгээх мэдэгдэл
+func (s *AuthService) accountLockedAdminBody(user *domain.User, lockedUntil time.Time) string {
+	until := "until an administrator unlocks it"
+	if lockedUntil.Before(indefiniteLockUntil) {
+		until = "until " + lockedUntil.UTC().Format(time.RFC1123)
+	}
+	return fmt.Sprintf(
+		"The account of user %d (%s) has been locked after too many failed login attempts.\n\n"+
+			"It stays locked %s.",
+		user.Id, user.Email, until,
+	)
+}
+
 // ============================================================
 // MFA VERIFICATION
 // ============================================================
@@ -551,9 +774,27 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID int, currentPas
 		return ErrInvalidCredentials
 	}
 
+	return s.updatePassword(ctx, userID, cred, newPass, domain.AuditActionPasswordChange, ip, userAgent)
+}
+
+// ResetPassword sets a new password without requiring the current one.
+// Used by the forgot/reset password flow after the reset token is verified;
+// applies the same strength and history checks as ChangePassword.
+func (s *AuthService) ResetPassword(ctx context.Context, userID int, newPass, ip, userAgent string) error {
+	cred, err := s.repo.GetCredentialByUserID(ctx, userID)
+	if err != nil {
+		return ErrCredentialsNotFound
+	}
+
+	return s.updatePassword(ctx, userID, cred, newPass, domain.AuditActionPasswordReset, ip, userAgent)
+}
+
+// updatePassword validates, hashes and stores a new password for existing credentials.
+// The previous hash is added to password history and the change is audited.
+func (s *AuthService) updatePassword(ctx context.Context, userID int, cred *domain.UserCredential, newPass string, action domain.SecurityAuditAction, ip, userAgent string) error {
 	// Validate new password
-	if len(newPass) < s.cfg.PasswordMinLength {
-		return ErrPasswordTooWeak
+	if err := validatePassword(s.cfg, newPass); err != nil {
+		return err
 	}
 
 	// Check password history
@@ -584,7 +825,7 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID int, currentPas
 	}
 
 	// Log password change
-	s.logAudit(ctx, &userID, string(domain.AuditActionPasswordChange), "user", strconv.Itoa(userID),
+	s.logAudit(ctx, &userID, string(action), "user", strconv.Itoa(userID),
 		nil, nil, ip, userAgent)
 
 	return nil
@@ -593,8 +834,8 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID int, currentPas
 // SetPassword sets a password for a user (admin/setup)
 func (s *AuthService) SetPassword(ctx context.Context, userID int, password string) error {
 	// Validate password
-	if len(password) < s.cfg.PasswordMinLength {
-		return ErrPasswordTooWeak
+	if err := validatePassword(s.cfg, password); err != nil {
+		return err
 	}
 
 	// Hash password
@@ -654,6 +895,11 @@ func (s *AuthService) RefreshSession(ctx context.Context, sessionID string) (*Se
 		return nil, ErrInvalidSession
 	}
 
+	// Impersonation session нь ImpersonationSessionTTL-ээс удаан амьдрахгүй
+	if session.IsImpersonated() {
+		return session, nil
+	}
+
 	newExpiry := time.Now().Add(s.cfg.SessionTTL)
 	if err := s.sessionStore.Refresh(ctx, sessionID, newExpiry); err != nil {
 		return nil, fmt.Errorf("failed to refresh session: %w", err)
@@ -704,6 +950,38 @@ func (s *AuthService) LogoutAll(ctx context.Context, userID int, ip, userAgent s
 	return nil
 }
 
+// RevokeAllSessions revokes every session of the user except exceptSessionID
+// (the caller's current session), so "sign out everywhere" keeps the caller signed in
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID int, exceptSessionID, ip, userAgent string) error {
+	sessionIDs, err := s.sessionStore.GetUserSessions(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	// Delete other sessions from Redis
+	revoked := 0
+	for _, id := range sessionIDs {
+		if id == exceptSessionID {
+			continue
+		}
+		if err := s.sessionStore.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete session: %w", err)
+		}
+		revoked++
+	}
+
+	// Revoke in DB
+	if err := s.repo.RevokeOtherUserSessions(ctx, userID, exceptSessionID, "logout others"); err != nil {
+		s.logger.Warn("failed to revoke other sessions", zap.Int("user_id", userID), zap.Error(err))
+	}
+
+	// Log
+	s.logAudit(ctx, &userID, string(domain.AuditActionLogoutOthers), "user", strconv.Itoa(userID),
+		nil, map[string]interface{}{"kept_session": exceptSessionID, "revoked": revoked}, ip, userAgent)
+
+	return nil
+}
+
 // GetActiveSessions returns all active sessions for a user
 func (s *AuthService) GetActiveSessions(ctx context.Context, userID int) ([]SessionData, error) {
 	sessionIDs, err := s.sessionStore.GetUserSessions(ctx, userID)
@@ -722,7 +1000,79 @@ func (s *AuthService) GetActiveSessions(ctx context.Context, userID int) ([]Sess
 	return sessions, nil
 }
 
+// Impersonate creates a short-lived session for targetID on behalf of adminID (support use-case).
+// The session is tagged with ImpersonatedBy and expires after ImpersonationSessionTTL.
+// Targets whose highest role ranks equal to or above the admin's are refused.
+func (s *AuthService) Impersonate(ctx context.Context, adminID, targetID int, reason, ip, userAgent string) (*SessionData, error) {
+	if adminID == targetID {
+		return nil, ErrSelfImpersonation
+	}
+
+	user, err := s.repo.GetUserByID(ctx, targetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Status == string(domain.UserStatusSuspended) {
+		return nil, ErrAccountSuspended
+	}
+	if user.Status != string(domain.UserStatusActive) {
+		return nil, ErrAccountNotActive
+	}
+	if err := s.checkImpersonationRank(ctx, adminID, targetID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.newSession(ctx, user, ImpersonationSessionTTL, adminID, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	// Админы нэр дээр audit бичнэ (target нь target_id-д)
+	s.logAudit(ctx, &adminID, string(domain.AuditActionImpersonate), "user", strconv.Itoa(targetID),
+		nil, map[string]interface{}{"reason": reason, "session_id": session.SessionID, "expires_at": session.ExpiresAt},
+		ip, userAgent)
+
+	return session, nil
+}
+
+// checkImpersonationRank returns ErrImpersonationDenied unless the admin outranks the target
+func (s *AuthService) checkImpersonationRank(ctx context.Context, adminID, targetID int) error {
+	if s.roles == nil {
+		return ErrImpersonationDenied
+	}
+	adminRoles, err := s.roles.GetRoleCodes(ctx, adminID)
+	if err != nil {
+		return fmt.Errorf("failed to get admin roles: %w", err)
+	}
+	targetRoles, err := s.roles.GetRoleCodes(ctx, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to get target roles: %w", err)
+	}
+	if highestRoleRank(targetRoles) >= highestRoleRank(adminRoles) {
+		return ErrImpersonationDenied
+	}
+	return nil
+}
+
+// highestRoleRank returns the highest impersonationRoleRanks value among codes
+func highestRoleRank(codes []string) int {
+	best := 0
+	for _, code := range codes {
+		if r := impersonationRoleRanks[code]; r > best {
+			best = r
+		}
+	}
+	return best
+}
+
 func (s *AuthService) createSession(ctx context.Context, user *domain.User, ip, userAgent string) (*SessionData, error) {
+	return s.newSession(ctx, user, s.cfg.SessionTTL, 0, ip, userAgent)
+}
+
+func (s *AuthService) newSession(ctx context.Context, user *domain.User, ttl time.Duration, impersonatedBy int, ip, userAgent string) (*SessionData, error) {
 	sessionID := uuid.New().String()
 	now := time.Now()
 
@@ -733,8 +1083,9 @@ func (s *AuthService) createSession(ctx context.Context, user *domain.User, ip,
 		IPAddress:      ip,
 		UserAgent:      userAgent,
 		CreatedAt:      now,
-		ExpiresAt:      now.Add(s.cfg.SessionTTL),
+		ExpiresAt:      now.Add(ttl),
 		LastActivityAt: now,
+		ImpersonatedBy: impersonatedBy,
 	}
 
 	// Store in Redis
@@ -769,7 +1120,7 @@ func (s *AuthService) UpdateUserStatus(ctx context.Context, userID int, status,
 	}
 
 	// Get current status for audit
-	user, err := s.repo.GetUserByEmail(ctx, "") // This needs fixing - we need GetUserByID
+	user, err := s.repo.GetUserByID(ctx, userID)
 	if err != nil {
 		s.logger.Warn("could not get user for audit", zap.Int("user_id", userID))
 	}
@@ -790,6 +1141,14 @@ func (s *AuthService) UpdateUserStatus(ctx context.Context, userID int, status,
 		s.repo.RevokeAllUserSessions(ctx, userID, "status change: "+status)
 	}
 
+	// Дахин идэвхжүүлэхэд өмнөх буруу оролдлого, түгжээг цэвэрлэнэ — эс бөгөөс
+	// PermanentLockAfter-д хүрсэн тоолуур хэвээр үлдэж дараагийн алдаа л хангалттай болно
+	if status == string(domain.UserStatusActive) {
+		if err := s.repo.UnlockAccount(ctx, userID); err != nil {
+			return fmt.Errorf("failed to reset failed attempts: %w", err)
+		}
+	}
+
 	// Log audit
 	s.logAudit(ctx, &changedBy, string(domain.AuditActionStatusChange), "user", strconv.Itoa(userID),
 		map[string]interface{}{"status": oldStatus},
@@ -830,7 +1189,7 @@ func (s *AuthService) GetSecurityAudit(ctx context.Context, userID int, limit in
 func (s *AuthService) GetMFAStatus(ctx context.Context, userID int) (enabled bool, hasBackupCodes bool, err error) {
 	mfa, err := s.repo.GetMFAByUserID(ctx, userID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, domain.ErrNotFound) {
 			return false, false, nil
 		}
 		return false, false, err
@@ -72,6 +72,11 @@ func (s *CachedUserService) List(ctx context.Context, p common.PaginationQuery)
 	return s.UserService.List(ctx, p)
 }
 
+// Search is not cached (query and pagination vary)
+func (s *CachedUserService) Search(ctx context.Context, q string, p common.PaginationQuery) ([]domain.User, int64, int, int, error) {
+	return s.UserService.Search(ctx, q, p)
+}
+
 // Create invalidates cache after creating
 func (s *CachedUserService) Create(ctx context.Context, req dto.UserCreateDto) (domain.User, error) {
 	user, err := s.UserService.Create(ctx, req)
@@ -10,37 +10,93 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"templatev25/internal/cache"
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 
 	"templatev25/internal/repository"
 
+	"git.gerege.mn/backend-packages/common"
 	"go.uber.org/zap"
 )
 
+// GET /chat?q= хайлтын үр дүнгийн cache: олон хайгддаг үгс LRU-д үлдэнэ
+const (
+	chatSearchCacheSize = 200
+	chatSearchCacheTTL  = 5 * time.Minute
+)
+
+// chatSearchResult нь нэг (үг, page, size) хайлтын хуудас
+type chatSearchResult struct {
+	items      []domain.ChatItem
+	total      int64
+	page, size int
+}
+
 type ChatItemService struct {
-	repo repository.ChatItemRepository
-	log  *zap.Logger
+	repo   repository.ChatItemRepository
+	log    *zap.Logger
+	search *cache.LRU[chatSearchResult]
 }
 
 func NewChatItemService(r repository.ChatItemRepository, log *zap.Logger) *ChatItemService {
-	return &ChatItemService{repo: r, log: log}
+	return &ChatItemService{
+		repo:   r,
+		log:    log,
+		search: cache.NewLRU[chatSearchResult](chatSearchCacheSize, chatSearchCacheTTL),
+	}
 }
 
 func (s *ChatItemService) GetByKey(ctx context.Context, key string) (domain.ChatItem, error) {
 	return s.repo.FindByKey(ctx, key)
 }
 
+// GetByID нь chat item-ийг tag-уудын хамт буцаана
+func (s *ChatItemService) GetByID(ctx context.Context, id int) (domain.ChatItem, error) {
+	return s.repo.ByID(ctx, id)
+}
+
 func (s *ChatItemService) List(ctx context.Context, q dto.ChatItemQuery) ([]domain.ChatItem, int64, int, int, error) {
 	return s.repo.List(ctx, q)
 }
 
+// Search нь Answer-аар хайна (GET /chat?q=). Хоосон үг бол бүх item-ийг хуудаслана.
+// Хоосон биш хайлтын үр дүнг (үг, page, size)-аар LRU cache-д (200 entry, 5 минут) хадгална;
+// Create/Update/Delete нь cache-ийг цэвэрлэнэ.
+func (s *ChatItemService) Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.ChatItem, int64, int, int, error) {
+	// Answer нь жижиг үсгээр хадгалагддаг тул үгийг мөн жижигрүүлж cache key-г нэгтгэнэ
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return s.repo.Search(ctx, query, p)
+	}
+
+	key := fmt.Sprintf("%s|%d|%d", query, p.Page, p.Size)
+	if r, ok := s.search.Get(key); ok {
+		return r.items, r.total, r.page, r.size, nil
+	}
+
+	items, total, page, size, err := s.repo.Search(ctx, query, p)
+	if err != nil {
+		return nil, 0, 0, 0, err
+	}
+	s.search.Set(key, chatSearchResult{items: items, total: total, page: page, size: size})
+	return items, total, page, size, nil
+}
+
 func (s *ChatItemService) Create(ctx context.Context, d dto.ChatItemCreateDto) error {
 	m := domain.ChatItem{
 		Key:    d.Key,
 		Answer: d.Answer,
 	}
-	return s.repo.Create(ctx, m)
+	if err := s.repo.Create(ctx, m); err != nil {
+		return err
+	}
+	s.search.Clear()
+	return nil
 }
 
 func (s *ChatItemService) Update(ctx context.Context, id int, d dto.ChatItemUpdateDto) error {
@@ -48,9 +104,34 @@ func (s *ChatItemService) Update(ctx context.Context, id int, d dto.ChatItemUpda
 		Key:    d.Key,
 		Answer: d.Answer,
 	}
-	return s.repo.Update(ctx, id, m)
+	if err := s.repo.Update(ctx, id, m); err != nil {
+		return err
+	}
+	s.search.Clear()
+	return nil
 }
 
 func (s *ChatItemService) Delete(ctx context.Context, id int) error {
-	return s.repo.Delete(ctx, id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.search.Clear()
+	return nil
+}
+
+// AddTags нь chat item-д tag-ууд оноож, оноогдсон бүх tag-ийг буцаана.
+// Chat item эсвэл tag олдохгүй бол ErrNotFound.
+func (s *ChatItemService) AddTags(ctx context.Context, itemID int, d dto.ChatItemTagsDto) ([]domain.Tag, error) {
+	if _, err := s.repo.ByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddTags(ctx, itemID, d.TagIDs); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTags(ctx, itemID)
+}
+
+// RemoveTag нь chat item-ээс tag салгана
+func (s *ChatItemService) RemoveTag(ctx context.Context, itemID, tagID int) error {
+	return s.repo.RemoveTag(ctx, itemID, tagID)
 }
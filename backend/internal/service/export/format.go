@@ -0,0 +1,170 @@
+// Package export provides implementation for export
+//
+// File: format.go
+// Description: Export format enum and Accept/?format= negotiation
+/*
+Package export нь жагсаалт export хийх форматуудыг (CSV, NDJSON, XLSX)
+тодорхойлж, request-ээс формат сонгох болон мөр бичих formatter-уудыг агуулна.
+
+Формат сонгох дараалал:
+ 1. ?format= query (csv, json, xlsx) — давуу эрхтэй
+ 2. Accept header (q-утгаар эрэмбэлнэ)
+ 3. Аль нь ч байхгүй бол CSV
+
+Ашиглалт:
+
+	format, err := export.Resolve(c.Query("format"), c.Get(fiber.HeaderAccept))
+	f, err := export.NewFormatter(format, &buf)
+*/
+package export
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExportFormat нь export файлын формат
+type ExportFormat string
+
+const (
+	// FormatCSV нь text/csv (default)
+	FormatCSV ExportFormat = "csv"
+
+	// FormatJSON нь мөр бүр нэг JSON object байх newline-delimited JSON
+	FormatJSON ExportFormat = "json"
+
+	// FormatXLSX нь Excel workbook
+	FormatXLSX ExportFormat = "xlsx"
+)
+
+// MIME төрлүүд
+const (
+	MIMECSV    = "text/csv"
+	MIMEJSON   = "application/json"
+	MIMENDJSON = "application/x-ndjson"
+	MIMEExcel  = "application/vnd.ms-excel"
+	MIMEXLSX   = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+var (
+	// ErrUnsupportedFormat нь ?format= утга танигдаагүй үед буцна (400)
+	ErrUnsupportedFormat = errors.New("unsupported export format")
+
+	// ErrNotAcceptable нь Accept header-т тохирох формат байхгүй үед буцна (406)
+	ErrNotAcceptable = errors.New("no acceptable export format")
+)
+
+// mimeFormats нь Accept header-ийн MIME төрлийг формат руу хөрвүүлнэ
+var mimeFormats = map[string]ExportFormat{
+	MIMECSV:         FormatCSV,
+	MIMEJSON:        FormatJSON,
+	MIMENDJSON:      FormatJSON,
+	MIMEExcel:       FormatXLSX,
+	MIMEXLSX:        FormatXLSX,
+	"*/*":           FormatCSV,
+	"text/*":        FormatCSV,
+	"application/*": FormatJSON,
+}
+
+// ContentType нь response-ийн Content-Type утгыг буцаана
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return MIMENDJSON
+	case FormatXLSX:
+		return MIMEXLSX
+	default:
+		return MIMECSV
+	}
+}
+
+// Extension нь файлын өргөтгөлийг буцаана (цэггүй)
+func (f ExportFormat) Extension() string {
+	switch f {
+	case FormatJSON:
+		return "ndjson"
+	case FormatXLSX:
+		return "xlsx"
+	default:
+		return "csv"
+	}
+}
+
+// ParseFormat нь ?format= query утгыг формат болгоно (том/жижиг үсэг хамаарахгүй).
+// "ndjson" нь json-ий, "excel" нь xlsx-ийн alias.
+func ParseFormat(s string) (ExportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "csv":
+		return FormatCSV, nil
+	case "json", "ndjson":
+		return FormatJSON, nil
+	case "xlsx", "excel":
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Resolve нь query болон Accept header-ээс export форматыг сонгоно.
+// query хоосон биш бол Accept-ийг үл тооно.
+//
+// Returns:
+//   - ErrUnsupportedFormat: query утга танигдаагүй
+//   - ErrNotAcceptable: Accept-д дэмжигдэх MIME төрөл байхгүй
+func Resolve(query, accept string) (ExportFormat, error) {
+	if query != "" {
+		return ParseFormat(query)
+	}
+	if strings.TrimSpace(accept) == "" {
+		return FormatCSV, nil
+	}
+	for _, mime := range parseAccept(accept) {
+		if f, ok := mimeFormats[mime]; ok {
+			return f, nil
+		}
+	}
+	return "", ErrNotAcceptable
+}
+
+// acceptRange нь Accept header-ийн нэг элемент
+type acceptRange struct {
+	mime string
+	q    float64
+}
+
+// parseAccept нь Accept header-ийг q-утгаар буурахаар эрэмбэлсэн MIME жагсаалт болгоно.
+// Ижил q-тэй бол header дээрх дарааллыг хадгална; q=0 элементийг хасна.
+func parseAccept(accept string) []string {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		mime := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mime == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(k) != "q" {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		ranges = append(ranges, acceptRange{mime: mime, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	mimes := make([]string, len(ranges))
+	for i, r := range ranges {
+		mimes[i] = r.mime
+	}
+	return mimes
+}
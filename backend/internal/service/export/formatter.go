@@ -0,0 +1,170 @@
+// Package export provides implementation for export
+//
+// File: formatter.go
+// Description: Row formatters (CSV, NDJSON, XLSX) and FormatterFactory
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Formatter нь export мөрүүдийг тодорхой форматаар w руу бичнэ.
+// WriteHeader-ийг нэг удаа, дараа нь WriteRow-г дурын удаа, эцэст нь Close дуудна.
+type Formatter interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []string) error
+	Close() error
+}
+
+// FormatterFactory нь w руу бичих Formatter үүсгэнэ
+type FormatterFactory func(w io.Writer) (Formatter, error)
+
+// factories нь формат бүрийн FormatterFactory
+var factories = map[ExportFormat]FormatterFactory{
+	FormatCSV:  newCSVFormatter,
+	FormatJSON: newNDJSONFormatter,
+	FormatXLSX: newXLSXFormatter,
+}
+
+// NewFormatter нь format-д тохирох Formatter үүсгэнэ.
+// Бүртгэгдээгүй формат бол ErrUnsupportedFormat буцаана.
+func NewFormatter(format ExportFormat, w io.Writer) (Formatter, error) {
+	factory, ok := factories[format]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+	return factory(w)
+}
+
+// ============================================================
+// CSV
+// ============================================================
+
+type csvFormatter struct {
+	w *csv.Writer
+}
+
+func newCSVFormatter(w io.Writer) (Formatter, error) {
+	return &csvFormatter{w: csv.NewWriter(w)}, nil
+}
+
+func (f *csvFormatter) WriteHeader(columns []string) error {
+	return f.w.Write(columns)
+}
+
+func (f *csvFormatter) WriteRow(values []string) error {
+	return f.w.Write(values)
+}
+
+func (f *csvFormatter) Close() error {
+	f.w.Flush()
+	return f.w.Error()
+}
+
+// ============================================================
+// NDJSON
+// ============================================================
+
+// ndjsonFormatter нь мөр бүрийг header-ийн дарааллаар key-тэй JSON object болгоно.
+// Header мөр тусдаа бичигдэхгүй.
+type ndjsonFormatter struct {
+	w       io.Writer
+	columns [][]byte
+}
+
+func newNDJSONFormatter(w io.Writer) (Formatter, error) {
+	return &ndjsonFormatter{w: w}, nil
+}
+
+func (f *ndjsonFormatter) WriteHeader(columns []string) error {
+	f.columns = make([][]byte, len(columns))
+	for i, col := range columns {
+		key, err := json.Marshal(col)
+		if err != nil {
+			return err
+		}
+		f.columns[i] = key
+	}
+	return nil
+}
+
+func (f *ndjsonFormatter) WriteRow(values []string) error {
+	if len(values) != len(f.columns) {
+		return fmt.Errorf("export: row has %d values, header has %d columns", len(values), len(f.columns))
+	}
+	line := []byte{'{'}
+	for i, v := range values {
+		if i > 0 {
+			line = append(line, ',')
+		}
+		val, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		line = append(line, f.columns[i]...)
+		line = append(line, ':')
+		line = append(line, val...)
+	}
+	line = append(line, '}', '\n')
+	_, err := f.w.Write(line)
+	return err
+}
+
+func (f *ndjsonFormatter) Close() error {
+	return nil
+}
+
+// ============================================================
+// XLSX
+// ============================================================
+
+// xlsxSheet нь export хийх sheet-ийн нэр (excelize-ийн default sheet)
+const xlsxSheet = "Sheet1"
+
+// xlsxFormatter нь excelize StreamWriter-ээр мөрүүдийг бичиж, Close үед workbook-ийг w руу гаргана
+type xlsxFormatter struct {
+	w    io.Writer
+	file *excelize.File
+	sw   *excelize.StreamWriter
+	row  int
+}
+
+func newXLSXFormatter(w io.Writer) (Formatter, error) {
+	file := excelize.NewFile()
+	sw, err := file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &xlsxFormatter{w: w, file: file, sw: sw}, nil
+}
+
+func (f *xlsxFormatter) WriteHeader(columns []string) error {
+	return f.WriteRow(columns)
+}
+
+func (f *xlsxFormatter) WriteRow(values []string) error {
+	f.row++
+	cell, err := excelize.CoordinatesToCellName(1, f.row)
+	if err != nil {
+		return err
+	}
+	row := make([]interface{}, len(values))
+	for i, v := range values {
+		row[i] = v
+	}
+	return f.sw.SetRow(cell, row)
+}
+
+func (f *xlsxFormatter) Close() error {
+	defer f.file.Close()
+	if err := f.sw.Flush(); err != nil {
+		return err
+	}
+	return f.file.Write(f.w)
+}
@@ -0,0 +1,286 @@
+// Package service provides implementation for service
+//
+// File: google_oauth_service.go
+// Description: Google OAuth2 authorization code exchange for local sessions
+/*
+ExchangeGoogleCode нь Google-ийн callback-аас ирсэн authorization code-ийг
+token endpoint дээр солиод local session үүсгэнэ.
+
+Урсгал:
+ 1. code → token (golang.org/x/oauth2/google), хариунаас id_token авна
+ 2. id_token-ийн payload-аас sub, email-ийг уншина (iss, aud, exp шалгана)
+ 3. user_credentials (oauth_provider='google', oauth_provider_id=sub)-ээр хэрэглэгч хайна;
+    олдохгүй бол email-ээр хайж (байхгүй бол үүсгэж) credential-ийг холбоно.
+    Өөр Google account-д холбогдсон бүртгэлийг дахин холбохгүй.
+ 4. Login-тэй ижил шалгалт: хэрэглэгчийн төлөв, credential түгжээ, MFA
+    (MFA идэвхтэй бол session-ий оронд MFA token буцаана → /auth/local/mfa/verify)
+ 5. session үүсгэнэ (SessionStore + sessions table)
+
+id_token нь token endpoint-оос TLS-ээр шууд ирдэг тул гарын үсгийг шалгахгүй
+(OpenID Connect Core 3.1.3.7). Google баталгаажуулаагүй email-ийг хүлээж авахгүй —
+өөр хүний бүртгэлд холбогдохоос сэргийлнэ.
+
+HTTP client-ийг ctx-д oauth2.HTTPClient-ээр өгч болно (тест, proxy):
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, client)
+*/
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"templatev25/internal/config"
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// CredentialProviderGoogle нь Google-ээр холбогдсон credential-ийн provider нэр
+const CredentialProviderGoogle = "google"
+
+// Google OAuth errors
+var (
+	ErrGoogleOAuthDisabled    = errors.New("google oauth is not configured")
+	ErrGoogleCodeExchange     = errors.New("failed to exchange google authorization code")
+	ErrInvalidGoogleIDToken   = errors.New("invalid google id token")
+	ErrGoogleEmailNotVerified = errors.New("google email is not verified")
+	ErrGoogleAccountConflict  = errors.New("account is already linked to another external identity")
+)
+
+// googleIssuers нь Google ID token-ий зөвшөөрөгдсөн iss утгууд
+var googleIssuers = map[string]bool{
+	"accounts.google.com":         true,
+	"https://accounts.google.com": true,
+}
+
+// SessionResult contains the outcome of an external provider login.
+// RequiresMFA бол Session хоосон бөгөөд MFAToken-оор VerifyMFA/VerifyBackupCode дуудна.
+type SessionResult struct {
+	Session *SessionData
+	User    *domain.User
+	// IsNewUser нь энэ нэвтрэлтээр хэрэглэгч шинээр үүссэн эсэх
+	IsNewUser bool
+
+	RequiresMFA bool
+	MFAToken    string
+}
+
+// googleIDClaims нь ID token-оос ашиглах claim-ууд
+type googleIDClaims struct {
+	Iss           string `json:"iss"`
+	Aud           string `json:"aud"`
+	Sub           string `json:"sub"`
+	Exp           int64  `json:"exp"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+}
+
+// SetGoogleOAuth enables ExchangeGoogleCode. users is used to create or update
+// the domain.User behind a Google account.
+func (s *AuthService) SetGoogleOAuth(cfg config.GoogleOAuthConfig, users repository.UserRepository) {
+	s.google = &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		Endpoint:     google.Endpoint,
+		Scopes:       []string{"openid", "email", "profile"},
+	}
+	s.users = users
+}
+
+// ExchangeGoogleCode exchanges a Google authorization code for tokens, links the
+// Google account (sub) to a local user and creates a session.
+// redirectURI must match the one used in the authorization request.
+func (s *AuthService) ExchangeGoogleCode(ctx context.Context, code, redirectURI string) (SessionResult, error) {
+	if s.google == nil {
+		return SessionResult{}, ErrGoogleOAuthDisabled
+	}
+
+	conf := *s.google
+	conf.RedirectURL = redirectURI
+	token, err := conf.Exchange(ctx, code)
+	if err != nil {
+		s.logger.Warn("google code exchange failed", zap.Error(err))
+		return SessionResult{}, fmt.Errorf("%w: %v", ErrGoogleCodeExchange, err)
+	}
+
+	rawIDToken, _ := token.Extra("id_token").(string)
+	claims, err := parseGoogleIDToken(rawIDToken, conf.ClientID, time.Now())
+	if err != nil {
+		return SessionResult{}, err
+	}
+	if !claims.EmailVerified {
+		return SessionResult{}, ErrGoogleEmailNotVerified
+	}
+
+	user, cred, isNew, err := s.upsertGoogleUser(ctx, claims)
+	if err != nil {
+		return SessionResult{}, err
+	}
+	if user.Status != string(domain.UserStatusActive) {
+		s.logGoogleLoginFailure(ctx, user, "account not active")
+		return SessionResult{}, ErrAccountNotActive
+	}
+	if cred.IsLocked() {
+		s.logGoogleLoginFailure(ctx, user, "account locked")
+		return SessionResult{}, ErrAccountLocked
+	}
+
+	// Login-тэй адил MFA идэвхтэй бол session үүсгэхгүй
+	mfa, err := s.repo.GetMFAByUserID(ctx, user.Id)
+	if err == nil && mfa != nil && mfa.IsEnabled {
+		mfaToken, err := s.issueMFAToken(ctx, user, "", "")
+		if err != nil {
+			return SessionResult{}, err
+		}
+		return SessionResult{User: user, IsNewUser: isNew, RequiresMFA: true, MFAToken: mfaToken}, nil
+	}
+
+	session, err := s.createSession(ctx, user, "", "")
+	if err != nil {
+		return SessionResult{}, err
+	}
+
+	s.repo.UpdateUserLoginStats(ctx, user.Id)
+	s.repo.CreateLoginHistory(ctx, &domain.LoginHistory{
+		UserID:      &user.Id,
+		Email:       user.Email,
+		LoginMethod: CredentialProviderGoogle,
+		Success:     true,
+	})
+	s.logAudit(ctx, &user.Id, string(domain.AuditActionLoginSuccess), "user", strconv.Itoa(user.Id),
+		nil, map[string]interface{}{"provider": CredentialProviderGoogle, "new_user": isNew}, "", "")
+
+	return SessionResult{Session: session, User: user, IsNewUser: isNew}, nil
+}
+
+// logGoogleLoginFailure нь Google-ээр нэвтрэх оролдлогын татгалзлыг login history-д бичнэ
+func (s *AuthService) logGoogleLoginFailure(ctx context.Context, user *domain.User, reason string) {
+	s.repo.CreateLoginHistory(ctx, &domain.LoginHistory{
+		UserID:        &user.Id,
+		Email:         user.Email,
+		LoginMethod:   CredentialProviderGoogle,
+		Success:       false,
+		FailureReason: reason,
+	})
+}
+
+// upsertGoogleUser нь sub-аар холбогдсон хэрэглэгч болон түүний credential-ийг буцаана.
+// Холбогдоогүй бол email-ээр хэрэглэгч хайж (байхгүй бол үүсгэж) credential-д provider/sub-ийг
+// бичнэ. Credential нь өөр provider ID-д холбогдсон бол ErrGoogleAccountConflict.
+func (s *AuthService) upsertGoogleUser(ctx context.Context, claims *googleIDClaims) (*domain.User, *domain.UserCredential, bool, error) {
+	cred, err := s.repo.GetCredentialByProvider(ctx, CredentialProviderGoogle, claims.Sub)
+	if err == nil {
+		user, err := s.repo.GetUserByID(ctx, cred.UserID)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("failed to get user: %w", err)
+		}
+		return user, cred, false, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, nil, false, fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	isNew := false
+	user, err := s.repo.GetUserByEmail(ctx, claims.Email)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		created, err := s.users.Create(ctx, domain.User{
+			Email:     claims.Email,
+			FirstName: claims.GivenName,
+			LastName:  claims.FamilyName,
+			Status:    string(domain.UserStatusActive),
+		})
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("failed to create user: %w", err)
+		}
+		user, isNew = &created, true
+	case err != nil:
+		return nil, nil, false, fmt.Errorf("failed to get user: %w", err)
+	case (user.FirstName == "" && claims.GivenName != "") || (user.LastName == "" && claims.FamilyName != ""):
+		// Хоосон нэрийг л Google-ийн profile-оор нөхнө
+		patch := domain.User{Id: user.Id}
+		if user.FirstName == "" {
+			patch.FirstName, user.FirstName = claims.GivenName, claims.GivenName
+		}
+		if user.LastName == "" {
+			patch.LastName, user.LastName = claims.FamilyName, claims.FamilyName
+		}
+		if _, err := s.users.Update(ctx, patch); err != nil {
+			return nil, nil, false, fmt.Errorf("failed to update user: %w", err)
+		}
+	}
+
+	existing, err := s.repo.GetCredentialByUserID(ctx, user.Id)
+	switch {
+	case err == nil && existing.ProviderID != "":
+		// sub-аар олдоогүй тул энэ нь өөр Google (эсвэл өөр provider) account — дарж бичихгүй
+		s.logger.Warn("google login rejected: account linked to another identity",
+			zap.Int("user_id", user.Id),
+			zap.String("provider", existing.Provider),
+		)
+		return nil, nil, false, ErrGoogleAccountConflict
+	case err == nil:
+		existing.Provider = CredentialProviderGoogle
+		existing.ProviderID = claims.Sub
+		err = s.repo.UpdateCredential(ctx, existing)
+	case errors.Is(err, domain.ErrNotFound):
+		// Нууц үггүй credential — DB default "password" биш
+		existing = &domain.UserCredential{
+			UserID:         user.Id,
+			CredentialType: string(domain.CredentialTypeOAuth),
+			Provider:       CredentialProviderGoogle,
+			ProviderID:     claims.Sub,
+		}
+		err = s.repo.CreateCredential(ctx, existing)
+	}
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to link google account: %w", err)
+	}
+
+	s.logger.Info("google account linked",
+		zap.Int("user_id", user.Id),
+		zap.Bool("new_user", isNew),
+	)
+	return user, existing, isNew, nil
+}
+
+// parseGoogleIDToken нь JWT payload-ийг decode хийж iss, aud, exp, sub, email-ийг шалгана
+func parseGoogleIDToken(raw, clientID string, now time.Time) (*googleIDClaims, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidGoogleIDToken)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGoogleIDToken, err)
+	}
+
+	var claims googleIDClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGoogleIDToken, err)
+	}
+
+	switch {
+	case !googleIssuers[claims.Iss]:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidGoogleIDToken, claims.Iss)
+	case claims.Aud != clientID:
+		return nil, fmt.Errorf("%w: audience mismatch", ErrInvalidGoogleIDToken)
+	case !now.Before(time.Unix(claims.Exp, 0)):
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidGoogleIDToken)
+	case claims.Sub == "" || claims.Email == "":
+		return nil, fmt.Errorf("%w: missing sub or email", ErrInvalidGoogleIDToken)
+	}
+	return &claims, nil
+}
@@ -13,10 +13,12 @@ package service
 
 import (
 	"context"
+	"io"
 
 	"templatev25/internal/auth"
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/service/export"
 
 	"git.gerege.mn/backend-packages/common"
 )
@@ -33,9 +35,19 @@ type UserServiceInterface interface {
 	// List retrieves paginated users
 	List(ctx context.Context, p common.PaginationQuery) ([]domain.User, int64, int, int, error)
 
+	// Search retrieves paginated users whose name, email, reg_no or phone_no contains q
+	Search(ctx context.Context, q string, p common.PaginationQuery) ([]domain.User, int64, int, int, error)
+
+	// Export streams users matching the filter to w in the given format (CSV, NDJSON, XLSX)
+	Export(ctx context.Context, p common.PaginationQuery, format export.ExportFormat, w io.Writer) error
+
 	// Create creates a new user or returns existing if already exists
 	Create(ctx context.Context, req dto.UserCreateDto) (domain.User, error)
 
+	// SyncFromSSO upserts users pushed by SSO in bulk and returns the upserted count
+	// and the ids of deleted users that were skipped
+	SyncFromSSO(ctx context.Context, users []dto.UserCreateDto) (upserted int, skipped []int, err error)
+
 	// Update updates an existing user
 	Update(ctx context.Context, req dto.UserUpdateDto) (domain.User, error)
 
@@ -44,6 +56,9 @@ type UserServiceInterface interface {
 
 	// Organizations retrieves user's organizations
 	Organizations(ctx context.Context, userID, currentOrgID int, fields []string) (orgID int, org *domain.Organization, items []domain.Organization, err error)
+
+	// SwitchOrganization changes the active organization of the current session
+	SwitchOrganization(ctx context.Context, citizenID, newOrgID int) error
 }
 
 // ============================================================
@@ -58,6 +73,9 @@ type RoleServiceInterface interface {
 	// ListFilteredPaged retrieves paginated roles with filtering
 	ListFilteredPaged(ctx context.Context, p dto.RoleListQuery) ([]domain.Role, int64, int, int, error)
 
+	// GetByID retrieves a role together with its permissions
+	GetByID(ctx context.Context, id int) (domain.Role, []domain.Permission, error)
+
 	// Create creates a new role
 	Create(ctx context.Context, req dto.RoleCreateDto) error
 
@@ -95,6 +113,15 @@ type PermissionServiceInterface interface {
 	// Create creates new permissions in batch
 	Create(ctx context.Context, req dto.PermissionCreateDto) error
 
+	// GenerateCode builds a "system.module.action" permission code ("" if invalid)
+	GenerateCode(systemCode, moduleCode, actionCode string) string
+
+	// ListBySystemGrouped retrieves a system's permissions grouped by module
+	ListBySystemGrouped(ctx context.Context, systemID int) ([]dto.SystemPermissionGroup, error)
+
+	// ListByModule retrieves a module's permissions (paginated); ErrNotFound if the module is missing
+	ListByModule(ctx context.Context, moduleID int, q dto.PermissionQuery) ([]domain.Permission, int64, int, int, error)
+
 	// Update updates an existing permission
 	Update(ctx context.Context, id int, req dto.PermissionUpdateDto) error
 
@@ -123,8 +150,20 @@ type OrganizationServiceInterface interface {
 	// ByID retrieves an organization by ID
 	ByID(ctx context.Context, id int) (domain.Organization, error)
 
+	// GetByID retrieves an organization with its type name, children count and user count
+	GetByID(ctx context.Context, id int) (dto.OrganizationDetail, error)
+
 	// Tree retrieves organization hierarchy tree starting from rootID
 	Tree(ctx context.Context, rootID int) ([]domain.Organization, error)
+
+	// Exists reports whether an organization with the given ID exists
+	Exists(ctx context.Context, id int) (bool, error)
+
+	// Search performs a full-text search over organizations
+	Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.Organization, int64, int, int, error)
+
+	// MoveToParent reparents an organization subtree, rejecting circular references
+	MoveToParent(ctx context.Context, id, newParentID int) error
 }
 
 // ============================================================
@@ -147,6 +186,12 @@ type NewsServiceInterface interface {
 
 	// Delete deletes a news item
 	Delete(ctx context.Context, id int) error
+
+	// Publish marks a news item as published
+	Publish(ctx context.Context, id int) error
+
+	// Unpublish moves a news item back to draft
+	Unpublish(ctx context.Context, id int) error
 }
 
 // ============================================================
@@ -158,6 +203,9 @@ type SystemServiceInterface interface {
 	// List retrieves paginated systems
 	List(ctx context.Context, q dto.SystemListQuery) ([]domain.System, int64, int, int, error)
 
+	// ListActive retrieves all active systems ordered by sequence
+	ListActive(ctx context.Context) ([]domain.System, error)
+
 	// ByID retrieves a system by ID
 	ByID(ctx context.Context, id int) (domain.System, error)
 
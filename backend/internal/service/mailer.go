@@ -0,0 +1,160 @@
+// Package service provides implementation for service
+//
+// File: mailer.go
+// Description: Outgoing email abstraction used by registration and password reset
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"templatev25/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// ErrInvalidMailHeader is returned when a recipient or subject contains a line break (header injection)
+var ErrInvalidMailHeader = errors.New("mail header contains line break")
+
+// smtpSendTimeout bounds one SMTP delivery (dial through QUIT) so a stalled
+// server cannot hold the caller's goroutine indefinitely
+const smtpSendTimeout = 15 * time.Second
+
+// Mailer sends transactional emails (password reset, verification).
+// Send stops when ctx is cancelled or its deadline passes.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// LogMailer is a Mailer that only logs outgoing emails.
+// Used until a real mail transport (SMTP, provider API) is configured.
+type LogMailer struct {
+	logger *zap.Logger
+}
+
+// NewLogMailer creates a mailer that writes emails to the log
+func NewLogMailer(logger *zap.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+// Send logs the recipient and subject; the body is not logged because it may contain secrets
+func (m *LogMailer) Send(_ context.Context, to, subject, body string) error {
+	m.logger.Info("email not sent (log mailer)",
+		zap.String("to", to),
+		zap.String("subject", subject),
+		zap.Int("body_length", len(body)),
+	)
+	return nil
+}
+
+// SMTPMailer sends plain-text UTF-8 emails through an SMTP server (SMTP_HOST).
+// The connection upgrades to STARTTLS when the server offers it; AUTH PLAIN is used only when a username is set.
+// Every delivery is bounded by smtpSendTimeout and the caller's ctx.
+type SMTPMailer struct {
+	host string
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer creates a mailer from a validated MailConfig
+func NewSMTPMailer(cfg *config.MailConfig) *SMTPMailer {
+	m := &SMTPMailer{
+		host: cfg.Host,
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		from: cfg.From,
+	}
+	if cfg.Username != "" {
+		m.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return m
+}
+
+// Send delivers one email to a single recipient
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return ErrInvalidMailHeader
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+
+	ctx, cancel := context.WithTimeout(ctx, smtpSendTimeout)
+	defer cancel()
+	if err := m.deliver(ctx, to, msg.Bytes()); err != nil {
+		return fmt.Errorf("smtp send: %w", err)
+	}
+	return nil
+}
+
+// deliver runs the same SMTP exchange as smtp.SendMail, but on a connection whose
+// deadline follows ctx: a cancelled ctx unblocks any pending read or write at once.
+func (m *SMTPMailer) deliver(ctx context.Context, to string, msg []byte) error {
+	dialer := net.Dialer{Timeout: smtpSendTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("server doesn't support AUTH")
+		}
+		if err := c.Auth(m.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
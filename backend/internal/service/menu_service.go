@@ -10,10 +10,12 @@ package service
 
 import (
 	"context"
-	"sort"
+	"fmt"
+	"slices"
 
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/util"
 
 	"templatev25/internal/repository"
 )
@@ -26,16 +28,37 @@ type MenuService interface {
 	Create(ctx context.Context, req dto.MenuCreateDto) error
 	Update(ctx context.Context, id int64, req dto.MenuUpdateDto) error
 	Delete(ctx context.Context, id int64) error
+	SetPermissions(ctx context.Context, menuID int64, permissionIDs []int) error
+	Reorder(ctx context.Context, orders []dto.MenuReorderItem) error
+}
+
+// UserPermissionLister нь хэрэглэгчийн эзэмшиж буй permission кодуудыг буцаана (PermissionService)
+type UserPermissionLister interface {
+	GetUserPermissions(ctx context.Context, userID int) ([]string, error)
 }
 
 type menuService struct {
-	repo repository.MenuRepository
+	repo        repository.MenuRepository
+	systems     SystemService        // nil бол system-ээр шүүхгүй
+	permissions UserPermissionLister // nil бол menu_permissions-ийг харгалзахгүй
 }
 
 func NewMenuService(repo repository.MenuRepository) MenuService {
 	return &menuService{repo: repo}
 }
 
+// NewMenuServiceWithSystems нь ListByUserRoles-д зөвхөн идэвхтэй system-ийн
+// permission-тэй menu-г буцаадаг service үүсгэнэ (/me/menu)
+func NewMenuServiceWithSystems(repo repository.MenuRepository, systems SystemService) MenuService {
+	return &menuService{repo: repo, systems: systems}
+}
+
+// NewMenuServiceWithPermissions нь NewMenuServiceWithSystems дээр нэмээд
+// menu_permissions-ийн шаардлагыг бүрэн хангасан menu-г ListByUserRoles-д оруулна
+func NewMenuServiceWithPermissions(repo repository.MenuRepository, systems SystemService, permissions UserPermissionLister) MenuService {
+	return &menuService{repo: repo, systems: systems, permissions: permissions}
+}
+
 func (s *menuService) List(ctx context.Context, q dto.MenuListQuery) ([]domain.Menu, int64, int, int, error) {
 	return s.repo.List(ctx, q)
 }
@@ -46,7 +69,7 @@ func (s *menuService) ListAll(ctx context.Context) ([]domain.Menu, error) {
 
 func (s *menuService) ListByUserRoles(ctx context.Context, userID int) ([]domain.Menu, error) {
 	// Get menus by user roles
-	allMenus, err := s.repo.ListByUserRoles(ctx, userID)
+	allMenus, err := s.listPermissionMenus(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -103,82 +126,20 @@ func (s *menuService) ListByUserRoles(ctx context.Context, userID int) ([]domain
 	// Combine all menus using map to avoid duplicates
 	allMenusMap := make(map[int64]domain.Menu, len(allMenus)+len(parentMenus))
 	for _, menu := range allMenus {
-		menu.Children = nil
 		allMenusMap[menu.ID] = menu
 	}
 	for _, menu := range parentMenus {
 		if _, exists := allMenusMap[menu.ID]; !exists {
-			menu.Children = nil
 			allMenusMap[menu.ID] = menu
 		}
 	}
 
-	// Convert to slice and sort - O(n log n) instead of O(n²)
 	combinedMenus := make([]domain.Menu, 0, len(allMenusMap))
 	for _, menu := range allMenusMap {
 		combinedMenus = append(combinedMenus, menu)
 	}
-	sort.Slice(combinedMenus, func(i, j int) bool {
-		if combinedMenus[i].Sequence != combinedMenus[j].Sequence {
-			return combinedMenus[i].Sequence < combinedMenus[j].Sequence
-		}
-		return combinedMenus[i].ID < combinedMenus[j].ID
-	})
-
-	// Build tree in single pass using map of pointers
-	menuMap := make(map[int64]*domain.Menu, len(combinedMenus))
-	for i := range combinedMenus {
-		combinedMenus[i].Children = []domain.Menu{}
-		menuMap[combinedMenus[i].ID] = &combinedMenus[i]
-	}
-
-	// Attach children to parents (single pass, O(n))
-	var rootMenus []*domain.Menu
-	for i := range combinedMenus {
-		menu := &combinedMenus[i]
-		if menu.ParentID == nil {
-			rootMenus = append(rootMenus, menu)
-		} else if parent, exists := menuMap[*menu.ParentID]; exists {
-			parent.Children = append(parent.Children, *menu)
-		}
-	}
-
-	// Sort children recursively using sort.Slice - O(n log n)
-	var sortChildren func(children []domain.Menu)
-	sortChildren = func(children []domain.Menu) {
-		sort.Slice(children, func(i, j int) bool {
-			if children[i].Sequence != children[j].Sequence {
-				return children[i].Sequence < children[j].Sequence
-			}
-			return children[i].ID < children[j].ID
-		})
-		for i := range children {
-			if len(children[i].Children) > 0 {
-				sortChildren(children[i].Children)
-			}
-		}
-	}
-
-	// Sort root menus using sort.Slice
-	sort.Slice(rootMenus, func(i, j int) bool {
-		if rootMenus[i].Sequence != rootMenus[j].Sequence {
-			return rootMenus[i].Sequence < rootMenus[j].Sequence
-		}
-		return rootMenus[i].ID < rootMenus[j].ID
-	})
-
-	// Sort all children recursively
-	for _, menu := range rootMenus {
-		sortChildren(menu.Children)
-	}
 
-	// Convert to result slice
-	result := make([]domain.Menu, len(rootMenus))
-	for i, menu := range rootMenus {
-		result[i] = *menu
-	}
-
-	return result, nil
+	return util.BuildMenuTree(combinedMenus), nil
 }
 
 func (s *menuService) ByID(ctx context.Context, id int64) (domain.Menu, error) {
@@ -244,3 +205,128 @@ func (s *menuService) Update(ctx context.Context, id int64, req dto.MenuUpdateDt
 func (s *menuService) Delete(ctx context.Context, id int64) error {
 	return s.repo.Delete(ctx, id)
 }
+
+// SetPermissions нь menu-д шаардагдах permission-уудыг бүрэн солино (хоосон бол бүгдийг хасна).
+// Menu байхгүй бол ErrNotFound.
+func (s *menuService) SetPermissions(ctx context.Context, menuID int64, permissionIDs []int) error {
+	if _, err := s.repo.ByID(ctx, menuID); err != nil {
+		return err
+	}
+
+	// Давхардсан ID нь composite PK-г зөрчихөөс сэргийлнэ
+	ids := make([]int, 0, len(permissionIDs))
+	for _, id := range permissionIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return s.repo.SetPermissions(ctx, menuID, ids)
+}
+
+// Reorder нь drag-and-drop-оор өөрчилсөн дарааллыг нэг bulk UPDATE-аар хадгална.
+// ID давхардсан эсвэл sequence-ууд цоорхойтой/давхардсан бол ErrInvalidInput.
+func (s *menuService) Reorder(ctx context.Context, orders []dto.MenuReorderItem) error {
+	if err := validateMenuReorder(orders); err != nil {
+		return err
+	}
+	return s.repo.Reorder(ctx, orders)
+}
+
+// validateMenuReorder нь ID давхардаагүй, эрэмбэлсэн sequence-ууд хамгийн багаас
+// эхлэн 1-ээр нэмэгдэж байгааг (жишээ нь 3,4,5) шалгана
+func validateMenuReorder(orders []dto.MenuReorderItem) error {
+	if len(orders) == 0 {
+		return domain.NewInvalidInput("orders is required", nil)
+	}
+	ids := make(map[int64]struct{}, len(orders))
+	seqs := make([]int, 0, len(orders))
+	for _, o := range orders {
+		if _, dup := ids[o.ID]; dup {
+			return domain.NewInvalidInput(fmt.Sprintf("duplicate menu id %d", o.ID), nil)
+		}
+		ids[o.ID] = struct{}{}
+		seqs = append(seqs, o.Sequence)
+	}
+
+	slices.Sort(seqs)
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] != seqs[i-1]+1 {
+			return domain.NewInvalidInput(fmt.Sprintf("sequences must be consecutive without gaps or duplicates (got %d after %d)", seqs[i], seqs[i-1]), nil)
+		}
+	}
+	return nil
+}
+
+// listPermissionMenus нь хэрэглэгчийн role-оор олгогдсон menu болон
+// menu_permissions-ийн шаардлагыг хангасан menu-г буцаана.
+// systems тохируулсан бол идэвхгүй system-ийн menu-г хасна.
+func (s *menuService) listPermissionMenus(ctx context.Context, userID int) ([]domain.Menu, error) {
+	var (
+		menus     []domain.Menu
+		systemIDs []int
+		err       error
+	)
+	if s.systems == nil {
+		menus, err = s.repo.ListByUserRoles(ctx, userID)
+	} else {
+		active, lerr := s.systems.ListActive(ctx)
+		if lerr != nil {
+			return nil, lerr
+		}
+		systemIDs = make([]int, 0, len(active))
+		for _, sys := range active {
+			systemIDs = append(systemIDs, sys.ID)
+		}
+		menus, err = s.repo.ListByUserRolesInSystems(ctx, userID, systemIDs)
+	}
+	if err != nil || s.permissions == nil {
+		return menus, err
+	}
+
+	ids, err := s.requiredPermissionMenuIDs(ctx, userID, systemIDs)
+	if err != nil {
+		return nil, err
+	}
+	ids = slices.DeleteFunc(ids, func(id int64) bool {
+		return slices.ContainsFunc(menus, func(m domain.Menu) bool { return m.ID == id })
+	})
+	if len(ids) == 0 {
+		return menus, nil
+	}
+
+	extra, err := s.repo.GetMenusByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return append(menus, extra...), nil
+}
+
+// requiredPermissionMenuIDs нь menu_permissions-д бүртгэгдсэн бүх permission-ийг
+// хэрэглэгч эзэмшсэн menu-уудын ID-г өсөх дарааллаар буцаана. systemIDs nil биш бол
+// шаардагдах permission бүр идэвхтэй system-д хамаарах ёстой.
+func (s *menuService) requiredPermissionMenuIDs(ctx context.Context, userID int, systemIDs []int) ([]int64, error) {
+	links, err := s.repo.ListMenuPermissions(ctx)
+	if err != nil || len(links) == 0 {
+		return nil, err
+	}
+	codes, err := s.permissions.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	denied := make(map[int64]bool)
+	var ids []int64
+	for _, l := range links {
+		held := l.Permission != nil && slices.Contains(codes, l.Permission.Code) &&
+			(systemIDs == nil || slices.Contains(systemIDs, l.Permission.SystemID))
+		if !held {
+			denied[l.MenuID] = true
+		}
+		if !slices.Contains(ids, l.MenuID) {
+			ids = append(ids, l.MenuID)
+		}
+	}
+	ids = slices.DeleteFunc(ids, func(id int64) bool { return denied[id] })
+	slices.Sort(ids)
+	return ids, nil
+}
@@ -10,13 +10,19 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 
+	"templatev25/internal/auth"
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 
 	"templatev25/internal/repository"
+
+	"git.gerege.mn/backend-packages/ctx"
 )
 
 type ModuleService interface {
@@ -25,14 +31,37 @@ type ModuleService interface {
 	Create(ctx context.Context, req dto.ModuleCreateDto) error
 	Update(ctx context.Context, id int, req dto.ModuleUpdateDto) error
 	Delete(ctx context.Context, id int) error
+
+	// SetPermissionCleanup нь Delete-д модулийн permission-уудыг нэг transaction-д устгаж audit бичихээр тохируулна
+	SetPermissionCleanup(tx repository.TxManager, perms repository.PermissionRepository, audit repository.AuthRepository)
+	// SetCacheInvalidator нь permission устгасны дараа цэвэрлэх cache-ийг тохируулна
+	SetCacheInvalidator(cache auth.CacheInvalidator)
 }
 
-type moduleService struct{ repo repository.ModuleRepository }
+type moduleService struct {
+	repo repository.ModuleRepository
+
+	// Permission cascade (SetPermissionCleanup-ээр тохируулна)
+	tx    repository.TxManager
+	perms repository.PermissionRepository
+	audit repository.AuthRepository
+	cache auth.CacheInvalidator // optional
+}
 
 func NewModuleService(repo repository.ModuleRepository) ModuleService {
 	return &moduleService{repo: repo}
 }
 
+func (s *moduleService) SetPermissionCleanup(tx repository.TxManager, perms repository.PermissionRepository, audit repository.AuthRepository) {
+	s.tx = tx
+	s.perms = perms
+	s.audit = audit
+}
+
+func (s *moduleService) SetCacheInvalidator(cache auth.CacheInvalidator) {
+	s.cache = cache
+}
+
 func (s *moduleService) List(ctx context.Context, q dto.ModuleListQuery) ([]domain.Module, int64, int, int, error) {
 	return s.repo.List(ctx, q)
 }
@@ -77,5 +106,45 @@ func (s *moduleService) Delete(ctx context.Context, id int) error {
 	if existing.IsActive != nil && *existing.IsActive {
 		return errors.New("модуль идэвхитэй тул устгах боломжгүй")
 	}
-	return s.repo.Delete(ctx, id)
+	if s.tx == nil {
+		return s.repo.Delete(ctx, id)
+	}
+
+	// Permission-ууд эхэлж, дараа нь модуль — аль нэг нь амжилтгүй бол бүгд rollback
+	var deleted int64
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if deleted, err = s.perms.DeleteByModule(ctx, id); err != nil {
+			return fmt.Errorf("delete module permissions: %w", err)
+		}
+		return s.repo.Delete(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.auditModuleDelete(ctx, id, deleted)
+	if s.cache != nil && deleted > 0 {
+		s.cache.InvalidateAll()
+	}
+	return nil
+}
+
+// auditModuleDelete нь устгасан permission-ийн тоог security audit trail-д бичнэ
+func (s *moduleService) auditModuleDelete(uctx context.Context, id int, deleted int64) {
+	if s.audit == nil {
+		return
+	}
+	audit := &domain.SecurityAuditTrail{
+		Action:     string(domain.AuditActionModuleDelete),
+		TargetType: "module",
+		TargetID:   strconv.Itoa(id),
+	}
+	if uid, ok := ctx.GetValue[int](uctx, ctx.KeyUserID); ok {
+		audit.UserID = &uid
+	}
+	if b, err := json.Marshal(map[string]int64{"permissions_deleted": deleted}); err == nil {
+		audit.NewValue = string(b)
+	}
+	s.audit.CreateAuditTrail(uctx, audit)
 }
@@ -10,17 +10,51 @@ package service
 
 import (
 	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
 
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 
 	"templatev25/internal/repository"
+
+	"git.gerege.mn/backend-packages/common"
+)
+
+// News attachment (POST /news/:id/attachment)
+const (
+	// NewsAttachmentMaxSize нь зургийн дээд хэмжээ (5 MB)
+	NewsAttachmentMaxSize int64 = 5 * 1024 * 1024
+	// NewsAttachmentBucket нь FileRepository-д хадгалах bucket
+	NewsAttachmentBucket = "news"
 )
 
-type NewsService struct{ repo repository.NewsRepository }
+var (
+	ErrAttachmentTooLarge    = domain.NewInvalidInput("attachment exceeds 5 MB", nil)
+	ErrAttachmentUnsupported = domain.NewInvalidInput("attachment must be image/jpeg, image/png or image/webp", nil)
+)
+
+// newsAttachmentExt нь зөвшөөрөгдсөн MIME төрөл → хадгалах өргөтгөл
+var newsAttachmentExt = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+type NewsService struct {
+	repo  repository.NewsRepository
+	files repository.FileRepository
+}
 
 func NewNewsService(repo repository.NewsRepository) *NewsService { return &NewsService{repo: repo} }
 
+// NewNewsServiceWithFiles нь UploadAttachment-д файл хадгалах repository-тэй service үүсгэнэ
+func NewNewsServiceWithFiles(repo repository.NewsRepository, files repository.FileRepository) *NewsService {
+	return &NewsService{repo: repo, files: files}
+}
+
 func (s *NewsService) List(ctx context.Context, q dto.NewsListQuery) ([]domain.News, int64, int, int, error) {
 	return s.repo.List(ctx, q)
 }
@@ -31,18 +65,20 @@ func (s *NewsService) GetByID(ctx context.Context, id int) (domain.News, error)
 
 func (s *NewsService) Create(ctx context.Context, req dto.NewsDto) error {
 	m := domain.News{
-		Title:    req.Title,
-		Text:     req.Text,
-		ImageUrl: req.ImageUrl,
+		Title:      req.Title,
+		Text:       req.Text,
+		ImageUrl:   req.ImageUrl,
+		CategoryID: req.CategoryID,
 	}
 	return s.repo.Create(ctx, m)
 }
 
 func (s *NewsService) Update(ctx context.Context, id int, req dto.NewsDto) error {
 	m := domain.News{
-		Title:    req.Title,
-		Text:     req.Text,
-		ImageUrl: req.ImageUrl,
+		Title:      req.Title,
+		Text:       req.Text,
+		ImageUrl:   req.ImageUrl,
+		CategoryID: req.CategoryID,
 	}
 	return s.repo.Update(ctx, id, m)
 }
@@ -50,3 +86,94 @@ func (s *NewsService) Update(ctx context.Context, id int, req dto.NewsDto) error
 func (s *NewsService) Delete(ctx context.Context, id int) error {
 	return s.repo.Delete(ctx, id)
 }
+
+// UploadAttachment нь мэдээний зургийг хадгалж ImageUrl-ийг шинэчлээд хадгалсан URL-ийг буцаана.
+// Төрлийг header-ийн Content-Type биш файлын эхний байтаас тодорхойлно.
+// Мэдээ олдохгүй бол domain.ErrNotFound, хэмжээ/төрөл буруу бол ErrAttachmentTooLarge/ErrAttachmentUnsupported.
+func (s *NewsService) UploadAttachment(ctx context.Context, id int, header *multipart.FileHeader) (string, error) {
+	if s.files == nil {
+		return "", errors.New("file storage is not configured")
+	}
+	if header.Size > NewsAttachmentMaxSize {
+		return "", ErrAttachmentTooLarge
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	ext, ok := newsAttachmentExt[detectImageType(head[:n])]
+	if !ok {
+		return "", ErrAttachmentUnsupported
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return "", err
+	}
+
+	// Хэрэглэгчийн өгсөн нэрийн өргөтгөлийг (.html г.м.) ашиглахгүй
+	stored := *header
+	stored.Filename = "attachment" + ext
+	url, err := s.files.Upload(ctx, file, &stored, NewsAttachmentBucket)
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.Update(ctx, id, domain.News{ImageUrl: url}); err != nil {
+		return "", err
+	}
+	return url, nil
+}
+
+// detectImageType нь http.DetectContentType дээр WebP-г нэмж шалгана (stdlib WebP таньдаггүй)
+func detectImageType(head []byte) string {
+	if len(head) >= 12 && string(head[0:4]) == "RIFF" && string(head[8:12]) == "WEBP" {
+		return "image/webp"
+	}
+	return http.DetectContentType(head)
+}
+
+// Publish нь мэдээг нийтэлнэ (олдохгүй бол domain.ErrNotFound).
+func (s *NewsService) Publish(ctx context.Context, id int) error {
+	return s.repo.SetPublished(ctx, id, true)
+}
+
+// Unpublish нь мэдээг draft төлөвт буцаана (олдохгүй бол domain.ErrNotFound).
+func (s *NewsService) Unpublish(ctx context.Context, id int) error {
+	return s.repo.SetPublished(ctx, id, false)
+}
+
+type NewsCategoryService struct {
+	repo repository.NewsCategoryRepository
+}
+
+func NewNewsCategoryService(repo repository.NewsCategoryRepository) *NewsCategoryService {
+	return &NewsCategoryService{repo: repo}
+}
+
+func (s *NewsCategoryService) List(ctx context.Context, p common.PaginationQuery) ([]domain.NewsCategory, int64, int, int, error) {
+	return s.repo.List(ctx, p)
+}
+
+func (s *NewsCategoryService) Create(ctx context.Context, req dto.NewsCategoryDto) error {
+	return s.repo.Create(ctx, domain.NewsCategory{Name: req.Name, Code: req.Code})
+}
+
+// Update нь ангиллыг шинэчилнэ (олдохгүй бол domain.ErrNotFound).
+func (s *NewsCategoryService) Update(ctx context.Context, id int, req dto.NewsCategoryDto) error {
+	return s.repo.Update(ctx, id, domain.NewsCategory{Name: req.Name, Code: req.Code})
+}
+
+// Delete нь ангиллыг устгаж, холбогдох мэдээг ангилалгүй болгоно (олдохгүй бол domain.ErrNotFound).
+func (s *NewsCategoryService) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,127 @@
+// Package service provides implementation for service
+//
+// File: notification_hub.go
+// Description: In-memory registry of real-time notification connections
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// notificationWriteTimeout bounds a single push to one connection, so a slow or
+// stalled client cannot block delivery to the user's other connections
+const notificationWriteTimeout = 5 * time.Second
+
+// NotificationConn is a connection that can receive pushed notifications
+// (satisfied by *websocket.Conn).
+type NotificationConn interface {
+	WriteJSON(v interface{}) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// NotificationHub keeps track of connected users (userID -> connections)
+type NotificationHub struct {
+	conns sync.Map // int -> *hubEntry
+}
+
+// hubEntry holds all open connections of a single user
+type hubEntry struct {
+	mu    sync.Mutex
+	conns map[NotificationConn]*sync.Mutex // conn -> write lock
+	// dead is set once the entry is removed from the hub; Register retries
+	// with a fresh entry instead of adding to an orphaned one
+	dead bool
+}
+
+// NewNotificationHub creates an empty connection registry
+func NewNotificationHub() *NotificationHub {
+	return &NotificationHub{}
+}
+
+// Register adds a connection for the user
+func (h *NotificationHub) Register(userID int, conn NotificationConn) {
+	for {
+		v, _ := h.conns.LoadOrStore(userID, &hubEntry{conns: map[NotificationConn]*sync.Mutex{}})
+		e := v.(*hubEntry)
+		e.mu.Lock()
+		if e.dead {
+			// Unregister removed this entry concurrently; load again
+			e.mu.Unlock()
+			continue
+		}
+		e.conns[conn] = &sync.Mutex{}
+		e.mu.Unlock()
+		return
+	}
+}
+
+// Unregister removes a connection of the user
+func (h *NotificationHub) Unregister(userID int, conn NotificationConn) {
+	v, ok := h.conns.Load(userID)
+	if !ok {
+		return
+	}
+	e := v.(*hubEntry)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.conns, conn)
+	if len(e.conns) == 0 && !e.dead {
+		e.dead = true
+		h.conns.CompareAndDelete(userID, e)
+	}
+}
+
+// Connected reports whether the user has at least one open connection
+func (h *NotificationHub) Connected(userID int) bool {
+	v, ok := h.conns.Load(userID)
+	if !ok {
+		return false
+	}
+	e := v.(*hubEntry)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.conns) > 0
+}
+
+// Send writes v to every connection of the user.
+// Returns true if at least one connection received the message.
+// Connections that fail to write (including a write deadline timeout) are
+// dropped from the registry.
+//
+// Writes happen outside the entry lock, so Register/Unregister are never
+// blocked by a slow client; each connection's own lock keeps its writes serial.
+func (h *NotificationHub) Send(userID int, v interface{}) bool {
+	val, ok := h.conns.Load(userID)
+	if !ok {
+		return false
+	}
+	e := val.(*hubEntry)
+
+	e.mu.Lock()
+	targets := make(map[NotificationConn]*sync.Mutex, len(e.conns))
+	for conn, wmu := range e.conns {
+		targets[conn] = wmu
+	}
+	e.mu.Unlock()
+
+	delivered := false
+	for conn, wmu := range targets {
+		if err := writeNotification(conn, wmu, v); err != nil {
+			h.Unregister(userID, conn)
+			continue
+		}
+		delivered = true
+	}
+	return delivered
+}
+
+// writeNotification writes v to conn under its write lock with a deadline
+func writeNotification(conn NotificationConn, wmu *sync.Mutex, v interface{}) error {
+	wmu.Lock()
+	defer wmu.Unlock()
+	if err := conn.SetWriteDeadline(time.Now().Add(notificationWriteTimeout)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(v)
+}
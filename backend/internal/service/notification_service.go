@@ -16,6 +16,7 @@ import (
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 	"templatev25/internal/repository"
+	"templatev25/internal/requestctx"
 
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/config"
@@ -29,6 +30,7 @@ type NotificationService struct {
 	repo repository.NotificationRepository
 	http *httpx.Client
 	cfg  *config.Config
+	hub  *NotificationHub // WebSocket холболтууд (GET /notification/ws)
 }
 
 func NewNotificationService(repo repository.NotificationRepository, cfg *config.Config) *NotificationService {
@@ -36,17 +38,39 @@ func NewNotificationService(repo repository.NotificationRepository, cfg *config.
 		repo: repo,
 		http: httpx.New(3 * time.Second),
 		cfg:  cfg,
+		hub:  NewNotificationHub(),
 	}
 }
 
+// Hub нь WebSocket холболтын registry-г буцаана.
+func (s *NotificationService) Hub() *NotificationHub {
+	return s.hub
+}
+
+// Push нь хэрэглэгч WebSocket-оор холбогдсон бол мэдэгдлийг шууд илгээнэ,
+// холбогдоогүй бол database-д хадгална (дараа нь GET /notification-оор авна).
+func (s *NotificationService) Push(ctx context.Context, userID int, n domain.Notification) error {
+	n.UserId = userID
+	n.TenantID = tenantOf(ctx, n.TenantID)
+	if s.hub.Send(userID, n) {
+		return nil
+	}
+	_, err := s.repo.CreateNotification(ctx, n)
+	return err
+}
+
 // getSocketAPIBase returns the socket API base URL
 // TODO: Add Socket field to config.URLConfig when available
 func (s *NotificationService) getSocketAPIBase() string {
 	return defaultSocketAPIBase
 }
 
-// List for current user
+// List for current user. Request-д tenant (SERVER_TENANT_ID) байвал зөвхөн тухайн
+// tenant-ийн мэдэгдлийг буцаана.
 func (s *NotificationService) List(ctx context.Context, userID int, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error) {
+	if tenantID := tenantOf(ctx, ""); tenantID != "" {
+		return s.repo.ListByTenant(ctx, tenantID, userID, p)
+	}
 	return s.repo.ListByUser(ctx, userID, p)
 }
 
@@ -59,18 +83,59 @@ func (s *NotificationService) MarkGroupRead(ctx context.Context, userID, groupID
 }
 
 func (s *NotificationService) MarkAllRead(ctx context.Context, userID int) error {
-	return s.repo.MarkAllRead(ctx, userID)
+	_, err := s.repo.MarkAllRead(ctx, userID)
+	return err
+}
+
+// MarkReadBatch нь req.All бол бүх, эс бөгөөс req.Ids мэдэгдлүүдийг уншсан болгоно.
+// Өөрчлөгдсөн мөрийн тоог буцаана. Аль аль нь хоосон бол domain.ErrInvalidInput.
+func (s *NotificationService) MarkReadBatch(ctx context.Context, userID int, req dto.NotificationReadBatchDto) (int64, error) {
+	if req.All {
+		return s.repo.MarkAllRead(ctx, userID)
+	}
+	if len(req.Ids) == 0 {
+		return 0, domain.NewInvalidInput("ids or all is required", nil)
+	}
+	return s.repo.MarkReadByIDs(ctx, userID, req.Ids)
+}
+
+// Stats нь хэрэглэгчийн уншаагүй мэдэгдлийн тоог type-аар болон нийтээр буцаана
+func (s *NotificationService) Stats(ctx context.Context, userID int) (dto.NotificationStatsResponse, error) {
+	byType, err := s.repo.CountByType(ctx, userID)
+	if err != nil {
+		return dto.NotificationStatsResponse{}, err
+	}
+
+	var total int64
+	for _, n := range byType {
+		total += n
+	}
+	return dto.NotificationStatsResponse{
+		TotalUnread: total,
+		ByType:      byType,
+		HasUnread:   total > 0,
+	}, nil
+}
+
+// UnreadCount нь хэрэглэгчийн уншаагүй мэдэгдлийн тоог буцаана (repository талд 5 секунд cache-тэй)
+func (s *NotificationService) UnreadCount(ctx context.Context, userID int) (int64, error) {
+	return s.repo.UnreadCount(ctx, userID)
 }
 
 // Send: if UserID==0 => broadcast_all, else direct (dm)
 func (s *NotificationService) Send(ctx context.Context, req dto.NotificationSendDto, createdUsername string) error {
+	tenantID, err := requestTenant(ctx, req.Tenant)
+	if err != nil {
+		return err
+	}
+
 	// 1) Create group
 	group := domain.NotificationGroup{
 		UserId:  req.UserID,
 		Title:   req.Title,
 		Content: req.Content,
 		Type:    typeOf(req.UserID), // "dm" | "broadcast_all"
-		Tenant:  req.Tenant,
+		Tenant:  tenantID,
 		// CreatedUserId:   createdBy,
 		CreatedUsername: createdUsername,
 	}
@@ -82,13 +147,13 @@ func (s *NotificationService) Send(ctx context.Context, req dto.NotificationSend
 	if req.UserID != 0 {
 		// 2a) Direct notification
 		n := domain.Notification{
-			UserId:  req.UserID,
-			Title:   req.Title,
-			Content: req.Content,
-			IsRead:  false,
-			Type:    "dm",
-			Tenant:  req.Tenant,
-			GroupId: g.Id,
+			UserId:   req.UserID,
+			Title:    req.Title,
+			Content:  req.Content,
+			IsRead:   false,
+			Type:     "dm",
+			TenantID: tenantID,
+			GroupId:  g.Id,
 			// CreatedUserId:   createdBy,
 			CreatedUsername: createdUsername,
 		}
@@ -107,16 +172,16 @@ func (s *NotificationService) Send(ctx context.Context, req dto.NotificationSend
 
 	// 2b) Broadcast: call socket first
 	bcast := domain.Notification{
-		UserId:  0,
-		Title:   req.Title,
-		Content: req.Content,
-		IsRead:  false,
-		Tenant:  req.Tenant,
-		Type:    "broadcast_all",
-		GroupId: g.Id,
+		UserId:   0,
+		Title:    req.Title,
+		Content:  req.Content,
+		IsRead:   false,
+		TenantID: tenantID,
+		Type:     "broadcast_all",
+		GroupId:  g.Id,
 	}
 	body := map[string]any{
-		"tenant":          req.Tenant,
+		"tenant":          tenantID,
 		"idempotency_key": req.IdempotentKey,
 		"body":            bcast,
 	}
@@ -132,13 +197,13 @@ func (s *NotificationService) Send(ctx context.Context, req dto.NotificationSend
 	bulk := make([]domain.Notification, 0, len(ids))
 	for _, uid := range ids {
 		bulk = append(bulk, domain.Notification{
-			UserId:  uid,
-			Title:   req.Title,
-			Content: req.Content,
-			IsRead:  false,
-			Tenant:  req.Tenant,
-			Type:    "broadcast_all",
-			GroupId: g.Id,
+			UserId:   uid,
+			Title:    req.Title,
+			Content:  req.Content,
+			IsRead:   false,
+			TenantID: tenantID,
+			Type:     "broadcast_all",
+			GroupId:  g.Id,
 			// CreatedUserId:   createdBy,
 			CreatedUsername: createdUsername,
 		})
@@ -146,6 +211,91 @@ func (s *NotificationService) Send(ctx context.Context, req dto.NotificationSend
 	return s.repo.CreateNotificationsBulk(ctx, bulk)
 }
 
+// defaultGroupNotificationType нь NotificationGroupDto.Type хоосон үеийн type
+const defaultGroupNotificationType = "info"
+
+// CreateGroup нь нэг NotificationGroup болон req.UserIDs-ийн хэрэглэгч бүрт Notification
+// үүсгэнэ (нэг transaction, CreateInBatches). WebSocket-оор холбогдсон хэрэглэгчид шууд илгээнэ.
+func (s *NotificationService) CreateGroup(ctx context.Context, req dto.NotificationGroupDto, createdUsername string) error {
+	if len(req.UserIDs) == 0 {
+		return domain.NewInvalidInput("user_ids is required", nil)
+	}
+	typ := req.Type
+	if typ == "" {
+		typ = defaultGroupNotificationType
+	}
+
+	tenantID := tenantOf(ctx, "")
+	seen := make(map[int]bool, len(req.UserIDs))
+	ids := make([]int, 0, len(req.UserIDs))
+	for _, uid := range req.UserIDs {
+		if !seen[uid] {
+			seen[uid] = true
+			ids = append(ids, uid)
+		}
+	}
+
+	// Байхгүй хэрэглэгч FK зөрчиж 500 болохоос өмнө 400-аар татгалзана
+	missing, err := s.repo.MissingUserIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return domain.NewInvalidInput(fmt.Sprintf("unknown user_ids: %v", missing), nil)
+	}
+
+	bulk := make([]domain.Notification, 0, len(ids))
+	for _, uid := range ids {
+		bulk = append(bulk, domain.Notification{
+			UserId:          uid,
+			Title:           req.Title,
+			Content:         req.Content,
+			Type:            typ,
+			TenantID:        tenantID,
+			CreatedUsername: createdUsername,
+		})
+	}
+
+	group := domain.NotificationGroup{
+		Title:           req.Title,
+		Content:         req.Content,
+		Type:            typ,
+		Tenant:          tenantID,
+		CreatedUsername: createdUsername,
+	}
+	if _, err := s.repo.CreateGroupWithNotifications(ctx, group, bulk); err != nil {
+		return err
+	}
+
+	for _, n := range bulk {
+		s.hub.Send(n.UserId, n)
+	}
+	return nil
+}
+
+// tenantOf нь middleware.Tenant-ийн тавьсан tenant ID-г буцаана (SERVER_TENANT_ID).
+// Тохируулаагүй бол fallback (жишээ нь хүсэлтийн tenant) ашиглагдана.
+func tenantOf(ctx context.Context, fallback string) string {
+	if id := requestctx.GetTenantID(ctx); id != "" {
+		return id
+	}
+	return fallback
+}
+
+// requestTenant нь хүсэлтийн tenant-ийг SERVER_TENANT_ID-тэй тулгана. Server tenant
+// тохируулсан үед өөр tenant ирвэл domain.ErrInvalidInput (400) буцаана — group болон
+// мэдэгдлүүд үргэлж нэг tenant-тай хадгалагдана.
+func requestTenant(ctx context.Context, requested string) (string, error) {
+	server := requestctx.GetTenantID(ctx)
+	if server == "" {
+		return requested, nil
+	}
+	if requested != "" && requested != server {
+		return "", domain.NewInvalidInput(fmt.Sprintf("tenant %q does not match the server tenant", requested), nil)
+	}
+	return server, nil
+}
+
 func typeOf(userID int) string {
 	if userID == 0 {
 		return "broadcast_all"
@@ -0,0 +1,60 @@
+// Package service provides implementation for service
+//
+// File: notification_subscriber.go
+// Description: Event bus subscriber that sends user notifications
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/event"
+
+	"go.uber.org/zap"
+)
+
+// notificationEventTimeout нь нэг event-ийн мэдэгдэл хадгалах хугацааны дээд хязгаар
+const notificationEventTimeout = 5 * time.Second
+
+// NotificationSubscriber нь bus-ийн event-үүдийг хэрэглэгчийн мэдэгдэл болгоно.
+type NotificationSubscriber struct {
+	notifications *NotificationService
+	log           *zap.Logger
+}
+
+func NewNotificationSubscriber(notifications *NotificationService, log *zap.Logger) *NotificationSubscriber {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return &NotificationSubscriber{notifications: notifications, log: log}
+}
+
+// Register нь subscriber-ийн handler-уудыг bus-д бүртгэнэ.
+func (s *NotificationSubscriber) Register(bus event.EventBus) {
+	bus.Subscribe(event.TypeUserAddedToOrg, s.onUserAddedToOrg)
+}
+
+// onUserAddedToOrg нь байгууллагад нэмэгдсэн хэрэглэгчид мэндчилгээний мэдэгдэл илгээнэ.
+func (s *NotificationSubscriber) onUserAddedToOrg(e event.Event) {
+	ev, ok := e.(event.UserAddedToOrgEvent)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), notificationEventTimeout)
+	defer cancel()
+
+	n := domain.Notification{
+		Title:   "Байгууллагад нэмэгдлээ",
+		Content: fmt.Sprintf("Таныг #%d байгууллагад нэмлээ. Тавтай морил!", ev.OrgID),
+		Type:    "dm",
+	}
+	if err := s.notifications.Push(ctx, ev.UserID, n); err != nil {
+		s.log.Error("welcome_notification_failed",
+			zap.Int("org_id", ev.OrgID),
+			zap.Int("user_id", ev.UserID),
+			zap.Error(err))
+	}
+}
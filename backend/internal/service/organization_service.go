@@ -10,27 +10,51 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"slices"
+	"strconv"
+	"time"
 
+	"templatev25/internal/auth"
 	"templatev25/internal/domain"
+	"templatev25/internal/event"
 	"templatev25/internal/http/dto"
 	"templatev25/internal/repository"
 
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/config"
+	"git.gerege.mn/backend-packages/ctx"
 	"git.gerege.mn/backend-packages/httpx"
 	"git.gerege.mn/backend-packages/utils"
 	"go.uber.org/zap"
 )
 
 type OrganizationService struct {
-	repo repository.OrganizationRepository
-	log  *zap.Logger
+	repo  repository.OrganizationRepository
+	audit repository.AuthRepository // security audit trail
+	log   *zap.Logger
+	cache auth.CacheInvalidator // Permission cache invalidation (optional)
 }
 
-func NewOrganizationService(repo repository.OrganizationRepository, log *zap.Logger) *OrganizationService {
-	return &OrganizationService{repo: repo, log: log}
+func NewOrganizationService(repo repository.OrganizationRepository, audit repository.AuthRepository, log *zap.Logger) *OrganizationService {
+	return &OrganizationService{repo: repo, audit: audit, log: log}
+}
+
+// SetCacheInvalidator нь permission cache invalidator-ийг тохируулна.
+// Байгууллагын төрөл солигдох, устгах, сэргээхэд гишүүдийн төрлөөс авсан
+// permission өөрчлөгдөх тул cache бүхэлдээ хүчингүй болно.
+func (s *OrganizationService) SetCacheInvalidator(cache auth.CacheInvalidator) {
+	s.cache = cache
+}
+
+// invalidatePermissions нь cache тохируулсан бол бүх хэрэглэгчийн permission cache-ийг цэвэрлэнэ
+func (s *OrganizationService) invalidatePermissions() {
+	if s.cache != nil {
+		s.cache.InvalidateAll()
+	}
 }
 
 func (s *OrganizationService) List(ctx context.Context, p common.PaginationQuery) ([]domain.Organization, int64, int, int, error) {
@@ -117,12 +141,25 @@ func (s *OrganizationService) Update(ctx context.Context, id int, req dto.Organi
 		ParentAddressName: req.ParentAddressName,
 		CountryNameEn:     req.CountryNameEn,
 		ParentId:          req.ParentID,
+		Version:           req.Version,
+	}
+	// Төрөл солигдвол гишүүдийн төрлөөс авсан permission өөрчлөгдөнө — хуучин төрлийг харьцуулахаар уншина
+	oldTypeID := m.TypeId
+	if s.cache != nil {
+		before, err := s.repo.ByID(ctx, id)
+		if err != nil {
+			return domain.Organization{}, err
+		}
+		oldTypeID = before.TypeId
 	}
 	org, err := s.repo.Update(ctx, id, m)
 	if err != nil {
 		s.log.Error("organization_update_failed", zap.Int("org_id", id), zap.Error(err))
 		return domain.Organization{}, err
 	}
+	if oldTypeID != m.TypeId {
+		s.invalidatePermissions()
+	}
 	s.log.Info("organization_updated", zap.Int("org_id", id))
 	return org, nil
 }
@@ -132,6 +169,7 @@ func (s *OrganizationService) Delete(ctx context.Context, id int) error {
 		s.log.Error("organization_delete_failed", zap.Int("org_id", id), zap.Error(err))
 		return err
 	}
+	s.invalidatePermissions()
 	s.log.Info("organization_deleted", zap.Int("org_id", id))
 	return nil
 }
@@ -145,22 +183,208 @@ func (s *OrganizationService) ByID(ctx context.Context, id int) (domain.Organiza
 	return org, nil
 }
 
+// GetByID нь GET /organization/:id-ийн дэлгэрэнгүй (төрлийн нэр, хүүхэд, хэрэглэгчийн тоо)
+func (s *OrganizationService) GetByID(ctx context.Context, id int) (dto.OrganizationDetail, error) {
+	detail, err := s.repo.ByIDWithDetail(ctx, id)
+	if err != nil {
+		s.log.Error("organization_get_detail_failed", zap.Int("org_id", id), zap.Error(err))
+		return dto.OrganizationDetail{}, err
+	}
+	return detail, nil
+}
+
+func (s *OrganizationService) Exists(ctx context.Context, id int) (bool, error) {
+	ok, err := s.repo.Exists(ctx, id)
+	if err != nil {
+		s.log.Error("organization_exists_failed", zap.Int("org_id", id), zap.Error(err))
+		return false, err
+	}
+	return ok, nil
+}
+
+// Search нь байгууллагыг search_vector-оор (нэр, код, регистр) full-text хайна.
+func (s *OrganizationService) Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.Organization, int64, int, int, error) {
+	items, total, page, size, err := s.repo.Search(ctx, query, p)
+	if err != nil {
+		s.log.Error("organization_search_failed", zap.String("query", query), zap.Error(err))
+		return nil, 0, 0, 0, err
+	}
+	return items, total, page, size, nil
+}
+
+// Stats нь байгууллагын төрөл тус бүрийн тоог буцаана (dashboard)
+func (s *OrganizationService) Stats(ctx context.Context) ([]dto.OrgTypeStats, error) {
+	stats, err := s.repo.Stats(ctx)
+	if err != nil {
+		s.log.Error("organization_stats_failed", zap.Error(err))
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Tree нь rootID-аас эхэлсэн бүх түвшний байгууллагыг Children-ээр үүрлэсэн мод болгож
+// (нэг элементтэй slice) буцаана. Root олдохгүй (эсвэл устгагдсан) бол domain.ErrNotFound.
 func (s *OrganizationService) Tree(ctx context.Context, rootID int) ([]domain.Organization, error) {
-	items, err := s.repo.Tree(ctx, rootID)
+	items, err := s.repo.TreeCTE(ctx, rootID)
 	if err != nil {
 		s.log.Error("organization_tree_failed", zap.Int("root_id", rootID), zap.Error(err))
 		return nil, err
 	}
+	if len(items) == 0 {
+		return nil, domain.NewNotFound("organization not found", nil)
+	}
 	s.log.Debug("organization_tree_fetched", zap.Int("root_id", rootID), zap.Int("count", len(items)))
-	return items, nil
+	return []domain.Organization{buildOrgTree(items, rootID)}, nil
+}
+
+// buildOrgTree нь хавтгай жагсаалтаас (CTE-ийн дараалал хадгалагдана) rootID-тай
+// байгууллагыг Children-ээр нь үүрлэж угсарна. Хүүхэдгүй node-ийн Children nil байна.
+//
+// parent_id-д цикл байвал CTE ижил мөрийг олон удаа буцаадаг тул id бүрийг нэг л
+// удаа (хамгийн бага гүнд) авч, мод угсрахдаа аль хэдийн орсон node-ийг алгасна.
+func buildOrgTree(items []domain.Organization, rootID int) domain.Organization {
+	byParent := make(map[int][]domain.Organization, len(items))
+	seen := make(map[int]bool, len(items))
+	var root domain.Organization
+	for _, o := range items {
+		if seen[o.Id] {
+			continue
+		}
+		seen[o.Id] = true
+		if o.Id == rootID {
+			root = o
+			continue
+		}
+		if o.ParentId != nil {
+			byParent[*o.ParentId] = append(byParent[*o.ParentId], o)
+		}
+	}
+
+	visited := make(map[int]bool, len(items))
+	var attach func(node *domain.Organization)
+	attach = func(node *domain.Organization) {
+		visited[node.Id] = true
+		var children []domain.Organization
+		for _, c := range byParent[node.Id] {
+			if !visited[c.Id] {
+				children = append(children, c)
+			}
+		}
+		if len(children) == 0 {
+			node.Children = nil
+			return
+		}
+		for i := range children {
+			visited[children[i].Id] = true
+		}
+		for i := range children {
+			attach(&children[i])
+		}
+		node.Children = &children
+	}
+	attach(&root)
+	return root
+}
+
+// MoveToParent нь байгууллагыг (дэд модтой нь) шинэ parent-ийн доор шилжүүлж, security audit trail-д
+// хуучин болон шинэ parent_id-г бичнэ. newParentID == 0 бол root болгоно.
+// Цикл үүсэх бол domain.ErrInvalidInput, байгууллага/parent олдохгүй бол domain.ErrNotFound буцаана.
+func (s *OrganizationService) MoveToParent(c context.Context, id, newParentID int) error {
+	old, err := s.repo.ByID(c, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.MoveToParent(c, id, newParentID); err != nil {
+		s.log.Error("organization_move_failed", zap.Int("org_id", id), zap.Int("parent_id", newParentID), zap.Error(err))
+		return err
+	}
+
+	var newParent *int
+	if newParentID != 0 {
+		newParent = &newParentID
+	}
+	oldJSON, _ := json.Marshal(map[string]*int{"parent_id": old.ParentId})
+	newJSON, _ := json.Marshal(map[string]*int{"parent_id": newParent})
+	audit := &domain.SecurityAuditTrail{
+		Action:     "ORG_PARENT_CHANGED",
+		TargetType: "organization",
+		TargetID:   strconv.Itoa(id),
+		OldValue:   string(oldJSON),
+		NewValue:   string(newJSON),
+	}
+	if userID, ok := ctx.GetValue[int](c, ctx.KeyUserID); ok {
+		audit.UserID = &userID
+	}
+	if err := s.audit.CreateAuditTrail(c, audit); err != nil {
+		// Audit алдаа нь шилжүүлэлтийг буцаахгүй
+		s.log.Error("organization_move_audit_failed", zap.Int("org_id", id), zap.Error(err))
+	}
+
+	s.log.Info("organization_moved", zap.Int("org_id", id), zap.Int("parent_id", newParentID))
+	return nil
+}
+
+// Restore нь soft delete хийгдсэн байгууллагыг сэргээж, security audit trail-д бичнэ.
+// Байгууллага устгагдаагүй бол domain.ErrConflict, огт байхгүй (hard delete хийгдсэн) бол
+// domain.ErrNotFound буцаана. Амжилттай бол сэргээгдсэн байгууллагыг буцаана.
+func (s *OrganizationService) Restore(c context.Context, id int) (domain.Organization, error) {
+	deleted, err := s.repo.DeletedByID(c, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			s.log.Error("organization_restore_lookup_failed", zap.Int("org_id", id), zap.Error(err))
+			return domain.Organization{}, err
+		}
+		exists, exErr := s.repo.Exists(c, id)
+		if exErr != nil {
+			return domain.Organization{}, exErr
+		}
+		if exists {
+			return domain.Organization{}, domain.NewConflict("organization is not deleted", nil)
+		}
+		return domain.Organization{}, err
+	}
+
+	if err := s.repo.Restore(c, id); err != nil {
+		s.log.Error("organization_restore_failed", zap.Int("org_id", id), zap.Error(err))
+		return domain.Organization{}, err
+	}
+	s.invalidatePermissions()
+
+	oldJSON, _ := json.Marshal(map[string]any{"deleted_date": deleted.DeletedDate.Time})
+	audit := &domain.SecurityAuditTrail{
+		Action:     "ORG_RESTORED",
+		TargetType: "organization",
+		TargetID:   strconv.Itoa(id),
+		OldValue:   string(oldJSON),
+		NewValue:   `{"deleted_date":null}`,
+	}
+	if userID, ok := ctx.GetValue[int](c, ctx.KeyUserID); ok {
+		audit.UserID = &userID
+	}
+	if err := s.audit.CreateAuditTrail(c, audit); err != nil {
+		// Audit алдаа нь сэргээлтийг буцаахгүй
+		s.log.Error("organization_restore_audit_failed", zap.Int("org_id", id), zap.Error(err))
+	}
+
+	s.log.Info("organization_restored", zap.Int("org_id", id))
+	return s.repo.ByID(c, id)
 }
 
 type OrganizationTypeService struct {
-	repo repository.OrganizationTypeRepository
+	repo  repository.OrganizationTypeRepository
+	audit repository.AuthRepository // security audit trail
+	log   *zap.Logger
+	cache auth.CacheInvalidator // Permission cache invalidation (optional)
+}
+
+// SetCacheInvalidator нь permission cache invalidator-ийг тохируулна.
+// Төрлийн permission өөрчлөгдөхөд тухайн төрлийн бүх гишүүний cache хүчингүй болно.
+func (s *OrganizationTypeService) SetCacheInvalidator(cache auth.CacheInvalidator) {
+	s.cache = cache
 }
 
-func NewOrganizationTypeService(repo repository.OrganizationTypeRepository) *OrganizationTypeService {
-	return &OrganizationTypeService{repo: repo}
+func NewOrganizationTypeService(repo repository.OrganizationTypeRepository, audit repository.AuthRepository, log *zap.Logger) *OrganizationTypeService {
+	return &OrganizationTypeService{repo: repo, audit: audit, log: log}
 }
 
 func (s *OrganizationTypeService) List(ctx context.Context, p common.PaginationQuery) ([]domain.OrganizationType, int64, int, int, error) {
@@ -169,20 +393,52 @@ func (s *OrganizationTypeService) List(ctx context.Context, p common.PaginationQ
 
 func (s *OrganizationTypeService) Create(ctx context.Context, req dto.OrganizationTypeDto) error {
 	m := domain.OrganizationType{
-		Code:        req.Code,
-		Name:        req.Name,
-		Description: req.Description,
+		Code:             req.Code,
+		Name:             req.Name,
+		Description:      req.Description,
+		EnforceRoleLimit: req.EnforceRoleLimit,
 	}
 	return s.repo.Create(ctx, m)
 }
 
 func (s *OrganizationTypeService) Update(ctx context.Context, id int, req dto.OrganizationTypeDto) error {
-	m := domain.OrganizationType{Code: req.Code, Name: req.Name, Description: req.Description}
+	m := domain.OrganizationType{Code: req.Code, Name: req.Name, Description: req.Description, EnforceRoleLimit: req.EnforceRoleLimit}
 	return s.repo.Update(ctx, id, m)
 }
 
-func (s *OrganizationTypeService) Delete(ctx context.Context, id int) error {
-	return s.repo.Delete(ctx, id)
+// Delete нь байгууллагын төрлийг soft delete хийж, security audit trail-д бичнэ.
+// reason хоосон байж болно.
+func (s *OrganizationTypeService) Delete(c context.Context, id int, reason string) error {
+	old, err := s.repo.ByID(c, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(c, id, reason); err != nil {
+		s.log.Error("org_type_delete_failed", zap.Int("type_id", id), zap.Error(err))
+		return err
+	}
+
+	oldJSON, _ := json.Marshal(old)
+	audit := &domain.SecurityAuditTrail{
+		Action:     "ORG_TYPE_DELETED",
+		TargetType: "org_type",
+		TargetID:   strconv.Itoa(id),
+		OldValue:   string(oldJSON),
+	}
+	if reason != "" {
+		newJSON, _ := json.Marshal(map[string]string{"reason": reason})
+		audit.NewValue = string(newJSON)
+	}
+	if userID, ok := ctx.GetValue[int](c, ctx.KeyUserID); ok {
+		audit.UserID = &userID
+	}
+	if err := s.audit.CreateAuditTrail(c, audit); err != nil {
+		// Audit алдаа нь устгалтыг буцаахгүй
+		s.log.Error("org_type_delete_audit_failed", zap.Int("type_id", id), zap.Error(err))
+	}
+
+	s.log.Info("org_type_deleted", zap.Int("type_id", id), zap.String("reason", reason))
+	return nil
 }
 
 func (s *OrganizationTypeService) Systems(ctx context.Context, typeID int) ([]domain.System, error) {
@@ -201,11 +457,77 @@ func (s *OrganizationTypeService) AddRoles(ctx context.Context, typeID int, role
 	return s.repo.AddRoles(ctx, typeID, roleIDs)
 }
 
+// Permissions нь байгууллагын төрөлд шууд олгосон permission-уудыг буцаана.
+// Төрөл байхгүй бол ErrNotFound.
+func (s *OrganizationTypeService) Permissions(ctx context.Context, typeID int) ([]domain.Permission, error) {
+	if _, err := s.repo.ByID(ctx, typeID); err != nil {
+		return nil, err
+	}
+	return s.repo.Permissions(ctx, typeID)
+}
+
+// SetPermissions нь төрлийн permission-уудыг permissionIDs-ээр бүрэн солино (хоосон бол бүгдийг хасна).
+// Төрөл байхгүй бол ErrNotFound. Амжилттай бол permission cache-ийг бүхэлд нь цэвэрлэж
+// (аль хэрэглэгч тухайн төрлийн гишүүн болохыг cache мэдэхгүй), security audit trail-д бичнэ.
+func (s *OrganizationTypeService) SetPermissions(c context.Context, typeID int, permissionIDs []int) error {
+	if _, err := s.repo.ByID(c, typeID); err != nil {
+		return err
+	}
+	old, err := s.repo.Permissions(c, typeID)
+	if err != nil {
+		return err
+	}
+
+	// Давхардсан ID нь composite PK-г зөрчихөөс сэргийлнэ
+	ids := make([]int, 0, len(permissionIDs))
+	for _, id := range permissionIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if err := s.repo.AddPermissions(c, typeID, ids); err != nil {
+		s.log.Error("org_type_permissions_set_failed", zap.Int("type_id", typeID), zap.Error(err))
+		return err
+	}
+	if s.cache != nil {
+		s.cache.InvalidateAll()
+	}
+
+	oldIDs := make([]int, 0, len(old))
+	for _, p := range old {
+		oldIDs = append(oldIDs, p.ID)
+	}
+	oldJSON, _ := json.Marshal(map[string][]int{"permission_ids": oldIDs})
+	newJSON, _ := json.Marshal(map[string][]int{"permission_ids": ids})
+	audit := &domain.SecurityAuditTrail{
+		Action:     "ORG_TYPE_PERMISSIONS_SET",
+		TargetType: "org_type",
+		TargetID:   strconv.Itoa(typeID),
+		OldValue:   string(oldJSON),
+		NewValue:   string(newJSON),
+	}
+	if userID, ok := ctx.GetValue[int](c, ctx.KeyUserID); ok {
+		audit.UserID = &userID
+	}
+	if err := s.audit.CreateAuditTrail(c, audit); err != nil {
+		s.log.Error("org_type_permissions_audit_failed", zap.Int("type_id", typeID), zap.Error(err))
+	}
+	return nil
+}
+
 type OrgUserService struct {
-	repo  repository.OrgUserRepository
-	urepo repository.UserRepository
-	http  *httpx.Client
-	cfg   *config.Config
+	repo   repository.OrgUserRepository
+	urepo  repository.UserRepository
+	http   *httpx.Client
+	cfg    *config.Config
+	events event.EventBus // nil бол event publish хийхгүй
+
+	// Role limit (nil бол шалгахгүй)
+	orgs      repository.OrganizationRepository
+	orgTypes  repository.OrganizationTypeRepository
+	userRoles repository.UserRoleRepository
+
+	cache auth.CacheInvalidator // Permission cache invalidation (optional)
 }
 
 func NewOrgUserService(repo repository.OrgUserRepository, cfg *config.Config, urepo repository.UserRepository) *OrgUserService {
@@ -217,6 +539,25 @@ func NewOrgUserService(repo repository.OrgUserRepository, cfg *config.Config, ur
 	}
 }
 
+// SetEventBus нь Add амжилттай болсны дараа UserAddedToOrgEvent publish хийх bus-ийг тохируулна.
+func (s *OrgUserService) SetEventBus(bus event.EventBus) {
+	s.events = bus
+}
+
+// SetRoleLimit нь Add-д байгууллагын төрлийн role хязгаарыг (OrganizationType.EnforceRoleLimit)
+// шалгахад шаардлагатай repository-уудыг тохируулна.
+func (s *OrgUserService) SetRoleLimit(orgs repository.OrganizationRepository, orgTypes repository.OrganizationTypeRepository, userRoles repository.UserRoleRepository) {
+	s.orgs = orgs
+	s.orgTypes = orgTypes
+	s.userRoles = userRoles
+}
+
+// SetCacheInvalidator нь permission cache invalidator-ийг тохируулна.
+// Гишүүнчлэл нэмэгдэх, хасагдахад тухайн хэрэглэгчийн төрлөөс авсан permission өөрчлөгдөнө.
+func (s *OrgUserService) SetCacheInvalidator(cache auth.CacheInvalidator) {
+	s.cache = cache
+}
+
 func (s *OrgUserService) List(ctx context.Context, q dto.OrgUserListQuery) ([]domain.OrganizationUser, int64, int, int, error) {
 	return s.repo.List(ctx, q)
 }
@@ -236,6 +577,11 @@ func (s *OrgUserService) Add(ctx context.Context, req dto.OrgUserCreateDto, auth
 		return fmt.Errorf("байгууллага олдсонгүй")
 	}
 
+	// байгууллагын төрөл role хязгаартай бол хэрэглэгчийн role-ууд зөвшөөрөгдсөн байх ёстой
+	if err := s.checkRoleLimit(ctx, req.OrgId, req.UserId); err != nil {
+		return err
+	}
+
 	// хэрэглэгч локалд байхгүй бол CORE → citizen/find
 	uok, err := s.repo.UserExists(ctx, req.UserId)
 	if err != nil {
@@ -274,14 +620,72 @@ func (s *OrgUserService) Add(ctx context.Context, req dto.OrgUserCreateDto, auth
 		// Тайлбар: Хэрэв локал insert шаардлагатай бол энд User insert хийх логикоо нэмээрэй.
 	}
 
-	return s.repo.Add(ctx, domain.OrganizationUser{
+	if err := s.repo.Add(ctx, domain.OrganizationUser{
 		OrgId:  req.OrgId,
 		UserId: req.UserId,
-	})
+	}); err != nil {
+		return err
+	}
+	if s.cache != nil {
+		s.cache.InvalidateUser(req.UserId)
+	}
+
+	// Мэдэгдэл гэх мэт дагалдах үйлдлүүдийг subscriber-ууд гүйцэтгэнэ
+	if s.events != nil {
+		s.events.Publish(event.UserAddedToOrgEvent{
+			OrgID:      req.OrgId,
+			UserID:     req.UserId,
+			OccurredAt: time.Now(),
+		})
+	}
+	return nil
+}
+
+// checkRoleLimit нь байгууллагын төрөл EnforceRoleLimit-тэй бол хэрэглэгчид оноогдсон
+// (SSO-оос sync хийгдсэн user_roles) бүх role тухайн төрлийн OrgTypeRole-д багтаж байгааг шалгана.
+// Багтахгүй role байвал domain.ErrForbidden буцаана. SetRoleLimit дуудаагүй бол шалгахгүй.
+func (s *OrgUserService) checkRoleLimit(ctx context.Context, orgID, userID int) error {
+	if s.orgs == nil || s.orgTypes == nil || s.userRoles == nil {
+		return nil
+	}
+
+	org, err := s.orgs.ByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org.Type == nil || !org.Type.EnforceRoleLimit {
+		return nil
+	}
+
+	allowed, err := s.orgTypes.Roles(ctx, org.TypeId)
+	if err != nil {
+		return err
+	}
+	codes, err := s.userRoles.GetRoleCodes(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, r := range allowed {
+		allowedSet[r.Code] = true
+	}
+	for _, code := range codes {
+		if !allowedSet[code] {
+			return domain.NewForbidden(fmt.Sprintf("role %s is not allowed for organization type %s", code, org.Type.Code), nil)
+		}
+	}
+	return nil
 }
 
 func (s *OrgUserService) Remove(ctx context.Context, req dto.OrgUserDeleteDto) error {
-	return s.repo.Remove(ctx, req.OrgId, req.UserId)
+	if err := s.repo.Remove(ctx, req.OrgId, req.UserId); err != nil {
+		return err
+	}
+	if s.cache != nil {
+		s.cache.InvalidateUser(req.UserId)
+	}
+	return nil
 }
 
 func (s *OrgUserService) UsersByOrg(ctx context.Context, orgId int, name string, p common.PaginationQuery) ([]dto.ResOrguserUserItem, int64, int, int, error) {
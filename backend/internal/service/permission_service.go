@@ -10,6 +10,10 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
 	"templatev25/internal/auth"
 	"templatev25/internal/domain"
@@ -20,14 +24,18 @@ import (
 	"go.uber.org/zap"
 )
 
+// permissionCodeRe нь system.module.action хэлбэрийн permission code
+var permissionCodeRe = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*){2}$`)
+
 type PermissionService struct {
-	repo  repository.PermissionRepository
-	log   *zap.Logger
-	cache auth.CacheInvalidator // Permission cache invalidation (optional)
+	repo    repository.PermissionRepository
+	modules repository.ModuleRepository // module нэр (ListBySystemGrouped), оршин буй эсэх (ListByModule)
+	log     *zap.Logger
+	cache   auth.CacheInvalidator // Permission cache invalidation (optional)
 }
 
-func NewPermissionService(repo repository.PermissionRepository, log *zap.Logger) *PermissionService {
-	return &PermissionService{repo: repo, log: log}
+func NewPermissionService(repo repository.PermissionRepository, modules repository.ModuleRepository, log *zap.Logger) *PermissionService {
+	return &PermissionService{repo: repo, modules: modules, log: log}
 }
 
 // SetCacheInvalidator нь permission cache invalidator-ийг тохируулна.
@@ -48,10 +56,116 @@ func (s *PermissionService) ByCode(ctx context.Context, code string) (domain.Per
 	return s.repo.ByCode(ctx, code)
 }
 
+// ListBySystemGrouped нь system-ийн permission-уудыг module-аар бүлэглэнэ.
+// Module-ийн нэрсийг нэг batch query-ээр авна. Бүлгүүд module_id-ийн дарааллаар байна.
+func (s *PermissionService) ListBySystemGrouped(ctx context.Context, systemID int) ([]dto.SystemPermissionGroup, error) {
+	perms, err := s.repo.ListBySystem(ctx, systemID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]dto.SystemPermissionGroup, 0)
+	index := make(map[int]int) // module_id → groups index
+	moduleIDs := make([]int, 0)
+	for _, p := range perms {
+		i, ok := index[p.ModuleID]
+		if !ok {
+			i = len(groups)
+			index[p.ModuleID] = i
+			groups = append(groups, dto.SystemPermissionGroup{ModuleID: p.ModuleID, Permissions: []domain.Permission{}})
+			moduleIDs = append(moduleIDs, p.ModuleID)
+		}
+		groups[i].Permissions = append(groups[i].Permissions, p)
+	}
+	if len(moduleIDs) == 0 {
+		return groups, nil
+	}
+
+	modules, err := s.modules.ByIDs(ctx, moduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range modules {
+		if i, ok := index[m.ID]; ok {
+			groups[i].ModuleName = m.Name
+		}
+	}
+	return groups, nil
+}
+
+// ListByModule нь module-ийн permission-уудыг хуудаслаж буцаана.
+// Module олдохгүй бол domain.ErrNotFound.
+func (s *PermissionService) ListByModule(ctx context.Context, moduleID int, q dto.PermissionQuery) ([]domain.Permission, int64, int, int, error) {
+	if _, err := s.modules.ByID(ctx, moduleID); err != nil {
+		return nil, 0, 0, 0, err
+	}
+	return s.repo.ListByModule(ctx, moduleID, q)
+}
+
+// Create нь action бүрт нэг permission үүсгэнэ.
+// req.Code хоосон бол code-г ID-уудаас олдсон system/module/action кодоор GenerateCode үүсгэнэ.
+// req.Code өгсөн бол зөвхөн нэг action-тэй байх ба permissionCodeRe-д таарах ёстой.
 func (s *PermissionService) Create(ctx context.Context, req dto.PermissionCreateDto) error {
+	code := strings.TrimSpace(req.Code)
+	if code != "" {
+		if len(req.ActionIDs) != 1 {
+			return domain.NewInvalidInput("code can only be set when creating a single permission", nil)
+		}
+		if !permissionCodeRe.MatchString(code) {
+			return domain.NewInvalidInput("code must be in system.module.action format (lowercase letters, digits, underscore)", nil)
+		}
+	}
+
 	// ActionIDs-ээс Permission үүсгэх (нэг system-ийн нэг module-д олон action-д зориулсан permission үүсгэх)
 	// Transaction ашиглаж бүх Permission-г нэгэн зэрэг үүсгэх
-	return s.repo.CreateBatch(ctx, req.SystemID, req.ModuleID, req.ActionIDs)
+	return s.repo.CreateBatch(ctx, req.SystemID, req.ModuleID, req.ActionIDs, func(systemCode, moduleCode, actionCode string) (string, error) {
+		if code != "" {
+			return code, nil
+		}
+		if generated := s.GenerateCode(systemCode, moduleCode, actionCode); generated != "" {
+			return generated, nil
+		}
+		return "", domain.NewInvalidInput(fmt.Sprintf(
+			"cannot generate permission code from %q, %q, %q; provide code explicitly", systemCode, moduleCode, actionCode), nil)
+	})
+}
+
+// GenerateCode нь system, module, action кодуудыг "system.module.action" болгоно.
+//
+// Сегмент бүрийг lower case болгож, a-z, 0-9, _-ээс бусад тэмдэгтийг (зай,
+// зураас, цэг, unicode) "_" болгоно. Давхар "_"-г нэгтгэж, захын "_"-г хасна.
+//
+// Үр дүн permissionCodeRe-д таарахгүй бол (хоосон сегмент, тоогоор эхэлсэн
+// сегмент, зөвхөн unicode гэх мэт) хоосон string буцаана.
+//
+// Жишээ:
+//
+//	GenerateCode("Admin", "User-Management", "Create") // "admin.user_management.create"
+//	GenerateCode("admin", "", "create")                // ""
+func (s *PermissionService) GenerateCode(systemCode, moduleCode, actionCode string) string {
+	code := normalizeCodeSegment(systemCode) + "." + normalizeCodeSegment(moduleCode) + "." + normalizeCodeSegment(actionCode)
+	if !permissionCodeRe.MatchString(code) {
+		return ""
+	}
+	return code
+}
+
+// normalizeCodeSegment нь нэг сегментийг [a-z0-9_] тэмдэгтүүд болгоно
+func normalizeCodeSegment(seg string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(seg)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.Trim(b.String(), "_")
 }
 
 func (s *PermissionService) Update(ctx context.Context, id int, req dto.PermissionUpdateDto) error {
@@ -85,6 +199,8 @@ func (s *PermissionService) Delete(ctx context.Context, id int) error {
 }
 
 // HasPermission нь хэрэглэгч тодорхой permission-тэй эсэхийг шалгана.
+// Эхлээд role-оор (user_roles), олдохгүй бол хэрэглэгчийн байгууллагын
+// төрөлд шууд олгосон permission-оор (org_type_permissions) шалгана.
 //
 // Parameters:
 //   - ctx: Context
@@ -95,10 +211,16 @@ func (s *PermissionService) Delete(ctx context.Context, id int) error {
 //   - bool: Permission байвал true
 //   - error: Алдаа
 func (s *PermissionService) HasPermission(ctx context.Context, userID int, permissionCode string) (bool, error) {
-	return s.repo.UserHasPermission(ctx, userID, permissionCode)
+	ok, err := s.repo.UserHasPermission(ctx, userID, permissionCode)
+	if err != nil || ok {
+		return ok, err
+	}
+	return s.repo.UserHasOrgTypePermission(ctx, userID, permissionCode)
 }
 
-// GetUserPermissions нь хэрэглэгчийн бүх permission код-уудыг буцаана.
+// GetUserPermissions нь хэрэглэгчийн бүх permission код-уудыг буцаана:
+// role-оор олгогдсон болон байгууллагын төрлөөр олгогдсон кодуудын нэгдэл (давхардалгүй).
+// PermissionCache (RequirePermission middleware) энэ функцийг ашигладаг.
 //
 // Parameters:
 //   - ctx: Context
@@ -108,5 +230,18 @@ func (s *PermissionService) HasPermission(ctx context.Context, userID int, permi
 //   - []string: Permission кодуудын жагсаалт
 //   - error: Алдаа
 func (s *PermissionService) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
-	return s.repo.GetUserPermissionCodes(ctx, userID)
+	codes, err := s.repo.GetUserPermissionCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	orgTypeCodes, err := s.repo.GetUserOrgTypePermissionCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range orgTypeCodes {
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
 }
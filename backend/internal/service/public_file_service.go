@@ -23,7 +23,6 @@ import (
 
 	"git.gerege.mn/backend-packages/config"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 // Public file service constants
@@ -127,7 +126,7 @@ func (s *PublicFileService) Upload(ctx context.Context, header *multipart.FileHe
 func (s *PublicFileService) deleteByName(ctx context.Context, name string) error {
 	old, err := s.repo.GetByName(ctx, name)
 	if err != nil {
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
+		if !errors.Is(err, domain.ErrNotFound) {
 			return err
 		}
 	}
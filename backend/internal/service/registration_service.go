@@ -7,10 +7,13 @@ package service
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"time"
 
 	"templatev25/internal/config"
@@ -18,7 +21,6 @@ import (
 	"templatev25/internal/repository"
 
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 )
 
 // Registration error definitions
@@ -28,6 +30,7 @@ var (
 	ErrInvalidResetToken        = errors.New("invalid or expired password reset token")
 	ErrUserAlreadyVerified      = errors.New("user is already verified")
 	ErrPasswordMismatch         = errors.New("passwords do not match")
+	ErrRegistrationDisabled     = errors.New("registration is disabled")
 )
 
 // RegistrationService handles user registration, email verification, and password reset
@@ -36,6 +39,7 @@ type RegistrationService struct {
 	userRepo    repository.UserRepository
 	regRepo     repository.RegistrationRepository
 	authService *AuthService
+	mailer      Mailer
 	cfg         *config.LocalAuthConfig
 	logger      *zap.Logger
 }
@@ -54,11 +58,17 @@ func NewRegistrationService(
 		userRepo:    userRepo,
 		regRepo:     regRepo,
 		authService: authService,
+		mailer:      NewLogMailer(logger),
 		cfg:         cfg,
 		logger:      logger,
 	}
 }
 
+// SetMailer replaces the default log-only mailer
+func (s *RegistrationService) SetMailer(m Mailer) {
+	s.mailer = m
+}
+
 // ============================================================
 // REGISTRATION
 // ============================================================
@@ -82,19 +92,26 @@ type RegistrationResponse struct {
 	Message          string
 }
 
-// Register creates a new user account
+// Register creates a new user account.
+// The user and its password credential are created in one transaction, then a
+// verification token is emailed. A mailer failure is logged and reported via
+// VerificationSent=false; the user can request a new email with ResendVerificationEmail.
 func (s *RegistrationService) Register(ctx context.Context, req RegistrationRequest) (*RegistrationResponse, error) {
+	if !s.cfg.RegistrationEnabled {
+		return nil, ErrRegistrationDisabled
+	}
+
 	// Validate password match
 	if req.Password != req.ConfirmPassword {
 		return nil, ErrPasswordMismatch
 	}
 
 	// Validate password strength
-	if len(req.Password) < s.cfg.PasswordMinLength {
-		return nil, ErrPasswordTooWeak
+	if err := validatePassword(s.cfg, req.Password); err != nil {
+		return nil, err
 	}
 
-	// Check if email already exists
+	// Check if email already exists (soft-deleted users included — the unique index covers them)
 	exists, err := s.regRepo.EmailExists(ctx, req.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check email: %w", err)
@@ -103,21 +120,29 @@ func (s *RegistrationService) Register(ctx context.Context, req RegistrationRequ
 		return nil, ErrEmailAlreadyExists
 	}
 
+	hash, err := s.authService.hashPassword(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
 	// Create user with pending_verification status
+	now := time.Now()
 	user := &domain.User{
 		Email:     req.Email,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		Status:    string(domain.UserStatusPendingVerification),
 	}
-
-	if err := s.regRepo.CreateUser(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+	cred := &domain.UserCredential{
+		PasswordHash:      hash,
+		PasswordChangedAt: &now,
 	}
 
-	// Set password
-	if err := s.authService.SetPassword(ctx, user.Id, req.Password); err != nil {
-		return nil, fmt.Errorf("failed to set password: %w", err)
+	if err := s.regRepo.CreateUserWithCredential(ctx, user, cred); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, ErrEmailAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
 	// Generate verification token
@@ -129,15 +154,23 @@ func (s *RegistrationService) Register(ctx context.Context, req RegistrationRequ
 	verificationToken := &domain.EmailVerificationToken{
 		UserID:    user.Id,
 		Token:     token,
-		ExpiresAt: time.Now().Add(24 * time.Hour), // 24 hours expiry
+		ExpiresAt: time.Now().Add(emailVerificationTokenTTL),
 	}
 
 	if err := s.regRepo.CreateEmailVerificationToken(ctx, verificationToken); err != nil {
 		return nil, fmt.Errorf("failed to create verification token: %w", err)
 	}
 
-	// TODO: Send verification email
-	// s.emailService.SendVerificationEmail(user.Email, token)
+	verificationSent := true
+	message := "Registration successful. Please check your email to verify your account."
+	if err := s.mailer.Send(ctx, user.Email, "Verify your email", s.verificationBody(token)); err != nil {
+		s.logger.Error("failed to send verification email",
+			zap.Int("user_id", user.Id),
+			zap.Error(err),
+		)
+		verificationSent = false
+		message = "Registration successful, but the verification email could not be sent. Please request a new one."
+	}
 
 	s.logger.Info("user registered",
 		zap.Int("user_id", user.Id),
@@ -147,8 +180,8 @@ func (s *RegistrationService) Register(ctx context.Context, req RegistrationRequ
 	return &RegistrationResponse{
 		UserID:           user.Id,
 		Email:            user.Email,
-		VerificationSent: true,
-		Message:          "Registration successful. Please check your email to verify your account.",
+		VerificationSent: verificationSent,
+		Message:          message,
 	}, nil
 }
 
@@ -156,12 +189,15 @@ func (s *RegistrationService) Register(ctx context.Context, req RegistrationRequ
 // EMAIL VERIFICATION
 // ============================================================
 
+// emailVerificationTokenTTL is how long an email verification link stays valid
+const emailVerificationTokenTTL = 24 * time.Hour
+
 // VerifyEmail verifies a user's email address
 func (s *RegistrationService) VerifyEmail(ctx context.Context, tokenStr string) error {
 	// Get token
 	token, err := s.regRepo.GetEmailVerificationToken(ctx, tokenStr)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, domain.ErrNotFound) {
 			return ErrInvalidVerificationToken
 		}
 		return fmt.Errorf("failed to get token: %w", err)
@@ -199,7 +235,7 @@ func (s *RegistrationService) ResendVerificationEmail(ctx context.Context, email
 	// Get user
 	user, err := s.authRepo.GetUserByEmail(ctx, email)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, domain.ErrNotFound) {
 			// Don't reveal if email exists
 			return nil
 		}
@@ -223,15 +259,16 @@ func (s *RegistrationService) ResendVerificationEmail(ctx context.Context, email
 	verificationToken := &domain.EmailVerificationToken{
 		UserID:    user.Id,
 		Token:     token,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		ExpiresAt: time.Now().Add(emailVerificationTokenTTL),
 	}
 
 	if err := s.regRepo.CreateEmailVerificationToken(ctx, verificationToken); err != nil {
 		return fmt.Errorf("failed to create verification token: %w", err)
 	}
 
-	// TODO: Send verification email
-	// s.emailService.SendVerificationEmail(user.Email, token)
+	if err := s.mailer.Send(ctx, user.Email, "Verify your email", s.verificationBody(token)); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
 
 	return nil
 }
@@ -240,12 +277,20 @@ func (s *RegistrationService) ResendVerificationEmail(ctx context.Context, email
 // PASSWORD RESET
 // ============================================================
 
-// ForgotPassword initiates the password reset process
+// passwordResetTokenTTL is how long a password reset link stays valid
+const passwordResetTokenTTL = 1 * time.Hour
+
+// passwordResetEmailTimeout bounds the background password reset email
+const passwordResetEmailTimeout = 10 * time.Second
+
+// ForgotPassword initiates the password reset process.
+// A random token is emailed to the user; only its SHA-256 hash is stored.
+// Unknown emails return nil so callers cannot enumerate accounts.
 func (s *RegistrationService) ForgotPassword(ctx context.Context, email string) error {
 	// Get user
 	user, err := s.authRepo.GetUserByEmail(ctx, email)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, domain.ErrNotFound) {
 			// Don't reveal if email exists - always return success
 			return nil
 		}
@@ -263,16 +308,16 @@ func (s *RegistrationService) ForgotPassword(ctx context.Context, email string)
 
 	resetToken := &domain.PasswordResetToken{
 		UserID:    user.Id,
-		Token:     token,
-		ExpiresAt: time.Now().Add(1 * time.Hour), // 1 hour expiry
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTokenTTL),
 	}
 
 	if err := s.regRepo.CreatePasswordResetToken(ctx, resetToken); err != nil {
 		return fmt.Errorf("failed to create reset token: %w", err)
 	}
 
-	// TODO: Send password reset email
-	// s.emailService.SendPasswordResetEmail(user.Email, token)
+	// The email is sent in the background so known and unknown emails return equally fast
+	s.sendPasswordResetAsync(ctx, user, token)
 
 	s.logger.Info("password reset requested",
 		zap.Int("user_id", user.Id),
@@ -282,6 +327,25 @@ func (s *RegistrationService) ForgotPassword(ctx context.Context, email string)
 	return nil
 }
 
+// sendPasswordResetAsync emails the reset link without delaying the response.
+// The request context is detached so the email survives the request being finished;
+// a failure is logged, not returned, to avoid enumeration.
+func (s *RegistrationService) sendPasswordResetAsync(ctx context.Context, user *domain.User, token string) {
+	bg := context.WithoutCancel(ctx)
+	userID, to, body := user.Id, user.Email, s.passwordResetBody(token)
+	go func() {
+		ctx, cancel := context.WithTimeout(bg, passwordResetEmailTimeout)
+		defer cancel()
+
+		if err := s.mailer.Send(ctx, to, "Password reset", body); err != nil {
+			s.logger.Error("failed to send password reset email",
+				zap.Int("user_id", userID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
 // ResetPassword resets the user's password using a valid token
 func (s *RegistrationService) ResetPassword(ctx context.Context, tokenStr, newPassword, confirmPassword string) error {
 	// Validate password match
@@ -290,14 +354,14 @@ func (s *RegistrationService) ResetPassword(ctx context.Context, tokenStr, newPa
 	}
 
 	// Validate password strength
-	if len(newPassword) < s.cfg.PasswordMinLength {
-		return ErrPasswordTooWeak
+	if err := validatePassword(s.cfg, newPassword); err != nil {
+		return err
 	}
 
 	// Get token
-	token, err := s.regRepo.GetPasswordResetToken(ctx, tokenStr)
+	token, err := s.regRepo.GetPasswordResetToken(ctx, hashResetToken(tokenStr))
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, domain.ErrNotFound) {
 			return ErrInvalidResetToken
 		}
 		return fmt.Errorf("failed to get token: %w", err)
@@ -308,16 +372,16 @@ func (s *RegistrationService) ResetPassword(ctx context.Context, tokenStr, newPa
 		return ErrInvalidResetToken
 	}
 
+	// Set new password (same checks as ChangePassword, without the current password)
+	if err := s.authService.ResetPassword(ctx, token.UserID, newPassword, "", ""); err != nil {
+		return err
+	}
+
 	// Mark token as used
 	if err := s.regRepo.MarkPasswordResetTokenUsed(ctx, token.ID); err != nil {
 		return fmt.Errorf("failed to mark token used: %w", err)
 	}
 
-	// Set new password
-	if err := s.authService.SetPassword(ctx, token.UserID, newPassword); err != nil {
-		return fmt.Errorf("failed to set password: %w", err)
-	}
-
 	// Delete all password reset tokens for this user
 	s.regRepo.DeleteUserPasswordResetTokens(ctx, token.UserID)
 
@@ -331,6 +395,34 @@ func (s *RegistrationService) ResetPassword(ctx context.Context, tokenStr, newPa
 	return nil
 }
 
+// passwordResetBody builds the reset email body.
+// If PasswordResetURL is configured the token is appended as ?token=.
+func (s *RegistrationService) passwordResetBody(token string) string {
+	if s.cfg.PasswordResetURL != "" {
+		return "Reset your password: " + s.cfg.PasswordResetURL + "?token=" + url.QueryEscape(token) +
+			"\n\nThis link expires in 1 hour. If you did not request a reset, ignore this email."
+	}
+	return "Your password reset token: " + token +
+		"\n\nThis token expires in 1 hour. If you did not request a reset, ignore this email."
+}
+
+// verificationBody builds the verification email body.
+// If EmailVerificationURL is configured the token is appended as ?token=.
+func (s *RegistrationService) verificationBody(token string) string {
+	if s.cfg.EmailVerificationURL != "" {
+		return "Verify your email: " + s.cfg.EmailVerificationURL + "?token=" + url.QueryEscape(token) +
+			"\n\nThis link expires in 24 hours."
+	}
+	return "Your email verification token: " + token +
+		"\n\nThis token expires in 24 hours."
+}
+
+// hashResetToken returns the hex SHA-256 of a reset token (the stored form)
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // ============================================================
 // HELPER METHODS
 // ============================================================
@@ -11,6 +11,7 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"templatev25/internal/auth"
 	"templatev25/internal/domain"
@@ -21,6 +22,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrSystemRole нь is_system_role = true role-ийг устгах гэсэн үед (403)
+var ErrSystemRole = domain.NewForbidden("system role cannot be deleted", nil)
+
 type RoleService struct {
 	repo  repository.RoleRepository
 	log   *zap.Logger
@@ -49,6 +53,22 @@ func (s *RoleService) ListFilteredPaged(ctx context.Context, p dto.RoleListQuery
 	return items, total, page, size, nil
 }
 
+// GetByID нь role-ийг түүнд оноосон permission-уудын хамт буцаана
+func (s *RoleService) GetByID(ctx context.Context, id int) (domain.Role, []domain.Permission, error) {
+	log := middleware.LoggerOrDefault(ctx, s.log)
+	role, err := s.repo.ByIDWithPermissions(ctx, id)
+	if err != nil {
+		log.Error("role_get_failed", zap.Int("role_id", id), zap.Error(err))
+		return domain.Role{}, nil, err
+	}
+	perms := role.Permissions
+	if perms == nil {
+		perms = []domain.Permission{}
+	}
+	log.Debug("role_fetched", zap.Int("role_id", id), zap.Int("permission_count", len(perms)))
+	return role, perms, nil
+}
+
 // Create — handler аль хэдийн validate хийсэн гэж үзэж repo руу шууд дамжуулна
 func (s *RoleService) Create(ctx context.Context, req dto.RoleCreateDto) error {
 	log := middleware.LoggerOrDefault(ctx, s.log)
@@ -97,6 +117,10 @@ func (s *RoleService) Delete(ctx context.Context, id int) error {
 		log.Error("role_delete_not_found", zap.Int("role_id", id), zap.Error(err))
 		return err
 	}
+	if existing.IsSystemRole != nil && *existing.IsSystemRole {
+		log.Warn("role_delete_blocked_system", zap.Int("role_id", id), zap.String("code", existing.Code))
+		return ErrSystemRole
+	}
 	if existing.IsActive != nil && *existing.IsActive {
 		log.Warn("role_delete_blocked_active", zap.Int("role_id", id))
 		return errors.New("эрх идэвхитэй тул устгах боломжгүй")
@@ -120,6 +144,38 @@ func (s *RoleService) GetPermissions(ctx context.Context, q dto.RolePermissionsQ
 	return perms, nil
 }
 
+// DiffPermissionIDs нь role-ийн одоогийн permission ID-уудыг санал болгож буйтай харьцуулна.
+// to_add = proposed - current, to_remove = current - proposed, unchanged = огтлолцол.
+// Давхардсан ID-г нэг удаа тооцож, үр дүнг өсөх эрэмбээр буцаана.
+func DiffPermissionIDs(current, proposed []int) dto.RolePermissionsDiffResponse {
+	cur := make(map[int]struct{}, len(current))
+	for _, id := range current {
+		cur[id] = struct{}{}
+	}
+	prop := make(map[int]struct{}, len(proposed))
+	for _, id := range proposed {
+		prop[id] = struct{}{}
+	}
+
+	out := dto.RolePermissionsDiffResponse{ToAdd: []int{}, ToRemove: []int{}, Unchanged: []int{}}
+	for id := range prop {
+		if _, ok := cur[id]; ok {
+			out.Unchanged = append(out.Unchanged, id)
+		} else {
+			out.ToAdd = append(out.ToAdd, id)
+		}
+	}
+	for id := range cur {
+		if _, ok := prop[id]; !ok {
+			out.ToRemove = append(out.ToRemove, id)
+		}
+	}
+	slices.Sort(out.ToAdd)
+	slices.Sort(out.ToRemove)
+	slices.Sort(out.Unchanged)
+	return out
+}
+
 func (s *RoleService) SetPermissions(ctx context.Context, req dto.RolePermissionsUpdateDto) error {
 	log := middleware.LoggerOrDefault(ctx, s.log)
 	if err := s.repo.ReplacePermissions(ctx, req.RoleID, req.PermissionIDs); err != nil {
@@ -166,8 +166,8 @@ func TestServiceConstructors(t *testing.T) {
 	constructors := []string{
 		"NewUserService(repo, cfg, log)",
 		"NewRoleService(repo, log)",
-		"NewPermissionService(repo, log)",
-		"NewOrganizationService(repo, log)",
+		"NewPermissionService(repo, modules, log)",
+		"NewOrganizationService(repo, audit, log)",
 		"NewNewsService(repo)",
 		"NewNotificationService(repo, cfg)",
 	}
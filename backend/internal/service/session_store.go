@@ -34,6 +34,10 @@ type SessionStore interface {
 	GetMFAToken(ctx context.Context, token string) (*MFAPendingData, error)
 	DeleteMFAToken(ctx context.Context, token string) error
 
+	// Active organization override (multi-org switching)
+	SetOrgOverride(ctx context.Context, sessionID string, orgID int, ttl time.Duration) error
+	GetOrgOverride(ctx context.Context, sessionID string) (int, error)
+
 	// Health check
 	Ping(ctx context.Context) error
 
@@ -51,6 +55,14 @@ type SessionData struct {
 	CreatedAt      time.Time `json:"created_at"`
 	ExpiresAt      time.Time `json:"expires_at"`
 	LastActivityAt time.Time `json:"last_activity_at"`
+
+	// ImpersonatedBy нь админ impersonation-аар үүссэн session-ий админы ID (0 = энгийн session)
+	ImpersonatedBy int `json:"impersonated_by,omitempty"`
+}
+
+// IsImpersonated reports whether the session was created by an admin impersonation
+func (s *SessionData) IsImpersonated() bool {
+	return s.ImpersonatedBy != 0
 }
 
 // MFAPendingData represents temporary data during MFA verification
@@ -73,6 +85,7 @@ const (
 	sessionPrefix     = "session:"
 	userSessionPrefix = "user:sessions:"
 	mfaTokenPrefix    = "mfa:token:"
+	orgOverridePrefix = "session:org:"
 )
 
 // NewRedisSessionStore creates a new Redis session store with a pre-created Redis client
@@ -321,6 +334,32 @@ func (s *RedisSessionStore) DeleteMFAToken(ctx context.Context, token string) er
 	return s.client.Del(ctx, key).Err()
 }
 
+// ============================================================
+// ORGANIZATION OVERRIDE
+// ============================================================
+
+// SetOrgOverride stores the active organization selected for a session
+func (s *RedisSessionStore) SetOrgOverride(ctx context.Context, sessionID string, orgID int, ttl time.Duration) error {
+	key := s.orgOverrideKey(sessionID)
+	if err := s.client.Set(ctx, key, orgID, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store org override: %w", err)
+	}
+	return nil
+}
+
+// GetOrgOverride returns the active organization for a session (0 if not set)
+func (s *RedisSessionStore) GetOrgOverride(ctx context.Context, sessionID string) (int, error) {
+	key := s.orgOverrideKey(sessionID)
+	orgID, err := s.client.Get(ctx, key).Int()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil // No override
+		}
+		return 0, fmt.Errorf("failed to get org override: %w", err)
+	}
+	return orgID, nil
+}
+
 // ============================================================
 // UTILITY METHODS
 // ============================================================
@@ -347,3 +386,7 @@ func (s *RedisSessionStore) userSessionsKey(userID int) string {
 func (s *RedisSessionStore) mfaTokenKey(token string) string {
 	return s.prefix + mfaTokenPrefix + token
 }
+
+func (s *RedisSessionStore) orgOverrideKey(sessionID string) string {
+	return s.prefix + orgOverridePrefix + sessionID
+}
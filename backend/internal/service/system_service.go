@@ -11,7 +11,9 @@ package service
 import (
 	"context"
 	"errors"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
@@ -22,7 +24,9 @@ import (
 
 type SystemService interface {
 	List(ctx context.Context, q dto.SystemListQuery) ([]domain.System, int64, int, int, error)
+	ListActive(ctx context.Context) ([]domain.System, error)
 	ByID(ctx context.Context, id int) (domain.System, error)
+	BySlug(ctx context.Context, slug string) (domain.System, error)
 	Create(ctx context.Context, req dto.SystemCreateDto) error
 	Update(ctx context.Context, id int, req dto.SystemUpdateDto) error
 	Delete(ctx context.Context, id int) error
@@ -48,6 +52,16 @@ func (s *systemService) List(ctx context.Context, q dto.SystemListQuery) ([]doma
 	return items, total, page, size, nil
 }
 
+// ListActive нь идэвхтэй system-үүдийг sequence дарааллаар буцаана (/me/menu шүүлтүүр)
+func (s *systemService) ListActive(ctx context.Context) ([]domain.System, error) {
+	items, err := s.repo.ListActive(ctx)
+	if err != nil {
+		s.log.Error("system_list_active_failed", zap.Error(err))
+		return nil, err
+	}
+	return items, nil
+}
+
 // ByID
 func (s *systemService) ByID(ctx context.Context, id int) (domain.System, error) {
 	sys, err := s.repo.ByID(ctx, id)
@@ -58,6 +72,16 @@ func (s *systemService) ByID(ctx context.Context, id int) (domain.System, error)
 	return sys, nil
 }
 
+// BySlug
+func (s *systemService) BySlug(ctx context.Context, slug string) (domain.System, error) {
+	sys, err := s.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		s.log.Error("system_get_by_slug_failed", zap.String("slug", slug), zap.Error(err))
+		return domain.System{}, err
+	}
+	return sys, nil
+}
+
 // Create
 func (s *systemService) Create(ctx context.Context, req dto.SystemCreateDto) error {
 	// Code-г lower case болгох
@@ -71,10 +95,17 @@ func (s *systemService) Create(ctx context.Context, req dto.SystemCreateDto) err
 		key = strings.ToLower(key)
 	}
 
+	slug, err := s.uniqueSlug(ctx, req.Name, code)
+	if err != nil {
+		s.log.Error("system_slug_failed", zap.String("code", code), zap.Error(err))
+		return err
+	}
+
 	m := domain.System{
 		Code:        code,
 		Key:         key,
 		Name:        req.Name,
+		Slug:        slug,
 		Description: req.Description,
 		IsActive:    req.IsActive,
 		Icon:        req.Icon,
@@ -151,3 +182,47 @@ func (s *systemService) Delete(ctx context.Context, id int) error {
 	s.log.Info("system_deleted", zap.Int("system_id", id))
 	return nil
 }
+
+// uniqueSlug нь name-ээс (ASCII үсэг/тоо үлдэхгүй бол code-оос) slug үүсгэж,
+// давхцвал -2, -3, ... залгана
+func (s *systemService) uniqueSlug(ctx context.Context, name, code string) (string, error) {
+	base := slugify(name)
+	if base == "" {
+		base = slugify(code)
+	}
+	if base == "" {
+		base = "system"
+	}
+
+	slug := base
+	for n := 2; ; n++ {
+		_, err := s.repo.GetBySlug(ctx, slug)
+		if errors.Is(err, domain.ErrNotFound) {
+			return slug, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		slug = base + "-" + strconv.Itoa(n)
+	}
+}
+
+// slugify нь жижиг үсэг болгож, зайг '-' болгоод a-z, 0-9, '-'-ээс бусад тэмдэгтийг хасна.
+// Дараалсан '-'-г нэг болгож, эхлэл төгсгөлийнхийг хасна.
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		case r == '-' || unicode.IsSpace(r):
+			dash = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,35 @@
+// Package service provides business logic layer
+//
+// File: system_slug_test.go
+// Description: Unit tests for system slug generation
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Gerege App", want: "gerege-app"},
+		{in: "TPay", want: "tpay"},
+		{in: "  HR   Portal  ", want: "hr-portal"},
+		{in: "C++ Tools & API v2", want: "c-tools-api-v2"},
+		{in: "already-slug", want: "already-slug"},
+		{in: "a -- b", want: "a-b"},
+		{in: "GEREGE_BUSINESS", want: "geregebusiness"},
+		{in: "Админ систем", want: ""},
+		{in: "Gerege Бизнес 2025", want: "gerege-2025"},
+		{in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, slugify(tt.in))
+		})
+	}
+}
@@ -16,14 +16,18 @@ import (
 	"templatev25/internal/http/dto"
 
 	"templatev25/internal/repository"
+
+	"git.gerege.mn/backend-packages/common"
 )
 
 type UserRoleService interface {
 	UsersByRole(ctx context.Context, q dto.UserRoleUsersQuery) ([]domain.UserRole, int64, int, int, error)
 	RolesByUser(ctx context.Context, q dto.UserRoleRolesQuery) ([]domain.UserRole, int64, int, int, error)
+	GetByUserID(ctx context.Context, userID int) ([]dto.UserRoleDetail, error)
 	AssignByRole(ctx context.Context, req dto.UserRoleAssignByRole) error
 	AssignByUser(ctx context.Context, req dto.UserRoleAssignByUser) error
 	Remove(ctx context.Context, req dto.UserRoleRemoveDto) error
+	History(ctx context.Context, userID int, p common.PaginationQuery) ([]domain.UserRoleHistory, int64, int, int, error)
 	SetCacheInvalidator(cache auth.CacheInvalidator)
 }
 
@@ -48,6 +52,33 @@ func (s *userRoleService) UsersByRole(ctx context.Context, q dto.UserRoleUsersQu
 func (s *userRoleService) RolesByUser(ctx context.Context, q dto.UserRoleRolesQuery) ([]domain.UserRole, int64, int, int, error) {
 	return s.repo.RolesByUser(ctx, q)
 }
+
+// GetByUserID нь хэрэглэгчийн role-уудыг системийн нэрийн хамт DTO болгож буцаана.
+// Устгагдсан (preload хийгдээгүй) role-ийг алгасна.
+func (s *userRoleService) GetByUserID(ctx context.Context, userID int) ([]dto.UserRoleDetail, error) {
+	items, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]dto.UserRoleDetail, 0, len(items))
+	for _, ur := range items {
+		if ur.Role == nil {
+			continue
+		}
+		d := dto.UserRoleDetail{
+			RoleID:   ur.RoleID,
+			RoleName: ur.Role.Name,
+			RoleCode: ur.Role.Code,
+			SystemID: ur.Role.SystemID,
+		}
+		if ur.Role.System != nil {
+			d.SystemName = ur.Role.System.Name
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
 func (s *userRoleService) AssignByRole(ctx context.Context, req dto.UserRoleAssignByRole) error {
 	if err := s.repo.AddUsersToRole(ctx, req.RoleID, req.UserIDs); err != nil {
 		return err
@@ -78,3 +109,8 @@ func (s *userRoleService) Remove(ctx context.Context, req dto.UserRoleRemoveDto)
 	}
 	return nil
 }
+
+// History нь хэрэглэгчийн эрх олгосон/хассан түүхийг буцаана (AuditedUserRoleService бичдэг)
+func (s *userRoleService) History(ctx context.Context, userID int, p common.PaginationQuery) ([]domain.UserRoleHistory, int64, int, int, error) {
+	return s.repo.HistoryByUser(ctx, userID, p)
+}
@@ -10,22 +10,57 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
 
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
 	"templatev25/internal/middleware"
 	"templatev25/internal/repository"
+	"templatev25/internal/service/export"
 
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/config"
+	"git.gerege.mn/backend-packages/ctx"
 	"git.gerege.mn/backend-packages/utils"
 	"go.uber.org/zap"
 )
 
+// Organization switching errors
+var (
+	ErrOrgNotFound     = domain.NewNotFound("organization not found", nil)
+	ErrOrgNotMember    = domain.NewForbidden("user is not a member of the organization", nil)
+	ErrOrgSwitchNotSet = errors.New("organization switching is not configured")
+)
+
+// userExportBatchSize нь export хийхэд DB-ээс нэг удаад унших мөрийн тоо
+const userExportBatchSize = 500
+
+// userStreamTimeout нь GET /user?stream=true-ийн DB уншилт + response бичилтийн дээд хугацаа
+const userStreamTimeout = 5 * time.Minute
+
+// userExportHeader нь GET /user/export-ийн баганууд
+var userExportHeader = []string{"id", "reg_no", "first_name", "last_name", "email", "phone_no", "created_date"}
+
 type UserService struct {
 	repo repository.UserRepository
 	log  *zap.Logger
 	cfg  *config.Config
+
+	// org switching (SetOrgSwitcher-ээр тохируулна)
+	orgUsers    repository.OrgUserRepository
+	sessions    SessionStore
+	overrideTTL time.Duration
+
+	// GDPR erasure (SetErasure-ээр тохируулна)
+	tx            repository.TxManager
+	notifications repository.NotificationRepository
+	authData      repository.AuthRepository
+	sessionStore  SessionStore
 }
 
 func NewUserService(repo repository.UserRepository, cfg *config.Config, log *zap.Logger) *UserService {
@@ -36,6 +71,24 @@ func NewUserService(repo repository.UserRepository, cfg *config.Config, log *zap
 	}
 }
 
+// SetOrgSwitcher нь идэвхтэй байгууллага солиход шаардлагатай dependency-г тохируулна.
+// Membership шалгалтад orgUsers, session-ий org_id override хадгалахад sessions ашиглагдана.
+func (s *UserService) SetOrgSwitcher(orgUsers repository.OrgUserRepository, sessions SessionStore, ttl time.Duration) {
+	s.orgUsers = orgUsers
+	s.sessions = sessions
+	s.overrideTTL = ttl
+}
+
+// SetErasure нь хэрэглэгч устгахад notification болон auth өгөгдлийг (credential,
+// session, MFA, login history) нэг transaction-д бүрмөсөн устгахаар тохируулна.
+// sessions өгсөн бол commit амжилттай болсны дараа Redis дэх идэвхтэй session-уудыг устгана.
+func (s *UserService) SetErasure(tx repository.TxManager, notifications repository.NotificationRepository, authData repository.AuthRepository, sessions SessionStore) {
+	s.tx = tx
+	s.notifications = notifications
+	s.authData = authData
+	s.sessionStore = sessions
+}
+
 func (s *UserService) GetByID(ctx context.Context, id int) (domain.User, error) {
 	log := middleware.LoggerOrDefault(ctx, s.log)
 	user, err := s.repo.GetByID(ctx, id)
@@ -58,9 +111,95 @@ func (s *UserService) List(ctx context.Context, p common.PaginationQuery) ([]dom
 	return items, total, page, size, nil
 }
 
-func (s *UserService) Create(ctx context.Context, req dto.UserCreateDto) (domain.User, error) {
+// Search нь q-г нэр, овог, и-мэйл, регистр, утасны дугаараас нэг дор хайна (GET /user/search)
+func (s *UserService) Search(ctx context.Context, q string, p common.PaginationQuery) ([]domain.User, int64, int, int, error) {
 	log := middleware.LoggerOrDefault(ctx, s.log)
-	m := domain.User{
+	items, total, page, size, err := s.repo.Search(ctx, q, p)
+	if err != nil {
+		log.Error("user_search_failed", zap.Error(err))
+		return nil, 0, 0, 0, err
+	}
+	log.Debug("user_search_success", zap.Int64("total", total), zap.Int("page", page))
+	return items, total, page, size, nil
+}
+
+// ListStream нь List-тэй ижил шүүлтүүр/эрэмбээр бүх хэрэглэгчийг (pagination-гүй) channel-аар буцаана.
+// Response body нь handler дууссаны дараа бичигддэг тул request context-ийн утгуудыг (trace, logger)
+// хадгалсан боловч цуцлалтаас салгасан, userStreamTimeout-той context-оор уншина.
+// Алдаа (байвал) items хаагдсаны дараа errc-ээс ирнэ.
+func (s *UserService) ListStream(ctx context.Context, p common.PaginationQuery) (<-chan domain.User, <-chan error) {
+	log := middleware.LoggerOrDefault(ctx, s.log)
+
+	sctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), userStreamTimeout)
+	items, repoErrc := s.repo.ListStream(sctx, p)
+
+	errc := make(chan error, 1)
+	go func() {
+		defer cancel()
+		defer close(errc)
+		if err := <-repoErrc; err != nil {
+			log.Error("user_list_stream_failed", zap.Error(err))
+			errc <- err
+		}
+	}()
+	return items, errc
+}
+
+// Export нь List-тэй ижил шүүлтүүрээр (search, createdFrom/To) хэрэглэгчдийг format-аар (CSV, NDJSON, XLSX) w руу бичнэ.
+// DB-ээс userExportBatchSize мөрөөр хувааж уншиж шууд w руу бичдэг тул бүх entity болон файл санах ойд зэрэг ачаалагдахгүй.
+// ListStream-тэй адил request context-ийн утгыг хадгалсан боловч цуцлалтаас салгасан, userStreamTimeout-той context-оор уншина.
+func (s *UserService) Export(ctx context.Context, p common.PaginationQuery, format export.ExportFormat, w io.Writer) (err error) {
+	log := middleware.LoggerOrDefault(ctx, s.log)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), userStreamTimeout)
+	defer cancel()
+
+	f, err := export.NewFormatter(format, w)
+	if err != nil {
+		return err
+	}
+	rows := 0
+	// Close нь алдаа гарсан ч дуудагдаж xlsx workbook-ийн түр файлуудыг чөлөөлнө
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			log.Error("user_export_failed", zap.String("format", string(format)), zap.Int("rows", rows), zap.Error(err))
+			return
+		}
+		log.Info("user_export_success", zap.String("format", string(format)), zap.Int("rows", rows))
+	}()
+
+	if err := f.WriteHeader(userExportHeader); err != nil {
+		return err
+	}
+	return s.repo.FindInBatches(ctx, p, userExportBatchSize, func(batch []domain.User) error {
+		for _, u := range batch {
+			createdDate := ""
+			if u.CreatedDate != nil {
+				createdDate = u.CreatedDate.String()
+			}
+			if err := f.WriteRow([]string{
+				strconv.Itoa(u.Id),
+				u.RegNo,
+				u.FirstName,
+				u.LastName,
+				u.Email,
+				u.PhoneNo,
+				createdDate,
+			}); err != nil {
+				return err
+			}
+		}
+		rows += len(batch)
+		return nil
+	})
+}
+
+// userFromCreateDto нь SSO-гийн хэрэглэгчийн мэдээллийг domain.User болгоно
+func userFromCreateDto(req dto.UserCreateDto) domain.User {
+	return domain.User{
 		Id:         req.Id,
 		CivilId:    req.CivilId,
 		RegNo:      req.RegNo,
@@ -72,6 +211,11 @@ func (s *UserService) Create(ctx context.Context, req dto.UserCreateDto) (domain
 		PhoneNo:    req.PhoneNo,
 		Email:      req.Email,
 	}
+}
+
+func (s *UserService) Create(ctx context.Context, req dto.UserCreateDto) (domain.User, error) {
+	log := middleware.LoggerOrDefault(ctx, s.log)
+	m := userFromCreateDto(req)
 	// exists check (хуучин логик)
 	if user, err := s.repo.GetByID(ctx, req.Id); err == nil {
 		log.Debug("user_already_exists", zap.Int("user_id", req.Id))
@@ -86,6 +230,39 @@ func (s *UserService) Create(ctx context.Context, req dto.UserCreateDto) (domain
 	return user, nil
 }
 
+// SyncFromSSO нь SSO-оос түлхсэн хэрэглэгчдийг нэг дор upsert хийж, upsert хийгдсэн тоог буцаана.
+// Нэг id давхардвал сүүлийнх нь хэрэглэгдэнэ (Postgres ON CONFLICT нэг мөрийг хоёр удаа шинэчилж чадахгүй).
+// Устгагдсан хэрэглэгчид сэргээгдэхгүй — тэдний id skipped-д буцна.
+func (s *UserService) SyncFromSSO(ctx context.Context, users []dto.UserCreateDto) (int, []int, error) {
+	log := middleware.LoggerOrDefault(ctx, s.log)
+
+	index := make(map[int]int, len(users))
+	items := make([]domain.User, 0, len(users))
+	for i, u := range users {
+		if err := validation.Struct(u); err != nil {
+			return 0, nil, domain.NewInvalidInput(fmt.Sprintf("users[%d]: %v", i, err), err)
+		}
+		if j, ok := index[u.Id]; ok {
+			items[j] = userFromCreateDto(u)
+			continue
+		}
+		index[u.Id] = len(items)
+		items = append(items, userFromCreateDto(u))
+	}
+
+	skipped, err := s.repo.BulkUpsert(ctx, items)
+	if err != nil {
+		log.Error("user_sync_failed", zap.Int("count", len(items)), zap.Error(err))
+		return 0, nil, err
+	}
+	if len(skipped) > 0 {
+		log.Warn("user_sync_skipped_deleted", zap.Ints("user_ids", skipped))
+	}
+	upserted := len(items) - len(skipped)
+	log.Info("user_sync_success", zap.Int("received", len(users)), zap.Int("upserted", upserted), zap.Int("skipped", len(skipped)))
+	return upserted, skipped, nil
+}
+
 func (s *UserService) Update(ctx context.Context, req dto.UserUpdateDto) (domain.User, error) {
 	log := middleware.LoggerOrDefault(ctx, s.log)
 	// exists check
@@ -114,17 +291,53 @@ func (s *UserService) Update(ctx context.Context, req dto.UserUpdateDto) (domain
 	return user, nil
 }
 
+// Delete нь хэрэглэгчийг soft delete хийнэ. SetErasure тохируулсан бол notification,
+// notification group, auth өгөгдлийг нэг transaction-д hard delete хийнэ (GDPR erasure);
+// аль нэг нь амжилтгүй бол хэрэглэгч ч устгагдахгүй. Дараа нь Redis session-уудыг устгана.
 func (s *UserService) Delete(ctx context.Context, id int) (domain.User, error) {
 	log := middleware.LoggerOrDefault(ctx, s.log)
-	user, err := s.repo.Delete(ctx, id)
+	var user domain.User
+	var err error
+	if s.tx == nil {
+		user, err = s.repo.Delete(ctx, id)
+	} else {
+		err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			if user, err = s.repo.Delete(ctx, id); err != nil {
+				return err
+			}
+			return s.eraseUserData(ctx, id)
+		})
+	}
 	if err != nil {
 		log.Error("user_delete_failed", zap.Int("user_id", id), zap.Error(err))
 		return domain.User{}, err
 	}
+	// Redis transaction-д оролцохгүй тул commit-ийн дараа устгана; устгасан
+	// хэрэглэгчийн cookie-ээр цаашид нэвтрэх боломжгүй болно
+	if s.sessionStore != nil {
+		if err := s.sessionStore.DeleteAllUserSessions(ctx, id); err != nil {
+			log.Error("user_delete_sessions_failed", zap.Int("user_id", id), zap.Error(err))
+		}
+	}
 	log.Info("user_deleted", zap.Int("user_id", id))
 	return user, nil
 }
 
+// eraseUserData нь хэрэглэгчтэй холбоотой notification, auth өгөгдлийг устгана
+func (s *UserService) eraseUserData(ctx context.Context, id int) error {
+	if err := s.notifications.DeleteByUserID(ctx, id); err != nil {
+		return fmt.Errorf("erase notifications: %w", err)
+	}
+	if err := s.notifications.DeleteGroupsByUserID(ctx, id); err != nil {
+		return fmt.Errorf("erase notification groups: %w", err)
+	}
+	if err := s.authData.DeleteByUserID(ctx, id); err != nil {
+		return fmt.Errorf("erase auth data: %w", err)
+	}
+	return nil
+}
+
 // -------- Profile & Organizations --------
 
 func (s *UserService) Organizations(ctx context.Context, userID, currentOrgID int, fields []string) (orgID int, org *domain.Organization, items []domain.Organization, err error) {
@@ -150,3 +363,43 @@ func (s *UserService) Organizations(ctx context.Context, userID, currentOrgID in
 	log.Debug("user_orgs_fetched", zap.Int("user_id", userID), zap.Int("org_count", len(items)))
 	return orgID, org, items, nil
 }
+
+// SwitchOrganization нь одоогийн session-ий идэвхтэй байгууллагыг солино.
+// Хэрэглэгч тухайн байгууллагын гишүүн эсэхийг шалгаад Redis дахь org_id override-ийг шинэчилнэ.
+// Session ID-г context-оос (ctx.KeySID) авна.
+func (s *UserService) SwitchOrganization(c context.Context, citizenID, newOrgID int) error {
+	log := middleware.LoggerOrDefault(c, s.log)
+	if s.orgUsers == nil || s.sessions == nil {
+		return ErrOrgSwitchNotSet
+	}
+
+	exists, err := s.orgUsers.OrgExists(c, newOrgID)
+	if err != nil {
+		log.Error("user_switch_org_exists_failed", zap.Int("org_id", newOrgID), zap.Error(err))
+		return err
+	}
+	if !exists {
+		return ErrOrgNotFound
+	}
+
+	if _, err := s.orgUsers.FindByOrgAndUser(c, newOrgID, citizenID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("user_switch_org_not_member", zap.Int("user_id", citizenID), zap.Int("org_id", newOrgID))
+			return ErrOrgNotMember
+		}
+		log.Error("user_switch_org_membership_failed", zap.Int("user_id", citizenID), zap.Error(err))
+		return err
+	}
+
+	sid, ok := ctx.GetValue[string](c, ctx.KeySID)
+	if !ok || sid == "" {
+		return ErrInvalidSession
+	}
+	if err := s.sessions.SetOrgOverride(c, sid, newOrgID, s.overrideTTL); err != nil {
+		log.Error("user_switch_org_store_failed", zap.Int("org_id", newOrgID), zap.Error(err))
+		return err
+	}
+
+	log.Info("user_switched_org", zap.Int("user_id", citizenID), zap.Int("org_id", newOrgID))
+	return nil
+}
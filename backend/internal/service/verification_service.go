@@ -0,0 +1,150 @@
+// Package service provides implementation for service
+//
+// File: verification_service.go
+// Description: 6-digit email verification codes with 15 minute expiry (/verify/email)
+/*
+VerificationService нь нэвтэрсэн хэрэглэгчийн email-ийг 6 оронтой кодоор
+баталгаажуулна.
+
+  - SendEmailCode: код үүсгэж SHA-256 hash-ийг ExpiresAt = now + 15min-тэй хадгалаад
+    хэрэглэгчийн email рүү илгээнэ
+  - ConfirmEmail: кодыг шалгаж (ExpiresAt > now, UsedAt IS NULL) ашигласан болгоно,
+    хэрэглэгчийн email_verified-ийг true болгоно
+
+Алдаа:
+  - буруу код         → ErrEmailCodeInvalid (400)
+  - ашиглагдсан код   → ErrEmailCodeUsed (409)
+  - хугацаа дууссан   → domain.ErrExpired (410)
+*/
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// emailCodeTTL нь email баталгаажуулах кодын хүчинтэй хугацаа
+const emailCodeTTL = 15 * time.Minute
+
+var (
+	// ErrEmailCodeInvalid нь тухайн хэрэглэгчид ийм код үүсгээгүй үед
+	ErrEmailCodeInvalid = domain.NewInvalidInput("invalid verification code", nil)
+	// ErrEmailCodeUsed нь код аль хэдийн ашиглагдсан үед
+	ErrEmailCodeUsed = domain.NewConflict("verification code already used", nil)
+	// ErrEmailCodeExpired нь кодын хугацаа дууссан үед (errors.Is(err, domain.ErrExpired))
+	ErrEmailCodeExpired = domain.NewExpired("verification code expired", nil)
+)
+
+// VerificationService нь email баталгаажуулах кодын business logic
+type VerificationService struct {
+	repo   repository.EmailVerificationRepository
+	users  repository.UserRepository
+	mailer Mailer
+	log    *zap.Logger
+}
+
+// NewVerificationService creates a verification service with a log-only mailer
+func NewVerificationService(repo repository.EmailVerificationRepository, users repository.UserRepository, log *zap.Logger) *VerificationService {
+	return &VerificationService{
+		repo:   repo,
+		users:  users,
+		mailer: NewLogMailer(log),
+		log:    log,
+	}
+}
+
+// SetMailer replaces the default log-only mailer
+func (s *VerificationService) SetMailer(m Mailer) {
+	s.mailer = m
+}
+
+// SendEmailCode нь шинэ 6 оронтой код үүсгэж хадгалаад хэрэглэгчийн email рүү илгээнэ.
+// Өмнөх кодууд хүчинтэй хэвээр (хугацаа дуустал) үлдэнэ.
+func (s *VerificationService) SendEmailCode(ctx context.Context, userID int) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return domain.NewInvalidInput("user has no email address", nil)
+	}
+
+	code, err := generateEmailCode()
+	if err != nil {
+		return fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	v := &domain.EmailVerification{
+		UserID:    userID,
+		Code:      hashEmailCode(userID, code),
+		ExpiresAt: time.Now().Add(emailCodeTTL),
+	}
+	if err := s.repo.Create(ctx, v); err != nil {
+		return fmt.Errorf("failed to store code: %w", err)
+	}
+
+	body := "Your email verification code: " + code + "\n\nThis code expires in 15 minutes."
+	if err := s.mailer.Send(ctx, user.Email, "Email verification code", body); err != nil {
+		s.log.Error("email_code_send_failed", zap.Int("user_id", userID), zap.Error(err))
+		return fmt.Errorf("failed to send code: %w", err)
+	}
+
+	s.log.Info("email_code_sent", zap.Int("user_id", userID))
+	return nil
+}
+
+// ConfirmEmail нь кодыг шалгаж ашигласан болгоно, хэрэглэгчийн email-ийг баталгаажуулна.
+func (s *VerificationService) ConfirmEmail(ctx context.Context, userID int, code string) error {
+	v, err := s.repo.LatestByCode(ctx, userID, hashEmailCode(userID, code))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrEmailCodeInvalid
+		}
+		return err
+	}
+
+	if v.IsUsed() {
+		return ErrEmailCodeUsed
+	}
+	if v.IsExpired(time.Now()) {
+		return ErrEmailCodeExpired
+	}
+
+	if err := s.repo.MarkUsed(ctx, v.ID, userID); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return ErrEmailCodeUsed
+		}
+		return err
+	}
+
+	s.log.Info("email_verified", zap.Int("user_id", userID))
+	return nil
+}
+
+// generateEmailCode нь crypto/rand-аар 000000-999999 код үүсгэнэ
+func generateEmailCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashEmailCode нь хадгалах хэлбэр: SHA-256("userID:code") hex.
+// userID-г оруулснаар өөр хэрэглэгчийн ижил код ижил hash болохгүй.
+func hashEmailCode(userID int, code string) string {
+	sum := sha256.Sum256([]byte(strconv.Itoa(userID) + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,64 @@
+// Package softdelete provides implementation for softdelete
+//
+// File: softdelete.go
+// Description: GORM scopes for deleted_date aware queries
+/*
+Package softdelete нь deleted_date багана дээр суурилсан soft delete
+шүүлтүүрүүдийг GORM scope хэлбэрээр агуулна.
+
+domain.ExtraFields-ийн gorm.DeletedAt нь model-оор хийсэн query-д
+"deleted_date IS NULL"-ийг автоматаар нэмдэг. Гэхдээ JOIN хийсэн хүснэгт,
+Table()-аар бичсэн query-д энэ шүүлтүүр ажиллахгүй тул repository бүр
+гараар бичдэг байсан. Энэ package тэдгээрийг нэг газар төвлөрүүлнэ.
+
+backend-packages/scopes нь гадны module тул энэ scope-уудыг тэнд нэмэх
+боломжгүй.
+
+Ашиглалт:
+
+	db.Scopes(softdelete.Active()).First(&user, "email = ?", email)
+
+	db.Table("organization_users AS tou").
+	    Joins("LEFT JOIN users tu ON tou.user_id = tu.id").
+	    Scopes(softdelete.ActiveOn("tou"), softdelete.ActiveOn("tu"))
+
+	db.Scopes(softdelete.DeletedOnly()).Find(&users) // хогийн сав
+*/
+package softdelete
+
+import (
+	"gorm.io/gorm"        // ORM
+	"gorm.io/gorm/clause" // Column expression
+)
+
+// Column нь soft delete баганын нэр (domain.ExtraFields.DeletedDate)
+const Column = "deleted_date"
+
+// Active нь үндсэн хүснэгтийн устгагдаагүй мөрүүдийг шүүнэ.
+// Багана нь current table-ээр qualify хийгдэх тул JOIN-тэй query-д ч давхцахгүй.
+func Active() func(db *gorm.DB) *gorm.DB {
+	return ActiveOn(clause.CurrentTable)
+}
+
+// ActiveOn нь өгөгдсөн хүснэгт (эсвэл alias)-ийн устгагдаагүй мөрүүдийг шүүнэ.
+// JOIN хийсэн хүснэгтэд ашиглана.
+func ActiveOn(table string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Eq{Column: clause.Column{Table: table, Name: Column}, Value: nil})
+	}
+}
+
+// DeletedOnly нь зөвхөн soft delete хийгдсэн мөрүүдийг буцаана.
+// gorm.DeletedAt-ийн автомат шүүлтүүрийг Unscoped-оор унтраана.
+func DeletedOnly() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Where(clause.Neq{Column: clause.Column{Table: clause.CurrentTable, Name: Column}, Value: nil})
+	}
+}
+
+// WithDeleted нь устгагдсан, устгагдаагүй бүх мөрүүдийг буцаана.
+func WithDeleted() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+}
@@ -0,0 +1,76 @@
+// Package softdelete provides implementation for softdelete
+//
+// File: softdelete_test.go
+// Description: DryRun SQL tests for the soft delete scopes
+package softdelete
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// newDryRunDB нь DB руу холбогдолгүйгээр SQL үүсгэх gorm.DB буцаана
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=x dbname=x sslmode=disable"), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               gormlogger.Discard,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+// findSQL нь scope-уудтай users query-ийн SQL-ийг буцаана
+func findSQL(t *testing.T, scopes ...func(*gorm.DB) *gorm.DB) string {
+	t.Helper()
+	var users []domain.User
+	stmt := newDryRunDB(t).Scopes(scopes...).Find(&users).Statement
+	return stmt.SQL.String()
+}
+
+func TestActive(t *testing.T) {
+	sql := findSQL(t, Active())
+
+	assert.Contains(t, sql, `"users"."deleted_date" IS NULL`)
+	assert.NotContains(t, sql, "IS NOT NULL")
+}
+
+func TestActiveOn(t *testing.T) {
+	var total int64
+	stmt := newDryRunDB(t).
+		Table("organization_users AS tou").
+		Joins("LEFT JOIN users tu ON tou.user_id = tu.id").
+		Scopes(ActiveOn("tou"), ActiveOn("tu")).
+		Count(&total).Statement
+	sql := stmt.SQL.String()
+
+	assert.Contains(t, sql, `"tou"."deleted_date" IS NULL`)
+	assert.Contains(t, sql, `"tu"."deleted_date" IS NULL`)
+}
+
+func TestDeletedOnly(t *testing.T) {
+	sql := findSQL(t, DeletedOnly())
+
+	assert.Contains(t, sql, `"users"."deleted_date" IS NOT NULL`)
+	// gorm.DeletedAt-ийн автомат "IS NULL" шүүлтүүр нэмэгдэхгүй
+	assert.NotContains(t, sql, `"deleted_date" IS NULL`)
+}
+
+func TestWithDeleted(t *testing.T) {
+	sql := findSQL(t, WithDeleted())
+
+	assert.NotContains(t, sql, "deleted_date")
+}
+
+func TestWithoutScopes_DefaultSoftDelete(t *testing.T) {
+	// Model-оор хийсэн query-д GORM өөрөө шүүлтүүр нэмдэг
+	assert.Contains(t, findSQL(t), `"users"."deleted_date" IS NULL`)
+}
@@ -0,0 +1,53 @@
+// Package util provides shared helpers for service and handler layers
+//
+// File: tree.go
+// Description: Flat parent/child list-ийг мод бүтэц рүү хөрвүүлэх helper
+package util
+
+import (
+	"sort"
+
+	"templatev25/internal/domain"
+)
+
+// BuildMenuTree нь ParentID-аар холбогдсон хавтгай menu жагсаалтыг мод бүтэц болгоно.
+//
+//   - ParentID == nil бол root node
+//   - Node бүрийн Children-ийг рекурсив байдлаар тохируулна (навч node-д хоосон slice)
+//   - Түвшин бүр Sequence, дараа нь ID-аар эрэмбэлэгдэнэ
+//   - Parent нь жагсаалтад байхгүй (orphan) menu болон түүний үр удам үр дүнд орохгүй
+func BuildMenuTree(menus []domain.Menu) []domain.Menu {
+	childrenOf := make(map[int64][]domain.Menu, len(menus))
+	var roots []domain.Menu
+	for _, m := range menus {
+		if m.ParentID == nil {
+			roots = append(roots, m)
+			continue
+		}
+		childrenOf[*m.ParentID] = append(childrenOf[*m.ParentID], m)
+	}
+
+	var attach func(nodes []domain.Menu) []domain.Menu
+	attach = func(nodes []domain.Menu) []domain.Menu {
+		if len(nodes) == 0 {
+			return []domain.Menu{}
+		}
+		sortMenus(nodes)
+		for i := range nodes {
+			nodes[i].Children = attach(childrenOf[nodes[i].ID])
+		}
+		return nodes
+	}
+
+	return attach(roots)
+}
+
+// sortMenus нь Sequence, дараа нь ID-аар өсөхөөр эрэмбэлнэ
+func sortMenus(menus []domain.Menu) {
+	sort.Slice(menus, func(i, j int) bool {
+		if menus[i].Sequence != menus[j].Sequence {
+			return menus[i].Sequence < menus[j].Sequence
+		}
+		return menus[i].ID < menus[j].ID
+	})
+}
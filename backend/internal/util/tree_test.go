@@ -0,0 +1,117 @@
+// Package util provides shared helpers for service and handler layers
+//
+// File: tree_test.go
+// Description: Unit tests for BuildMenuTree
+package util
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func menuID(id int64) *int64 { return &id }
+
+func TestBuildMenuTree_Empty(t *testing.T) {
+	assert.Equal(t, []domain.Menu{}, BuildMenuTree(nil))
+}
+
+func TestBuildMenuTree_NestedAndSorted(t *testing.T) {
+	menus := []domain.Menu{
+		{ID: 4, Name: "grandchild", ParentID: menuID(2), Sequence: 1},
+		{ID: 3, Name: "child-b", ParentID: menuID(1), Sequence: 2},
+		{ID: 2, Name: "child-a", ParentID: menuID(1), Sequence: 1},
+		{ID: 5, Name: "root-b", Sequence: 2},
+		{ID: 1, Name: "root-a", Sequence: 1},
+	}
+
+	tree := BuildMenuTree(menus)
+
+	require.Len(t, tree, 2)
+	assert.Equal(t, int64(1), tree[0].ID)
+	assert.Equal(t, int64(5), tree[1].ID)
+	assert.Empty(t, tree[1].Children)
+
+	require.Len(t, tree[0].Children, 2)
+	assert.Equal(t, int64(2), tree[0].Children[0].ID)
+	assert.Equal(t, int64(3), tree[0].Children[1].ID)
+
+	// 3 түвшний гүн хадгалагдана
+	require.Len(t, tree[0].Children[0].Children, 1)
+	assert.Equal(t, int64(4), tree[0].Children[0].Children[0].ID)
+	assert.NotNil(t, tree[0].Children[0].Children[0].Children)
+}
+
+func TestBuildMenuTree_SameSequenceOrderedByID(t *testing.T) {
+	tree := BuildMenuTree([]domain.Menu{
+		{ID: 9, Sequence: 1},
+		{ID: 7, Sequence: 1},
+	})
+
+	require.Len(t, tree, 2)
+	assert.Equal(t, int64(7), tree[0].ID)
+	assert.Equal(t, int64(9), tree[1].ID)
+}
+
+func TestBuildMenuTree_Orphans(t *testing.T) {
+	tests := []struct {
+		name    string
+		menus   []domain.Menu
+		wantIDs []int64
+	}{
+		{
+			name: "orphan is dropped",
+			menus: []domain.Menu{
+				{ID: 1, Sequence: 1},
+				{ID: 2, ParentID: menuID(99), Sequence: 1},
+			},
+			wantIDs: []int64{1},
+		},
+		{
+			name: "descendants of orphan are dropped",
+			menus: []domain.Menu{
+				{ID: 1, Sequence: 1},
+				{ID: 2, ParentID: menuID(99), Sequence: 1},
+				{ID: 3, ParentID: menuID(2), Sequence: 1},
+			},
+			wantIDs: []int64{1},
+		},
+		{
+			name: "only orphans",
+			menus: []domain.Menu{
+				{ID: 2, ParentID: menuID(99)},
+				{ID: 3, ParentID: menuID(2)},
+			},
+			wantIDs: []int64{},
+		},
+		{
+			name: "self-referencing menu is dropped",
+			menus: []domain.Menu{
+				{ID: 1, Sequence: 1},
+				{ID: 2, ParentID: menuID(2), Sequence: 1},
+			},
+			wantIDs: []int64{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := BuildMenuTree(tt.menus)
+
+			ids := []int64{}
+			var walk func(nodes []domain.Menu)
+			walk = func(nodes []domain.Menu) {
+				for _, n := range nodes {
+					ids = append(ids, n.ID)
+					walk(n.Children)
+				}
+			}
+			walk(tree)
+
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
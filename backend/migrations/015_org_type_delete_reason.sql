@@ -0,0 +1,10 @@
+-- ============================================================
+-- Migration: 015_org_type_delete_reason.sql
+-- Description: Soft-delete reason for organization types
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+ALTER TABLE organization_types ADD COLUMN IF NOT EXISTS delete_reason VARCHAR(500);
@@ -0,0 +1,15 @@
+-- ============================================================
+-- Migration: 016_news_publish_state.sql
+-- Description: Draft/published state for news
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+ALTER TABLE news ADD COLUMN IF NOT EXISTS is_published BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Өмнө нь status-аар нийтлэгдсэн мэдээнүүдийг шилжүүлэх
+UPDATE news SET is_published = TRUE WHERE status = 'published' AND is_published = FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_news_is_published ON news(is_published) WHERE deleted_date IS NULL;
@@ -0,0 +1,23 @@
+-- ============================================================
+-- Migration: 017_organization_search_vector.sql
+-- Description: Full-text search column for organizations
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- Generated tsvector column (INSERT/UPDATE үед автоматаар шинэчлэгдэнэ).
+-- 'simple' config: Кирилл/Латин үгсийг stemming-гүйгээр индексжүүлнэ.
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        to_tsvector('simple',
+            coalesce(name, '') || ' ' ||
+            coalesce(code, '') || ' ' ||
+            coalesce(register_number, ''))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_organizations_search_vector ON organizations USING GIN(search_vector);
+
+-- 009_indexes.sql-ийн expression index-ийг search_vector орлоно
+DROP INDEX IF EXISTS idx_organizations_fts;
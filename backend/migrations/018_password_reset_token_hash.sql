@@ -0,0 +1,17 @@
+-- ============================================================
+-- Migration: 018_password_reset_token_hash.sql
+-- Description: Store password reset tokens as SHA-256 hashes
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- Өмнөх токенууд plaintext хадгалагдсан тул хүчингүй болгоно
+DELETE FROM password_reset_tokens;
+
+ALTER TABLE password_reset_tokens RENAME COLUMN token TO token_hash;
+ALTER TABLE password_reset_tokens ALTER COLUMN token_hash TYPE VARCHAR(64);
+
+DROP INDEX IF EXISTS idx_password_reset_tokens_token;
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_token_hash ON password_reset_tokens(token_hash);
@@ -0,0 +1,23 @@
+-- ============================================================
+-- Migration: 019_chat_item_tags.sql
+-- Description: Tags for chat items (many-to-many)
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+CREATE TABLE IF NOT EXISTS tags (
+    id      SERIAL PRIMARY KEY,
+    name    VARCHAR(100) UNIQUE NOT NULL
+);
+
+-- chat_items хүснэгт эдгээр migration-аас гадуур (GORM) үүсдэг тул
+-- chat_item_id дээр FK тавихгүй
+CREATE TABLE IF NOT EXISTS chat_item_tags (
+    chat_item_id    INTEGER NOT NULL,
+    tag_id          INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (chat_item_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_item_tags_tag_id ON chat_item_tags(tag_id);
@@ -0,0 +1,12 @@
+-- ============================================================
+-- Migration: 020_organization_version.sql
+-- Description: Optimistic locking version column for organizations
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- PUT /organization/:id нь client-ийн илгээсэн version таарвал л шинэчилж,
+-- version-ийг 1-ээр нэмэгдүүлнэ. Таарахгүй бол 409 Conflict.
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
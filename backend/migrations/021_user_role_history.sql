@@ -0,0 +1,21 @@
+-- ============================================================
+-- Migration: 021_user_role_history.sql
+-- Description: Append-only history of role assignments/revocations
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- POST/DELETE /user-role бүр энд мөр нэмнэ (GET /user/:id/role-history)
+CREATE TABLE IF NOT EXISTS user_role_history (
+    id          SERIAL PRIMARY KEY,
+    user_id     INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id     INTEGER NOT NULL,
+    action      VARCHAR(20) NOT NULL,
+    changed_by  INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    changed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_user_role_history_action CHECK (action IN ('ROLE_ASSIGNED', 'ROLE_REVOKED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_role_history_user ON user_role_history(user_id, changed_at);
@@ -0,0 +1,12 @@
+-- ============================================================
+-- Migration: 022_org_type_enforce_role_limit.sql
+-- Description: Per organization type role limit flag
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- true бол тухайн төрлийн байгууллагад зөвхөн org_type_roles-д холбогдсон
+-- role-той хэрэглэгчийг нэмнэ (POST /orguser → 403).
+ALTER TABLE organization_types ADD COLUMN IF NOT EXISTS enforce_role_limit BOOLEAN NOT NULL DEFAULT FALSE;
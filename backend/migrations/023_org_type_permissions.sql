@@ -0,0 +1,28 @@
+-- ============================================================
+-- Migration: 023_org_type_permissions.sql
+-- Description: Permissions linked directly to organization types
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- Байгууллагын төрөлд шууд олгосон permission. Тухайн төрлийн байгууллагын
+-- гишүүн (organization_users) бүр role-оос гадна эдгээр permission-тэй болно.
+-- PUT/POST /orgtype/:id/permissions нь бүх мөрийг солино (replace semantics).
+CREATE TABLE IF NOT EXISTS org_type_permissions (
+    type_id          INTEGER NOT NULL REFERENCES organization_types(id) ON DELETE CASCADE,
+    permission_id    INTEGER NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    created_date     TIMESTAMPTZ DEFAULT NOW(),
+    created_user_id  INTEGER,
+    created_org_id   INTEGER,
+    updated_date     TIMESTAMPTZ DEFAULT NOW(),
+    updated_user_id  INTEGER,
+    updated_org_id   INTEGER,
+    deleted_date     TIMESTAMPTZ,
+    deleted_user_id  INTEGER,
+    deleted_org_id   INTEGER,
+    PRIMARY KEY (type_id, permission_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_type_permissions_permission_id ON org_type_permissions(permission_id);
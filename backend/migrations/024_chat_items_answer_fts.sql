@@ -0,0 +1,19 @@
+-- ============================================================
+-- Migration: 024_chat_items_answer_fts.sql
+-- Description: Full-text index for GET /chat?q= (CHAT_SEARCH_FULLTEXT=true)
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- chat_items нь GORM-оор үүсдэг тул хүснэгт байгаа үед л индекс үүсгэнэ.
+-- Expression нь ChatItemRepository.Search-ийн to_tsvector('simple', answer)-тай яг ижил байх ёстой.
+DO $$
+BEGIN
+    IF to_regclass('chat_items') IS NOT NULL THEN
+        CREATE INDEX IF NOT EXISTS idx_chat_items_answer_fts
+            ON chat_items USING GIN (to_tsvector('simple', answer));
+    END IF;
+END
+$$;
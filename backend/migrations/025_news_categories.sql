@@ -0,0 +1,34 @@
+-- ============================================================
+-- Migration: 025_news_categories.sql
+-- Description: News categories (/news/category) and news.category_id filter
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- 007_news.sql-ээр үүссэн орчинд хүснэгт аль хэдийн байгаа тул бүх алхам idempotent.
+CREATE TABLE IF NOT EXISTS news_categories (
+    id               SERIAL PRIMARY KEY,
+    name             VARCHAR(100) NOT NULL,
+    code             VARCHAR(50) UNIQUE NOT NULL,
+    created_date     TIMESTAMPTZ DEFAULT NOW(),
+    updated_date     TIMESTAMPTZ DEFAULT NOW(),
+    deleted_date     TIMESTAMPTZ
+);
+
+-- domain.ExtraFields-ийн audit баганууд (007-д байхгүй)
+ALTER TABLE news_categories
+    ADD COLUMN IF NOT EXISTS created_user_id INTEGER,
+    ADD COLUMN IF NOT EXISTS created_org_id  INTEGER,
+    ADD COLUMN IF NOT EXISTS updated_user_id INTEGER,
+    ADD COLUMN IF NOT EXISTS updated_org_id  INTEGER,
+    ADD COLUMN IF NOT EXISTS deleted_user_id INTEGER,
+    ADD COLUMN IF NOT EXISTS deleted_org_id  INTEGER;
+
+-- Ангилал soft delete хийгдэхэд NewsCategoryRepository.Delete нь category_id-г NULL болгоно;
+-- ON DELETE SET NULL нь hard delete-д зориулагдсан.
+ALTER TABLE news
+    ADD COLUMN IF NOT EXISTS category_id INTEGER REFERENCES news_categories(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_news_category_id ON news(category_id);
@@ -0,0 +1,20 @@
+-- ============================================================
+-- Migration: 026_email_verifications.sql
+-- Description: 6-digit email verification codes (POST /verify/email, 15 min expiry)
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- code нь SHA-256 hash (hex); plain код хадгалагдахгүй
+CREATE TABLE IF NOT EXISTS email_verifications (
+    id          SERIAL PRIMARY KEY,
+    user_id     INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code        VARCHAR(64) NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    used_at     TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verifications_user_id ON email_verifications(user_id);
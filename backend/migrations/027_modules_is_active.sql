@@ -0,0 +1,14 @@
+-- ============================================================
+-- Migration: 027_modules_is_active.sql
+-- Description: modules.is_active NOT NULL DEFAULT TRUE (inactive modules hide their permissions)
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- NULL-ийг идэвхтэй гэж үзэж байсан тул TRUE болгоно
+UPDATE modules SET is_active = TRUE WHERE is_active IS NULL;
+
+ALTER TABLE modules ALTER COLUMN is_active SET DEFAULT TRUE;
+ALTER TABLE modules ALTER COLUMN is_active SET NOT NULL;
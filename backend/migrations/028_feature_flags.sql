@@ -0,0 +1,24 @@
+-- ============================================================
+-- Migration: 028_feature_flags.sql
+-- Description: System-level feature flags (middleware.FeatureGate returns 404 when inactive)
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- valid_from/valid_to NULL бол тухайн тал хязгааргүй; идэвхтэй хугацаа [valid_from, valid_to)
+CREATE TABLE IF NOT EXISTS feature_flags (
+    id           SERIAL PRIMARY KEY,
+    code         VARCHAR(100) NOT NULL UNIQUE,
+    enabled      BOOLEAN NOT NULL DEFAULT FALSE,
+    description  VARCHAR(255),
+    valid_from   TIMESTAMPTZ,
+    valid_to     TIMESTAMPTZ,
+    CHECK (valid_from IS NULL OR valid_to IS NULL OR valid_from < valid_to)
+);
+
+-- GET /user/search нь анхнаасаа идэвхтэй
+INSERT INTO feature_flags (code, enabled, description)
+VALUES ('user_search', TRUE, 'GET /user/search free-text user search')
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,28 @@
+-- ============================================================
+-- Migration: 029_menu_permissions.sql
+-- Description: Permissions required to see a menu
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- Menu-г харахад шаардагдах permission-ууд. Хэрэглэгч бүгдийг нь эзэмшсэн
+-- (role эсвэл байгууллагын төрлөөр) бол menu /me/menu-д харагдана.
+-- POST /menu/:id/permissions нь бүх мөрийг солино (replace semantics).
+CREATE TABLE IF NOT EXISTS menu_permissions (
+    menu_id          INTEGER NOT NULL REFERENCES menus(id) ON DELETE CASCADE,
+    permission_id    INTEGER NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    created_date     TIMESTAMPTZ DEFAULT NOW(),
+    created_user_id  INTEGER,
+    created_org_id   INTEGER,
+    updated_date     TIMESTAMPTZ DEFAULT NOW(),
+    updated_user_id  INTEGER,
+    updated_org_id   INTEGER,
+    deleted_date     TIMESTAMPTZ,
+    deleted_user_id  INTEGER,
+    deleted_org_id   INTEGER,
+    PRIMARY KEY (menu_id, permission_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_menu_permissions_permission_id ON menu_permissions(permission_id);
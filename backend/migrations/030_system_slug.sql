@@ -0,0 +1,34 @@
+-- ============================================================
+-- Migration: 030_system_slug.sql
+-- Description: URL-safe unique slug for systems
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+ALTER TABLE systems ADD COLUMN IF NOT EXISTS slug VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Одоо байгаа system-үүдэд SystemService.Create-ийн slugify-тай ижил дүрмээр slug оноох:
+-- name (ASCII үсэг/тоо үлдэхгүй бол code) → жижиг үсэг, зай → '-', бусад тэмдэгт хасна.
+-- Давхцвал id-ийн дарааллаар -2, -3 залгана.
+WITH base AS (
+    SELECT id,
+           COALESCE(
+               NULLIF(trim(BOTH '-' FROM regexp_replace(regexp_replace(regexp_replace(lower(name), '\s+', '-', 'g'), '[^a-z0-9-]', '', 'g'), '-+', '-', 'g')), ''),
+               NULLIF(trim(BOTH '-' FROM regexp_replace(regexp_replace(regexp_replace(lower(code), '\s+', '-', 'g'), '[^a-z0-9-]', '', 'g'), '-+', '-', 'g')), ''),
+               'system'
+           ) AS slug
+    FROM systems
+    WHERE slug = '' AND deleted_date IS NULL
+), numbered AS (
+    SELECT id, slug, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS n
+    FROM base
+)
+UPDATE systems s
+SET slug = CASE WHEN n.n = 1 THEN n.slug ELSE n.slug || '-' || n.n END
+FROM numbered n
+WHERE s.id = n.id;
+
+-- Soft delete хийгдсэн system-ийн slug дахин ашиглагдах боломжтой
+CREATE UNIQUE INDEX IF NOT EXISTS idx_systems_slug ON systems(slug) WHERE slug <> '' AND deleted_date IS NULL;
@@ -0,0 +1,30 @@
+-- ============================================================
+-- Migration: 031_tenant_id.sql
+-- Description: tenant_id column on API logs and notifications (SERVER_TENANT_ID)
+-- Database: gerege_db
+-- Schema: template_backend
+-- ============================================================
+
+SET search_path TO template_backend, public;
+
+-- API log: middleware.Tenant-ийн тавьсан tenant ID
+ALTER TABLE logs ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_logs_tenant_id ON logs(tenant_id);
+
+-- Notification: хуучин tenant баганыг tenant_id болгож нэрлэнэ (өгөгдөл хадгалагдана)
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = 'template_backend' AND table_name = 'notifications' AND column_name = 'tenant'
+    ) AND NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = 'template_backend' AND table_name = 'notifications' AND column_name = 'tenant_id'
+    ) THEN
+        ALTER TABLE notifications RENAME COLUMN tenant TO tenant_id;
+    END IF;
+END $$;
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT '';
+UPDATE notifications SET tenant_id = '' WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_tenant_id ON notifications(tenant_id);
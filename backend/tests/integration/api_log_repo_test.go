@@ -0,0 +1,162 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: api_log_repo_test.go
+// Description: API log list filter integration tests
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/repository"
+
+	"git.gerege.mn/backend-packages/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedAPILogs нь 100 log бичнэ:
+//   - i%2: user 1 / user 2
+//   - i%4: GET, POST, PUT, DELETE
+//   - i%5 == 0 бол 500, i%5 == 1 бол 404, бусад нь 200
+//   - i < 50 бол /api/v1/user/..., бусад нь /api/v1/news
+//   - created_date: 2024-01-01-ээс эхлэн өдөр бүр нэг log (2024-01-01 ... 2024-04-09)
+func seedAPILogs(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	methods := []string{"GET", "POST", "PUT", "DELETE"}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	logs := make([]domain.APILog, 0, 100)
+	for i := 0; i < 100; i++ {
+		userID := int64(i%2 + 1)
+		status := 200
+		switch i % 5 {
+		case 0:
+			status = 500
+		case 1:
+			status = 404
+		}
+		path := "/api/v1/news"
+		if i < 50 {
+			path = "/api/v1/user/" + itoa(i)
+		}
+		logs = append(logs, domain.APILog{
+			UserId:      &userID,
+			Method:      methods[i%4],
+			Path:        path,
+			StatusCode:  status,
+			CreatedDate: start.AddDate(0, 0, i),
+		})
+	}
+	require.NoError(t, db.CreateInBatches(&logs, 50).Error)
+}
+
+func TestAPILogRepository_List_Filters(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewAPILogRepository(db)
+	ctx := CreateTestContext()
+	seedAPILogs(t, db)
+
+	intPtr := func(v int) *int { return &v }
+	int64Ptr := func(v int64) *int64 { return &v }
+	page := common.PaginationQuery{Page: 1, Size: 200}
+
+	tests := []struct {
+		name      string
+		query     dto.APILogListQuery
+		wantTotal int64
+		check     func(t *testing.T, l domain.APILog)
+	}{
+		{
+			name:      "no filter",
+			query:     dto.APILogListQuery{PaginationQuery: page},
+			wantTotal: 100,
+		},
+		{
+			name:      "user_id",
+			query:     dto.APILogListQuery{UserID: int64Ptr(1), PaginationQuery: page},
+			wantTotal: 50,
+			check:     func(t *testing.T, l domain.APILog) { assert.Equal(t, int64(1), *l.UserId) },
+		},
+		{
+			name:      "method",
+			query:     dto.APILogListQuery{Method: "POST", PaginationQuery: page},
+			wantTotal: 25,
+			check:     func(t *testing.T, l domain.APILog) { assert.Equal(t, "POST", l.Method) },
+		},
+		{
+			name:      "status_gte",
+			query:     dto.APILogListQuery{StatusGTE: intPtr(400), PaginationQuery: page},
+			wantTotal: 40,
+			check:     func(t *testing.T, l domain.APILog) { assert.GreaterOrEqual(t, l.StatusCode, 400) },
+		},
+		{
+			name:      "path",
+			query:     dto.APILogListQuery{Path: "/api/v1/user", PaginationQuery: page},
+			wantTotal: 50,
+			check:     func(t *testing.T, l domain.APILog) { assert.Contains(t, l.Path, "/api/v1/user") },
+		},
+		{
+			name: "from/to inclusive",
+			// 2024-01-11 (i=10) ... 2024-01-20 (i=19)
+			query:     dto.APILogListQuery{From: "2024-01-11", To: "2024-01-20", PaginationQuery: page},
+			wantTotal: 10,
+		},
+		{
+			name: "combined",
+			// i%2 == 0 (user 1), i%5 == 0 (500), i < 50 → i = 0, 10, 20, 30, 40
+			query: dto.APILogListQuery{
+				UserID:          int64Ptr(1),
+				StatusGTE:       intPtr(500),
+				Path:            "/api/v1/user",
+				PaginationQuery: page,
+			},
+			wantTotal: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, total, _, _, err := repo.List(ctx, tt.query)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+			assert.Len(t, items, int(tt.wantTotal))
+			if tt.check != nil {
+				for _, l := range items {
+					tt.check(t, l)
+				}
+			}
+		})
+	}
+}
+
+func TestAPILogRepository_List_Pagination(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewAPILogRepository(db)
+	ctx := CreateTestContext()
+	seedAPILogs(t, db)
+
+	items, total, page, size, err := repo.List(ctx, dto.APILogListQuery{PaginationQuery: common.PaginationQuery{Page: 2, Size: 20}})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), total)
+	assert.Equal(t, 2, page)
+	assert.Equal(t, 20, size)
+	require.Len(t, items, 20)
+	// created_date DESC: 2-р хуудас нь i = 79 ... 60
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local).AddDate(0, 0, 79).Unix(), items[0].CreatedDate.Unix())
+}
+
+func TestAPILogRepository_List_InvalidDate(t *testing.T) {
+	repo := repository.NewAPILogRepository(GetTestDBWithTx(t))
+
+	_, _, _, _, err := repo.List(CreateTestContext(), dto.APILogListQuery{From: "not-a-date"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
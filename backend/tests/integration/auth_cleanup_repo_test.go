@@ -0,0 +1,103 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: auth_cleanup_repo_test.go
+// Description: Session revocation and login history cleanup integration tests
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthRepository_DeleteExpiredSessions(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewAuthRepository(db, nil)
+	ctx := CreateTestContext()
+	user := SeedTestUser(t, db)
+
+	now := time.Now()
+	recentlyRevoked := now.Add(-24 * time.Hour)
+	longRevoked := now.Add(-31 * 24 * time.Hour)
+
+	sessions := []domain.Session{
+		{ID: "active", UserID: user.Id, ExpiresAt: now.Add(time.Hour)},
+		{ID: "expired", UserID: user.Id, ExpiresAt: now.Add(-time.Hour)},
+		{ID: "revoked-recent", UserID: user.Id, ExpiresAt: now.Add(time.Hour), RevokedAt: &recentlyRevoked},
+		{ID: "revoked-old", UserID: user.Id, ExpiresAt: now.Add(time.Hour), RevokedAt: &longRevoked},
+	}
+	for i := range sessions {
+		require.NoError(t, repo.CreateSession(ctx, &sessions[i]))
+	}
+
+	deleted, err := repo.DeleteExpiredSessions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	var remaining []string
+	require.NoError(t, db.Unscoped().Model(&domain.Session{}).Order("id").Pluck("id", &remaining).Error)
+	assert.Equal(t, []string{"active", "revoked-recent"}, remaining)
+}
+
+func TestAuthRepository_DeleteOldLoginHistory(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewAuthRepository(db, nil)
+	ctx := CreateTestContext()
+
+	for _, email := range []string{"old@example.com", "recent@example.com"} {
+		require.NoError(t, repo.CreateLoginHistory(ctx, &domain.LoginHistory{
+			Email:       email,
+			LoginMethod: "local",
+			Success:     true,
+		}))
+	}
+	require.NoError(t, db.Exec(
+		"UPDATE login_history SET created_date = ? WHERE email = ?",
+		time.Now().AddDate(0, 0, -91), "old@example.com",
+	).Error)
+
+	deleted, err := repo.DeleteOldLoginHistory(ctx, 90)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	var remaining []string
+	require.NoError(t, db.Unscoped().Model(&domain.LoginHistory{}).Pluck("email", &remaining).Error)
+	assert.Equal(t, []string{"recent@example.com"}, remaining)
+}
+
+func TestAuthRepository_RevokeOtherUserSessions(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewAuthRepository(db, nil)
+	ctx := CreateTestContext()
+	users := SeedTestUsers(t, db, 2)
+	user, other := users[0], users[1]
+
+	now := time.Now()
+	sessions := []domain.Session{
+		{ID: "current", UserID: user.Id, ExpiresAt: now.Add(time.Hour)},
+		{ID: "laptop", UserID: user.Id, ExpiresAt: now.Add(time.Hour)},
+		{ID: "phone", UserID: user.Id, ExpiresAt: now.Add(time.Hour)},
+		{ID: "other-user", UserID: other.Id, ExpiresAt: now.Add(time.Hour)},
+	}
+	for i := range sessions {
+		require.NoError(t, repo.CreateSession(ctx, &sessions[i]))
+	}
+
+	require.NoError(t, repo.RevokeOtherUserSessions(ctx, user.Id, "current", "logout others"))
+
+	var revoked []string
+	require.NoError(t, db.Model(&domain.Session{}).Where("revoked_at IS NOT NULL").Order("id").Pluck("id", &revoked).Error)
+	assert.Equal(t, []string{"laptop", "phone"}, revoked)
+
+	active, err := repo.GetActiveUserSessions(ctx, user.Id)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "current", active[0].ID)
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"templatev25/internal/middleware"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/stretchr/testify/assert"
@@ -210,14 +212,7 @@ func TestAuthenticationRequired(t *testing.T) {
 func TestSecurityHeaders(t *testing.T) {
 	app := fiber.New()
 
-	// Simulate SecurityHeaders middleware
-	app.Use(func(c *fiber.Ctx) error {
-		c.Set("X-Content-Type-Options", "nosniff")
-		c.Set("X-Frame-Options", "DENY")
-		c.Set("X-XSS-Protection", "1; mode=block")
-		c.Set("Referrer-Policy", "strict-origin-when-cross-origin")
-		return c.Next()
-	})
+	app.Use(middleware.SecurityHeaders())
 
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"ok": true})
@@ -297,3 +297,103 @@ func TestChatItemRepository_List(t *testing.T) {
 		})
 	}
 }
+
+func TestChatItemRepository_Tags(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewChatItemRepository(db)
+	ctx := CreateTestContext()
+
+	item := SeedTestChatItem(t, db)
+	faq := SeedTestTag(t, db, "faq")
+	billing := SeedTestTag(t, db, "billing")
+
+	t.Run("add tags - duplicates are ignored", func(t *testing.T) {
+		require.NoError(t, repo.AddTags(ctx, item.ID, []int{faq.ID, billing.ID, faq.ID}))
+		require.NoError(t, repo.AddTags(ctx, item.ID, []int{faq.ID}))
+
+		tags, err := repo.GetTags(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Tag{billing, faq}, tags)
+	})
+
+	t.Run("ByID preloads tags", func(t *testing.T) {
+		got, err := repo.ByID(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Tag{billing, faq}, got.Tags)
+	})
+
+	t.Run("unknown tag - nothing added", func(t *testing.T) {
+		other := SeedTestChatItem(t, db)
+
+		err := repo.AddTags(ctx, other.ID, []int{faq.ID, 99999})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		tags, err := repo.GetTags(ctx, other.ID)
+		require.NoError(t, err)
+		assert.Empty(t, tags)
+	})
+
+	t.Run("remove tag", func(t *testing.T) {
+		require.NoError(t, repo.RemoveTag(ctx, item.ID, billing.ID))
+		assert.ErrorIs(t, repo.RemoveTag(ctx, item.ID, billing.ID), domain.ErrNotFound)
+
+		tags, err := repo.GetTags(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Tag{faq}, tags)
+	})
+}
+
+func TestChatItemRepository_Search(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	ctx := CreateTestContext()
+
+	seed := []domain.ChatItem{
+		{Key: "search.hours", Answer: "office hours are 9 to 6"},
+		{Key: "search.office", Answer: "our office is in ulaanbaatar"},
+		{Key: "search.percent", Answer: "discount is 100% for members_only"},
+	}
+	require.NoError(t, db.Create(&seed).Error)
+	p := common.PaginationQuery{Page: 1, Size: 10}
+
+	tests := []struct {
+		name     string
+		fullText bool
+		query    string
+		wantKeys []string
+	}{
+		{name: "ilike - substring", query: "FFICE", wantKeys: []string{"search.hours", "search.office"}},
+		{name: "ilike - percent is literal", query: "100%", wantKeys: []string{"search.percent"}},
+		{name: "ilike - underscore is literal", query: "r_o", wantKeys: []string{}},
+		{name: "ilike - underscore matches itself", query: "s_o", wantKeys: []string{"search.percent"}},
+		{name: "fulltext - word prefix", fullText: true, query: "ulaan", wantKeys: []string{"search.office"}},
+		{name: "fulltext - all words must match", fullText: true, query: "office hours", wantKeys: []string{"search.hours"}},
+		{name: "fulltext - no match", fullText: true, query: "ffice", wantKeys: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewChatItemRepositoryWithFullText(db, tt.fullText)
+
+			items, total, page, size, err := repo.Search(ctx, tt.query, p)
+
+			require.NoError(t, err)
+			keys := make([]string, 0, len(items))
+			for _, it := range items {
+				keys = append(keys, it.Key)
+			}
+			assert.ElementsMatch(t, tt.wantKeys, keys)
+			assert.Equal(t, int64(len(tt.wantKeys)), total)
+			assert.Equal(t, 1, page)
+			assert.Equal(t, 10, size)
+		})
+	}
+
+	t.Run("empty query lists everything", func(t *testing.T) {
+		for _, fullText := range []bool{false, true} {
+			repo := repository.NewChatItemRepositoryWithFullText(db, fullText)
+			_, total, _, _, err := repo.Search(ctx, "  ", p)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, total, int64(len(seed)))
+		}
+	})
+}
@@ -0,0 +1,61 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: feature_flag_repo_test.go
+// Description: Integration tests for feature flag repository (List, GetByCode, Enable, Disable)
+package integration
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagRepository(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewFeatureFlagRepository(db)
+	ctx := CreateTestContext()
+
+	require.NoError(t, db.Create(&domain.FeatureFlag{Code: "beta_b", Description: "B"}).Error)
+	require.NoError(t, db.Create(&domain.FeatureFlag{Code: "beta_a", Enabled: true, Description: "A"}).Error)
+
+	t.Run("list ordered by code", func(t *testing.T) {
+		items, err := repo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, "beta_a", items[0].Code)
+		assert.Equal(t, "beta_b", items[1].Code)
+	})
+
+	t.Run("get by code", func(t *testing.T) {
+		flag, err := repo.GetByCode(ctx, "beta_a")
+		require.NoError(t, err)
+		assert.True(t, flag.Enabled)
+	})
+
+	t.Run("get unknown - not found", func(t *testing.T) {
+		_, err := repo.GetByCode(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("enable and disable", func(t *testing.T) {
+		require.NoError(t, repo.Enable(ctx, "beta_b"))
+		flag, err := repo.GetByCode(ctx, "beta_b")
+		require.NoError(t, err)
+		assert.True(t, flag.Enabled)
+
+		require.NoError(t, repo.Disable(ctx, "beta_b"))
+		flag, err = repo.GetByCode(ctx, "beta_b")
+		require.NoError(t, err)
+		assert.False(t, flag.Enabled)
+	})
+
+	t.Run("enable unknown - not found", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Enable(ctx, "missing"), domain.ErrNotFound)
+	})
+}
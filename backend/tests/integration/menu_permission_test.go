@@ -0,0 +1,120 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: menu_permission_test.go
+// Description: Integration tests for permissions required by menus
+package integration
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"git.gerege.mn/backend-packages/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// seedPermissionMenu нь key-тэй идэвхтэй menu үүсгэнэ
+func seedPermissionMenu(t *testing.T, db *gorm.DB, key string) domain.Menu {
+	t.Helper()
+	menu := domain.Menu{Key: key, Name: key, Path: "/" + key, Sequence: 1, IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&menu).Error)
+	return menu
+}
+
+// menuPermissionIDs нь menu-д холбогдсон permission ID-уудыг буцаана
+func menuPermissionIDs(t *testing.T, repo repository.MenuRepository, menuID int64) []int {
+	t.Helper()
+	links, err := repo.ListMenuPermissions(CreateTestContext())
+	require.NoError(t, err)
+	ids := []int{}
+	for _, l := range links {
+		if l.MenuID == menuID {
+			ids = append(ids, l.PermissionID)
+		}
+	}
+	return ids
+}
+
+func TestMenuRepository_SetPermissions(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewMenuRepository(db, &config.Config{})
+	ctx := CreateTestContext()
+
+	_, perms := seedOrgTypePermissions(t, db, 3)
+	menu := seedPermissionMenu(t, db, "reports")
+
+	steps := []struct {
+		name string
+		set  []int
+		want []int
+	}{
+		{name: "add two", set: []int{perms[0].ID, perms[1].ID}, want: []int{perms[0].ID, perms[1].ID}},
+		{name: "replace with third", set: []int{perms[2].ID}, want: []int{perms[2].ID}},
+		{name: "re-add previously removed", set: []int{perms[0].ID, perms[2].ID}, want: []int{perms[0].ID, perms[2].ID}},
+		{name: "clear", set: []int{}, want: []int{}},
+	}
+
+	for _, st := range steps {
+		t.Run(st.name, func(t *testing.T) {
+			require.NoError(t, repo.SetPermissions(ctx, menu.ID, st.set))
+			assert.ElementsMatch(t, st.want, menuPermissionIDs(t, repo, menu.ID))
+		})
+	}
+}
+
+func TestMenuService_ListByUserRoles_RequiredPermissions(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	ctx := CreateTestContext()
+	menuRepo := repository.NewMenuRepository(db, &config.Config{})
+	perms := service.NewPermissionService(repository.NewPermissionRepository(db), nil, zap.NewNop())
+	svc := service.NewMenuServiceWithPermissions(menuRepo, nil, perms)
+
+	// member нь байгууллагын төрлөөр зөвхөн orgPerms[0]-ийг эзэмшинэ
+	orgType, orgPerms := seedOrgTypePermissions(t, db, 2)
+	require.NoError(t, repository.NewOrganizationTypeRepository(db).AddPermissions(ctx, orgType.Id, []int{orgPerms[0].ID}))
+	org := domain.Organization{Name: "Menu Org", TypeId: orgType.Id, IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&org).Error)
+	users := SeedTestUsers(t, db, 2)
+	member, outsider := users[0], users[1]
+	require.NoError(t, db.Create(&domain.OrganizationUser{OrgId: org.Id, UserId: member.Id}).Error)
+
+	granted := seedPermissionMenu(t, db, "granted")
+	partial := seedPermissionMenu(t, db, "partial")
+	require.NoError(t, svc.SetPermissions(ctx, granted.ID, []int{orgPerms[0].ID}))
+	require.NoError(t, svc.SetPermissions(ctx, partial.ID, []int{orgPerms[0].ID, orgPerms[1].ID}))
+
+	menuIDs := func(t *testing.T, userID int) []int64 {
+		t.Helper()
+		menus, err := svc.ListByUserRoles(ctx, userID)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(menus))
+		for _, m := range menus {
+			ids = append(ids, m.ID)
+		}
+		return ids
+	}
+
+	t.Run("user holding all required permissions sees the menu", func(t *testing.T) {
+		ids := menuIDs(t, member.Id)
+		assert.Contains(t, ids, granted.ID)
+		assert.NotContains(t, ids, partial.ID)
+	})
+
+	t.Run("user without the required permission can't see the menu", func(t *testing.T) {
+		ids := menuIDs(t, outsider.Id)
+		assert.NotContains(t, ids, granted.ID)
+		assert.NotContains(t, ids, partial.ID)
+	})
+
+	t.Run("clearing requirements hides the menu", func(t *testing.T) {
+		require.NoError(t, svc.SetPermissions(ctx, granted.ID, []int{}))
+		assert.NotContains(t, menuIDs(t, member.Id), granted.ID)
+	})
+}
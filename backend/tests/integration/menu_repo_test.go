@@ -267,3 +267,43 @@ func TestMenuRepository_ListAll(t *testing.T) {
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, len(menus), 5)
 }
+
+func TestMenuRepository_Reorder(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewMenuRepository(db, &config.Config{})
+	ctx := CreateTestContext()
+
+	a := seedPermissionMenu(t, db, "reorder-a")
+	b := seedPermissionMenu(t, db, "reorder-b")
+	c := seedPermissionMenu(t, db, "reorder-c")
+
+	sequenceOf := func(id int64) int64 {
+		t.Helper()
+		m, err := repo.ByID(ctx, id)
+		require.NoError(t, err)
+		return m.Sequence
+	}
+
+	t.Run("success - all sequences updated in one statement", func(t *testing.T) {
+		err := repo.Reorder(ctx, []dto.MenuReorderItem{
+			{ID: c.ID, Sequence: 1},
+			{ID: a.ID, Sequence: 2},
+			{ID: b.ID, Sequence: 3},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(2), sequenceOf(a.ID))
+		assert.Equal(t, int64(3), sequenceOf(b.ID))
+		assert.Equal(t, int64(1), sequenceOf(c.ID))
+	})
+
+	t.Run("error - unknown id rolls back", func(t *testing.T) {
+		err := repo.Reorder(ctx, []dto.MenuReorderItem{
+			{ID: a.ID, Sequence: 10},
+			{ID: 999999, Sequence: 11},
+		})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		assert.Equal(t, int64(2), sequenceOf(a.ID), "partial update must not persist")
+	})
+}
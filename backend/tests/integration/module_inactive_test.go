@@ -0,0 +1,101 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: module_inactive_test.go
+// Description: Integration tests for hiding permissions of inactive modules and rejecting such systems on org types
+package integration
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/repository"
+
+	"git.gerege.mn/backend-packages/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedModuleWithPermission нь system-д module болон нэг permission үүсгэнэ
+func seedModuleWithPermission(t *testing.T, db *gorm.DB, systemID int, code string, active bool) (domain.Module, domain.Permission) {
+	t.Helper()
+	module := domain.Module{SystemID: systemID, Code: code, Name: code, IsActive: boolPtr(active)}
+	require.NoError(t, db.Create(&module).Error)
+
+	perm := domain.Permission{SystemID: systemID, ModuleID: module.ID, Code: code + ".read", Name: code + " read", IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&perm).Error)
+	return module, perm
+}
+
+// listPermissionIDs нь system-ийн permission ID-уудыг буцаана
+func listPermissionIDs(t *testing.T, repo repository.PermissionRepository, systemID int, includeInactive bool) []int {
+	t.Helper()
+	items, _, _, _, err := repo.List(CreateTestContext(), dto.PermissionQuery{
+		PaginationQuery:        common.PaginationQuery{Page: 1, Size: 100},
+		SystemID:               systemID,
+		IncludeInactiveModules: includeInactive,
+	})
+	require.NoError(t, err)
+	return permissionIDs(items)
+}
+
+func TestPermissionRepository_List_HidesInactiveModules(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewPermissionRepository(db)
+	system := SeedTestSystem(t, db)
+
+	activeModule, activePerm := seedModuleWithPermission(t, db, system.ID, "inactive_test_a", true)
+	_, inactivePerm := seedModuleWithPermission(t, db, system.ID, "inactive_test_b", false)
+
+	t.Run("inactive module permissions hidden by default", func(t *testing.T) {
+		assert.ElementsMatch(t, []int{activePerm.ID}, listPermissionIDs(t, repo, system.ID, false))
+	})
+
+	t.Run("include_inactive_modules shows all", func(t *testing.T) {
+		assert.ElementsMatch(t, []int{activePerm.ID, inactivePerm.ID}, listPermissionIDs(t, repo, system.ID, true))
+	})
+
+	t.Run("deactivating a module hides its permissions", func(t *testing.T) {
+		require.NoError(t, db.Model(&domain.Module{}).Where("id = ?", activeModule.ID).Update("is_active", false).Error)
+		assert.Empty(t, listPermissionIDs(t, repo, system.ID, false))
+
+		require.NoError(t, db.Model(&domain.Module{}).Where("id = ?", activeModule.ID).Update("is_active", true).Error)
+		assert.ElementsMatch(t, []int{activePerm.ID}, listPermissionIDs(t, repo, system.ID, false))
+	})
+}
+
+func TestOrganizationTypeRepository_AddSystems_RejectsInactiveModules(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationTypeRepository(db)
+	ctx := CreateTestContext()
+
+	orgType := domain.OrganizationType{Code: "MOD_INACTIVE", Name: "Module Inactive Test"}
+	require.NoError(t, db.Create(&orgType).Error)
+
+	mixed := SeedTestSystem(t, db)
+	seedModuleWithPermission(t, db, mixed.ID, "mixed_on", true)
+	seedModuleWithPermission(t, db, mixed.ID, "mixed_off", false)
+
+	allInactive := SeedTestSystem(t, db)
+	seedModuleWithPermission(t, db, allInactive.ID, "dead_1", false)
+	seedModuleWithPermission(t, db, allInactive.ID, "dead_2", false)
+
+	noModules := SeedTestSystem(t, db)
+
+	require.NoError(t, repo.AddSystems(ctx, orgType.Id, []int{mixed.ID, noModules.ID}))
+
+	err := repo.AddSystems(ctx, orgType.Id, []int{mixed.ID, allInactive.ID})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	// Татгалзсан үед өмнөх холбоос хэвээр үлдэнэ
+	systems, err := repo.Systems(ctx, orgType.Id)
+	require.NoError(t, err)
+	ids := make([]int, 0, len(systems))
+	for _, s := range systems {
+		ids = append(ids, s.ID)
+	}
+	assert.ElementsMatch(t, []int{mixed.ID, noModules.ID}, ids)
+}
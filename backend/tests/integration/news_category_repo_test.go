@@ -0,0 +1,109 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: news_category_repo_test.go
+// Description: News category repository integration tests
+package integration
+
+import (
+	"errors"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/repository"
+
+	"git.gerege.mn/backend-packages/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewsCategoryRepository_CRUD(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewNewsCategoryRepository(db)
+	ctx := CreateTestContext()
+
+	require.NoError(t, repo.Create(ctx, domain.NewsCategory{Name: "Sport", Code: "sport"}))
+	require.NoError(t, repo.Create(ctx, domain.NewsCategory{Name: "Art", Code: "art"}))
+
+	items, total, _, _, err := repo.List(ctx, common.PaginationQuery{Page: 1, Size: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Art", items[0].Name, "ordered by name")
+
+	sport := items[1]
+	require.NoError(t, repo.Update(ctx, sport.Id, domain.NewsCategory{Name: "Sports", Code: "sport"}))
+	var got domain.NewsCategory
+	require.NoError(t, db.First(&got, sport.Id).Error)
+	assert.Equal(t, "Sports", got.Name)
+
+	err = repo.Update(ctx, 999999, domain.NewsCategory{Name: "x", Code: "x"})
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+
+	require.NoError(t, repo.Delete(ctx, sport.Id))
+	_, total, _, _, err = repo.List(ctx, common.PaginationQuery{Page: 1, Size: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	err = repo.Delete(ctx, sport.Id)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "already deleted")
+}
+
+func TestNewsRepository_ListByCategory(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewNewsRepository(db)
+	ctx := CreateTestContext()
+
+	sport := SeedTestNewsCategory(t, db, "sport")
+	art := SeedTestNewsCategory(t, db, "art")
+	for i, categoryID := range []*int{&sport.Id, &sport.Id, &art.Id, nil} {
+		news := SeedTestNews(t, db)
+		require.NoError(t, db.Model(&news).Update("category_id", categoryID).Error, i)
+	}
+
+	tests := []struct {
+		name       string
+		categoryID *int
+		wantTotal  int64
+	}{
+		{name: "no filter", categoryID: nil, wantTotal: 4},
+		{name: "sport", categoryID: &sport.Id, wantTotal: 2},
+		{name: "art", categoryID: &art.Id, wantTotal: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, total, _, _, err := repo.List(ctx, dto.NewsListQuery{
+				CategoryID:      tt.categoryID,
+				PaginationQuery: common.PaginationQuery{Page: 1, Size: 10},
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+			for _, n := range items {
+				if tt.categoryID != nil {
+					require.NotNil(t, n.CategoryID)
+					assert.Equal(t, *tt.categoryID, *n.CategoryID)
+				}
+			}
+		})
+	}
+}
+
+func TestNewsCategoryRepository_DeleteUncategorizesNews(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewNewsCategoryRepository(db)
+	ctx := CreateTestContext()
+
+	category := SeedTestNewsCategory(t, db, "events")
+	news := SeedTestNews(t, db)
+	require.NoError(t, db.Model(&news).Update("category_id", category.Id).Error)
+
+	require.NoError(t, repo.Delete(ctx, category.Id))
+
+	var got domain.News
+	require.NoError(t, db.First(&got, news.Id).Error)
+	assert.Nil(t, got.CategoryID)
+}
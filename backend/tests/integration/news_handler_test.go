@@ -111,7 +111,7 @@ func setupNewsTestApp(svc *mockNewsService) *fiber.App {
 
 		item, err := svc.GetByID(c.UserContext(), id)
 		if err != nil {
-			if err.Error() == "not found" {
+			if errors.Is(err, domain.ErrNotFound) {
 				return fiber.NewError(fiber.StatusNotFound, "news not found")
 			}
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -162,7 +162,7 @@ func setupNewsTestApp(svc *mockNewsService) *fiber.App {
 		}
 
 		if err := svc.Update(c.UserContext(), id, req); err != nil {
-			if err.Error() == "not found" {
+			if errors.Is(err, domain.ErrNotFound) {
 				return fiber.NewError(fiber.StatusNotFound, "news not found")
 			}
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -182,7 +182,7 @@ func setupNewsTestApp(svc *mockNewsService) *fiber.App {
 		}
 
 		if err := svc.Delete(c.UserContext(), id); err != nil {
-			if err.Error() == "not found" {
+			if errors.Is(err, domain.ErrNotFound) {
 				return fiber.NewError(fiber.StatusNotFound, "news not found")
 			}
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -321,7 +321,7 @@ func TestNewsHandler_GetByID(t *testing.T) {
 			newsID: "999",
 			mockSetup: func(m *mockNewsService) {
 				m.On("GetByID", mock.Anything, 999).
-					Return(domain.News{}, errors.New("not found"))
+					Return(domain.News{}, domain.ErrNotFound)
 			},
 			wantStatus: http.StatusNotFound,
 		},
@@ -466,7 +466,7 @@ func TestNewsHandler_Update(t *testing.T) {
 			body:   `{"title": "Updated Title", "text": "Updated content"}`,
 			mockSetup: func(m *mockNewsService) {
 				m.On("Update", mock.Anything, 999, mock.AnythingOfType("dto.NewsDto")).
-					Return(errors.New("not found"))
+					Return(domain.ErrNotFound)
 			},
 			wantStatus: http.StatusNotFound,
 		},
@@ -526,7 +526,7 @@ func TestNewsHandler_Delete(t *testing.T) {
 			name:   "error - not found",
 			newsID: "999",
 			mockSetup: func(m *mockNewsService) {
-				m.On("Delete", mock.Anything, 999).Return(errors.New("not found"))
+				m.On("Delete", mock.Anything, 999).Return(domain.ErrNotFound)
 			},
 			wantStatus: http.StatusNotFound,
 		},
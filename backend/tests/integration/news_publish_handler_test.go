@@ -0,0 +1,139 @@
+//go:build integration
+
+// Package integration provides integration tests for HTTP handlers
+//
+// File: news_publish_handler_test.go
+// Description: Integration tests for PATCH /news/:id/publish, /news/:id/unpublish and the is_published filter
+package integration
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupNewsPublishTestApp creates a test Fiber app with list and publish routes backed by the real news service
+func setupNewsPublishTestApp(db *gorm.DB) *fiber.App {
+	svc := service.NewNewsService(repository.NewNewsRepository(db))
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	news := app.Group("/api/v1/news")
+
+	news.Get("/", func(c *fiber.Ctx) error {
+		q := dto.NewsListQuery{}
+		if err := c.QueryParser(&q); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+		}
+		items, total, _, _, err := svc.List(c.UserContext(), q)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(fiber.Map{"data": fiber.Map{"items": items, "total": total}})
+	})
+
+	setState := func(fn func(c *fiber.Ctx, id int) error) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			id, err := c.ParamsInt("id")
+			if err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "invalid news ID")
+			}
+			if err := fn(c, id); err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					return fiber.NewError(fiber.StatusNotFound, "news not found")
+				}
+				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+			}
+			return c.JSON(fiber.Map{"success": true})
+		}
+	}
+	news.Patch("/:id/publish", setState(func(c *fiber.Ctx, id int) error { return svc.Publish(c.UserContext(), id) }))
+	news.Patch("/:id/unpublish", setState(func(c *fiber.Ctx, id int) error { return svc.Unpublish(c.UserContext(), id) }))
+
+	return app
+}
+
+// listNewsIDs calls GET /news with the given query string and returns the item IDs
+func listNewsIDs(t *testing.T, app *fiber.App, query string) []int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/news?size=100"+query, nil)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data struct {
+			Items []domain.News `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	ids := make([]int, 0, len(body.Data.Items))
+	for _, n := range body.Data.Items {
+		ids = append(ids, n.Id)
+	}
+	return ids
+}
+
+// patchNews calls PATCH /news/:id/<action> and returns the status code
+func patchNews(t *testing.T, app *fiber.App, id int, action string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/news/"+strconv.Itoa(id)+"/"+action, nil)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestNewsHandler_PublishCycle(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	app := setupNewsPublishTestApp(db)
+
+	news := SeedTestNews(t, db)
+
+	// Шинэ мэдээ draft төлөвтэй
+	assert.NotContains(t, listNewsIDs(t, app, "&is_published=true"), news.Id)
+	assert.Contains(t, listNewsIDs(t, app, "&is_published=false"), news.Id)
+
+	// Publish
+	require.Equal(t, http.StatusOK, patchNews(t, app, news.Id, "publish"))
+
+	var stored domain.News
+	require.NoError(t, db.First(&stored, news.Id).Error)
+	assert.True(t, stored.IsPublished)
+	assert.NotNil(t, stored.PublishedAt)
+
+	assert.Contains(t, listNewsIDs(t, app, "&is_published=true"), news.Id)
+	assert.NotContains(t, listNewsIDs(t, app, "&is_published=false"), news.Id)
+	assert.Contains(t, listNewsIDs(t, app, ""), news.Id)
+
+	// Unpublish
+	require.Equal(t, http.StatusOK, patchNews(t, app, news.Id, "unpublish"))
+
+	require.NoError(t, db.First(&stored, news.Id).Error)
+	assert.False(t, stored.IsPublished)
+	assert.Nil(t, stored.PublishedAt)
+
+	assert.NotContains(t, listNewsIDs(t, app, "&is_published=true"), news.Id)
+	assert.Contains(t, listNewsIDs(t, app, "&is_published=false"), news.Id)
+}
+
+func TestNewsHandler_PublishNotFound(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	app := setupNewsPublishTestApp(db)
+
+	assert.Equal(t, http.StatusNotFound, patchNews(t, app, 999999, "publish"))
+	assert.Equal(t, http.StatusNotFound, patchNews(t, app, 999999, "unpublish"))
+}
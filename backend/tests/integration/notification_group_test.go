@@ -0,0 +1,141 @@
+//go:build integration
+
+// Package integration provides integration tests for HTTP handlers
+//
+// File: notification_group_test.go
+// Description: Integration tests for POST /notification/group (batched group notifications)
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/http/validation"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"git.gerege.mn/backend-packages/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupNotificationGroupTestApp creates a test Fiber app with POST /notification/group backed by the real service
+func setupNotificationGroupTestApp(db *gorm.DB) *fiber.App {
+	svc := service.NewNotificationService(repository.NewNotificationRepository(db), &config.Config{})
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+
+	app.Post("/api/v1/notification/group", func(c *fiber.Ctx) error {
+		req, ok := validation.BodyBindAndValidate[dto.NotificationGroupDto](c)
+		if !ok {
+			return nil
+		}
+		if err := svc.CreateGroup(c.UserContext(), req, "tester"); err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(fiber.Map{"success": true})
+	})
+	return app
+}
+
+func postNotificationGroup(t *testing.T, app *fiber.App, body interface{}) int {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/notification/group", bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestNotificationGroup_CreatesOneNotificationPerUser(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	app := setupNotificationGroupTestApp(db)
+
+	users := SeedTestUsers(t, db, 50)
+	ids := make([]int, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.Id)
+	}
+
+	status := postNotificationGroup(t, app, dto.NotificationGroupDto{
+		UserIDs: ids,
+		Title:   "Maintenance",
+		Content: "Tonight 22:00",
+		Type:    "info",
+	})
+	require.Equal(t, http.StatusOK, status)
+
+	var group domain.NotificationGroup
+	require.NoError(t, db.Where("title = ? AND created_username = ?", "Maintenance", "tester").First(&group).Error)
+	assert.Equal(t, "info", group.Type)
+
+	var notifications []domain.Notification
+	require.NoError(t, db.Where("group_id = ?", group.Id).Find(&notifications).Error)
+	require.Len(t, notifications, 50)
+
+	got := map[int]bool{}
+	for _, n := range notifications {
+		got[n.UserId] = true
+		assert.Equal(t, "Maintenance", n.Title)
+		assert.False(t, n.IsRead)
+	}
+	for _, id := range ids {
+		assert.True(t, got[id], "user %d should have a notification", id)
+	}
+}
+
+func TestNotificationGroup_ValidatesUserIDs(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	app := setupNotificationGroupTestApp(db)
+
+	tooMany := make([]int, 1001)
+	for i := range tooMany {
+		tooMany[i] = i + 1
+	}
+
+	tests := []struct {
+		name    string
+		userIDs []int
+	}{
+		{name: "empty user_ids", userIDs: []int{}},
+		{name: "missing user_ids", userIDs: nil},
+		{name: "more than 1000 user_ids", userIDs: tooMany},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := postNotificationGroup(t, app, dto.NotificationGroupDto{UserIDs: tt.userIDs, Title: "Hi"})
+			assert.Equal(t, http.StatusUnprocessableEntity, status)
+		})
+	}
+
+	var count int64
+	require.NoError(t, db.Model(&domain.NotificationGroup{}).Where("title = ?", "Hi").Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestNotificationGroup_UnknownUserIDs(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	app := setupNotificationGroupTestApp(db)
+
+	users := SeedTestUsers(t, db, 1)
+	status := postNotificationGroup(t, app, dto.NotificationGroupDto{
+		UserIDs: []int{users[0].Id, 999_999_901, 999_999_902},
+		Title:   "Unknown",
+	})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// Мэдэгдэл хэсэгчлэн ч үүсэхгүй
+	var count int64
+	require.NoError(t, db.Model(&domain.NotificationGroup{}).Where("title = ?", "Unknown").Count(&count).Error)
+	assert.Zero(t, count)
+}
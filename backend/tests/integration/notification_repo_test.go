@@ -76,12 +76,12 @@ func TestNotificationRepository_CreateNotification(t *testing.T) {
 		{
 			name: "success - create notification",
 			notification: domain.Notification{
-				UserId:  user.Id,
-				Title:   "New Notification",
-				Content: "Notification content",
-				Type:    "info",
-				Tenant:  "test",
-				GroupId: group.Id,
+				UserId:   user.Id,
+				Title:    "New Notification",
+				Content:  "Notification content",
+				Type:     "info",
+				TenantID: "test",
+				GroupId:  group.Id,
 			},
 			wantErr: false,
 		},
@@ -179,6 +179,37 @@ func TestNotificationRepository_ListByUser(t *testing.T) {
 	}
 }
 
+func TestNotificationRepository_ListByTenant(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewNotificationRepository(db)
+	ctx := CreateTestContext()
+
+	user := SeedTestUser(t, db)
+	group := SeedTestNotificationGroup(t, db, user.Id)
+	SeedTestNotifications(t, db, user.Id, group.Id, 3) // tenant "test"
+	other := domain.Notification{UserId: user.Id, Title: "Other tenant", GroupId: group.Id, Type: "info", TenantID: "other"}
+	require.NoError(t, db.Create(&other).Error)
+
+	q := common.PaginationQuery{Page: 1, Size: 10}
+
+	items, total, _, _, err := repo.ListByTenant(ctx, "test", user.Id, q)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	for _, n := range items {
+		assert.Equal(t, "test", n.TenantID)
+	}
+
+	items, total, _, _, err = repo.ListByTenant(ctx, "other", user.Id, q)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, items, 1)
+	assert.Equal(t, other.Id, items[0].Id)
+
+	_, total, _, _, err = repo.ListByTenant(ctx, "missing", user.Id, q)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
 func TestNotificationRepository_MarkGroupRead(t *testing.T) {
 	db := GetTestDBWithTx(t)
 	repo := repository.NewNotificationRepository(db)
@@ -250,7 +281,7 @@ func TestNotificationRepository_MarkAllRead(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.MarkAllRead(ctx, tt.userID)
+			updated, err := repo.MarkAllRead(ctx, tt.userID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -258,6 +289,7 @@ func TestNotificationRepository_MarkAllRead(t *testing.T) {
 			}
 
 			require.NoError(t, err)
+			assert.Equal(t, int64(5), updated)
 
 			// Verify all notifications are marked as read
 			notifications, _, _, _, err := repo.ListByUser(ctx, tt.userID, common.PaginationQuery{Page: 1, Size: 100})
@@ -269,6 +301,73 @@ func TestNotificationRepository_MarkAllRead(t *testing.T) {
 	}
 }
 
+func TestNotificationRepository_MarkReadByIDs(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewNotificationRepository(db)
+	ctx := CreateTestContext()
+
+	owner := SeedTestUser(t, db)
+	other := SeedTestUser(t, db)
+	group := SeedTestNotificationGroup(t, db, owner.Id)
+	own := SeedTestNotifications(t, db, owner.Id, group.Id, 3)
+	foreign := SeedTestNotifications(t, db, other.Id, group.Id, 1)
+
+	// Бусдын мэдэгдлийн ID нь user_id нөхцөлөөр алгасагдана
+	updated, err := repo.MarkReadByIDs(ctx, owner.Id, []int{own[0].Id, own[1].Id, foreign[0].Id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+
+	var readIDs []int
+	require.NoError(t, db.Model(&domain.Notification{}).Where("is_read = ?", true).
+		Where("id IN ?", []int{own[0].Id, own[1].Id, own[2].Id, foreign[0].Id}).Pluck("id", &readIDs).Error)
+	assert.ElementsMatch(t, []int{own[0].Id, own[1].Id}, readIDs)
+
+	// Аль хэдийн уншсан мэдэгдэл дахин тоологдохгүй
+	updated, err = repo.MarkReadByIDs(ctx, owner.Id, []int{own[0].Id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), updated)
+}
+
+func TestNotificationRepository_CountByType(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewNotificationRepository(db)
+	ctx := CreateTestContext()
+
+	user := SeedTestUser(t, db)
+	other := SeedTestUser(t, db)
+	group := SeedTestNotificationGroup(t, db, user.Id)
+
+	infos := SeedTestNotifications(t, db, user.Id, group.Id, 6)
+	warnings := SeedTestNotifications(t, db, user.Id, group.Id, 7)
+	alerts := SeedTestNotifications(t, db, user.Id, group.Id, 2)
+	SeedTestNotifications(t, db, other.Id, group.Id, 3)
+
+	setType := func(ns []domain.Notification, typ string) {
+		ids := make([]int, len(ns))
+		for i, n := range ns {
+			ids[i] = n.Id
+		}
+		require.NoError(t, db.Model(&domain.Notification{}).Where("id IN ?", ids).Update("type", typ).Error)
+	}
+	setType(warnings, "warning")
+	setType(alerts, "alert")
+
+	// info-оос 1, alert-ийг бүгдийг нь уншсан болгоно
+	_, err := repo.MarkReadByIDs(ctx, user.Id, []int{infos[0].Id, alerts[0].Id, alerts[1].Id})
+	require.NoError(t, err)
+
+	counts, err := repo.CountByType(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"info": 5, "warning": 7}, counts)
+
+	_, err = repo.MarkAllRead(ctx, user.Id)
+	require.NoError(t, err)
+
+	counts, err = repo.CountByType(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+}
+
 func TestNotificationRepository_ListGroups(t *testing.T) {
 	db := GetTestDBWithTx(t)
 	repo := repository.NewNotificationRepository(db)
@@ -323,9 +422,9 @@ func TestNotificationRepository_CreateNotificationsBulk(t *testing.T) {
 	group := SeedTestNotificationGroup(t, db, user.Id)
 
 	notifications := []domain.Notification{
-		{UserId: user.Id, Title: "Bulk 1", GroupId: group.Id, Type: "info", Tenant: "test"},
-		{UserId: user.Id, Title: "Bulk 2", GroupId: group.Id, Type: "info", Tenant: "test"},
-		{UserId: user.Id, Title: "Bulk 3", GroupId: group.Id, Type: "info", Tenant: "test"},
+		{UserId: user.Id, Title: "Bulk 1", GroupId: group.Id, Type: "info", TenantID: "test"},
+		{UserId: user.Id, Title: "Bulk 2", GroupId: group.Id, Type: "info", TenantID: "test"},
+		{UserId: user.Id, Title: "Bulk 3", GroupId: group.Id, Type: "info", TenantID: "test"},
 	}
 
 	err := repo.CreateNotificationsBulk(ctx, notifications)
@@ -0,0 +1,152 @@
+//go:build integration
+
+// Package integration provides integration tests for HTTP handlers
+//
+// File: notification_ws_test.go
+// Description: Integration tests for GET /notification/ws (real-time notifications)
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/handlers"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"git.gerege.mn/backend-packages/config"
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+	fastws "github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// setupNotificationWSTestApp starts a Fiber app with the real NotificationHandler
+// WebSocket routes on a random port and returns the base ws:// URL.
+//
+// GET /notification/ws is wired exactly like MapNotificationRoutes (WSAuth + WS);
+// SSO rejects every token. GET /notification/ws-as/:uid stands in for a
+// successful SSO lookup only: it stores the user ID the way WSAuth does and
+// hands the connection to the same WS handler.
+func setupNotificationWSTestApp(t *testing.T, svc *service.NotificationService) string {
+	t.Helper()
+
+	// SSO that rejects every session token
+	sso := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(sso.Close)
+
+	cfg := &config.Config{}
+	cfg.URLS.SSO = sso.URL
+	cfg.Auth.ClientID, cfg.Auth.ClientSecret = "test-client", "test-secret"
+	log := zap.NewNop()
+	h := handlers.NewNotificationHandler(&app.Dependencies{
+		Cfg:     cfg,
+		Log:     log,
+		SSO:     ssoclient.NewSSOClient(cfg, log, ssoclient.NewCache(time.Minute, 10)),
+		Service: &app.ServiceContainer{Notification: svc},
+	})
+
+	fapp := fiber.New(fiber.Config{DisableStartupMessage: true})
+	fapp.Get("/api/v1/notification/ws", h.WSAuth, websocket.New(h.WS))
+	fapp.Get("/api/v1/notification/ws-as/:uid", func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+		uid, err := c.ParamsInt("uid")
+		if err != nil {
+			return fiber.ErrBadRequest
+		}
+		c.Locals("ws_user_id", uid)
+		return c.Next()
+	}, websocket.New(h.WS))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = fapp.Listener(ln) }()
+	t.Cleanup(func() { _ = fapp.Shutdown() })
+
+	return "ws://" + ln.Addr().String() + "/api/v1/notification"
+}
+
+func TestNotificationWS_Auth(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	svc := service.NewNotificationService(repository.NewNotificationRepository(db), &config.Config{})
+	url := setupNotificationWSTestApp(t, svc)
+
+	_, resp, err := fastws.DefaultDialer.Dial(url+"/ws?token=invalid", nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	_, resp, err = fastws.DefaultDialer.Dial(url+"/ws", nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// Upgrade-гүй энгийн GET
+	plain, err := http.Get("http" + strings.TrimPrefix(url, "ws") + "/ws?token=invalid")
+	require.NoError(t, err)
+	defer plain.Body.Close()
+	assert.Equal(t, http.StatusUpgradeRequired, plain.StatusCode)
+}
+
+func TestNotificationWS_Push(t *testing.T) {
+	db := GetTestDBWithTx(t)
+
+	online := SeedTestUser(t, db)
+	offline := SeedTestUser(t, db)
+
+	svc := service.NewNotificationService(repository.NewNotificationRepository(db), &config.Config{})
+	url := setupNotificationWSTestApp(t, svc)
+
+	conn, _, err := fastws.DefaultDialer.Dial(fmt.Sprintf("%s/ws-as/%d", url, online.Id), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Register нь upgrade-ийн дараа async хийгддэг тул хүлээнэ
+	require.Eventually(t, func() bool { return svc.Hub().Connected(online.Id) }, 2*time.Second, 10*time.Millisecond)
+
+	t.Run("connected user receives push without persistence", func(t *testing.T) {
+		err := svc.Push(context.Background(), online.Id, domain.Notification{Title: "Hello", Content: "realtime"})
+		require.NoError(t, err)
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		var got domain.Notification
+		require.NoError(t, conn.ReadJSON(&got))
+		assert.Equal(t, online.Id, got.UserId)
+		assert.Equal(t, "Hello", got.Title)
+		assert.Equal(t, "realtime", got.Content)
+
+		var count int64
+		require.NoError(t, db.Model(&domain.Notification{}).Where("user_id = ?", online.Id).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("disconnected user notification is persisted", func(t *testing.T) {
+		err := svc.Push(context.Background(), offline.Id, domain.Notification{Title: "Later", Content: "stored"})
+		require.NoError(t, err)
+
+		var stored []domain.Notification
+		require.NoError(t, db.Where("user_id = ?", offline.Id).Find(&stored).Error)
+		require.Len(t, stored, 1)
+		assert.Equal(t, "Later", stored[0].Title)
+	})
+
+	t.Run("user is unregistered after disconnect", func(t *testing.T) {
+		require.NoError(t, conn.Close())
+		require.Eventually(t, func() bool { return !svc.Hub().Connected(online.Id) }, 2*time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,129 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: org_type_permission_test.go
+// Description: Integration tests for permissions linked directly to organization types
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// seedOrgTypePermissions нь байгууллагын төрөл болон count ширхэг permission үүсгэнэ
+func seedOrgTypePermissions(t *testing.T, db *gorm.DB, count int) (domain.OrganizationType, []domain.Permission) {
+	t.Helper()
+
+	orgType := domain.OrganizationType{Code: "OTP_TEST", Name: "Org Type Permission Test"}
+	require.NoError(t, db.Create(&orgType).Error)
+
+	system := SeedTestSystem(t, db)
+	module := seedTestModule(t, db, system.ID)
+	perms := make([]domain.Permission, count)
+	for i := range perms {
+		perms[i] = domain.Permission{
+			ModuleID: module.ID,
+			Code:     fmt.Sprintf("org.report.p%d", i),
+			Name:     fmt.Sprintf("Org Report %d", i),
+			IsActive: boolPtr(true),
+		}
+		require.NoError(t, db.Create(&perms[i]).Error)
+	}
+	return orgType, perms
+}
+
+// permissionIDs нь permission-уудын ID-г буцаана
+func permissionIDs(perms []domain.Permission) []int {
+	ids := make([]int, 0, len(perms))
+	for _, p := range perms {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestOrganizationTypeRepository_AddPermissions(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationTypeRepository(db)
+	ctx := CreateTestContext()
+
+	orgType, perms := seedOrgTypePermissions(t, db, 3)
+
+	steps := []struct {
+		name string
+		set  []int
+		want []int
+	}{
+		{name: "add two", set: []int{perms[0].ID, perms[1].ID}, want: []int{perms[0].ID, perms[1].ID}},
+		{name: "replace with third", set: []int{perms[2].ID}, want: []int{perms[2].ID}},
+		{name: "re-add previously removed", set: []int{perms[0].ID, perms[2].ID}, want: []int{perms[0].ID, perms[2].ID}},
+		{name: "clear", set: []int{}, want: []int{}},
+	}
+
+	for _, st := range steps {
+		t.Run(st.name, func(t *testing.T) {
+			require.NoError(t, repo.AddPermissions(ctx, orgType.Id, st.set))
+
+			got, err := repo.Permissions(ctx, orgType.Id)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, st.want, permissionIDs(got))
+		})
+	}
+}
+
+func TestPermissionService_HasPermission_OrgType(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	ctx := CreateTestContext()
+	svc := service.NewPermissionService(repository.NewPermissionRepository(db), nil, zap.NewNop())
+
+	orgType, perms := seedOrgTypePermissions(t, db, 2)
+	require.NoError(t, repository.NewOrganizationTypeRepository(db).AddPermissions(ctx, orgType.Id, []int{perms[0].ID}))
+
+	org := domain.Organization{Name: "Typed Org", TypeId: orgType.Id, IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&org).Error)
+
+	users := SeedTestUsers(t, db, 2)
+	member, outsider := users[0], users[1]
+	membership := domain.OrganizationUser{OrgId: org.Id, UserId: member.Id}
+	require.NoError(t, db.Create(&membership).Error)
+
+	t.Run("member gets org type permission without any role", func(t *testing.T) {
+		has, err := svc.HasPermission(ctx, member.Id, perms[0].Code)
+		require.NoError(t, err)
+		assert.True(t, has)
+
+		codes, err := svc.GetUserPermissions(ctx, member.Id)
+		require.NoError(t, err)
+		assert.Contains(t, codes, perms[0].Code)
+		assert.NotContains(t, codes, perms[1].Code)
+	})
+
+	t.Run("permission not linked to the type", func(t *testing.T) {
+		has, err := svc.HasPermission(ctx, member.Id, perms[1].Code)
+		require.NoError(t, err)
+		assert.False(t, has)
+	})
+
+	t.Run("non-member does not get it", func(t *testing.T) {
+		has, err := svc.HasPermission(ctx, outsider.Id, perms[0].Code)
+		require.NoError(t, err)
+		assert.False(t, has)
+	})
+
+	t.Run("removed membership revokes it", func(t *testing.T) {
+		require.NoError(t, db.Where("org_id = ? AND user_id = ?", org.Id, member.Id).Delete(&domain.OrganizationUser{}).Error)
+
+		has, err := svc.HasPermission(ctx, member.Id, perms[0].Code)
+		require.NoError(t, err)
+		assert.False(t, has)
+	})
+}
@@ -0,0 +1,75 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: organization_detail_test.go
+// Description: Integration tests for organization detail counts (GET /organization/:id)
+package integration
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationRepository_ByIDWithDetail(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+	ctx := CreateTestContext()
+
+	orgType := domain.OrganizationType{Code: "DETAIL_LLC", Name: "Detail LLC"}
+	require.NoError(t, db.Create(&orgType).Error)
+
+	org := domain.Organization{Name: "Detail Org", TypeId: orgType.Id, IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&org).Error)
+
+	// 3 хүүхэд (1 нь устгагдсан) + ач (тоологдохгүй)
+	children := make([]domain.Organization, 3)
+	for i := range children {
+		children[i] = domain.Organization{Name: "Detail Child " + itoa(i), ParentId: &org.Id, IsActive: boolPtr(true)}
+		require.NoError(t, db.Create(&children[i]).Error)
+	}
+	require.NoError(t, db.Delete(&domain.Organization{}, children[2].Id).Error)
+	grandchild := domain.Organization{Name: "Detail Grandchild", ParentId: &children[0].Id, IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&grandchild).Error)
+
+	// 4 гишүүн: 1 гишүүнчлэл устгагдсан, 1 хэрэглэгч устгагдсан
+	users := SeedTestUsers(t, db, 4)
+	for _, u := range users {
+		require.NoError(t, db.Create(&domain.OrganizationUser{OrgId: org.Id, UserId: u.Id}).Error)
+	}
+	require.NoError(t, db.Where("org_id = ? AND user_id = ?", org.Id, users[2].Id).Delete(&domain.OrganizationUser{}).Error)
+	require.NoError(t, db.Delete(&domain.User{}, users[3].Id).Error)
+
+	t.Run("counts active children and users", func(t *testing.T) {
+		detail, err := repo.ByIDWithDetail(ctx, org.Id)
+		require.NoError(t, err)
+		assert.Equal(t, org.Id, detail.Id)
+		assert.Equal(t, "Detail Org", detail.Name)
+		assert.Equal(t, "Detail LLC", detail.TypeName)
+		assert.Equal(t, 2, detail.ChildrenCount)
+		assert.Equal(t, 2, detail.UserCount)
+	})
+
+	t.Run("leaf without type", func(t *testing.T) {
+		detail, err := repo.ByIDWithDetail(ctx, grandchild.Id)
+		require.NoError(t, err)
+		assert.Empty(t, detail.TypeName)
+		assert.Zero(t, detail.ChildrenCount)
+		assert.Zero(t, detail.UserCount)
+	})
+
+	t.Run("soft-deleted - not found", func(t *testing.T) {
+		_, err := repo.ByIDWithDetail(ctx, children[2].Id)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("unknown - not found", func(t *testing.T) {
+		_, err := repo.ByIDWithDetail(ctx, 999999999)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
@@ -4,19 +4,23 @@
 package integration
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
 	"templatev25/internal/repository"
 
 	"git.gerege.mn/backend-packages/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestOrganizationRepository_Create(t *testing.T) {
 	db := GetTestDBWithTx(t)
-	repo := repository.NewOrganizationRepository(db)
+	repo := repository.NewOrganizationRepository(db, nil)
 	ctx := CreateTestContext()
 
 	tests := []struct {
@@ -60,7 +64,7 @@ func TestOrganizationRepository_Create(t *testing.T) {
 
 func TestOrganizationRepository_ByID(t *testing.T) {
 	db := GetTestDBWithTx(t)
-	repo := repository.NewOrganizationRepository(db)
+	repo := repository.NewOrganizationRepository(db, nil)
 	ctx := CreateTestContext()
 
 	// Seed
@@ -100,7 +104,7 @@ func TestOrganizationRepository_ByID(t *testing.T) {
 
 func TestOrganizationRepository_Update(t *testing.T) {
 	db := GetTestDBWithTx(t)
-	repo := repository.NewOrganizationRepository(db)
+	repo := repository.NewOrganizationRepository(db, nil)
 	ctx := CreateTestContext()
 
 	// Seed
@@ -116,7 +120,8 @@ func TestOrganizationRepository_Update(t *testing.T) {
 			name:  "success - update name",
 			orgID: org.Id,
 			update: domain.Organization{
-				Name: "Updated Organization Name",
+				Name:    "Updated Organization Name",
+				Version: org.Version,
 			},
 			wantErr: false,
 		},
@@ -133,13 +138,38 @@ func TestOrganizationRepository_Update(t *testing.T) {
 
 			require.NoError(t, err)
 			assert.Equal(t, tt.update.Name, updated.Name)
+			assert.Equal(t, tt.update.Version+1, updated.Version)
 		})
 	}
 }
 
+func TestOrganizationRepository_Update_VersionConflict(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+	ctx := CreateTestContext()
+
+	org := SeedTestOrganization(t, db)
+	require.Equal(t, 1, org.Version)
+
+	// Хоёр client нэг version-ийг уншсан
+	_, err := repo.Update(ctx, org.Id, domain.Organization{Name: "First writer", Version: org.Version})
+	require.NoError(t, err)
+
+	_, err = repo.Update(ctx, org.Id, domain.Organization{Name: "Second writer", Version: org.Version})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	var stored domain.Organization
+	require.NoError(t, db.First(&stored, org.Id).Error)
+	assert.Equal(t, "First writer", stored.Name)
+	assert.Equal(t, 2, stored.Version)
+
+	_, err = repo.Update(ctx, 999999, domain.Organization{Name: "Missing", Version: 1})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
 func TestOrganizationRepository_Delete(t *testing.T) {
 	db := GetTestDBWithTx(t)
-	repo := repository.NewOrganizationRepository(db)
+	repo := repository.NewOrganizationRepository(db, nil)
 	ctx := CreateTestContext()
 
 	// Seed
@@ -177,7 +207,7 @@ func TestOrganizationRepository_Delete(t *testing.T) {
 
 func TestOrganizationRepository_List(t *testing.T) {
 	db := GetTestDBWithTx(t)
-	repo := repository.NewOrganizationRepository(db)
+	repo := repository.NewOrganizationRepository(db, nil)
 	ctx := CreateTestContext()
 
 	// Seed multiple organizations
@@ -233,7 +263,7 @@ func TestOrganizationRepository_List(t *testing.T) {
 
 func TestOrganizationRepository_Tree(t *testing.T) {
 	db := GetTestDBWithTx(t)
-	repo := repository.NewOrganizationRepository(db)
+	repo := repository.NewOrganizationRepository(db, nil)
 	ctx := CreateTestContext()
 
 	// Create a hierarchy
@@ -266,14 +296,14 @@ func TestOrganizationRepository_Tree(t *testing.T) {
 		{
 			name:         "success - get tree from root",
 			rootID:       parent.Id,
-			wantMinItems: 1, // At least the children
+			wantMinItems: 3, // Parent + 2 children
 			wantErr:      false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tree, err := repo.Tree(ctx, tt.rootID)
+			tree, err := repo.TreeCTE(ctx, tt.rootID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -282,6 +312,190 @@ func TestOrganizationRepository_Tree(t *testing.T) {
 
 			require.NoError(t, err)
 			assert.GreaterOrEqual(t, len(tree), tt.wantMinItems)
+			assert.Equal(t, tt.rootID, tree[0].Id, "root row comes first (depth 0)")
 		})
 	}
 }
+
+// seedOrgHierarchy creates root → mid → leaf and returns them in that order
+func seedOrgHierarchy(t *testing.T, db *gorm.DB) (domain.Organization, domain.Organization, domain.Organization) {
+	t.Helper()
+
+	root := domain.Organization{Name: "Root Org", IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&root).Error)
+	mid := domain.Organization{Name: "Mid Org", ParentId: &root.Id, IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&mid).Error)
+	leaf := domain.Organization{Name: "Leaf Org", ParentId: &mid.Id, IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&leaf).Error)
+
+	return root, mid, leaf
+}
+
+func TestOrganizationRepository_Tree_Grandchildren(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+	ctx := CreateTestContext()
+	root, mid, leaf := seedOrgHierarchy(t, db)
+
+	rows, err := repo.TreeCTE(ctx, root.Id)
+	require.NoError(t, err)
+	require.Len(t, rows, 3, "root, mid and leaf (grandchild) in one query")
+
+	// Мөрүүдийг parent_id-аар нь дамжин leaf-ээс root хүртэл явна
+	byID := make(map[int]domain.Organization, len(rows))
+	for _, row := range rows {
+		byID[row.Id] = row
+	}
+	path := []int{}
+	for id := leaf.Id; ; {
+		node, ok := byID[id]
+		require.True(t, ok, "node %d must be in the tree", id)
+		path = append(path, node.Id)
+		if node.ParentId == nil || node.Id == root.Id {
+			break
+		}
+		id = *node.ParentId
+	}
+	assert.Equal(t, []int{leaf.Id, mid.Id, root.Id}, path)
+}
+
+func TestOrganizationRepository_MoveToParent(t *testing.T) {
+	ctx := CreateTestContext()
+
+	t.Run("success - move leaf under root", func(t *testing.T) {
+		db := GetTestDBWithTx(t)
+		repo := repository.NewOrganizationRepository(db, nil)
+		root, _, leaf := seedOrgHierarchy(t, db)
+
+		require.NoError(t, repo.MoveToParent(ctx, leaf.Id, root.Id))
+
+		moved, err := repo.ByID(ctx, leaf.Id)
+		require.NoError(t, err)
+		require.NotNil(t, moved.ParentId)
+		assert.Equal(t, root.Id, *moved.ParentId)
+	})
+
+	t.Run("success - move mid subtree to root level", func(t *testing.T) {
+		db := GetTestDBWithTx(t)
+		repo := repository.NewOrganizationRepository(db, nil)
+		_, mid, leaf := seedOrgHierarchy(t, db)
+
+		require.NoError(t, repo.MoveToParent(ctx, mid.Id, 0))
+
+		moved, err := repo.ByID(ctx, mid.Id)
+		require.NoError(t, err)
+		assert.Nil(t, moved.ParentId)
+
+		// Дэд мод хамт шилжинэ (leaf-ийн parent хэвээр mid)
+		child, err := repo.ByID(ctx, leaf.Id)
+		require.NoError(t, err)
+		require.NotNil(t, child.ParentId)
+		assert.Equal(t, mid.Id, *child.ParentId)
+	})
+
+	cycleTests := []struct {
+		name string
+		pick func(root, mid, leaf domain.Organization) (orgID, newParentID int)
+	}{
+		{
+			name: "error - move root under its grandchild",
+			pick: func(root, _, leaf domain.Organization) (int, int) { return root.Id, leaf.Id },
+		},
+		{
+			name: "error - move root under its child",
+			pick: func(root, mid, _ domain.Organization) (int, int) { return root.Id, mid.Id },
+		},
+		{
+			name: "error - move under itself",
+			pick: func(_, mid, _ domain.Organization) (int, int) { return mid.Id, mid.Id },
+		},
+	}
+
+	for _, tt := range cycleTests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := GetTestDBWithTx(t)
+			repo := repository.NewOrganizationRepository(db, nil)
+			root, mid, leaf := seedOrgHierarchy(t, db)
+			orgID, newParentID := tt.pick(root, mid, leaf)
+
+			err := repo.MoveToParent(ctx, orgID, newParentID)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+			// parent_id өөрчлөгдөөгүй
+			unchanged, err := repo.ByID(ctx, orgID)
+			require.NoError(t, err)
+			before := map[int]*int{root.Id: root.ParentId, mid.Id: mid.ParentId, leaf.Id: leaf.ParentId}[orgID]
+			assert.Equal(t, before, unchanged.ParentId)
+		})
+	}
+
+	t.Run("error - parent not found", func(t *testing.T) {
+		db := GetTestDBWithTx(t)
+		repo := repository.NewOrganizationRepository(db, nil)
+		_, mid, _ := seedOrgHierarchy(t, db)
+
+		err := repo.MoveToParent(ctx, mid.Id, 999999)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("error - organization not found", func(t *testing.T) {
+		db := GetTestDBWithTx(t)
+		repo := repository.NewOrganizationRepository(db, nil)
+		root, _, _ := seedOrgHierarchy(t, db)
+
+		err := repo.MoveToParent(ctx, 999999, root.Id)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestOrganizationRepository_Stats(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+	ctx := CreateTestContext()
+
+	company := domain.OrganizationType{Code: "STATS_LLC", Name: "Stats LLC"}
+	ngo := domain.OrganizationType{Code: "STATS_NGO", Name: "Stats NGO"}
+	require.NoError(t, db.Create(&company).Error)
+	require.NoError(t, db.Create(&ngo).Error)
+
+	latest := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	orgs := []struct {
+		typeID  int
+		created time.Time
+		deleted bool
+	}{
+		{typeID: company.Id, created: latest.AddDate(0, -1, 0)},
+		{typeID: company.Id, created: latest},
+		{typeID: ngo.Id, created: latest.AddDate(0, 0, -7)},
+		{typeID: ngo.Id, created: latest.AddDate(0, 1, 0), deleted: true}, // устгагдсан нь тоологдохгүй
+	}
+	for i, o := range orgs {
+		org := domain.Organization{Name: "Stats Org", RegNo: fmt.Sprintf("ST%05d", i), TypeId: o.typeID, IsActive: boolPtr(true)}
+		require.NoError(t, db.Create(&org).Error)
+		require.NoError(t, db.Model(&domain.Organization{}).Where("id = ?", org.Id).UpdateColumn("created_date", o.created).Error)
+		if o.deleted {
+			require.NoError(t, db.Delete(&domain.Organization{}, org.Id).Error)
+		}
+	}
+
+	stats, err := repo.Stats(ctx)
+	require.NoError(t, err)
+
+	byType := make(map[int]dto.OrgTypeStats, len(stats))
+	for _, s := range stats {
+		byType[s.TypeID] = s
+	}
+
+	require.Contains(t, byType, company.Id)
+	assert.Equal(t, int64(2), byType[company.Id].Count)
+	assert.Equal(t, "Stats LLC", byType[company.Id].TypeName)
+	assert.WithinDuration(t, latest, byType[company.Id].LatestCreated, time.Second)
+
+	require.Contains(t, byType, ngo.Id)
+	assert.Equal(t, int64(1), byType[ngo.Id].Count)
+	assert.Equal(t, "Stats NGO", byType[ngo.Id].TypeName)
+	assert.WithinDuration(t, latest.AddDate(0, 0, -7), byType[ngo.Id].LatestCreated, time.Second)
+}
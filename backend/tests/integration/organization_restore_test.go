@@ -0,0 +1,95 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: organization_restore_test.go
+// Description: Integration tests for restoring soft-deleted organizations
+package integration
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"git.gerege.mn/backend-packages/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// listContains нь List-ийн эхний хуудсанд orgID байгаа эсэхийг шалгана
+func listContains(t *testing.T, repo repository.OrganizationRepository, orgID int) bool {
+	t.Helper()
+	orgs, _, _, _, err := repo.List(CreateTestContext(), common.PaginationQuery{Page: 1, Size: 500})
+	require.NoError(t, err)
+	for _, o := range orgs {
+		if o.Id == orgID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOrganizationRepository_Restore(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+	ctx := CreateTestContext()
+
+	org := SeedTestOrganization(t, db)
+	require.NoError(t, repo.Delete(ctx, org.Id))
+	assert.False(t, listContains(t, repo, org.Id))
+
+	deleted, err := repo.DeletedByID(ctx, org.Id)
+	require.NoError(t, err)
+	assert.True(t, deleted.DeletedDate.Valid)
+
+	require.NoError(t, repo.Restore(ctx, org.Id))
+
+	assert.True(t, listContains(t, repo, org.Id))
+	restored, err := repo.ByID(ctx, org.Id)
+	require.NoError(t, err)
+	assert.False(t, restored.DeletedDate.Valid)
+	assert.Equal(t, org.Version+1, restored.Version)
+
+	// Дахин сэргээх мөр байхгүй
+	assert.ErrorIs(t, repo.Restore(ctx, org.Id), domain.ErrNotFound)
+	_, err = repo.DeletedByID(ctx, org.Id)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestOrganizationService_Restore(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+	svc := service.NewOrganizationService(repo, repository.NewAuthRepository(db, nil), zap.NewNop())
+	ctx := CreateTestContext()
+
+	org := SeedTestOrganization(t, db)
+
+	t.Run("error - not deleted", func(t *testing.T) {
+		_, err := svc.Restore(ctx, org.Id)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("success - restored org is listed again", func(t *testing.T) {
+		require.NoError(t, svc.Delete(ctx, org.Id))
+
+		restored, err := svc.Restore(ctx, org.Id)
+		require.NoError(t, err)
+		assert.Equal(t, org.Id, restored.Id)
+		assert.True(t, listContains(t, repo, org.Id))
+
+		var audit domain.SecurityAuditTrail
+		require.NoError(t, db.Where("action = ? AND target_id = ?", "ORG_RESTORED", itoa(org.Id)).Take(&audit).Error)
+		assert.Equal(t, "organization", audit.TargetType)
+	})
+
+	t.Run("error - hard deleted", func(t *testing.T) {
+		gone := SeedTestOrganization(t, db)
+		require.NoError(t, db.Unscoped().Delete(&domain.Organization{Id: gone.Id}).Error)
+
+		_, err := svc.Restore(ctx, gone.Id)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
@@ -0,0 +1,118 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: organization_search_test.go
+// Description: Organization full-text search tests and ILIKE vs tsvector benchmark
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+
+	"git.gerege.mn/backend-packages/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationRepository_Search(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+	ctx := CreateTestContext()
+
+	orgs := []domain.Organization{
+		{Name: "Gerege Systems", ShortName: "Gerege", RegNo: "1234567"},
+		{Name: "Gerege Core Team", ShortName: "GCT", RegNo: "7654321"},
+		{Name: "Улаанбаатар Банк", ShortName: "УБ", RegNo: "5555555"},
+	}
+	require.NoError(t, db.Create(&orgs).Error)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantNames []string
+	}{
+		{name: "single word", query: "gerege", wantNames: []string{"Gerege Core Team", "Gerege Systems"}},
+		{name: "prefix match", query: "syst", wantNames: []string{"Gerege Systems"}},
+		{name: "all words must match", query: "gerege core", wantNames: []string{"Gerege Core Team"}},
+		{name: "cyrillic", query: "банк", wantNames: []string{"Улаанбаатар Банк"}},
+		{name: "registry number", query: "7654321", wantNames: []string{"Gerege Core Team"}},
+		{name: "tsquery operators are stripped", query: "gerege & | ! (core)", wantNames: []string{"Gerege Core Team"}},
+		{name: "no match", query: "nothing", wantNames: []string{}},
+		{name: "only punctuation", query: "&|!", wantNames: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, total, _, _, err := repo.Search(ctx, tt.query, common.PaginationQuery{Page: 1, Size: 50})
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(items))
+			for _, o := range items {
+				names = append(names, o.Name)
+			}
+			assert.ElementsMatch(t, tt.wantNames, names)
+			assert.Equal(t, int64(len(tt.wantNames)), total)
+		})
+	}
+}
+
+// seedSearchBenchmarkOrgs inserts n organizations for the search benchmarks
+func seedSearchBenchmarkOrgs(b *testing.B, db *gorm.DB, n int) {
+	b.Helper()
+	orgs := make([]domain.Organization, n)
+	for i := range orgs {
+		orgs[i] = domain.Organization{
+			Name:      fmt.Sprintf("Organization %d Holding", i),
+			ShortName: fmt.Sprintf("ORG%d", i),
+			RegNo:     fmt.Sprintf("%07d", i),
+		}
+	}
+	if err := db.CreateInBatches(&orgs, 1000).Error; err != nil {
+		b.Fatalf("failed to seed organizations: %v", err)
+	}
+	if err := db.Exec("ANALYZE organizations").Error; err != nil {
+		b.Fatalf("failed to analyze organizations: %v", err)
+	}
+}
+
+// BenchmarkOrganizationSearch_ILIKE нь List-ийн хуучин ILIKE хайлтыг хэмжинэ
+func BenchmarkOrganizationSearch_ILIKE(b *testing.B) {
+	db := GetTestDBWithTx(b)
+	seedSearchBenchmarkOrgs(b, db, 10000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		like := "%holding%"
+		tx := db.Model(&domain.Organization{}).
+			Where("name ILIKE ? OR short_name ILIKE ? OR reg_no ILIKE ?", like, like, like)
+
+		var total int64
+		if err := tx.Count(&total).Error; err != nil {
+			b.Fatal(err)
+		}
+		var items []domain.Organization
+		if err := tx.Order("name ASC").Limit(20).Find(&items).Error; err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkOrganizationSearch_FullText нь search_vector (GIN) хайлтыг хэмжинэ
+func BenchmarkOrganizationSearch_FullText(b *testing.B) {
+	db := GetTestDBWithTx(b)
+	seedSearchBenchmarkOrgs(b, db, 10000)
+	repo := repository.NewOrganizationRepository(db, nil)
+	ctx := CreateTestContext()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, _, err := repo.Search(ctx, "holding", common.PaginationQuery{Page: 1, Size: 20}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
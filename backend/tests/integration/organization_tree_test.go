@@ -0,0 +1,125 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: organization_tree_test.go
+// Description: Integration tests for the recursive CTE organization tree (GET /organization/:id/tree)
+package integration
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// seedOrgTree4 нь 4 түвшний мод үүсгэнэ:
+//
+//	root
+//	├── a
+//	│   ├── a1
+//	│   │   └── a1x
+//	│   └── a2
+//	└── b
+func seedOrgTree4(t *testing.T, db *gorm.DB) map[string]domain.Organization {
+	t.Helper()
+	orgs := map[string]domain.Organization{}
+	create := func(key string, parent string, seq int) {
+		o := domain.Organization{Name: "Tree " + key, Sequence: seq, IsActive: boolPtr(true)}
+		if parent != "" {
+			pid := orgs[parent].Id
+			o.ParentId = &pid
+		}
+		require.NoError(t, db.Create(&o).Error)
+		orgs[key] = o
+	}
+	create("root", "", 0)
+	create("a", "root", 1)
+	create("b", "root", 2)
+	create("a1", "a", 1)
+	create("a2", "a", 2)
+	create("a1x", "a1", 1)
+	return orgs
+}
+
+// childIDs нь node-ийн шууд хүүхдүүдийн ID-г дарааллаар нь буцаана
+func childIDs(node domain.Organization) []int {
+	if node.Children == nil {
+		return nil
+	}
+	ids := make([]int, 0, len(*node.Children))
+	for _, c := range *node.Children {
+		ids = append(ids, c.Id)
+	}
+	return ids
+}
+
+func TestOrganizationRepository_TreeCTE(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+	ctx := CreateTestContext()
+	orgs := seedOrgTree4(t, db)
+
+	t.Run("returns whole subtree ordered by depth", func(t *testing.T) {
+		rows, err := repo.TreeCTE(ctx, orgs["root"].Id)
+		require.NoError(t, err)
+		require.Len(t, rows, 6)
+		assert.Equal(t, orgs["root"].Id, rows[0].Id)
+		assert.Equal(t, orgs["a1x"].Id, rows[5].Id, "deepest node comes last")
+	})
+
+	t.Run("subtree from middle node", func(t *testing.T) {
+		rows, err := repo.TreeCTE(ctx, orgs["a"].Id)
+		require.NoError(t, err)
+		assert.Len(t, rows, 4) // a, a1, a2, a1x
+	})
+
+	t.Run("unknown root - empty", func(t *testing.T) {
+		rows, err := repo.TreeCTE(ctx, 999999999)
+		require.NoError(t, err)
+		assert.Empty(t, rows)
+	})
+
+	// Сүүлд ажиллана: a1-ийг soft delete хийнэ
+	t.Run("soft-deleted node and its descendants excluded", func(t *testing.T) {
+		require.NoError(t, db.Delete(&domain.Organization{}, orgs["a1"].Id).Error)
+
+		rows, err := repo.TreeCTE(ctx, orgs["root"].Id)
+		require.NoError(t, err)
+		assert.Len(t, rows, 4) // root, a, b, a2
+	})
+}
+
+func TestOrganizationService_Tree_FourLevels(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	svc := service.NewOrganizationService(repository.NewOrganizationRepository(db, nil), nil, zap.NewNop())
+	ctx := CreateTestContext()
+	orgs := seedOrgTree4(t, db)
+
+	tree, err := svc.Tree(ctx, orgs["root"].Id)
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+
+	root := tree[0]
+	assert.Equal(t, orgs["root"].Id, root.Id)
+	require.Equal(t, []int{orgs["a"].Id, orgs["b"].Id}, childIDs(root))
+
+	a, b := (*root.Children)[0], (*root.Children)[1]
+	require.Equal(t, []int{orgs["a1"].Id, orgs["a2"].Id}, childIDs(a))
+	assert.Nil(t, b.Children, "leaf has no children key")
+
+	a1 := (*a.Children)[0]
+	require.Equal(t, []int{orgs["a1x"].Id}, childIDs(a1))
+	assert.Nil(t, (*a1.Children)[0].Children)
+
+	t.Run("unknown root - not found", func(t *testing.T) {
+		_, err := svc.Tree(ctx, 999999999)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
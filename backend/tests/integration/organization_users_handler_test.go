@@ -0,0 +1,147 @@
+//go:build integration
+
+// Package integration provides integration tests for HTTP handlers
+//
+// File: organization_users_handler_test.go
+// Description: Integration tests for GET /organization/:id/users
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"git.gerege.mn/backend-packages/common"
+	"git.gerege.mn/backend-packages/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// setupOrgUsersTestApp creates a test Fiber app with GET /organization/:id/users backed by real services
+func setupOrgUsersTestApp(db *gorm.DB) *fiber.App {
+	orgSvc := service.NewOrganizationService(repository.NewOrganizationRepository(db, nil), repository.NewAuthRepository(db, nil), zap.NewNop())
+	orgUserSvc := service.NewOrgUserService(repository.NewOrgUserRepository(db, &config.Config{}), &config.Config{}, repository.NewUserRepository(db))
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+
+	app.Get("/api/v1/organization/:id/users", func(c *fiber.Ctx) error {
+		id, err := c.ParamsInt("id")
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid organization ID")
+		}
+		var p common.PaginationQuery
+		if err := c.QueryParser(&p); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+		}
+
+		exists, err := orgSvc.Exists(c.UserContext(), id)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if !exists {
+			return fiber.NewError(fiber.StatusNotFound, "organization not found")
+		}
+
+		items, total, page, size, err := orgUserSvc.UsersByOrg(c.UserContext(), id, strings.TrimSpace(c.Query("name")), p)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(fiber.Map{
+			"data": fiber.Map{
+				"items": items,
+				"total": total,
+				"page":  page,
+				"size":  size,
+			},
+		})
+	})
+
+	return app
+}
+
+func TestOrganizationHandler_Users(t *testing.T) {
+	db := GetTestDBWithTx(t)
+
+	org := SeedTestOrganization(t, db)
+	users := SeedTestUsers(t, db, 3)
+	for _, u := range users {
+		require.NoError(t, db.Create(&domain.OrganizationUser{OrgId: org.Id, UserId: u.Id}).Error)
+	}
+
+	app := setupOrgUsersTestApp(db)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantTotal  int
+		wantItems  int
+		wantSize   int
+	}{
+		{
+			name:       "success - all users",
+			path:       "/api/v1/organization/" + itoa(org.Id) + "/users",
+			wantStatus: http.StatusOK,
+			wantTotal:  3,
+			wantItems:  3,
+		},
+		{
+			name:       "success - paginated",
+			path:       "/api/v1/organization/" + itoa(org.Id) + "/users?page=1&size=2",
+			wantStatus: http.StatusOK,
+			wantTotal:  3,
+			wantItems:  2,
+			wantSize:   2,
+		},
+		{
+			name:       "success - name filter",
+			path:       "/api/v1/organization/" + itoa(org.Id) + "/users?name=" + users[1].FirstName,
+			wantStatus: http.StatusOK,
+			wantTotal:  1,
+			wantItems:  1,
+		},
+		{
+			name:       "error - organization not found",
+			path:       "/api/v1/organization/999999/users",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil), -1)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data struct {
+					Items []map[string]any `json:"items"`
+					Total int              `json:"total"`
+					Page  int              `json:"page"`
+					Size  int              `json:"size"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.wantTotal, body.Data.Total)
+			assert.Len(t, body.Data.Items, tt.wantItems)
+			assert.Equal(t, 1, body.Data.Page)
+			if tt.wantSize > 0 {
+				assert.Equal(t, tt.wantSize, body.Data.Size)
+			}
+		})
+	}
+}
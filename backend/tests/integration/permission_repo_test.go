@@ -9,10 +9,13 @@ import (
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 	"templatev25/internal/repository"
+	"templatev25/internal/service"
 
 	"git.gerege.mn/backend-packages/common"
+	"git.gerege.mn/backend-packages/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -214,6 +217,108 @@ func TestPermissionRepository_List(t *testing.T) {
 	}
 }
 
+func TestPermissionRepository_ListBySystem(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewPermissionRepository(db)
+	modules := repository.NewModuleRepository(db, &config.Config{})
+	ctx := CreateTestContext()
+
+	// Seed: system дээр хоёр module, өөр system дээр нэг permission
+	system := SeedTestSystem(t, db)
+	userModule := domain.Module{SystemID: system.ID, Code: "LBS_USER", Name: "User", IsActive: boolPtr(true)}
+	roleModule := domain.Module{SystemID: system.ID, Code: "LBS_ROLE", Name: "Role", IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&userModule).Error)
+	require.NoError(t, db.Create(&roleModule).Error)
+
+	for _, p := range []domain.Permission{
+		{SystemID: system.ID, ModuleID: roleModule.ID, Code: "lbs.role.read", IsActive: boolPtr(true)},
+		{SystemID: system.ID, ModuleID: userModule.ID, Code: "lbs.user.read", IsActive: boolPtr(true)},
+		{SystemID: system.ID, ModuleID: userModule.ID, Code: "lbs.user.create", IsActive: boolPtr(true)},
+	} {
+		require.NoError(t, db.Create(&p).Error)
+	}
+	other := domain.System{Code: "LBS_OTHER", Name: "Other", IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&other).Error)
+	require.NoError(t, db.Create(&domain.Permission{SystemID: other.ID, ModuleID: userModule.ID, Code: "lbs.other.read", IsActive: boolPtr(true)}).Error)
+
+	perms, err := repo.ListBySystem(ctx, system.ID)
+	require.NoError(t, err)
+	require.Len(t, perms, 3)
+	for i := 1; i < len(perms); i++ {
+		prev, cur := perms[i-1], perms[i]
+		assert.True(t, prev.ModuleID < cur.ModuleID || (prev.ModuleID == cur.ModuleID && prev.Code < cur.Code),
+			"permissions should be ordered by module_id, code")
+	}
+
+	found, err := modules.ByIDs(ctx, []int{userModule.ID, roleModule.ID, 999999})
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+}
+
+func TestPermissionRepository_ListByModule(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewPermissionRepository(db)
+	ctx := CreateTestContext()
+
+	// Seed: module дээр 5 permission, өөр module дээр 2
+	system := SeedTestSystem(t, db)
+	module := domain.Module{SystemID: system.ID, Code: "LBM_USER", Name: "User", IsActive: boolPtr(true)}
+	other := domain.Module{SystemID: system.ID, Code: "LBM_ROLE", Name: "Role", IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&module).Error)
+	require.NoError(t, db.Create(&other).Error)
+
+	for _, action := range []string{"create", "read", "update", "delete", "export"} {
+		require.NoError(t, db.Create(&domain.Permission{SystemID: system.ID, ModuleID: module.ID, Code: "lbm.user." + action, IsActive: boolPtr(true)}).Error)
+	}
+	for _, action := range []string{"create", "read"} {
+		require.NoError(t, db.Create(&domain.Permission{SystemID: system.ID, ModuleID: other.ID, Code: "lbm.role." + action, IsActive: boolPtr(true)}).Error)
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		perms, total, page, size, err := repo.ListByModule(ctx, module.ID, dto.PermissionQuery{
+			PaginationQuery: common.PaginationQuery{Page: 1, Size: 3},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), total)
+		assert.Equal(t, 1, page)
+		assert.Equal(t, 3, size)
+		assert.Len(t, perms, 3)
+		for _, p := range perms {
+			assert.Equal(t, module.ID, p.ModuleID)
+		}
+	})
+
+	t.Run("second page", func(t *testing.T) {
+		perms, total, _, _, err := repo.ListByModule(ctx, module.ID, dto.PermissionQuery{
+			PaginationQuery: common.PaginationQuery{Page: 2, Size: 3},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), total)
+		assert.Len(t, perms, 2)
+	})
+
+	t.Run("module_id query is overridden by path module", func(t *testing.T) {
+		_, total, _, _, err := repo.ListByModule(ctx, other.ID, dto.PermissionQuery{
+			PaginationQuery: common.PaginationQuery{Page: 1, Size: 20},
+			ModuleID:        module.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+	})
+
+	t.Run("service returns not found for missing module", func(t *testing.T) {
+		svc := service.NewPermissionService(repo, repository.NewModuleRepository(db, &config.Config{}), zap.NewNop())
+
+		perms, total, _, _, err := svc.ListByModule(ctx, module.ID, dto.PermissionQuery{PaginationQuery: common.PaginationQuery{Page: 1, Size: 20}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), total)
+		assert.Len(t, perms, 5)
+
+		_, _, _, _, err = svc.ListByModule(ctx, 999999, dto.PermissionQuery{})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
 func TestPermissionRepository_UserHasPermission(t *testing.T) {
 	db := GetTestDBWithTx(t)
 	repo := repository.NewPermissionRepository(db)
@@ -347,6 +452,39 @@ func TestPermissionRepository_GetUserPermissionCodes(t *testing.T) {
 }
 
 // Helper functions
+func TestPermissionRepository_DeleteByModule(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewPermissionRepository(db)
+	ctx := CreateTestContext()
+
+	system := SeedTestSystem(t, db)
+	module := seedTestModule(t, db, system.ID)
+	other := domain.Module{SystemID: system.ID, Code: "TEST_MODULE_OTHER", Name: "Other", IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&other).Error)
+
+	for _, code := range []string{"TEST_PERM_DBM_1", "TEST_PERM_DBM_2"} {
+		require.NoError(t, db.Create(&domain.Permission{ModuleID: module.ID, Code: code, Name: code, IsActive: boolPtr(true)}).Error)
+	}
+	keep := domain.Permission{ModuleID: other.ID, Code: "TEST_PERM_DBM_KEEP", Name: "keep", IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&keep).Error)
+
+	deleted, err := repo.DeleteByModule(ctx, module.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	// Soft delete: идэвхтэй жагсаалтаас алга болно, бусад модулийн permission үлдэнэ
+	var remaining int64
+	require.NoError(t, db.Model(&domain.Permission{}).Where("module_id = ?", module.ID).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+	require.NoError(t, db.Model(&domain.Permission{}).Where("id = ?", keep.ID).Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining)
+
+	// Дахин дуудахад аль хэдийн устсан мөрүүд тоологдохгүй
+	deleted, err = repo.DeleteByModule(ctx, module.ID)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+}
+
 func seedTestModule(t *testing.T, db *gorm.DB, systemID int) domain.Module {
 	t.Helper()
 	module := domain.Module{
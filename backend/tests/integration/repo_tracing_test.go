@@ -0,0 +1,91 @@
+//go:build integration
+
+// Package integration provides integration tests for repositories
+//
+// File: repo_tracing_test.go
+// Description: Integration tests for OpenTelemetry spans emitted by repository methods
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"templatev25/internal/repository"
+
+	"git.gerege.mn/backend-packages/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setupTestTracer installs an in-memory exporter as the global tracer provider
+// and restores the previous provider when the test finishes.
+func setupTestTracer(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		_ = tp.Shutdown(context.Background())
+	})
+	return exporter
+}
+
+func TestOrganizationRepository_Spans(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	org := SeedTestOrganization(t, db)
+
+	exporter := setupTestTracer(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+	ctx := context.Background()
+
+	_, _, _, _, err := repo.List(ctx, common.PaginationQuery{Page: 1, Size: 10})
+	require.NoError(t, err)
+	_, err = repo.ByID(ctx, org.Id)
+	require.NoError(t, err)
+	_, err = repo.Exists(ctx, org.Id)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	for i, method := range []string{"List", "ByID", "Exists"} {
+		span := spans[i]
+		assert.Equal(t, "repo."+method, span.Name)
+		assert.Equal(t, "templatev25", span.InstrumentationScope.Name)
+
+		attrs := map[attribute.Key]string{}
+		for _, kv := range span.Attributes {
+			attrs[kv.Key] = kv.Value.AsString()
+		}
+		assert.Equal(t, "postgresql", attrs["db.system"])
+		assert.Equal(t, method, attrs["db.operation"])
+		assert.Equal(t, "organizations", attrs["db.table"])
+	}
+}
+
+func TestOrganizationRepository_SpanParent(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	org := SeedTestOrganization(t, db)
+
+	exporter := setupTestTracer(t)
+	repo := repository.NewOrganizationRepository(db, nil)
+
+	// Repository span нь дуудагчийн span-ийн хүү байх ёстой
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "handler")
+	_, err := repo.ByID(ctx, org.Id)
+	require.NoError(t, err)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "repo.ByID", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, spans[1].SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+}
@@ -105,24 +105,63 @@ func teardown(ctx context.Context, container testcontainers.Container) {
 
 // runMigrations creates test tables
 func runMigrations(db *gorm.DB) error {
-	return db.AutoMigrate(
+	// domain.APILog нь template_backend.logs хүснэгтэд хадгалагдана
+	if err := db.Exec(`CREATE SCHEMA IF NOT EXISTS template_backend`).Error; err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(
 		&domain.User{},
 		&domain.Organization{},
+		&domain.OrganizationUser{},
 		&domain.System{},
 		&domain.Module{},
 		&domain.Role{},
 		&domain.Permission{},
+		&domain.OrganizationType{},
+		&domain.OrgTypePermission{},
 		&domain.UserRole{},
 		&domain.Menu{},
+		&domain.MenuPermission{},
+		&domain.NewsCategory{},
 		&domain.News{},
 		&domain.Notification{},
 		&domain.NotificationGroup{},
+		&domain.Tag{},
 		&domain.ChatItem{},
-	)
+		&domain.Session{},
+		&domain.LoginHistory{},
+		&domain.UserCredential{},
+		&domain.PasswordHistory{},
+		&domain.UserMFATotp{},
+		&domain.UserMFABackupCode{},
+		&domain.RefreshToken{},
+		&domain.EmailVerificationToken{},
+		&domain.EmailVerification{},
+		&domain.PasswordResetToken{},
+		&domain.UserRoleHistory{},
+		&domain.SecurityAuditTrail{},
+		&domain.APILog{},
+		&domain.FeatureFlag{},
+	); err != nil {
+		return err
+	}
+
+	// migrations/017_organization_search_vector.sql-тэй ижил generated column
+	// (AutoMigrate schema дээр name/short_name/reg_no баганууд байна)
+	return db.Exec(`
+		ALTER TABLE organizations ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+			GENERATED ALWAYS AS (
+				to_tsvector('simple',
+					coalesce(name, '') || ' ' ||
+					coalesce(short_name, '') || ' ' ||
+					coalesce(reg_no, ''))
+			) STORED;
+		CREATE INDEX IF NOT EXISTS idx_organizations_search_vector ON organizations USING GIN(search_vector);
+	`).Error
 }
 
 // GetTestDB returns the test database connection
-func GetTestDB(t *testing.T) *gorm.DB {
+func GetTestDB(t testing.TB) *gorm.DB {
 	t.Helper()
 	if testDB == nil {
 		t.Fatal("test database not initialized")
@@ -131,7 +170,7 @@ func GetTestDB(t *testing.T) *gorm.DB {
 }
 
 // GetTestDBWithTx returns a database wrapped in a transaction for isolation
-func GetTestDBWithTx(t *testing.T) *gorm.DB {
+func GetTestDBWithTx(t testing.TB) *gorm.DB {
 	t.Helper()
 	db := GetTestDB(t)
 
@@ -168,7 +207,7 @@ func NewTestRepositories(t *testing.T, db *gorm.DB) *Repositories {
 		User:         repository.NewUserRepository(db),
 		Role:         repository.NewRoleRepository(db),
 		Permission:   repository.NewPermissionRepository(db),
-		Organization: repository.NewOrganizationRepository(db),
+		Organization: repository.NewOrganizationRepository(db, nil),
 		System:       repository.NewSystemRepository(db),
 		Module:       repository.NewModuleRepository(db, &config.Config{}),
 		Menu:         repository.NewMenuRepository(db, &config.Config{}),
@@ -363,6 +402,19 @@ func SeedTestNewsItems(t *testing.T, db *gorm.DB, count int) []domain.News {
 	return newsItems
 }
 
+// SeedTestNewsCategory creates a news category with the given code and returns it
+func SeedTestNewsCategory(t *testing.T, db *gorm.DB, code string) domain.NewsCategory {
+	t.Helper()
+	category := domain.NewsCategory{
+		Name: "Category " + code,
+		Code: code,
+	}
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatalf("failed to seed test news category: %v", err)
+	}
+	return category
+}
+
 // SeedTestNotificationGroup creates a test notification group
 func SeedTestNotificationGroup(t *testing.T, db *gorm.DB, userID int) domain.NotificationGroup {
 	t.Helper()
@@ -383,13 +435,13 @@ func SeedTestNotificationGroup(t *testing.T, db *gorm.DB, userID int) domain.Not
 func SeedTestNotification(t *testing.T, db *gorm.DB, userID int, groupID int) domain.Notification {
 	t.Helper()
 	notification := domain.Notification{
-		UserId:  userID,
-		Title:   "Test Notification",
-		Content: "Test notification content",
-		IsRead:  false,
-		Type:    "info",
-		Tenant:  "test",
-		GroupId: groupID,
+		UserId:   userID,
+		Title:    "Test Notification",
+		Content:  "Test notification content",
+		IsRead:   false,
+		Type:     "info",
+		TenantID: "test",
+		GroupId:  groupID,
 	}
 	if err := db.Create(&notification).Error; err != nil {
 		t.Fatalf("failed to seed test notification: %v", err)
@@ -403,13 +455,13 @@ func SeedTestNotifications(t *testing.T, db *gorm.DB, userID int, groupID int, c
 	notifications := make([]domain.Notification, count)
 	for i := 0; i < count; i++ {
 		notifications[i] = domain.Notification{
-			UserId:  userID,
-			Title:   fmt.Sprintf("Notification %d", i),
-			Content: fmt.Sprintf("Notification content %d", i),
-			IsRead:  false,
-			Type:    "info",
-			Tenant:  "test",
-			GroupId: groupID,
+			UserId:   userID,
+			Title:    fmt.Sprintf("Notification %d", i),
+			Content:  fmt.Sprintf("Notification content %d", i),
+			IsRead:   false,
+			Type:     "info",
+			TenantID: "test",
+			GroupId:  groupID,
 		}
 	}
 	if err := db.Create(&notifications).Error; err != nil {
@@ -430,6 +482,16 @@ func SeedTestChatItem(t *testing.T, db *gorm.DB) domain.ChatItem {
 	return item
 }
 
+// SeedTestTag creates a test tag
+func SeedTestTag(t *testing.T, db *gorm.DB, name string) domain.Tag {
+	t.Helper()
+	tag := domain.Tag{Name: name}
+	if err := db.Create(&tag).Error; err != nil {
+		t.Fatalf("failed to seed test tag: %v", err)
+	}
+	return tag
+}
+
 // SeedTestChatItems creates multiple test chat items
 func SeedTestChatItems(t *testing.T, db *gorm.DB, count int) []domain.ChatItem {
 	t.Helper()
@@ -16,6 +16,7 @@ import (
 	"git.gerege.mn/backend-packages/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestSystemRepository_Create(t *testing.T) {
@@ -111,6 +112,40 @@ func TestSystemRepository_ByID(t *testing.T) {
 	}
 }
 
+func TestSystemRepository_GetBySlug(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewSystemRepository(db)
+	ctx := CreateTestContext()
+
+	seeded := domain.System{Code: "slug_sys", Key: "slug_sys", Name: "Slug System", Slug: "slug-system"}
+	require.NoError(t, db.Create(&seeded).Error)
+
+	t.Run("found", func(t *testing.T) {
+		got, err := repo.GetBySlug(ctx, "slug-system")
+		require.NoError(t, err)
+		assert.Equal(t, seeded.ID, got.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := repo.GetBySlug(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("duplicate slug rejected", func(t *testing.T) {
+		dup := domain.System{Code: "slug_sys_dup", Key: "slug_sys_dup", Name: "Slug System", Slug: "slug-system"}
+		assert.Error(t, db.Transaction(func(tx *gorm.DB) error { return tx.Create(&dup).Error }))
+	})
+
+	t.Run("slug of deleted system can be reused", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, seeded.ID))
+		_, err := repo.GetBySlug(ctx, "slug-system")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		reused := domain.System{Code: "slug_sys_new", Key: "slug_sys_new", Name: "Slug System", Slug: "slug-system"}
+		require.NoError(t, db.Create(&reused).Error)
+	})
+}
+
 func TestSystemRepository_Update(t *testing.T) {
 	db := GetTestDBWithTx(t)
 	repo := repository.NewSystemRepository(db)
@@ -0,0 +1,72 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: system_role_seed_test.go
+// Description: Integration tests for the system role seeder
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"templatev25/internal/db"
+	"templatev25/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedSystemRoles_Idempotent(t *testing.T) {
+	gdb := GetTestDBWithTx(t)
+	ctx := context.Background()
+
+	isActive := true
+	admin := domain.System{Code: db.AdminSystemCode, Name: "Admin", IsActive: &isActive}
+	require.NoError(t, gdb.Create(&admin).Error)
+
+	inserted, err := db.SeedSystemRoles(ctx, gdb)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(db.SystemRoles)), inserted)
+
+	// Дахин ажиллуулахад юу ч нэмэгдэхгүй
+	inserted, err = db.SeedSystemRoles(ctx, gdb)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), inserted)
+
+	for _, r := range db.SystemRoles {
+		var roles []domain.Role
+		require.NoError(t, gdb.Where("code = ?", r.Code).Find(&roles).Error)
+		require.Len(t, roles, 1, r.Code)
+		assert.Equal(t, admin.ID, roles[0].SystemID)
+		require.NotNil(t, roles[0].IsSystemRole)
+		assert.True(t, *roles[0].IsSystemRole)
+	}
+}
+
+func TestSeedSystemRoles_KeepsExistingRole(t *testing.T) {
+	gdb := GetTestDBWithTx(t)
+	ctx := context.Background()
+
+	isActive := true
+	admin := domain.System{Code: db.AdminSystemCode, Name: "Admin", IsActive: &isActive}
+	require.NoError(t, gdb.Create(&admin).Error)
+	existing := domain.Role{SystemID: admin.ID, Code: "ADMIN", Name: "Renamed admin"}
+	require.NoError(t, gdb.Create(&existing).Error)
+
+	inserted, err := db.SeedSystemRoles(ctx, gdb)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(db.SystemRoles)-1), inserted)
+
+	var got domain.Role
+	require.NoError(t, gdb.First(&got, existing.ID).Error)
+	assert.Equal(t, "Renamed admin", got.Name, "ON CONFLICT DO NOTHING leaves existing rows untouched")
+}
+
+func TestSeedSystemRoles_MissingAdminSystem(t *testing.T) {
+	gdb := GetTestDBWithTx(t)
+
+	_, err := db.SeedSystemRoles(context.Background(), gdb)
+
+	assert.ErrorContains(t, err, db.AdminSystemCode)
+}
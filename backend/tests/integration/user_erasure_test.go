@@ -0,0 +1,153 @@
+//go:build integration
+
+// Package integration contains integration tests
+//
+// File: user_erasure_test.go
+// Description: GDPR erasure (UserService.Delete → notifications, auth data) integration tests
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"git.gerege.mn/backend-packages/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// erasureModels нь user_id-аар тоолох хүснэгтүүд
+var erasureModels = []interface{}{
+	&domain.Notification{},
+	&domain.NotificationGroup{},
+	&domain.UserCredential{},
+	&domain.PasswordHistory{},
+	&domain.UserMFATotp{},
+	&domain.UserMFABackupCode{},
+	&domain.Session{},
+	&domain.RefreshToken{},
+	&domain.LoginHistory{},
+	&domain.EmailVerificationToken{},
+	&domain.PasswordResetToken{},
+}
+
+// seedUserData нь хэрэглэгчид notification болон бүх auth өгөгдлийг үүсгэнэ
+func seedUserData(t *testing.T, db *gorm.DB, userID int) {
+	t.Helper()
+	group := SeedTestNotificationGroup(t, db, userID)
+	SeedTestNotifications(t, db, userID, group.Id, 2)
+
+	suffix := fmt.Sprintf("%d", userID)
+	expires := time.Now().Add(time.Hour)
+	uid := userID
+	rows := []interface{}{
+		&domain.UserCredential{UserID: userID, PasswordHash: "hash"},
+		&domain.PasswordHistory{UserID: userID, PasswordHash: "old-hash"},
+		&domain.UserMFATotp{UserID: userID, SecretEncrypted: "secret"},
+		&domain.UserMFABackupCode{UserID: userID, CodeHash: "code"},
+		&domain.Session{ID: "erasure-" + suffix, UserID: userID, ExpiresAt: expires},
+		&domain.RefreshToken{UserID: userID, TokenHash: "refresh-" + suffix, SessionID: "erasure-" + suffix, ExpiresAt: expires},
+		&domain.LoginHistory{UserID: &uid, Email: suffix + "@example.com", LoginMethod: "local", Success: true},
+		&domain.EmailVerificationToken{UserID: userID, Token: "verify-" + suffix, ExpiresAt: expires},
+		&domain.PasswordResetToken{UserID: userID, TokenHash: "reset-" + suffix, ExpiresAt: expires},
+	}
+	for _, row := range rows {
+		require.NoError(t, db.Create(row).Error)
+	}
+}
+
+// countUserRows нь хүснэгт бүрт userID-тай мөрийн тоог (soft delete-ийг оруулан) буцаана
+func countUserRows(t *testing.T, db *gorm.DB, userID int) map[string]int64 {
+	t.Helper()
+	out := map[string]int64{}
+	for _, model := range erasureModels {
+		var n int64
+		require.NoError(t, db.Unscoped().Model(model).Where("user_id = ?", userID).Count(&n).Error)
+		out[fmt.Sprintf("%T", model)] = n
+	}
+	return out
+}
+
+func assertAllZero(t *testing.T, counts map[string]int64) {
+	t.Helper()
+	for table, n := range counts {
+		assert.Zero(t, n, "rows left in %s", table)
+	}
+}
+
+func TestNotificationRepository_DeleteByUserID(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewNotificationRepository(db)
+	ctx := CreateTestContext()
+
+	users := SeedTestUsers(t, db, 2)
+	for _, u := range users {
+		group := SeedTestNotificationGroup(t, db, u.Id)
+		SeedTestNotifications(t, db, u.Id, group.Id, 3)
+	}
+
+	require.NoError(t, repo.DeleteByUserID(ctx, users[0].Id))
+	require.NoError(t, repo.DeleteGroupsByUserID(ctx, users[0].Id))
+
+	var n int64
+	require.NoError(t, db.Unscoped().Model(&domain.Notification{}).Where("user_id = ?", users[0].Id).Count(&n).Error)
+	assert.Zero(t, n)
+	require.NoError(t, db.Unscoped().Model(&domain.NotificationGroup{}).Where("user_id = ?", users[0].Id).Count(&n).Error)
+	assert.Zero(t, n)
+
+	// Өөр хэрэглэгчийнх хөндөгдөхгүй
+	require.NoError(t, db.Model(&domain.Notification{}).Where("user_id = ?", users[1].Id).Count(&n).Error)
+	assert.Equal(t, int64(3), n)
+}
+
+func TestAuthRepository_DeleteByUserID(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewAuthRepository(db, nil)
+	ctx := CreateTestContext()
+
+	users := SeedTestUsers(t, db, 2)
+	for _, u := range users {
+		seedUserData(t, db, u.Id)
+	}
+
+	require.NoError(t, repo.DeleteByUserID(ctx, users[0].Id))
+
+	counts := countUserRows(t, db, users[0].Id)
+	// Notification нь auth өгөгдөл биш тул хөндөгдөхгүй
+	assert.Equal(t, int64(2), counts["*domain.Notification"])
+	assert.Equal(t, int64(1), counts["*domain.NotificationGroup"])
+	delete(counts, "*domain.Notification")
+	delete(counts, "*domain.NotificationGroup")
+	assertAllZero(t, counts)
+
+	for table, n := range countUserRows(t, db, users[1].Id) {
+		assert.NotZero(t, n, "other user's %s was deleted", table)
+	}
+}
+
+func TestUserService_Delete_ErasesUserData(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	ctx := CreateTestContext()
+	user := SeedTestUser(t, db)
+	seedUserData(t, db, user.Id)
+
+	notifRepo := repository.NewNotificationRepository(db)
+	svc := service.NewUserService(repository.NewUserRepository(db), &config.Config{}, zap.NewNop())
+	svc.SetErasure(repository.NewTxManager(db), notifRepo, repository.NewAuthRepository(db, nil), nil)
+
+	_, err := svc.Delete(ctx, user.Id)
+	require.NoError(t, err)
+
+	assertAllZero(t, countUserRows(t, db, user.Id))
+
+	// Хэрэглэгч soft delete хийгдсэн
+	var deleted domain.User
+	require.NoError(t, db.Unscoped().Take(&deleted, "id = ?", user.Id).Error)
+	assert.True(t, deleted.DeletedDate.Valid)
+}
@@ -0,0 +1,177 @@
+//go:build integration
+
+// Package integration provides integration tests for HTTP handlers
+//
+// File: user_export_test.go
+// Description: Integration tests for GET /user/export content negotiation
+package integration
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	ihttp "templatev25/internal/http"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+	"templatev25/internal/service/export"
+
+	"git.gerege.mn/backend-packages/common"
+	"git.gerege.mn/backend-packages/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// setupUserExportTestApp creates a test Fiber app with GET /user/export backed by the real user service
+func setupUserExportTestApp(db *gorm.DB) *fiber.App {
+	userSvc := service.NewUserService(repository.NewUserRepository(db), &config.Config{}, zap.NewNop())
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+
+	app.Get("/api/v1/user/export", func(c *fiber.Ctx) error {
+		c.Vary(fiber.HeaderAccept)
+		format, err := export.Resolve(c.Query("format"), c.Get(fiber.HeaderAccept))
+		if err != nil {
+			if errors.Is(err, export.ErrNotAcceptable) {
+				return fiber.NewError(fiber.StatusNotAcceptable, err.Error())
+			}
+			return fiber.NewError(fiber.StatusBadRequest, "unsupported export format: "+c.Query("format"))
+		}
+		var p common.PaginationQuery
+		if err := c.QueryParser(&p); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+		}
+
+		filename := fmt.Sprintf("users_%s.%s", time.Now().Format("2006-01-02"), format.Extension())
+		c.Set(fiber.HeaderContentType, format.ContentType())
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+		ctx := c.UserContext()
+		return ihttp.Stream(c, func(w io.Writer) error {
+			return userSvc.Export(ctx, p, format, w)
+		})
+	})
+
+	return app
+}
+
+// doExport performs GET /user/export and returns the response and body
+func doExport(t *testing.T, app *fiber.App, query, accept string) (*http.Response, []byte) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/user/export"+query, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp, body
+}
+
+// exportedIDs нь export-ийн мөрүүдээс id баганыг цуглуулна (header-гүй)
+func exportedIDs(rows [][]string) map[string]bool {
+	ids := map[string]bool{}
+	for _, row := range rows[1:] {
+		ids[row[0]] = true
+	}
+	return ids
+}
+
+func TestUserHandler_Export(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	users := SeedTestUsers(t, db, 3)
+	app := setupUserExportTestApp(db)
+
+	header := []string{"id", "reg_no", "first_name", "last_name", "email", "phone_no", "created_date"}
+
+	t.Run("csv - default without accept", func(t *testing.T) {
+		resp, body := doExport(t, app, "", "")
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), ".csv")
+
+		rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, header, rows[0])
+		ids := exportedIDs(rows)
+		for _, u := range users {
+			assert.True(t, ids[strconv.Itoa(u.Id)], "user %d missing from CSV", u.Id)
+		}
+	})
+
+	t.Run("json - accept application/json returns ndjson", func(t *testing.T) {
+		resp, body := doExport(t, app, "", "application/json")
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), ".ndjson")
+		assert.Equal(t, "Accept", resp.Header.Get("Vary"))
+
+		ids := map[string]bool{}
+		sc := bufio.NewScanner(bytes.NewReader(body))
+		for sc.Scan() {
+			var row map[string]string
+			require.NoError(t, json.Unmarshal(sc.Bytes(), &row))
+			for _, col := range header {
+				assert.Contains(t, row, col)
+			}
+			ids[row["id"]] = true
+		}
+		for _, u := range users {
+			assert.True(t, ids[strconv.Itoa(u.Id)], "user %d missing from NDJSON", u.Id)
+		}
+	})
+
+	t.Run("xlsx - accept application/vnd.ms-excel", func(t *testing.T) {
+		resp, body := doExport(t, app, "", "application/vnd.ms-excel")
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, export.MIMEXLSX, resp.Header.Get("Content-Type"))
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), ".xlsx")
+
+		wb, err := excelize.OpenReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		defer wb.Close()
+		rows, err := wb.GetRows("Sheet1")
+		require.NoError(t, err)
+		assert.Equal(t, header, rows[0])
+		ids := exportedIDs(rows)
+		for _, u := range users {
+			assert.True(t, ids[strconv.Itoa(u.Id)], "user %d missing from XLSX", u.Id)
+		}
+	})
+
+	t.Run("query format overrides accept", func(t *testing.T) {
+		resp, _ := doExport(t, app, "?format=csv", "application/json")
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	})
+
+	t.Run("unsupported query format returns 400", func(t *testing.T) {
+		resp, _ := doExport(t, app, "?format=pdf", "")
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("unacceptable accept returns 406", func(t *testing.T) {
+		resp, _ := doExport(t, app, "", "image/png")
+
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+	})
+}
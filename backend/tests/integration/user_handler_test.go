@@ -117,7 +117,7 @@ func setupUserTestApp(svc *mockUserService) *fiber.App {
 
 		u, err := svc.GetByID(c.UserContext(), id)
 		if err != nil {
-			if err.Error() == "not found" {
+			if errors.Is(err, domain.ErrNotFound) {
 				return fiber.NewError(fiber.StatusNotFound, "user not found")
 			}
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -172,7 +172,7 @@ func setupUserTestApp(svc *mockUserService) *fiber.App {
 
 		u, err := svc.Update(c.UserContext(), req)
 		if err != nil {
-			if err.Error() == "not found" {
+			if errors.Is(err, domain.ErrNotFound) {
 				return fiber.NewError(fiber.StatusNotFound, "user not found")
 			}
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -194,7 +194,7 @@ func setupUserTestApp(svc *mockUserService) *fiber.App {
 
 		u, err := svc.Delete(c.UserContext(), id)
 		if err != nil {
-			if err.Error() == "not found" {
+			if errors.Is(err, domain.ErrNotFound) {
 				return fiber.NewError(fiber.StatusNotFound, "user not found")
 			}
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -321,7 +321,7 @@ func TestUserHandler_GetByID(t *testing.T) {
 			userID: "999",
 			mockSetup: func(m *mockUserService) {
 				m.On("GetByID", mock.Anything, 999).
-					Return(domain.User{}, errors.New("not found"))
+					Return(domain.User{}, domain.ErrNotFound)
 			},
 			wantStatus: http.StatusNotFound,
 		},
@@ -459,7 +459,7 @@ func TestUserHandler_Update(t *testing.T) {
 			body:   `{"first_name": "John", "last_name": "Doe"}`,
 			mockSetup: func(m *mockUserService) {
 				m.On("Update", mock.Anything, mock.AnythingOfType("dto.UserUpdateDto")).
-					Return(domain.User{}, errors.New("not found"))
+					Return(domain.User{}, domain.ErrNotFound)
 			},
 			wantStatus: http.StatusNotFound,
 		},
@@ -521,7 +521,7 @@ func TestUserHandler_Delete(t *testing.T) {
 			userID: "999",
 			mockSetup: func(m *mockUserService) {
 				m.On("Delete", mock.Anything, 999).
-					Return(domain.User{}, errors.New("not found"))
+					Return(domain.User{}, domain.ErrNotFound)
 			},
 			wantStatus: http.StatusNotFound,
 		},
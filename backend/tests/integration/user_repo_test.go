@@ -7,6 +7,7 @@
 package integration
 
 import (
+	"fmt"
 	"testing"
 
 	"templatev25/internal/domain"
@@ -273,6 +274,32 @@ func TestUserRepository_List(t *testing.T) {
 	}
 }
 
+func TestUserRepository_ListStream(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewUserRepository(db)
+	ctx := CreateTestContext()
+
+	seeded := SeedTestUsers(t, db, 15)
+
+	// Size нь stream-д үл хэрэглэгдэнэ: бүх мөр ирнэ
+	items, errc := repo.ListStream(ctx, common.PaginationQuery{Page: 1, Size: 5})
+
+	ids := map[int]bool{}
+	prev := 0
+	for u := range items {
+		if prev != 0 {
+			assert.Less(t, u.Id, prev, "default sort is id DESC")
+		}
+		prev = u.Id
+		ids[u.Id] = true
+	}
+	require.NoError(t, <-errc)
+
+	for _, u := range seeded {
+		assert.True(t, ids[u.Id], "user %d missing from stream", u.Id)
+	}
+}
+
 func TestUserRepository_UserOrgIDs(t *testing.T) {
 	db := GetTestDBWithTx(t)
 	repo := repository.NewUserRepository(db)
@@ -319,3 +346,74 @@ func TestUserRepository_UserOrgIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_BulkUpsert(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewUserRepository(db)
+	ctx := CreateTestContext()
+
+	const (
+		count  = 1000
+		baseID = 1_000_000 // sequence-тэй давхцахгүй SSO id-ууд
+	)
+	build := func(firstName string) []domain.User {
+		users := make([]domain.User, count)
+		for i := range users {
+			users[i] = domain.User{
+				Id:        baseID + i,
+				RegNo:     fmt.Sprintf("УУ%08d", i),
+				FirstName: firstName,
+				LastName:  "Sync",
+				Email:     fmt.Sprintf("sync%d@example.com", i),
+			}
+		}
+		return users
+	}
+	countSynced := func() int64 {
+		var n int64
+		require.NoError(t, db.Model(&domain.User{}).Where("id >= ? AND id < ?", baseID, baseID+count).Count(&n).Error)
+		return n
+	}
+
+	upsert := func(users []domain.User) []int {
+		skipped, err := repo.BulkUpsert(ctx, users)
+		require.NoError(t, err)
+		return skipped
+	}
+
+	// Анхны sync
+	assert.Empty(t, upsert(build("First")))
+	assert.Equal(t, int64(count), countSynced())
+
+	// Локал талбарыг өөрчилнө — дахин sync хийхэд дарагдах ёсгүй
+	require.NoError(t, db.Model(&domain.User{}).Where("id = ?", baseID).
+		Updates(map[string]interface{}{"status": "suspended", "login_count": 7}).Error)
+
+	// Дахин sync: мөрийн тоо өөрчлөгдөхгүй, SSO талбарууд шинэчлэгдэнэ
+	assert.Empty(t, upsert(build("Second")))
+	assert.Equal(t, int64(count), countSynced())
+
+	got, err := repo.GetByID(ctx, baseID+count-1)
+	require.NoError(t, err)
+	assert.Equal(t, "Second", got.FirstName)
+
+	first, err := repo.GetByID(ctx, baseID)
+	require.NoError(t, err)
+	assert.Equal(t, "Second", first.FirstName)
+	assert.Equal(t, "suspended", first.Status)
+	assert.Equal(t, 7, first.LoginCount)
+
+	// Устгагдсан хэрэглэгч сэргээгдэхгүй, SSO талбар нь дарагдахгүй
+	_, err = repo.Delete(ctx, baseID+1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{baseID + 1}, upsert(build("Third")))
+	assert.Equal(t, int64(count-1), countSynced())
+
+	var deleted domain.User
+	require.NoError(t, db.Unscoped().Take(&deleted, "id = ?", baseID+1).Error)
+	assert.Equal(t, "Second", deleted.FirstName)
+	assert.True(t, deleted.DeletedDate.Valid)
+
+	// Хоосон slice — юу ч хийхгүй
+	assert.Empty(t, upsert(nil))
+}
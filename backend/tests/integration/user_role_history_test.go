@@ -0,0 +1,84 @@
+//go:build integration
+
+// Package integration provides integration tests for repositories
+//
+// File: user_role_history_test.go
+// Description: Integration tests for role assignment history (AuditedUserRoleService)
+package integration
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"git.gerege.mn/backend-packages/common"
+	"git.gerege.mn/backend-packages/ctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAuditedUserRoleService_History(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	userRoleRepo := repository.NewUserRoleRepository(db)
+	authRepo := repository.NewAuthRepository(db, nil)
+	svc := service.NewAuditedUserRoleService(service.NewUserRoleService(userRoleRepo), userRoleRepo, authRepo, zap.NewNop())
+
+	system := SeedTestSystem(t, db)
+	admin := SeedTestUser(t, db)
+	user := SeedTestUser(t, db)
+	editor := domain.Role{SystemID: system.ID, Code: "TEST_EDITOR", Name: "Editor", IsActive: boolPtr(true)}
+	viewer := domain.Role{SystemID: system.ID, Code: "TEST_VIEWER", Name: "Viewer", IsActive: boolPtr(true)}
+	require.NoError(t, db.Create(&editor).Error)
+	require.NoError(t, db.Create(&viewer).Error)
+
+	// Өөрчлөлт хийсэн хэрэглэгч (auth middleware-ийн тавьдагтай ижил)
+	actx := ctx.WithValue(CreateTestContext(), ctx.KeyUserID, admin.Id)
+
+	require.NoError(t, svc.AssignByUser(actx, dto.UserRoleAssignByUser{UserID: user.Id, RoleIDs: []int{editor.ID, viewer.ID}}))
+	require.NoError(t, svc.Remove(actx, dto.UserRoleRemoveDto{UserID: user.Id, RoleID: editor.ID}))
+
+	t.Run("history is recorded newest first", func(t *testing.T) {
+		items, total, _, _, err := svc.History(actx, user.Id, common.PaginationQuery{Page: 1, Size: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, items, 3)
+
+		assert.Equal(t, domain.UserRoleActionRevoked, items[0].Action)
+		assert.Equal(t, editor.ID, items[0].RoleID)
+		require.NotNil(t, items[0].Role)
+		assert.Equal(t, "TEST_EDITOR", items[0].Role.Code)
+
+		for _, h := range items {
+			assert.Equal(t, user.Id, h.UserID)
+			require.NotNil(t, h.ChangedBy)
+			assert.Equal(t, admin.Id, *h.ChangedBy)
+		}
+		assert.ElementsMatch(t,
+			[]string{domain.UserRoleActionAssigned, domain.UserRoleActionAssigned},
+			[]string{items[1].Action, items[2].Action})
+	})
+
+	t.Run("other user has no history", func(t *testing.T) {
+		items, total, _, _, err := svc.History(actx, admin.Id, common.PaginationQuery{Page: 1, Size: 10})
+		require.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, items)
+	})
+
+	t.Run("audit trail entries are written", func(t *testing.T) {
+		var audits []domain.SecurityAuditTrail
+		require.NoError(t, db.Where("target_type = ?", "user_role").Order("id").Find(&audits).Error)
+		require.Len(t, audits, 3)
+
+		revoked := audits[2]
+		assert.Equal(t, domain.UserRoleActionRevoked, revoked.Action)
+		require.NotNil(t, revoked.UserID)
+		assert.Equal(t, admin.Id, *revoked.UserID)
+		assert.JSONEq(t, `{"user_id":`+itoa(user.Id)+`,"role_id":`+itoa(editor.ID)+`}`, revoked.OldValue)
+		assert.JSONEq(t, `null`, revoked.NewValue)
+	})
+}
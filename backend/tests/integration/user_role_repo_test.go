@@ -0,0 +1,79 @@
+//go:build integration
+
+// Package integration provides integration tests for repositories
+//
+// File: user_role_repo_test.go
+// Description: Integration tests for UserRoleRepository.GetRoleCodes
+package integration
+
+import (
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRoleRepository_GetRoleCodes(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewUserRoleRepository(db)
+	ctx := CreateTestContext()
+
+	system := SeedTestSystem(t, db)
+	user := SeedTestUser(t, db)
+	other := SeedTestUser(t, db)
+
+	admin := domain.Role{SystemID: system.ID, Code: "TEST_ADMIN", Name: "Admin", IsActive: boolPtr(true)}
+	support := domain.Role{SystemID: system.ID, Code: "TEST_SUPPORT", Name: "Support", IsActive: boolPtr(true)}
+	inactive := domain.Role{SystemID: system.ID, Code: "TEST_INACTIVE", Name: "Inactive", IsActive: boolPtr(false)}
+	require.NoError(t, db.Create(&admin).Error)
+	require.NoError(t, db.Create(&support).Error)
+	require.NoError(t, db.Create(&inactive).Error)
+
+	require.NoError(t, repo.AddRolesToUser(ctx, user.Id, []int{admin.ID, support.ID, inactive.ID}))
+
+	t.Run("returns active role codes", func(t *testing.T) {
+		codes, err := repo.GetRoleCodes(ctx, user.Id)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"TEST_ADMIN", "TEST_SUPPORT"}, codes)
+	})
+
+	t.Run("user without roles", func(t *testing.T) {
+		codes, err := repo.GetRoleCodes(ctx, other.Id)
+		require.NoError(t, err)
+		assert.Empty(t, codes)
+	})
+
+	t.Run("removed role is excluded", func(t *testing.T) {
+		require.NoError(t, repo.Remove(ctx, user.Id, support.ID))
+		codes, err := repo.GetRoleCodes(ctx, user.Id)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TEST_ADMIN"}, codes)
+	})
+}
+
+func TestUserRoleRepository_GetByUserID(t *testing.T) {
+	db := GetTestDBWithTx(t)
+	repo := repository.NewUserRoleRepository(db)
+	ctx := CreateTestContext()
+
+	system := SeedTestSystem(t, db)
+	user := SeedTestUser(t, db)
+	admin := domain.Role{SystemID: system.ID, Code: "TEST_ROLES_ADMIN", Name: "Admin", IsActive: boolPtr(true)}
+	inactive := domain.Role{SystemID: system.ID, Code: "TEST_ROLES_INACTIVE", Name: "Inactive", IsActive: boolPtr(false)}
+	require.NoError(t, db.Create(&admin).Error)
+	require.NoError(t, db.Create(&inactive).Error)
+	require.NoError(t, repo.AddRolesToUser(ctx, user.Id, []int{admin.ID, inactive.ID}))
+
+	items, err := repo.GetByUserID(ctx, user.Id)
+
+	require.NoError(t, err)
+	require.Len(t, items, 2, "inactive roles are listed too")
+	for _, ur := range items {
+		require.NotNil(t, ur.Role)
+		require.NotNil(t, ur.Role.System)
+		assert.Equal(t, system.Name, ur.Role.System.Name)
+	}
+}
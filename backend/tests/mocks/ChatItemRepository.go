@@ -4,6 +4,9 @@ package mocks
 
 import (
 	context "context"
+
+	common "git.gerege.mn/backend-packages/common"
+
 	domain "templatev25/internal/domain"
 	dto "templatev25/internal/http/dto"
 
@@ -15,6 +18,24 @@ type ChatItemRepository struct {
 	mock.Mock
 }
 
+// AddTags provides a mock function with given fields: ctx, itemID, tagIDs
+func (_m *ChatItemRepository) AddTags(ctx context.Context, itemID int, tagIDs []int) error {
+	ret := _m.Called(ctx, itemID, tagIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, itemID, tagIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ByID provides a mock function with given fields: ctx, id
 func (_m *ChatItemRepository) ByID(ctx context.Context, id int) (domain.ChatItem, error) {
 	ret := _m.Called(ctx, id)
@@ -107,6 +128,36 @@ func (_m *ChatItemRepository) FindByKey(ctx context.Context, key string) (domain
 	return r0, r1
 }
 
+// GetTags provides a mock function with given fields: ctx, itemID
+func (_m *ChatItemRepository) GetTags(ctx context.Context, itemID int) ([]domain.Tag, error) {
+	ret := _m.Called(ctx, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []domain.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Tag, error)); ok {
+		return rf(ctx, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Tag); ok {
+		r0 = rf(ctx, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, q
 func (_m *ChatItemRepository) List(ctx context.Context, q dto.ChatItemQuery) ([]domain.ChatItem, int64, int, int, error) {
 	ret := _m.Called(ctx, q)
@@ -158,6 +209,75 @@ func (_m *ChatItemRepository) List(ctx context.Context, q dto.ChatItemQuery) ([]
 	return r0, r1, r2, r3, r4
 }
 
+// RemoveTag provides a mock function with given fields: ctx, itemID, tagID
+func (_m *ChatItemRepository) RemoveTag(ctx context.Context, itemID int, tagID int) error {
+	ret := _m.Called(ctx, itemID, tagID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, itemID, tagID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: ctx, query, p
+func (_m *ChatItemRepository) Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.ChatItem, int64, int, int, error) {
+	ret := _m.Called(ctx, query, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.ChatItem
+	var r1 int64
+	var r2 int
+	var r3 int
+	var r4 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, common.PaginationQuery) ([]domain.ChatItem, int64, int, int, error)); ok {
+		return rf(ctx, query, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, common.PaginationQuery) []domain.ChatItem); ok {
+		r0 = rf(ctx, query, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ChatItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, common.PaginationQuery) int64); ok {
+		r1 = rf(ctx, query, p)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, common.PaginationQuery) int); ok {
+		r2 = rf(ctx, query, p)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, string, common.PaginationQuery) int); ok {
+		r3 = rf(ctx, query, p)
+	} else {
+		r3 = ret.Get(3).(int)
+	}
+
+	if rf, ok := ret.Get(4).(func(context.Context, string, common.PaginationQuery) error); ok {
+		r4 = rf(ctx, query, p)
+	} else {
+		r4 = ret.Error(4)
+	}
+
+	return r0, r1, r2, r3, r4
+}
+
 // Update provides a mock function with given fields: ctx, id, m
 func (_m *ChatItemRepository) Update(ctx context.Context, id int, m domain.ChatItem) error {
 	ret := _m.Called(ctx, id, m)
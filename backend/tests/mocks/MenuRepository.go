@@ -250,6 +250,102 @@ func (_m *MenuRepository) ListByUserRoles(ctx context.Context, userID int) ([]do
 	return r0, r1
 }
 
+// ListByUserRolesInSystems provides a mock function with given fields: ctx, userID, systemIDs
+func (_m *MenuRepository) ListByUserRolesInSystems(ctx context.Context, userID int, systemIDs []int) ([]domain.Menu, error) {
+	ret := _m.Called(ctx, userID, systemIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserRolesInSystems")
+	}
+
+	var r0 []domain.Menu
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) ([]domain.Menu, error)); ok {
+		return rf(ctx, userID, systemIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) []domain.Menu); ok {
+		r0 = rf(ctx, userID, systemIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Menu)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, userID, systemIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMenuPermissions provides a mock function with given fields: ctx
+func (_m *MenuRepository) ListMenuPermissions(ctx context.Context) ([]domain.MenuPermission, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMenuPermissions")
+	}
+
+	var r0 []domain.MenuPermission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.MenuPermission, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.MenuPermission); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.MenuPermission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reorder provides a mock function with given fields: ctx, orders
+func (_m *MenuRepository) Reorder(ctx context.Context, orders []dto.MenuReorderItem) error {
+	ret := _m.Called(ctx, orders)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reorder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []dto.MenuReorderItem) error); ok {
+		r0 = rf(ctx, orders)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPermissions provides a mock function with given fields: ctx, menuID, permissionIDs
+func (_m *MenuRepository) SetPermissions(ctx context.Context, menuID int64, permissionIDs []int) error {
+	ret := _m.Called(ctx, menuID, permissionIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPermissions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int) error); ok {
+		r0 = rf(ctx, menuID, permissionIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, id, m
 func (_m *MenuRepository) Update(ctx context.Context, id int64, m domain.Menu) error {
 	ret := _m.Called(ctx, id, m)
@@ -43,6 +43,36 @@ func (_m *ModuleRepository) ByID(ctx context.Context, id int) (domain.Module, er
 	return r0, r1
 }
 
+// ByIDs provides a mock function with given fields: ctx, ids
+func (_m *ModuleRepository) ByIDs(ctx context.Context, ids []int) ([]domain.Module, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ByIDs")
+	}
+
+	var r0 []domain.Module
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]domain.Module, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []domain.Module); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Module)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, m
 func (_m *ModuleRepository) Create(ctx context.Context, m domain.Module) error {
 	ret := _m.Called(ctx, m)
@@ -130,6 +130,24 @@ func (_m *NewsRepository) List(ctx context.Context, q dto.NewsListQuery) ([]doma
 	return r0, r1, r2, r3, r4
 }
 
+// SetPublished provides a mock function with given fields: ctx, id, published
+func (_m *NewsRepository) SetPublished(ctx context.Context, id int, published bool) error {
+	ret := _m.Called(ctx, id, published)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPublished")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) error); ok {
+		r0 = rf(ctx, id, published)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, id, m
 func (_m *NewsRepository) Update(ctx context.Context, id int, m domain.News) error {
 	ret := _m.Called(ctx, id, m)
@@ -47,6 +47,36 @@ func (_m *NotificationRepository) AllUserIDs(ctx context.Context) ([]int, error)
 	return r0, r1
 }
 
+// CountByType provides a mock function with given fields: ctx, userID
+func (_m *NotificationRepository) CountByType(ctx context.Context, userID int) (map[string]int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByType")
+	}
+
+	var r0 map[string]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (map[string]int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) map[string]int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateGroup provides a mock function with given fields: ctx, g
 func (_m *NotificationRepository) CreateGroup(ctx context.Context, g domain.NotificationGroup) (domain.NotificationGroup, error) {
 	ret := _m.Called(ctx, g)
@@ -75,6 +105,34 @@ func (_m *NotificationRepository) CreateGroup(ctx context.Context, g domain.Noti
 	return r0, r1
 }
 
+// CreateGroupWithNotifications provides a mock function with given fields: ctx, g, ns
+func (_m *NotificationRepository) CreateGroupWithNotifications(ctx context.Context, g domain.NotificationGroup, ns []domain.Notification) (domain.NotificationGroup, error) {
+	ret := _m.Called(ctx, g, ns)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroupWithNotifications")
+	}
+
+	var r0 domain.NotificationGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.NotificationGroup, []domain.Notification) (domain.NotificationGroup, error)); ok {
+		return rf(ctx, g, ns)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.NotificationGroup, []domain.Notification) domain.NotificationGroup); ok {
+		r0 = rf(ctx, g, ns)
+	} else {
+		r0 = ret.Get(0).(domain.NotificationGroup)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.NotificationGroup, []domain.Notification) error); ok {
+		r1 = rf(ctx, g, ns)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateNotification provides a mock function with given fields: ctx, n
 func (_m *NotificationRepository) CreateNotification(ctx context.Context, n domain.Notification) (domain.Notification, error) {
 	ret := _m.Called(ctx, n)
@@ -121,6 +179,93 @@ func (_m *NotificationRepository) CreateNotificationsBulk(ctx context.Context, n
 	return r0
 }
 
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *NotificationRepository) DeleteByUserID(ctx context.Context, userID int) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGroupsByUserID provides a mock function with given fields: ctx, userID
+func (_m *NotificationRepository) DeleteGroupsByUserID(ctx context.Context, userID int) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroupsByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListByTenant provides a mock function with given fields: ctx, tenantID, userID, p
+func (_m *NotificationRepository) ListByTenant(ctx context.Context, tenantID string, userID int, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error) {
+	ret := _m.Called(ctx, tenantID, userID, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByTenant")
+	}
+
+	var r0 []domain.Notification
+	var r1 int64
+	var r2 int
+	var r3 int
+	var r4 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, common.PaginationQuery) ([]domain.Notification, int64, int, int, error)); ok {
+		return rf(ctx, tenantID, userID, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, common.PaginationQuery) []domain.Notification); ok {
+		r0 = rf(ctx, tenantID, userID, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, common.PaginationQuery) int64); ok {
+		r1 = rf(ctx, tenantID, userID, p)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, common.PaginationQuery) int); ok {
+		r2 = rf(ctx, tenantID, userID, p)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, string, int, common.PaginationQuery) int); ok {
+		r3 = rf(ctx, tenantID, userID, p)
+	} else {
+		r3 = ret.Get(3).(int)
+	}
+
+	if rf, ok := ret.Get(4).(func(context.Context, string, int, common.PaginationQuery) error); ok {
+		r4 = rf(ctx, tenantID, userID, p)
+	} else {
+		r4 = ret.Error(4)
+	}
+
+	return r0, r1, r2, r3, r4
+}
+
 // ListByUser provides a mock function with given fields: ctx, userID, p
 func (_m *NotificationRepository) ListByUser(ctx context.Context, userID int, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error) {
 	ret := _m.Called(ctx, userID, p)
@@ -224,21 +369,31 @@ func (_m *NotificationRepository) ListGroups(ctx context.Context, p common.Pagin
 }
 
 // MarkAllRead provides a mock function with given fields: ctx, userID
-func (_m *NotificationRepository) MarkAllRead(ctx context.Context, userID int) error {
+func (_m *NotificationRepository) MarkAllRead(ctx context.Context, userID int) (int64, error) {
 	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MarkAllRead")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
 		r0 = rf(ctx, userID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MarkGroupRead provides a mock function with given fields: ctx, userID, groupID
@@ -259,6 +414,92 @@ func (_m *NotificationRepository) MarkGroupRead(ctx context.Context, userID int,
 	return r0
 }
 
+// MarkReadByIDs provides a mock function with given fields: ctx, userID, ids
+func (_m *NotificationRepository) MarkReadByIDs(ctx context.Context, userID int, ids []int) (int64, error) {
+	ret := _m.Called(ctx, userID, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReadByIDs")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) (int64, error)); ok {
+		return rf(ctx, userID, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) int64); ok {
+		r0 = rf(ctx, userID, ids)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, userID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MissingUserIDs provides a mock function with given fields: ctx, ids
+func (_m *NotificationRepository) MissingUserIDs(ctx context.Context, ids []int) ([]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MissingUserIDs")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]int, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnreadCount provides a mock function with given fields: ctx, userID
+func (_m *NotificationRepository) UnreadCount(ctx context.Context, userID int) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnreadCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewNotificationRepository creates a new instance of NotificationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewNotificationRepository(t interface {
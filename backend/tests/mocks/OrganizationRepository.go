@@ -9,6 +9,8 @@ import (
 
 	domain "templatev25/internal/domain"
 
+	dto "templatev25/internal/http/dto"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -45,6 +47,34 @@ func (_m *OrganizationRepository) ByID(ctx context.Context, id int) (domain.Orga
 	return r0, r1
 }
 
+// ByIDWithDetail provides a mock function with given fields: ctx, id
+func (_m *OrganizationRepository) ByIDWithDetail(ctx context.Context, id int) (dto.OrganizationDetail, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ByIDWithDetail")
+	}
+
+	var r0 dto.OrganizationDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (dto.OrganizationDetail, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) dto.OrganizationDetail); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(dto.OrganizationDetail)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, m
 func (_m *OrganizationRepository) Create(ctx context.Context, m domain.Organization) (domain.Organization, error) {
 	ret := _m.Called(ctx, m)
@@ -91,6 +121,62 @@ func (_m *OrganizationRepository) Delete(ctx context.Context, id int) error {
 	return r0
 }
 
+// DeletedByID provides a mock function with given fields: ctx, id
+func (_m *OrganizationRepository) DeletedByID(ctx context.Context, id int) (domain.Organization, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletedByID")
+	}
+
+	var r0 domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Organization, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Organization); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exists provides a mock function with given fields: ctx, id
+func (_m *OrganizationRepository) Exists(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, p
 func (_m *OrganizationRepository) List(ctx context.Context, p common.PaginationQuery) ([]domain.Organization, int64, int, int, error) {
 	ret := _m.Called(ctx, p)
@@ -142,12 +228,129 @@ func (_m *OrganizationRepository) List(ctx context.Context, p common.PaginationQ
 	return r0, r1, r2, r3, r4
 }
 
-// Tree provides a mock function with given fields: ctx, rootID
-func (_m *OrganizationRepository) Tree(ctx context.Context, rootID int) ([]domain.Organization, error) {
+// MoveToParent provides a mock function with given fields: ctx, orgID, newParentID
+func (_m *OrganizationRepository) MoveToParent(ctx context.Context, orgID int, newParentID int) error {
+	ret := _m.Called(ctx, orgID, newParentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveToParent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, orgID, newParentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *OrganizationRepository) Restore(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: ctx, query, p
+func (_m *OrganizationRepository) Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.Organization, int64, int, int, error) {
+	ret := _m.Called(ctx, query, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.Organization
+	var r1 int64
+	var r2 int
+	var r3 int
+	var r4 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, common.PaginationQuery) ([]domain.Organization, int64, int, int, error)); ok {
+		return rf(ctx, query, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, common.PaginationQuery) []domain.Organization); ok {
+		r0 = rf(ctx, query, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, common.PaginationQuery) int64); ok {
+		r1 = rf(ctx, query, p)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, common.PaginationQuery) int); ok {
+		r2 = rf(ctx, query, p)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, string, common.PaginationQuery) int); ok {
+		r3 = rf(ctx, query, p)
+	} else {
+		r3 = ret.Get(3).(int)
+	}
+
+	if rf, ok := ret.Get(4).(func(context.Context, string, common.PaginationQuery) error); ok {
+		r4 = rf(ctx, query, p)
+	} else {
+		r4 = ret.Error(4)
+	}
+
+	return r0, r1, r2, r3, r4
+}
+
+// Stats provides a mock function with given fields: ctx
+func (_m *OrganizationRepository) Stats(ctx context.Context) ([]dto.OrgTypeStats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 []dto.OrgTypeStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dto.OrgTypeStats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dto.OrgTypeStats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.OrgTypeStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TreeCTE provides a mock function with given fields: ctx, rootID
+func (_m *OrganizationRepository) TreeCTE(ctx context.Context, rootID int) ([]domain.Organization, error) {
 	ret := _m.Called(ctx, rootID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Tree")
+		panic("no return value specified for TreeCTE")
 	}
 
 	var r0 []domain.Organization
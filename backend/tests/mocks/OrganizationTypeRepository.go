@@ -17,6 +17,24 @@ type OrganizationTypeRepository struct {
 	mock.Mock
 }
 
+// AddPermissions provides a mock function with given fields: ctx, orgTypeID, permissionIDs
+func (_m *OrganizationTypeRepository) AddPermissions(ctx context.Context, orgTypeID int, permissionIDs []int) error {
+	ret := _m.Called(ctx, orgTypeID, permissionIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPermissions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, orgTypeID, permissionIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddRoles provides a mock function with given fields: ctx, orgTypeID, roleIDs
 func (_m *OrganizationTypeRepository) AddRoles(ctx context.Context, orgTypeID int, roleIDs []int) error {
 	ret := _m.Called(ctx, orgTypeID, roleIDs)
@@ -53,6 +71,34 @@ func (_m *OrganizationTypeRepository) AddSystems(ctx context.Context, orgTypeID
 	return r0
 }
 
+// ByID provides a mock function with given fields: ctx, id
+func (_m *OrganizationTypeRepository) ByID(ctx context.Context, id int) (domain.OrganizationType, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ByID")
+	}
+
+	var r0 domain.OrganizationType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.OrganizationType, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.OrganizationType); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.OrganizationType)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, m
 func (_m *OrganizationTypeRepository) Create(ctx context.Context, m domain.OrganizationType) error {
 	ret := _m.Called(ctx, m)
@@ -71,17 +117,17 @@ func (_m *OrganizationTypeRepository) Create(ctx context.Context, m domain.Organ
 	return r0
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *OrganizationTypeRepository) Delete(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// Delete provides a mock function with given fields: ctx, id, reason
+func (_m *OrganizationTypeRepository) Delete(ctx context.Context, id int, reason string) error {
+	ret := _m.Called(ctx, id, reason)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, reason)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -89,6 +135,36 @@ func (_m *OrganizationTypeRepository) Delete(ctx context.Context, id int) error
 	return r0
 }
 
+// ForceRefresh provides a mock function with given fields: ctx
+func (_m *OrganizationTypeRepository) ForceRefresh(ctx context.Context) ([]domain.OrganizationType, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceRefresh")
+	}
+
+	var r0 []domain.OrganizationType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.OrganizationType, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.OrganizationType); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrganizationType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, p
 func (_m *OrganizationTypeRepository) List(ctx context.Context, p common.PaginationQuery) ([]domain.OrganizationType, int64, int, int, error) {
 	ret := _m.Called(ctx, p)
@@ -140,6 +216,36 @@ func (_m *OrganizationTypeRepository) List(ctx context.Context, p common.Paginat
 	return r0, r1, r2, r3, r4
 }
 
+// Permissions provides a mock function with given fields: ctx, orgTypeID
+func (_m *OrganizationTypeRepository) Permissions(ctx context.Context, orgTypeID int) ([]domain.Permission, error) {
+	ret := _m.Called(ctx, orgTypeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Permissions")
+	}
+
+	var r0 []domain.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Permission, error)); ok {
+		return rf(ctx, orgTypeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Permission); ok {
+		r0 = rf(ctx, orgTypeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, orgTypeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Roles provides a mock function with given fields: ctx, orgTypeID
 func (_m *OrganizationTypeRepository) Roles(ctx context.Context, orgTypeID int) ([]domain.Role, error) {
 	ret := _m.Called(ctx, orgTypeID)
@@ -6,6 +6,7 @@ import (
 	context "context"
 	domain "templatev25/internal/domain"
 	dto "templatev25/internal/http/dto"
+	repository "templatev25/internal/repository"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -89,17 +90,17 @@ func (_m *PermissionRepository) Create(ctx context.Context, m domain.Permission)
 	return r0
 }
 
-// CreateBatch provides a mock function with given fields: ctx, systemID, moduleID, actionIDs
-func (_m *PermissionRepository) CreateBatch(ctx context.Context, systemID int, moduleID int, actionIDs []int64) error {
-	ret := _m.Called(ctx, systemID, moduleID, actionIDs)
+// CreateBatch provides a mock function with given fields: ctx, systemID, moduleID, actionIDs, codeFn
+func (_m *PermissionRepository) CreateBatch(ctx context.Context, systemID int, moduleID int, actionIDs []int64, codeFn repository.PermissionCodeFunc) error {
+	ret := _m.Called(ctx, systemID, moduleID, actionIDs, codeFn)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateBatch")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, []int64) error); ok {
-		r0 = rf(ctx, systemID, moduleID, actionIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, []int64, repository.PermissionCodeFunc) error); ok {
+		r0 = rf(ctx, systemID, moduleID, actionIDs, codeFn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -125,6 +126,64 @@ func (_m *PermissionRepository) Delete(ctx context.Context, id int) error {
 	return r0
 }
 
+// DeleteByModule provides a mock function with given fields: ctx, moduleID
+func (_m *PermissionRepository) DeleteByModule(ctx context.Context, moduleID int) (int64, error) {
+	ret := _m.Called(ctx, moduleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByModule")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int64, error)); ok {
+		return rf(ctx, moduleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
+		r0 = rf(ctx, moduleID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, moduleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserOrgTypePermissionCodes provides a mock function with given fields: ctx, userID
+func (_m *PermissionRepository) GetUserOrgTypePermissionCodes(ctx context.Context, userID int) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserOrgTypePermissionCodes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserPermissionCodes provides a mock function with given fields: ctx, userID
 func (_m *PermissionRepository) GetUserPermissionCodes(ctx context.Context, userID int) ([]string, error) {
 	ret := _m.Called(ctx, userID)
@@ -206,6 +265,87 @@ func (_m *PermissionRepository) List(ctx context.Context, q dto.PermissionQuery)
 	return r0, r1, r2, r3, r4
 }
 
+// ListByModule provides a mock function with given fields: ctx, moduleID, q
+func (_m *PermissionRepository) ListByModule(ctx context.Context, moduleID int, q dto.PermissionQuery) ([]domain.Permission, int64, int, int, error) {
+	ret := _m.Called(ctx, moduleID, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByModule")
+	}
+
+	var r0 []domain.Permission
+	var r1 int64
+	var r2 int
+	var r3 int
+	var r4 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, dto.PermissionQuery) ([]domain.Permission, int64, int, int, error)); ok {
+		return rf(ctx, moduleID, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, dto.PermissionQuery) []domain.Permission); ok {
+		r0 = rf(ctx, moduleID, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, dto.PermissionQuery) int64); ok {
+		r1 = rf(ctx, moduleID, q)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, dto.PermissionQuery) int); ok {
+		r2 = rf(ctx, moduleID, q)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, int, dto.PermissionQuery) int); ok {
+		r3 = rf(ctx, moduleID, q)
+	} else {
+		r3 = ret.Get(3).(int)
+	}
+
+	if rf, ok := ret.Get(4).(func(context.Context, int, dto.PermissionQuery) error); ok {
+		r4 = rf(ctx, moduleID, q)
+	} else {
+		r4 = ret.Error(4)
+	}
+
+	return r0, r1, r2, r3, r4
+}
+
+// ListBySystem provides a mock function with given fields: ctx, systemID
+func (_m *PermissionRepository) ListBySystem(ctx context.Context, systemID int) ([]domain.Permission, error) {
+	ret := _m.Called(ctx, systemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBySystem")
+	}
+
+	var r0 []domain.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Permission, error)); ok {
+		return rf(ctx, systemID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Permission); ok {
+		r0 = rf(ctx, systemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, systemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, id, m
 func (_m *PermissionRepository) Update(ctx context.Context, id int, m domain.Permission) error {
 	ret := _m.Called(ctx, id, m)
@@ -224,6 +364,34 @@ func (_m *PermissionRepository) Update(ctx context.Context, id int, m domain.Per
 	return r0
 }
 
+// UserHasOrgTypePermission provides a mock function with given fields: ctx, userID, permissionCode
+func (_m *PermissionRepository) UserHasOrgTypePermission(ctx context.Context, userID int, permissionCode string) (bool, error) {
+	ret := _m.Called(ctx, userID, permissionCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserHasOrgTypePermission")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (bool, error)); ok {
+		return rf(ctx, userID, permissionCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) bool); ok {
+		r0 = rf(ctx, userID, permissionCode)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, permissionCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UserHasPermission provides a mock function with given fields: ctx, userID, permissionCode
 func (_m *PermissionRepository) UserHasPermission(ctx context.Context, userID int, permissionCode string) (bool, error) {
 	ret := _m.Called(ctx, userID, permissionCode)
@@ -43,6 +43,34 @@ func (_m *RoleRepository) ByID(ctx context.Context, id int) (domain.Role, error)
 	return r0, r1
 }
 
+// ByIDWithPermissions provides a mock function with given fields: ctx, id
+func (_m *RoleRepository) ByIDWithPermissions(ctx context.Context, id int) (domain.Role, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ByIDWithPermissions")
+	}
+
+	var r0 domain.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Role, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Role); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, m
 func (_m *RoleRepository) Create(ctx context.Context, m domain.Role) error {
 	ret := _m.Called(ctx, m)
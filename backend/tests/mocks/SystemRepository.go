@@ -115,6 +115,34 @@ func (_m *SystemRepository) GetActiveRoleCount(uctx context.Context, id int) int
 	return r0
 }
 
+// GetBySlug provides a mock function with given fields: ctx, slug
+func (_m *SystemRepository) GetBySlug(ctx context.Context, slug string) (domain.System, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 domain.System
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.System, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.System); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(domain.System)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, q
 func (_m *SystemRepository) List(ctx context.Context, q dto.SystemListQuery) ([]domain.System, int64, int, int, error) {
 	ret := _m.Called(ctx, q)
@@ -166,6 +194,36 @@ func (_m *SystemRepository) List(ctx context.Context, q dto.SystemListQuery) ([]
 	return r0, r1, r2, r3, r4
 }
 
+// ListActive provides a mock function with given fields: ctx
+func (_m *SystemRepository) ListActive(ctx context.Context) ([]domain.System, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActive")
+	}
+
+	var r0 []domain.System
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.System, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.System); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.System)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, id, m
 func (_m *SystemRepository) Update(ctx context.Context, id int, m domain.System) error {
 	ret := _m.Called(ctx, id, m)
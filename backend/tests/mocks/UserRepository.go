@@ -17,6 +17,36 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// BulkUpsert provides a mock function with given fields: ctx, users
+func (_m *UserRepository) BulkUpsert(ctx context.Context, users []domain.User) ([]int, error) {
+	ret := _m.Called(ctx, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpsert")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.User) ([]int, error)); ok {
+		return rf(ctx, users)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.User) []int); ok {
+		r0 = rf(ctx, users)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.User) error); ok {
+		r1 = rf(ctx, users)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, m
 func (_m *UserRepository) Create(ctx context.Context, m domain.User) (domain.User, error) {
 	ret := _m.Called(ctx, m)
@@ -73,6 +103,24 @@ func (_m *UserRepository) Delete(ctx context.Context, id int) (domain.User, erro
 	return r0, r1
 }
 
+// FindInBatches provides a mock function with given fields: ctx, p, batchSize, fn
+func (_m *UserRepository) FindInBatches(ctx context.Context, p common.PaginationQuery, batchSize int, fn func([]domain.User) error) error {
+	ret := _m.Called(ctx, p, batchSize, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindInBatches")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.PaginationQuery, int, func([]domain.User) error) error); ok {
+		r0 = rf(ctx, p, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *UserRepository) GetByID(ctx context.Context, id int) (domain.User, error) {
 	ret := _m.Called(ctx, id)
@@ -212,6 +260,89 @@ func (_m *UserRepository) List(ctx context.Context, p common.PaginationQuery) ([
 	return r0, r1, r2, r3, r4
 }
 
+// ListStream provides a mock function with given fields: ctx, p
+func (_m *UserRepository) ListStream(ctx context.Context, p common.PaginationQuery) (<-chan domain.User, <-chan error) {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStream")
+	}
+
+	var r0 <-chan domain.User
+	var r1 <-chan error
+	if rf, ok := ret.Get(0).(func(context.Context, common.PaginationQuery) (<-chan domain.User, <-chan error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.PaginationQuery) <-chan domain.User); ok {
+		r0 = rf(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.PaginationQuery) <-chan error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan error)
+		}
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, q, p
+func (_m *UserRepository) Search(ctx context.Context, q string, p common.PaginationQuery) ([]domain.User, int64, int, int, error) {
+	ret := _m.Called(ctx, q, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.User
+	var r1 int64
+	var r2 int
+	var r3 int
+	var r4 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, common.PaginationQuery) ([]domain.User, int64, int, int, error)); ok {
+		return rf(ctx, q, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, common.PaginationQuery) []domain.User); ok {
+		r0 = rf(ctx, q, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, common.PaginationQuery) int64); ok {
+		r1 = rf(ctx, q, p)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, common.PaginationQuery) int); ok {
+		r2 = rf(ctx, q, p)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, string, common.PaginationQuery) int); ok {
+		r3 = rf(ctx, q, p)
+	} else {
+		r3 = ret.Get(3).(int)
+	}
+
+	if rf, ok := ret.Get(4).(func(context.Context, string, common.PaginationQuery) error); ok {
+		r4 = rf(ctx, q, p)
+	} else {
+		r4 = ret.Error(4)
+	}
+
+	return r0, r1, r2, r3, r4
+}
+
 // Update provides a mock function with given fields: ctx, m
 func (_m *UserRepository) Update(ctx context.Context, m domain.User) (domain.User, error) {
 	ret := _m.Called(ctx, m)
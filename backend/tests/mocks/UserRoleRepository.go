@@ -4,7 +4,11 @@ package mocks
 
 import (
 	context "context"
+
+	common "git.gerege.mn/backend-packages/common"
+
 	domain "templatev25/internal/domain"
+
 	dto "templatev25/internal/http/dto"
 
 	mock "github.com/stretchr/testify/mock"
@@ -51,6 +55,135 @@ func (_m *UserRoleRepository) AddUsersToRole(ctx context.Context, roleID int, us
 	return r0
 }
 
+// CreateHistory provides a mock function with given fields: ctx, entries
+func (_m *UserRoleRepository) CreateHistory(ctx context.Context, entries []domain.UserRoleHistory) error {
+	ret := _m.Called(ctx, entries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.UserRoleHistory) error); ok {
+		r0 = rf(ctx, entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRoleCodes provides a mock function with given fields: ctx, userID
+func (_m *UserRoleRepository) GetRoleCodes(ctx context.Context, userID int) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoleCodes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *UserRoleRepository) GetByUserID(ctx context.Context, userID int) ([]domain.UserRole, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []domain.UserRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.UserRole, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.UserRole); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HistoryByUser provides a mock function with given fields: ctx, userID, p
+func (_m *UserRoleRepository) HistoryByUser(ctx context.Context, userID int, p common.PaginationQuery) ([]domain.UserRoleHistory, int64, int, int, error) {
+	ret := _m.Called(ctx, userID, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HistoryByUser")
+	}
+
+	var r0 []domain.UserRoleHistory
+	var r1 int64
+	var r2 int
+	var r3 int
+	var r4 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, common.PaginationQuery) ([]domain.UserRoleHistory, int64, int, int, error)); ok {
+		return rf(ctx, userID, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, common.PaginationQuery) []domain.UserRoleHistory); ok {
+		r0 = rf(ctx, userID, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserRoleHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, common.PaginationQuery) int64); ok {
+		r1 = rf(ctx, userID, p)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, common.PaginationQuery) int); ok {
+		r2 = rf(ctx, userID, p)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, int, common.PaginationQuery) int); ok {
+		r3 = rf(ctx, userID, p)
+	} else {
+		r3 = ret.Get(3).(int)
+	}
+
+	if rf, ok := ret.Get(4).(func(context.Context, int, common.PaginationQuery) error); ok {
+		r4 = rf(ctx, userID, p)
+	} else {
+		r4 = ret.Error(4)
+	}
+
+	return r0, r1, r2, r3, r4
+}
+
 // Remove provides a mock function with given fields: ctx, userID, roleID
 func (_m *UserRoleRepository) Remove(ctx context.Context, userID int, roleID int) error {
 	ret := _m.Called(ctx, userID, roleID)
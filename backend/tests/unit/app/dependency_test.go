@@ -2,10 +2,15 @@ package app_test
 
 import (
 	"testing"
+	"time"
 
 	"templatev25/internal/app"
 
+	"git.gerege.mn/backend-packages/config"
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 func TestDependencies_StructFields(t *testing.T) {
@@ -99,3 +104,60 @@ func TestServiceContainer_StructFields(t *testing.T) {
 	assert.Nil(t, svc.Meet)
 	assert.Nil(t, svc.Tpay)
 }
+
+func TestNewDependencies_MissingOptionsPanic(t *testing.T) {
+	db := &gorm.DB{}
+	cfg := &config.Config{}
+	log := zap.NewNop()
+	cache := ssoclient.NewCache(time.Minute, 10)
+
+	tests := []struct {
+		name string
+		opts []app.Option
+		want string
+	}{
+		{
+			name: "no options",
+			want: "app.NewDependencies: missing required option(s): WithDB, WithConfig, WithLogger, WithAuthCache",
+		},
+		{
+			name: "missing logger",
+			opts: []app.Option{app.WithDB(db), app.WithConfig(cfg), app.WithAuthCache(cache)},
+			want: "app.NewDependencies: missing required option(s): WithLogger",
+		},
+		{
+			name: "nil db counts as missing",
+			opts: []app.Option{app.WithDB(nil), app.WithConfig(cfg), app.WithLogger(log), app.WithAuthCache(cache)},
+			want: "app.NewDependencies: missing required option(s): WithDB",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.PanicsWithError(t, tt.want, func() { app.NewDependencies(tt.opts...) })
+		})
+	}
+}
+
+func TestNewDependenciesPositional_MissingArgsPanic(t *testing.T) {
+	assert.PanicsWithError(t,
+		"app.NewDependencies: missing required option(s): WithConfig, WithAuthCache",
+		func() { app.NewDependenciesPositional(&gorm.DB{}, nil, zap.NewNop(), nil) })
+}
+
+func TestOptions_SetCoreFields(t *testing.T) {
+	db := &gorm.DB{}
+	cfg := &config.Config{}
+	log := zap.NewNop()
+	cache := ssoclient.NewCache(time.Minute, 10)
+
+	deps := &app.Dependencies{}
+	for _, opt := range []app.Option{app.WithDB(db), app.WithConfig(cfg), app.WithLogger(log), app.WithAuthCache(cache)} {
+		opt(deps)
+	}
+
+	assert.Same(t, db, deps.DB)
+	assert.Same(t, cfg, deps.Cfg)
+	assert.Same(t, log, deps.Log)
+	assert.Same(t, cache, deps.AuthCache)
+}
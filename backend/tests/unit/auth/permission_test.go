@@ -312,7 +312,7 @@ func TestPermissionCache_HasPermission(t *testing.T) {
 			mockService := &mockPermissionChecker{}
 			tt.mockSetup(mockService)
 
-			cache := auth.NewPermissionCache(mockService, 5*time.Minute)
+			cache := auth.NewPermissionCache(mockService, auth.PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
 
 			got, err := cache.HasPermission(context.Background(), tt.userID, tt.permissionCode)
 
@@ -333,7 +333,7 @@ func TestPermissionCache_CacheHit(t *testing.T) {
 	// Service should only be called once (first request caches the result)
 	mockService.On("GetUserPermissions", mock.Anything, 1).Return([]string{"admin.role.create"}, nil).Once()
 
-	cache := auth.NewPermissionCache(mockService, 5*time.Minute)
+	cache := auth.NewPermissionCache(mockService, auth.PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
 
 	// First call - cache miss
 	got1, err1 := cache.HasPermission(context.Background(), 1, "admin.role.create")
@@ -354,7 +354,7 @@ func TestPermissionCache_InvalidateUser(t *testing.T) {
 	// Service should be called twice (once before invalidation, once after)
 	mockService.On("GetUserPermissions", mock.Anything, 1).Return([]string{"admin.role.create"}, nil).Twice()
 
-	cache := auth.NewPermissionCache(mockService, 5*time.Minute)
+	cache := auth.NewPermissionCache(mockService, auth.PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
 
 	// First call - cache miss
 	_, _ = cache.HasPermission(context.Background(), 1, "admin.role.create")
@@ -375,7 +375,7 @@ func TestPermissionCache_InvalidateAll(t *testing.T) {
 	mockService.On("GetUserPermissions", mock.Anything, 1).Return([]string{"admin.role.create"}, nil).Twice()
 	mockService.On("GetUserPermissions", mock.Anything, 2).Return([]string{"user.role.read"}, nil).Twice()
 
-	cache := auth.NewPermissionCache(mockService, 5*time.Minute)
+	cache := auth.NewPermissionCache(mockService, auth.PermissionCacheOptions{DefaultTTL: 5 * time.Minute})
 
 	// First calls - cache miss for both users
 	_, _ = cache.HasPermission(context.Background(), 1, "admin.role.create")
@@ -396,7 +396,11 @@ func TestPermissionCache_Stats(t *testing.T) {
 	mockService := &mockPermissionChecker{}
 	mockService.On("GetUserPermissions", mock.Anything, mock.Anything).Return([]string{"admin.role.create"}, nil)
 
-	cache := auth.NewPermissionCache(mockService, 5*time.Minute)
+	cache := auth.NewPermissionCache(mockService, auth.PermissionCacheOptions{
+		DefaultTTL:      5 * time.Minute,
+		MaxSize:         100,
+		UserTTLOverride: map[int]time.Duration{4: 0},
+	})
 
 	// Initially empty
 	stats := cache.Stats()
@@ -406,8 +410,45 @@ func TestPermissionCache_Stats(t *testing.T) {
 	_, _ = cache.HasPermission(context.Background(), 1, "admin.role.create")
 	_, _ = cache.HasPermission(context.Background(), 2, "admin.role.create")
 	_, _ = cache.HasPermission(context.Background(), 3, "admin.role.create")
+	// TTL 0 override-тэй хэрэглэгч cache-д орохгүй
+	_, _ = cache.HasPermission(context.Background(), 4, "admin.role.create")
 
 	stats = cache.Stats()
 	assert.Equal(t, 3, stats.CachedUsers)
 	assert.Equal(t, 5*time.Minute, stats.TTL)
+	assert.Equal(t, 100, stats.MaxSize)
+	assert.Equal(t, 1, stats.UserOverrides)
+
+	cache.InvalidateUser(2)
+	assert.Equal(t, 2, cache.Stats().CachedUsers)
+}
+
+func TestPermissionCache_UserTTLOverrideTakesPrecedence(t *testing.T) {
+	mockService := &mockPermissionChecker{}
+	mockService.On("GetUserPermissions", mock.Anything, mock.Anything).Return([]string{"admin.role.create"}, nil)
+
+	cache := auth.NewPermissionCache(mockService, auth.PermissionCacheOptions{
+		DefaultTTL:      time.Hour,
+		UserTTLOverride: map[int]time.Duration{1: 20 * time.Millisecond},
+	})
+	ctx := context.Background()
+
+	for _, id := range []int{1, 2} {
+		_, _ = cache.HasPermission(ctx, id, "admin.role.create")
+	}
+	mockService.AssertNumberOfCalls(t, "GetUserPermissions", 2)
+
+	time.Sleep(40 * time.Millisecond)
+
+	// 1-ийн override TTL дууссан, 2 нь default (1 цаг)-аар cache-д хэвээр
+	_, _ = cache.HasPermission(ctx, 1, "admin.role.create")
+	_, _ = cache.HasPermission(ctx, 2, "admin.role.create")
+	mockService.AssertNumberOfCalls(t, "GetUserPermissions", 3)
+	mockService.AssertCalled(t, "GetUserPermissions", mock.Anything, 1)
+
+	// Runtime override: 2-г cache-лэхгүй болгоно
+	cache.SetUserTTL(2, 0)
+	_, _ = cache.HasPermission(ctx, 2, "admin.role.create")
+	_, _ = cache.HasPermission(ctx, 2, "admin.role.create")
+	mockService.AssertNumberOfCalls(t, "GetUserPermissions", 5)
 }
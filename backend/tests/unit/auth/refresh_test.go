@@ -0,0 +1,252 @@
+// Package auth provides implementation for auth
+//
+// File: refresh_test.go
+// Description: Tests for RequireWithRefresh (SSO session auto-refresh)
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"templatev25/internal/auth"
+	localconfig "templatev25/internal/config"
+
+	"git.gerege.mn/backend-packages/config"
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// ============================================================
+// MOCK SESSION REFRESHER
+// ============================================================
+
+type mockSessionRefresher struct {
+	mock.Mock
+}
+
+func (m *mockSessionRefresher) GetClaims(ctx context.Context, sid, reqID string) (ssoclient.Claims, error) {
+	args := m.Called(ctx, sid, reqID)
+	return args.Get(0).(ssoclient.Claims), args.Error(1)
+}
+
+func (m *mockSessionRefresher) RefreshToken(ctx context.Context, oldToken string) (string, error) {
+	args := m.Called(ctx, oldToken)
+	return args.String(0), args.Error(1)
+}
+
+// ============================================================
+// TEST AUTO REFRESH
+// ============================================================
+
+// expiringIn нь ttl хугацааны дараа дуусах session-ий claims
+func expiringIn(ttl time.Duration) ssoclient.Claims {
+	return ssoclient.Claims{UserID: 1, Exp: time.Now().Add(ttl).Unix()}
+}
+
+// staticOrgOverride нь бүх session-д ижил org override буцаана
+type staticOrgOverride int
+
+func (o staticOrgOverride) GetOrgOverride(ctx context.Context, sessionID string) (int, error) {
+	return int(o), nil
+}
+
+func newRefreshTestApp(sso auth.SessionRefresher, overrides auth.OrgOverrideReader) *fiber.App {
+	cfg := &config.Config{}
+	cfg.Cookie.Name = "sid"
+	sessCfg := &localconfig.SessionConfig{RefreshThreshold: 5 * time.Minute}
+
+	app := fiber.New()
+	app.Get("/test", auth.RequireWithRefreshWith(cfg, sessCfg, zap.NewNop(), sso, overrides), func(c *fiber.Ctx) error {
+		// Downstream handler шинэ токен болон claims-ийг харах ёстой
+		orgID := 0
+		if cl, ok := ssoclient.GetClaims(c); ok {
+			orgID = cl.OrgID
+		}
+		return c.JSON(fiber.Map{
+			"sid":     auth.ExtractSID(c, cfg),
+			"user_id": ssoclient.GetUserID(c),
+			"org_id":  orgID,
+		})
+	})
+	return app
+}
+
+func TestRequireWithRefresh(t *testing.T) {
+	tests := []struct {
+		name          string
+		authHeader    string
+		cookie        string
+		mockSetup     func(*mockSessionRefresher)
+		wantStatus    int
+		wantRefreshed string
+		wantCookie    string
+	}{
+		{
+			name:       "success - expiring bearer token is refreshed",
+			authHeader: "Bearer old-token",
+			mockSetup: func(m *mockSessionRefresher) {
+				m.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(expiringIn(2*time.Minute), nil)
+				m.On("RefreshToken", mock.Anything, "old-token").Return("new-token", nil)
+				m.On("GetClaims", mock.Anything, "new-token", mock.Anything).Return(ssoclient.Claims{UserID: 7}, nil)
+			},
+			wantStatus:    fiber.StatusOK,
+			wantRefreshed: "new-token",
+		},
+		{
+			// HttpOnly cookie-ийн SID header-ээр ил гарахгүй, зөвхөн Set-Cookie
+			name:   "success - expiring cookie session is refreshed without header",
+			cookie: "old-token",
+			mockSetup: func(m *mockSessionRefresher) {
+				m.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(expiringIn(time.Minute), nil)
+				m.On("RefreshToken", mock.Anything, "old-token").Return("new-token", nil)
+				m.On("GetClaims", mock.Anything, "new-token", mock.Anything).Return(ssoclient.Claims{UserID: 7}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+			wantCookie: "new-token",
+		},
+		{
+			name:       "skip - session has enough TTL",
+			authHeader: "Bearer old-token",
+			mockSetup: func(m *mockSessionRefresher) {
+				m.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(expiringIn(time.Hour), nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "skip - claims without expiry",
+			authHeader: "Bearer old-token",
+			mockSetup: func(m *mockSessionRefresher) {
+				m.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(ssoclient.Claims{UserID: 7}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			// Refresh амжилтгүй ч session хүчинтэй хэвээр — хуучин токеноор үргэлжилнэ
+			name:       "continue - refresh fails",
+			authHeader: "Bearer old-token",
+			mockSetup: func(m *mockSessionRefresher) {
+				m.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(expiringIn(time.Minute), nil)
+				m.On("RefreshToken", mock.Anything, "old-token").Return("", errors.New("sso unavailable"))
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "continue - refreshed token claims fail",
+			authHeader: "Bearer old-token",
+			mockSetup: func(m *mockSessionRefresher) {
+				m.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(expiringIn(time.Minute), nil)
+				m.On("RefreshToken", mock.Anything, "old-token").Return("new-token", nil)
+				m.On("GetClaims", mock.Anything, "new-token", mock.Anything).Return(ssoclient.Claims{}, errors.New("invalid session"))
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "error - invalid session",
+			authHeader: "Bearer old-token",
+			mockSetup: func(m *mockSessionRefresher) {
+				m.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(ssoclient.Claims{}, errors.New("sso unavailable"))
+			},
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "error - no session",
+			mockSetup:  func(m *mockSessionRefresher) {},
+			wantStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sso := new(mockSessionRefresher)
+			tt.mockSetup(sso)
+			app := newRefreshTestApp(sso, nil)
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			if tt.cookie != "" {
+				req.Header.Set("Cookie", "sid="+tt.cookie)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantRefreshed, resp.Header.Get(auth.HeaderRefreshedToken))
+			if tt.wantCookie != "" {
+				assert.Contains(t, resp.Header.Get("Set-Cookie"), "sid="+tt.wantCookie)
+			}
+			sso.AssertExpectations(t)
+		})
+	}
+}
+
+// refreshTestBody нь /test handler-ийн хариу
+type refreshTestBody struct {
+	SID    string `json:"sid"`
+	UserID int    `json:"user_id"`
+	OrgID  int    `json:"org_id"`
+}
+
+func getRefreshTest(t *testing.T, app *fiber.App) refreshTestBody {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer old-token")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body refreshTestBody
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body
+}
+
+func TestRequireWithRefresh_DownstreamSeesRefreshedClaims(t *testing.T) {
+	sso := new(mockSessionRefresher)
+	sso.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(expiringIn(time.Minute), nil)
+	sso.On("RefreshToken", mock.Anything, "old-token").Return("new-token", nil)
+	sso.On("GetClaims", mock.Anything, "new-token", mock.Anything).Return(ssoclient.Claims{UserID: 42}, nil)
+
+	body := getRefreshTest(t, newRefreshTestApp(sso, nil))
+
+	assert.Equal(t, "new-token", body.SID)
+	assert.Equal(t, 42, body.UserID)
+}
+
+func TestRequireWithRefresh_RefreshFailureKeepsCurrentSession(t *testing.T) {
+	sso := new(mockSessionRefresher)
+	current := expiringIn(time.Minute)
+	current.UserID = 7
+	sso.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(current, nil)
+	sso.On("RefreshToken", mock.Anything, "old-token").Return("", errors.New("sso unavailable"))
+
+	body := getRefreshTest(t, newRefreshTestApp(sso, nil))
+
+	assert.Equal(t, "old-token", body.SID)
+	assert.Equal(t, 7, body.UserID)
+}
+
+func TestRequireWithRefresh_RefreshedClaimsKeepOrgOverride(t *testing.T) {
+	sso := new(mockSessionRefresher)
+	sso.On("GetClaims", mock.Anything, "old-token", mock.Anything).Return(expiringIn(time.Minute), nil)
+	sso.On("RefreshToken", mock.Anything, "old-token").Return("new-token", nil)
+	sso.On("GetClaims", mock.Anything, "new-token", mock.Anything).Return(ssoclient.Claims{UserID: 42, OrgID: 1}, nil)
+
+	body := getRefreshTest(t, newRefreshTestApp(sso, staticOrgOverride(9)))
+
+	assert.Equal(t, "new-token", body.SID)
+	assert.Equal(t, 9, body.OrgID)
+}
@@ -0,0 +1,143 @@
+// Package auth provides implementation for auth
+//
+// File: role_test.go
+// Description: Tests for role middleware
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/auth"
+
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ============================================================
+// MOCK ROLE CHECKER
+// ============================================================
+
+type mockRoleChecker struct {
+	mock.Mock
+}
+
+func (m *mockRoleChecker) GetRoleCodes(ctx context.Context, userID int) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// ============================================================
+// TEST REQUIRE ROLE
+// ============================================================
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		roles      []string
+		claims     *ssoclient.Claims
+		mockSetup  func(*mockRoleChecker)
+		wantStatus int
+	}{
+		{
+			name:   "success - user has required role",
+			roles:  []string{"ADMIN"},
+			claims: &ssoclient.Claims{UserID: 1},
+			mockSetup: func(m *mockRoleChecker) {
+				m.On("GetRoleCodes", mock.Anything, 1).Return([]string{"ADMIN"}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "success - user has one of the roles",
+			roles:  []string{"SUPER_ADMIN", "ADMIN"},
+			claims: &ssoclient.Claims{UserID: 1},
+			mockSetup: func(m *mockRoleChecker) {
+				m.On("GetRoleCodes", mock.Anything, 1).Return([]string{"SUPPORT", "ADMIN"}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "success - empty role list allows all",
+			roles:      []string{},
+			claims:     &ssoclient.Claims{UserID: 1},
+			mockSetup:  func(m *mockRoleChecker) {},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "forbidden - user lacks role",
+			roles:  []string{"SUPER_ADMIN", "ADMIN"},
+			claims: &ssoclient.Claims{UserID: 1},
+			mockSetup: func(m *mockRoleChecker) {
+				m.On("GetRoleCodes", mock.Anything, 1).Return([]string{"APP_USER"}, nil)
+			},
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:   "forbidden - user has no roles",
+			roles:  []string{"ADMIN"},
+			claims: &ssoclient.Claims{UserID: 1},
+			mockSetup: func(m *mockRoleChecker) {
+				m.On("GetRoleCodes", mock.Anything, 1).Return([]string{}, nil)
+			},
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:   "forbidden - role check error",
+			roles:  []string{"ADMIN"},
+			claims: &ssoclient.Claims{UserID: 1},
+			mockSetup: func(m *mockRoleChecker) {
+				m.On("GetRoleCodes", mock.Anything, 1).Return(nil, errors.New("db error"))
+			},
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:       "forbidden - no user ID",
+			roles:      []string{"ADMIN"},
+			claims:     &ssoclient.Claims{},
+			mockSetup:  func(m *mockRoleChecker) {},
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:       "unauthorized - no claims",
+			roles:      []string{"ADMIN"},
+			mockSetup:  func(m *mockRoleChecker) {},
+			wantStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockChecker := &mockRoleChecker{}
+			tt.mockSetup(mockChecker)
+
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				// Simulate SSO claims being set
+				if tt.claims != nil {
+					c.Locals(ssoclient.LocalsClaims, tt.claims)
+				}
+				return c.Next()
+			})
+			app.Get("/test", auth.RequireRole(mockChecker, tt.roles...), func(c *fiber.Ctx) error {
+				return c.SendString("OK")
+			})
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			resp, err := app.Test(req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			mockChecker.AssertExpectations(t)
+		})
+	}
+}
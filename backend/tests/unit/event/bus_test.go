@@ -0,0 +1,163 @@
+// Package event provides implementation for event
+//
+// File: bus_test.go
+// Description: Tests for the in-memory event bus
+package event_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"templatev25/internal/event"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEvent нь тестэд хэрэглэх энгийн event
+type testEvent struct {
+	kind string
+	n    int
+}
+
+func (e testEvent) Type() string { return e.kind }
+
+// recorder нь хүлээн авсан event-үүдийг thread-safe хадгална
+type recorder struct {
+	mu  sync.Mutex
+	got []event.Event
+}
+
+func (r *recorder) handle(e event.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.got = append(r.got, e)
+}
+
+func (r *recorder) events() []event.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]event.Event(nil), r.got...)
+}
+
+func TestInMemoryBus_PublishSubscribe(t *testing.T) {
+	bus := event.NewInMemoryBus(8, nil)
+
+	var a, b, other recorder
+	bus.Subscribe("a", a.handle)
+	bus.Subscribe("a", b.handle)
+	bus.Subscribe("other", other.handle)
+
+	bus.Publish(testEvent{kind: "a", n: 1})
+	bus.Publish(testEvent{kind: "a", n: 2})
+	bus.Close() // бүх event хүргэгдэхийг хүлээнэ
+
+	want := []event.Event{testEvent{kind: "a", n: 1}, testEvent{kind: "a", n: 2}}
+	assert.Equal(t, want, a.events())
+	assert.Equal(t, want, b.events())
+	assert.Empty(t, other.events())
+	assert.Equal(t, int64(0), bus.Dropped())
+}
+
+func TestInMemoryBus_PublishWithoutSubscribers(t *testing.T) {
+	bus := event.NewInMemoryBus(1, nil)
+
+	assert.NotPanics(t, func() {
+		bus.Publish(testEvent{kind: "nobody"})
+		bus.Close()
+	})
+}
+
+func TestInMemoryBus_Unsubscribe(t *testing.T) {
+	bus := event.NewInMemoryBus(8, nil)
+
+	var kept, removed recorder
+	bus.Subscribe("a", kept.handle)
+	unsubscribe := bus.Subscribe("a", removed.handle)
+
+	unsubscribe()
+	unsubscribe() // дахин дуудахад алдаагүй
+
+	bus.Publish(testEvent{kind: "a", n: 1})
+	bus.Close()
+
+	assert.Len(t, kept.events(), 1)
+	assert.Empty(t, removed.events())
+}
+
+func TestInMemoryBus_DropsEventsWhenBufferFull(t *testing.T) {
+	const bufferSize = 2
+	bus := event.NewInMemoryBus(bufferSize, nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var rec recorder
+	bus.Subscribe("a", func(e event.Event) {
+		if e.(testEvent).n == 0 {
+			close(started)
+			<-release // dispatcher-ийг блоклоно
+		}
+		rec.handle(e)
+	})
+
+	// Эхний event dispatcher-т орж блоклогдоно
+	bus.Publish(testEvent{kind: "a", n: 0})
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("dispatcher did not pick up the first event")
+	}
+
+	// Buffer дүүргэнэ, дараагийнх нь хаягдана
+	for i := 1; i <= bufferSize; i++ {
+		bus.Publish(testEvent{kind: "a", n: i})
+	}
+	bus.Publish(testEvent{kind: "a", n: 99})
+	assert.Equal(t, int64(1), bus.Dropped())
+
+	close(release)
+	bus.Close()
+
+	got := rec.events()
+	require.Len(t, got, bufferSize+1)
+	for _, e := range got {
+		assert.NotEqual(t, 99, e.(testEvent).n, "dropped event must not be delivered")
+	}
+}
+
+func TestInMemoryBus_PublishAfterClose(t *testing.T) {
+	bus := event.NewInMemoryBus(1, nil)
+
+	var rec recorder
+	bus.Subscribe("a", rec.handle)
+	bus.Close()
+	bus.Close() // олон удаа дуудаж болно
+
+	bus.Publish(testEvent{kind: "a"})
+
+	assert.Empty(t, rec.events())
+	assert.Equal(t, int64(1), bus.Dropped())
+}
+
+func TestInMemoryBus_HandlerPanicDoesNotStopDispatch(t *testing.T) {
+	bus := event.NewInMemoryBus(8, nil)
+
+	var rec recorder
+	bus.Subscribe("a", func(e event.Event) {
+		if e.(testEvent).n == 1 {
+			panic("boom")
+		}
+	})
+	bus.Subscribe("a", rec.handle)
+
+	bus.Publish(testEvent{kind: "a", n: 1})
+	bus.Publish(testEvent{kind: "a", n: 2})
+	bus.Close()
+
+	assert.Len(t, rec.events(), 2)
+}
+
+func TestUserAddedToOrgEvent_Type(t *testing.T) {
+	assert.Equal(t, event.TypeUserAddedToOrg, event.UserAddedToOrgEvent{}.Type())
+}
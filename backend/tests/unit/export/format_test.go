@@ -0,0 +1,146 @@
+// Package export provides implementation for export
+//
+// File: format_test.go
+// Description: Tests for export format negotiation and formatters
+package export_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"templatev25/internal/service/export"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+// ============================================================
+// TEST RESOLVE
+// ============================================================
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		accept  string
+		want    export.ExportFormat
+		wantErr error
+	}{
+		{name: "default - no query, no accept", want: export.FormatCSV},
+		{name: "default - wildcard accept", accept: "*/*", want: export.FormatCSV},
+		{name: "accept - text/csv", accept: "text/csv", want: export.FormatCSV},
+		{name: "accept - application/json", accept: "application/json", want: export.FormatJSON},
+		{name: "accept - application/x-ndjson", accept: "application/x-ndjson", want: export.FormatJSON},
+		{name: "accept - application/vnd.ms-excel", accept: "application/vnd.ms-excel", want: export.FormatXLSX},
+		{name: "accept - openxml spreadsheet", accept: export.MIMEXLSX, want: export.FormatXLSX},
+		{name: "accept - case and params ignored", accept: "Application/JSON; charset=utf-8", want: export.FormatJSON},
+		{name: "accept - first supported type wins", accept: "text/html, application/json, text/csv", want: export.FormatJSON},
+		{name: "accept - highest q wins", accept: "text/csv;q=0.5, application/vnd.ms-excel;q=0.9", want: export.FormatXLSX},
+		{name: "accept - q=0 excluded", accept: "application/json;q=0, text/csv", want: export.FormatCSV},
+		{name: "accept - browser default falls back to csv", accept: "text/html,application/xhtml+xml,*/*;q=0.8", want: export.FormatCSV},
+		{name: "query - overrides accept", query: "xlsx", accept: "application/json", want: export.FormatXLSX},
+		{name: "query - case insensitive", query: "JSON", want: export.FormatJSON},
+		{name: "query - excel alias", query: "excel", want: export.FormatXLSX},
+		{name: "error - unknown query", query: "pdf", accept: "text/csv", wantErr: export.ErrUnsupportedFormat},
+		{name: "error - nothing acceptable", accept: "text/html, image/png", wantErr: export.ErrNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := export.Resolve(tt.query, tt.accept)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExportFormat_ContentTypeAndExtension(t *testing.T) {
+	tests := []struct {
+		format      export.ExportFormat
+		contentType string
+		extension   string
+	}{
+		{export.FormatCSV, "text/csv", "csv"},
+		{export.FormatJSON, "application/x-ndjson", "ndjson"},
+		{export.FormatXLSX, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			assert.Equal(t, tt.contentType, tt.format.ContentType())
+			assert.Equal(t, tt.extension, tt.format.Extension())
+		})
+	}
+}
+
+// ============================================================
+// TEST FORMATTERS
+// ============================================================
+
+func writeRows(t *testing.T, format export.ExportFormat) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	f, err := export.NewFormatter(format, &buf)
+	require.NoError(t, err)
+	require.NoError(t, f.WriteHeader([]string{"id", "name"}))
+	require.NoError(t, f.WriteRow([]string{"1", "Бат"}))
+	require.NoError(t, f.WriteRow([]string{"2", `Smith, "John"`}))
+	require.NoError(t, f.Close())
+	return buf.Bytes()
+}
+
+func TestNewFormatter_CSV(t *testing.T) {
+	out := writeRows(t, export.FormatCSV)
+	assert.Equal(t, "id,name\n1,Бат\n2,\"Smith, \"\"John\"\"\"\n", string(out))
+}
+
+func TestNewFormatter_NDJSON(t *testing.T) {
+	out := writeRows(t, export.FormatJSON)
+
+	var lines []map[string]string
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		var row map[string]string
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &row))
+		lines = append(lines, row)
+	}
+	require.Len(t, lines, 2)
+	assert.Equal(t, map[string]string{"id": "1", "name": "Бат"}, lines[0])
+	assert.Equal(t, map[string]string{"id": "2", "name": `Smith, "John"`}, lines[1])
+
+	// Key-ийн дараалал header-ийн дарааллыг дагана
+	assert.Equal(t, `{"id":"1","name":"Бат"}`, string(bytes.SplitN(out, []byte("\n"), 2)[0]))
+}
+
+func TestNewFormatter_NDJSONColumnMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := export.NewFormatter(export.FormatJSON, &buf)
+	require.NoError(t, err)
+	require.NoError(t, f.WriteHeader([]string{"id", "name"}))
+	assert.Error(t, f.WriteRow([]string{"1"}))
+}
+
+func TestNewFormatter_XLSX(t *testing.T) {
+	out := writeRows(t, export.FormatXLSX)
+
+	wb, err := excelize.OpenReader(bytes.NewReader(out))
+	require.NoError(t, err)
+	defer wb.Close()
+
+	rows, err := wb.GetRows("Sheet1")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"id", "name"}, {"1", "Бат"}, {"2", `Smith, "John"`}}, rows)
+}
+
+func TestNewFormatter_Unsupported(t *testing.T) {
+	_, err := export.NewFormatter("pdf", &bytes.Buffer{})
+	assert.ErrorIs(t, err, export.ErrUnsupportedFormat)
+}
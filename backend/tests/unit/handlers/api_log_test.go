@@ -0,0 +1,96 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: api_log_test.go
+// Description: Unit tests for GET /api-log query parsing
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/http/handlers"
+	"templatev25/internal/service"
+	"templatev25/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// setupAPILogApp нь жинхэнэ APILogHandler.List-ийг mock repository-той холбоно
+func setupAPILogApp(repo *mocks.APILogRepository) *fiber.App {
+	h := handlers.NewAPILogHandler(&app.Dependencies{
+		Log:     zap.NewNop(),
+		Service: &app.ServiceContainer{APILog: service.NewAPILogService(repo)},
+	})
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/api-log", h.List)
+	return app
+}
+
+func getAPILog(t *testing.T, app *fiber.App, query string) int {
+	t.Helper()
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, "/api-log"+query, nil))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	return res.StatusCode
+}
+
+// =============================================================================
+// GET /api-log
+// =============================================================================
+
+func TestAPILogHandler_List_ParsesQuery(t *testing.T) {
+	repo := mocks.NewAPILogRepository(t)
+
+	var got dto.APILogListQuery
+	repo.On("List", mock.Anything, mock.AnythingOfType("dto.APILogListQuery")).
+		Run(func(args mock.Arguments) { got = args.Get(1).(dto.APILogListQuery) }).
+		Return([]domain.APILog{}, int64(0), 1, 20, nil)
+
+	status := getAPILog(t, setupAPILogApp(repo),
+		"?user_id=1&method=POST&status_gte=400&path=/api/v1/user&from=2024-01-01&to=2024-12-31&page=2&size=20")
+
+	require.Equal(t, http.StatusOK, status)
+	require.NotNil(t, got.UserID)
+	assert.Equal(t, int64(1), *got.UserID)
+	assert.Equal(t, "POST", got.Method)
+	require.NotNil(t, got.StatusGTE)
+	assert.Equal(t, 400, *got.StatusGTE)
+	assert.Nil(t, got.StatusCode)
+	assert.Equal(t, "/api/v1/user", got.Path)
+	assert.Equal(t, "2024-01-01", got.From)
+	assert.Equal(t, "2024-12-31", got.To)
+	assert.Equal(t, 2, got.Page)
+	assert.Equal(t, 20, got.Size)
+}
+
+func TestAPILogHandler_List_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "from not a date", query: "?from=yesterday"},
+		{name: "to with time", query: "?to=2024-12-31T10:00:00Z"},
+		{name: "status_gte below range", query: "?status_gte=42"},
+		{name: "status_gte above range", query: "?status_gte=600"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewAPILogRepository(t)
+
+			status := getAPILog(t, setupAPILogApp(repo), tt.query)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, status)
+			repo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: client_scope_test.go
+// Description: Unit tests for POST/DELETE /client/scope request binding
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"templatev25/internal/app"
+	"templatev25/internal/http/handlers"
+
+	"git.gerege.mn/backend-packages/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupClientScopeApp нь SSO-г httptest server-ээр орлуулж scope route-уудыг үүсгэнэ.
+// Server нь хүлээн авсан method болон body-г буцаана.
+func setupClientScopeApp(t *testing.T) (*fiber.App, *[]map[string]any) {
+	t.Helper()
+	var got []map[string]any
+	sso := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var m map[string]any
+		_ = json.Unmarshal(body, &m)
+		m["method"] = r.Method
+		got = append(got, m)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(sso.Close)
+
+	cfg := &config.Config{}
+	cfg.Cookie.Name = "sid"
+	cfg.URLS.SSO = sso.URL
+
+	h := handlers.NewClientHandler(&app.Dependencies{Cfg: cfg})
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Post("/client/scope", h.ScopeCreate)
+	app.Delete("/client/scope", h.ScopeDelete)
+	return app, &got
+}
+
+func sendClientScope(t *testing.T, app *fiber.App, method, body string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(method, "/client/scope", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Cookie", "sid=test-session")
+	res, err := app.Test(req)
+	require.NoError(t, err)
+	return res
+}
+
+func TestClientScope_ValidBodyForwarded(t *testing.T) {
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		t.Run(method, func(t *testing.T) {
+			app, got := setupClientScopeApp(t)
+
+			res := sendClientScope(t, app, method, `{"scopes":["profile","email"]}`)
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			require.Len(t, *got, 1)
+			assert.Equal(t, method, (*got)[0]["method"])
+			assert.Equal(t, []any{"profile", "email"}, (*got)[0]["scopes"])
+		})
+	}
+}
+
+func TestClientScope_InvalidBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing scopes", `{}`},
+		{"empty scopes", `{"scopes":[]}`},
+		{"blank scope", `{"scopes":[""]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, got := setupClientScopeApp(t)
+
+			res := sendClientScope(t, app, http.MethodPost, tt.body)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+			assert.Empty(t, *got, "SSO must not be called")
+		})
+	}
+}
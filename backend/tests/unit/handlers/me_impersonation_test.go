@@ -0,0 +1,102 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: me_impersonation_test.go
+// Description: Unit tests for is_impersonated on GET /me
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/app"
+	"templatev25/internal/http/handlers"
+	"templatev25/internal/service"
+
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memSessionStore нь зөвхөн Get дэмжих санах ойн SessionStore
+type memSessionStore struct {
+	service.SessionStore
+	sessions map[string]*service.SessionData
+	err      error
+}
+
+func (m *memSessionStore) Get(_ context.Context, sessionID string) (*service.SessionData, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.sessions[sessionID], nil
+}
+
+// getMe нь auth.Require-ийг дуурайж claims, SID хадгалаад GET /me дуудна
+func getMe(t *testing.T, store service.SessionStore) (*http.Response, map[string]any) {
+	t.Helper()
+	d := &app.Dependencies{Service: &app.ServiceContainer{SessionStore: store}}
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/me", func(c *fiber.Ctx) error {
+		c.Locals(ssoclient.LocalsSID, "sid-1")
+		c.Locals(ssoclient.LocalsClaims, &ssoclient.Claims{UserID: 42})
+		return c.Next()
+	}, handlers.NewUserHandler(d).Me)
+
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, "/me", nil))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var payload map[string]any
+	_ = json.NewDecoder(res.Body).Decode(&payload)
+	data, _ := payload["data"].(map[string]any)
+	return res, data
+}
+
+func TestMe_IsImpersonated(t *testing.T) {
+	tests := []struct {
+		name       string
+		session    *service.SessionData
+		wantFlag   bool
+		wantByUser any
+	}{
+		{
+			name:       "impersonation session",
+			session:    &service.SessionData{SessionID: "sid-1", UserID: 42, ImpersonatedBy: 7},
+			wantFlag:   true,
+			wantByUser: float64(7),
+		},
+		{
+			name:    "regular session",
+			session: &service.SessionData{SessionID: "sid-1", UserID: 42},
+		},
+		{
+			name: "no local session",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memSessionStore{sessions: map[string]*service.SessionData{}}
+			if tt.session != nil {
+				store.sessions["sid-1"] = tt.session
+			}
+
+			res, data := getMe(t, store)
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, tt.wantFlag, data["is_impersonated"])
+			assert.Equal(t, tt.wantByUser, data["impersonated_by"])
+		})
+	}
+}
+
+func TestMe_SessionStoreError(t *testing.T) {
+	res, _ := getMe(t, &memSessionStore{err: errors.New("redis down")})
+
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
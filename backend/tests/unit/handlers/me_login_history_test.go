@@ -0,0 +1,144 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: me_login_history_test.go
+// Description: Unit tests for GET /me/login-history (claims user, limit clamping)
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/handlers"
+
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockLoginHistoryReader implements handlers.LoginHistoryReader
+type mockLoginHistoryReader struct {
+	mock.Mock
+}
+
+func (m *mockLoginHistoryReader) GetLoginHistory(ctx context.Context, userID int, limit int) ([]domain.LoginHistory, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.LoginHistory), args.Error(1)
+}
+
+// setupMeLoginHistoryApp нь claims-тай (nil бол claims-гүй) GET /me/login-history app үүсгэнэ
+func setupMeLoginHistoryApp(claims *ssoclient.Claims, svc *mockLoginHistoryReader) *fiber.App {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		if claims != nil {
+			c.Locals(ssoclient.LocalsClaims, claims)
+		}
+		return c.Next()
+	})
+	app.Get("/me/login-history", handlers.NewMeLoginHistoryHandler(svc).GetLoginHistory)
+	return app
+}
+
+func getMeLoginHistory(t *testing.T, app *fiber.App, query string) *http.Response {
+	t.Helper()
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, "/me/login-history"+query, nil))
+	require.NoError(t, err)
+	return res
+}
+
+// =============================================================================
+// GET /me/login-history
+// =============================================================================
+
+func TestMeLoginHistoryHandler_UsesClaimsUserID(t *testing.T) {
+	svc := &mockLoginHistoryReader{}
+	svc.On("GetLoginHistory", mock.Anything, 42, 50).Return([]domain.LoginHistory{
+		{ID: 1, Email: "a@example.com", LoginMethod: "password", Success: true},
+		{ID: 2, Email: "a@example.com", LoginMethod: "password", FailureReason: "invalid_password"},
+	}, nil)
+
+	res := getMeLoginHistory(t, setupMeLoginHistoryApp(&ssoclient.Claims{UserID: 42}, svc), "")
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body struct {
+		Data struct {
+			Entries []map[string]any `json:"entries"`
+			Total   int              `json:"total"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Equal(t, 2, body.Data.Total)
+	assert.Equal(t, "invalid_password", body.Data.Entries[1]["failure_reason"])
+	svc.AssertExpectations(t)
+}
+
+func TestMeLoginHistoryHandler_LimitClamping(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantLimit int
+	}{
+		{name: "default", query: "", wantLimit: 50},
+		{name: "within range", query: "?limit=20", wantLimit: 20},
+		{name: "lower bound", query: "?limit=1", wantLimit: 1},
+		{name: "upper bound", query: "?limit=100", wantLimit: 100},
+		{name: "above max", query: "?limit=500", wantLimit: 100},
+		{name: "zero", query: "?limit=0", wantLimit: 1},
+		{name: "negative", query: "?limit=-5", wantLimit: 1},
+		{name: "not a number", query: "?limit=abc", wantLimit: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockLoginHistoryReader{}
+			svc.On("GetLoginHistory", mock.Anything, 42, tt.wantLimit).Return([]domain.LoginHistory{}, nil)
+
+			res := getMeLoginHistory(t, setupMeLoginHistoryApp(&ssoclient.Claims{UserID: 42}, svc), tt.query)
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMeLoginHistoryHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     *ssoclient.Claims
+		svcErr     error
+		wantStatus int
+		wantCall   bool
+	}{
+		{name: "no claims", claims: nil, wantStatus: http.StatusUnauthorized},
+		{name: "claims without user id", claims: &ssoclient.Claims{CitizenID: 7}, wantStatus: http.StatusUnauthorized},
+		{name: "service error", claims: &ssoclient.Claims{UserID: 42}, svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError, wantCall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockLoginHistoryReader{}
+			svc.On("GetLoginHistory", mock.Anything, 42, 50).Return(nil, tt.svcErr)
+
+			res := getMeLoginHistory(t, setupMeLoginHistoryApp(tt.claims, svc), "")
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode)
+			if tt.wantCall {
+				svc.AssertNumberOfCalls(t, "GetLoginHistory", 1)
+			} else {
+				svc.AssertNotCalled(t, "GetLoginHistory", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: me_org_switch_test.go
+// Description: Unit tests for PUT /me/org (active organization switching)
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/handlers"
+	"templatev25/internal/service"
+	"templatev25/tests/mocks"
+
+	"git.gerege.mn/backend-packages/config"
+	"git.gerege.mn/backend-packages/ctx"
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testSessionID = "test-sid"
+
+// memOrgOverrideStore нь зөвхөн org override дэмжих санах ойн SessionStore
+type memOrgOverrideStore struct {
+	service.SessionStore
+	mu        sync.Mutex
+	overrides map[string]int
+}
+
+func newMemOrgOverrideStore() *memOrgOverrideStore {
+	return &memOrgOverrideStore{overrides: map[string]int{}}
+}
+
+func (m *memOrgOverrideStore) SetOrgOverride(_ context.Context, sessionID string, orgID int, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.overrides[sessionID] = orgID
+	return nil
+}
+
+func (m *memOrgOverrideStore) GetOrgOverride(_ context.Context, sessionID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.overrides[sessionID], nil
+}
+
+// setupMeOrgSwitchApp нь mock repo-той UserService-ээр PUT /me/org route үүсгэнэ
+func setupMeOrgSwitchApp(orgUsers *mocks.OrgUserRepository, store service.SessionStore) *fiber.App {
+	userSvc := service.NewUserService(nil, &config.Config{}, zap.NewNop())
+	userSvc.SetOrgSwitcher(orgUsers, store, time.Hour)
+	d := &app.Dependencies{
+		Service: &app.ServiceContainer{User: userSvc},
+	}
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Put("/me/org", func(c *fiber.Ctx) error {
+		// auth.Require-ийг дуурайна: claims болон SID-ийг хадгална
+		c.Locals(ssoclient.LocalsClaims, &ssoclient.Claims{CitizenID: 123})
+		c.SetUserContext(ctx.WithValue(c.UserContext(), ctx.KeySID, testSessionID))
+		return c.Next()
+	}, handlers.NewUserHandler(d).SwitchOrganization)
+	return app
+}
+
+func putMeOrg(t *testing.T, app *fiber.App, body string) (*http.Response, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPut, "/me/org", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := app.Test(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var payload map[string]any
+	_ = json.NewDecoder(res.Body).Decode(&payload)
+	return res, payload
+}
+
+func TestMeSwitchOrganization_Success(t *testing.T) {
+	orgUsers := mocks.NewOrgUserRepository(t)
+	orgUsers.On("OrgExists", mock.Anything, 10).Return(true, nil).Once()
+	orgUsers.On("FindByOrgAndUser", mock.Anything, 10, 123).Return(domain.OrganizationUser{OrgId: 10, UserId: 123}, nil).Once()
+	store := newMemOrgOverrideStore()
+
+	res, payload := putMeOrg(t, setupMeOrgSwitchApp(orgUsers, store), `{"org_id":10}`)
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	data, ok := payload["data"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, float64(10), data["org_id"])
+	// Session ID (HttpOnly cookie) хариунд ил гарахгүй
+	assert.NotContains(t, data, "token")
+
+	override, err := store.GetOrgOverride(context.Background(), testSessionID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, override)
+}
+
+func TestMeSwitchOrganization_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		mockSetup  func(*mocks.OrgUserRepository)
+		wantStatus int
+	}{
+		{
+			name: "not a member",
+			body: `{"org_id":10}`,
+			mockSetup: func(m *mocks.OrgUserRepository) {
+				m.On("OrgExists", mock.Anything, 10).Return(true, nil).Once()
+				m.On("FindByOrgAndUser", mock.Anything, 10, 123).Return(domain.OrganizationUser{}, domain.ErrNotFound).Once()
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "organization does not exist",
+			body: `{"org_id":999}`,
+			mockSetup: func(m *mocks.OrgUserRepository) {
+				m.On("OrgExists", mock.Anything, 999).Return(false, nil).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "repository failure",
+			body: `{"org_id":10}`,
+			mockSetup: func(m *mocks.OrgUserRepository) {
+				m.On("OrgExists", mock.Anything, 10).Return(false, errors.New("db down")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "invalid body",
+			body:       `{"org_id":0}`,
+			mockSetup:  func(m *mocks.OrgUserRepository) {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgUsers := mocks.NewOrgUserRepository(t)
+			tt.mockSetup(orgUsers)
+			store := newMemOrgOverrideStore()
+
+			res, _ := putMeOrg(t, setupMeOrgSwitchApp(orgUsers, store), tt.body)
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode)
+			override, err := store.GetOrgOverride(context.Background(), testSessionID)
+			require.NoError(t, err)
+			assert.Zero(t, override)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: me_password_test.go
+// Description: Unit tests for PATCH /me/password
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/auth"
+	"templatev25/internal/http/handlers"
+	"templatev25/internal/service"
+
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPasswordChanger implements handlers.PasswordChanger
+type mockPasswordChanger struct {
+	mock.Mock
+}
+
+func (m *mockPasswordChanger) ChangePassword(ctx context.Context, userID int, currentPass, newPass, ip, userAgent string) error {
+	args := m.Called(ctx, userID, currentPass, newPass, ip, userAgent)
+	return args.Error(0)
+}
+
+// setupMePasswordApp нь claims-тай (nil бол claims-гүй) PATCH /me/password app үүсгэнэ
+func setupMePasswordApp(claims *ssoclient.Claims, svc *mockPasswordChanger) *fiber.App {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		if claims != nil {
+			c.Locals(ssoclient.LocalsClaims, claims)
+		}
+		return c.Next()
+	})
+	app.Patch("/me/password", handlers.NewMePasswordHandler(svc).ChangePassword)
+	return app
+}
+
+func patchMePassword(t *testing.T, app *fiber.App, body map[string]string) int {
+	t.Helper()
+	b, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPatch, "/me/password", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "unit-test")
+
+	res, err := app.Test(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	return res.StatusCode
+}
+
+// =============================================================================
+// PATCH /me/password
+// =============================================================================
+
+func TestMePasswordHandler_ChangePassword_UsesClaimsUserID(t *testing.T) {
+	svc := &mockPasswordChanger{}
+	svc.On("ChangePassword", mock.Anything, 42, "OldPass123!", "NewPass456!", mock.Anything, "unit-test").Return(nil)
+
+	status := patchMePassword(t, setupMePasswordApp(&ssoclient.Claims{UserID: 42, CitizenID: 7}, svc),
+		map[string]string{"current_password": "OldPass123!", "new_password": "NewPass456!"})
+
+	assert.Equal(t, http.StatusOK, status)
+	svc.AssertExpectations(t)
+}
+
+func TestMePasswordHandler_ChangePassword_Errors(t *testing.T) {
+	valid := map[string]string{"current_password": "OldPass123!", "new_password": "NewPass456!"}
+
+	tests := []struct {
+		name       string
+		claims     *ssoclient.Claims
+		body       map[string]string
+		svcErr     error
+		wantStatus int
+		wantCall   bool
+	}{
+		{name: "no claims", claims: nil, body: valid, wantStatus: http.StatusUnauthorized},
+		{name: "claims without user id", claims: &ssoclient.Claims{CitizenID: 7}, body: valid, wantStatus: http.StatusUnauthorized},
+		{name: "short new password", claims: &ssoclient.Claims{UserID: 42}, body: map[string]string{"current_password": "x", "new_password": "short"}, wantStatus: http.StatusUnprocessableEntity},
+		{name: "wrong current password", claims: &ssoclient.Claims{UserID: 42}, body: valid, svcErr: service.ErrInvalidCredentials, wantStatus: http.StatusBadRequest, wantCall: true},
+		{name: "reused password", claims: &ssoclient.Claims{UserID: 42}, body: valid, svcErr: service.ErrPasswordReused, wantStatus: http.StatusBadRequest, wantCall: true},
+		{name: "unexpected error", claims: &ssoclient.Claims{UserID: 42}, body: valid, svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError, wantCall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockPasswordChanger{}
+			svc.On("ChangePassword", mock.Anything, 42, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.svcErr)
+
+			status := patchMePassword(t, setupMePasswordApp(tt.claims, svc), tt.body)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantCall {
+				svc.AssertNumberOfCalls(t, "ChangePassword", 1)
+			} else {
+				svc.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestMePasswordHandler_ChangePassword_WeakPasswordViolations(t *testing.T) {
+	svc := &mockPasswordChanger{}
+	svc.On("ChangePassword", mock.Anything, 42, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&service.PasswordTooWeakError{Violations: []auth.PolicyViolation{auth.ViolationUppercase, auth.ViolationDigit}})
+
+	b, err := json.Marshal(map[string]string{"current_password": "OldPass123!", "new_password": "weakpassword"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPatch, "/me/password", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := setupMePasswordApp(&ssoclient.Claims{UserID: 42}, svc).Test(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var body struct {
+		Data struct {
+			Violations []string `json:"violations"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, []string{"uppercase", "digit"}, body.Data.Violations)
+}
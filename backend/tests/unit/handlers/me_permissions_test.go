@@ -0,0 +1,164 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: me_permissions_test.go
+// Description: Unit tests for GET /me/permissions
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"templatev25/internal/app"
+	"templatev25/internal/auth"
+	"templatev25/internal/http/handlers"
+
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPermissionService implements auth.PermissionChecker for testing
+type mockPermissionService struct {
+	mock.Mock
+}
+
+func (m *mockPermissionService) HasPermission(ctx context.Context, userID int, permissionCode string) (bool, error) {
+	args := m.Called(ctx, userID, permissionCode)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockPermissionService) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// setupMePermissionsApp нь жинхэнэ UserHandler.Permissions-ийг PermissionCache-тэй холбоно
+func setupMePermissionsApp(claims *ssoclient.Claims, svc *mockPermissionService) *fiber.App {
+	h := handlers.NewUserHandler(&app.Dependencies{
+		PermCache: auth.NewPermissionCache(svc, auth.PermissionCacheOptions{DefaultTTL: time.Minute}),
+	})
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		// Simulate SSO claims being set
+		if claims != nil {
+			c.Locals(ssoclient.LocalsClaims, claims)
+		}
+		return c.Next()
+	})
+	app.Get("/me/permissions", h.Permissions)
+	return app
+}
+
+// getPermissions нь GET /me/permissions дуудаж status болон data-г буцаана
+func getPermissions(t *testing.T, app *fiber.App, query string) (int, []string) {
+	t.Helper()
+
+	res, err := app.Test(httptest.NewRequest("GET", "/me/permissions"+query, nil))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var body struct {
+		Data []string `json:"data"`
+	}
+	if res.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	}
+	return res.StatusCode, body.Data
+}
+
+// =============================================================================
+// GET /me/permissions
+// =============================================================================
+
+func TestMeHandler_Permissions(t *testing.T) {
+	claims := &ssoclient.Claims{CitizenID: 42}
+
+	tests := []struct {
+		name       string
+		claims     *ssoclient.Claims
+		query      string
+		codes      []string
+		svcErr     error
+		wantStatus int
+		wantCodes  []string
+	}{
+		{
+			name:       "success - empty list",
+			claims:     claims,
+			codes:      []string{},
+			wantStatus: http.StatusOK,
+			wantCodes:  []string{},
+		},
+		{
+			name:       "success - populated list",
+			claims:     claims,
+			codes:      []string{"admin.user.read", "admin.role.read", "chat.room.create"},
+			wantStatus: http.StatusOK,
+			wantCodes:  []string{"admin.user.read", "admin.role.read", "chat.room.create"},
+		},
+		{
+			name:       "success - filtered by system prefix",
+			claims:     claims,
+			query:      "?system=admin",
+			codes:      []string{"admin.user.read", "chat.room.create", "administration.x"},
+			wantStatus: http.StatusOK,
+			wantCodes:  []string{"admin.user.read"},
+		},
+		{
+			name:       "error - service failure",
+			claims:     claims,
+			svcErr:     errors.New("db error"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "error - unauthorized (no claims)",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockPermissionService{}
+			if tt.claims != nil {
+				svc.On("GetUserPermissions", mock.Anything, 42).Return(tt.codes, tt.svcErr)
+			}
+
+			status, codes := getPermissions(t, setupMePermissionsApp(tt.claims, svc), tt.query)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantCodes != nil {
+				assert.Equal(t, tt.wantCodes, codes)
+			}
+			if tt.claims == nil {
+				svc.AssertNotCalled(t, "GetUserPermissions", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestMeHandler_Permissions_CacheHit(t *testing.T) {
+	svc := &mockPermissionService{}
+	svc.On("GetUserPermissions", mock.Anything, 42).Return([]string{"admin.user.read"}, nil).Once()
+
+	app := setupMePermissionsApp(&ssoclient.Claims{CitizenID: 42}, svc)
+
+	for i := 0; i < 3; i++ {
+		status, codes := getPermissions(t, app, "")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{"admin.user.read"}, codes)
+	}
+
+	// Дараагийн дуудлагууд cache-ээс уншигдана
+	svc.AssertNumberOfCalls(t, "GetUserPermissions", 1)
+}
@@ -0,0 +1,72 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: orgtype_delete_test.go
+// Description: Unit tests for DELETE /orgtype/:id
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/app"
+	"templatev25/internal/domain"
+	"templatev25/internal/http/handlers"
+	"templatev25/internal/service"
+	"templatev25/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// setupOrgTypeDeleteApp нь mock repo-той OrganizationTypeService-ээр DELETE route үүсгэнэ
+func setupOrgTypeDeleteApp(repo *mocks.OrganizationTypeRepository) *fiber.App {
+	d := &app.Dependencies{
+		Service: &app.ServiceContainer{
+			OrganizationType: service.NewOrganizationTypeService(repo, nil, zap.NewNop()),
+		},
+	}
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Delete("/orgtype/:id", handlers.NewOrganizationTypeHandler(d).Delete)
+	return app
+}
+
+func TestOrgTypeDelete_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockSetup  func(*mocks.OrganizationTypeRepository)
+		wantStatus int
+	}{
+		{
+			name: "organization type not found",
+			mockSetup: func(m *mocks.OrganizationTypeRepository) {
+				m.On("ByID", mock.Anything, 42).Return(domain.OrganizationType{}, domain.NewNotFound("organization type not found", nil)).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "repository failure",
+			mockSetup: func(m *mocks.OrganizationTypeRepository) {
+				m.On("ByID", mock.Anything, 42).Return(domain.OrganizationType{Id: 42}, nil).Once()
+				m.On("Delete", mock.Anything, 42, "").Return(errors.New("db down")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewOrganizationTypeRepository(t)
+			tt.mockSetup(repo)
+
+			res, err := setupOrgTypeDeleteApp(repo).Test(httptest.NewRequest(http.MethodDelete, "/orgtype/42", nil))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode)
+		})
+	}
+}
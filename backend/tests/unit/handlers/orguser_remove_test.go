@@ -0,0 +1,82 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: orguser_remove_test.go
+// Description: Unit tests for DELETE /organization/:id/user/:userID
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"templatev25/internal/app"
+	"templatev25/internal/http/handlers"
+	"templatev25/internal/service"
+	"templatev25/tests/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupOrgUserRemoveApp нь mock repo-той OrgUserService-ээр DELETE route үүсгэнэ
+func setupOrgUserRemoveApp(repo *mocks.OrgUserRepository) *fiber.App {
+	d := &app.Dependencies{
+		Service: &app.ServiceContainer{
+			OrgUser: service.NewOrgUserService(repo, nil, nil),
+		},
+	}
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Delete("/organization/:id/user/:userID", handlers.NewOrgUserHandler(d).RemoveByPath)
+	return app
+}
+
+func deleteOrgUser(t *testing.T, app *fiber.App, path string) *http.Response {
+	t.Helper()
+	res, err := app.Test(httptest.NewRequest(http.MethodDelete, path, nil))
+	require.NoError(t, err)
+	return res
+}
+
+func TestOrgUserRemoveByPath_Success(t *testing.T) {
+	repo := mocks.NewOrgUserRepository(t)
+	repo.On("Remove", mock.Anything, 10, 123).Return(nil).Once()
+
+	res := deleteOrgUser(t, setupOrgUserRemoveApp(repo), "/organization/10/user/123")
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestOrgUserRemoveByPath_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"non-numeric org", "/organization/abc/user/123", http.StatusBadRequest},
+		{"zero org", "/organization/0/user/123", http.StatusUnprocessableEntity},
+		{"non-numeric user", "/organization/10/user/abc", http.StatusBadRequest},
+		{"negative user", "/organization/10/user/-1", http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Remove дуудагдахгүй (mocks.NewOrgUserRepository үүнийг шалгана)
+			repo := mocks.NewOrgUserRepository(t)
+
+			res := deleteOrgUser(t, setupOrgUserRemoveApp(repo), tt.path)
+
+			assert.Equal(t, tt.want, res.StatusCode)
+		})
+	}
+}
+
+func TestOrgUserRemoveByPath_ServiceError(t *testing.T) {
+	repo := mocks.NewOrgUserRepository(t)
+	repo.On("Remove", mock.Anything, 10, 123).Return(errors.New("db down")).Once()
+
+	res := deleteOrgUser(t, setupOrgUserRemoveApp(repo), "/organization/10/user/123")
+
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
@@ -0,0 +1,125 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: permission_check_test.go
+// Description: Unit tests for POST /permission/check
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"templatev25/internal/app"
+	"templatev25/internal/auth"
+	"templatev25/internal/http/handlers"
+
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupPermissionCheckApp нь жинхэнэ PermissionHandler.Check-ийг PermissionCache-тэй холбоно
+func setupPermissionCheckApp(svc *mockPermissionService) *fiber.App {
+	h := handlers.NewPermissionHandler(&app.Dependencies{
+		PermCache: auth.NewPermissionCache(svc, auth.PermissionCacheOptions{DefaultTTL: time.Minute}),
+	})
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(ssoclient.LocalsClaims, &ssoclient.Claims{CitizenID: 42})
+		return c.Next()
+	})
+	app.Post("/permission/check", h.Check)
+	return app
+}
+
+// postCheck нь POST /permission/check дуудаж status болон results-ийг буцаана
+func postCheck(t *testing.T, app *fiber.App, codes []string) (int, map[string]bool) {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"permission_codes": codes})
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", "/permission/check", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := app.Test(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var out struct {
+		Data struct {
+			Results map[string]bool `json:"results"`
+		} `json:"data"`
+	}
+	if res.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&out))
+	}
+	return res.StatusCode, out.Data.Results
+}
+
+// =============================================================================
+// POST /permission/check
+// =============================================================================
+
+func TestPermissionHandler_Check(t *testing.T) {
+	svc := &mockPermissionService{}
+	svc.On("GetUserPermissions", mock.Anything, 42).Return([]string{"admin.user.read", "chat.room.create"}, nil)
+
+	status, results := postCheck(t, setupPermissionCheckApp(svc), []string{"admin.user.read", "admin.role.delete"})
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]bool{"admin.user.read": true, "admin.role.delete": false}, results)
+}
+
+func TestPermissionHandler_Check_CodeLimit(t *testing.T) {
+	codes := make([]string, 51)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("admin.perm%d.read", i)
+	}
+
+	tests := []struct {
+		name       string
+		codes      []string
+		wantStatus int
+	}{
+		{name: "success - 50 codes", codes: codes[:50], wantStatus: http.StatusOK},
+		{name: "error - 51 codes", codes: codes, wantStatus: http.StatusUnprocessableEntity},
+		{name: "error - empty list", codes: []string{}, wantStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockPermissionService{}
+			svc.On("GetUserPermissions", mock.Anything, 42).Return([]string{}, nil)
+
+			status, results := postCheck(t, setupPermissionCheckApp(svc), tt.codes)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantStatus == http.StatusOK {
+				assert.Len(t, results, len(tt.codes))
+			} else {
+				svc.AssertNotCalled(t, "GetUserPermissions", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPermissionHandler_Check_UsesCache(t *testing.T) {
+	svc := &mockPermissionService{}
+	svc.On("GetUserPermissions", mock.Anything, 42).Return([]string{"admin.user.read"}, nil).Once()
+
+	app := setupPermissionCheckApp(svc)
+	for i := 0; i < 3; i++ {
+		status, results := postCheck(t, app, []string{"admin.user.read"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, results["admin.user.read"])
+	}
+
+	svc.AssertNumberOfCalls(t, "GetUserPermissions", 1)
+}
@@ -0,0 +1,229 @@
+// Package handlers provides unit tests for HTTP handlers
+//
+// File: user_audit_test.go
+// Description: Unit tests for GET /user/:id/audit (permission guard, limit, action filter, mapping)
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/handlers"
+
+	ssoclient "git.gerege.mn/backend-packages/sso-client"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAuditTrailReader implements handlers.AuditTrailReader
+type mockAuditTrailReader struct {
+	mock.Mock
+}
+
+func (m *mockAuditTrailReader) GetAuditTrail(ctx context.Context, userID int, limit int) ([]domain.SecurityAuditTrail, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SecurityAuditTrail), args.Error(1)
+}
+
+func (m *mockAuditTrailReader) GetAuditTrailByAction(ctx context.Context, userID int, action string, limit int) ([]domain.SecurityAuditTrail, error) {
+	args := m.Called(ctx, userID, action, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SecurityAuditTrail), args.Error(1)
+}
+
+// mockAuditPermissionChecker implements auth.PermissionChecker
+type mockAuditPermissionChecker struct {
+	mock.Mock
+}
+
+func (m *mockAuditPermissionChecker) HasPermission(ctx context.Context, userID int, permissionCode string) (bool, error) {
+	args := m.Called(ctx, userID, permissionCode)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAuditPermissionChecker) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// setupUserAuditApp нь claims-тай (nil бол claims-гүй) GET /user/:id/audit app үүсгэнэ
+func setupUserAuditApp(claims *ssoclient.Claims, reader *mockAuditTrailReader, perms *mockAuditPermissionChecker) *fiber.App {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		if claims != nil {
+			c.Locals(ssoclient.LocalsClaims, claims)
+		}
+		return c.Next()
+	})
+	app.Get("/user/:id/audit", handlers.NewUserAuditHandler(reader, perms).GetAudit)
+	return app
+}
+
+func getUserAudit(t *testing.T, app *fiber.App, path string) *http.Response {
+	t.Helper()
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+	require.NoError(t, err)
+	return res
+}
+
+// allowAudit нь admin (ID 1)-д admin.user.audit.read эрх өгнө
+func allowAudit() *mockAuditPermissionChecker {
+	perms := &mockAuditPermissionChecker{}
+	perms.On("HasPermission", mock.Anything, 1, handlers.UserAuditPermission).Return(true, nil)
+	return perms
+}
+
+func TestUserAuditHandler_PermissionGuard(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   *ssoclient.Claims
+		allowed  bool
+		checkErr error
+		want     int
+	}{
+		{name: "no claims", claims: nil, want: http.StatusUnauthorized},
+		{name: "missing permission", claims: &ssoclient.Claims{UserID: 1}, allowed: false, want: http.StatusForbidden},
+		{name: "permission check error", claims: &ssoclient.Claims{UserID: 1}, checkErr: errors.New("db down"), want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &mockAuditTrailReader{}
+			perms := &mockAuditPermissionChecker{}
+			perms.On("HasPermission", mock.Anything, 1, handlers.UserAuditPermission).Return(tt.allowed, tt.checkErr).Maybe()
+
+			res := getUserAudit(t, setupUserAuditApp(tt.claims, reader, perms), "/user/7/audit")
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.want, res.StatusCode)
+			reader.AssertNotCalled(t, "GetAuditTrail", mock.Anything, mock.Anything, mock.Anything)
+			reader.AssertNotCalled(t, "GetAuditTrailByAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUserAuditHandler_MapsEntries(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	createdLocal := domain.LocalDateTime(created)
+	reader := &mockAuditTrailReader{}
+	reader.On("GetAuditTrail", mock.Anything, 7, 50).Return([]domain.SecurityAuditTrail{
+		{ID: 2, Action: "login", IPAddress: "10.0.0.1", UserAgent: "curl/8", ExtraFields: domain.ExtraFields{CreatedDate: &createdLocal}},
+		{ID: 1, Action: "password_change", IPAddress: "10.0.0.2", UserAgent: "firefox"},
+	}, nil)
+
+	res := getUserAudit(t, setupUserAuditApp(&ssoclient.Claims{UserID: 1}, reader, allowAudit()), "/user/7/audit")
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body struct {
+		Data struct {
+			Entries []struct {
+				Action    string    `json:"action"`
+				IPAddress string    `json:"ip_address"`
+				UserAgent string    `json:"user_agent"`
+				CreatedAt time.Time `json:"created_at"`
+			} `json:"entries"`
+			Total int `json:"total"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Equal(t, 2, body.Data.Total)
+	require.Len(t, body.Data.Entries, 2)
+	assert.Equal(t, "login", body.Data.Entries[0].Action)
+	assert.Equal(t, "10.0.0.1", body.Data.Entries[0].IPAddress)
+	assert.Equal(t, "curl/8", body.Data.Entries[0].UserAgent)
+	assert.True(t, created.Equal(body.Data.Entries[0].CreatedAt))
+	assert.Equal(t, "password_change", body.Data.Entries[1].Action)
+	reader.AssertExpectations(t)
+}
+
+func TestUserAuditHandler_EmptyTrail(t *testing.T) {
+	reader := &mockAuditTrailReader{}
+	reader.On("GetAuditTrail", mock.Anything, 7, 50).Return(nil, nil)
+
+	res := getUserAudit(t, setupUserAuditApp(&ssoclient.Claims{UserID: 1}, reader, allowAudit()), "/user/7/audit")
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body struct {
+		Data struct {
+			Entries []map[string]any `json:"entries"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.NotNil(t, body.Data.Entries, "entries is [] not null")
+	assert.Empty(t, body.Data.Entries)
+}
+
+func TestUserAuditHandler_Limit(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{name: "default", query: "", want: 50},
+		{name: "custom", query: "?limit=120", want: 120},
+		{name: "clamped to max", query: "?limit=1000", want: 200},
+		{name: "negative clamped to 1", query: "?limit=-5", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &mockAuditTrailReader{}
+			reader.On("GetAuditTrail", mock.Anything, 7, tt.want).Return([]domain.SecurityAuditTrail{}, nil).Once()
+
+			res := getUserAudit(t, setupUserAuditApp(&ssoclient.Claims{UserID: 1}, reader, allowAudit()), "/user/7/audit"+tt.query)
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			reader.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserAuditHandler_ActionFilter(t *testing.T) {
+	reader := &mockAuditTrailReader{}
+	reader.On("GetAuditTrailByAction", mock.Anything, 7, "login", 20).
+		Return([]domain.SecurityAuditTrail{{ID: 3, Action: "login"}}, nil).Once()
+
+	res := getUserAudit(t, setupUserAuditApp(&ssoclient.Claims{UserID: 1}, reader, allowAudit()), "/user/7/audit?action=login&limit=20")
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	reader.AssertExpectations(t)
+	reader.AssertNotCalled(t, "GetAuditTrail", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserAuditHandler_InvalidIDAndRepoError(t *testing.T) {
+	t.Run("non-numeric id", func(t *testing.T) {
+		reader := &mockAuditTrailReader{}
+
+		res := getUserAudit(t, setupUserAuditApp(&ssoclient.Claims{UserID: 1}, reader, allowAudit()), "/user/abc/audit")
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		reader := &mockAuditTrailReader{}
+		reader.On("GetAuditTrail", mock.Anything, 7, 50).Return(nil, errors.New("db down"))
+
+		res := getUserAudit(t, setupUserAuditApp(&ssoclient.Claims{UserID: 1}, reader, allowAudit()), "/user/7/audit")
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,198 @@
+// Package health provides implementation for health
+//
+// File: health_test.go
+// Description: Tests for health checkers and result aggregation
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"templatev25/internal/health"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// ============================================================
+// MOCK CHECKER
+// ============================================================
+
+type mockChecker struct {
+	mock.Mock
+}
+
+func (m *mockChecker) Check(ctx context.Context) health.HealthResult {
+	args := m.Called(ctx)
+	return args.Get(0).(health.HealthResult)
+}
+
+func newMockChecker(result health.HealthResult) *mockChecker {
+	m := new(mockChecker)
+	m.On("Check", mock.Anything).Return(result)
+	return m
+}
+
+// ============================================================
+// TEST RUN
+// ============================================================
+
+func TestRun(t *testing.T) {
+	dbOK := health.HealthResult{Name: "database", Status: health.StatusOK}
+	ssoOK := health.HealthResult{Name: "sso", Status: health.StatusOK}
+	redisOK := health.HealthResult{Name: "redis", Status: health.StatusOK}
+	dbDown := health.HealthResult{Name: "database", Status: health.StatusError, Error: "db_unreachable"}
+	ssoDown := health.HealthResult{Name: "sso", Status: health.StatusError, Error: "sso_unreachable"}
+	redisDown := health.HealthResult{Name: "redis", Status: health.StatusError, Error: "redis_unreachable"}
+
+	tests := []struct {
+		name       string
+		results    []health.HealthResult
+		wantStatus string
+	}{
+		{name: "ok - all checkers pass", results: []health.HealthResult{dbOK, ssoOK, redisOK}, wantStatus: health.StatusOK},
+		{name: "ok - no checkers", results: nil, wantStatus: health.StatusOK},
+		{name: "degraded - database down", results: []health.HealthResult{dbDown, ssoOK, redisOK}, wantStatus: health.StatusDegraded},
+		{name: "degraded - sso down", results: []health.HealthResult{dbOK, ssoDown, redisOK}, wantStatus: health.StatusDegraded},
+		{name: "degraded - redis down", results: []health.HealthResult{dbOK, ssoOK, redisDown}, wantStatus: health.StatusDegraded},
+		{name: "degraded - all down", results: []health.HealthResult{dbDown, ssoDown, redisDown}, wantStatus: health.StatusDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checkers []health.HealthChecker
+			var mocks []*mockChecker
+			for _, r := range tt.results {
+				m := newMockChecker(r)
+				mocks = append(mocks, m)
+				checkers = append(checkers, m)
+			}
+
+			status, results := health.Run(context.Background(), checkers)
+
+			assert.Equal(t, tt.wantStatus, status)
+			// Үр дүн checker-ийн дарааллаар, алдааны дэлгэрэнгүйтэй
+			assert.Equal(t, len(tt.results), len(results))
+			for i := range tt.results {
+				assert.Equal(t, tt.results[i], results[i])
+			}
+			for _, m := range mocks {
+				m.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestRun_ChecksRunConcurrently(t *testing.T) {
+	slow := func(name string) *mockChecker {
+		m := new(mockChecker)
+		m.On("Check", mock.Anything).
+			Run(func(mock.Arguments) { time.Sleep(100 * time.Millisecond) }).
+			Return(health.HealthResult{Name: name, Status: health.StatusOK})
+		return m
+	}
+
+	start := time.Now()
+	status, _ := health.Run(context.Background(), []health.HealthChecker{slow("a"), slow("b"), slow("c")})
+
+	assert.Equal(t, health.StatusOK, status)
+	assert.Less(t, time.Since(start), 250*time.Millisecond)
+}
+
+func TestHealthResult_MarshalJSON(t *testing.T) {
+	r := health.HealthResult{
+		Name:    "database",
+		Status:  health.StatusOK,
+		Details: map[string]any{"open_conns": 3},
+	}
+	out, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"ok","open_conns":3}`, string(out))
+
+	r = health.HealthResult{Name: "sso", Status: health.StatusError, Error: "sso_unreachable"}
+	out, err = json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"error","error":"sso_unreachable"}`, string(out))
+}
+
+// ============================================================
+// TEST CHECKERS
+// ============================================================
+
+func TestSSOChecker(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantStatus string
+		wantError  string
+	}{
+		{name: "ok - 200", statusCode: http.StatusOK, wantStatus: health.StatusOK},
+		{name: "error - 503", statusCode: http.StatusServiceUnavailable, wantStatus: health.StatusError, wantError: "sso_unhealthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer srv.Close()
+
+			result := health.NewSSOChecker(srv.URL+"/", nil).Check(context.Background())
+
+			assert.Equal(t, "/health", gotPath)
+			assert.Equal(t, "sso", result.Name)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantError, result.Error)
+			assert.Equal(t, tt.statusCode, result.Details["status_code"])
+		})
+	}
+
+	t.Run("error - unreachable", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		url := srv.URL
+		srv.Close()
+
+		result := health.NewSSOChecker(url, nil).Check(context.Background())
+
+		assert.Equal(t, health.StatusError, result.Status)
+		assert.Equal(t, "sso_unreachable", result.Error)
+	})
+}
+
+func TestRedisChecker_Unreachable(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		DialTimeout: 200 * time.Millisecond,
+		MaxRetries:  -1,
+	})
+	defer client.Close()
+
+	result := health.NewRedisChecker(client).Check(context.Background())
+
+	assert.Equal(t, "redis", result.Name)
+	assert.Equal(t, health.StatusError, result.Status)
+	assert.Equal(t, "redis_unreachable", result.Error)
+}
+
+func TestPostgresChecker_Unreachable(t *testing.T) {
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=x dbname=x sslmode=disable connect_timeout=1"), &gorm.Config{
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	result := health.NewPostgresChecker(db).Check(context.Background())
+
+	assert.Equal(t, "database", result.Name)
+	assert.Equal(t, health.StatusError, result.Status)
+	assert.Equal(t, "db_unreachable", result.Error)
+}
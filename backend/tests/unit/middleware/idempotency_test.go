@@ -0,0 +1,184 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"templatev25/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIdempotencyTestApp returns an app whose POST /items handler counts its invocations
+func newIdempotencyTestApp(store middleware.IdempotencyStore, calls *int) *fiber.App {
+	app := fiber.New()
+	app.Post("/items", middleware.Idempotency(store, time.Hour), func(c *fiber.Ctx) error {
+		*calls++
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": *calls})
+	})
+	app.Post("/fail", middleware.Idempotency(store, time.Hour), func(c *fiber.Ctx) error {
+		*calls++
+		return fiber.NewError(fiber.StatusInternalServerError, "boom")
+	})
+	return app
+}
+
+func postWithKey(t *testing.T, app *fiber.App, path, key string) (int, string, string) {
+	t.Helper()
+	return postBodyWithKey(t, app, path, key, "")
+}
+
+func postBodyWithKey(t *testing.T, app *fiber.App, path, key, body string) (int, string, string) {
+	t.Helper()
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(middleware.HeaderIdempotencyKey, key)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	out, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(out), resp.Header.Get(middleware.HeaderIdempotentReplayed)
+}
+
+func TestIdempotency_CacheMissThenHit(t *testing.T) {
+	calls := 0
+	app := newIdempotencyTestApp(middleware.NewInMemoryIdempotencyStore(), &calls)
+
+	// Cache miss: handler ажиллаж хариу хадгалагдана
+	status, body, replayed := postWithKey(t, app, "/items", "key-1")
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, `{"id":1}`, body)
+	assert.Empty(t, replayed)
+
+	// Cache hit: handler дахин ажиллахгүй, ижил хариу буцна
+	status, body, replayed = postWithKey(t, app, "/items", "key-1")
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, `{"id":1}`, body)
+	assert.Equal(t, "true", replayed)
+	assert.Equal(t, 1, calls)
+
+	// Өөр key нь шинэ request
+	status, body, _ = postWithKey(t, app, "/items", "key-2")
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, `{"id":2}`, body)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_MissingHeaderBypass(t *testing.T) {
+	calls := 0
+	app := newIdempotencyTestApp(middleware.NewInMemoryIdempotencyStore(), &calls)
+
+	for i := 1; i <= 3; i++ {
+		status, body, replayed := postWithKey(t, app, "/items", "")
+		assert.Equal(t, fiber.StatusCreated, status)
+		assert.Equal(t, `{"id":`+strconv.Itoa(i)+`}`, body)
+		assert.Empty(t, replayed)
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestIdempotency_ErrorNotCached(t *testing.T) {
+	calls := 0
+	app := newIdempotencyTestApp(middleware.NewInMemoryIdempotencyStore(), &calls)
+
+	status, _, _ := postWithKey(t, app, "/fail", "key-1")
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	status, _, replayed := postWithKey(t, app, "/fail", "key-1")
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	assert.Empty(t, replayed)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_KeyReusedForDifferentRequest(t *testing.T) {
+	calls := 0
+	app := newIdempotencyTestApp(middleware.NewInMemoryIdempotencyStore(), &calls)
+
+	status, _, _ := postBodyWithKey(t, app, "/items", "key-1", `{"name":"a"}`)
+	require.Equal(t, fiber.StatusCreated, status)
+
+	// Ижил key, өөр body: хадгалсан хариуг буцаахгүй
+	status, _, replayed := postBodyWithKey(t, app, "/items", "key-1", `{"name":"b"}`)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+	assert.Empty(t, replayed)
+
+	// Ижил key, өөр route
+	status, _, _ = postBodyWithKey(t, app, "/fail", "key-1", `{"name":"a"}`)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_ConcurrentRequestGets409(t *testing.T) {
+	store := middleware.NewInMemoryIdempotencyStore()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	calls := 0
+
+	app := fiber.New()
+	app.Post("/items", middleware.Idempotency(store, time.Hour), func(c *fiber.Ctx) error {
+		calls++
+		close(started)
+		<-release
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": calls})
+	})
+
+	first := make(chan int, 1)
+	go func() {
+		status, _, _ := postWithKey(t, app, "/items", "key-1")
+		first <- status
+	}()
+	<-started
+
+	// Эхний request ажиллаж байх үед ижил key-тэй request handler руу орохгүй
+	status, _, _ := postWithKey(t, app, "/items", "key-1")
+	assert.Equal(t, fiber.StatusConflict, status)
+
+	close(release)
+	assert.Equal(t, fiber.StatusCreated, <-first)
+
+	// Дууссаны дараа хадгалсан хариу буцна
+	status, body, replayed := postWithKey(t, app, "/items", "key-1")
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, `{"id":1}`, body)
+	assert.Equal(t, "true", replayed)
+	assert.Equal(t, 1, calls)
+}
+
+func TestInMemoryIdempotencyStore_SetNX(t *testing.T) {
+	store := middleware.NewInMemoryIdempotencyStore()
+
+	assert.True(t, store.SetNX("k", []byte("a"), time.Hour))
+	assert.False(t, store.SetNX("k", []byte("b"), time.Hour))
+	got, _ := store.Get("k")
+	assert.Equal(t, []byte("a"), got)
+
+	store.Delete("k")
+	assert.True(t, store.SetNX("k", []byte("c"), time.Hour))
+
+	// Хугацаа нь дууссан захиалгыг дахин авч болно
+	store.Set("expired", []byte("v"), -time.Second)
+	assert.True(t, store.SetNX("expired", []byte("v"), time.Hour))
+}
+
+func TestInMemoryIdempotencyStore_Expiry(t *testing.T) {
+	store := middleware.NewInMemoryIdempotencyStore()
+
+	store.Set("k", []byte("v"), time.Hour)
+	got, ok := store.Get("k")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("v"), got)
+
+	store.Set("expired", []byte("v"), -time.Second)
+	_, ok = store.Get("expired")
+	assert.False(t, ok)
+
+	_, ok = store.Get("missing")
+	assert.False(t, ok)
+}
@@ -88,7 +88,8 @@ func TestSecurityHeaders(t *testing.T) {
 
 		assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
 		assert.Equal(t, "DENY", resp.Header.Get("X-Frame-Options"))
-		assert.Equal(t, "no-referrer", resp.Header.Get("Referrer-Policy"))
+		assert.Equal(t, "1; mode=block", resp.Header.Get("X-XSS-Protection"))
+		assert.Equal(t, "strict-origin-when-cross-origin", resp.Header.Get("Referrer-Policy"))
 		assert.NotEmpty(t, resp.Header.Get("Content-Security-Policy"))
 		assert.NotEmpty(t, resp.Header.Get("Permissions-Policy"))
 	})
@@ -105,6 +106,54 @@ func TestSecurityHeaders(t *testing.T) {
 	})
 }
 
+func TestSecurityHeaders_ConfiguredCSP(t *testing.T) {
+	const csp = "default-src 'none'; frame-ancestors 'none'"
+
+	app := fiber.New()
+	app.Use(middleware.SecurityHeaders(csp))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("OK")
+	})
+	app.Get("/docs/index.html", func(c *fiber.Ctx) error {
+		return c.SendString("Swagger")
+	})
+
+	t.Run("configured CSP replaces default", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, csp, resp.Header.Get("Content-Security-Policy"))
+		assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", resp.Header.Get("X-Frame-Options"))
+		assert.Equal(t, "1; mode=block", resp.Header.Get("X-XSS-Protection"))
+		assert.Equal(t, "strict-origin-when-cross-origin", resp.Header.Get("Referrer-Policy"))
+	})
+
+	t.Run("docs keep swagger CSP", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/docs/index.html", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.NotEqual(t, csp, resp.Header.Get("Content-Security-Policy"))
+		assert.Contains(t, resp.Header.Get("Content-Security-Policy"), "validator.swagger.io")
+	})
+
+	t.Run("blank CSP falls back to default", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(middleware.SecurityHeaders("  "))
+		app.Get("/", func(c *fiber.Ctx) error {
+			return c.SendString("OK")
+		})
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Contains(t, resp.Header.Get("Content-Security-Policy"), "frame-ancestors 'none'")
+	})
+}
+
 func TestBodySizeLimit(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -159,24 +208,28 @@ func TestPaginationLimit(t *testing.T) {
 		maxSize        int
 		query          string
 		expectedStatus int
+		expectedSize   string // handler-т хүрсэн size
 	}{
 		{
 			name:           "size within limit",
 			maxSize:        100,
 			query:          "?size=50",
 			expectedStatus: 200,
+			expectedSize:   "50",
 		},
 		{
 			name:           "size at limit",
 			maxSize:        100,
 			query:          "?size=100",
 			expectedStatus: 200,
+			expectedSize:   "100",
 		},
 		{
-			name:           "size exceeds limit",
+			name:           "size exceeds limit - clamped",
 			maxSize:        100,
-			query:          "?size=200",
-			expectedStatus: 400,
+			query:          "?size=500",
+			expectedStatus: 200,
+			expectedSize:   "100",
 		},
 		{
 			name:           "pageSize parameter",
@@ -185,10 +238,18 @@ func TestPaginationLimit(t *testing.T) {
 			expectedStatus: 200,
 		},
 		{
-			name:           "negative size",
+			name:           "zero size - default 10",
+			maxSize:        100,
+			query:          "?size=0",
+			expectedStatus: 200,
+			expectedSize:   "10",
+		},
+		{
+			name:           "negative size - default 10",
 			maxSize:        100,
 			query:          "?size=-1",
-			expectedStatus: 400,
+			expectedStatus: 200,
+			expectedSize:   "10",
 		},
 		{
 			name:           "negative page",
@@ -205,8 +266,9 @@ func TestPaginationLimit(t *testing.T) {
 		{
 			name:           "default max size",
 			maxSize:        0, // Use default
-			query:          "?size=50",
+			query:          "?size=500",
 			expectedStatus: 200,
+			expectedSize:   "100",
 		},
 	}
 
@@ -219,7 +281,7 @@ func TestPaginationLimit(t *testing.T) {
 				app.Use(middleware.PaginationLimit())
 			}
 			app.Get("/", func(c *fiber.Ctx) error {
-				return c.SendString("OK")
+				return c.SendString(c.Query("size"))
 			})
 
 			req := httptest.NewRequest("GET", "/"+tt.query, nil)
@@ -228,6 +290,10 @@ func TestPaginationLimit(t *testing.T) {
 			defer resp.Body.Close()
 
 			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus == 200 {
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, tt.expectedSize, string(body))
+			}
 		})
 	}
 }
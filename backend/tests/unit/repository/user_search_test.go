@@ -0,0 +1,68 @@
+// Package repository_test contains unit tests for repository scopes
+//
+// File: user_search_test.go
+// Description: GORM DryRun tests for UserSearchScope (GET /user/search)
+package repository_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB нь DB-д холбогдолгүйгээр SQL үүсгэх GORM instance
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=x dbname=x sslmode=disable"), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestUserSearchScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		q        string
+		wantLike string
+		wantOr   bool
+	}{
+		{name: "matches all five columns with OR", q: "bold", wantLike: "%bold%", wantOr: true},
+		{name: "trims whitespace", q: "  bold  ", wantLike: "%bold%", wantOr: true},
+		{name: "escapes LIKE wildcards", q: "50%_a", wantLike: `%50\%\_a%`, wantOr: true},
+		{name: "empty query adds no filter", q: "   ", wantOr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var users []domain.User
+			stmt := dryRunDB(t).Model(&domain.User{}).Scopes(repository.UserSearchScope(tt.q)).Find(&users).Statement
+			sql := stmt.SQL.String()
+
+			if !tt.wantOr {
+				assert.NotContains(t, sql, "ILIKE")
+				assert.Empty(t, stmt.Vars)
+				return
+			}
+
+			columns := []string{"first_name", "last_name", "email", "reg_no", "phone_no"}
+			for i, col := range columns {
+				assert.Contains(t, sql, fmt.Sprintf("users.%s ILIKE $%d ESCAPE", col, i+1))
+			}
+			assert.Equal(t, len(columns)-1, strings.Count(sql, " OR "))
+			require.Len(t, stmt.Vars, len(columns))
+			for _, v := range stmt.Vars {
+				assert.Equal(t, tt.wantLike, v)
+			}
+		})
+	}
+}
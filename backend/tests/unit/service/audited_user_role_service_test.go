@@ -0,0 +1,108 @@
+// Package service provides implementation for service
+//
+// File: audited_user_role_service_test.go
+// Description: Unit tests for AuditedUserRoleService (role change history)
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/service"
+	"templatev25/tests/mocks"
+
+	"git.gerege.mn/backend-packages/ctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newAuditedUserRoleService(repo *mocks.UserRoleRepository, audit *mockAuditRepository) *service.AuditedUserRoleService {
+	return service.NewAuditedUserRoleService(service.NewUserRoleService(repo), repo, audit, zap.NewNop())
+}
+
+func TestAuditedUserRoleService_AssignByRole(t *testing.T) {
+	repo := &mocks.UserRoleRepository{}
+	audit := &mockAuditRepository{}
+	repo.On("AddUsersToRole", mock.Anything, 7, []int{1, 2}).Return(nil)
+
+	var recorded []domain.UserRoleHistory
+	repo.On("CreateHistory", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { recorded = args.Get(1).([]domain.UserRoleHistory) }).
+		Return(nil)
+
+	var audits []*domain.SecurityAuditTrail
+	audit.On("CreateAuditTrail", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { audits = append(audits, args.Get(1).(*domain.SecurityAuditTrail)) }).
+		Return(nil)
+
+	c := ctx.WithValue(context.Background(), ctx.KeyUserID, 99)
+	err := newAuditedUserRoleService(repo, audit).AssignByRole(c, dto.UserRoleAssignByRole{RoleID: 7, UserIDs: []int{1, 2}})
+	require.NoError(t, err)
+
+	require.Len(t, recorded, 2)
+	for i, uid := range []int{1, 2} {
+		assert.Equal(t, uid, recorded[i].UserID)
+		assert.Equal(t, 7, recorded[i].RoleID)
+		assert.Equal(t, domain.UserRoleActionAssigned, recorded[i].Action)
+		require.NotNil(t, recorded[i].ChangedBy)
+		assert.Equal(t, 99, *recorded[i].ChangedBy)
+		assert.False(t, recorded[i].ChangedAt.IsZero())
+	}
+
+	require.Len(t, audits, 2)
+	assert.Equal(t, "user_role", audits[0].TargetType)
+	assert.Equal(t, "1", audits[0].TargetID)
+	assert.Equal(t, "ROLE_ASSIGNED", audits[0].Action)
+	assert.Equal(t, "null", audits[0].OldValue)
+	assert.JSONEq(t, `{"user_id":1,"role_id":7}`, audits[0].NewValue)
+}
+
+func TestAuditedUserRoleService_Remove(t *testing.T) {
+	repo := &mocks.UserRoleRepository{}
+	audit := &mockAuditRepository{}
+	repo.On("Remove", mock.Anything, 3, 7).Return(nil)
+	repo.On("CreateHistory", mock.Anything, mock.MatchedBy(func(h []domain.UserRoleHistory) bool {
+		return len(h) == 1 && h[0].Action == domain.UserRoleActionRevoked && h[0].ChangedBy == nil
+	})).Return(nil)
+	audit.On("CreateAuditTrail", mock.Anything, mock.MatchedBy(func(a *domain.SecurityAuditTrail) bool {
+		return a.Action == "ROLE_REVOKED" && a.NewValue == "null" && a.OldValue == `{"role_id":7,"user_id":3}`
+	})).Return(nil)
+
+	// Actor-гүй context (system) → ChangedBy nil
+	err := newAuditedUserRoleService(repo, audit).Remove(context.Background(), dto.UserRoleRemoveDto{UserID: 3, RoleID: 7})
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
+
+func TestAuditedUserRoleService_FailedChangeIsNotRecorded(t *testing.T) {
+	repo := &mocks.UserRoleRepository{}
+	audit := &mockAuditRepository{}
+	dbErr := errors.New("db down")
+	repo.On("AddRolesToUser", mock.Anything, 3, []int{7}).Return(dbErr)
+
+	err := newAuditedUserRoleService(repo, audit).AssignByUser(context.Background(), dto.UserRoleAssignByUser{UserID: 3, RoleIDs: []int{7}})
+
+	assert.ErrorIs(t, err, dbErr)
+	repo.AssertNotCalled(t, "CreateHistory", mock.Anything, mock.Anything)
+	audit.AssertNotCalled(t, "CreateAuditTrail", mock.Anything, mock.Anything)
+}
+
+func TestAuditedUserRoleService_HistoryFailureDoesNotFailRequest(t *testing.T) {
+	repo := &mocks.UserRoleRepository{}
+	audit := &mockAuditRepository{}
+	repo.On("AddRolesToUser", mock.Anything, 3, []int{7}).Return(nil)
+	repo.On("CreateHistory", mock.Anything, mock.Anything).Return(errors.New("insert failed"))
+	audit.On("CreateAuditTrail", mock.Anything, mock.Anything).Return(errors.New("insert failed"))
+
+	err := newAuditedUserRoleService(repo, audit).AssignByUser(context.Background(), dto.UserRoleAssignByUser{UserID: 3, RoleIDs: []int{7}})
+
+	assert.NoError(t, err)
+	audit.AssertNumberOfCalls(t, "CreateAuditTrail", 1)
+}
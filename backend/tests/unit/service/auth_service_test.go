@@ -0,0 +1,874 @@
+// Package service provides implementation for service
+//
+// File: auth_service_test.go
+// Description: Unit tests for account lockout schedule, permanent lock (suspend), lock email notification, password history, session revocation and impersonation
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"templatev25/internal/auth"
+	"templatev25/internal/config"
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/argon2"
+)
+
+// ============================================================
+// MOCKS
+// ============================================================
+
+// mockLockAuthRepository covers the AuthRepository methods used by the lockout flow
+type mockLockAuthRepository struct {
+	repository.AuthRepository
+	mock.Mock
+
+	audits []*domain.SecurityAuditTrail
+}
+
+func (m *mockLockAuthRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *mockLockAuthRepository) GetUserByID(ctx context.Context, userID int) (*domain.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *mockLockAuthRepository) GetCredentialByUserID(ctx context.Context, userID int) (*domain.UserCredential, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserCredential), args.Error(1)
+}
+
+func (m *mockLockAuthRepository) IncrementFailedAttempts(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockLockAuthRepository) LockAccount(ctx context.Context, userID int, until time.Time) (bool, error) {
+	args := m.Called(ctx, userID, until)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockLockAuthRepository) UpdateUserStatus(ctx context.Context, userID int, status string, reason string, changedBy int) error {
+	args := m.Called(ctx, userID, status, reason, changedBy)
+	return args.Error(0)
+}
+
+func (m *mockLockAuthRepository) UnlockAccount(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockLockAuthRepository) RevokeAllUserSessions(ctx context.Context, userID int, reason string) error {
+	args := m.Called(ctx, userID, reason)
+	return args.Error(0)
+}
+
+func (m *mockLockAuthRepository) CreateLoginHistory(ctx context.Context, history *domain.LoginHistory) error {
+	return nil
+}
+
+func (m *mockLockAuthRepository) CreateAuditTrail(ctx context.Context, audit *domain.SecurityAuditTrail) error {
+	m.audits = append(m.audits, audit)
+	return nil
+}
+
+func (m *mockLockAuthRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	return nil
+}
+
+// mockSessionAuthRepository covers the AuthRepository methods used by session revocation
+type mockSessionAuthRepository struct {
+	repository.AuthRepository
+	mock.Mock
+}
+
+func (m *mockSessionAuthRepository) RevokeOtherUserSessions(ctx context.Context, userID int, exceptSessionID string, reason string) error {
+	args := m.Called(ctx, userID, exceptSessionID, reason)
+	return args.Error(0)
+}
+
+func (m *mockSessionAuthRepository) CreateAuditTrail(ctx context.Context, audit *domain.SecurityAuditTrail) error {
+	args := m.Called(ctx, audit)
+	return args.Error(0)
+}
+
+// mockSessionStore covers the SessionStore methods used by revocation, impersonation and refresh
+type mockSessionStore struct {
+	service.SessionStore
+	mock.Mock
+}
+
+func (m *mockSessionStore) GetUserSessions(ctx context.Context, userID int) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockSessionStore) Delete(ctx context.Context, sessionID string) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *mockSessionStore) Create(ctx context.Context, session *service.SessionData) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *mockSessionStore) Get(ctx context.Context, sessionID string) (*service.SessionData, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.SessionData), args.Error(1)
+}
+
+func (m *mockSessionStore) Refresh(ctx context.Context, sessionID string, newExpiry time.Time) error {
+	args := m.Called(ctx, sessionID, newExpiry)
+	return args.Error(0)
+}
+
+// ============================================================
+// HELPERS
+// ============================================================
+
+func newLockTestService(repo *mockLockAuthRepository, mailer *mockMailer) *service.AuthService {
+	cfg := &config.LocalAuthConfig{
+		LockoutThreshold: 3,
+		LockoutDuration:  15 * time.Minute,
+	}
+	svc := service.NewAuthService(repo, nil, cfg, zap.NewNop())
+	svc.SetMailer(mailer)
+	return svc
+}
+
+// ============================================================
+// TEST NOTIFY ACCOUNT LOCKED
+// ============================================================
+
+func TestAuthService_NotifyAccountLocked(t *testing.T) {
+	ctx := context.Background()
+	lockedUntil := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("success - sends one email to the user", func(t *testing.T) {
+		repo := new(mockLockAuthRepository)
+		mailer := new(mockMailer)
+		svc := newLockTestService(repo, mailer)
+
+		repo.On("GetUserByID", ctx, 7).Return(&domain.User{Id: 7, Email: "user@example.com"}, nil)
+		mailer.On("Send", "user@example.com", "Account locked", mock.AnythingOfType("string")).Return(nil)
+
+		err := svc.NotifyAccountLocked(ctx, 7, lockedUntil)
+
+		require.NoError(t, err)
+		mailer.AssertNumberOfCalls(t, "Send", 1)
+		body := mailer.Calls[0].Arguments.String(2)
+		assert.Contains(t, body, lockedUntil.Format(time.RFC1123))
+	})
+
+	t.Run("success - also emails admin recipients", func(t *testing.T) {
+		repo := new(mockLockAuthRepository)
+		mailer := new(mockMailer)
+		svc := service.NewAuthService(repo, nil, &config.LocalAuthConfig{
+			LockNotifyEmails: []string{"admin@example.com", "security@example.com"},
+		}, zap.NewNop())
+		svc.SetMailer(mailer)
+
+		repo.On("GetUserByID", ctx, 7).Return(&domain.User{Id: 7, Email: "user@example.com"}, nil)
+		mailer.On("Send", mock.Anything, mock.Anything, mock.AnythingOfType("string")).Return(nil)
+
+		err := svc.NotifyAccountLocked(ctx, 7, lockedUntil)
+
+		require.NoError(t, err)
+		mailer.AssertNumberOfCalls(t, "Send", 3)
+		mailer.AssertCalled(t, "Send", "user@example.com", "Account locked", mock.AnythingOfType("string"))
+		mailer.AssertCalled(t, "Send", "admin@example.com", "User account locked", mock.AnythingOfType("string"))
+		mailer.AssertCalled(t, "Send", "security@example.com", "User account locked", mock.AnythingOfType("string"))
+		assert.Contains(t, mailer.Calls[1].Arguments.String(2), "user@example.com")
+	})
+
+	t.Run("error - user not found", func(t *testing.T) {
+		repo := new(mockLockAuthRepository)
+		mailer := new(mockMailer)
+		svc := newLockTestService(repo, mailer)
+
+		repo.On("GetUserByID", ctx, 7).Return(nil, domain.ErrNotFound)
+
+		err := svc.NotifyAccountLocked(ctx, 7, lockedUntil)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - mailer failure", func(t *testing.T) {
+		repo := new(mockLockAuthRepository)
+		mailer := new(mockMailer)
+		svc := newLockTestService(repo, mailer)
+
+		repo.On("GetUserByID", ctx, 7).Return(&domain.User{Id: 7, Email: "user@example.com"}, nil)
+		mailer.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+
+		err := svc.NotifyAccountLocked(ctx, 7, lockedUntil)
+
+		assert.Error(t, err)
+	})
+}
+
+// ============================================================
+// TEST LOGIN LOCKOUT
+// ============================================================
+
+func TestAuthService_Login_LockoutSendsEmailOnce(t *testing.T) {
+	repo := new(mockLockAuthRepository)
+	mailer := new(mockMailer)
+	svc := newLockTestService(repo, mailer)
+
+	user := &domain.User{Id: 7, Email: "user@example.com", Status: string(domain.UserStatusActive)}
+	repo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil)
+	repo.On("GetUserByID", mock.Anything, 7).Return(user, nil)
+	repo.On("GetCredentialByUserID", mock.Anything, 7).Return(&domain.UserCredential{UserID: 7, FailedLoginAttempts: 3}, nil)
+	repo.On("IncrementFailedAttempts", mock.Anything, 7).Return(nil)
+	repo.On("LockAccount", mock.Anything, 7, mock.AnythingOfType("time.Time")).Return(true, nil)
+
+	sent := make(chan struct{}, 2)
+	mailer.On("Send", "user@example.com", "Account locked", mock.AnythingOfType("string")).
+		Run(func(mock.Arguments) { sent <- struct{}{} }).
+		Return(nil)
+
+	// Request context-ийг цуцалсан ч email илгээгдэх ёстой
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := svc.Login(ctx, service.LoginRequest{Email: "user@example.com", Password: "wrong"})
+	cancel()
+
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+	repo.AssertCalled(t, "LockAccount", mock.Anything, 7, mock.AnythingOfType("time.Time"))
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("account locked email was not sent")
+	}
+	// Нэмэлт илгээлт байхгүйг шалгана
+	select {
+	case <-sent:
+		t.Fatal("account locked email sent more than once")
+	case <-time.After(50 * time.Millisecond):
+	}
+	mailer.AssertNumberOfCalls(t, "Send", 1)
+}
+
+func TestAuthService_Login_AlreadyLockedSendsNoEmail(t *testing.T) {
+	repo := new(mockLockAuthRepository)
+	mailer := new(mockMailer)
+	svc := newLockTestService(repo, mailer)
+
+	user := &domain.User{Id: 7, Email: "user@example.com", Status: string(domain.UserStatusActive)}
+	repo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil)
+	repo.On("GetCredentialByUserID", mock.Anything, 7).Return(&domain.UserCredential{UserID: 7, FailedLoginAttempts: 3}, nil)
+	repo.On("IncrementFailedAttempts", mock.Anything, 7).Return(nil)
+	// Зэрэг ирсэн өөр оролдлого аль хэдийн түгжсэн
+	repo.On("LockAccount", mock.Anything, 7, mock.AnythingOfType("time.Time")).Return(false, nil)
+
+	_, err := svc.Login(context.Background(), service.LoginRequest{Email: "user@example.com", Password: "wrong"})
+
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+	time.Sleep(50 * time.Millisecond)
+	mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	assert.Empty(t, repo.audits)
+}
+
+func TestAuthService_Login_BelowThresholdSendsNoEmail(t *testing.T) {
+	repo := new(mockLockAuthRepository)
+	mailer := new(mockMailer)
+	svc := newLockTestService(repo, mailer)
+
+	user := &domain.User{Id: 7, Email: "user@example.com", Status: string(domain.UserStatusActive)}
+	repo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil)
+	repo.On("GetCredentialByUserID", mock.Anything, 7).Return(&domain.UserCredential{UserID: 7, FailedLoginAttempts: 1}, nil)
+	repo.On("IncrementFailedAttempts", mock.Anything, 7).Return(nil)
+
+	_, err := svc.Login(context.Background(), service.LoginRequest{Email: "user@example.com", Password: "wrong"})
+
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+	repo.AssertNotCalled(t, "LockAccount", mock.Anything, mock.Anything, mock.Anything)
+	mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ============================================================
+// TEST LOCK DURATION
+// ============================================================
+
+func TestLockDuration(t *testing.T) {
+	schedule := config.DefaultLockSchedule()
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 0},
+		{attempts: 2, want: 0},
+		{attempts: 3, want: time.Minute},
+		{attempts: 4, want: time.Minute},
+		{attempts: 5, want: 15 * time.Minute},
+		{attempts: 6, want: 15 * time.Minute},
+		{attempts: 7, want: 2 * time.Hour},
+		{attempts: 9, want: 2 * time.Hour},
+		{attempts: 10, want: config.LockIndefinite},
+		{attempts: 25, want: config.LockIndefinite},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, service.LockDuration(tt.attempts, schedule), "attempts=%d", tt.attempts)
+	}
+}
+
+func TestLockDuration_EmptyAndUnsortedSchedule(t *testing.T) {
+	assert.Equal(t, time.Duration(0), service.LockDuration(100, nil))
+
+	unsorted := []config.LockThreshold{
+		{Attempts: 7, Duration: 2 * time.Hour},
+		{Attempts: 3, Duration: time.Minute},
+	}
+	assert.Equal(t, time.Minute, service.LockDuration(4, unsorted))
+	assert.Equal(t, 2*time.Hour, service.LockDuration(8, unsorted))
+}
+
+func TestAuthService_Login_IndefiniteLock(t *testing.T) {
+	repo := new(mockLockAuthRepository)
+	mailer := new(mockMailer)
+	svc := service.NewAuthService(repo, nil, &config.LocalAuthConfig{LockSchedule: config.DefaultLockSchedule()}, zap.NewNop())
+	svc.SetMailer(mailer)
+
+	user := &domain.User{Id: 7, Email: "user@example.com", Status: string(domain.UserStatusActive)}
+	repo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil)
+	repo.On("GetUserByID", mock.Anything, 7).Return(user, nil)
+	repo.On("GetCredentialByUserID", mock.Anything, 7).Return(&domain.UserCredential{UserID: 7, FailedLoginAttempts: 10}, nil)
+	repo.On("IncrementFailedAttempts", mock.Anything, 7).Return(nil)
+
+	var lockedUntil time.Time
+	repo.On("LockAccount", mock.Anything, 7, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { lockedUntil = args.Get(2).(time.Time) }).
+		Return(true, nil)
+
+	sent := make(chan struct{}, 1)
+	mailer.On("Send", "user@example.com", "Account locked", mock.AnythingOfType("string")).
+		Run(func(mock.Arguments) { sent <- struct{}{} }).
+		Return(nil)
+
+	_, err := svc.Login(context.Background(), service.LoginRequest{Email: "user@example.com", Password: "wrong"})
+
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+	// Админ тайлах хүртэл түгжигдэнэ
+	assert.Equal(t, 9999, lockedUntil.Year())
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("account locked email was not sent")
+	}
+	assert.Contains(t, mailer.Calls[0].Arguments.String(2), "administrator")
+}
+
+// ============================================================
+// TEST PERMANENT LOCK (SUSPEND)
+// ============================================================
+
+func TestAuthService_Login_PermanentLockThresholds(t *testing.T) {
+	tests := []struct {
+		name          string
+		permanentLock int
+		attempts      int // IncrementFailedAttempts-ийн дараах тоо
+		wantSuspend   bool
+		wantLock      bool
+	}{
+		{name: "below lock schedule", permanentLock: 10, attempts: 2},
+		{name: "time lock below permanent threshold", permanentLock: 10, attempts: 9, wantLock: true},
+		{name: "suspend at threshold", permanentLock: 10, attempts: 10, wantSuspend: true},
+		{name: "suspend above threshold", permanentLock: 10, attempts: 11, wantSuspend: true},
+		{name: "custom low threshold", permanentLock: 4, attempts: 4, wantSuspend: true},
+		{name: "disabled - schedule locks instead", permanentLock: 0, attempts: 10, wantLock: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockLockAuthRepository)
+			store := new(mockResetSessionStore)
+			mailer := new(mockMailer)
+			cfg := &config.LocalAuthConfig{LockSchedule: config.DefaultLockSchedule(), PermanentLockAfter: tt.permanentLock}
+			svc := service.NewAuthService(repo, store, cfg, zap.NewNop())
+			svc.SetMailer(mailer)
+
+			user := &domain.User{Id: 7, Email: "user@example.com", Status: string(domain.UserStatusActive)}
+			repo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil)
+			repo.On("GetUserByID", mock.Anything, 7).Return(user, nil)
+			repo.On("GetCredentialByUserID", mock.Anything, 7).Return(&domain.UserCredential{UserID: 7, FailedLoginAttempts: tt.attempts}, nil)
+			repo.On("IncrementFailedAttempts", mock.Anything, 7).Return(nil)
+			repo.On("LockAccount", mock.Anything, 7, mock.AnythingOfType("time.Time")).Return(true, nil)
+			repo.On("UpdateUserStatus", mock.Anything, 7, "suspended", "too many failed logins", 0).Return(nil)
+			repo.On("RevokeAllUserSessions", mock.Anything, 7, mock.Anything).Return(nil)
+			store.On("DeleteAllUserSessions", mock.Anything, 7).Return(nil)
+			mailer.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			_, err := svc.Login(context.Background(), service.LoginRequest{Email: "user@example.com", Password: "wrong"})
+			assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+
+			if tt.wantSuspend {
+				repo.AssertCalled(t, "UpdateUserStatus", mock.Anything, 7, "suspended", "too many failed logins", 0)
+				repo.AssertCalled(t, "RevokeAllUserSessions", mock.Anything, 7, mock.Anything)
+				store.AssertCalled(t, "DeleteAllUserSessions", mock.Anything, 7)
+			} else {
+				repo.AssertNotCalled(t, "UpdateUserStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.wantLock {
+				repo.AssertCalled(t, "LockAccount", mock.Anything, 7, mock.AnythingOfType("time.Time"))
+			} else {
+				repo.AssertNotCalled(t, "LockAccount", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAuthService_Login_InactiveStatusRevealedOnlyAfterPassword(t *testing.T) {
+	const password = "Correct1!"
+
+	tests := []struct {
+		name     string
+		status   domain.UserStatus
+		password string
+		wantErr  error
+	}{
+		{name: "suspended, wrong password", status: domain.UserStatusSuspended, password: "wrong", wantErr: service.ErrInvalidCredentials},
+		{name: "suspended, correct password", status: domain.UserStatusSuspended, password: password, wantErr: service.ErrAccountSuspended},
+		{name: "not active, wrong password", status: domain.UserStatusLocked, password: "wrong", wantErr: service.ErrInvalidCredentials},
+		{name: "not active, correct password", status: domain.UserStatusLocked, password: password, wantErr: service.ErrAccountNotActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockLockAuthRepository)
+			svc := service.NewAuthService(repo, nil, &config.LocalAuthConfig{PermanentLockAfter: 10}, zap.NewNop())
+
+			user := &domain.User{Id: 7, Email: "user@example.com", Status: string(tt.status)}
+			repo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil)
+			repo.On("GetCredentialByUserID", mock.Anything, 7).Return(&domain.UserCredential{UserID: 7, PasswordHash: testPasswordHash(password)}, nil)
+
+			_, err := svc.Login(context.Background(), service.LoginRequest{Email: "user@example.com", Password: tt.password})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			// Идэвхтэй бус бүртгэлд түгжээний тоолуур ажиллахгүй
+			repo.AssertNotCalled(t, "IncrementFailedAttempts", mock.Anything, mock.Anything)
+			repo.AssertNotCalled(t, "UpdateUserStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthService_UpdateUserStatus_ReactivationResetsFailedAttempts(t *testing.T) {
+	repo := new(mockLockAuthRepository)
+	svc := service.NewAuthService(repo, nil, &config.LocalAuthConfig{PermanentLockAfter: 10}, zap.NewNop())
+
+	repo.On("GetUserByID", mock.Anything, 7).Return(&domain.User{Id: 7, Status: string(domain.UserStatusSuspended)}, nil)
+	repo.On("UpdateUserStatus", mock.Anything, 7, "active", "reviewed", 1).Return(nil)
+	repo.On("UnlockAccount", mock.Anything, 7).Return(nil)
+
+	err := svc.UpdateUserStatus(context.Background(), 7, "active", "reviewed", 1, "127.0.0.1", "test")
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	require.Len(t, repo.audits, 1)
+	assert.JSONEq(t, `{"status":"suspended"}`, repo.audits[0].OldValue)
+}
+
+func TestAuthService_UpdateUserStatus_SuspendKeepsFailedAttempts(t *testing.T) {
+	repo := new(mockLockAuthRepository)
+	store := new(mockResetSessionStore)
+	svc := service.NewAuthService(repo, store, &config.LocalAuthConfig{}, zap.NewNop())
+
+	repo.On("GetUserByID", mock.Anything, 7).Return(&domain.User{Id: 7, Status: string(domain.UserStatusActive)}, nil)
+	repo.On("UpdateUserStatus", mock.Anything, 7, "suspended", "abuse", 1).Return(nil)
+	repo.On("RevokeAllUserSessions", mock.Anything, 7, "status change: suspended").Return(nil)
+	store.On("DeleteAllUserSessions", mock.Anything, 7).Return(nil)
+
+	err := svc.UpdateUserStatus(context.Background(), 7, "suspended", "abuse", 1, "127.0.0.1", "test")
+
+	require.NoError(t, err)
+	repo.AssertNotCalled(t, "UnlockAccount", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_IsAccountSuspended(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		user    *domain.User
+		err     error
+		want    bool
+		wantErr bool
+	}{
+		{name: "suspended", user: &domain.User{Id: 7, Status: string(domain.UserStatusSuspended)}, want: true},
+		{name: "active", user: &domain.User{Id: 7, Status: string(domain.UserStatusActive)}},
+		{name: "locked is not suspended", user: &domain.User{Id: 7, Status: string(domain.UserStatusLocked)}},
+		{name: "user not found", err: domain.ErrNotFound, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockLockAuthRepository)
+			svc := service.NewAuthService(repo, nil, &config.LocalAuthConfig{}, zap.NewNop())
+			if tt.user != nil {
+				repo.On("GetUserByID", ctx, 7).Return(tt.user, nil)
+			} else {
+				repo.On("GetUserByID", ctx, 7).Return(nil, tt.err)
+			}
+
+			got, err := svc.IsAccountSuspended(ctx, 7)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// ============================================================
+// TEST CHANGE PASSWORD HISTORY
+// ============================================================
+
+// testPasswordHash нь service-ийн argon2id форматаар хямд параметртэй hash үүсгэнэ
+func testPasswordHash(password string) string {
+	salt := []byte("0123456789abcdef")
+	hash := argon2.IDKey([]byte(password), salt, 1, 1024, 1, 32)
+	return fmt.Sprintf("$argon2id$v=%d$m=1024,t=1,p=1$%s$%s", argon2.Version,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(hash))
+}
+
+func TestAuthService_ChangePassword_History(t *testing.T) {
+	ctx := context.Background()
+	const current = "Current1!"
+
+	// Хамгийн сүүлийнх эхэндээ (GetPasswordHistory created_date DESC)
+	history := make([]domain.PasswordHistory, 5)
+	for i := range history {
+		history[i] = domain.PasswordHistory{UserID: 7, PasswordHash: testPasswordHash(fmt.Sprintf("Previous%d!", i+1))}
+	}
+
+	tests := []struct {
+		name    string
+		history []domain.PasswordHistory
+		newPass string
+		wantErr error
+	}{
+		{name: "success - empty history", history: []domain.PasswordHistory{}, newPass: "Brand-new1!"},
+		{name: "error - matches last entry", history: history, newPass: "Previous1!", wantErr: service.ErrPasswordReused},
+		{name: "error - matches 5th entry", history: history, newPass: "Previous5!", wantErr: service.ErrPasswordReused},
+		{name: "success - matches none of 5", history: history, newPass: "Previous6!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockResetAuthRepository)
+			cfg := &config.LocalAuthConfig{PasswordMinLength: 8, PasswordHistoryCount: 5}
+			svc := service.NewAuthService(repo, nil, cfg, zap.NewNop())
+
+			repo.On("GetCredentialByUserID", ctx, 7).Return(&domain.UserCredential{UserID: 7, PasswordHash: testPasswordHash(current)}, nil)
+			repo.On("GetPasswordHistory", ctx, 7, 5).Return(tt.history, nil)
+			repo.On("CreatePasswordHistory", ctx, mock.Anything).Return(nil)
+			repo.On("UpdateCredential", ctx, mock.Anything).Return(nil)
+
+			err := svc.ChangePassword(ctx, 7, current, tt.newPass, "127.0.0.1", "test")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "UpdateCredential", mock.Anything, mock.Anything)
+				repo.AssertNotCalled(t, "CreatePasswordHistory", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			repo.AssertCalled(t, "UpdateCredential", ctx, mock.Anything)
+			repo.AssertCalled(t, "GetPasswordHistory", ctx, 7, 5)
+		})
+	}
+}
+
+func TestAuthService_ChangePassword_Policy(t *testing.T) {
+	ctx := context.Background()
+	const current = "Current1!"
+
+	tests := []struct {
+		name    string
+		newPass string
+		want    []auth.PolicyViolation
+	}{
+		{name: "success - meets policy", newPass: "Brand-new1"},
+		{name: "error - lowercase only", newPass: "brandnewpass", want: []auth.PolicyViolation{auth.ViolationUppercase, auth.ViolationDigit}},
+		{name: "error - short and no digit", newPass: "Short", want: []auth.PolicyViolation{auth.ViolationMinLength, auth.ViolationDigit}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockResetAuthRepository)
+			cfg := &config.LocalAuthConfig{PasswordMinLength: 8, PasswordRequireUppercase: true, PasswordRequireLowercase: true, PasswordRequireDigit: true}
+			svc := service.NewAuthService(repo, nil, cfg, zap.NewNop())
+
+			repo.On("GetCredentialByUserID", ctx, 7).Return(&domain.UserCredential{UserID: 7, PasswordHash: testPasswordHash(current)}, nil)
+			repo.On("GetPasswordHistory", ctx, 7, 0).Return([]domain.PasswordHistory{}, nil)
+			repo.On("CreatePasswordHistory", ctx, mock.Anything).Return(nil)
+			repo.On("UpdateCredential", ctx, mock.Anything).Return(nil)
+
+			err := svc.ChangePassword(ctx, 7, current, tt.newPass, "127.0.0.1", "test")
+
+			if tt.want != nil {
+				var weak *service.PasswordTooWeakError
+				require.ErrorAs(t, err, &weak)
+				assert.Equal(t, tt.want, weak.Violations)
+				assert.ErrorIs(t, err, service.ErrPasswordTooWeak)
+				repo.AssertNotCalled(t, "UpdateCredential", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			repo.AssertCalled(t, "UpdateCredential", ctx, mock.Anything)
+		})
+	}
+}
+
+// ============================================================
+// TEST REVOKE ALL SESSIONS
+// ============================================================
+
+func TestAuthService_RevokeAllSessions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - current session is preserved", func(t *testing.T) {
+		repo := new(mockSessionAuthRepository)
+		store := new(mockSessionStore)
+		svc := service.NewAuthService(repo, store, &config.LocalAuthConfig{}, zap.NewNop())
+
+		store.On("GetUserSessions", ctx, 7).Return([]string{"s-1", "current", "s-2"}, nil)
+		store.On("Delete", ctx, "s-1").Return(nil)
+		store.On("Delete", ctx, "s-2").Return(nil)
+		repo.On("RevokeOtherUserSessions", ctx, 7, "current", "logout others").Return(nil)
+		repo.On("CreateAuditTrail", ctx, mock.MatchedBy(func(a *domain.SecurityAuditTrail) bool {
+			return a.Action == string(domain.AuditActionLogoutOthers) && *a.UserID == 7 &&
+				a.NewValue == `{"kept_session":"current","revoked":2}` && a.IPAddress == "127.0.0.1"
+		})).Return(nil)
+
+		err := svc.RevokeAllSessions(ctx, 7, "current", "127.0.0.1", "test")
+
+		require.NoError(t, err)
+		store.AssertExpectations(t)
+		store.AssertNotCalled(t, "Delete", ctx, "current")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success - only current session", func(t *testing.T) {
+		repo := new(mockSessionAuthRepository)
+		store := new(mockSessionStore)
+		svc := service.NewAuthService(repo, store, &config.LocalAuthConfig{}, zap.NewNop())
+
+		store.On("GetUserSessions", ctx, 7).Return([]string{"current"}, nil)
+		repo.On("RevokeOtherUserSessions", ctx, 7, "current", "logout others").Return(nil)
+		repo.On("CreateAuditTrail", ctx, mock.Anything).Return(nil)
+
+		require.NoError(t, svc.RevokeAllSessions(ctx, 7, "current", "127.0.0.1", "test"))
+		store.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - session store fails", func(t *testing.T) {
+		repo := new(mockSessionAuthRepository)
+		store := new(mockSessionStore)
+		svc := service.NewAuthService(repo, store, &config.LocalAuthConfig{}, zap.NewNop())
+
+		store.On("GetUserSessions", ctx, 7).Return([]string{"s-1", "current"}, nil)
+		store.On("Delete", ctx, "s-1").Return(errors.New("redis down"))
+
+		err := svc.RevokeAllSessions(ctx, 7, "current", "127.0.0.1", "test")
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "RevokeOtherUserSessions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "CreateAuditTrail", mock.Anything, mock.Anything)
+	})
+}
+
+// ============================================================
+// TEST IMPERSONATE
+// ============================================================
+
+// staticRoleChecker нь user ID → role кодын auth.RoleChecker
+type staticRoleChecker map[int][]string
+
+func (r staticRoleChecker) GetRoleCodes(ctx context.Context, userID int) ([]string, error) {
+	return r[userID], nil
+}
+
+func TestAuthService_Impersonate(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.LocalAuthConfig{SessionTTL: 24 * time.Hour}
+
+	t.Run("session is tagged and expires after 30 minutes", func(t *testing.T) {
+		repo := new(mockLockAuthRepository)
+		store := new(mockSessionStore)
+		svc := service.NewAuthService(repo, store, cfg, zap.NewNop())
+		svc.SetRoleChecker(staticRoleChecker{1: {"ADMIN"}, 42: {"USER"}})
+
+		repo.On("GetUserByID", ctx, 42).Return(&domain.User{Id: 42, Email: "u@test.mn", Status: string(domain.UserStatusActive)}, nil)
+		store.On("Create", ctx, mock.Anything).Return(nil)
+
+		before := time.Now()
+		session, err := svc.Impersonate(ctx, 1, 42, "support ticket #12", "127.0.0.1", "test")
+
+		require.NoError(t, err)
+		assert.Equal(t, 42, session.UserID)
+		assert.Equal(t, 1, session.ImpersonatedBy)
+		assert.True(t, session.IsImpersonated())
+		assert.WithinDuration(t, before.Add(service.ImpersonationSessionTTL), session.ExpiresAt, time.Second)
+		assert.Equal(t, 30*time.Minute, service.ImpersonationSessionTTL)
+
+		require.Len(t, repo.audits, 1)
+		audit := repo.audits[0]
+		assert.Equal(t, string(domain.AuditActionImpersonate), audit.Action)
+		assert.Equal(t, 1, *audit.UserID)
+		assert.Equal(t, "42", audit.TargetID)
+		assert.Contains(t, audit.NewValue, `"reason":"support ticket #12"`)
+	})
+
+	t.Run("target with equal or higher role is rejected", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			roles staticRoleChecker
+		}{
+			{"admin to admin", staticRoleChecker{1: {"ADMIN"}, 42: {"ADMIN", "USER"}}},
+			{"admin to super admin", staticRoleChecker{1: {"ADMIN"}, 42: {"SUPER_ADMIN"}}},
+			{"super admin to super admin", staticRoleChecker{1: {"SUPER_ADMIN"}, 42: {"SUPER_ADMIN"}}},
+			{"no role checker", nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				repo := new(mockLockAuthRepository)
+				store := new(mockSessionStore)
+				svc := service.NewAuthService(repo, store, cfg, zap.NewNop())
+				if tt.roles != nil {
+					svc.SetRoleChecker(tt.roles)
+				}
+				repo.On("GetUserByID", ctx, 42).Return(&domain.User{Id: 42, Status: string(domain.UserStatusActive)}, nil)
+
+				_, err := svc.Impersonate(ctx, 1, 42, "x", "127.0.0.1", "test")
+
+				assert.ErrorIs(t, err, service.ErrImpersonationDenied)
+				store.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				assert.Empty(t, repo.audits)
+			})
+		}
+	})
+
+	t.Run("super admin can impersonate an admin", func(t *testing.T) {
+		repo := new(mockLockAuthRepository)
+		store := new(mockSessionStore)
+		svc := service.NewAuthService(repo, store, cfg, zap.NewNop())
+		svc.SetRoleChecker(staticRoleChecker{1: {"SUPER_ADMIN"}, 42: {"ADMIN"}})
+		repo.On("GetUserByID", ctx, 42).Return(&domain.User{Id: 42, Status: string(domain.UserStatusActive)}, nil)
+		store.On("Create", ctx, mock.Anything).Return(nil)
+
+		_, err := svc.Impersonate(ctx, 1, 42, "x", "127.0.0.1", "test")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("self impersonation is rejected", func(t *testing.T) {
+		repo := new(mockLockAuthRepository)
+		store := new(mockSessionStore)
+		svc := service.NewAuthService(repo, store, cfg, zap.NewNop())
+
+		_, err := svc.Impersonate(ctx, 1, 1, "x", "127.0.0.1", "test")
+
+		assert.ErrorIs(t, err, service.ErrSelfImpersonation)
+		store.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("target errors", func(t *testing.T) {
+		tests := []struct {
+			name string
+			user *domain.User
+			err  error
+			want error
+		}{
+			{"not found", nil, domain.ErrNotFound, service.ErrUserNotFound},
+			{"suspended", &domain.User{Id: 42, Status: string(domain.UserStatusSuspended)}, nil, service.ErrAccountSuspended},
+			{"not active", &domain.User{Id: 42, Status: string(domain.UserStatusLocked)}, nil, service.ErrAccountNotActive},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				repo := new(mockLockAuthRepository)
+				store := new(mockSessionStore)
+				svc := service.NewAuthService(repo, store, cfg, zap.NewNop())
+				if tt.user == nil {
+					repo.On("GetUserByID", ctx, 42).Return(nil, tt.err)
+				} else {
+					repo.On("GetUserByID", ctx, 42).Return(tt.user, nil)
+				}
+
+				_, err := svc.Impersonate(ctx, 1, 42, "x", "127.0.0.1", "test")
+
+				assert.ErrorIs(t, err, tt.want)
+				store.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				assert.Empty(t, repo.audits)
+			})
+		}
+	})
+}
+
+func TestAuthService_RefreshSession_Impersonated(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.LocalAuthConfig{SessionTTL: 24 * time.Hour}
+
+	t.Run("impersonation session is not extended", func(t *testing.T) {
+		store := new(mockSessionStore)
+		svc := service.NewAuthService(new(mockLockAuthRepository), store, cfg, zap.NewNop())
+		expiresAt := time.Now().Add(10 * time.Minute)
+		store.On("Get", ctx, "imp").Return(&service.SessionData{SessionID: "imp", UserID: 42, ImpersonatedBy: 1, ExpiresAt: expiresAt}, nil)
+
+		session, err := svc.RefreshSession(ctx, "imp")
+
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, session.ExpiresAt)
+		store.AssertNotCalled(t, "Refresh", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("regular session is extended", func(t *testing.T) {
+		store := new(mockSessionStore)
+		svc := service.NewAuthService(new(mockLockAuthRepository), store, cfg, zap.NewNop())
+		store.On("Get", ctx, "s-1").Return(&service.SessionData{SessionID: "s-1", UserID: 42, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+		store.On("Refresh", ctx, "s-1", mock.Anything).Return(nil)
+
+		session, err := svc.RefreshSession(ctx, "s-1")
+
+		require.NoError(t, err)
+		assert.False(t, session.IsImpersonated())
+		assert.WithinDuration(t, time.Now().Add(cfg.SessionTTL), session.ExpiresAt, time.Second)
+	})
+}
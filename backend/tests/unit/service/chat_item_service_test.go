@@ -7,12 +7,14 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 	"templatev25/internal/service"
 
+	"git.gerege.mn/backend-packages/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
@@ -31,6 +33,14 @@ func (m *mockChatItemRepository) List(ctx context.Context, q dto.ChatItemQuery)
 	return args.Get(0).([]domain.ChatItem), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
 }
 
+func (m *mockChatItemRepository) Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.ChatItem, int64, int, int, error) {
+	args := m.Called(ctx, query, p)
+	if args.Get(0) == nil {
+		return nil, 0, 0, 0, args.Error(4)
+	}
+	return args.Get(0).([]domain.ChatItem), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
+}
+
 func (m *mockChatItemRepository) ByID(ctx context.Context, id int) (domain.ChatItem, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(domain.ChatItem), args.Error(1)
@@ -56,6 +66,24 @@ func (m *mockChatItemRepository) Delete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *mockChatItemRepository) AddTags(ctx context.Context, itemID int, tagIDs []int) error {
+	args := m.Called(ctx, itemID, tagIDs)
+	return args.Error(0)
+}
+
+func (m *mockChatItemRepository) RemoveTag(ctx context.Context, itemID, tagID int) error {
+	args := m.Called(ctx, itemID, tagID)
+	return args.Error(0)
+}
+
+func (m *mockChatItemRepository) GetTags(ctx context.Context, itemID int) ([]domain.Tag, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Tag), args.Error(1)
+}
+
 func TestChatItemService_GetByKey(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -169,6 +197,96 @@ func TestChatItemService_List(t *testing.T) {
 	}
 }
 
+func TestChatItemService_Search(t *testing.T) {
+	ctx := context.Background()
+	p := common.PaginationQuery{Page: 1, Size: 10}
+	items := []domain.ChatItem{{ID: 1, Key: "hello", Answer: "сайн байна уу"}}
+
+	t.Run("repeated search is served from cache", func(t *testing.T) {
+		mockRepo := new(mockChatItemRepository)
+		mockRepo.On("Search", ctx, "сайн", p).Return(items, int64(1), 1, 10, nil).Once()
+		svc := service.NewChatItemService(mockRepo, zap.NewNop())
+
+		for _, q := range []string{"сайн", "  САЙН ", "сайн"} {
+			got, total, _, _, err := svc.Search(ctx, q, p)
+			assert.NoError(t, err)
+			assert.Equal(t, items, got)
+			assert.Equal(t, int64(1), total)
+		}
+		mockRepo.AssertNumberOfCalls(t, "Search", 1)
+	})
+
+	t.Run("different page is a separate entry", func(t *testing.T) {
+		mockRepo := new(mockChatItemRepository)
+		p2 := common.PaginationQuery{Page: 2, Size: 10}
+		mockRepo.On("Search", ctx, "сайн", p).Return(items, int64(11), 1, 10, nil).Once()
+		mockRepo.On("Search", ctx, "сайн", p2).Return([]domain.ChatItem{}, int64(11), 2, 10, nil).Once()
+		svc := service.NewChatItemService(mockRepo, zap.NewNop())
+
+		_, _, _, _, _ = svc.Search(ctx, "сайн", p)
+		_, _, page, _, err := svc.Search(ctx, "сайн", p2)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, page)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty query is not cached", func(t *testing.T) {
+		mockRepo := new(mockChatItemRepository)
+		mockRepo.On("Search", ctx, "", p).Return(items, int64(1), 1, 10, nil).Twice()
+		svc := service.NewChatItemService(mockRepo, zap.NewNop())
+
+		_, _, _, _, _ = svc.Search(ctx, " ", p)
+		_, _, _, _, _ = svc.Search(ctx, "", p)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		mockRepo := new(mockChatItemRepository)
+		mockRepo.On("Search", ctx, "сайн", p).Return(nil, int64(0), 0, 0, errors.New("db error")).Once()
+		mockRepo.On("Search", ctx, "сайн", p).Return(items, int64(1), 1, 10, nil).Once()
+		svc := service.NewChatItemService(mockRepo, zap.NewNop())
+
+		_, _, _, _, err := svc.Search(ctx, "сайн", p)
+		assert.Error(t, err)
+		got, _, _, _, err := svc.Search(ctx, "сайн", p)
+		assert.NoError(t, err)
+		assert.Equal(t, items, got)
+	})
+
+	t.Run("least recently used term is evicted", func(t *testing.T) {
+		mockRepo := new(mockChatItemRepository)
+		mockRepo.On("Search", ctx, mock.Anything, p).Return(items, int64(1), 1, 10, nil)
+		svc := service.NewChatItemService(mockRepo, zap.NewNop())
+
+		_, _, _, _, _ = svc.Search(ctx, "first", p)
+		for i := 0; i < 200; i++ {
+			_, _, _, _, _ = svc.Search(ctx, fmt.Sprintf("term%d", i), p)
+		}
+		_, _, _, _, _ = svc.Search(ctx, "first", p)
+
+		mockRepo.AssertNumberOfCalls(t, "Search", 202)
+	})
+
+	t.Run("writes clear the cache", func(t *testing.T) {
+		mockRepo := new(mockChatItemRepository)
+		mockRepo.On("Search", ctx, "сайн", p).Return(items, int64(1), 1, 10, nil)
+		mockRepo.On("Create", ctx, mock.Anything).Return(nil)
+		mockRepo.On("Update", ctx, 1, mock.Anything).Return(nil)
+		mockRepo.On("Delete", ctx, 1).Return(nil)
+		svc := service.NewChatItemService(mockRepo, zap.NewNop())
+
+		_, _, _, _, _ = svc.Search(ctx, "сайн", p)
+		assert.NoError(t, svc.Create(ctx, dto.ChatItemCreateDto{Key: "k", Answer: "сайн"}))
+		_, _, _, _, _ = svc.Search(ctx, "сайн", p)
+		assert.NoError(t, svc.Update(ctx, 1, dto.ChatItemUpdateDto{Key: "k", Answer: "сайн"}))
+		_, _, _, _, _ = svc.Search(ctx, "сайн", p)
+		assert.NoError(t, svc.Delete(ctx, 1))
+		_, _, _, _, _ = svc.Search(ctx, "сайн", p)
+
+		mockRepo.AssertNumberOfCalls(t, "Search", 4)
+	})
+}
+
 func TestChatItemService_Create(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -324,3 +442,78 @@ func TestChatItemService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestChatItemService_AddTags(t *testing.T) {
+	tags := []domain.Tag{{ID: 1, Name: "billing"}, {ID: 2, Name: "faq"}}
+
+	tests := []struct {
+		name      string
+		mockSetup func(*mockChatItemRepository)
+		wantTags  []domain.Tag
+		wantErrIs error
+		wantErr   bool
+	}{
+		{
+			name: "success - returns all tags of the item",
+			mockSetup: func(m *mockChatItemRepository) {
+				m.On("ByID", mock.Anything, 1).Return(domain.ChatItem{ID: 1}, nil)
+				m.On("AddTags", mock.Anything, 1, []int{1, 2}).Return(nil)
+				m.On("GetTags", mock.Anything, 1).Return(tags, nil)
+			},
+			wantTags: tags,
+		},
+		{
+			name: "error - chat item not found",
+			mockSetup: func(m *mockChatItemRepository) {
+				m.On("ByID", mock.Anything, 1).Return(domain.ChatItem{}, domain.NewNotFound("chat item not found", nil))
+			},
+			wantErrIs: domain.ErrNotFound,
+			wantErr:   true,
+		},
+		{
+			name: "error - unknown tag",
+			mockSetup: func(m *mockChatItemRepository) {
+				m.On("ByID", mock.Anything, 1).Return(domain.ChatItem{ID: 1}, nil)
+				m.On("AddTags", mock.Anything, 1, []int{1, 2}).Return(domain.NewNotFound("tag not found", nil))
+			},
+			wantErrIs: domain.ErrNotFound,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockChatItemRepository{}
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewChatItemService(mockRepo, zap.NewNop())
+
+			got, err := svc.AddTags(context.Background(), 1, dto.ChatItemTagsDto{TagIDs: []int{1, 2}})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				mockRepo.AssertNotCalled(t, "GetTags", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTags, got)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChatItemService_RemoveTag(t *testing.T) {
+	mockRepo := &mockChatItemRepository{}
+	mockRepo.On("RemoveTag", mock.Anything, 1, 2).Return(nil)
+	mockRepo.On("RemoveTag", mock.Anything, 1, 3).Return(domain.NewNotFound("chat item tag not found", nil))
+
+	svc := service.NewChatItemService(mockRepo, zap.NewNop())
+
+	assert.NoError(t, svc.RemoveTag(context.Background(), 1, 2))
+	assert.ErrorIs(t, svc.RemoveTag(context.Background(), 1, 3), domain.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,449 @@
+// Package service provides implementation for service
+//
+// File: google_oauth_service_test.go
+// Description: Unit tests for Google authorization code exchange (token endpoint mocked via oauth2.HTTPClient)
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"templatev25/internal/config"
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+const testGoogleClientID = "client-123.apps.googleusercontent.com"
+
+// ============================================================
+// MOCKS
+// ============================================================
+
+// mockGoogleAuthRepository covers the AuthRepository methods used by ExchangeGoogleCode
+type mockGoogleAuthRepository struct {
+	repository.AuthRepository
+	mock.Mock
+}
+
+func (m *mockGoogleAuthRepository) GetCredentialByProvider(ctx context.Context, provider, providerID string) (*domain.UserCredential, error) {
+	args := m.Called(ctx, provider, providerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserCredential), args.Error(1)
+}
+
+func (m *mockGoogleAuthRepository) GetCredentialByUserID(ctx context.Context, userID int) (*domain.UserCredential, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserCredential), args.Error(1)
+}
+
+func (m *mockGoogleAuthRepository) CreateCredential(ctx context.Context, cred *domain.UserCredential) error {
+	args := m.Called(ctx, cred)
+	return args.Error(0)
+}
+
+func (m *mockGoogleAuthRepository) UpdateCredential(ctx context.Context, cred *domain.UserCredential) error {
+	args := m.Called(ctx, cred)
+	return args.Error(0)
+}
+
+func (m *mockGoogleAuthRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *mockGoogleAuthRepository) GetUserByID(ctx context.Context, userID int) (*domain.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *mockGoogleAuthRepository) GetMFAByUserID(ctx context.Context, userID int) (*domain.UserMFATotp, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserMFATotp), args.Error(1)
+}
+
+func (m *mockGoogleAuthRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	return nil
+}
+
+func (m *mockGoogleAuthRepository) UpdateUserLoginStats(ctx context.Context, userID int) error {
+	return nil
+}
+
+func (m *mockGoogleAuthRepository) CreateLoginHistory(ctx context.Context, history *domain.LoginHistory) error {
+	return nil
+}
+
+func (m *mockGoogleAuthRepository) CreateAuditTrail(ctx context.Context, audit *domain.SecurityAuditTrail) error {
+	return nil
+}
+
+// mockGoogleUserRepository covers Create and Update
+type mockGoogleUserRepository struct {
+	repository.UserRepository
+	mock.Mock
+}
+
+func (m *mockGoogleUserRepository) Create(ctx context.Context, u domain.User) (domain.User, error) {
+	args := m.Called(ctx, u)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *mockGoogleUserRepository) Update(ctx context.Context, u domain.User) (domain.User, error) {
+	args := m.Called(ctx, u)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+// mockCreateSessionStore covers Create and StoreMFAToken
+type mockCreateSessionStore struct {
+	service.SessionStore
+	mock.Mock
+}
+
+func (m *mockCreateSessionStore) Create(ctx context.Context, session *service.SessionData) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *mockCreateSessionStore) StoreMFAToken(ctx context.Context, token string, data *service.MFAPendingData, ttl time.Duration) error {
+	args := m.Called(ctx, token, data, ttl)
+	return args.Error(0)
+}
+
+// roundTripFunc нь OAuth token endpoint-ийг орлох http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// ============================================================
+// HELPERS
+// ============================================================
+
+// fakeIDToken нь гарын үсэггүй JWT (header.payload.sig) үүсгэнэ
+func fakeIDToken(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." + enc.EncodeToString(payload) + ".sig"
+}
+
+func googleClaims(overrides map[string]interface{}) map[string]interface{} {
+	c := map[string]interface{}{
+		"iss":            "https://accounts.google.com",
+		"aud":            testGoogleClientID,
+		"sub":            "google-sub-1",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"email":          "bat@example.com",
+		"email_verified": true,
+		"given_name":     "Bat",
+		"family_name":    "Dorj",
+	}
+	for k, v := range overrides {
+		c[k] = v
+	}
+	return c
+}
+
+// tokenEndpointCtx нь token endpoint-ийн хариуг буцаах HTTP client-тэй ctx болон
+// илгээсэн form-ийг барих хувьсагч буцаана
+func tokenEndpointCtx(t *testing.T, status int, body map[string]interface{}) (context.Context, *url.Values) {
+	t.Helper()
+	sent := &url.Values{}
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		raw, _ := io.ReadAll(r.Body)
+		*sent, _ = url.ParseQuery(string(raw))
+		b, _ := json.Marshal(body)
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(string(b))),
+			Request:    r,
+		}, nil
+	})}
+	return context.WithValue(context.Background(), oauth2.HTTPClient, client), sent
+}
+
+func tokenResponse(idToken string) map[string]interface{} {
+	return map[string]interface{}{
+		"access_token": "ya29.access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	}
+}
+
+// newGoogleTestService нь MFA-гүй хэрэглэгчийг default болгоно (тест өмнө нь өөр On бүртгэж болно)
+func newGoogleTestService(repo *mockGoogleAuthRepository, users *mockGoogleUserRepository, store *mockCreateSessionStore) *service.AuthService {
+	repo.On("GetMFAByUserID", mock.Anything, mock.Anything).Return(nil, domain.NewNotFound("mfa not found", nil)).Maybe()
+	svc := service.NewAuthService(repo, store, &config.LocalAuthConfig{SessionTTL: time.Hour, MFATokenTTL: 5 * time.Minute}, zap.NewNop())
+	svc.SetGoogleOAuth(config.GoogleOAuthConfig{ClientID: testGoogleClientID, ClientSecret: "secret"}, users)
+	return svc
+}
+
+// ============================================================
+// TESTS
+// ============================================================
+
+func TestAuthService_ExchangeGoogleCode_NewUser(t *testing.T) {
+	repo := new(mockGoogleAuthRepository)
+	users := new(mockGoogleUserRepository)
+	store := new(mockCreateSessionStore)
+
+	repo.On("GetCredentialByProvider", mock.Anything, service.CredentialProviderGoogle, "google-sub-1").
+		Return(nil, domain.NewNotFound("credentials not found", nil))
+	repo.On("GetUserByEmail", mock.Anything, "bat@example.com").
+		Return(nil, domain.NewNotFound("user not found", nil))
+	users.On("Create", mock.Anything, domain.User{
+		Email: "bat@example.com", FirstName: "Bat", LastName: "Dorj", Status: string(domain.UserStatusActive),
+	}).Return(domain.User{Id: 10, Email: "bat@example.com", Status: string(domain.UserStatusActive)}, nil)
+	repo.On("GetCredentialByUserID", mock.Anything, 10).
+		Return(nil, domain.NewNotFound("credentials not found", nil))
+	repo.On("CreateCredential", mock.Anything, &domain.UserCredential{
+		UserID: 10, CredentialType: string(domain.CredentialTypeOAuth),
+		Provider: service.CredentialProviderGoogle, ProviderID: "google-sub-1",
+	}).Return(nil)
+	store.On("Create", mock.Anything, mock.MatchedBy(func(s *service.SessionData) bool {
+		return s.UserID == 10 && s.Email == "bat@example.com"
+	})).Return(nil)
+
+	ctx, sent := tokenEndpointCtx(t, http.StatusOK, tokenResponse(fakeIDToken(t, googleClaims(nil))))
+	svc := newGoogleTestService(repo, users, store)
+
+	res, err := svc.ExchangeGoogleCode(ctx, "auth-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+
+	assert.True(t, res.IsNewUser)
+	assert.Equal(t, 10, res.User.Id)
+	require.NotNil(t, res.Session)
+	assert.NotEmpty(t, res.Session.SessionID)
+	assert.Equal(t, "auth-code", sent.Get("code"))
+	assert.Equal(t, "https://app.example.com/callback", sent.Get("redirect_uri"))
+	assert.Equal(t, "authorization_code", sent.Get("grant_type"))
+
+	repo.AssertExpectations(t)
+	users.AssertExpectations(t)
+	store.AssertExpectations(t)
+}
+
+func TestAuthService_ExchangeGoogleCode_LinkedAccount(t *testing.T) {
+	repo := new(mockGoogleAuthRepository)
+	users := new(mockGoogleUserRepository)
+	store := new(mockCreateSessionStore)
+
+	repo.On("GetCredentialByProvider", mock.Anything, service.CredentialProviderGoogle, "google-sub-1").
+		Return(&domain.UserCredential{UserID: 5, Provider: service.CredentialProviderGoogle, ProviderID: "google-sub-1"}, nil)
+	repo.On("GetUserByID", mock.Anything, 5).
+		Return(&domain.User{Id: 5, Email: "bat@example.com", Status: string(domain.UserStatusActive)}, nil)
+	store.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	ctx, _ := tokenEndpointCtx(t, http.StatusOK, tokenResponse(fakeIDToken(t, googleClaims(nil))))
+	res, err := newGoogleTestService(repo, users, store).ExchangeGoogleCode(ctx, "auth-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+
+	assert.False(t, res.IsNewUser)
+	assert.Equal(t, 5, res.User.Id)
+	repo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+	users.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ExchangeGoogleCode_LinksExistingEmailUser(t *testing.T) {
+	repo := new(mockGoogleAuthRepository)
+	users := new(mockGoogleUserRepository)
+	store := new(mockCreateSessionStore)
+
+	repo.On("GetCredentialByProvider", mock.Anything, service.CredentialProviderGoogle, "google-sub-1").
+		Return(nil, domain.NewNotFound("credentials not found", nil))
+	repo.On("GetUserByEmail", mock.Anything, "bat@example.com").
+		Return(&domain.User{Id: 7, Email: "bat@example.com", LastName: "Existing", Status: string(domain.UserStatusActive)}, nil)
+	// Зөвхөн хоосон FirstName нөхөгдөнө
+	users.On("Update", mock.Anything, domain.User{Id: 7, FirstName: "Bat"}).Return(domain.User{}, nil)
+	repo.On("GetCredentialByUserID", mock.Anything, 7).
+		Return(&domain.UserCredential{ID: 3, UserID: 7, PasswordHash: "$argon2id$hash"}, nil)
+	repo.On("UpdateCredential", mock.Anything, mock.MatchedBy(func(c *domain.UserCredential) bool {
+		return c.ID == 3 && c.PasswordHash == "$argon2id$hash" &&
+			c.Provider == service.CredentialProviderGoogle && c.ProviderID == "google-sub-1"
+	})).Return(nil)
+	store.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	ctx, _ := tokenEndpointCtx(t, http.StatusOK, tokenResponse(fakeIDToken(t, googleClaims(nil))))
+	res, err := newGoogleTestService(repo, users, store).ExchangeGoogleCode(ctx, "auth-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+
+	assert.False(t, res.IsNewUser)
+	assert.Equal(t, "Bat", res.User.FirstName)
+	assert.Equal(t, "Existing", res.User.LastName)
+	repo.AssertExpectations(t)
+	users.AssertExpectations(t)
+}
+
+func TestAuthService_ExchangeGoogleCode_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    map[string]interface{}
+		wantErr error
+	}{
+		{
+			name:    "token endpoint rejects code",
+			status:  http.StatusBadRequest,
+			body:    map[string]interface{}{"error": "invalid_grant"},
+			wantErr: service.ErrGoogleCodeExchange,
+		},
+		{
+			name:    "missing id_token",
+			status:  http.StatusOK,
+			body:    map[string]interface{}{"access_token": "ya29.access", "token_type": "Bearer"},
+			wantErr: service.ErrInvalidGoogleIDToken,
+		},
+		{
+			name:    "audience mismatch",
+			status:  http.StatusOK,
+			body:    tokenResponse(fakeIDToken(t, googleClaims(map[string]interface{}{"aud": "other-client"}))),
+			wantErr: service.ErrInvalidGoogleIDToken,
+		},
+		{
+			name:    "wrong issuer",
+			status:  http.StatusOK,
+			body:    tokenResponse(fakeIDToken(t, googleClaims(map[string]interface{}{"iss": "https://evil.example.com"}))),
+			wantErr: service.ErrInvalidGoogleIDToken,
+		},
+		{
+			name:    "expired id token",
+			status:  http.StatusOK,
+			body:    tokenResponse(fakeIDToken(t, googleClaims(map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()}))),
+			wantErr: service.ErrInvalidGoogleIDToken,
+		},
+		{
+			name:    "unverified email",
+			status:  http.StatusOK,
+			body:    tokenResponse(fakeIDToken(t, googleClaims(map[string]interface{}{"email_verified": false}))),
+			wantErr: service.ErrGoogleEmailNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockGoogleAuthRepository)
+			users := new(mockGoogleUserRepository)
+			store := new(mockCreateSessionStore)
+
+			ctx, _ := tokenEndpointCtx(t, tt.status, tt.body)
+			_, err := newGoogleTestService(repo, users, store).ExchangeGoogleCode(ctx, "auth-code", "https://app.example.com/callback")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertNotCalled(t, "GetCredentialByProvider", mock.Anything, mock.Anything, mock.Anything)
+			store.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthService_ExchangeGoogleCode_InactiveUser(t *testing.T) {
+	repo := new(mockGoogleAuthRepository)
+	store := new(mockCreateSessionStore)
+
+	repo.On("GetCredentialByProvider", mock.Anything, service.CredentialProviderGoogle, "google-sub-1").
+		Return(&domain.UserCredential{UserID: 5}, nil)
+	repo.On("GetUserByID", mock.Anything, 5).
+		Return(&domain.User{Id: 5, Status: string(domain.UserStatusSuspended)}, nil)
+
+	ctx, _ := tokenEndpointCtx(t, http.StatusOK, tokenResponse(fakeIDToken(t, googleClaims(nil))))
+	_, err := newGoogleTestService(repo, new(mockGoogleUserRepository), store).ExchangeGoogleCode(ctx, "auth-code", "https://app.example.com/callback")
+
+	assert.ErrorIs(t, err, service.ErrAccountNotActive)
+	store.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ExchangeGoogleCode_LockedAccount(t *testing.T) {
+	repo := new(mockGoogleAuthRepository)
+	store := new(mockCreateSessionStore)
+
+	lockedUntil := time.Now().Add(time.Hour)
+	repo.On("GetCredentialByProvider", mock.Anything, service.CredentialProviderGoogle, "google-sub-1").
+		Return(&domain.UserCredential{UserID: 5, LockedUntil: &lockedUntil}, nil)
+	repo.On("GetUserByID", mock.Anything, 5).
+		Return(&domain.User{Id: 5, Status: string(domain.UserStatusActive)}, nil)
+
+	ctx, _ := tokenEndpointCtx(t, http.StatusOK, tokenResponse(fakeIDToken(t, googleClaims(nil))))
+	_, err := newGoogleTestService(repo, new(mockGoogleUserRepository), store).ExchangeGoogleCode(ctx, "auth-code", "https://app.example.com/callback")
+
+	assert.ErrorIs(t, err, service.ErrAccountLocked)
+	store.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ExchangeGoogleCode_MFARequired(t *testing.T) {
+	repo := new(mockGoogleAuthRepository)
+	store := new(mockCreateSessionStore)
+
+	repo.On("GetCredentialByProvider", mock.Anything, service.CredentialProviderGoogle, "google-sub-1").
+		Return(&domain.UserCredential{UserID: 5}, nil)
+	repo.On("GetUserByID", mock.Anything, 5).
+		Return(&domain.User{Id: 5, Email: "bat@example.com", Status: string(domain.UserStatusActive)}, nil)
+	repo.On("GetMFAByUserID", mock.Anything, 5).Return(&domain.UserMFATotp{UserID: 5, IsEnabled: true}, nil)
+	store.On("StoreMFAToken", mock.Anything, mock.AnythingOfType("string"), mock.MatchedBy(func(d *service.MFAPendingData) bool {
+		return d.UserID == 5 && d.Email == "bat@example.com"
+	}), 5*time.Minute).Return(nil)
+
+	ctx, _ := tokenEndpointCtx(t, http.StatusOK, tokenResponse(fakeIDToken(t, googleClaims(nil))))
+	res, err := newGoogleTestService(repo, new(mockGoogleUserRepository), store).ExchangeGoogleCode(ctx, "auth-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+
+	assert.True(t, res.RequiresMFA)
+	assert.NotEmpty(t, res.MFAToken)
+	assert.Nil(t, res.Session)
+	store.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	store.AssertExpectations(t)
+}
+
+func TestAuthService_ExchangeGoogleCode_LinkedToOtherSubject(t *testing.T) {
+	repo := new(mockGoogleAuthRepository)
+	store := new(mockCreateSessionStore)
+
+	repo.On("GetCredentialByProvider", mock.Anything, service.CredentialProviderGoogle, "google-sub-1").
+		Return(nil, domain.NewNotFound("credentials not found", nil))
+	repo.On("GetUserByEmail", mock.Anything, "bat@example.com").
+		Return(&domain.User{Id: 7, Email: "bat@example.com", FirstName: "Bat", LastName: "Dorj", Status: string(domain.UserStatusActive)}, nil)
+	repo.On("GetCredentialByUserID", mock.Anything, 7).
+		Return(&domain.UserCredential{ID: 3, UserID: 7, Provider: service.CredentialProviderGoogle, ProviderID: "google-sub-OTHER"}, nil)
+
+	ctx, _ := tokenEndpointCtx(t, http.StatusOK, tokenResponse(fakeIDToken(t, googleClaims(nil))))
+	_, err := newGoogleTestService(repo, new(mockGoogleUserRepository), store).ExchangeGoogleCode(ctx, "auth-code", "https://app.example.com/callback")
+
+	assert.ErrorIs(t, err, service.ErrGoogleAccountConflict)
+	repo.AssertNotCalled(t, "UpdateCredential", mock.Anything, mock.Anything)
+	store.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ExchangeGoogleCode_NotConfigured(t *testing.T) {
+	svc := service.NewAuthService(new(mockGoogleAuthRepository), nil, &config.LocalAuthConfig{}, zap.NewNop())
+
+	_, err := svc.ExchangeGoogleCode(context.Background(), "auth-code", "https://app.example.com/callback")
+	assert.ErrorIs(t, err, service.ErrGoogleOAuthDisabled)
+}
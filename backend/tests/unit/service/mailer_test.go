@@ -0,0 +1,67 @@
+// Package service provides implementation for service
+//
+// File: mailer_test.go
+// Description: Unit tests for the SMTP mailer (header validation, context deadline)
+package service_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"templatev25/internal/config"
+	"templatev25/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPMailer_Send_RejectsHeaderInjection(t *testing.T) {
+	// Port 1 руу холбогдох гэж оролдвол өөр алдаа гарна — header шалгалт түүнээс өмнө хийгдэх ёстой
+	m := service.NewSMTPMailer(&config.MailConfig{Host: "127.0.0.1", Port: 1, From: "no-reply@example.com"})
+
+	tests := []struct {
+		name    string
+		to      string
+		subject string
+	}{
+		{name: "recipient with CRLF", to: "a@example.com\r\nBcc: x@evil.com", subject: "Hi"},
+		{name: "subject with LF", to: "a@example.com", subject: "Hi\nBcc: x@evil.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.Send(context.Background(), tt.to, tt.subject, "body")
+			assert.ErrorIs(t, err, service.ErrInvalidMailHeader)
+		})
+	}
+}
+
+func TestSMTPMailer_Send_StalledServerHonorsContext(t *testing.T) {
+	// Холболтыг хүлээж аваад greeting илгээдэггүй сервер
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	m := service.NewSMTPMailer(&config.MailConfig{Host: "127.0.0.1", Port: addr.Port, From: "no-reply@example.com"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = m.Send(ctx, "a@example.com", "Hi", "body")
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
@@ -12,10 +12,12 @@ import (
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
 	"templatev25/internal/service"
+	"templatev25/internal/util"
 
 	"git.gerege.mn/backend-packages/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
 )
 
 // mockMenuRepository implements repository.MenuRepository for testing
@@ -47,6 +49,14 @@ func (m *mockMenuRepository) ListByUserRoles(ctx context.Context, userID int) ([
 	return args.Get(0).([]domain.Menu), args.Error(1)
 }
 
+func (m *mockMenuRepository) ListByUserRolesInSystems(ctx context.Context, userID int, systemIDs []int) ([]domain.Menu, error) {
+	args := m.Called(ctx, userID, systemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Menu), args.Error(1)
+}
+
 func (m *mockMenuRepository) GetMenusByIDs(ctx context.Context, ids []int64) ([]domain.Menu, error) {
 	args := m.Called(ctx, ids)
 	if args.Get(0) == nil {
@@ -83,6 +93,24 @@ func (m *mockMenuRepository) Delete(ctx context.Context, id int64) error {
 	return args.Error(0)
 }
 
+func (m *mockMenuRepository) SetPermissions(ctx context.Context, menuID int64, permissionIDs []int) error {
+	args := m.Called(ctx, menuID, permissionIDs)
+	return args.Error(0)
+}
+
+func (m *mockMenuRepository) Reorder(ctx context.Context, orders []dto.MenuReorderItem) error {
+	args := m.Called(ctx, orders)
+	return args.Error(0)
+}
+
+func (m *mockMenuRepository) ListMenuPermissions(ctx context.Context) ([]domain.MenuPermission, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.MenuPermission), args.Error(1)
+}
+
 func TestMenuService_List(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -193,12 +221,13 @@ func TestMenuService_ListAll(t *testing.T) {
 
 func TestMenuService_ListByUserRoles(t *testing.T) {
 	parentID := int64(1)
+	childID := int64(2)
 
 	tests := []struct {
 		name      string
 		userID    int
 		mockSetup func(*mockMenuRepository)
-		wantCount int
+		wantTree  []domain.Menu
 		wantErr   bool
 	}{
 		{
@@ -214,8 +243,32 @@ func TestMenuService_ListByUserRoles(t *testing.T) {
 				m.On("ListByUserRoles", mock.Anything, 1).Return(menus, nil)
 				m.On("GetMenusByIDs", mock.Anything, mock.AnythingOfType("[]int64")).Return(parentMenus, nil)
 			},
-			wantCount: 1, // Root menu count
-			wantErr:   false,
+			wantTree: util.BuildMenuTree([]domain.Menu{
+				{ID: 1, Name: "Parent Menu", ParentID: nil, Sequence: 1},
+				{ID: 2, Name: "Child Menu", ParentID: &parentID, Sequence: 1},
+			}),
+			wantErr: false,
+		},
+		{
+			name:   "success - grandparents fetched level by level",
+			userID: 4,
+			mockSetup: func(m *mockMenuRepository) {
+				m.On("ListByUserRoles", mock.Anything, 4).Return([]domain.Menu{
+					{ID: 3, Name: "Leaf", ParentID: &childID, Sequence: 1},
+				}, nil)
+				m.On("GetMenusByIDs", mock.Anything, []int64{2}).Return([]domain.Menu{
+					{ID: 2, Name: "Child", ParentID: &parentID, Sequence: 1},
+				}, nil)
+				m.On("GetMenusByIDs", mock.Anything, []int64{1}).Return([]domain.Menu{
+					{ID: 1, Name: "Root", Sequence: 1},
+				}, nil)
+			},
+			wantTree: util.BuildMenuTree([]domain.Menu{
+				{ID: 1, Name: "Root", Sequence: 1},
+				{ID: 2, Name: "Child", ParentID: &parentID, Sequence: 1},
+				{ID: 3, Name: "Leaf", ParentID: &childID, Sequence: 1},
+			}),
+			wantErr: false,
 		},
 		{
 			name:   "success - user without menus",
@@ -223,8 +276,8 @@ func TestMenuService_ListByUserRoles(t *testing.T) {
 			mockSetup: func(m *mockMenuRepository) {
 				m.On("ListByUserRoles", mock.Anything, 2).Return([]domain.Menu{}, nil)
 			},
-			wantCount: 0,
-			wantErr:   false,
+			wantTree: []domain.Menu{},
+			wantErr:  false,
 		},
 		{
 			name:   "error - db error",
@@ -232,8 +285,7 @@ func TestMenuService_ListByUserRoles(t *testing.T) {
 			mockSetup: func(m *mockMenuRepository) {
 				m.On("ListByUserRoles", mock.Anything, 3).Return(nil, errors.New("db error"))
 			},
-			wantCount: 0,
-			wantErr:   true,
+			wantErr: true,
 		},
 	}
 
@@ -249,7 +301,7 @@ func TestMenuService_ListByUserRoles(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Len(t, menus, tt.wantCount)
+				assert.Equal(t, tt.wantTree, menus)
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -257,6 +309,60 @@ func TestMenuService_ListByUserRoles(t *testing.T) {
 	}
 }
 
+func TestMenuService_ListByUserRoles_ActiveSystems(t *testing.T) {
+	parentID := int64(1)
+
+	t.Run("success - filters by active system IDs", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockSystems := &mockSystemRepository{}
+		mockSystems.On("ListActive", mock.Anything).Return([]domain.System{{ID: 5}, {ID: 7}}, nil)
+		mockRepo.On("ListByUserRolesInSystems", mock.Anything, 1, []int{5, 7}).Return([]domain.Menu{
+			{ID: 2, Name: "Child", ParentID: &parentID, Sequence: 1},
+		}, nil)
+		mockRepo.On("GetMenusByIDs", mock.Anything, []int64{1}).Return([]domain.Menu{
+			{ID: 1, Name: "Root", Sequence: 1},
+		}, nil)
+
+		svc := service.NewMenuServiceWithSystems(mockRepo, service.NewSystemService(mockSystems, zap.NewNop()))
+		menus, err := svc.ListByUserRoles(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, util.BuildMenuTree([]domain.Menu{
+			{ID: 1, Name: "Root", Sequence: 1},
+			{ID: 2, Name: "Child", ParentID: &parentID, Sequence: 1},
+		}), menus)
+		mockRepo.AssertNotCalled(t, "ListByUserRoles", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+		mockSystems.AssertExpectations(t)
+	})
+
+	t.Run("success - no active systems", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockSystems := &mockSystemRepository{}
+		mockSystems.On("ListActive", mock.Anything).Return([]domain.System{}, nil)
+		mockRepo.On("ListByUserRolesInSystems", mock.Anything, 1, []int{}).Return([]domain.Menu{}, nil)
+
+		svc := service.NewMenuServiceWithSystems(mockRepo, service.NewSystemService(mockSystems, zap.NewNop()))
+		menus, err := svc.ListByUserRoles(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, menus)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - system list error", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockSystems := &mockSystemRepository{}
+		mockSystems.On("ListActive", mock.Anything).Return(nil, errors.New("db error"))
+
+		svc := service.NewMenuServiceWithSystems(mockRepo, service.NewSystemService(mockSystems, zap.NewNop()))
+		_, err := svc.ListByUserRoles(context.Background(), 1)
+
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "ListByUserRolesInSystems", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func TestMenuService_ByID(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -486,3 +592,186 @@ func TestMenuService_Delete(t *testing.T) {
 		})
 	}
 }
+
+// stubUserPermissions implements service.UserPermissionLister
+type stubUserPermissions struct {
+	codes []string
+	err   error
+}
+
+func (s stubUserPermissions) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
+	return s.codes, s.err
+}
+
+func TestMenuService_ListByUserRoles_RequiredPermissions(t *testing.T) {
+	reportRead := &domain.Permission{ID: 10, Code: "report.read", SystemID: 5}
+	reportExport := &domain.Permission{ID: 11, Code: "report.export", SystemID: 5}
+	links := []domain.MenuPermission{
+		{MenuID: 20, PermissionID: 10, Permission: reportRead},
+		{MenuID: 30, PermissionID: 10, Permission: reportRead},
+		{MenuID: 30, PermissionID: 11, Permission: reportExport},
+	}
+
+	t.Run("success - menu added when all required permissions are held", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockRepo.On("ListByUserRoles", mock.Anything, 1).Return([]domain.Menu{{ID: 2, Name: "Role Menu", Sequence: 1}}, nil)
+		mockRepo.On("ListMenuPermissions", mock.Anything).Return(links, nil)
+		mockRepo.On("GetMenusByIDs", mock.Anything, []int64{20}).Return([]domain.Menu{{ID: 20, Name: "Reports", Sequence: 2}}, nil)
+
+		svc := service.NewMenuServiceWithPermissions(mockRepo, nil, stubUserPermissions{codes: []string{"report.read"}})
+		menus, err := svc.ListByUserRoles(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, util.BuildMenuTree([]domain.Menu{
+			{ID: 2, Name: "Role Menu", Sequence: 1},
+			{ID: 20, Name: "Reports", Sequence: 2},
+		}), menus)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - menu hidden without required permission", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockRepo.On("ListByUserRoles", mock.Anything, 1).Return([]domain.Menu{}, nil)
+		mockRepo.On("ListMenuPermissions", mock.Anything).Return(links, nil)
+
+		svc := service.NewMenuServiceWithPermissions(mockRepo, nil, stubUserPermissions{codes: []string{"report.export"}})
+		menus, err := svc.ListByUserRoles(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, menus)
+		mockRepo.AssertNotCalled(t, "GetMenusByIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - menu already granted by role is not fetched twice", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockRepo.On("ListByUserRoles", mock.Anything, 1).Return([]domain.Menu{{ID: 20, Name: "Reports", Sequence: 2}}, nil)
+		mockRepo.On("ListMenuPermissions", mock.Anything).Return(links, nil)
+
+		svc := service.NewMenuServiceWithPermissions(mockRepo, nil, stubUserPermissions{codes: []string{"report.read"}})
+		menus, err := svc.ListByUserRoles(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, menus, 1)
+		mockRepo.AssertNotCalled(t, "GetMenusByIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - permission of inactive system is not accepted", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockSystems := &mockSystemRepository{}
+		mockSystems.On("ListActive", mock.Anything).Return([]domain.System{{ID: 7}}, nil)
+		mockRepo.On("ListByUserRolesInSystems", mock.Anything, 1, []int{7}).Return([]domain.Menu{}, nil)
+		mockRepo.On("ListMenuPermissions", mock.Anything).Return(links, nil)
+
+		svc := service.NewMenuServiceWithPermissions(mockRepo, service.NewSystemService(mockSystems, zap.NewNop()),
+			stubUserPermissions{codes: []string{"report.read", "report.export"}})
+		menus, err := svc.ListByUserRoles(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, menus)
+		mockRepo.AssertNotCalled(t, "GetMenusByIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - permission lookup fails", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockRepo.On("ListByUserRoles", mock.Anything, 1).Return([]domain.Menu{}, nil)
+		mockRepo.On("ListMenuPermissions", mock.Anything).Return(links, nil)
+
+		svc := service.NewMenuServiceWithPermissions(mockRepo, nil, stubUserPermissions{err: errors.New("db error")})
+		_, err := svc.ListByUserRoles(context.Background(), 1)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMenuService_SetPermissions(t *testing.T) {
+	t.Run("success - duplicates removed", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockRepo.On("ByID", mock.Anything, int64(1)).Return(domain.Menu{ID: 1}, nil)
+		mockRepo.On("SetPermissions", mock.Anything, int64(1), []int{3, 4}).Return(nil)
+
+		err := service.NewMenuService(mockRepo).SetPermissions(context.Background(), 1, []int{3, 4, 3})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - menu not found", func(t *testing.T) {
+		mockRepo := &mockMenuRepository{}
+		mockRepo.On("ByID", mock.Anything, int64(99)).Return(domain.Menu{}, domain.NewNotFound("menu not found", nil))
+
+		err := service.NewMenuService(mockRepo).SetPermissions(context.Background(), 99, []int{3})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "SetPermissions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestMenuService_Reorder(t *testing.T) {
+	tests := []struct {
+		name    string
+		orders  []dto.MenuReorderItem
+		repoErr error
+		wantErr error
+		callsDB bool
+	}{
+		{
+			name:    "success - consecutive sequences in any order",
+			orders:  []dto.MenuReorderItem{{ID: 3, Sequence: 2}, {ID: 1, Sequence: 0}, {ID: 2, Sequence: 1}},
+			callsDB: true,
+		},
+		{
+			name:    "success - run may start above zero",
+			orders:  []dto.MenuReorderItem{{ID: 1, Sequence: 5}, {ID: 2, Sequence: 6}},
+			callsDB: true,
+		},
+		{
+			name:    "error - duplicate id",
+			orders:  []dto.MenuReorderItem{{ID: 1, Sequence: 1}, {ID: 1, Sequence: 2}},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:    "error - gap in sequences",
+			orders:  []dto.MenuReorderItem{{ID: 1, Sequence: 1}, {ID: 2, Sequence: 3}},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:    "error - duplicate sequence",
+			orders:  []dto.MenuReorderItem{{ID: 1, Sequence: 1}, {ID: 2, Sequence: 1}},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:    "error - empty orders",
+			orders:  nil,
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:    "error - missing menu",
+			orders:  []dto.MenuReorderItem{{ID: 1, Sequence: 1}},
+			repoErr: domain.NewNotFound("menu not found", nil),
+			wantErr: domain.ErrNotFound,
+			callsDB: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockMenuRepository{}
+			if tt.callsDB {
+				mockRepo.On("Reorder", mock.Anything, tt.orders).Return(tt.repoErr)
+			}
+
+			err := service.NewMenuService(mockRepo).Reorder(context.Background(), tt.orders)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.callsDB {
+				mockRepo.AssertExpectations(t)
+			} else {
+				mockRepo.AssertNotCalled(t, "Reorder", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 // Package service provides implementation for service
 //
 // File: module_service_test.go
-// Description: Unit tests for module service
+// Description: Unit tests for module service (incl. permission cascade on delete)
 package service_test
 
 import (
@@ -35,6 +35,14 @@ func (m *mockModuleRepository) ByID(ctx context.Context, id int) (domain.Module,
 	return args.Get(0).(domain.Module), args.Error(1)
 }
 
+func (m *mockModuleRepository) ByIDs(ctx context.Context, ids []int) ([]domain.Module, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Module), args.Error(1)
+}
+
 func (m *mockModuleRepository) Create(ctx context.Context, module domain.Module) error {
 	args := m.Called(ctx, module)
 	return args.Error(0)
@@ -362,3 +370,95 @@ func TestModuleService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestModuleService_Delete_PermissionCascade(t *testing.T) {
+	inTx := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txCtxKey{}) != nil })
+	isInactive := false
+	errDB := errors.New("db error")
+
+	t.Run("success - permissions deleted before module, audited, cache invalidated", func(t *testing.T) {
+		modules := &mockModuleRepository{}
+		perms := &mockPermissionRepository{}
+		audit := &mockSessionAuthRepository{}
+		cache := &mockCacheInvalidator{}
+		tx := &fakeTxManager{}
+
+		var order []string
+		modules.On("ByID", mock.Anything, 5).Return(domain.Module{ID: 5, IsActive: &isInactive}, nil)
+		perms.On("DeleteByModule", inTx, 5).Return(int64(3), nil).
+			Run(func(mock.Arguments) { order = append(order, "permissions") })
+		modules.On("Delete", inTx, 5).Return(nil).
+			Run(func(mock.Arguments) { order = append(order, "module") })
+		audit.On("CreateAuditTrail", mock.Anything, mock.MatchedBy(func(a *domain.SecurityAuditTrail) bool {
+			return a.Action == string(domain.AuditActionModuleDelete) && a.TargetType == "module" &&
+				a.TargetID == "5" && a.NewValue == `{"permissions_deleted":3}`
+		})).Return(nil).Run(func(mock.Arguments) { order = append(order, "audit") })
+		cache.On("InvalidateAll").Return().
+			Run(func(mock.Arguments) { order = append(order, "cache") })
+
+		svc := service.NewModuleService(modules)
+		svc.SetPermissionCleanup(tx, perms, audit)
+		svc.SetCacheInvalidator(cache)
+
+		assert.NoError(t, svc.Delete(context.Background(), 5))
+		assert.Equal(t, []string{"permissions", "module", "audit", "cache"}, order)
+		assert.Equal(t, 1, tx.calls)
+		assert.False(t, tx.rolledBack)
+		modules.AssertExpectations(t)
+		perms.AssertExpectations(t)
+		audit.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("no permissions - cache not invalidated, no cache set is fine", func(t *testing.T) {
+		modules := &mockModuleRepository{}
+		perms := &mockPermissionRepository{}
+		audit := &mockSessionAuthRepository{}
+		modules.On("ByID", mock.Anything, 5).Return(domain.Module{ID: 5, IsActive: &isInactive}, nil)
+		perms.On("DeleteByModule", inTx, 5).Return(int64(0), nil)
+		modules.On("Delete", inTx, 5).Return(nil)
+		audit.On("CreateAuditTrail", mock.Anything, mock.Anything).Return(nil)
+
+		svc := service.NewModuleService(modules)
+		svc.SetPermissionCleanup(&fakeTxManager{}, perms, audit)
+
+		assert.NoError(t, svc.Delete(context.Background(), 5))
+	})
+
+	t.Run("error - permission delete fails, module kept", func(t *testing.T) {
+		modules := &mockModuleRepository{}
+		perms := &mockPermissionRepository{}
+		audit := &mockSessionAuthRepository{}
+		cache := &mockCacheInvalidator{}
+		tx := &fakeTxManager{}
+		modules.On("ByID", mock.Anything, 5).Return(domain.Module{ID: 5, IsActive: &isInactive}, nil)
+		perms.On("DeleteByModule", inTx, 5).Return(int64(0), errDB)
+
+		svc := service.NewModuleService(modules)
+		svc.SetPermissionCleanup(tx, perms, audit)
+		svc.SetCacheInvalidator(cache)
+
+		assert.ErrorIs(t, svc.Delete(context.Background(), 5), errDB)
+		assert.True(t, tx.rolledBack)
+		modules.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		audit.AssertNotCalled(t, "CreateAuditTrail", mock.Anything, mock.Anything)
+		cache.AssertNotCalled(t, "InvalidateAll")
+	})
+
+	t.Run("error - module delete fails, rolled back", func(t *testing.T) {
+		modules := &mockModuleRepository{}
+		perms := &mockPermissionRepository{}
+		audit := &mockSessionAuthRepository{}
+		tx := &fakeTxManager{}
+		modules.On("ByID", mock.Anything, 5).Return(domain.Module{ID: 5, IsActive: &isInactive}, nil)
+		perms.On("DeleteByModule", inTx, 5).Return(int64(2), nil)
+		modules.On("Delete", inTx, 5).Return(errDB)
+
+		svc := service.NewModuleService(modules)
+		svc.SetPermissionCleanup(tx, perms, audit)
+
+		assert.ErrorIs(t, svc.Delete(context.Background(), 5), errDB)
+		assert.True(t, tx.rolledBack)
+		audit.AssertNotCalled(t, "CreateAuditTrail", mock.Anything, mock.Anything)
+	})
+}
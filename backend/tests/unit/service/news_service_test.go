@@ -5,8 +5,10 @@
 package service_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"mime/multipart"
 	"testing"
 
 	"templatev25/internal/domain"
@@ -16,6 +18,7 @@ import (
 	"git.gerege.mn/backend-packages/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // mockNewsRepository implements repository.NewsRepository for testing
@@ -51,6 +54,11 @@ func (m *mockNewsRepository) Delete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *mockNewsRepository) SetPublished(ctx context.Context, id int, published bool) error {
+	args := m.Called(ctx, id, published)
+	return args.Error(0)
+}
+
 func TestNewsService_List(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -316,3 +324,166 @@ func TestNewsService_Delete(t *testing.T) {
 		})
 	}
 }
+
+// mockNewsCategoryRepository implements repository.NewsCategoryRepository for testing
+type mockNewsCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *mockNewsCategoryRepository) List(ctx context.Context, p common.PaginationQuery) ([]domain.NewsCategory, int64, int, int, error) {
+	args := m.Called(ctx, p)
+	if args.Get(0) == nil {
+		return nil, 0, 0, 0, args.Error(4)
+	}
+	return args.Get(0).([]domain.NewsCategory), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
+}
+
+func (m *mockNewsCategoryRepository) Create(ctx context.Context, c domain.NewsCategory) error {
+	return m.Called(ctx, c).Error(0)
+}
+
+func (m *mockNewsCategoryRepository) Update(ctx context.Context, id int, c domain.NewsCategory) error {
+	return m.Called(ctx, id, c).Error(0)
+}
+
+func (m *mockNewsCategoryRepository) Delete(ctx context.Context, id int) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func TestNewsService_CreateWithCategory(t *testing.T) {
+	mockRepo := new(mockNewsRepository)
+	categoryID := 3
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(n domain.News) bool {
+		return n.CategoryID != nil && *n.CategoryID == categoryID
+	})).Return(nil)
+
+	svc := service.NewNewsService(mockRepo)
+	err := svc.Create(context.Background(), dto.NewsDto{Title: "Title", Text: "Text", CategoryID: &categoryID})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNewsCategoryService(t *testing.T) {
+	ctx := context.Background()
+	req := dto.NewsCategoryDto{Name: "Sport", Code: "sport"}
+	want := domain.NewsCategory{Name: "Sport", Code: "sport"}
+
+	mockRepo := new(mockNewsCategoryRepository)
+	mockRepo.On("Create", ctx, want).Return(nil)
+	mockRepo.On("Update", ctx, 1, want).Return(nil)
+	mockRepo.On("Update", ctx, 2, want).Return(domain.NewNotFound("news category not found", nil))
+	mockRepo.On("Delete", ctx, 1).Return(nil)
+
+	svc := service.NewNewsCategoryService(mockRepo)
+
+	assert.NoError(t, svc.Create(ctx, req))
+	assert.NoError(t, svc.Update(ctx, 1, req))
+	assert.True(t, errors.Is(svc.Update(ctx, 2, req), domain.ErrNotFound))
+	assert.NoError(t, svc.Delete(ctx, 1))
+	mockRepo.AssertExpectations(t)
+}
+
+// mockFileRepository implements repository.FileRepository for testing
+type mockFileRepository struct {
+	mock.Mock
+}
+
+func (m *mockFileRepository) Upload(ctx context.Context, file multipart.File, header *multipart.FileHeader, bucket string) (string, error) {
+	args := m.Called(ctx, file, header, bucket)
+	return args.String(0), args.Error(1)
+}
+
+// newFormFile нь санах ойд multipart form үүсгэж "file" талбарын header-ийг буцаана
+func newFormFile(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(32 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File["file"][0]
+}
+
+func TestNewsService_UploadAttachment(t *testing.T) {
+	pngHeader := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpegHeader := []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+	webpHeader := []byte("RIFF\x24\x00\x00\x00WEBPVP8 ")
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		wantExt  string
+		wantErr  error
+	}{
+		{name: "png", filename: "a.png", content: pngHeader, wantExt: ".png"},
+		{name: "jpeg", filename: "a.jpeg", content: jpegHeader, wantExt: ".jpg"},
+		{name: "webp", filename: "a.webp", content: webpHeader, wantExt: ".webp"},
+		{name: "png renamed to html keeps png extension", filename: "x.html", content: pngHeader, wantExt: ".png"},
+		{name: "gif is rejected", filename: "a.gif", content: []byte("GIF89a\x01\x00\x01\x00"), wantErr: service.ErrAttachmentUnsupported},
+		{name: "html with image name is rejected", filename: "a.png", content: []byte("<html><script>alert(1)</script>"), wantErr: service.ErrAttachmentUnsupported},
+		{name: "empty file is rejected", filename: "a.png", content: nil, wantErr: service.ErrAttachmentUnsupported},
+		{
+			name:     "exactly 5 MB is allowed",
+			filename: "big.png",
+			content:  append(append([]byte{}, pngHeader...), make([]byte, int(service.NewsAttachmentMaxSize)-len(pngHeader))...),
+			wantExt:  ".png",
+		},
+		{
+			name:     "over 5 MB is rejected",
+			filename: "big.png",
+			content:  append(append([]byte{}, pngHeader...), make([]byte, int(service.NewsAttachmentMaxSize))...),
+			wantErr:  service.ErrAttachmentTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newsRepo := new(mockNewsRepository)
+			files := new(mockFileRepository)
+			header := newFormFile(t, tt.filename, tt.content)
+
+			if tt.wantErr == nil {
+				url := "https://example.com/file/news-1" + tt.wantExt
+				newsRepo.On("GetByID", mock.Anything, 7).Return(domain.News{Id: 7}, nil)
+				files.On("Upload", mock.Anything, mock.Anything, mock.MatchedBy(func(h *multipart.FileHeader) bool {
+					return h.Filename == "attachment"+tt.wantExt
+				}), service.NewsAttachmentBucket).Return(url, nil)
+				newsRepo.On("Update", mock.Anything, 7, domain.News{ImageUrl: url}).Return(nil)
+			}
+
+			svc := service.NewNewsServiceWithFiles(newsRepo, files)
+			got, err := svc.UploadAttachment(context.Background(), 7, header)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+				files.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://example.com/file/news-1"+tt.wantExt, got)
+			newsRepo.AssertExpectations(t)
+			files.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewsService_UploadAttachment_NewsNotFound(t *testing.T) {
+	newsRepo := new(mockNewsRepository)
+	files := new(mockFileRepository)
+	newsRepo.On("GetByID", mock.Anything, 404).Return(domain.News{}, domain.NewNotFound("news not found", nil))
+
+	svc := service.NewNewsServiceWithFiles(newsRepo, files)
+	_, err := svc.UploadAttachment(context.Background(), 404, newFormFile(t, "a.png", []byte("\x89PNG\r\n\x1a\n")))
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	files.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,85 @@
+// Package service provides implementation for service
+//
+// File: notification_hub_test.go
+// Description: Unit tests for the real-time notification connection hub
+package service_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"templatev25/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationHub_SendSetsWriteDeadline(t *testing.T) {
+	hub := service.NewNotificationHub()
+	conn := &fakeNotificationConn{}
+	hub.Register(1, conn)
+
+	before := time.Now()
+	require.True(t, hub.Send(1, "hello"))
+
+	require.Len(t, conn.got, 1)
+	assert.True(t, conn.deadline.After(before), "write must be bounded by a deadline")
+}
+
+// blockingNotificationConn нь unblock хаагдах хүртэл WriteJSON-д гацна (удаан client)
+type blockingNotificationConn struct {
+	fakeNotificationConn
+	unblock chan struct{}
+}
+
+func (b *blockingNotificationConn) WriteJSON(v interface{}) error {
+	<-b.unblock
+	return b.fakeNotificationConn.WriteJSON(v)
+}
+
+func TestNotificationHub_SlowWriteDoesNotBlockRegistry(t *testing.T) {
+	hub := service.NewNotificationHub()
+	slow := &blockingNotificationConn{unblock: make(chan struct{})}
+	hub.Register(1, slow)
+
+	sent := make(chan bool)
+	go func() { sent <- hub.Send(1, "hello") }()
+
+	// Send нь удаан холболт руу бичиж байх үед Register/Unregister/Connected гацахгүй
+	done := make(chan struct{})
+	go func() {
+		other := &fakeNotificationConn{}
+		hub.Register(1, other)
+		hub.Unregister(1, other)
+		_ = hub.Connected(1)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("registry blocked by a slow connection write")
+	}
+
+	close(slow.unblock)
+	assert.True(t, <-sent)
+}
+
+func TestNotificationHub_RegisterRacingUnregister(t *testing.T) {
+	// Unregister сүүлийн холболтыг хасч entry-г устгах үед зэрэг ирсэн Register
+	// устгагдсан entry-д бүртгэгдэж алга болох ёсгүй
+	for i := 0; i < 500; i++ {
+		hub := service.NewNotificationHub()
+		first, second := &fakeNotificationConn{}, &fakeNotificationConn{}
+		hub.Register(1, first)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); hub.Unregister(1, first) }()
+		go func() { defer wg.Done(); hub.Register(1, second) }()
+		wg.Wait()
+
+		require.True(t, hub.Connected(1), "iteration %d: registered connection was lost", i)
+		require.True(t, hub.Send(1, "hello"), "iteration %d: registered connection was not reachable", i)
+	}
+}
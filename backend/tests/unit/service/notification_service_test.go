@@ -8,8 +8,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/requestctx"
 	"templatev25/internal/service"
 
 	"git.gerege.mn/backend-packages/common"
@@ -17,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // mockNotificationRepository implements repository.NotificationRepository
@@ -32,6 +36,14 @@ func (m *mockNotificationRepository) ListByUser(ctx context.Context, userID int,
 	return args.Get(0).([]domain.Notification), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
 }
 
+func (m *mockNotificationRepository) ListByTenant(ctx context.Context, tenantID string, userID int, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error) {
+	args := m.Called(ctx, tenantID, userID, p)
+	if args.Get(0) == nil {
+		return nil, 0, 0, 0, args.Error(4)
+	}
+	return args.Get(0).([]domain.Notification), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
+}
+
 func (m *mockNotificationRepository) ListGroups(ctx context.Context, p common.PaginationQuery) ([]domain.NotificationGroup, int64, int, int, error) {
 	args := m.Called(ctx, p)
 	if args.Get(0) == nil {
@@ -45,9 +57,27 @@ func (m *mockNotificationRepository) MarkGroupRead(ctx context.Context, userID,
 	return args.Error(0)
 }
 
-func (m *mockNotificationRepository) MarkAllRead(ctx context.Context, userID int) error {
+func (m *mockNotificationRepository) MarkAllRead(ctx context.Context, userID int) (int64, error) {
 	args := m.Called(ctx, userID)
-	return args.Error(0)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockNotificationRepository) MarkReadByIDs(ctx context.Context, userID int, ids []int) (int64, error) {
+	args := m.Called(ctx, userID, ids)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockNotificationRepository) CountByType(ctx context.Context, userID int) (map[string]int64, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+func (m *mockNotificationRepository) UnreadCount(ctx context.Context, userID int) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 func (m *mockNotificationRepository) CreateGroup(ctx context.Context, g domain.NotificationGroup) (domain.NotificationGroup, error) {
@@ -65,6 +95,29 @@ func (m *mockNotificationRepository) CreateNotificationsBulk(ctx context.Context
 	return args.Error(0)
 }
 
+func (m *mockNotificationRepository) CreateGroupWithNotifications(ctx context.Context, g domain.NotificationGroup, ns []domain.Notification) (domain.NotificationGroup, error) {
+	args := m.Called(ctx, g, ns)
+	return args.Get(0).(domain.NotificationGroup), args.Error(1)
+}
+
+func (m *mockNotificationRepository) MissingUserIDs(ctx context.Context, ids []int) ([]int, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *mockNotificationRepository) DeleteByUserID(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockNotificationRepository) DeleteGroupsByUserID(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 func (m *mockNotificationRepository) AllUserIDs(ctx context.Context) ([]int, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -142,6 +195,40 @@ func TestNotificationService_List(t *testing.T) {
 	}
 }
 
+// tenantNotificationRepository нь хадгалсан мэдэгдлүүдийг tenant, user-ээр шүүнэ
+type tenantNotificationRepository struct {
+	mockNotificationRepository
+	items []domain.Notification
+}
+
+func (r *tenantNotificationRepository) ListByTenant(ctx context.Context, tenantID string, userID int, p common.PaginationQuery) ([]domain.Notification, int64, int, int, error) {
+	var out []domain.Notification
+	for _, n := range r.items {
+		if n.TenantID == tenantID && n.UserId == userID {
+			out = append(out, n)
+		}
+	}
+	return out, int64(len(out)), p.Page, p.Size, nil
+}
+
+func TestNotificationService_List_TenantScoped(t *testing.T) {
+	repo := &tenantNotificationRepository{items: []domain.Notification{
+		{Id: 1, UserId: 1, TenantID: "gerege", Title: "own"},
+		{Id: 2, UserId: 1, TenantID: "other", Title: "foreign"},
+	}}
+	svc := service.NewNotificationService(repo, &config.Config{})
+
+	ctx := requestctx.WithTenantID(context.Background(), "gerege")
+	notifications, total, _, _, err := svc.List(ctx, 1, common.PaginationQuery{Page: 1, Size: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, 1, notifications[0].Id)
+	// Tenant байхад tenant-гүй ListByUser дуудагдахгүй
+	repo.AssertNotCalled(t, "ListByUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestNotificationService_Groups(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -256,7 +343,7 @@ func TestNotificationService_MarkAllRead(t *testing.T) {
 			name:   "success - all marked as read",
 			userID: 1,
 			mockSetup: func(m *mockNotificationRepository) {
-				m.On("MarkAllRead", mock.Anything, 1).Return(nil)
+				m.On("MarkAllRead", mock.Anything, 1).Return(int64(3), nil)
 			},
 			wantErr: false,
 		},
@@ -264,7 +351,7 @@ func TestNotificationService_MarkAllRead(t *testing.T) {
 			name:   "error - db error",
 			userID: 2,
 			mockSetup: func(m *mockNotificationRepository) {
-				m.On("MarkAllRead", mock.Anything, 2).Return(errors.New("db error"))
+				m.On("MarkAllRead", mock.Anything, 2).Return(int64(0), errors.New("db error"))
 			},
 			wantErr: true,
 		},
@@ -289,3 +376,354 @@ func TestNotificationService_MarkAllRead(t *testing.T) {
 		})
 	}
 }
+
+func TestNotificationService_MarkReadBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         dto.NotificationReadBatchDto
+		mockSetup   func(*mockNotificationRepository)
+		wantUpdated int64
+		wantErr     error
+	}{
+		{
+			name: "all - marks every unread notification",
+			req:  dto.NotificationReadBatchDto{All: true},
+			mockSetup: func(m *mockNotificationRepository) {
+				m.On("MarkAllRead", mock.Anything, 1).Return(int64(12), nil)
+			},
+			wantUpdated: 12,
+		},
+		{
+			name: "all - ids are ignored",
+			req:  dto.NotificationReadBatchDto{All: true, Ids: []int{1, 2}},
+			mockSetup: func(m *mockNotificationRepository) {
+				m.On("MarkAllRead", mock.Anything, 1).Return(int64(4), nil)
+			},
+			wantUpdated: 4,
+		},
+		{
+			name: "ids - marks only given notifications",
+			req:  dto.NotificationReadBatchDto{Ids: []int{1, 2, 3}},
+			mockSetup: func(m *mockNotificationRepository) {
+				m.On("MarkReadByIDs", mock.Anything, 1, []int{1, 2, 3}).Return(int64(2), nil)
+			},
+			wantUpdated: 2,
+		},
+		{
+			name:      "error - neither ids nor all",
+			req:       dto.NotificationReadBatchDto{},
+			mockSetup: func(m *mockNotificationRepository) {},
+			wantErr:   domain.ErrInvalidInput,
+		},
+		{
+			name: "error - db error",
+			req:  dto.NotificationReadBatchDto{Ids: []int{5}},
+			mockSetup: func(m *mockNotificationRepository) {
+				m.On("MarkReadByIDs", mock.Anything, 1, []int{5}).Return(int64(0), errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNotificationRepository{}
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+			updated, err := svc.MarkReadBatch(context.Background(), 1, tt.req)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				if errors.Is(tt.wantErr, domain.ErrInvalidInput) {
+					assert.ErrorIs(t, err, domain.ErrInvalidInput)
+				}
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantUpdated, updated)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationService_Stats(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*mockNotificationRepository)
+		want      dto.NotificationStatsResponse
+		wantErr   bool
+	}{
+		{
+			name: "success - sums counts by type",
+			mockSetup: func(m *mockNotificationRepository) {
+				m.On("CountByType", mock.Anything, 1).Return(map[string]int64{"info": 5, "warning": 7}, nil)
+			},
+			want: dto.NotificationStatsResponse{
+				TotalUnread: 12,
+				ByType:      map[string]int64{"info": 5, "warning": 7},
+				HasUnread:   true,
+			},
+		},
+		{
+			name: "success - nothing unread",
+			mockSetup: func(m *mockNotificationRepository) {
+				m.On("CountByType", mock.Anything, 1).Return(map[string]int64{}, nil)
+			},
+			want: dto.NotificationStatsResponse{ByType: map[string]int64{}},
+		},
+		{
+			name: "error - db error",
+			mockSetup: func(m *mockNotificationRepository) {
+				m.On("CountByType", mock.Anything, 1).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNotificationRepository{}
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+			got, err := svc.Stats(context.Background(), 1)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// fakeNotificationConn records notifications written by the hub
+type fakeNotificationConn struct {
+	got      []interface{}
+	err      error
+	deadline time.Time
+}
+
+func (f *fakeNotificationConn) SetWriteDeadline(t time.Time) error {
+	f.deadline = t
+	return nil
+}
+
+func (f *fakeNotificationConn) WriteJSON(v interface{}) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.got = append(f.got, v)
+	return nil
+}
+
+func TestNotificationService_Push(t *testing.T) {
+	t.Run("connected user - delivered over hub", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+		conn := &fakeNotificationConn{}
+		svc.Hub().Register(1, conn)
+
+		err := svc.Push(context.Background(), 1, domain.Notification{Title: "Hi"})
+
+		require.NoError(t, err)
+		require.Len(t, conn.got, 1)
+		assert.Equal(t, 1, conn.got[0].(domain.Notification).UserId)
+		mockRepo.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+	})
+
+	t.Run("disconnected user - persisted", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n domain.Notification) bool {
+			return n.UserId == 2 && n.Title == "Hi"
+		})).Return(domain.Notification{Id: 1}, nil)
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+		err := svc.Push(context.Background(), 2, domain.Notification{Title: "Hi"})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("broken connection - dropped and persisted", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("CreateNotification", mock.Anything, mock.Anything).Return(domain.Notification{Id: 1}, nil)
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+		svc.Hub().Register(3, &fakeNotificationConn{err: errors.New("closed")})
+
+		err := svc.Push(context.Background(), 3, domain.Notification{Title: "Hi"})
+
+		require.NoError(t, err)
+		assert.False(t, svc.Hub().Connected(3))
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNotificationService_CreateGroup(t *testing.T) {
+	t.Run("success - one notification per unique user, default type", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("MissingUserIDs", mock.Anything, []int{1, 2, 3}).Return(nil, nil)
+		mockRepo.On("CreateGroupWithNotifications", mock.Anything,
+			domain.NotificationGroup{Title: "Hi", Content: "Body", Type: "info", CreatedUsername: "admin"},
+			mock.MatchedBy(func(ns []domain.Notification) bool {
+				return len(ns) == 3 && ns[0].UserId == 1 && ns[1].UserId == 2 && ns[2].UserId == 3 &&
+					ns[0].Type == "info" && ns[0].Title == "Hi" && ns[0].CreatedUsername == "admin"
+			}),
+		).Return(domain.NotificationGroup{Id: 9}, nil)
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+		conn := &fakeNotificationConn{}
+		svc.Hub().Register(2, conn)
+
+		err := svc.CreateGroup(context.Background(), dto.NotificationGroupDto{
+			UserIDs: []int{1, 2, 2, 3},
+			Title:   "Hi",
+			Content: "Body",
+		}, "admin")
+
+		require.NoError(t, err)
+		require.Len(t, conn.got, 1, "connected user receives it over the hub")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - empty user_ids", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+		err := svc.CreateGroup(context.Background(), dto.NotificationGroupDto{Title: "Hi"}, "admin")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "CreateGroupWithNotifications", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - unknown user ids rejected before insert", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("MissingUserIDs", mock.Anything, []int{1, 4, 7}).Return([]int{4, 7}, nil)
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+		err := svc.CreateGroup(context.Background(), dto.NotificationGroupDto{UserIDs: []int{1, 4, 7}, Title: "Hi"}, "admin")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "[4 7]")
+		mockRepo.AssertNotCalled(t, "CreateGroupWithNotifications", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - repository failure", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("MissingUserIDs", mock.Anything, mock.Anything).Return(nil, nil)
+		mockRepo.On("CreateGroupWithNotifications", mock.Anything, mock.Anything, mock.Anything).
+			Return(domain.NotificationGroup{}, errors.New("db down"))
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+		err := svc.CreateGroup(context.Background(), dto.NotificationGroupDto{UserIDs: []int{1}, Title: "Hi", Type: "warning"}, "admin")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNotificationService_TenantID(t *testing.T) {
+	t.Run("push - tenant from context", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n domain.Notification) bool {
+			return n.UserId == 2 && n.TenantID == "gerege"
+		})).Return(domain.Notification{Id: 1}, nil)
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+		ctx := requestctx.WithTenantID(context.Background(), "gerege")
+		err := svc.Push(ctx, 2, domain.Notification{Title: "Hi", TenantID: "ignored"})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("push - no tenant in context keeps notification tenant", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n domain.Notification) bool {
+			return n.TenantID == "own"
+		})).Return(domain.Notification{Id: 1}, nil)
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+		err := svc.Push(context.Background(), 2, domain.Notification{Title: "Hi", TenantID: "own"})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("create group - every notification stamped", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("MissingUserIDs", mock.Anything, mock.Anything).Return(nil, nil)
+		mockRepo.On("CreateGroupWithNotifications", mock.Anything,
+			mock.MatchedBy(func(g domain.NotificationGroup) bool { return g.Tenant == "gerege" }),
+			mock.MatchedBy(func(ns []domain.Notification) bool {
+				for _, n := range ns {
+					if n.TenantID != "gerege" {
+						return false
+					}
+				}
+				return len(ns) == 2
+			}),
+		).Return(domain.NotificationGroup{Id: 9}, nil)
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+		ctx := requestctx.WithTenantID(context.Background(), "gerege")
+		err := svc.CreateGroup(ctx, dto.NotificationGroupDto{UserIDs: []int{1, 2}, Title: "Hi"}, "admin")
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+	t.Run("send - tenant mismatch rejected", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+		ctx := requestctx.WithTenantID(context.Background(), "gerege")
+		err := svc.Send(ctx, dto.NotificationSendDto{Tenant: "other", UserID: 2, Title: "Hi"}, "admin")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything)
+	})
+
+	t.Run("send - group stores the server tenant", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		// Group үүсгэсний дараах socket дуудлагад хүрэхгүйн тулд алдаа буцаана
+		mockRepo.On("CreateGroup", mock.Anything, mock.MatchedBy(func(g domain.NotificationGroup) bool {
+			return g.Tenant == "gerege"
+		})).Return(domain.NotificationGroup{}, errors.New("stop"))
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+
+		ctx := requestctx.WithTenantID(context.Background(), "gerege")
+		err := svc.Send(ctx, dto.NotificationSendDto{Tenant: "gerege", UserID: 2, Title: "Hi"}, "admin")
+
+		assert.EqualError(t, err, "stop")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNotificationService_UnreadCount(t *testing.T) {
+	t.Run("success - returns repository count", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("UnreadCount", mock.Anything, 1).Return(int64(12), nil)
+
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+		n, err := svc.UnreadCount(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), n)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - db error", func(t *testing.T) {
+		mockRepo := &mockNotificationRepository{}
+		mockRepo.On("UnreadCount", mock.Anything, 1).Return(int64(0), errors.New("db error"))
+
+		svc := service.NewNotificationService(mockRepo, &config.Config{})
+		_, err := svc.UnreadCount(context.Background(), 1)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,69 @@
+// Package service provides implementation for service
+//
+// File: notification_subscriber_test.go
+// Description: Unit tests for the event bus notification subscriber
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/event"
+	"templatev25/internal/service"
+
+	"git.gerege.mn/backend-packages/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSubscriber_UserAddedToOrg(t *testing.T) {
+	tests := []struct {
+		name     string
+		storeErr error
+	}{
+		{name: "success - welcome notification stored"},
+		{name: "error - store failure is only logged", storeErr: errors.New("db error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockNotificationRepository{}
+			repo.On("CreateNotification", mock.Anything, mock.Anything).Return(domain.Notification{}, tt.storeErr)
+
+			bus := event.NewInMemoryBus(4, nil)
+			svc := service.NewNotificationService(repo, &config.Config{})
+			service.NewNotificationSubscriber(svc, nil).Register(bus)
+
+			bus.Publish(event.UserAddedToOrgEvent{OrgID: 3, UserID: 42})
+			bus.Close()
+
+			// Хэрэглэгч WebSocket-оор холбогдоогүй тул DB-д хадгалагдана
+			repo.AssertNumberOfCalls(t, "CreateNotification", 1)
+			n := repo.Calls[0].Arguments.Get(1).(domain.Notification)
+			assert.Equal(t, 42, n.UserId)
+			assert.Equal(t, "dm", n.Type)
+			assert.Contains(t, n.Content, "#3")
+		})
+	}
+}
+
+func TestNotificationSubscriber_IgnoresOtherEvents(t *testing.T) {
+	repo := &mockNotificationRepository{}
+
+	bus := event.NewInMemoryBus(4, nil)
+	svc := service.NewNotificationService(repo, &config.Config{})
+	service.NewNotificationSubscriber(svc, nil).Register(bus)
+
+	bus.Publish(otherEvent{})
+	bus.Close()
+
+	require.Empty(t, repo.Calls)
+}
+
+// otherEvent нь UserAddedToOrgEvent-ийн type-тай ижил боловч өөр бүтэцтэй event
+type otherEvent struct{}
+
+func (otherEvent) Type() string { return event.TypeUserAddedToOrg }
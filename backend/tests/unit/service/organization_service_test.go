@@ -8,16 +8,23 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"templatev25/internal/domain"
+	"templatev25/internal/event"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/repository"
 	"templatev25/internal/service"
+	"templatev25/tests/mocks"
 
 	"git.gerege.mn/backend-packages/common"
+	"git.gerege.mn/backend-packages/config"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 // mockOrganizationRepository for testing
@@ -53,7 +60,25 @@ func (m *mockOrganizationRepository) ByID(ctx context.Context, id int) (domain.O
 	return args.Get(0).(domain.Organization), args.Error(1)
 }
 
-func (m *mockOrganizationRepository) Tree(ctx context.Context, rootID int) ([]domain.Organization, error) {
+func (m *mockOrganizationRepository) ByIDWithDetail(ctx context.Context, id int) (dto.OrganizationDetail, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(dto.OrganizationDetail), args.Error(1)
+}
+
+func (m *mockOrganizationRepository) Exists(ctx context.Context, id int) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockOrganizationRepository) Search(ctx context.Context, query string, p common.PaginationQuery) ([]domain.Organization, int64, int, int, error) {
+	args := m.Called(ctx, query, p)
+	if args.Get(0) == nil {
+		return nil, 0, 0, 0, args.Error(4)
+	}
+	return args.Get(0).([]domain.Organization), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
+}
+
+func (m *mockOrganizationRepository) TreeCTE(ctx context.Context, rootID int) ([]domain.Organization, error) {
 	args := m.Called(ctx, rootID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -61,6 +86,27 @@ func (m *mockOrganizationRepository) Tree(ctx context.Context, rootID int) ([]do
 	return args.Get(0).([]domain.Organization), args.Error(1)
 }
 
+func (m *mockOrganizationRepository) Stats(ctx context.Context) ([]dto.OrgTypeStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.OrgTypeStats), args.Error(1)
+}
+
+func (m *mockOrganizationRepository) MoveToParent(ctx context.Context, orgID, newParentID int) error {
+	return m.Called(ctx, orgID, newParentID).Error(0)
+}
+
+func (m *mockOrganizationRepository) DeletedByID(ctx context.Context, id int) (domain.Organization, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Organization), args.Error(1)
+}
+
+func (m *mockOrganizationRepository) Restore(ctx context.Context, id int) error {
+	return m.Called(ctx, id).Error(0)
+}
+
 func TestOrganizationService_List(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -110,7 +156,7 @@ func TestOrganizationService_List(t *testing.T) {
 			mockRepo := &mockOrganizationRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewOrganizationService(mockRepo, zap.NewNop())
+			svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
 
 			orgs, _, _, _, err := svc.List(context.Background(), tt.query)
 
@@ -177,7 +223,7 @@ func TestOrganizationService_Create(t *testing.T) {
 			mockRepo := &mockOrganizationRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewOrganizationService(mockRepo, zap.NewNop())
+			svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
 
 			_, err := svc.Create(context.Background(), tt.input)
 
@@ -232,7 +278,7 @@ func TestOrganizationService_Update(t *testing.T) {
 			mockRepo := &mockOrganizationRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewOrganizationService(mockRepo, zap.NewNop())
+			svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
 
 			_, err := svc.Update(context.Background(), tt.orgID, tt.input)
 
@@ -247,6 +293,66 @@ func TestOrganizationService_Update(t *testing.T) {
 	}
 }
 
+func TestOrganizationService_Update_VersionConflict(t *testing.T) {
+	mockRepo := &mockOrganizationRepository{}
+	svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
+
+	// Хоёр админ version=3-ийг уншаад зэрэг хадгална: эхнийх нь амжиж,
+	// хоёр дахь нь хуучирсан version-тэй тул conflict авна.
+	mockRepo.On("Update", mock.Anything, 1, mock.MatchedBy(func(o domain.Organization) bool {
+		return o.Name == "First" && o.Version == 3
+	})).Return(domain.Organization{Id: 1, Name: "First", Version: 4}, nil).Once()
+	mockRepo.On("Update", mock.Anything, 1, mock.MatchedBy(func(o domain.Organization) bool {
+		return o.Name == "Second" && o.Version == 3
+	})).Return(domain.Organization{}, domain.NewConflict("organization was modified by another request", nil)).Once()
+
+	first, err := svc.Update(context.Background(), 1, dto.OrganizationUpdateDto{Name: "First", Version: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 4, first.Version)
+
+	_, err = svc.Update(context.Background(), 1, dto.OrganizationUpdateDto{Name: "Second", Version: 3})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrganizationService_Update_InvalidatesPermissionsOnTypeChange(t *testing.T) {
+	tests := []struct {
+		name           string
+		oldTypeID      int
+		newTypeID      int
+		wantInvalidate bool
+	}{
+		{name: "type changed - cache invalidated", oldTypeID: 2, newTypeID: 3, wantInvalidate: true},
+		{name: "type unchanged - cache kept", oldTypeID: 2, newTypeID: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockOrganizationRepository{}
+			cache := &mockCacheInvalidator{}
+			mockRepo.On("ByID", mock.Anything, 1).Return(domain.Organization{Id: 1, TypeId: tt.oldTypeID}, nil)
+			mockRepo.On("Update", mock.Anything, 1, mock.AnythingOfType("domain.Organization")).
+				Return(domain.Organization{Id: 1, TypeId: tt.newTypeID}, nil)
+			if tt.wantInvalidate {
+				cache.On("InvalidateAll").Return()
+			}
+
+			svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
+			svc.SetCacheInvalidator(cache)
+
+			_, err := svc.Update(context.Background(), 1, dto.OrganizationUpdateDto{Name: "Org", TypeId: tt.newTypeID})
+
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+			cache.AssertExpectations(t)
+			if !tt.wantInvalidate {
+				cache.AssertNotCalled(t, "InvalidateAll")
+			}
+		})
+	}
+}
+
 func TestOrganizationService_Delete(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -277,17 +383,24 @@ func TestOrganizationService_Delete(t *testing.T) {
 			mockRepo := &mockOrganizationRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewOrganizationService(mockRepo, zap.NewNop())
+			cache := &mockCacheInvalidator{}
+			if !tt.wantErr {
+				cache.On("InvalidateAll").Return()
+			}
+			svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
+			svc.SetCacheInvalidator(cache)
 
 			err := svc.Delete(context.Background(), tt.orgID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				cache.AssertNotCalled(t, "InvalidateAll")
 			} else {
 				assert.NoError(t, err)
 			}
 
 			mockRepo.AssertExpectations(t)
+			cache.AssertExpectations(t)
 		})
 	}
 }
@@ -325,7 +438,7 @@ func TestOrganizationService_ByID(t *testing.T) {
 			mockRepo := &mockOrganizationRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewOrganizationService(mockRepo, zap.NewNop())
+			svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
 
 			org, err := svc.ByID(context.Background(), tt.orgID)
 
@@ -341,36 +454,76 @@ func TestOrganizationService_ByID(t *testing.T) {
 	}
 }
 
+func TestOrganizationService_GetByID(t *testing.T) {
+	t.Run("success - returns detail", func(t *testing.T) {
+		mockRepo := &mockOrganizationRepository{}
+		want := dto.OrganizationDetail{
+			Organization:  domain.Organization{Id: 1, Name: "Test Org"},
+			TypeName:      "Company",
+			ChildrenCount: 2,
+			UserCount:     3,
+		}
+		mockRepo.On("ByIDWithDetail", mock.Anything, 1).Return(want, nil)
+
+		svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
+		got, err := svc.GetByID(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		mockRepo := &mockOrganizationRepository{}
+		mockRepo.On("ByIDWithDetail", mock.Anything, 999).
+			Return(dto.OrganizationDetail{}, domain.NewNotFound("organization not found", nil))
+
+		svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
+		_, err := svc.GetByID(context.Background(), 999)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
 func TestOrganizationService_Tree(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
 	tests := []struct {
 		name      string
 		rootID    int
 		mockSetup func(*mockOrganizationRepository)
-		wantCount int
-		wantErr   bool
+		wantErr   error
 	}{
 		{
-			name:   "success - returns tree",
+			name:   "success - nests CTE rows under root",
 			rootID: 1,
 			mockSetup: func(m *mockOrganizationRepository) {
+				// TreeCTE-ийн дараалал: depth, sequence, id
 				orgs := []domain.Organization{
-					{Id: 1, Name: "Parent"},
-					{Id: 2, Name: "Child1"},
-					{Id: 3, Name: "Child2"},
+					{Id: 1, Name: "Root"},
+					{Id: 2, Name: "Child1", ParentId: intPtr(1)},
+					{Id: 3, Name: "Child2", ParentId: intPtr(1)},
+					{Id: 4, Name: "Grandchild", ParentId: intPtr(2)},
 				}
-				m.On("Tree", mock.Anything, 1).Return(orgs, nil)
+				m.On("TreeCTE", mock.Anything, 1).Return(orgs, nil)
 			},
-			wantCount: 3,
-			wantErr:   false,
 		},
 		{
-			name:   "error - tree fetch fails",
+			name:   "error - root not found",
 			rootID: 999,
 			mockSetup: func(m *mockOrganizationRepository) {
-				m.On("Tree", mock.Anything, 999).Return(nil, errors.New("not found"))
+				m.On("TreeCTE", mock.Anything, 999).Return([]domain.Organization{}, nil)
 			},
-			wantCount: 0,
-			wantErr:   true,
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:   "error - tree fetch fails",
+			rootID: 5,
+			mockSetup: func(m *mockOrganizationRepository) {
+				m.On("TreeCTE", mock.Anything, 5).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
 		},
 	}
 
@@ -379,18 +532,464 @@ func TestOrganizationService_Tree(t *testing.T) {
 			mockRepo := &mockOrganizationRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewOrganizationService(mockRepo, zap.NewNop())
+			svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
 
 			orgs, err := svc.Tree(context.Background(), tt.rootID)
 
-			if tt.wantErr {
-				assert.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
 			} else {
-				assert.NoError(t, err)
-				assert.Len(t, orgs, tt.wantCount)
+				require.NoError(t, err)
+				require.Len(t, orgs, 1)
+				root := orgs[0]
+				assert.Equal(t, 1, root.Id)
+				require.NotNil(t, root.Children)
+				require.Len(t, *root.Children, 2)
+				child1, child2 := (*root.Children)[0], (*root.Children)[1]
+				assert.Equal(t, 2, child1.Id)
+				assert.Equal(t, 3, child2.Id)
+				require.NotNil(t, child1.Children)
+				require.Len(t, *child1.Children, 1)
+				assert.Equal(t, 4, (*child1.Children)[0].Id)
+				assert.Nil(t, (*child1.Children)[0].Children)
+				assert.Nil(t, child2.Children)
 			}
 
 			mockRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestOrganizationService_Tree_CycleInData(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	mockRepo := &mockOrganizationRepository{}
+	// root(1) ↔ 2 цикл: depth хязгаар хүртэл CTE ижил мөрүүдийг давтан буцаана
+	mockRepo.On("TreeCTE", mock.Anything, 1).Return([]domain.Organization{
+		{Id: 1, Name: "Root", ParentId: intPtr(2)},
+		{Id: 2, Name: "Child", ParentId: intPtr(1)},
+		{Id: 1, Name: "Root", ParentId: intPtr(2)},
+		{Id: 2, Name: "Child", ParentId: intPtr(1)},
+		{Id: 3, Name: "Grandchild", ParentId: intPtr(2)},
+		{Id: 3, Name: "Grandchild", ParentId: intPtr(2)},
+	}, nil)
+	svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
+
+	orgs, err := svc.Tree(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Len(t, orgs, 1)
+	require.NotNil(t, orgs[0].Children)
+	require.Len(t, *orgs[0].Children, 1)
+	child := (*orgs[0].Children)[0]
+	assert.Equal(t, 2, child.Id)
+	require.NotNil(t, child.Children)
+	require.Len(t, *child.Children, 1)
+	assert.Equal(t, 3, (*child.Children)[0].Id)
+	assert.Nil(t, (*child.Children)[0].Children)
+}
+
+func TestOrganizationService_Stats(t *testing.T) {
+	t.Run("success - returns repository stats", func(t *testing.T) {
+		mockRepo := &mockOrganizationRepository{}
+		stats := []dto.OrgTypeStats{
+			{TypeID: 1, TypeName: "ХХК", Count: 12, LatestCreated: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+			{TypeID: 2, TypeName: "ТББ", Count: 3},
+		}
+		mockRepo.On("Stats", mock.Anything).Return(stats, nil)
+		svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
+
+		got, err := svc.Stats(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, stats, got)
+	})
+
+	t.Run("error - repository fails", func(t *testing.T) {
+		mockRepo := &mockOrganizationRepository{}
+		mockRepo.On("Stats", mock.Anything).Return(nil, errors.New("db error"))
+		svc := service.NewOrganizationService(mockRepo, nil, zap.NewNop())
+
+		got, err := svc.Stats(context.Background())
+
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestOrganizationService_MoveToParent(t *testing.T) {
+	oldParent := 2
+
+	tests := []struct {
+		name        string
+		newParentID int
+		mockSetup   func(*mockOrganizationRepository, *mockAuditRepository)
+		wantErr     error
+		wantOld     string
+		wantNew     string
+	}{
+		{
+			name:        "success - audit has old and new parent",
+			newParentID: 5,
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 10).Return(domain.Organization{Id: 10, ParentId: &oldParent}, nil)
+				r.On("MoveToParent", mock.Anything, 10, 5).Return(nil)
+				a.On("CreateAuditTrail", mock.Anything, mock.AnythingOfType("*domain.SecurityAuditTrail")).Return(nil)
+			},
+			wantOld: `{"parent_id":2}`,
+			wantNew: `{"parent_id":5}`,
+		},
+		{
+			name:        "success - move to root",
+			newParentID: 0,
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 10).Return(domain.Organization{Id: 10, ParentId: &oldParent}, nil)
+				r.On("MoveToParent", mock.Anything, 10, 0).Return(nil)
+				a.On("CreateAuditTrail", mock.Anything, mock.AnythingOfType("*domain.SecurityAuditTrail")).Return(nil)
+			},
+			wantOld: `{"parent_id":2}`,
+			wantNew: `{"parent_id":null}`,
+		},
+		{
+			name:        "success - audit failure is not returned",
+			newParentID: 5,
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 10).Return(domain.Organization{Id: 10}, nil)
+				r.On("MoveToParent", mock.Anything, 10, 5).Return(nil)
+				a.On("CreateAuditTrail", mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			wantOld: `{"parent_id":null}`,
+			wantNew: `{"parent_id":5}`,
+		},
+		{
+			name:        "error - organization not found",
+			newParentID: 5,
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 10).Return(domain.Organization{}, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:        "error - circular reference, no audit",
+			newParentID: 5,
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 10).Return(domain.Organization{Id: 10}, nil)
+				r.On("MoveToParent", mock.Anything, 10, 5).Return(domain.NewInvalidInput("cycle", nil))
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockOrganizationRepository{}
+			mockAudit := &mockAuditRepository{}
+			tt.mockSetup(mockRepo, mockAudit)
+
+			svc := service.NewOrganizationService(mockRepo, mockAudit, zap.NewNop())
+
+			err := svc.MoveToParent(context.Background(), 10, tt.newParentID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockAudit.AssertNotCalled(t, "CreateAuditTrail", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				audit := mockAudit.Calls[0].Arguments.Get(1).(*domain.SecurityAuditTrail)
+				assert.Equal(t, "ORG_PARENT_CHANGED", audit.Action)
+				assert.Equal(t, "organization", audit.TargetType)
+				assert.Equal(t, "10", audit.TargetID)
+				assert.JSONEq(t, tt.wantOld, audit.OldValue)
+				assert.JSONEq(t, tt.wantNew, audit.NewValue)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrganizationService_Restore(t *testing.T) {
+	deletedAt := gorm.DeletedAt{Valid: true, Time: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)}
+	errDB := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		mockSetup func(*mockOrganizationRepository, *mockAuditRepository)
+		wantErr   error
+	}{
+		{
+			name: "success - restored and audited",
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("DeletedByID", mock.Anything, 10).Return(domain.Organization{Id: 10, ExtraFields: domain.ExtraFields{DeletedDate: deletedAt}}, nil)
+				r.On("Restore", mock.Anything, 10).Return(nil)
+				r.On("ByID", mock.Anything, 10).Return(domain.Organization{Id: 10, Name: "Org"}, nil)
+				a.On("CreateAuditTrail", mock.Anything, mock.AnythingOfType("*domain.SecurityAuditTrail")).Return(nil)
+			},
+		},
+		{
+			name: "success - audit failure is not returned",
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("DeletedByID", mock.Anything, 10).Return(domain.Organization{Id: 10, ExtraFields: domain.ExtraFields{DeletedDate: deletedAt}}, nil)
+				r.On("Restore", mock.Anything, 10).Return(nil)
+				r.On("ByID", mock.Anything, 10).Return(domain.Organization{Id: 10, Name: "Org"}, nil)
+				a.On("CreateAuditTrail", mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+		},
+		{
+			name: "error - organization is not deleted",
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("DeletedByID", mock.Anything, 10).Return(domain.Organization{}, domain.NewNotFound("deleted organization not found", nil))
+				r.On("Exists", mock.Anything, 10).Return(true, nil)
+			},
+			wantErr: domain.ErrConflict,
+		},
+		{
+			name: "error - organization does not exist",
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("DeletedByID", mock.Anything, 10).Return(domain.Organization{}, domain.NewNotFound("deleted organization not found", nil))
+				r.On("Exists", mock.Anything, 10).Return(false, nil)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "error - restore failed, no audit",
+			mockSetup: func(r *mockOrganizationRepository, a *mockAuditRepository) {
+				r.On("DeletedByID", mock.Anything, 10).Return(domain.Organization{Id: 10, ExtraFields: domain.ExtraFields{DeletedDate: deletedAt}}, nil)
+				r.On("Restore", mock.Anything, 10).Return(errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockOrganizationRepository{}
+			mockAudit := &mockAuditRepository{}
+			cache := &mockCacheInvalidator{}
+			tt.mockSetup(mockRepo, mockAudit)
+			if tt.wantErr == nil {
+				cache.On("InvalidateAll").Return()
+			}
+
+			svc := service.NewOrganizationService(mockRepo, mockAudit, zap.NewNop())
+			svc.SetCacheInvalidator(cache)
+
+			org, err := svc.Restore(context.Background(), 10)
+
+			cache.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "ByID", mock.Anything, mock.Anything)
+				mockAudit.AssertNotCalled(t, "CreateAuditTrail", mock.Anything, mock.Anything)
+				cache.AssertNotCalled(t, "InvalidateAll")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "Org", org.Name)
+				audit := mockAudit.Calls[0].Arguments.Get(1).(*domain.SecurityAuditTrail)
+				assert.Equal(t, "ORG_RESTORED", audit.Action)
+				assert.Equal(t, "organization", audit.TargetType)
+				assert.Equal(t, "10", audit.TargetID)
+				assert.Contains(t, audit.OldValue, "2025-03-01")
+				assert.JSONEq(t, `{"deleted_date":null}`, audit.NewValue)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+// mockOrgUserRepository covers the methods used by OrgUserService.Add
+type mockOrgUserRepository struct {
+	repository.OrgUserRepository
+	mock.Mock
+}
+
+func (m *mockOrgUserRepository) FindByOrgAndUser(ctx context.Context, orgId, userId int) (domain.OrganizationUser, error) {
+	args := m.Called(ctx, orgId, userId)
+	return args.Get(0).(domain.OrganizationUser), args.Error(1)
+}
+
+func (m *mockOrgUserRepository) OrgExists(ctx context.Context, orgId int) (bool, error) {
+	args := m.Called(ctx, orgId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockOrgUserRepository) UserExists(ctx context.Context, userId int) (bool, error) {
+	args := m.Called(ctx, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockOrgUserRepository) Add(ctx context.Context, ou domain.OrganizationUser) error {
+	return m.Called(ctx, ou).Error(0)
+}
+
+func (m *mockOrgUserRepository) Remove(ctx context.Context, orgId, userId int) error {
+	return m.Called(ctx, orgId, userId).Error(0)
+}
+
+// mockEventBus records published events
+type mockEventBus struct {
+	published []event.Event
+}
+
+func (b *mockEventBus) Publish(e event.Event) { b.published = append(b.published, e) }
+
+func (b *mockEventBus) Subscribe(string, func(event.Event)) func() { return func() {} }
+
+func TestOrgUserService_Add_PublishesEvent(t *testing.T) {
+	req := dto.OrgUserCreateDto{OrgId: 3, UserId: 42}
+
+	tests := []struct {
+		name        string
+		addErr      error
+		wantPublish bool
+	}{
+		{name: "success - event published", wantPublish: true},
+		{name: "error - add failed, no event", addErr: errors.New("db error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockOrgUserRepository{}
+			repo.On("FindByOrgAndUser", mock.Anything, 3, 42).Return(domain.OrganizationUser{}, domain.ErrNotFound)
+			repo.On("OrgExists", mock.Anything, 3).Return(true, nil)
+			repo.On("UserExists", mock.Anything, 42).Return(true, nil)
+			repo.On("Add", mock.Anything, domain.OrganizationUser{OrgId: 3, UserId: 42}).Return(tt.addErr)
+
+			bus := &mockEventBus{}
+			svc := service.NewOrgUserService(repo, &config.Config{}, nil)
+			svc.SetEventBus(bus)
+
+			err := svc.Add(context.Background(), req, "")
+
+			if !tt.wantPublish {
+				assert.Error(t, err)
+				assert.Empty(t, bus.published)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, bus.published, 1)
+			ev, ok := bus.published[0].(event.UserAddedToOrgEvent)
+			require.True(t, ok)
+			assert.Equal(t, 3, ev.OrgID)
+			assert.Equal(t, 42, ev.UserID)
+			assert.False(t, ev.OccurredAt.IsZero())
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrgUserService_Add_WithoutEventBus(t *testing.T) {
+	repo := &mockOrgUserRepository{}
+	repo.On("FindByOrgAndUser", mock.Anything, 3, 42).Return(domain.OrganizationUser{}, domain.ErrNotFound)
+	repo.On("OrgExists", mock.Anything, 3).Return(true, nil)
+	repo.On("UserExists", mock.Anything, 42).Return(true, nil)
+	repo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+	svc := service.NewOrgUserService(repo, &config.Config{}, nil)
+
+	assert.NoError(t, svc.Add(context.Background(), dto.OrgUserCreateDto{OrgId: 3, UserId: 42}, ""))
+}
+
+func TestOrgUserService_InvalidatesMemberPermissions(t *testing.T) {
+	t.Run("add", func(t *testing.T) {
+		repo := &mockOrgUserRepository{}
+		repo.On("FindByOrgAndUser", mock.Anything, 3, 42).Return(domain.OrganizationUser{}, domain.ErrNotFound)
+		repo.On("OrgExists", mock.Anything, 3).Return(true, nil)
+		repo.On("UserExists", mock.Anything, 42).Return(true, nil)
+		repo.On("Add", mock.Anything, mock.Anything).Return(nil)
+		cache := &mockCacheInvalidator{}
+		cache.On("InvalidateUser", 42).Return()
+
+		svc := service.NewOrgUserService(repo, &config.Config{}, nil)
+		svc.SetCacheInvalidator(cache)
+
+		require.NoError(t, svc.Add(context.Background(), dto.OrgUserCreateDto{OrgId: 3, UserId: 42}, ""))
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		repo := &mockOrgUserRepository{}
+		repo.On("Remove", mock.Anything, 3, 42).Return(nil)
+		cache := &mockCacheInvalidator{}
+		cache.On("InvalidateUser", 42).Return()
+
+		svc := service.NewOrgUserService(repo, &config.Config{}, nil)
+		svc.SetCacheInvalidator(cache)
+
+		require.NoError(t, svc.Remove(context.Background(), dto.OrgUserDeleteDto{OrgId: 3, UserId: 42}))
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("remove failed - cache kept", func(t *testing.T) {
+		repo := &mockOrgUserRepository{}
+		repo.On("Remove", mock.Anything, 3, 42).Return(errors.New("db error"))
+		cache := &mockCacheInvalidator{}
+
+		svc := service.NewOrgUserService(repo, &config.Config{}, nil)
+		svc.SetCacheInvalidator(cache)
+
+		assert.Error(t, svc.Remove(context.Background(), dto.OrgUserDeleteDto{OrgId: 3, UserId: 42}))
+		cache.AssertNotCalled(t, "InvalidateUser", mock.Anything)
+	})
+}
+
+func TestOrgUserService_Add_RoleLimit(t *testing.T) {
+	allowed := []domain.Role{{Code: "ORG_ADMIN"}, {Code: "ORG_MEMBER"}}
+
+	tests := []struct {
+		name      string
+		enforce   bool
+		userRoles []string
+		wantErr   error
+		wantAdd   bool
+	}{
+		{name: "enforcement on - roles allowed", enforce: true, userRoles: []string{"ORG_MEMBER"}, wantAdd: true},
+		{name: "enforcement on - no roles", enforce: true, userRoles: []string{}, wantAdd: true},
+		{name: "enforcement on - role disallowed", enforce: true, userRoles: []string{"ORG_MEMBER", "SUPER_ADMIN"}, wantErr: domain.ErrForbidden},
+		{name: "enforcement off - any role", enforce: false, userRoles: []string{"SUPER_ADMIN"}, wantAdd: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockOrgUserRepository{}
+			repo.On("FindByOrgAndUser", mock.Anything, 3, 42).Return(domain.OrganizationUser{}, domain.ErrNotFound)
+			repo.On("OrgExists", mock.Anything, 3).Return(true, nil)
+			if tt.wantAdd {
+				repo.On("UserExists", mock.Anything, 42).Return(true, nil)
+				repo.On("Add", mock.Anything, domain.OrganizationUser{OrgId: 3, UserId: 42}).Return(nil)
+			}
+
+			orgs := &mockOrganizationRepository{}
+			orgs.On("ByID", mock.Anything, 3).Return(domain.Organization{
+				Id:     3,
+				TypeId: 7,
+				Type:   &domain.OrganizationType{Id: 7, Code: "SCHOOL", EnforceRoleLimit: tt.enforce},
+			}, nil)
+
+			orgTypes := &mockOrganizationTypeRepository{}
+			userRoles := mocks.NewUserRoleRepository(t)
+			if tt.enforce {
+				orgTypes.On("Roles", mock.Anything, 7).Return(allowed, nil)
+				userRoles.On("GetRoleCodes", mock.Anything, 42).Return(tt.userRoles, nil)
+			}
+
+			svc := service.NewOrgUserService(repo, &config.Config{}, nil)
+			svc.SetRoleLimit(orgs, orgTypes, userRoles)
+
+			err := svc.Add(context.Background(), dto.OrgUserCreateDto{OrgId: 3, UserId: 42}, "")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Contains(t, err.Error(), "SUPER_ADMIN")
+				repo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+			orgs.AssertExpectations(t)
+			orgTypes.AssertExpectations(t)
+		})
+	}
+}
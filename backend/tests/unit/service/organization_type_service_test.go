@@ -0,0 +1,270 @@
+// Package service provides implementation for service
+//
+// File: organization_type_service_test.go
+// Description: Unit tests for organization type service
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"git.gerege.mn/backend-packages/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// mockOrganizationTypeRepository for testing
+type mockOrganizationTypeRepository struct {
+	mock.Mock
+}
+
+func (m *mockOrganizationTypeRepository) List(ctx context.Context, p common.PaginationQuery) ([]domain.OrganizationType, int64, int, int, error) {
+	args := m.Called(ctx, p)
+	if args.Get(0) == nil {
+		return nil, 0, 0, 0, args.Error(4)
+	}
+	return args.Get(0).([]domain.OrganizationType), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
+}
+
+func (m *mockOrganizationTypeRepository) ForceRefresh(ctx context.Context) ([]domain.OrganizationType, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrganizationType), args.Error(1)
+}
+
+func (m *mockOrganizationTypeRepository) Create(ctx context.Context, t domain.OrganizationType) error {
+	return m.Called(ctx, t).Error(0)
+}
+
+func (m *mockOrganizationTypeRepository) Update(ctx context.Context, id int, t domain.OrganizationType) error {
+	return m.Called(ctx, id, t).Error(0)
+}
+
+func (m *mockOrganizationTypeRepository) Delete(ctx context.Context, id int, reason string) error {
+	return m.Called(ctx, id, reason).Error(0)
+}
+
+func (m *mockOrganizationTypeRepository) ByID(ctx context.Context, id int) (domain.OrganizationType, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.OrganizationType), args.Error(1)
+}
+
+func (m *mockOrganizationTypeRepository) AddSystems(ctx context.Context, orgTypeID int, systemIDs []int) error {
+	return m.Called(ctx, orgTypeID, systemIDs).Error(0)
+}
+
+func (m *mockOrganizationTypeRepository) Systems(ctx context.Context, orgTypeID int) ([]domain.System, error) {
+	args := m.Called(ctx, orgTypeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.System), args.Error(1)
+}
+
+func (m *mockOrganizationTypeRepository) AddRoles(ctx context.Context, orgTypeID int, roleIDs []int) error {
+	return m.Called(ctx, orgTypeID, roleIDs).Error(0)
+}
+
+func (m *mockOrganizationTypeRepository) Roles(ctx context.Context, orgTypeID int) ([]domain.Role, error) {
+	args := m.Called(ctx, orgTypeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Role), args.Error(1)
+}
+
+func (m *mockOrganizationTypeRepository) AddPermissions(ctx context.Context, orgTypeID int, permissionIDs []int) error {
+	return m.Called(ctx, orgTypeID, permissionIDs).Error(0)
+}
+
+func (m *mockOrganizationTypeRepository) Permissions(ctx context.Context, orgTypeID int) ([]domain.Permission, error) {
+	args := m.Called(ctx, orgTypeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Permission), args.Error(1)
+}
+
+// mockAuditRepository records audit trail entries; other AuthRepository methods are not used
+type mockAuditRepository struct {
+	repository.AuthRepository
+	mock.Mock
+}
+
+func (m *mockAuditRepository) CreateAuditTrail(ctx context.Context, audit *domain.SecurityAuditTrail) error {
+	return m.Called(ctx, audit).Error(0)
+}
+
+func TestOrganizationTypeService_Delete(t *testing.T) {
+	orgType := domain.OrganizationType{Id: 7, Code: "GOV", Name: "Government"}
+
+	tests := []struct {
+		name      string
+		reason    string
+		mockSetup func(*mockOrganizationTypeRepository, *mockAuditRepository)
+		wantErr   bool
+		wantAudit bool
+	}{
+		{
+			name:   "success - without reason still writes audit",
+			reason: "",
+			mockSetup: func(r *mockOrganizationTypeRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 7).Return(orgType, nil)
+				r.On("Delete", mock.Anything, 7, "").Return(nil)
+				a.On("CreateAuditTrail", mock.Anything, mock.AnythingOfType("*domain.SecurityAuditTrail")).Return(nil)
+			},
+			wantAudit: true,
+		},
+		{
+			name:   "success - with reason",
+			reason: "duplicate type",
+			mockSetup: func(r *mockOrganizationTypeRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 7).Return(orgType, nil)
+				r.On("Delete", mock.Anything, 7, "duplicate type").Return(nil)
+				a.On("CreateAuditTrail", mock.Anything, mock.AnythingOfType("*domain.SecurityAuditTrail")).Return(nil)
+			},
+			wantAudit: true,
+		},
+		{
+			name:   "success - audit failure does not fail delete",
+			reason: "",
+			mockSetup: func(r *mockOrganizationTypeRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 7).Return(orgType, nil)
+				r.On("Delete", mock.Anything, 7, "").Return(nil)
+				a.On("CreateAuditTrail", mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			wantAudit: true,
+		},
+		{
+			name:   "error - org type not found",
+			reason: "",
+			mockSetup: func(r *mockOrganizationTypeRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 7).Return(domain.OrganizationType{}, domain.ErrNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "error - delete fails, no audit",
+			reason: "",
+			mockSetup: func(r *mockOrganizationTypeRepository, a *mockAuditRepository) {
+				r.On("ByID", mock.Anything, 7).Return(orgType, nil)
+				r.On("Delete", mock.Anything, 7, "").Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockOrganizationTypeRepository{}
+			audit := &mockAuditRepository{}
+			tt.mockSetup(repo, audit)
+
+			svc := service.NewOrganizationTypeService(repo, audit, zap.NewNop())
+			err := svc.Delete(context.Background(), 7, tt.reason)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.wantAudit {
+				require.Len(t, audit.Calls, 1)
+				entry := audit.Calls[0].Arguments.Get(1).(*domain.SecurityAuditTrail)
+				assert.Equal(t, "ORG_TYPE_DELETED", entry.Action)
+				assert.Equal(t, "org_type", entry.TargetType)
+				assert.Equal(t, "7", entry.TargetID)
+
+				var old domain.OrganizationType
+				require.NoError(t, json.Unmarshal([]byte(entry.OldValue), &old))
+				assert.Equal(t, orgType.Code, old.Code)
+			} else {
+				audit.AssertNotCalled(t, "CreateAuditTrail", mock.Anything, mock.Anything)
+			}
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrganizationTypeService_SetPermissions(t *testing.T) {
+	orgType := domain.OrganizationType{Id: 7, Code: "GOV", Name: "Government"}
+
+	tests := []struct {
+		name      string
+		ids       []int
+		mockSetup func(*mockOrganizationTypeRepository, *mockAuditRepository, *mockCacheInvalidator)
+		wantErr   error
+	}{
+		{
+			name: "success - replaces, dedupes, invalidates cache and audits",
+			ids:  []int{3, 5, 3},
+			mockSetup: func(r *mockOrganizationTypeRepository, a *mockAuditRepository, c *mockCacheInvalidator) {
+				r.On("ByID", mock.Anything, 7).Return(orgType, nil)
+				r.On("Permissions", mock.Anything, 7).Return([]domain.Permission{{ID: 1}}, nil)
+				r.On("AddPermissions", mock.Anything, 7, []int{3, 5}).Return(nil)
+				c.On("InvalidateAll").Return()
+				a.On("CreateAuditTrail", mock.Anything, mock.MatchedBy(func(e *domain.SecurityAuditTrail) bool {
+					return e.Action == "ORG_TYPE_PERMISSIONS_SET" && e.TargetID == "7" &&
+						e.OldValue == `{"permission_ids":[1]}` && e.NewValue == `{"permission_ids":[3,5]}`
+				})).Return(nil)
+			},
+		},
+		{
+			name: "success - empty list clears permissions",
+			ids:  []int{},
+			mockSetup: func(r *mockOrganizationTypeRepository, a *mockAuditRepository, c *mockCacheInvalidator) {
+				r.On("ByID", mock.Anything, 7).Return(orgType, nil)
+				r.On("Permissions", mock.Anything, 7).Return([]domain.Permission{}, nil)
+				r.On("AddPermissions", mock.Anything, 7, []int{}).Return(nil)
+				c.On("InvalidateAll").Return()
+				a.On("CreateAuditTrail", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "error - org type not found",
+			ids:  []int{3},
+			mockSetup: func(r *mockOrganizationTypeRepository, a *mockAuditRepository, c *mockCacheInvalidator) {
+				r.On("ByID", mock.Anything, 7).Return(domain.OrganizationType{}, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockOrganizationTypeRepository{}
+			audit := &mockAuditRepository{}
+			cache := &mockCacheInvalidator{}
+			tt.mockSetup(repo, audit, cache)
+
+			svc := service.NewOrganizationTypeService(repo, audit, zap.NewNop())
+			svc.SetCacheInvalidator(cache)
+			err := svc.SetPermissions(context.Background(), 7, tt.ids)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "AddPermissions", mock.Anything, mock.Anything, mock.Anything)
+				cache.AssertNotCalled(t, "InvalidateAll")
+			} else {
+				assert.NoError(t, err)
+			}
+
+			repo.AssertExpectations(t)
+			audit.AssertExpectations(t)
+			cache.AssertExpectations(t)
+		})
+	}
+}
@@ -11,10 +11,14 @@ import (
 
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/repository"
 	"templatev25/internal/service"
 
+	"git.gerege.mn/backend-packages/common"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -41,13 +45,29 @@ func (m *mockPermissionRepository) ByCode(ctx context.Context, code string) (dom
 	return args.Get(0).(domain.Permission), args.Error(1)
 }
 
+func (m *mockPermissionRepository) ListBySystem(ctx context.Context, systemID int) ([]domain.Permission, error) {
+	args := m.Called(ctx, systemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Permission), args.Error(1)
+}
+
+func (m *mockPermissionRepository) ListByModule(ctx context.Context, moduleID int, q dto.PermissionQuery) ([]domain.Permission, int64, int, int, error) {
+	args := m.Called(ctx, moduleID, q)
+	if args.Get(0) == nil {
+		return nil, 0, 0, 0, args.Error(4)
+	}
+	return args.Get(0).([]domain.Permission), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
+}
+
 func (m *mockPermissionRepository) Create(ctx context.Context, p domain.Permission) error {
 	args := m.Called(ctx, p)
 	return args.Error(0)
 }
 
-func (m *mockPermissionRepository) CreateBatch(ctx context.Context, systemID, moduleID int, actionIDs []int64) error {
-	args := m.Called(ctx, systemID, moduleID, actionIDs)
+func (m *mockPermissionRepository) CreateBatch(ctx context.Context, systemID, moduleID int, actionIDs []int64, codeFn repository.PermissionCodeFunc) error {
+	args := m.Called(ctx, systemID, moduleID, actionIDs, codeFn)
 	return args.Error(0)
 }
 
@@ -61,6 +81,11 @@ func (m *mockPermissionRepository) Delete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *mockPermissionRepository) DeleteByModule(ctx context.Context, moduleID int) (int64, error) {
+	args := m.Called(ctx, moduleID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *mockPermissionRepository) UserHasPermission(ctx context.Context, userID int, permissionCode string) (bool, error) {
 	args := m.Called(ctx, userID, permissionCode)
 	return args.Bool(0), args.Error(1)
@@ -74,6 +99,19 @@ func (m *mockPermissionRepository) GetUserPermissionCodes(ctx context.Context, u
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *mockPermissionRepository) UserHasOrgTypePermission(ctx context.Context, userID int, permissionCode string) (bool, error) {
+	args := m.Called(ctx, userID, permissionCode)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockPermissionRepository) GetUserOrgTypePermissionCodes(ctx context.Context, userID int) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 // mockCacheInvalidator implements auth.CacheInvalidator
 type mockCacheInvalidator struct {
 	mock.Mock
@@ -140,7 +178,7 @@ func TestPermissionService_ListFilteredPaged(t *testing.T) {
 			mockRepo := &mockPermissionRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewPermissionService(mockRepo, zap.NewNop())
+			svc := service.NewPermissionService(mockRepo, nil, zap.NewNop())
 
 			permissions, _, _, _, err := svc.ListFilteredPaged(context.Background(), tt.query)
 
@@ -188,7 +226,7 @@ func TestPermissionService_ByID(t *testing.T) {
 			mockRepo := &mockPermissionRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewPermissionService(mockRepo, zap.NewNop())
+			svc := service.NewPermissionService(mockRepo, nil, zap.NewNop())
 
 			permission, err := svc.ByID(context.Background(), tt.id)
 
@@ -236,7 +274,7 @@ func TestPermissionService_ByCode(t *testing.T) {
 			mockRepo := &mockPermissionRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewPermissionService(mockRepo, zap.NewNop())
+			svc := service.NewPermissionService(mockRepo, nil, zap.NewNop())
 
 			permission, err := svc.ByCode(context.Background(), tt.code)
 
@@ -267,7 +305,7 @@ func TestPermissionService_Create(t *testing.T) {
 				ActionIDs: []int64{1, 2, 3},
 			},
 			mockSetup: func(m *mockPermissionRepository) {
-				m.On("CreateBatch", mock.Anything, 1, 2, []int64{1, 2, 3}).Return(nil)
+				m.On("CreateBatch", mock.Anything, 1, 2, []int64{1, 2, 3}, mock.Anything).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -279,7 +317,7 @@ func TestPermissionService_Create(t *testing.T) {
 				ActionIDs: []int64{1},
 			},
 			mockSetup: func(m *mockPermissionRepository) {
-				m.On("CreateBatch", mock.Anything, 1, 2, []int64{1}).Return(errors.New("create failed"))
+				m.On("CreateBatch", mock.Anything, 1, 2, []int64{1}, mock.Anything).Return(errors.New("create failed"))
 			},
 			wantErr: true,
 		},
@@ -290,7 +328,7 @@ func TestPermissionService_Create(t *testing.T) {
 			mockRepo := &mockPermissionRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewPermissionService(mockRepo, zap.NewNop())
+			svc := service.NewPermissionService(mockRepo, nil, zap.NewNop())
 
 			err := svc.Create(context.Background(), tt.input)
 
@@ -305,6 +343,115 @@ func TestPermissionService_Create(t *testing.T) {
 	}
 }
 
+func TestPermissionService_GenerateCode(t *testing.T) {
+	svc := service.NewPermissionService(&mockPermissionRepository{}, nil, zap.NewNop())
+
+	tests := []struct {
+		name                   string
+		system, module, action string
+		want                   string
+	}{
+		{name: "lowercases segments", system: "ADMIN", module: "User", action: "Create", want: "admin.user.create"},
+		{name: "keeps digits and underscore", system: "erp2", module: "org_user", action: "read_all", want: "erp2.org_user.read_all"},
+		{name: "special characters become underscore", system: "admin", module: "User-Management", action: "bulk delete!", want: "admin.user_management.bulk_delete"},
+		{name: "repeated separators collapse", system: "admin", module: "user -- role", action: "create", want: "admin.user_role.create"},
+		{name: "surrounding whitespace trimmed", system: "  admin ", module: "\tuser", action: "create\n", want: "admin.user.create"},
+		{name: "leading dots stripped", system: ".admin", module: "..user", action: "create.", want: "admin.user.create"},
+		{name: "dot inside segment", system: "admin", module: "user.role", action: "create", want: "admin.user_role.create"},
+		{name: "unicode mixed with ascii", system: "admin", module: "хэрэглэгч_user", action: "create", want: "admin.user.create"},
+		{name: "unicode only segment", system: "admin", module: "Хэрэглэгч", action: "create", want: ""},
+		{name: "empty system", system: "", module: "user", action: "create", want: ""},
+		{name: "empty module", system: "admin", module: "", action: "create", want: ""},
+		{name: "empty action", system: "admin", module: "user", action: "   ", want: ""},
+		{name: "only dots", system: "admin", module: "...", action: "create", want: ""},
+		{name: "segment starting with digit", system: "admin", module: "2fa", action: "enable", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, svc.GenerateCode(tt.system, tt.module, tt.action))
+		})
+	}
+}
+
+func TestPermissionService_Create_Code(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     dto.PermissionCreateDto
+		codes     [3]string // repository-оос олдсон system, module, action код
+		wantCode  string
+		wantErr   error
+		wantBatch bool
+	}{
+		{
+			name:      "generated from resolved codes",
+			input:     dto.PermissionCreateDto{SystemID: 1, ModuleID: 2, ActionIDs: []int64{3}},
+			codes:     [3]string{"Admin", "User Role", "Create"},
+			wantCode:  "admin.user_role.create",
+			wantBatch: true,
+		},
+		{
+			name:      "explicit code is used",
+			input:     dto.PermissionCreateDto{SystemID: 1, ModuleID: 2, ActionIDs: []int64{3}, Code: "admin.users.invite"},
+			codes:     [3]string{"Admin", "User", "Create"},
+			wantCode:  "admin.users.invite",
+			wantBatch: true,
+		},
+		{
+			name:      "ungeneratable codes roll back",
+			input:     dto.PermissionCreateDto{SystemID: 1, ModuleID: 2, ActionIDs: []int64{3}},
+			codes:     [3]string{"Админ", "User", "Create"},
+			wantErr:   domain.ErrInvalidInput,
+			wantBatch: true,
+		},
+		{
+			name:    "explicit code with invalid format",
+			input:   dto.PermissionCreateDto{SystemID: 1, ModuleID: 2, ActionIDs: []int64{3}, Code: "Admin.User"},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:    "explicit code with multiple actions",
+			input:   dto.PermissionCreateDto{SystemID: 1, ModuleID: 2, ActionIDs: []int64{3, 4}, Code: "admin.user.create"},
+			wantErr: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockPermissionRepository{}
+			var (
+				gotCode string
+				codeErr error
+			)
+			if tt.wantBatch {
+				mockRepo.On("CreateBatch", mock.Anything, 1, 2, tt.input.ActionIDs, mock.Anything).
+					Run(func(args mock.Arguments) {
+						codeFn := args.Get(4).(repository.PermissionCodeFunc)
+						gotCode, codeErr = codeFn(tt.codes[0], tt.codes[1], tt.codes[2])
+					}).
+					Return(nil)
+			}
+
+			svc := service.NewPermissionService(mockRepo, nil, zap.NewNop())
+			err := svc.Create(context.Background(), tt.input)
+
+			if !tt.wantBatch {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, codeErr, tt.wantErr)
+			} else {
+				assert.NoError(t, codeErr)
+				assert.Equal(t, tt.wantCode, gotCode)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestPermissionService_Update(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -366,7 +513,7 @@ func TestPermissionService_Update(t *testing.T) {
 			mockCache := &mockCacheInvalidator{}
 			tt.mockSetup(mockRepo, mockCache)
 
-			svc := service.NewPermissionService(mockRepo, zap.NewNop())
+			svc := service.NewPermissionService(mockRepo, nil, zap.NewNop())
 			if tt.useCache {
 				svc.SetCacheInvalidator(mockCache)
 			}
@@ -433,7 +580,7 @@ func TestPermissionService_Delete(t *testing.T) {
 			mockCache := &mockCacheInvalidator{}
 			tt.mockSetup(mockRepo, mockCache)
 
-			svc := service.NewPermissionService(mockRepo, zap.NewNop())
+			svc := service.NewPermissionService(mockRepo, nil, zap.NewNop())
 			if tt.useCache {
 				svc.SetCacheInvalidator(mockCache)
 			}
@@ -479,10 +626,22 @@ func TestPermissionService_HasPermission(t *testing.T) {
 			permissionCode: "admin.user.delete",
 			mockSetup: func(m *mockPermissionRepository) {
 				m.On("UserHasPermission", mock.Anything, 2, "admin.user.delete").Return(false, nil)
+				m.On("UserHasOrgTypePermission", mock.Anything, 2, "admin.user.delete").Return(false, nil)
 			},
 			wantResult: false,
 			wantErr:    false,
 		},
+		{
+			name:           "success - granted by organization type",
+			userID:         4,
+			permissionCode: "org.report.read",
+			mockSetup: func(m *mockPermissionRepository) {
+				m.On("UserHasPermission", mock.Anything, 4, "org.report.read").Return(false, nil)
+				m.On("UserHasOrgTypePermission", mock.Anything, 4, "org.report.read").Return(true, nil)
+			},
+			wantResult: true,
+			wantErr:    false,
+		},
 		{
 			name:           "error - db error",
 			userID:         3,
@@ -500,7 +659,7 @@ func TestPermissionService_HasPermission(t *testing.T) {
 			mockRepo := &mockPermissionRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewPermissionService(mockRepo, zap.NewNop())
+			svc := service.NewPermissionService(mockRepo, nil, zap.NewNop())
 
 			result, err := svc.HasPermission(context.Background(), tt.userID, tt.permissionCode)
 
@@ -530,15 +689,27 @@ func TestPermissionService_GetUserPermissions(t *testing.T) {
 			mockSetup: func(m *mockPermissionRepository) {
 				codes := []string{"admin.user.read", "admin.user.write", "admin.role.read"}
 				m.On("GetUserPermissionCodes", mock.Anything, 1).Return(codes, nil)
+				m.On("GetUserOrgTypePermissionCodes", mock.Anything, 1).Return([]string{}, nil)
 			},
 			wantCount: 3,
 			wantErr:   false,
 		},
+		{
+			name:   "success - merges organization type permissions without duplicates",
+			userID: 4,
+			mockSetup: func(m *mockPermissionRepository) {
+				m.On("GetUserPermissionCodes", mock.Anything, 4).Return([]string{"admin.user.read"}, nil)
+				m.On("GetUserOrgTypePermissionCodes", mock.Anything, 4).Return([]string{"admin.user.read", "org.report.read"}, nil)
+			},
+			wantCount: 2,
+			wantErr:   false,
+		},
 		{
 			name:   "success - empty permissions",
 			userID: 2,
 			mockSetup: func(m *mockPermissionRepository) {
 				m.On("GetUserPermissionCodes", mock.Anything, 2).Return([]string{}, nil)
+				m.On("GetUserOrgTypePermissionCodes", mock.Anything, 2).Return([]string{}, nil)
 			},
 			wantCount: 0,
 			wantErr:   false,
@@ -559,7 +730,7 @@ func TestPermissionService_GetUserPermissions(t *testing.T) {
 			mockRepo := &mockPermissionRepository{}
 			tt.mockSetup(mockRepo)
 
-			svc := service.NewPermissionService(mockRepo, zap.NewNop())
+			svc := service.NewPermissionService(mockRepo, nil, zap.NewNop())
 
 			codes, err := svc.GetUserPermissions(context.Background(), tt.userID)
 
@@ -574,3 +745,136 @@ func TestPermissionService_GetUserPermissions(t *testing.T) {
 		})
 	}
 }
+
+func TestPermissionService_ListBySystemGrouped(t *testing.T) {
+	t.Run("groups permissions spanning multiple modules", func(t *testing.T) {
+		repo := &mockPermissionRepository{}
+		modules := &mockModuleRepository{}
+		perms := []domain.Permission{
+			{ID: 1, Code: "admin.role.create", SystemID: 7, ModuleID: 2},
+			{ID: 2, Code: "admin.role.read", SystemID: 7, ModuleID: 2},
+			{ID: 3, Code: "admin.user.create", SystemID: 7, ModuleID: 5},
+			{ID: 4, Code: "admin.audit.read", SystemID: 7, ModuleID: 9},
+			{ID: 5, Code: "admin.user.delete", SystemID: 7, ModuleID: 5},
+		}
+		repo.On("ListBySystem", mock.Anything, 7).Return(perms, nil)
+		// Module нэрс нэг batch query-ээр, давхардалгүй ID-аар авагдана
+		modules.On("ByIDs", mock.Anything, []int{2, 5, 9}).Return([]domain.Module{
+			{ID: 9, Name: "Audit"},
+			{ID: 2, Name: "Role"},
+			{ID: 5, Name: "User"},
+		}, nil).Once()
+
+		svc := service.NewPermissionService(repo, modules, zap.NewNop())
+		groups, err := svc.ListBySystemGrouped(context.Background(), 7)
+
+		require.NoError(t, err)
+		require.Len(t, groups, 3)
+
+		assert.Equal(t, 2, groups[0].ModuleID)
+		assert.Equal(t, "Role", groups[0].ModuleName)
+		assert.Equal(t, []domain.Permission{perms[0], perms[1]}, groups[0].Permissions)
+
+		assert.Equal(t, 5, groups[1].ModuleID)
+		assert.Equal(t, "User", groups[1].ModuleName)
+		assert.Equal(t, []domain.Permission{perms[2], perms[4]}, groups[1].Permissions)
+
+		assert.Equal(t, 9, groups[2].ModuleID)
+		assert.Equal(t, "Audit", groups[2].ModuleName)
+		assert.Equal(t, []domain.Permission{perms[3]}, groups[2].Permissions)
+
+		repo.AssertExpectations(t)
+		modules.AssertExpectations(t)
+	})
+
+	t.Run("missing module keeps empty name", func(t *testing.T) {
+		repo := &mockPermissionRepository{}
+		modules := &mockModuleRepository{}
+		repo.On("ListBySystem", mock.Anything, 7).Return([]domain.Permission{{ID: 1, ModuleID: 3}}, nil)
+		modules.On("ByIDs", mock.Anything, []int{3}).Return([]domain.Module{}, nil)
+
+		svc := service.NewPermissionService(repo, modules, zap.NewNop())
+		groups, err := svc.ListBySystemGrouped(context.Background(), 7)
+
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, 3, groups[0].ModuleID)
+		assert.Empty(t, groups[0].ModuleName)
+	})
+
+	t.Run("no permissions skips module query", func(t *testing.T) {
+		repo := &mockPermissionRepository{}
+		modules := &mockModuleRepository{}
+		repo.On("ListBySystem", mock.Anything, 7).Return([]domain.Permission{}, nil)
+
+		svc := service.NewPermissionService(repo, modules, zap.NewNop())
+		groups, err := svc.ListBySystemGrouped(context.Background(), 7)
+
+		require.NoError(t, err)
+		assert.NotNil(t, groups)
+		assert.Empty(t, groups)
+		modules.AssertNotCalled(t, "ByIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository errors are returned", func(t *testing.T) {
+		repo := &mockPermissionRepository{}
+		modules := &mockModuleRepository{}
+		repo.On("ListBySystem", mock.Anything, 7).Return([]domain.Permission{{ID: 1, ModuleID: 3}}, nil)
+		modules.On("ByIDs", mock.Anything, []int{3}).Return(nil, errors.New("db error"))
+
+		svc := service.NewPermissionService(repo, modules, zap.NewNop())
+		_, err := svc.ListBySystemGrouped(context.Background(), 7)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPermissionService_ListByModule(t *testing.T) {
+	q := dto.PermissionQuery{PaginationQuery: common.PaginationQuery{Page: 1, Size: 20}}
+
+	t.Run("success - lists permissions of existing module", func(t *testing.T) {
+		repo := &mockPermissionRepository{}
+		modules := &mockModuleRepository{}
+		perms := []domain.Permission{
+			{ID: 1, Code: "admin.user.create", ModuleID: 5},
+			{ID: 2, Code: "admin.user.read", ModuleID: 5},
+		}
+		modules.On("ByID", mock.Anything, 5).Return(domain.Module{ID: 5, Name: "User"}, nil)
+		repo.On("ListByModule", mock.Anything, 5, q).Return(perms, int64(2), 1, 20, nil)
+
+		svc := service.NewPermissionService(repo, modules, zap.NewNop())
+		items, total, page, size, err := svc.ListByModule(context.Background(), 5, q)
+
+		require.NoError(t, err)
+		assert.Equal(t, perms, items)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, 1, page)
+		assert.Equal(t, 20, size)
+		repo.AssertExpectations(t)
+		modules.AssertExpectations(t)
+	})
+
+	t.Run("error - module not found skips permission query", func(t *testing.T) {
+		repo := &mockPermissionRepository{}
+		modules := &mockModuleRepository{}
+		modules.On("ByID", mock.Anything, 404).Return(domain.Module{}, domain.NewNotFound("module not found", nil))
+
+		svc := service.NewPermissionService(repo, modules, zap.NewNop())
+		_, _, _, _, err := svc.ListByModule(context.Background(), 404, q)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		repo.AssertNotCalled(t, "ListByModule", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - repository error is returned", func(t *testing.T) {
+		repo := &mockPermissionRepository{}
+		modules := &mockModuleRepository{}
+		modules.On("ByID", mock.Anything, 5).Return(domain.Module{ID: 5}, nil)
+		repo.On("ListByModule", mock.Anything, 5, q).Return(nil, int64(0), 0, 0, errors.New("db error"))
+
+		svc := service.NewPermissionService(repo, modules, zap.NewNop())
+		_, _, _, _, err := svc.ListByModule(context.Background(), 5, q)
+
+		assert.EqualError(t, err, "db error")
+	})
+}
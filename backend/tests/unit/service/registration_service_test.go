@@ -0,0 +1,554 @@
+// Package service provides implementation for service
+//
+// File: registration_service_test.go
+// Description: Unit tests for registration and forgot/reset password flow
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"templatev25/internal/auth"
+	"templatev25/internal/config"
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// ============================================================
+// MOCKS
+// ============================================================
+
+// mockMailer records sent emails
+type mockMailer struct {
+	mock.Mock
+}
+
+func (m *mockMailer) Send(_ context.Context, to, subject, body string) error {
+	args := m.Called(to, subject, body)
+	return args.Error(0)
+}
+
+// mockResetRegistrationRepository covers the registration and password reset methods only
+type mockResetRegistrationRepository struct {
+	repository.RegistrationRepository
+	mock.Mock
+}
+
+func (m *mockResetRegistrationRepository) CreateUserWithCredential(ctx context.Context, user *domain.User, cred *domain.UserCredential) error {
+	args := m.Called(ctx, user, cred)
+	return args.Error(0)
+}
+
+func (m *mockResetRegistrationRepository) EmailExists(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockResetRegistrationRepository) CreateEmailVerificationToken(ctx context.Context, token *domain.EmailVerificationToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockResetRegistrationRepository) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockResetRegistrationRepository) GetPasswordResetToken(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PasswordResetToken), args.Error(1)
+}
+
+func (m *mockResetRegistrationRepository) MarkPasswordResetTokenUsed(ctx context.Context, tokenID int) error {
+	args := m.Called(ctx, tokenID)
+	return args.Error(0)
+}
+
+func (m *mockResetRegistrationRepository) DeleteUserPasswordResetTokens(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// mockResetAuthRepository covers the AuthRepository methods used by the reset flow
+type mockResetAuthRepository struct {
+	repository.AuthRepository
+	mock.Mock
+}
+
+func (m *mockResetAuthRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *mockResetAuthRepository) GetCredentialByUserID(ctx context.Context, userID int) (*domain.UserCredential, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserCredential), args.Error(1)
+}
+
+func (m *mockResetAuthRepository) GetPasswordHistory(ctx context.Context, userID int, limit int) ([]domain.PasswordHistory, error) {
+	args := m.Called(ctx, userID, limit)
+	return args.Get(0).([]domain.PasswordHistory), args.Error(1)
+}
+
+func (m *mockResetAuthRepository) CreatePasswordHistory(ctx context.Context, history *domain.PasswordHistory) error {
+	args := m.Called(ctx, history)
+	return args.Error(0)
+}
+
+func (m *mockResetAuthRepository) UpdateCredential(ctx context.Context, cred *domain.UserCredential) error {
+	args := m.Called(ctx, cred)
+	return args.Error(0)
+}
+
+func (m *mockResetAuthRepository) CreateAuditTrail(ctx context.Context, audit *domain.SecurityAuditTrail) error {
+	return nil
+}
+
+func (m *mockResetAuthRepository) RevokeAllUserSessions(ctx context.Context, userID int, reason string) error {
+	args := m.Called(ctx, userID, reason)
+	return args.Error(0)
+}
+
+// mockResetSessionStore covers DeleteAllUserSessions only
+type mockResetSessionStore struct {
+	service.SessionStore
+	mock.Mock
+}
+
+func (m *mockResetSessionStore) DeleteAllUserSessions(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// ============================================================
+// HELPERS
+// ============================================================
+
+func newResetTestService(authRepo *mockResetAuthRepository, regRepo *mockResetRegistrationRepository, mailer *mockMailer) *service.RegistrationService {
+	cfg := &config.LocalAuthConfig{
+		PasswordMinLength:    8,
+		PasswordHistoryCount: 5,
+		PasswordResetURL:     "https://app.example.com/reset-password",
+		RegistrationEnabled:  true,
+		EmailVerificationURL: "https://app.example.com/verify-email",
+	}
+	store := new(mockResetSessionStore)
+	store.On("DeleteAllUserSessions", mock.Anything, mock.Anything).Return(nil)
+
+	authSvc := service.NewAuthService(authRepo, store, cfg, zap.NewNop())
+	svc := service.NewRegistrationService(authRepo, nil, regRepo, authSvc, cfg, zap.NewNop())
+	svc.SetMailer(mailer)
+	return svc
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// ============================================================
+// TEST REGISTER
+// ============================================================
+
+func TestRegistrationService_Register(t *testing.T) {
+	ctx := context.Background()
+	newReq := func() service.RegistrationRequest {
+		return service.RegistrationRequest{
+			Email:           "new@example.com",
+			Password:        "Password1!",
+			ConfirmPassword: "Password1!",
+			FirstName:       "Bat",
+			LastName:        "Dorj",
+		}
+	}
+
+	t.Run("success - creates user with credential and emails token", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		mailer := new(mockMailer)
+		svc := newResetTestService(authRepo, regRepo, mailer)
+
+		regRepo.On("EmailExists", ctx, "new@example.com").Return(false, nil)
+
+		var user *domain.User
+		var cred *domain.UserCredential
+		regRepo.On("CreateUserWithCredential", ctx, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				user = args.Get(1).(*domain.User)
+				cred = args.Get(2).(*domain.UserCredential)
+				user.Id = 42
+			}).
+			Return(nil)
+
+		var stored *domain.EmailVerificationToken
+		regRepo.On("CreateEmailVerificationToken", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.EmailVerificationToken) }).
+			Return(nil)
+
+		var body string
+		mailer.On("Send", "new@example.com", "Verify your email", mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { body = args.String(2) }).
+			Return(nil)
+
+		res, err := svc.Register(ctx, newReq())
+
+		require.NoError(t, err)
+		assert.Equal(t, 42, res.UserID)
+		assert.True(t, res.VerificationSent)
+
+		require.NotNil(t, user)
+		assert.Equal(t, string(domain.UserStatusPendingVerification), user.Status)
+		assert.Equal(t, "Bat", user.FirstName)
+		require.NotNil(t, cred)
+		assert.True(t, strings.HasPrefix(cred.PasswordHash, "$argon2id$"))
+		assert.NotContains(t, cred.PasswordHash, "Password1!")
+		assert.False(t, cred.MustChangePassword)
+
+		require.NotNil(t, stored)
+		assert.Equal(t, 42, stored.UserID)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), stored.ExpiresAt, time.Minute)
+		assert.Contains(t, body, "https://app.example.com/verify-email?token="+url.QueryEscape(stored.Token))
+		mailer.AssertExpectations(t)
+	})
+
+	t.Run("mailer error - user created, verification not sent", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		mailer := new(mockMailer)
+		svc := newResetTestService(authRepo, regRepo, mailer)
+
+		regRepo.On("EmailExists", ctx, "new@example.com").Return(false, nil)
+		regRepo.On("CreateUserWithCredential", ctx, mock.Anything, mock.Anything).Return(nil)
+		regRepo.On("CreateEmailVerificationToken", ctx, mock.Anything).Return(nil)
+		mailer.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+
+		res, err := svc.Register(ctx, newReq())
+
+		require.NoError(t, err)
+		assert.False(t, res.VerificationSent)
+	})
+
+	t.Run("error - duplicate email", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		svc := newResetTestService(authRepo, regRepo, new(mockMailer))
+
+		// EmailExists нь soft-delete хийгдсэн хэрэглэгчийг ч тоолно
+		regRepo.On("EmailExists", ctx, "new@example.com").Return(true, nil)
+
+		_, err := svc.Register(ctx, newReq())
+
+		assert.ErrorIs(t, err, service.ErrEmailAlreadyExists)
+		regRepo.AssertNotCalled(t, "CreateUserWithCredential", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - email lookup fails", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		svc := newResetTestService(authRepo, regRepo, new(mockMailer))
+
+		regRepo.On("EmailExists", ctx, "new@example.com").Return(false, errors.New("db down"))
+
+		_, err := svc.Register(ctx, newReq())
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, service.ErrEmailAlreadyExists)
+		regRepo.AssertNotCalled(t, "CreateUserWithCredential", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - transaction fails, no token or email", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		mailer := new(mockMailer)
+		svc := newResetTestService(authRepo, regRepo, mailer)
+
+		regRepo.On("EmailExists", ctx, "new@example.com").Return(false, nil)
+		regRepo.On("CreateUserWithCredential", ctx, mock.Anything, mock.Anything).Return(errors.New("insert failed"))
+
+		_, err := svc.Register(ctx, newReq())
+
+		require.Error(t, err)
+		regRepo.AssertNotCalled(t, "CreateEmailVerificationToken", mock.Anything, mock.Anything)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - concurrent registration hits unique index", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		mailer := new(mockMailer)
+		svc := newResetTestService(authRepo, regRepo, mailer)
+
+		regRepo.On("EmailExists", ctx, "new@example.com").Return(false, nil)
+		regRepo.On("CreateUserWithCredential", ctx, mock.Anything, mock.Anything).
+			Return(domain.NewConflict("email already registered", errors.New("duplicate key")))
+
+		_, err := svc.Register(ctx, newReq())
+
+		assert.ErrorIs(t, err, service.ErrEmailAlreadyExists)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - passwords do not match", func(t *testing.T) {
+		regRepo := new(mockResetRegistrationRepository)
+		svc := newResetTestService(new(mockResetAuthRepository), regRepo, new(mockMailer))
+		req := newReq()
+		req.ConfirmPassword = "Different1!"
+
+		_, err := svc.Register(ctx, req)
+
+		assert.ErrorIs(t, err, service.ErrPasswordMismatch)
+		regRepo.AssertNotCalled(t, "EmailExists", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - password too short", func(t *testing.T) {
+		regRepo := new(mockResetRegistrationRepository)
+		svc := newResetTestService(new(mockResetAuthRepository), regRepo, new(mockMailer))
+		req := newReq()
+		req.Password, req.ConfirmPassword = "short", "short"
+
+		_, err := svc.Register(ctx, req)
+
+		assert.ErrorIs(t, err, service.ErrPasswordTooWeak)
+		regRepo.AssertNotCalled(t, "EmailExists", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - password fails complexity policy", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		cfg := &config.LocalAuthConfig{PasswordMinLength: 8, RegistrationEnabled: true, PasswordRequireUppercase: true, PasswordRequireDigit: true}
+		authSvc := service.NewAuthService(authRepo, new(mockResetSessionStore), cfg, zap.NewNop())
+		regRepo := new(mockResetRegistrationRepository)
+		svc := service.NewRegistrationService(authRepo, nil, regRepo, authSvc, cfg, zap.NewNop())
+		req := newReq()
+		req.Password, req.ConfirmPassword = "lowercaseonly", "lowercaseonly"
+
+		_, err := svc.Register(ctx, req)
+
+		var weak *service.PasswordTooWeakError
+		require.ErrorAs(t, err, &weak)
+		assert.Equal(t, []auth.PolicyViolation{auth.ViolationUppercase, auth.ViolationDigit}, weak.Violations)
+		regRepo.AssertNotCalled(t, "EmailExists", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - registration disabled", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		cfg := &config.LocalAuthConfig{PasswordMinLength: 8}
+		authSvc := service.NewAuthService(authRepo, new(mockResetSessionStore), cfg, zap.NewNop())
+		regRepo := new(mockResetRegistrationRepository)
+		svc := service.NewRegistrationService(authRepo, nil, regRepo, authSvc, cfg, zap.NewNop())
+
+		_, err := svc.Register(ctx, newReq())
+
+		assert.ErrorIs(t, err, service.ErrRegistrationDisabled)
+		regRepo.AssertNotCalled(t, "EmailExists", mock.Anything, mock.Anything)
+	})
+}
+
+// ============================================================
+// TEST FORGOT PASSWORD
+// ============================================================
+
+func TestRegistrationService_ForgotPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - emails token and stores only its hash", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		mailer := new(mockMailer)
+		svc := newResetTestService(authRepo, regRepo, mailer)
+
+		authRepo.On("GetUserByEmail", ctx, "user@example.com").Return(&domain.User{Id: 7, Email: "user@example.com"}, nil)
+		regRepo.On("DeleteUserPasswordResetTokens", ctx, 7).Return(nil)
+
+		var stored *domain.PasswordResetToken
+		regRepo.On("CreatePasswordResetToken", ctx, mock.AnythingOfType("*domain.PasswordResetToken")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.PasswordResetToken) }).
+			Return(nil)
+
+		sent := make(chan string, 1)
+		mailer.On("Send", "user@example.com", "Password reset", mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { sent <- args.String(2) }).
+			Return(nil)
+
+		err := svc.ForgotPassword(ctx, "user@example.com")
+
+		require.NoError(t, err)
+		body := waitForEmail(t, sent)
+		require.NotNil(t, stored)
+		assert.Equal(t, 7, stored.UserID)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+
+		// Email-д raw токен, DB-д зөвхөн hash
+		const prefix = "?token="
+		idx := strings.Index(body, prefix)
+		require.GreaterOrEqual(t, idx, 0, "body should contain reset link")
+		rawToken, err := url.QueryUnescape(strings.Fields(body[idx+len(prefix):])[0])
+		require.NoError(t, err)
+		assert.NotEqual(t, rawToken, stored.TokenHash)
+		assert.Equal(t, sha256Hex(rawToken), stored.TokenHash)
+		assert.Contains(t, body, "https://app.example.com/reset-password")
+		mailer.AssertExpectations(t)
+	})
+
+	t.Run("unknown email - returns nil without sending", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		mailer := new(mockMailer)
+		svc := newResetTestService(authRepo, regRepo, mailer)
+
+		authRepo.On("GetUserByEmail", ctx, "nobody@example.com").Return(nil, domain.ErrNotFound)
+
+		err := svc.ForgotPassword(ctx, "nobody@example.com")
+
+		assert.NoError(t, err)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+		regRepo.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("mailer error - still returns nil", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		mailer := new(mockMailer)
+		svc := newResetTestService(authRepo, regRepo, mailer)
+
+		authRepo.On("GetUserByEmail", ctx, "user@example.com").Return(&domain.User{Id: 7, Email: "user@example.com"}, nil)
+		regRepo.On("DeleteUserPasswordResetTokens", ctx, 7).Return(nil)
+		regRepo.On("CreatePasswordResetToken", ctx, mock.Anything).Return(nil)
+		sent := make(chan string, 1)
+		mailer.On("Send", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { sent <- args.String(2) }).
+			Return(errors.New("smtp down"))
+
+		err := svc.ForgotPassword(ctx, "user@example.com")
+
+		assert.NoError(t, err)
+		waitForEmail(t, sent)
+		mailer.AssertExpectations(t)
+	})
+}
+
+// waitForEmail нь background-д илгээсэн email-ийн body-г хүлээнэ
+func waitForEmail(t *testing.T, sent <-chan string) string {
+	t.Helper()
+	select {
+	case body := <-sent:
+		return body
+	case <-time.After(time.Second):
+		t.Fatal("password reset email was not sent")
+		return ""
+	}
+}
+
+// ============================================================
+// TEST RESET PASSWORD
+// ============================================================
+
+func TestRegistrationService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	const rawToken = "raw-reset-token"
+
+	t.Run("success - updates credential and marks token used", func(t *testing.T) {
+		authRepo := new(mockResetAuthRepository)
+		regRepo := new(mockResetRegistrationRepository)
+		svc := newResetTestService(authRepo, regRepo, new(mockMailer))
+
+		regRepo.On("GetPasswordResetToken", ctx, sha256Hex(rawToken)).Return(&domain.PasswordResetToken{
+			ID:        3,
+			UserID:    7,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		authRepo.On("GetCredentialByUserID", ctx, 7).Return(&domain.UserCredential{UserID: 7, PasswordHash: "old-hash", MustChangePassword: true}, nil)
+		authRepo.On("GetPasswordHistory", ctx, 7, 5).Return([]domain.PasswordHistory{}, nil)
+		authRepo.On("CreatePasswordHistory", ctx, mock.Anything).Return(nil)
+
+		var updated *domain.UserCredential
+		authRepo.On("UpdateCredential", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { updated = args.Get(1).(*domain.UserCredential) }).
+			Return(nil)
+		authRepo.On("RevokeAllUserSessions", ctx, 7, mock.Anything).Return(nil)
+		regRepo.On("MarkPasswordResetTokenUsed", ctx, 3).Return(nil)
+		regRepo.On("DeleteUserPasswordResetTokens", ctx, 7).Return(nil)
+
+		err := svc.ResetPassword(ctx, rawToken, "NewPassword1!", "NewPassword1!")
+
+		require.NoError(t, err)
+		require.NotNil(t, updated)
+		assert.NotEqual(t, "old-hash", updated.PasswordHash)
+		assert.False(t, updated.MustChangePassword)
+		assert.NotNil(t, updated.PasswordChangedAt)
+		regRepo.AssertExpectations(t)
+		authRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name    string
+		token   *domain.PasswordResetToken
+		repoErr error
+	}{
+		{
+			name:    "error - unknown token",
+			repoErr: domain.ErrNotFound,
+		},
+		{
+			name:  "error - expired token",
+			token: &domain.PasswordResetToken{ID: 3, UserID: 7, ExpiresAt: time.Now().Add(-time.Minute)},
+		},
+		{
+			name: "error - used token",
+			token: func() *domain.PasswordResetToken {
+				usedAt := time.Now().Add(-time.Minute)
+				return &domain.PasswordResetToken{ID: 3, UserID: 7, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authRepo := new(mockResetAuthRepository)
+			regRepo := new(mockResetRegistrationRepository)
+			svc := newResetTestService(authRepo, regRepo, new(mockMailer))
+
+			if tt.token != nil {
+				regRepo.On("GetPasswordResetToken", ctx, sha256Hex(rawToken)).Return(tt.token, nil)
+			} else {
+				regRepo.On("GetPasswordResetToken", ctx, sha256Hex(rawToken)).Return(nil, tt.repoErr)
+			}
+
+			err := svc.ResetPassword(ctx, rawToken, "NewPassword1!", "NewPassword1!")
+
+			assert.ErrorIs(t, err, service.ErrInvalidResetToken)
+			authRepo.AssertNotCalled(t, "UpdateCredential", mock.Anything, mock.Anything)
+			regRepo.AssertNotCalled(t, "MarkPasswordResetTokenUsed", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("error - passwords do not match", func(t *testing.T) {
+		regRepo := new(mockResetRegistrationRepository)
+		svc := newResetTestService(new(mockResetAuthRepository), regRepo, new(mockMailer))
+
+		err := svc.ResetPassword(ctx, rawToken, "NewPassword1!", "Different1!")
+
+		assert.ErrorIs(t, err, service.ErrPasswordMismatch)
+		regRepo.AssertNotCalled(t, "GetPasswordResetToken", mock.Anything, mock.Anything)
+	})
+}
@@ -57,6 +57,11 @@ func (m *mockRoleRepository) ByID(ctx context.Context, id int) (domain.Role, err
 	return args.Get(0).(domain.Role), args.Error(1)
 }
 
+func (m *mockRoleRepository) ByIDWithPermissions(ctx context.Context, id int) (domain.Role, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Role), args.Error(1)
+}
+
 func (m *mockRoleRepository) Permissions(ctx context.Context, q dto.RolePermissionsQuery) ([]domain.Permission, error) {
 	args := m.Called(ctx, q)
 	if args.Get(0) == nil {
@@ -219,6 +224,23 @@ func TestRoleService_Delete(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:   "error - inactive system role",
+			roleID: 3,
+			mockSetup: func(m *mockRoleRepository) {
+				m.On("ByID", mock.Anything, 3).Return(domain.Role{ID: 3, Code: "SUPER_ADMIN", IsActive: &isActiveFalse, IsSystemRole: &isActiveTrue}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "success - is_system_role false",
+			roleID: 4,
+			mockSetup: func(m *mockRoleRepository) {
+				m.On("ByID", mock.Anything, 4).Return(domain.Role{ID: 4, IsActive: &isActiveFalse, IsSystemRole: &isActiveFalse}, nil)
+				m.On("Delete", mock.Anything, 4).Return(nil)
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -241,6 +263,88 @@ func TestRoleService_Delete(t *testing.T) {
 	}
 }
 
+func TestRoleService_Delete_SystemRoleForbidden(t *testing.T) {
+	isSystem, inactive := true, false
+	mockRepo := &mockRoleRepository{}
+	mockRepo.On("ByID", mock.Anything, 1).Return(domain.Role{ID: 1, Code: "ADMIN", IsActive: &inactive, IsSystemRole: &isSystem}, nil)
+
+	svc := service.NewRoleService(mockRepo, zap.NewNop())
+	err := svc.Delete(context.Background(), 1)
+
+	assert.ErrorIs(t, err, service.ErrSystemRole)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestRoleService_GetByID(t *testing.T) {
+	tests := []struct {
+		name      string
+		roleID    int
+		mockSetup func(*mockRoleRepository)
+		wantCodes []string
+		wantErr   error
+	}{
+		{
+			name:   "success - permissions embedded in role",
+			roleID: 1,
+			mockSetup: func(m *mockRoleRepository) {
+				m.On("ByIDWithPermissions", mock.Anything, 1).Return(domain.Role{
+					ID:   1,
+					Code: "ADMIN",
+					Permissions: []domain.Permission{
+						{ID: 10, Code: "admin.role.read"},
+						{ID: 11, Code: "admin.role.update"},
+					},
+				}, nil)
+			},
+			wantCodes: []string{"admin.role.read", "admin.role.update"},
+		},
+		{
+			name:   "success - role without permissions returns empty list",
+			roleID: 2,
+			mockSetup: func(m *mockRoleRepository) {
+				m.On("ByIDWithPermissions", mock.Anything, 2).Return(domain.Role{ID: 2}, nil)
+			},
+			wantCodes: []string{},
+		},
+		{
+			name:   "error - role not found",
+			roleID: 999,
+			mockSetup: func(m *mockRoleRepository) {
+				m.On("ByIDWithPermissions", mock.Anything, 999).Return(domain.Role{}, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockRoleRepository{}
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewRoleService(mockRepo, zap.NewNop())
+
+			role, perms, err := svc.GetByID(context.Background(), tt.roleID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, perms)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.roleID, role.ID)
+				assert.NotNil(t, perms)
+				codes := make([]string, 0, len(perms))
+				for _, p := range perms {
+					codes = append(codes, p.Code)
+				}
+				assert.Equal(t, tt.wantCodes, codes)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestRoleService_GetPermissions(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -339,6 +443,52 @@ func TestRoleService_SetPermissions(t *testing.T) {
 	}
 }
 
+func TestDiffPermissionIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []int
+		proposed []int
+		want     dto.RolePermissionsDiffResponse
+	}{
+		{
+			name:     "empty proposed - everything removed",
+			current:  []int{3, 1, 2},
+			proposed: []int{},
+			want:     dto.RolePermissionsDiffResponse{ToAdd: []int{}, ToRemove: []int{1, 2, 3}, Unchanged: []int{}},
+		},
+		{
+			name:     "no changes",
+			current:  []int{5, 6, 7},
+			proposed: []int{7, 6, 5},
+			want:     dto.RolePermissionsDiffResponse{ToAdd: []int{}, ToRemove: []int{}, Unchanged: []int{5, 6, 7}},
+		},
+		{
+			name:     "complete replacement",
+			current:  []int{1, 2},
+			proposed: []int{4, 3},
+			want:     dto.RolePermissionsDiffResponse{ToAdd: []int{3, 4}, ToRemove: []int{1, 2}, Unchanged: []int{}},
+		},
+		{
+			name:     "partial overlap with duplicates",
+			current:  []int{3, 6, 7},
+			proposed: []int{5, 6, 7, 5},
+			want:     dto.RolePermissionsDiffResponse{ToAdd: []int{5}, ToRemove: []int{3}, Unchanged: []int{6, 7}},
+		},
+		{
+			name:     "no current permissions",
+			current:  nil,
+			proposed: []int{2},
+			want:     dto.RolePermissionsDiffResponse{ToAdd: []int{2}, ToRemove: []int{}, Unchanged: []int{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, service.DiffPermissionIDs(tt.current, tt.proposed))
+		})
+	}
+}
+
 func TestRoleService_Update(t *testing.T) {
 	isActiveFalse := false
 
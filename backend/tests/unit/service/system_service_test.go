@@ -33,6 +33,14 @@ func (m *mockSystemRepository) List(ctx context.Context, q dto.SystemListQuery)
 	return args.Get(0).([]domain.System), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
 }
 
+func (m *mockSystemRepository) ListActive(ctx context.Context) ([]domain.System, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.System), args.Error(1)
+}
+
 func (m *mockSystemRepository) Create(ctx context.Context, sys domain.System) error {
 	args := m.Called(ctx, sys)
 	return args.Error(0)
@@ -53,6 +61,11 @@ func (m *mockSystemRepository) ByID(ctx context.Context, id int) (domain.System,
 	return args.Get(0).(domain.System), args.Error(1)
 }
 
+func (m *mockSystemRepository) GetBySlug(ctx context.Context, slug string) (domain.System, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(domain.System), args.Error(1)
+}
+
 func (m *mockSystemRepository) GetActiveModuleCount(ctx context.Context, systemID int) int64 {
 	args := m.Called(ctx, systemID)
 	return int64(args.Int(0))
@@ -118,6 +131,36 @@ func TestSystemService_List(t *testing.T) {
 	}
 }
 
+func TestSystemService_ListActive(t *testing.T) {
+	t.Run("success - returns active systems", func(t *testing.T) {
+		mockRepo := &mockSystemRepository{}
+		mockRepo.On("ListActive", mock.Anything).Return([]domain.System{
+			{ID: 2, Code: "hr", Sequence: 1},
+			{ID: 1, Code: "crm", Sequence: 2},
+		}, nil)
+
+		svc := service.NewSystemService(mockRepo, zap.NewNop())
+		systems, err := svc.ListActive(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, systems, 2)
+		assert.Equal(t, 2, systems[0].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - repository error", func(t *testing.T) {
+		mockRepo := &mockSystemRepository{}
+		mockRepo.On("ListActive", mock.Anything).Return(nil, errors.New("db error"))
+
+		svc := service.NewSystemService(mockRepo, zap.NewNop())
+		systems, err := svc.ListActive(context.Background())
+
+		assert.Error(t, err)
+		assert.Nil(t, systems)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
 func TestSystemService_ByID(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -185,8 +228,9 @@ func TestSystemService_Create(t *testing.T) {
 				IsActive: &isActive,
 			},
 			mockSetup: func(m *mockSystemRepository) {
+				m.On("GetBySlug", mock.Anything, "new-system").Return(domain.System{}, domain.ErrNotFound)
 				m.On("Create", mock.Anything, mock.MatchedBy(func(sys domain.System) bool {
-					return sys.Code == "new_sys" && sys.Key == "new_sys" // code is lowercased
+					return sys.Code == "new_sys" && sys.Key == "new_sys" && sys.Slug == "new-system" // code is lowercased
 				})).Return(nil)
 			},
 			wantErr: false,
@@ -198,6 +242,7 @@ func TestSystemService_Create(t *testing.T) {
 				Name: "Test System",
 			},
 			mockSetup: func(m *mockSystemRepository) {
+				m.On("GetBySlug", mock.Anything, "test-system").Return(domain.System{}, domain.ErrNotFound)
 				m.On("Create", mock.Anything, mock.MatchedBy(func(sys domain.System) bool {
 					return sys.Key == "test_code"
 				})).Return(nil)
@@ -211,11 +256,53 @@ func TestSystemService_Create(t *testing.T) {
 				Name: "Fail System",
 			},
 			mockSetup: func(m *mockSystemRepository) {
+				m.On("GetBySlug", mock.Anything, "fail-system").Return(domain.System{}, domain.ErrNotFound)
 				m.On("Create", mock.Anything, mock.AnythingOfType("domain.System")).
 					Return(errors.New("duplicate code"))
 			},
 			wantErr: true,
 		},
+		{
+			name: "success - slug collision appends -2, -3",
+			input: dto.SystemCreateDto{
+				Code: "HR2",
+				Name: "HR Portal",
+			},
+			mockSetup: func(m *mockSystemRepository) {
+				m.On("GetBySlug", mock.Anything, "hr-portal").Return(domain.System{ID: 1, Slug: "hr-portal"}, nil)
+				m.On("GetBySlug", mock.Anything, "hr-portal-2").Return(domain.System{ID: 2, Slug: "hr-portal-2"}, nil)
+				m.On("GetBySlug", mock.Anything, "hr-portal-3").Return(domain.System{}, domain.ErrNotFound)
+				m.On("Create", mock.Anything, mock.MatchedBy(func(sys domain.System) bool {
+					return sys.Slug == "hr-portal-3"
+				})).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - non-ASCII name falls back to code",
+			input: dto.SystemCreateDto{
+				Code: "ADMIN",
+				Name: "Админ систем",
+			},
+			mockSetup: func(m *mockSystemRepository) {
+				m.On("GetBySlug", mock.Anything, "admin").Return(domain.System{}, domain.ErrNotFound)
+				m.On("Create", mock.Anything, mock.MatchedBy(func(sys domain.System) bool {
+					return sys.Slug == "admin"
+				})).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error - slug lookup fails",
+			input: dto.SystemCreateDto{
+				Code: "DB",
+				Name: "DB Down",
+			},
+			mockSetup: func(m *mockSystemRepository) {
+				m.On("GetBySlug", mock.Anything, "db-down").Return(domain.System{}, errors.New("db error"))
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
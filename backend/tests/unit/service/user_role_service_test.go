@@ -0,0 +1,71 @@
+// Package service provides implementation for service
+//
+// File: user_role_service_test.go
+// Description: Unit tests for UserRoleService.GetByUserID (GET /user/:id/roles)
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/http/dto"
+	"templatev25/internal/service"
+	"templatev25/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRoleService_GetByUserID(t *testing.T) {
+	repo := &mocks.UserRoleRepository{}
+	repo.On("GetByUserID", context.Background(), 5).Return([]domain.UserRole{
+		{UserId: 5, RoleID: 1, Role: &domain.Role{ID: 1, Code: "ADMIN", Name: "Админ", SystemID: 10, System: &domain.System{ID: 10, Name: "Админ систем"}}},
+		{UserId: 5, RoleID: 2, Role: &domain.Role{ID: 2, Code: "APP_USER", Name: "Хэрэглэгч", SystemID: 11}},
+		{UserId: 5, RoleID: 3}, // устгагдсан role (preload хоосон)
+	}, nil)
+
+	got, err := service.NewUserRoleService(repo).GetByUserID(context.Background(), 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, []dto.UserRoleDetail{
+		{RoleID: 1, RoleName: "Админ", RoleCode: "ADMIN", SystemID: 10, SystemName: "Админ систем"},
+		{RoleID: 2, RoleName: "Хэрэглэгч", RoleCode: "APP_USER", SystemID: 11},
+	}, got)
+	repo.AssertExpectations(t)
+}
+
+func TestUserRoleService_GetByUserID_Empty(t *testing.T) {
+	repo := &mocks.UserRoleRepository{}
+	repo.On("GetByUserID", context.Background(), 6).Return(nil, nil)
+
+	got, err := service.NewUserRoleService(repo).GetByUserID(context.Background(), 6)
+
+	require.NoError(t, err)
+	assert.NotNil(t, got, "empty list serializes as []")
+	assert.Empty(t, got)
+}
+
+func TestUserRoleService_GetByUserID_Error(t *testing.T) {
+	repo := &mocks.UserRoleRepository{}
+	repo.On("GetByUserID", context.Background(), 7).Return(nil, errors.New("db down"))
+
+	got, err := service.NewUserRoleService(repo).GetByUserID(context.Background(), 7)
+
+	assert.EqualError(t, err, "db down")
+	assert.Nil(t, got)
+}
+
+func TestAuditedUserRoleService_GetByUserIDDelegates(t *testing.T) {
+	repo := &mocks.UserRoleRepository{}
+	repo.On("GetByUserID", context.Background(), 8).Return([]domain.UserRole{
+		{RoleID: 4, Role: &domain.Role{ID: 4, Code: "SUPPORT", Name: "Дэмжлэг", SystemID: 10, System: &domain.System{Name: "Админ систем"}}},
+	}, nil)
+
+	got, err := newAuditedUserRoleService(repo, &mockAuditRepository{}).GetByUserID(context.Background(), 8)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Админ систем", got[0].SystemName)
+}
@@ -9,19 +9,25 @@
 package service_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"templatev25/internal/domain"
 	"templatev25/internal/http/dto"
+	"templatev25/internal/repository"
 	"templatev25/internal/service"
+	"templatev25/internal/service/export"
 
 	"git.gerege.mn/backend-packages/common"
 	"git.gerege.mn/backend-packages/config"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -38,6 +44,19 @@ func (m *mockUserRepository) List(ctx context.Context, p common.PaginationQuery)
 	return args.Get(0).([]domain.User), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
 }
 
+func (m *mockUserRepository) Search(ctx context.Context, q string, p common.PaginationQuery) ([]domain.User, int64, int, int, error) {
+	args := m.Called(ctx, q, p)
+	if args.Get(0) == nil {
+		return nil, 0, 0, 0, args.Error(4)
+	}
+	return args.Get(0).([]domain.User), args.Get(1).(int64), args.Get(2).(int), args.Get(3).(int), args.Error(4)
+}
+
+func (m *mockUserRepository) ListStream(ctx context.Context, p common.PaginationQuery) (<-chan domain.User, <-chan error) {
+	args := m.Called(ctx, p)
+	return args.Get(0).(<-chan domain.User), args.Get(1).(<-chan error)
+}
+
 func (m *mockUserRepository) Create(ctx context.Context, u domain.User) (domain.User, error) {
 	args := m.Called(ctx, u)
 	return args.Get(0).(domain.User), args.Error(1)
@@ -58,6 +77,27 @@ func (m *mockUserRepository) GetByID(ctx context.Context, id int) (domain.User,
 	return args.Get(0).(domain.User), args.Error(1)
 }
 
+// FindInBatches calls fn for every batch configured in Return([][]domain.User, error)
+func (m *mockUserRepository) FindInBatches(ctx context.Context, p common.PaginationQuery, batchSize int, fn func([]domain.User) error) error {
+	args := m.Called(ctx, p, batchSize)
+	if batches, ok := args.Get(0).([][]domain.User); ok {
+		for _, b := range batches {
+			if err := fn(b); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *mockUserRepository) BulkUpsert(ctx context.Context, users []domain.User) ([]int, error) {
+	args := m.Called(ctx, users)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
 func (m *mockUserRepository) UserOrgIDs(ctx context.Context, userID int) ([]int, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -198,6 +238,101 @@ func TestUserService_List(t *testing.T) {
 	}
 }
 
+func TestUserService_Search(t *testing.T) {
+	p := common.PaginationQuery{Page: 1, Size: 10}
+
+	t.Run("success - passes q and pagination to repository", func(t *testing.T) {
+		mockRepo := &mockUserRepository{}
+		mockRepo.On("Search", mock.Anything, "bold", p).
+			Return([]domain.User{{Id: 1, FirstName: "Bold"}}, int64(1), 1, 10, nil)
+
+		svc := service.NewUserService(mockRepo, &config.Config{}, zap.NewNop())
+		users, total, page, size, err := svc.Search(context.Background(), "bold", p)
+
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, 1, page)
+		assert.Equal(t, 10, size)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - db error", func(t *testing.T) {
+		mockRepo := &mockUserRepository{}
+		mockRepo.On("Search", mock.Anything, "bold", p).
+			Return(nil, int64(0), 0, 0, errors.New("db error"))
+
+		svc := service.NewUserService(mockRepo, &config.Config{}, zap.NewNop())
+		users, _, _, _, err := svc.Search(context.Background(), "bold", p)
+
+		assert.Error(t, err)
+		assert.Nil(t, users)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_Export(t *testing.T) {
+	created := domain.LocalDateTime(time.Date(2025, 3, 1, 9, 30, 0, 0, time.Local))
+
+	tests := []struct {
+		name      string
+		mockSetup func(*mockUserRepository)
+		wantLines []string
+		wantErr   bool
+	}{
+		{
+			name: "success - header and rows across batches",
+			mockSetup: func(m *mockUserRepository) {
+				batches := [][]domain.User{
+					{{Id: 1, RegNo: "АА00112233", FirstName: "Бат", LastName: "Дорж", Email: "bat@example.com", PhoneNo: "99112233", ExtraFields: domain.ExtraFields{CreatedDate: &created}}},
+					{{Id: 2, FirstName: "Smith, John"}},
+				}
+				m.On("FindInBatches", mock.Anything, mock.AnythingOfType("common.PaginationQuery"), 500).Return(batches, nil)
+			},
+			wantLines: []string{
+				"id,reg_no,first_name,last_name,email,phone_no,created_date",
+				"1,АА00112233,Бат,Дорж,bat@example.com,99112233,2025-03-01 09:30:00",
+				`2,,"Smith, John",,,,`,
+			},
+		},
+		{
+			name: "success - no users, header only",
+			mockSetup: func(m *mockUserRepository) {
+				m.On("FindInBatches", mock.Anything, mock.AnythingOfType("common.PaginationQuery"), 500).Return(nil, nil)
+			},
+			wantLines: []string{"id,reg_no,first_name,last_name,email,phone_no,created_date"},
+		},
+		{
+			name: "error - db error",
+			mockSetup: func(m *mockUserRepository) {
+				m.On("FindInBatches", mock.Anything, mock.AnythingOfType("common.PaginationQuery"), 500).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockUserRepository{}
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewUserService(mockRepo, &config.Config{}, zap.NewNop())
+
+			var out bytes.Buffer
+			err := svc.Export(context.Background(), common.PaginationQuery{}, export.FormatCSV, &out)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantLines, strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"))
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUserService_Create(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -267,6 +402,101 @@ func TestUserService_Create(t *testing.T) {
 	}
 }
 
+func TestUserService_SyncFromSSO(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       []dto.UserCreateDto
+		mockSetup   func(*mockUserRepository)
+		wantCount   int
+		wantSkipped []int
+		wantErr     error
+	}{
+		{
+			name: "success - users upserted",
+			input: []dto.UserCreateDto{
+				{Id: 1, FirstName: "Bold", BirthDate: "1990-01-15"},
+				{Id: 2, FirstName: "Saraa", Email: "saraa@example.com"},
+			},
+			mockSetup: func(m *mockUserRepository) {
+				m.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(users []domain.User) bool {
+					return len(users) == 2 && users[0].Id == 1 && users[0].FirstName == "Bold" &&
+						users[1].Id == 2 && users[1].Email == "saraa@example.com"
+				})).Return(nil, nil)
+			},
+			wantCount: 2,
+		},
+		{
+			name: "success - duplicate id keeps the last entry",
+			input: []dto.UserCreateDto{
+				{Id: 1, FirstName: "Old"},
+				{Id: 2, FirstName: "Other"},
+				{Id: 1, FirstName: "New"},
+			},
+			mockSetup: func(m *mockUserRepository) {
+				m.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(users []domain.User) bool {
+					return len(users) == 2 && users[0].Id == 1 && users[0].FirstName == "New"
+				})).Return(nil, nil)
+			},
+			wantCount: 2,
+		},
+		{
+			name:  "success - deleted users skipped",
+			input: []dto.UserCreateDto{{Id: 1}, {Id: 2}, {Id: 3}},
+			mockSetup: func(m *mockUserRepository) {
+				m.On("BulkUpsert", mock.Anything, mock.Anything).Return([]int{2}, nil)
+			},
+			wantCount:   2,
+			wantSkipped: []int{2},
+		},
+		{
+			name:      "error - invalid user rejected before upsert",
+			input:     []dto.UserCreateDto{{Id: 1}, {Id: 0, FirstName: "NoID"}},
+			mockSetup: func(m *mockUserRepository) {},
+			wantErr:   domain.ErrInvalidInput,
+		},
+		{
+			name:      "error - invalid email rejected",
+			input:     []dto.UserCreateDto{{Id: 1, Email: "not-an-email"}},
+			mockSetup: func(m *mockUserRepository) {},
+			wantErr:   domain.ErrInvalidInput,
+		},
+		{
+			name:  "error - upsert fails",
+			input: []dto.UserCreateDto{{Id: 1}},
+			mockSetup: func(m *mockUserRepository) {
+				m.On("BulkUpsert", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockUserRepository{}
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewUserService(mockRepo, &config.Config{}, zap.NewNop())
+
+			n, skipped, err := svc.SyncFromSSO(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.wantErr, domain.ErrInvalidInput) {
+					assert.ErrorIs(t, err, domain.ErrInvalidInput)
+					mockRepo.AssertNotCalled(t, "BulkUpsert", mock.Anything, mock.Anything)
+				}
+				assert.Equal(t, 0, n)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCount, n)
+				assert.Equal(t, tt.wantSkipped, skipped)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUserService_Update(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -379,6 +609,128 @@ func TestUserService_Delete(t *testing.T) {
 	}
 }
 
+// txCtxKey нь fakeTxManager-ийн fn-д дамжуулсан ctx-г ялгана
+type txCtxKey struct{}
+
+// fakeTxManager нь fn-г transaction ctx-тэй дуудаж, rollback болсон эсэхийг бүртгэнэ
+type fakeTxManager struct {
+	calls      int
+	rolledBack bool
+}
+
+func (f *fakeTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	err := fn(context.WithValue(ctx, txCtxKey{}, true))
+	f.rolledBack = err != nil
+	return err
+}
+
+// mockErasureAuthRepository covers AuthRepository.DeleteByUserID only
+type mockErasureAuthRepository struct {
+	repository.AuthRepository
+	mock.Mock
+}
+
+func (m *mockErasureAuthRepository) DeleteByUserID(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestUserService_Delete_Erasure(t *testing.T) {
+	inTx := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txCtxKey{}) != nil })
+	errDB := errors.New("db error")
+
+	tests := []struct {
+		name         string
+		setup        func(users *mockUserRepository, notifs *mockNotificationRepository, auth *mockErasureAuthRepository)
+		wantErr      bool
+		wantRollback bool
+	}{
+		{
+			name: "success - user, notifications and auth data erased in one transaction, sessions deleted",
+			setup: func(users *mockUserRepository, notifs *mockNotificationRepository, auth *mockErasureAuthRepository) {
+				users.On("Delete", inTx, 7).Return(domain.User{Id: 7}, nil)
+				notifs.On("DeleteByUserID", inTx, 7).Return(nil)
+				notifs.On("DeleteGroupsByUserID", inTx, 7).Return(nil)
+				auth.On("DeleteByUserID", inTx, 7).Return(nil)
+			},
+		},
+		{
+			name: "error - user delete fails, nothing erased",
+			setup: func(users *mockUserRepository, notifs *mockNotificationRepository, auth *mockErasureAuthRepository) {
+				users.On("Delete", inTx, 7).Return(domain.User{}, domain.ErrNotFound)
+			},
+			wantErr:      true,
+			wantRollback: true,
+		},
+		{
+			name: "error - notification erase fails, rolled back",
+			setup: func(users *mockUserRepository, notifs *mockNotificationRepository, auth *mockErasureAuthRepository) {
+				users.On("Delete", inTx, 7).Return(domain.User{Id: 7}, nil)
+				notifs.On("DeleteByUserID", inTx, 7).Return(errDB)
+			},
+			wantErr:      true,
+			wantRollback: true,
+		},
+		{
+			name: "error - notification group erase fails, rolled back",
+			setup: func(users *mockUserRepository, notifs *mockNotificationRepository, auth *mockErasureAuthRepository) {
+				users.On("Delete", inTx, 7).Return(domain.User{Id: 7}, nil)
+				notifs.On("DeleteByUserID", inTx, 7).Return(nil)
+				notifs.On("DeleteGroupsByUserID", inTx, 7).Return(errDB)
+			},
+			wantErr:      true,
+			wantRollback: true,
+		},
+		{
+			name: "error - auth data erase fails, rolled back",
+			setup: func(users *mockUserRepository, notifs *mockNotificationRepository, auth *mockErasureAuthRepository) {
+				users.On("Delete", inTx, 7).Return(domain.User{Id: 7}, nil)
+				notifs.On("DeleteByUserID", inTx, 7).Return(nil)
+				notifs.On("DeleteGroupsByUserID", inTx, 7).Return(nil)
+				auth.On("DeleteByUserID", inTx, 7).Return(errDB)
+			},
+			wantErr:      true,
+			wantRollback: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &mockUserRepository{}
+			notifs := &mockNotificationRepository{}
+			auth := &mockErasureAuthRepository{}
+			sessions := &mockResetSessionStore{}
+			tt.setup(users, notifs, auth)
+			if !tt.wantErr {
+				sessions.On("DeleteAllUserSessions", mock.Anything, 7).Return(nil)
+			}
+
+			txm := &fakeTxManager{}
+			svc := service.NewUserService(users, &config.Config{}, zap.NewNop())
+			svc.SetErasure(txm, notifs, auth, sessions)
+
+			user, err := svc.Delete(context.Background(), 7)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 7, user.Id)
+			}
+			assert.Equal(t, 1, txm.calls)
+			assert.Equal(t, tt.wantRollback, txm.rolledBack)
+			users.AssertExpectations(t)
+			notifs.AssertExpectations(t)
+			auth.AssertExpectations(t)
+			sessions.AssertExpectations(t)
+			if tt.wantErr {
+				sessions.AssertNotCalled(t, "DeleteAllUserSessions", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
 func TestUserService_Organizations(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,199 @@
+// Package service provides implementation for service
+//
+// File: verification_service_test.go
+// Description: Unit tests for email verification codes (expiry, reuse, valid)
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"templatev25/internal/domain"
+	"templatev25/internal/repository"
+	"templatev25/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// mockEmailVerificationRepository implements repository.EmailVerificationRepository
+type mockEmailVerificationRepository struct {
+	mock.Mock
+}
+
+func (m *mockEmailVerificationRepository) Create(ctx context.Context, v *domain.EmailVerification) error {
+	args := m.Called(ctx, v)
+	return args.Error(0)
+}
+
+func (m *mockEmailVerificationRepository) LatestByCode(ctx context.Context, userID int, codeHash string) (*domain.EmailVerification, error) {
+	args := m.Called(ctx, userID, codeHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmailVerification), args.Error(1)
+}
+
+func (m *mockEmailVerificationRepository) MarkUsed(ctx context.Context, id, userID int) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+// mockVerificationUserRepository covers GetByID only
+type mockVerificationUserRepository struct {
+	repository.UserRepository
+	mock.Mock
+}
+
+func (m *mockVerificationUserRepository) GetByID(ctx context.Context, id int) (domain.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+// emailCodeHash нь service-ийн хадгалах hash-тай ижил (SHA-256("userID:code"))
+func emailCodeHash(userID, code string) string {
+	sum := sha256.Sum256([]byte(userID + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerificationService_SendEmailCode(t *testing.T) {
+	t.Run("success - stores hashed code with 15 minute expiry and emails it", func(t *testing.T) {
+		repo := new(mockEmailVerificationRepository)
+		users := new(mockVerificationUserRepository)
+		mailer := new(mockMailer)
+
+		users.On("GetByID", mock.Anything, 7).Return(domain.User{Id: 7, Email: "a@example.com"}, nil)
+
+		var stored *domain.EmailVerification
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.EmailVerification")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.EmailVerification) }).
+			Return(nil)
+
+		var body string
+		mailer.On("Send", "a@example.com", "Email verification code", mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { body = args.String(2) }).
+			Return(nil)
+
+		svc := service.NewVerificationService(repo, users, zap.NewNop())
+		svc.SetMailer(mailer)
+
+		before := time.Now()
+		require.NoError(t, svc.SendEmailCode(context.Background(), 7))
+
+		code := regexp.MustCompile(`\b\d{6}\b`).FindString(body)
+		require.NotEmpty(t, code, "email body should contain a 6-digit code")
+		require.NotNil(t, stored)
+		assert.Equal(t, 7, stored.UserID)
+		assert.Equal(t, emailCodeHash("7", code), stored.Code, "only the hash is stored")
+		assert.WithinDuration(t, before.Add(15*time.Minute), stored.ExpiresAt, 5*time.Second)
+		assert.Nil(t, stored.UsedAt)
+		mailer.AssertExpectations(t)
+	})
+
+	t.Run("error - user without email", func(t *testing.T) {
+		repo := new(mockEmailVerificationRepository)
+		users := new(mockVerificationUserRepository)
+		users.On("GetByID", mock.Anything, 8).Return(domain.User{Id: 8}, nil)
+
+		svc := service.NewVerificationService(repo, users, zap.NewNop())
+		err := svc.SendEmailCode(context.Background(), 8)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - mailer failure", func(t *testing.T) {
+		repo := new(mockEmailVerificationRepository)
+		users := new(mockVerificationUserRepository)
+		mailer := new(mockMailer)
+		users.On("GetByID", mock.Anything, 7).Return(domain.User{Id: 7, Email: "a@example.com"}, nil)
+		repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mailer.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+
+		svc := service.NewVerificationService(repo, users, zap.NewNop())
+		svc.SetMailer(mailer)
+
+		assert.Error(t, svc.SendEmailCode(context.Background(), 7))
+	})
+}
+
+func TestVerificationService_ConfirmEmail(t *testing.T) {
+	hash := emailCodeHash("7", "123456")
+	usedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		mockSetup func(*mockEmailVerificationRepository)
+		wantErr   error
+	}{
+		{
+			name: "valid code - marked used",
+			mockSetup: func(m *mockEmailVerificationRepository) {
+				m.On("LatestByCode", mock.Anything, 7, hash).Return(&domain.EmailVerification{
+					ID: 3, UserID: 7, Code: hash, ExpiresAt: time.Now().Add(10 * time.Minute),
+				}, nil)
+				m.On("MarkUsed", mock.Anything, 3, 7).Return(nil)
+			},
+		},
+		{
+			name: "expired code - after 15 minutes",
+			mockSetup: func(m *mockEmailVerificationRepository) {
+				m.On("LatestByCode", mock.Anything, 7, hash).Return(&domain.EmailVerification{
+					ID: 3, UserID: 7, Code: hash, ExpiresAt: time.Now().Add(-time.Second),
+				}, nil)
+			},
+			wantErr: domain.ErrExpired,
+		},
+		{
+			name: "already used code",
+			mockSetup: func(m *mockEmailVerificationRepository) {
+				m.On("LatestByCode", mock.Anything, 7, hash).Return(&domain.EmailVerification{
+					ID: 3, UserID: 7, Code: hash, ExpiresAt: time.Now().Add(10 * time.Minute), UsedAt: &usedAt,
+				}, nil)
+			},
+			wantErr: service.ErrEmailCodeUsed,
+		},
+		{
+			name: "concurrent confirm - conflict on mark used",
+			mockSetup: func(m *mockEmailVerificationRepository) {
+				m.On("LatestByCode", mock.Anything, 7, hash).Return(&domain.EmailVerification{
+					ID: 3, UserID: 7, Code: hash, ExpiresAt: time.Now().Add(10 * time.Minute),
+				}, nil)
+				m.On("MarkUsed", mock.Anything, 3, 7).Return(domain.NewConflict("verification code already used", nil))
+			},
+			wantErr: service.ErrEmailCodeUsed,
+		},
+		{
+			name: "unknown code",
+			mockSetup: func(m *mockEmailVerificationRepository) {
+				m.On("LatestByCode", mock.Anything, 7, hash).Return(nil, domain.NewNotFound("verification code not found", nil))
+			},
+			wantErr: service.ErrEmailCodeInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockEmailVerificationRepository)
+			tt.mockSetup(repo)
+
+			svc := service.NewVerificationService(repo, new(mockVerificationUserRepository), zap.NewNop())
+			err := svc.ConfirmEmail(context.Background(), 7, "123456")
+
+			if tt.wantErr != nil {
+				// MarkUsed-ийг зөвхөн mockSetup-д тохируулсан үед дуудна (бусад үед mock panic хийнэ)
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
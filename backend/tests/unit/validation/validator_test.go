@@ -0,0 +1,205 @@
+// Package validation provides implementation for validation
+//
+// File: validator_test.go
+// Description: Tests for field-level validation errors and Fiber bind helpers
+package validation_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"templatev25/internal/http/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sampleDto struct {
+	Name   string   `json:"name"   validate:"required,min=3,max=10"`
+	Email  string   `json:"email"  validate:"omitempty,email"`
+	Status string   `json:"status" validate:"omitempty,oneof=active locked"`
+	Age    int      `json:"age"    validate:"omitempty,max=150"`
+	Tags   []string `json:"tags"   validate:"omitempty,min=1,max=2"`
+}
+
+type sampleQuery struct {
+	OrgID int `query:"org_id" validate:"required"`
+}
+
+// ============================================================
+// TEST FORMAT
+// ============================================================
+
+func TestStruct_FieldErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		dto  sampleDto
+		want validation.FieldError
+	}{
+		{
+			name: "required",
+			dto:  sampleDto{},
+			want: validation.FieldError{Field: "name", Tag: "required", Message: "is required"},
+		},
+		{
+			name: "email",
+			dto:  sampleDto{Name: "bold", Email: "not-an-email"},
+			want: validation.FieldError{Field: "email", Tag: "email", Message: "invalid email format"},
+		},
+		{
+			name: "min string",
+			dto:  sampleDto{Name: "ab"},
+			want: validation.FieldError{Field: "name", Tag: "min", Message: "must be at least 3 characters"},
+		},
+		{
+			name: "max string",
+			dto:  sampleDto{Name: "abcdefghijk"},
+			want: validation.FieldError{Field: "name", Tag: "max", Message: "must be at most 10 characters"},
+		},
+		{
+			name: "max number",
+			dto:  sampleDto{Name: "bold", Age: 200},
+			want: validation.FieldError{Field: "age", Tag: "max", Message: "must be at most 150"},
+		},
+		{
+			name: "max slice",
+			dto:  sampleDto{Name: "bold", Tags: []string{"a", "b", "c"}},
+			want: validation.FieldError{Field: "tags", Tag: "max", Message: "must be at most 2 items"},
+		},
+		{
+			name: "oneof",
+			dto:  sampleDto{Name: "bold", Status: "deleted"},
+			want: validation.FieldError{Field: "status", Tag: "oneof", Message: "must be one of: active, locked"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.Struct(tt.dto)
+			require.Error(t, err)
+
+			var ve *validation.ValidationError
+			require.True(t, errors.As(err, &ve))
+			assert.Equal(t, []validation.FieldError{tt.want}, ve.Errors)
+			assert.Equal(t, []validation.FieldError{tt.want}, validation.Format(err))
+		})
+	}
+}
+
+func TestStruct_Valid(t *testing.T) {
+	err := validation.Struct(sampleDto{Name: "bold", Email: "bold@gerege.mn", Status: "active", Age: 30, Tags: []string{"a"}})
+	assert.NoError(t, err)
+}
+
+func TestStruct_MultipleErrors(t *testing.T) {
+	err := validation.Struct(sampleDto{Email: "x", Status: "y"})
+
+	fields := validation.Format(err)
+	require.Len(t, fields, 3)
+	assert.Equal(t, "name", fields[0].Field)
+	assert.Equal(t, "email", fields[1].Field)
+	assert.Equal(t, "status", fields[2].Field)
+	assert.Contains(t, err.Error(), "email: invalid email format")
+}
+
+func TestStruct_QueryTagName(t *testing.T) {
+	fields := validation.Format(validation.Struct(sampleQuery{}))
+
+	require.Len(t, fields, 1)
+	assert.Equal(t, "org_id", fields[0].Field)
+}
+
+func TestFormat_NonValidationError(t *testing.T) {
+	assert.Nil(t, validation.Format(nil))
+	assert.Nil(t, validation.Format(errors.New("boom")))
+}
+
+// ============================================================
+// TEST FIBER HELPERS
+// ============================================================
+
+func newBindApp() *fiber.App {
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		req, ok := validation.BodyBindAndValidate[sampleDto](c)
+		if !ok {
+			return nil
+		}
+		return c.JSON(req)
+	})
+	app.Get("/", func(c *fiber.Ctx) error {
+		req, ok := validation.QueryBindAndValidate[sampleQuery](c)
+		if !ok {
+			return nil
+		}
+		return c.JSON(req)
+	})
+	return app
+}
+
+func TestBodyBindAndValidate(t *testing.T) {
+	app := newBindApp()
+
+	t.Run("validation error", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"bold","email":"bad"}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := app.Test(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+		var body validation.Response
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		assert.Equal(t, validation.CodeValidationError, body.Code)
+		assert.Equal(t, []validation.FieldError{
+			{Field: "email", Tag: "email", Message: "invalid email format"},
+		}, body.Errors)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"bold"}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := app.Test(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := app.Test(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		assert.NotEqual(t, validation.CodeValidationError, body["code"])
+	})
+}
+
+func TestQueryBindAndValidate(t *testing.T) {
+	app := newBindApp()
+
+	res, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+
+	var body validation.Response
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Len(t, body.Errors, 1)
+	assert.Equal(t, "org_id", body.Errors[0].Field)
+	assert.Equal(t, "required", body.Errors[0].Tag)
+
+	res, err = app.Test(httptest.NewRequest("GET", "/?org_id=5", nil))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}